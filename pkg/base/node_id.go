@@ -40,6 +40,11 @@ func (n *NodeIDContainer) String() string {
 	return strconv.Itoa(int(val))
 }
 
+// SafeValue implements the log.SafeValue interface.
+func (n *NodeIDContainer) SafeValue() {}
+
+var _ log.SafeValue = &NodeIDContainer{}
+
 // Get returns the current node ID; 0 if it is unset.
 func (n *NodeIDContainer) Get() roachpb.NodeID {
 	return roachpb.NodeID(atomic.LoadInt32(&n.nodeID))
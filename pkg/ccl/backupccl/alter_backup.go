@@ -0,0 +1,129 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// alterBackupPlanHook implements PlanHookFn for ALTER BACKUP, which rotates
+// the KMS master keys of an encrypted backup: the data key of the backup is
+// decrypted with one of the old master keys and encrypted with each of the new
+// ones. The backup's files remain encrypted with the same data key, so they
+// don't need to be rewritten.
+func alterBackupPlanHook(
+	_ context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, sqlbase.ResultColumns, []sql.PlanNode, bool, error) {
+	alter, ok := stmt.(*tree.AlterBackup)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	const op = "ALTER BACKUP"
+	backupFn, err := p.TypeAsString(alter.Backup, op)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	var collectionFn func() (string, error)
+	if alter.InCollection != nil {
+		if collectionFn, err = p.TypeAsString(alter.InCollection, op); err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+	newKMSFn, err := p.TypeAsString(alter.NewKMSURI, op)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	oldKMSFn, err := p.TypeAsString(alter.OldKMSURI, op)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, _ chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer tracing.FinishSpan(span)
+
+		if err := utilccl.CheckEnterpriseEnabled(
+			p.ExecCfg().Settings, p.ExecCfg().ClusterID(), p.ExecCfg().Organization(), op,
+		); err != nil {
+			return err
+		}
+		if err := p.RequireAdminRole(ctx, op); err != nil {
+			return err
+		}
+
+		uri, err := backupFn()
+		if err != nil {
+			return err
+		}
+		makeCloudStorage := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI
+		if collectionFn != nil {
+			collection, err := collectionFn()
+			if err != nil {
+				return err
+			}
+			subdir, err := resolveCollectionSubdir(ctx, makeCloudStorage, collection, uri, hlc.Timestamp{})
+			if err != nil {
+				return err
+			}
+			paths, err := appendPaths([]string{collection}, subdir)
+			if err != nil {
+				return err
+			}
+			uri = paths[0]
+		}
+		newKMS, err := newKMSFn()
+		if err != nil {
+			return err
+		}
+		oldKMS, err := oldKMSFn()
+		if err != nil {
+			return err
+		}
+		newKMSURIs, oldKMSURIs := splitKMSURIs(newKMS), splitKMSURIs(oldKMS)
+		if len(newKMSURIs) == 0 || len(oldKMSURIs) == 0 {
+			return errors.New("ALTER BACKUP requires at least one new and one old KMS URI")
+		}
+
+		store, err := makeCloudStorage(ctx, uri)
+		if err != nil {
+			return errors.Wrapf(err, "make storage")
+		}
+		defer store.Close()
+		info, err := readEncryptionOptions(ctx, store)
+		if err != nil {
+			return err
+		}
+		if len(info.EncryptedDataKeyByKMSMasterKeyID) == 0 {
+			return errors.New("backup is not encrypted with KMS")
+		}
+		env := KMSEnv{Settings: p.ExecCfg().Settings}
+		dataKey, err := unwrapDataKey(ctx, info, oldKMSURIs, env)
+		if err != nil {
+			return err
+		}
+		if err := wrapDataKey(ctx, info, dataKey, newKMSURIs, env); err != nil {
+			return err
+		}
+		return writeEncryptionOptions(ctx, info, store)
+	}
+	return fn, nil, nil, false, nil
+}
+
+func init() {
+	sql.AddPlanHook(alterBackupPlanHook)
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// A collection is a location that holds a series of backups, as written by
+// BACKUP INTO. Each full backup is stored in a subdirectory of the collection
+// named after the time of the backup, and the incremental backups taken on top
+// of it are appended to that subdirectory. The root of the collection holds an
+// index file naming the most recent full backup.
+const (
+	// latestFileName is the name of the file in the root of a collection that
+	// holds the subdirectory of the most recent full backup.
+	latestFileName = "LATEST"
+	// latestSubdir denotes the most recent full backup of a collection.
+	latestSubdir = "LATEST"
+	// collectionSubdirFormat is the layout of the subdirectories of a
+	// collection in which full backups are stored.
+	collectionSubdirFormat = "/2006/01/02-150405.00"
+)
+
+// appendPaths appends the path tail to each of the URIs, which may be the
+// URIs of a partitioned backup.
+func appendPaths(uris []string, tail string) ([]string, error) {
+	res := make([]string, len(uris))
+	for i, uri := range uris {
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return nil, err
+		}
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/" + strings.TrimPrefix(tail, "/")
+		res[i] = parsed.String()
+	}
+	return res, nil
+}
+
+// newCollectionSubdir returns the subdirectory of a collection in which to
+// store a full backup taken at endTime.
+func newCollectionSubdir(endTime hlc.Timestamp) string {
+	return endTime.GoTime().Format(collectionSubdirFormat)
+}
+
+// writeLatestFile records subdir as the most recent full backup of the
+// collection.
+func writeLatestFile(ctx context.Context, collection cloud.ExternalStorage, subdir string) error {
+	return collection.WriteFile(ctx, latestFileName, bytes.NewReader([]byte(subdir)))
+}
+
+// readLatestFile returns the subdirectory of the most recent full backup of
+// the collection.
+func readLatestFile(ctx context.Context, collection cloud.ExternalStorage) (string, error) {
+	r, err := collection.ReadFile(ctx, latestFileName)
+	if err != nil {
+		// The index may be missing if the collection was written to by an older
+		// version, or if writing it failed; fall back to listing the collection.
+		backups, listErr := listFullBackupsInCollection(ctx, collection)
+		if listErr != nil || len(backups) == 0 {
+			return "", errors.Wrap(err, "no full backup found in collection")
+		}
+		return backups[len(backups)-1], nil
+	}
+	defer r.Close()
+	latest, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", errors.Wrap(err, "reading latest backup of collection")
+	}
+	return string(latest), nil
+}
+
+// listFullBackupsInCollection returns the subdirectories of the full backups
+// in the collection, oldest first.
+func listFullBackupsInCollection(
+	ctx context.Context, collection cloud.ExternalStorage,
+) ([]string, error) {
+	manifests, err := collection.ListFiles(ctx, "*/*/*/"+BackupManifestName)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing backups in collection")
+	}
+	subdirs := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		subdir := "/" + strings.TrimSuffix(strings.TrimPrefix(m, "/"), "/"+BackupManifestName)
+		// Skip anything that wasn't written by BACKUP INTO.
+		if _, err := time.Parse(collectionSubdirFormat, subdir); err != nil {
+			continue
+		}
+		subdirs = append(subdirs, subdir)
+	}
+	// The subdirectories are named such that sorting them sorts the backups
+	// chronologically.
+	sort.Strings(subdirs)
+	return subdirs, nil
+}
+
+// resolveCollectionSubdir resolves the subdirectory of a full backup in the
+// collection, as specified by the user. LATEST resolves to the most recent full
+// backup, or if asOf is set, to the most recent full backup taken at or
+// before asOf.
+func resolveCollectionSubdir(
+	ctx context.Context,
+	makeCloudStorage cloud.ExternalStorageFromURIFactory,
+	collectionURI string,
+	subdir string,
+	asOf hlc.Timestamp,
+) (string, error) {
+	if !strings.EqualFold(subdir, latestSubdir) {
+		return "/" + strings.TrimPrefix(subdir, "/"), nil
+	}
+	collection, err := makeCloudStorage(ctx, collectionURI)
+	if err != nil {
+		return "", err
+	}
+	defer collection.Close()
+
+	if asOf.IsEmpty() {
+		return readLatestFile(ctx, collection)
+	}
+	backups, err := listFullBackupsInCollection(ctx, collection)
+	if err != nil {
+		return "", err
+	}
+	if len(backups) == 0 {
+		return "", errors.Newf("no full backup found in collection")
+	}
+	// If all the full backups were taken after the requested time, the oldest
+	// one may still cover it if it has revision history.
+	res := backups[0]
+	for _, b := range backups[1:] {
+		t, err := time.Parse(collectionSubdirFormat, b)
+		if err != nil {
+			return "", err
+		}
+		if t.After(asOf.GoTime()) {
+			break
+		}
+		res = b
+	}
+	return res, nil
+}
@@ -470,6 +470,12 @@ func (b *backupResumer) Resume(
 	}
 	b.res = res
 
+	if details.CollectionURI != "" {
+		if err := b.writeLatestFile(ctx, p, details); err != nil {
+			return err
+		}
+	}
+
 	err = b.clearStats(ctx, p.ExecCfg().DB)
 	if err != nil {
 		log.Warningf(ctx, "unable to clear stats from job payload: %+v", err)
@@ -477,6 +483,24 @@ func (b *backupResumer) Resume(
 	return nil
 }
 
+// writeLatestFile records the completed backup as the most recent full backup
+// of the collection it was taken into.
+func (b *backupResumer) writeLatestFile(
+	ctx context.Context, p sql.PlanHookState, details jobspb.BackupDetails,
+) error {
+	collection, err := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, details.CollectionURI)
+	if err != nil {
+		return errors.Wrapf(err, "make storage")
+	}
+	defer collection.Close()
+	// Don't overwrite a more recent full backup that completed first.
+	if latest, err := readLatestFile(ctx, collection); err == nil && latest > details.CollectionSubdir {
+		return nil
+	}
+	return errors.Wrap(
+		writeLatestFile(ctx, collection, details.CollectionSubdir), "writing latest backup of collection")
+}
+
 func (b *backupResumer) clearStats(ctx context.Context, DB *client.DB) error {
 	details := b.job.Details().(jobspb.BackupDetails)
 	var backupManifest BackupManifest
@@ -554,4 +578,5 @@ func init() {
 			}
 		},
 	)
+	jobs.RegisterRetryPolicy(jobspb.TypeBackup, jobs.DefaultRetryPolicy)
 }
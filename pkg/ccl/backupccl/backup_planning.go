@@ -14,7 +14,6 @@ import (
 	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
-	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
 	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -40,6 +39,9 @@ import (
 const (
 	backupOptRevisionHistory = "revision_history"
 	backupOptEncPassphrase   = "encryption_passphrase"
+	backupOptEncKMS          = "kms"
+	backupOptDetached        = "detached"
+	backupOptCheckFiles      = "check_files"
 	localityURLParam         = "COCKROACH_LOCALITY"
 	defaultLocalityValue     = "default"
 )
@@ -69,6 +71,8 @@ var useTBI = settings.RegisterBoolSetting(
 var backupOptionExpectValues = map[string]sql.KVStringOptValidate{
 	backupOptRevisionHistory: sql.KVStringOptRequireNoValue,
 	backupOptEncPassphrase:   sql.KVStringOptRequireValue,
+	backupOptEncKMS:          sql.KVStringOptRequireValue,
+	backupOptDetached:        sql.KVStringOptRequireNoValue,
 }
 
 type tableAndIndex struct {
@@ -133,8 +137,11 @@ func optsToKVOptions(opts map[string]string) tree.KVOptions {
 	for _, k := range sortedOpts {
 		opt := tree.KVOption{Key: tree.Name(k)}
 		if v := opts[k]; v != "" {
-			if k == backupOptEncPassphrase {
+			switch k {
+			case backupOptEncPassphrase:
 				v = "redacted"
+			case backupOptEncKMS:
+				v = redactKMSURIs(v)
 			}
 			opt.Value = tree.NewDString(v)
 		}
@@ -263,11 +270,27 @@ func backupPlanHook(
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
+	var subdirFn func() (string, error)
+	if backupStmt.Subdir != nil {
+		subdirFn, err = p.TypeAsString(backupStmt.Subdir, "BACKUP")
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
 	optsFn, err := p.TypeAsStringOpts(backupStmt.Options, backupOptionExpectValues)
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
 
+	// A detached backup only creates the backup job, which is started once the
+	// transaction that created it commits.
+	detached := false
+	for _, opt := range backupStmt.Options {
+		if opt.Key == backupOptDetached {
+			detached = true
+		}
+	}
+
 	header := sqlbase.ResultColumns{
 		{Name: "job_id", Typ: types.Int},
 		{Name: "status", Typ: types.String},
@@ -276,6 +299,9 @@ func backupPlanHook(
 		{Name: "index_entries", Typ: types.Int},
 		{Name: "bytes", Typ: types.Int},
 	}
+	if detached {
+		header = sqlbase.ResultColumns{{Name: "job_id", Typ: types.Int}}
+	}
 
 	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
 		// TODO(dan): Move this span into sql.
@@ -292,8 +318,8 @@ func backupPlanHook(
 			return err
 		}
 
-		if !p.ExtendedEvalContext().TxnImplicit {
-			return errors.Errorf("BACKUP cannot be used inside a transaction")
+		if !detached && !p.ExtendedEvalContext().TxnImplicit {
+			return errors.Errorf("BACKUP cannot be used inside a transaction without %s", backupOptDetached)
 		}
 
 		to, err := toFn()
@@ -365,9 +391,38 @@ func backupPlanHook(
 
 		makeCloudStorage := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI
 
-		var encryptionPassphrase []byte
-		if passphrase, ok := opts[backupOptEncPassphrase]; ok {
-			encryptionPassphrase = []byte(passphrase)
+		encryptionParams, err := makeBackupEncryptionParams(opts, p.ExecCfg().Settings)
+		if err != nil {
+			return err
+		}
+
+		// For backups into a collection, find the subdirectory of the collection
+		// that the backup is written to. A new full backup gets a subdirectory
+		// of its own, while an incremental backup is appended to an existing
+		// full backup.
+		var collectionURI, collectionSubdir string
+		if backupStmt.Nested {
+			collectionDefaultURI, _, err := getURIsByLocalityKV(to, "")
+			if err != nil {
+				return err
+			}
+			if subdirFn == nil {
+				collectionURI = collectionDefaultURI
+				collectionSubdir = newCollectionSubdir(endTime)
+			} else {
+				subdir, err := subdirFn()
+				if err != nil {
+					return err
+				}
+				if collectionSubdir, err = resolveCollectionSubdir(
+					ctx, makeCloudStorage, collectionDefaultURI, subdir, hlc.Timestamp{},
+				); err != nil {
+					return err
+				}
+			}
+			if to, err = appendPaths(to, collectionSubdir); err != nil {
+				return err
+			}
 		}
 
 		defaultURI, urisByLocalityKV, err := getURIsByLocalityKV(to, "")
@@ -388,19 +443,15 @@ func backupPlanHook(
 		var encryption *roachpb.FileEncryptionOptions
 		var prevBackups []BackupManifest
 		if len(incrementalFrom) > 0 {
-			if encryptionPassphrase != nil {
+			if encryptionParams.isSet() {
 				exportStore, err := makeCloudStorage(ctx, incrementalFrom[0])
 				if err != nil {
 					return err
 				}
 				defer exportStore.Close()
-				opts, err := readEncryptionOptions(ctx, exportStore)
-				if err != nil {
+				if encryption, err = readBackupEncryption(ctx, exportStore, encryptionParams); err != nil {
 					return err
 				}
-				encryption = &roachpb.FileEncryptionOptions{
-					Key: storageccl.GenerateKey(encryptionPassphrase, opts.Salt),
-				}
 			}
 			prevBackups = make([]BackupManifest, len(incrementalFrom))
 			for i, uri := range incrementalFrom {
@@ -423,15 +474,12 @@ func backupPlanHook(
 			if err != nil {
 				return err
 			}
+			if backupStmt.Subdir != nil && !exists {
+				return errors.Errorf("no full backup found in %s of collection", collectionSubdir)
+			}
 			if exists {
-				if encryptionPassphrase != nil {
-					encOpts, err := readEncryptionOptions(ctx, defaultStore)
-					if err != nil {
-						return err
-					}
-					encryption = &roachpb.FileEncryptionOptions{
-						Key: storageccl.GenerateKey(encryptionPassphrase, encOpts.Salt),
-					}
+				if encryption, err = readBackupEncryption(ctx, defaultStore, encryptionParams); err != nil {
+					return err
 				}
 
 				prev, err := findPriorBackups(ctx, defaultStore)
@@ -621,9 +669,10 @@ func backupPlanHook(
 		}
 
 		// If we didn't load any prior backups from which get encryption info, we
-		// need to pick a new salt and record it.
-		if encryptionPassphrase != nil && encryption == nil {
-			salt, err := storageccl.GenerateSalt()
+		// need to pick a new salt or data key and record it.
+		if encryptionParams.isSet() && encryption == nil {
+			var encInfo *EncryptionInfo
+			encInfo, encryption, err = encryptionParams.newEncryptionInfo(ctx)
 			if err != nil {
 				return err
 			}
@@ -632,10 +681,9 @@ func backupPlanHook(
 				return err
 			}
 			defer exportStore.Close()
-			if err := writeEncryptionOptions(ctx, &EncryptionInfo{Salt: salt}, exportStore); err != nil {
+			if err := writeEncryptionOptions(ctx, encInfo, exportStore); err != nil {
 				return err
 			}
-			encryption = &roachpb.FileEncryptionOptions{Key: storageccl.GenerateKey(encryptionPassphrase, salt)}
 		}
 
 		// TODO (lucy): For partitioned backups, also add verification for other
@@ -646,7 +694,7 @@ func backupPlanHook(
 			return err
 		}
 
-		_, errCh, err := p.ExecCfg().JobRegistry.CreateAndStartJob(ctx, resultsCh, jobs.Record{
+		jobRecord := jobs.Record{
 			Description: description,
 			Username:    p.User(),
 			DescriptorIDs: func() (sqlDescIDs []sqlbase.ID) {
@@ -662,9 +710,20 @@ func backupPlanHook(
 				URIsByLocalityKV: urisByLocalityKV,
 				BackupManifest:   descBytes,
 				Encryption:       encryption,
+				CollectionURI:    collectionURI,
+				CollectionSubdir: collectionSubdir,
 			},
 			Progress: jobspb.BackupProgress{},
-		})
+		}
+		if detached {
+			job, err := p.ExecCfg().JobRegistry.CreateJobWithTxn(ctx, jobRecord, p.ExtendedEvalContext().Txn)
+			if err != nil {
+				return err
+			}
+			resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(*job.ID()))}
+			return nil
+		}
+		_, errCh, err := p.ExecCfg().JobRegistry.CreateAndStartJob(ctx, resultsCh, jobRecord)
 		if err != nil {
 			return err
 		}
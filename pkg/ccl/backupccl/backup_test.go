@@ -432,6 +432,57 @@ func TestBackupRestoreAppend(t *testing.T) {
 	// TODO(dt): test restoring to other backups via AOST.
 }
 
+func TestBackupRestoreCollection(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const numAccounts = 10
+	_, _, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+
+	const collection = localFoo + "/collection"
+	sqlDB.ExpectErr(t, "no full backup found",
+		`BACKUP DATABASE data INTO LATEST IN $1`, collection)
+
+	// Take a full backup and append an incremental backup to it, then take a
+	// second full backup.
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1 WITH revision_history`, collection)
+	var ts1 string
+	sqlDB.QueryRow(t,
+		`INSERT INTO data.bank VALUES (1000, 1, 'a') RETURNING cluster_logical_timestamp()`,
+	).Scan(&ts1)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO LATEST IN $1 WITH revision_history`, collection)
+	sqlDB.Exec(t, `INSERT INTO data.bank VALUES (1001, 1, 'b')`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1 WITH revision_history`, collection)
+
+	backups := sqlDB.QueryStr(t, `SHOW BACKUPS IN $1`, collection)
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 full backups in collection, found %v", backups)
+	}
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT count(*) FROM [SHOW BACKUP '%s' IN '%s'] WHERE table_name = 'bank'`,
+			backups[0][0], collection),
+		[][]string{{"1"}})
+
+	// Restore from the most recent full backup.
+	sqlDB.Exec(t, `DROP DATABASE data CASCADE`)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1`, collection)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.bank`,
+		[][]string{{strconv.Itoa(numAccounts + 2)}})
+
+	// Restore as of a time covered by the first full backup and its
+	// incremental backup.
+	sqlDB.Exec(t, `DROP DATABASE data CASCADE`)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 AS OF SYSTEM TIME `+ts1, collection)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.bank`,
+		[][]string{{strconv.Itoa(numAccounts + 1)}})
+
+	// Restore from an explicitly named full backup.
+	sqlDB.Exec(t, `DROP DATABASE data CASCADE`)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM $1 IN $2`, backups[0][0], collection)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.bank`,
+		[][]string{{strconv.Itoa(numAccounts + 1)}})
+}
+
 func TestBackupRestorePartitionedMergeDirectories(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -2721,6 +2772,62 @@ func TestBackupEncrypted(t *testing.T) {
 	sqlDB.CheckQueryResults(t, `SHOW EXPERIMENTAL_FINGERPRINTS FROM TABLE neverappears.neverappears`, before)
 }
 
+func TestBackupEncryptedWithKMS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const numAccounts = 10
+	_, _, sqlDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+
+	// Master keys for the local KMS are stored in files under the external IO
+	// directory.
+	if err := os.MkdirAll(filepath.Join(dir, "keys"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	makeKMS := func(name string) string {
+		t.Helper()
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(
+			filepath.Join(dir, "keys", name), []byte(fmt.Sprintf("%x\n", key)), 0600,
+		); err != nil {
+			t.Fatal(err)
+		}
+		return "local-kms:///keys/" + name
+	}
+	east, west, rotated := makeKMS("east"), makeKMS("west"), makeKMS("rotated")
+
+	// The data key of the backup is encrypted by the master key of each
+	// region, so either of them can be used to append to or restore it.
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1 WITH kms = $2`, localFoo, east+","+west)
+	sqlDB.Exec(t, `INSERT INTO data.bank VALUES (1000, 1, 'a')`)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1 WITH kms = $2`, localFoo, west)
+
+	sqlDB.ExpectErr(t, `file appears encrypted`, `SHOW BACKUP $1`, localFoo)
+	sqlDB.ExpectErr(t, `backup is encrypted with KMS`,
+		`SHOW BACKUP $1 WITH encryption_passphrase = 'abcdefg'`, localFoo)
+	sqlDB.ExpectErr(t, `backup is not encrypted with master key /keys/rotated`,
+		`SHOW BACKUP $1 WITH kms = $2`, localFoo, rotated)
+	sqlDB.ExpectErr(t, `cannot specify both`,
+		`SHOW BACKUP $1 WITH kms = $2, encryption_passphrase = 'abcdefg'`, localFoo, east)
+	sqlDB.Exec(t, `SHOW BACKUP $1 WITH kms = $2, check_files`, localFoo, east)
+
+	// Rotate the backup to a new master key, and retire an old one.
+	sqlDB.ExpectErr(t, `failed to decrypt the data key`,
+		`ALTER BACKUP $1 ADD NEW_KMS = $2 WITH OLD_KMS = $2`, localFoo, rotated)
+	sqlDB.Exec(t, `ALTER BACKUP $1 ADD NEW_KMS = $2 WITH OLD_KMS = $3`, localFoo, rotated, east)
+	if err := os.Remove(filepath.Join(dir, "keys", "east")); err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.Exec(t, `DROP DATABASE data CASCADE`)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM $1 WITH kms = $2`, localFoo, east+","+rotated)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.bank`,
+		[][]string{{strconv.Itoa(numAccounts + 1)}})
+}
+
 func TestRestoredPrivileges(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -2795,6 +2902,162 @@ func TestRestoreInto(t *testing.T) {
 	sqlDB.CheckQueryResults(t, `SELECT * FROM "data 2".bank`, expected)
 }
 
+func TestRestoreWithNewNames(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const numAccounts = 1
+	_, _, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `CREATE SEQUENCE data.seq`)
+	sqlDB.Exec(t, `CREATE TABLE data.parent (id INT PRIMARY KEY DEFAULT nextval('data.seq'), v STRING)`)
+	sqlDB.Exec(t, `CREATE TABLE data.child (id INT PRIMARY KEY, parent_id INT REFERENCES data.parent (id))`)
+	sqlDB.Exec(t, `CREATE VIEW data.v AS SELECT p.v FROM data.parent AS p JOIN data.child AS c ON p.id = c.parent_id`)
+	sqlDB.Exec(t, `INSERT INTO data.parent (v) VALUES ('a'), ('b')`)
+	sqlDB.Exec(t, `INSERT INTO data.child VALUES (1, 1), (2, 2)`)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+
+	t.Run("database", func(t *testing.T) {
+		sqlDB.ExpectErr(t, `"new_db_name" option can only be used when restoring a single database`,
+			`RESTORE TABLE data.bank FROM $1 WITH new_db_name = 'data2'`, localFoo)
+		sqlDB.ExpectErr(t, `database "data" already exists`,
+			`RESTORE DATABASE data FROM $1 WITH new_db_name = 'data'`, localFoo)
+
+		sqlDB.Exec(t, `RESTORE DATABASE data FROM $1 WITH new_db_name = 'data2'`, localFoo)
+		sqlDB.CheckQueryResults(t, `SELECT * FROM data2.bank`, sqlDB.QueryStr(t, `SELECT * FROM data.bank`))
+
+		// The sequence, foreign key and view of the restored database refer to
+		// its own tables rather than to those of the original one.
+		sqlDB.Exec(t, `INSERT INTO data2.parent (v) VALUES ('c')`)
+		sqlDB.Exec(t, `INSERT INTO data2.child VALUES (3, 3)`)
+		sqlDB.CheckQueryResults(t, `SELECT last_value FROM data2.seq`, [][]string{{"3"}})
+		sqlDB.CheckQueryResults(t, `SELECT last_value FROM data.seq`, [][]string{{"2"}})
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data2.v`, [][]string{{"3"}})
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.v`, [][]string{{"2"}})
+		sqlDB.ExpectErr(t, `foreign key`, `INSERT INTO data2.child VALUES (4, 4)`)
+
+		sqlDB.Exec(t, `DROP DATABASE data2 CASCADE`)
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.v`, [][]string{{"2"}})
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		const restoreAll = `RESTORE data.seq, data.parent, data.child, data.v FROM $1 `
+		sqlDB.ExpectErr(t, `table "nope" to rename is not being restored`,
+			restoreAll+`WITH new_table_names = 'nope=x'`, localFoo)
+		sqlDB.ExpectErr(t, `invalid "new_table_names" option`,
+			restoreAll+`WITH new_table_names = 'parent'`, localFoo)
+		sqlDB.ExpectErr(t, `relation ".+" already exists`,
+			restoreAll+`WITH new_table_names = 'parent=parent2'`, localFoo)
+
+		// Restore the tables side-by-side with the originals.
+		sqlDB.Exec(t, restoreAll+`WITH new_table_names = 'seq=seq2, parent=parent2, data.child=child2, v=v2'`,
+			localFoo)
+		sqlDB.CheckQueryResults(t, `SELECT * FROM data.parent2`, sqlDB.QueryStr(t, `SELECT * FROM data.parent`))
+
+		sqlDB.Exec(t, `INSERT INTO data.parent2 (v) VALUES ('c')`)
+		sqlDB.Exec(t, `INSERT INTO data.child2 VALUES (3, 3)`)
+		sqlDB.CheckQueryResults(t, `SELECT last_value FROM data.seq2`, [][]string{{"3"}})
+		sqlDB.CheckQueryResults(t, `SELECT last_value FROM data.seq`, [][]string{{"2"}})
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.v2`, [][]string{{"3"}})
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.v`, [][]string{{"2"}})
+		sqlDB.ExpectErr(t, `foreign key`, `INSERT INTO data.child2 VALUES (4, 4)`)
+	})
+
+	t.Run("same names in other databases", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE DATABASE other`)
+		sqlDB.Exec(t, `CREATE TABLE data.t (v STRING)`)
+		sqlDB.Exec(t, `CREATE TABLE other.t (v STRING)`)
+		sqlDB.Exec(t, `INSERT INTO data.t VALUES ('a')`)
+		sqlDB.Exec(t, `INSERT INTO other.t VALUES ('b')`)
+		sqlDB.Exec(t, `CREATE VIEW data.w AS SELECT v FROM data.t UNION ALL SELECT v FROM other.t`)
+		const backupOther = localFoo + "/other"
+		sqlDB.Exec(t, `BACKUP data.t, data.w, other.t TO $1`, backupOther)
+
+		// Each of the tables named t is renamed on its own in the view query.
+		sqlDB.Exec(t, `RESTORE data.t, data.w, other.t FROM $1 `+
+			`WITH new_table_names = 'data.t=t2, other.t=t3, w=w2'`, backupOther)
+		sqlDB.Exec(t, `INSERT INTO data.t2 VALUES ('c')`)
+		sqlDB.Exec(t, `INSERT INTO other.t3 VALUES ('d'), ('e')`)
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.w2`, [][]string{{"5"}})
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.w`, [][]string{{"2"}})
+	})
+}
+
+func TestRestoreOnline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer jobs.TestingSetProgressThresholds()()
+
+	// While blockImports is set, the responses to imports are blocked until
+	// allowImport is sent to or closed, so that the job can be paused midway.
+	var blockImports int32
+	allowImport := make(chan struct{})
+	params := base.TestClusterArgs{}
+	params.ServerArgs.Knobs.Store = &storage.StoreTestingKnobs{
+		TestingResponseFilter: func(ba roachpb.BatchRequest, br *roachpb.BatchResponse) *roachpb.Error {
+			for _, ru := range br.Responses {
+				if _, ok := ru.GetInner().(*roachpb.ImportResponse); ok && atomic.LoadInt32(&blockImports) == 1 {
+					<-allowImport
+				}
+			}
+			return nil
+		},
+	}
+
+	const numAccounts = 1000
+	ctx, _, sqlDB, _, cleanupFn := backupRestoreTestSetupWithParams(t, singleNode, numAccounts, initNone, params)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `ALTER TABLE data.bank SPLIT AT SELECT generate_series(100, 900, 100)`)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+	sqlDB.ExpectErr(t, `"online" option cannot be used with a full cluster restore`,
+		`RESTORE FROM $1 WITH online`, localFoo)
+
+	sqlDB.Exec(t, `CREATE DATABASE restored`)
+	atomic.StoreInt32(&blockImports, 1)
+	errCh := make(chan error)
+	go func() {
+		_, err := sqlDB.DB.ExecContext(ctx,
+			`RESTORE data.bank FROM $1 WITH into_db = 'restored', online`, localFoo)
+		errCh <- err
+	}()
+	// Once an import went through, the table is public; pause the job before
+	// it ingests the rest of the data.
+	allowImport <- struct{}{}
+	var jobID int64
+	sqlDB.QueryRow(t, `SELECT id FROM system.jobs ORDER BY created DESC LIMIT 1`).Scan(&jobID)
+	sqlDB.Exec(t, fmt.Sprintf(`PAUSE JOB %d`, jobID))
+	atomic.StoreInt32(&blockImports, 0)
+	close(allowImport)
+	if err := <-errCh; !testutils.IsError(err, "job paused") {
+		t.Fatalf("expected 'job paused' error, but got %+v", err)
+	}
+
+	// Reads of the table ingest the data they need from the backup, including
+	// those that don't go through the SQL planner.
+	sqlDB.Exec(t, `CREATE STATISTICS s FROM restored.bank`)
+	sqlDB.CheckQueryResults(t,
+		`SELECT row_count FROM [SHOW STATISTICS FOR TABLE restored.bank] WHERE statistics_name = 's'`,
+		[][]string{{strconv.Itoa(numAccounts)}})
+	sqlDB.CheckQueryResults(t, `SELECT * FROM restored.bank WHERE id BETWEEN 450 AND 550`,
+		sqlDB.QueryStr(t, `SELECT * FROM data.bank WHERE id BETWEEN 450 AND 550`))
+	sqlDB.CheckQueryResults(t, `SELECT count(*), sum(balance) FROM restored.bank`,
+		sqlDB.QueryStr(t, `SELECT count(*), sum(balance) FROM data.bank`))
+	sqlDB.ExpectErr(t, `table "bank" is being restored online`,
+		`INSERT INTO restored.bank VALUES (-1, 0, '')`)
+	sqlDB.ExpectErr(t, `table "bank" is being restored online`,
+		`ALTER TABLE restored.bank ADD COLUMN x INT`)
+
+	// Once the job is done, the table can be modified.
+	sqlDB.Exec(t, fmt.Sprintf(`RESUME JOB %d`, jobID))
+	jobutils.WaitForJob(t, sqlDB, jobID)
+	testutils.SucceedsSoon(t, func() error {
+		_, err := sqlDB.DB.ExecContext(ctx, `INSERT INTO restored.bank VALUES (-1, 0, '')`)
+		return err
+	})
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM restored.bank`,
+		[][]string{{strconv.Itoa(numAccounts + 1)}})
+}
+
 func TestBackupRestorePermissions(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -0,0 +1,164 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/covering"
+	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// maxReportedValidationProblems is the number of problems found while
+// validating a backup that are included in the returned error.
+const maxReportedValidationProblems = 10
+
+// validateBackup checks that the backup at uri, along with the incremental
+// backups appended to it, is restorable without actually restoring it:
+//  - every file listed in the manifests exists, can be decrypted with the
+//    supplied encryption options and matches its recorded checksum,
+//  - each incremental backup starts where the previous backup ends, and
+//  - the backed up spans are covered without gaps from the start of the chain
+//    to its end.
+// The span coverage is only checked if the chain starts with a full backup and
+// has no gaps in time, since the backups an explicitly incremental backup was
+// taken on top of are not known here.
+//
+// All the problems found are collected into the returned error, rather than
+// stopping at the first one.
+func validateBackup(
+	ctx context.Context,
+	mkStore cloud.ExternalStorageFromURIFactory,
+	uri string,
+	encryption *roachpb.FileEncryptionOptions,
+) error {
+	baseStore, err := mkStore(ctx, uri)
+	if err != nil {
+		return errors.Wrapf(err, "make storage")
+	}
+	defer baseStore.Close()
+
+	defaultURIs, manifests, localityInfo, err := resolveBackupManifests(
+		ctx, []cloud.ExternalStorage{baseStore}, mkStore, [][]string{{uri}}, encryption,
+	)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for i := 1; i < len(manifests); i++ {
+		if manifests[i].StartTime != manifests[i-1].EndTime {
+			problems = append(problems, fmt.Sprintf(
+				"backup in %s starts at %s, but the previous backup ends at %s",
+				defaultURIs[i], manifests[i].StartTime, manifests[i-1].EndTime))
+		}
+	}
+
+	if len(problems) == 0 && manifests[0].StartTime.IsEmpty() {
+		last := manifests[len(manifests)-1]
+		onMissing := func(span covering.Range, start, end hlc.Timestamp) error {
+			problems = append(problems, errOnMissingRange(span, start, end).Error())
+			return nil
+		}
+		if _, _, err := makeImportSpans(
+			last.Spans, manifests, localityInfo, keys.MinKey, onMissing,
+		); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	// Files are stored in the directory of the backup that lists them, or for
+	// locality-aware backups, in the directory of their locality.
+	stores := map[string]cloud.ExternalStorage{uri: baseStore}
+	defer func() {
+		for storeURI, store := range stores {
+			if storeURI != uri {
+				store.Close()
+			}
+		}
+	}()
+	for i, manifest := range manifests {
+		for _, file := range manifest.Files {
+			if file.Path == "" {
+				continue
+			}
+			storeURI := defaultURIs[i]
+			if u, ok := localityInfo[i].URIsByOriginalLocalityKV[file.LocalityKV]; ok {
+				storeURI = u
+			}
+			store, ok := stores[storeURI]
+			if !ok {
+				if store, err = mkStore(ctx, storeURI); err != nil {
+					return errors.Wrapf(err, "make storage")
+				}
+				stores[storeURI] = store
+			}
+			if err := validateBackupFile(ctx, store, file, encryption); err != nil {
+				problems = append(problems, fmt.Sprintf("%s in %s: %v", file.Path, storeURI, err))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	log.Warningf(ctx, "validation of backup in %s found %d problem(s)", uri, len(problems))
+	reported := problems
+	if len(reported) > maxReportedValidationProblems {
+		reported = reported[:maxReportedValidationProblems]
+	}
+	return errors.Errorf("backup in %s failed validation with %d problem(s):\n%s",
+		uri, len(problems), strings.Join(reported, "\n"))
+}
+
+// validateBackupFile reads the file of a backup, and checks that it can be
+// decrypted and that it matches its recorded checksum.
+func validateBackupFile(
+	ctx context.Context,
+	store cloud.ExternalStorage,
+	file BackupManifest_File,
+	encryption *roachpb.FileEncryptionOptions,
+) error {
+	r, err := store.ReadFile(ctx, file.Path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if encryption != nil {
+		if data, err = storageccl.DecryptFile(data, encryption.Key); err != nil {
+			return errors.Wrap(err, "decrypting file")
+		}
+	}
+	// Backups taken by old versions don't record the checksum of their files.
+	if len(file.Sha512) == 0 {
+		return nil
+	}
+	checksum, err := storageccl.SHA512ChecksumData(data)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(checksum, file.Sha512) {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/cron"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// scheduledBackupExecutorName is the executor type of backup schedules in
+// system.scheduled_jobs.
+const scheduledBackupExecutorName = "scheduled-backup-executor"
+
+// makeScheduledBackupStatement returns the detached BACKUP statement run by a
+// backup schedule. The backups are taken into the collection given by the $1
+// placeholder: a full backup into a new subdirectory of the collection if
+// subdir is nil, and an incremental backup appended to the full backup in
+// subdir otherwise.
+func makeScheduledBackupStatement(
+	schedule *tree.ScheduledBackup, opts map[string]string, subdir tree.Expr,
+) *tree.Backup {
+	backup := &tree.Backup{
+		DescriptorCoverage: tree.AllDescriptors,
+		To:                 tree.PartitionedBackup{&tree.Placeholder{Idx: 0}},
+		Nested:             true,
+		Subdir:             subdir,
+	}
+	if schedule.Targets != nil {
+		backup.Targets = *schedule.Targets
+		backup.DescriptorCoverage = tree.RequestedDescriptors
+	}
+
+	opts[backupOptDetached] = ""
+	keys := make([]string, 0, len(opts))
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		opt := tree.KVOption{Key: tree.Name(k)}
+		if v := opts[k]; v != "" {
+			opt.Value = tree.NewDString(v)
+		}
+		backup.Options = append(backup.Options, opt)
+	}
+	return backup
+}
+
+// createScheduledBackupPlanHook implements PlanHookFn for CREATE SCHEDULE FOR
+// BACKUP.
+func createScheduledBackupPlanHook(
+	_ context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, sqlbase.ResultColumns, []sql.PlanNode, bool, error) {
+	schedule, ok := stmt.(*tree.ScheduledBackup)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	const op = "CREATE SCHEDULE FOR BACKUP"
+	var nameFn func() (string, error)
+	if schedule.ScheduleName != nil {
+		var err error
+		if nameFn, err = p.TypeAsString(schedule.ScheduleName, op); err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+	toFn, err := p.TypeAsString(schedule.To, op)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	recurrenceFn, err := p.TypeAsString(schedule.Recurrence, op)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	var fullRecurrenceFn func() (string, error)
+	if schedule.FullBackup != nil && !schedule.FullBackup.AlwaysFull {
+		if fullRecurrenceFn, err = p.TypeAsString(schedule.FullBackup.Recurrence, op); err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+	optsFn, err := p.TypeAsStringOpts(schedule.BackupOptions, backupOptionExpectValues)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	header := sqlbase.ResultColumns{
+		{Name: "schedule_id", Typ: types.Int},
+		{Name: "label", Typ: types.String},
+		{Name: "next_run", Typ: types.TimestampTZ},
+		{Name: "backup_stmt", Typ: types.String},
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer tracing.FinishSpan(span)
+
+		if err := utilccl.CheckEnterpriseEnabled(
+			p.ExecCfg().Settings, p.ExecCfg().ClusterID(), p.ExecCfg().Organization(), op,
+		); err != nil {
+			return err
+		}
+		if err := p.RequireAdminRole(ctx, op); err != nil {
+			return err
+		}
+		if !cluster.Version.IsActive(ctx, p.ExecCfg().Settings, cluster.VersionScheduledJobs) {
+			return errors.Errorf("%s requires all nodes to be upgraded to %s",
+				op, cluster.VersionByKey(cluster.VersionScheduledJobs))
+		}
+
+		to, err := toFn()
+		if err != nil {
+			return err
+		}
+		recurrence, err := recurrenceFn()
+		if err != nil {
+			return err
+		}
+		opts, err := optsFn()
+		if err != nil {
+			return err
+		}
+		delete(opts, backupOptDetached)
+
+		backup := makeScheduledBackupStatement(schedule, opts, nil /* subdir */)
+		args := ScheduledBackupExecutionArgs{
+			BackupStatement: tree.AsStringWithFlags(backup, tree.FmtParsable),
+			Destination:     to,
+		}
+		if fullRecurrenceFn != nil {
+			incremental := makeScheduledBackupStatement(schedule, opts, tree.NewDString(latestSubdir))
+			args.IncrementalBackupStatement = tree.AsStringWithFlags(incremental, tree.FmtParsable)
+		}
+		// The statement shown to the user doesn't include the passphrase, or
+		// the secrets of KMS URIs.
+		if _, ok := opts[backupOptEncPassphrase]; ok {
+			opts[backupOptEncPassphrase] = "redacted"
+		}
+		if kms, ok := opts[backupOptEncKMS]; ok {
+			opts[backupOptEncKMS] = redactKMSURIs(kms)
+		}
+		displayStmt := tree.AsString(makeScheduledBackupStatement(schedule, opts, nil /* subdir */))
+		if fullRecurrenceFn != nil {
+			if args.FullBackupExpr, err = fullRecurrenceFn(); err != nil {
+				return err
+			}
+			if _, err := cron.Parse(args.FullBackupExpr); err != nil {
+				return errors.Wrap(err, "invalid full backup schedule")
+			}
+		}
+
+		name := "BACKUP"
+		if nameFn != nil {
+			if name, err = nameFn(); err != nil {
+				return err
+			}
+		} else if backup.DescriptorCoverage == tree.RequestedDescriptors {
+			name = "BACKUP " + tree.AsString(&backup.Targets)
+		}
+
+		argsBytes, err := protoutil.Marshal(&args)
+		if err != nil {
+			return err
+		}
+		sj := jobs.NewScheduledJob(name, p.User(), scheduledBackupExecutorName, argsBytes)
+		if err := sj.SetSchedule(recurrence, p.ExecCfg().Clock.Now().GoTime()); err != nil {
+			return errors.Wrap(err, "invalid backup schedule")
+		}
+		if err := sj.Create(ctx, p.ExecCfg().InternalExecutor, p.ExtendedEvalContext().Txn); err != nil {
+			return err
+		}
+
+		resultsCh <- tree.Datums{
+			tree.NewDInt(tree.DInt(sj.ScheduleID())),
+			tree.NewDString(sj.ScheduleName()),
+			tree.MakeDTimestampTZ(sj.NextRun(), time.Microsecond),
+			tree.NewDString(displayStmt),
+		}
+		return nil
+	}
+	return fn, header, nil, false, nil
+}
+
+// scheduledBackupExecutor runs backup schedules. Each run creates a detached
+// BACKUP job in the scheduler's transaction. The schedule's destination is a
+// collection: a full backup is taken into a new subdirectory of it, and the
+// incremental backups taken until the next full backup is due are appended to
+// the most recent full backup of the collection. This lets SHOW BACKUPS IN and
+// RESTORE FROM LATEST IN find the scheduled backups.
+type scheduledBackupExecutor struct{}
+
+var _ jobs.ScheduledJobExecutor = &scheduledBackupExecutor{}
+
+// ExecuteJob implements the jobs.ScheduledJobExecutor interface.
+func (e *scheduledBackupExecutor) ExecuteJob(
+	ctx context.Context, ex sqlutil.InternalExecutor, schedule *jobs.ScheduledJob, txn *client.Txn,
+) error {
+	var args ScheduledBackupExecutionArgs
+	if err := protoutil.Unmarshal(schedule.ExecutionArgs(), &args); err != nil {
+		return errors.Wrapf(err, "invalid arguments for schedule %d", schedule.ScheduleID())
+	}
+
+	now := txn.ReadTimestamp()
+	stmt := args.BackupStatement
+	if args.FullBackupExpr != "" {
+		if now.Less(args.NextFullBackup) {
+			stmt = args.IncrementalBackupStatement
+		} else {
+			sched, err := cron.Parse(args.FullBackupExpr)
+			if err != nil {
+				return err
+			}
+			args.NextFullBackup = hlc.Timestamp{WallTime: sched.Next(now.GoTime()).UnixNano()}
+		}
+	}
+
+	row, err := ex.QueryRowEx(ctx, "scheduled-backup", txn,
+		sqlbase.InternalExecutorSessionDataOverride{User: schedule.Owner()},
+		stmt, args.Destination)
+	if err != nil {
+		return errors.Wrapf(err, "failed to start backup for schedule %d", schedule.ScheduleID())
+	}
+	log.Infof(ctx, "schedule %d created backup job %s", schedule.ScheduleID(), row[0])
+
+	argsBytes, err := protoutil.Marshal(&args)
+	if err != nil {
+		return err
+	}
+	schedule.SetExecutionArgs(argsBytes)
+	return nil
+}
+
+func init() {
+	sql.AddPlanHook(createScheduledBackupPlanHook)
+	jobs.RegisterScheduledJobExecutor(scheduledBackupExecutorName, &scheduledBackupExecutor{})
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestScheduledBackup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	defer func(oldInterval time.Duration) {
+		jobs.DefaultAdoptInterval = oldInterval
+	}(jobs.DefaultAdoptInterval)
+	jobs.DefaultAdoptInterval = 100 * time.Millisecond
+
+	const numAccounts = 10
+	ctx, tc, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+
+	var scheduleID int64
+	var label, backupStmt string
+	var nextRun time.Time
+	sqlDB.QueryRow(t,
+		`CREATE SCHEDULE 'nightly' FOR BACKUP DATABASE data TO $1 RECURRING '@daily' FULL BACKUP '@weekly'`,
+		localFoo,
+	).Scan(&scheduleID, &label, &nextRun, &backupStmt)
+	if label != "nightly" {
+		t.Fatalf("expected label nightly, got %q", label)
+	}
+	if expected := `BACKUP DATABASE data INTO $1 WITH detached`; backupStmt != expected {
+		t.Fatalf("expected %q, got %q", expected, backupStmt)
+	}
+
+	sqlDB.ExpectErr(t, "invalid backup schedule",
+		`CREATE SCHEDULE FOR BACKUP TO $1 RECURRING 'sometimes'`, localFoo)
+	sqlDB.ExpectErr(t, "invalid full backup schedule",
+		`CREATE SCHEDULE FOR BACKUP TO $1 RECURRING '@daily' FULL BACKUP '61 * * * *'`, localFoo)
+
+	checkStatus := func(expected string) {
+		t.Helper()
+		sqlDB.CheckQueryResults(t,
+			`SELECT label, schedule_status, recurrence FROM [SHOW SCHEDULES]`,
+			[][]string{{"nightly", expected, "@daily"}})
+	}
+	checkStatus("ACTIVE")
+	sqlDB.Exec(t, `PAUSE SCHEDULE $1`, scheduleID)
+	checkStatus("PAUSED")
+	sqlDB.Exec(t, `RESUME SCHEDULES SELECT schedule_id FROM system.scheduled_jobs`)
+	checkStatus("ACTIVE")
+
+	// Execute the schedule twice. The first run takes a full backup into the
+	// collection, and the second one appends an incremental backup to it.
+	s := tc.Server(0)
+	ex := s.InternalExecutor().(*sql.InternalExecutor)
+	executor, err := jobs.GetScheduledJobExecutor("scheduled-backup-executor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	execute := func() {
+		t.Helper()
+		if err := s.DB().Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			schedule, err := jobs.LoadScheduledJob(ctx, ex, scheduleID, txn)
+			if err != nil {
+				return err
+			}
+			if err := executor.ExecuteJob(ctx, ex, schedule, txn); err != nil {
+				return err
+			}
+			return schedule.Update(ctx, ex, txn)
+		}); err != nil {
+			t.Fatal(err)
+		}
+		sqlDB.CheckQueryResultsRetry(t,
+			`SELECT count(*) FROM [SHOW JOBS] WHERE job_type = 'BACKUP' AND status != 'succeeded'`,
+			[][]string{{"0"}})
+	}
+
+	execute()
+	execute()
+	fullBackups := sqlDB.QueryStr(t, `SHOW BACKUPS IN $1`, localFoo)
+	if len(fullBackups) != 1 {
+		t.Fatalf("expected 1 full backup in collection, found %v", fullBackups)
+	}
+	var backups int
+	sqlDB.QueryRow(t,
+		`SELECT count(*) FROM [SHOW JOBS] WHERE job_type = 'BACKUP' AND status = 'succeeded'
+		 AND strpos(description, $1) > 0`, localFoo+fullBackups[0][0],
+	).Scan(&backups)
+	if backups != 2 {
+		t.Fatalf("expected 2 backups to %s, found %d", fullBackups[0][0], backups)
+	}
+
+	// The scheduled backups can be restored from the collection.
+	sqlDB.Exec(t, `DROP DATABASE data CASCADE`)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1`, localFoo)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.bank`,
+		[][]string{{strconv.Itoa(numAccounts)}})
+
+	sqlDB.Exec(t, `DROP SCHEDULE $1`, scheduleID)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM [SHOW SCHEDULES]`, [][]string{{"0"}})
+}
@@ -0,0 +1,226 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	crypto_rand "crypto/rand"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/errors"
+)
+
+// dataKeySize is the size of the randomly generated data keys of backups
+// encrypted with KMS.
+const dataKeySize = 32
+
+// backupEncryptionParams are the user-supplied means of encrypting a backup,
+// or of decrypting an existing one: either a passphrase from which the key of
+// the backup is derived, or the URIs of KMS master keys that wrap the data key
+// of the backup.
+type backupEncryptionParams struct {
+	passphrase []byte
+	kmsURIs    []string
+	kmsEnv     KMSEnv
+}
+
+// makeBackupEncryptionParams returns the encryption parameters given by the
+// options of a BACKUP, RESTORE or SHOW BACKUP statement. Several KMS URIs can
+// be given, separated by commas, for example to use a KMS in each region of a
+// locality-aware backup; the backup can then be decrypted with any one of them.
+func makeBackupEncryptionParams(
+	opts map[string]string, settings *cluster.Settings,
+) (backupEncryptionParams, error) {
+	params := backupEncryptionParams{kmsEnv: KMSEnv{Settings: settings}}
+	passphrase, hasPassphrase := opts[backupOptEncPassphrase]
+	kmsURIs, hasKMS := opts[backupOptEncKMS]
+	if hasPassphrase && hasKMS {
+		return params, errors.Errorf("cannot specify both %s and %s",
+			backupOptEncPassphrase, backupOptEncKMS)
+	}
+	if hasPassphrase {
+		params.passphrase = []byte(passphrase)
+	}
+	if hasKMS {
+		if params.kmsURIs = splitKMSURIs(kmsURIs); len(params.kmsURIs) == 0 {
+			return params, errors.Errorf("%s requires at least one KMS URI", backupOptEncKMS)
+		}
+	}
+	return params, nil
+}
+
+// splitKMSURIs splits a comma-separated list of KMS URIs.
+func splitKMSURIs(uris string) []string {
+	var res []string
+	for _, uri := range strings.Split(uris, ",") {
+		if uri = strings.TrimSpace(uri); uri != "" {
+			res = append(res, uri)
+		}
+	}
+	return res
+}
+
+// isSet returns whether the backup is to be encrypted.
+func (e backupEncryptionParams) isSet() bool {
+	return e.passphrase != nil || len(e.kmsURIs) > 0
+}
+
+// newEncryptionInfo returns the encryption info to store alongside a new
+// backup, and the key to encrypt its files with.
+func (e backupEncryptionParams) newEncryptionInfo(
+	ctx context.Context,
+) (*EncryptionInfo, *roachpb.FileEncryptionOptions, error) {
+	if e.passphrase != nil {
+		salt, err := storageccl.GenerateSalt()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := storageccl.GenerateKey(e.passphrase, salt)
+		return &EncryptionInfo{Salt: salt}, &roachpb.FileEncryptionOptions{Key: key}, nil
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := crypto_rand.Read(dataKey); err != nil {
+		return nil, nil, err
+	}
+	info := &EncryptionInfo{EncryptedDataKeyByKMSMasterKeyID: make(map[string][]byte)}
+	if err := wrapDataKey(ctx, info, dataKey, e.kmsURIs, e.kmsEnv); err != nil {
+		return nil, nil, err
+	}
+	return info, &roachpb.FileEncryptionOptions{Key: dataKey}, nil
+}
+
+// getEncryption returns the key of an existing backup, which was encrypted as
+// described by info.
+func (e backupEncryptionParams) getEncryption(
+	ctx context.Context, info *EncryptionInfo,
+) (*roachpb.FileEncryptionOptions, error) {
+	if len(info.EncryptedDataKeyByKMSMasterKeyID) == 0 {
+		if e.passphrase == nil {
+			return nil, errors.Errorf("backup is encrypted with a passphrase; specify %s",
+				backupOptEncPassphrase)
+		}
+		return &roachpb.FileEncryptionOptions{
+			Key: storageccl.GenerateKey(e.passphrase, info.Salt),
+		}, nil
+	}
+	if len(e.kmsURIs) == 0 {
+		return nil, errors.Errorf("backup is encrypted with KMS; specify %s", backupOptEncKMS)
+	}
+	dataKey, err := unwrapDataKey(ctx, info, e.kmsURIs, e.kmsEnv)
+	if err != nil {
+		return nil, err
+	}
+	return &roachpb.FileEncryptionOptions{Key: dataKey}, nil
+}
+
+// readBackupEncryption reads the encryption info stored alongside the backup
+// in store, and returns the key to decrypt the backup with, or nil if no
+// encryption parameters were given.
+func readBackupEncryption(
+	ctx context.Context, store cloud.ExternalStorage, params backupEncryptionParams,
+) (*roachpb.FileEncryptionOptions, error) {
+	if !params.isSet() {
+		return nil, nil
+	}
+	info, err := readEncryptionOptions(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	return params.getEncryption(ctx, info)
+}
+
+// wrapDataKey encrypts the data key of a backup with each of the KMS master
+// keys, and records the results in info.
+func wrapDataKey(
+	ctx context.Context, info *EncryptionInfo, dataKey []byte, kmsURIs []string, env KMSEnv,
+) error {
+	for _, uri := range kmsURIs {
+		if err := func() error {
+			kms, err := KMSFromURI(ctx, uri, env)
+			if err != nil {
+				return err
+			}
+			defer kms.Close()
+			id, err := kms.MasterKeyID()
+			if err != nil {
+				return err
+			}
+			encrypted, err := kms.Encrypt(ctx, dataKey)
+			if err != nil {
+				return err
+			}
+			info.EncryptedDataKeyByKMSMasterKeyID[id] = encrypted
+			return nil
+		}(); err != nil {
+			return errors.Wrapf(err, "encrypting data key with KMS %s", redactKMSURI(uri))
+		}
+	}
+	return nil
+}
+
+// unwrapDataKey decrypts the data key of a backup with the first of the KMS
+// master keys that is usable and that the data key was encrypted with.
+func unwrapDataKey(
+	ctx context.Context, info *EncryptionInfo, kmsURIs []string, env KMSEnv,
+) ([]byte, error) {
+	var errs error
+	for _, uri := range kmsURIs {
+		dataKey, err := func() ([]byte, error) {
+			kms, err := KMSFromURI(ctx, uri, env)
+			if err != nil {
+				return nil, err
+			}
+			defer kms.Close()
+			id, err := kms.MasterKeyID()
+			if err != nil {
+				return nil, err
+			}
+			encrypted, ok := info.EncryptedDataKeyByKMSMasterKeyID[id]
+			if !ok {
+				return nil, errors.Errorf("backup is not encrypted with master key %s", id)
+			}
+			dataKey, err := kms.Decrypt(ctx, encrypted)
+			if err != nil {
+				return nil, err
+			}
+			if len(dataKey) != dataKeySize {
+				return nil, errors.Errorf("decrypted data key has invalid length %d", len(dataKey))
+			}
+			return dataKey, nil
+		}()
+		if err == nil {
+			return dataKey, nil
+		}
+		errs = errors.CombineErrors(errs, errors.Wrapf(err, "KMS %s", redactKMSURI(uri)))
+	}
+	return nil, errors.Wrap(errs, "failed to decrypt the data key of the backup")
+}
+
+// redactKMSURIs redacts the secrets of a comma-separated list of KMS URIs.
+func redactKMSURIs(uris string) string {
+	res := splitKMSURIs(uris)
+	for i := range res {
+		res[i] = redactKMSURI(res[i])
+	}
+	return strings.Join(res, ",")
+}
+
+// redactKMSURI returns the KMS URI with its secrets redacted, for use in
+// errors and job descriptions.
+func redactKMSURI(uri string) string {
+	redacted, err := cloud.SanitizeExternalStorageURI(uri, nil /* extraParams */)
+	if err != nil {
+		return "<unparseable URI>"
+	}
+	return redacted
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/errors"
+)
+
+// KMS is the interface to a key management service. A KMS holds a master key
+// that never leaves it, and encrypts and decrypts small payloads, such as the
+// data keys of encrypted backups, with it.
+type KMS interface {
+	// MasterKeyID returns the identifier of the master key used by the KMS.
+	// Payloads encrypted by the KMS are recorded under this ID.
+	MasterKeyID() (string, error)
+	// Encrypt encrypts plaintext with the master key.
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	// Decrypt decrypts ciphertext that was encrypted with the master key.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+	// Close releases the resources held by the KMS.
+	Close() error
+}
+
+// KMSEnv is the environment in which a KMS is created.
+type KMSEnv struct {
+	Settings *cluster.Settings
+}
+
+// KMSFromURIFactory creates a KMS from its URI.
+type KMSFromURIFactory func(ctx context.Context, uri *url.URL, env KMSEnv) (KMS, error)
+
+// kmsFactories holds the KMS implementations, by URI scheme.
+var kmsFactories = map[string]KMSFromURIFactory{}
+
+// RegisterKMSFromURIFactory registers the factory of a KMS implementation for
+// URIs with the given scheme. It should be called from init().
+func RegisterKMSFromURIFactory(scheme string, factory KMSFromURIFactory) {
+	if _, ok := kmsFactories[scheme]; ok {
+		panic(fmt.Sprintf("KMS for scheme %q already registered", scheme))
+	}
+	kmsFactories[scheme] = factory
+}
+
+// KMSFromURI creates the KMS identified by uri.
+func KMSFromURI(ctx context.Context, uri string, env KMSEnv) (KMS, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	factory, ok := kmsFactories[parsed.Scheme]
+	if !ok {
+		return nil, errors.Errorf("unsupported KMS scheme %q", parsed.Scheme)
+	}
+	return factory(ctx, parsed, env)
+}
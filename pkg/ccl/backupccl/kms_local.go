@@ -0,0 +1,83 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/errors"
+)
+
+// localKMSScheme is the scheme of the URIs of master keys stored in files on
+// the local node, which look like local-kms:///<path>. The path is relative to
+// the node's external IO directory, and the file holds a hex-encoded AES key.
+// Since the master key is stored unprotected next to the node, this KMS is
+// only meant for testing.
+const localKMSScheme = "local-kms"
+
+type localKMS struct {
+	path      string
+	masterKey []byte
+}
+
+var _ KMS = &localKMS{}
+
+// makeLocalKMS implements KMSFromURIFactory.
+func makeLocalKMS(_ context.Context, uri *url.URL, env KMSEnv) (KMS, error) {
+	if env.Settings == nil || env.Settings.ExternalIODir == "" {
+		return nil, errors.Errorf("local file access is disabled")
+	}
+	// Cleaning the path as an absolute path keeps it inside the external IO
+	// directory.
+	path := filepath.Clean("/" + uri.Path)
+	contents, err := ioutil.ReadFile(filepath.Join(env.Settings.ExternalIODir, path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading master key")
+	}
+	masterKey, err := hex.DecodeString(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding master key in %s", path)
+	}
+	switch len(masterKey) {
+	case 16, 24, 32:
+	default:
+		return nil, errors.Errorf("master key in %s has invalid length %d", path, len(masterKey))
+	}
+	return &localKMS{path: path, masterKey: masterKey}, nil
+}
+
+// MasterKeyID implements the KMS interface.
+func (k *localKMS) MasterKeyID() (string, error) {
+	return k.path, nil
+}
+
+// Encrypt implements the KMS interface.
+func (k *localKMS) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	return storageccl.EncryptFile(plaintext, k.masterKey)
+}
+
+// Decrypt implements the KMS interface.
+func (k *localKMS) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	return storageccl.DecryptFile(ciphertext, k.masterKey)
+}
+
+// Close implements the KMS interface.
+func (k *localKMS) Close() error {
+	return nil
+}
+
+func init() {
+	RegisterKMSFromURIFactory(localKMSScheme, makeLocalKMS)
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// recordOnlineRestoreSpans records, in the ranges of the restored tables, the
+// import entries of an online restore, so that requests reading the keys of an
+// entry ingest it before the job gets to it. It must be called before the
+// tables are made public.
+//
+// Every entry is first split off into its own ranges, since a range records
+// the entries that start in it, and ranges are not split in the middle of an
+// entry afterwards until it is ingested.
+func recordOnlineRestoreSpans(
+	ctx context.Context,
+	db *client.DB,
+	details jobspb.RestoreDetails,
+	backupManifests []BackupManifest,
+	tables []*sqlbase.TableDescriptor,
+	oldTableIDs []sqlbase.ID,
+	spans []roachpb.Span,
+) error {
+	rekeys, err := makeTableRekeys(tables, oldTableIDs)
+	if err != nil {
+		return err
+	}
+	kr, err := storageccl.MakeKeyRewriterFromRekeys(rekeys)
+	if err != nil {
+		return err
+	}
+	importSpans, _, err := makeImportSpans(
+		spans, backupManifests, details.BackupLocalityInfo, keys.MinKey, errOnMissingRange,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "making import requests for %d backups", len(backupManifests))
+	}
+
+	reqs := make([]roachpb.ImportRequest, len(importSpans))
+	for i, entry := range importSpans {
+		newKey, err := rewriteBackupSpanKey(kr, entry.Key)
+		if err != nil {
+			return err
+		}
+		newEndKey, err := rewriteBackupSpanKey(kr, entry.EndKey)
+		if err != nil {
+			return err
+		}
+		reqs[i] = roachpb.ImportRequest{
+			RequestHeader: roachpb.RequestHeader{Key: newKey, EndKey: newEndKey},
+			DataSpan:      entry.Span,
+			Files:         entry.files,
+			EndTime:       details.EndTime,
+			Rekeys:        rekeys,
+			Encryption:    details.Encryption,
+		}
+	}
+	log.Eventf(ctx, "recording %d online restore spans", len(reqs))
+
+	// The splits are sticky so that the merge queue doesn't undo them before
+	// the entries are recorded.
+	expirationTime := db.Clock().Now().Add(time.Hour.Nanoseconds(), 0)
+	sem := make(chan struct{}, runtime.NumCPU())
+	g := ctxgroup.WithContext(ctx)
+	for i := range reqs {
+		req := &reqs[i]
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return errors.CombineErrors(ctx.Err(), g.Wait())
+		}
+		g.GoCtx(func(ctx context.Context) error {
+			defer func() { <-sem }()
+			for _, splitKey := range []roachpb.Key{req.Key, req.EndKey} {
+				if err := db.AdminSplit(ctx, splitKey, splitKey, expirationTime); err != nil {
+					return errors.Wrapf(err, "splitting at %s", splitKey)
+				}
+			}
+			if err := db.Put(ctx, keys.OnlineRestoreSpanKey(roachpb.RKey(req.Key)), req); err != nil {
+				return errors.Wrapf(err, "recording online restore span %s", req.Span())
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// clearOnlineRestoreSpans removes the online restore entries that start in
+// the span and haven't been ingested yet.
+func clearOnlineRestoreSpans(ctx context.Context, db *client.DB, span roachpb.Span) error {
+	kvs, err := db.Scan(ctx,
+		keys.MakeRangeKeyPrefix(roachpb.RKey(span.Key)),
+		keys.MakeRangeKeyPrefix(roachpb.RKey(span.EndKey)),
+		0, /* maxRows */
+	)
+	if err != nil {
+		return err
+	}
+	var toClear []interface{}
+	for _, kv := range kvs {
+		_, suffix, _, err := keys.DecodeRangeKey(kv.Key)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(suffix, keys.LocalOnlineRestoreSpanSuffix) {
+			toClear = append(toClear, kv.Key)
+		}
+	}
+	if len(toClear) == 0 {
+		return nil
+	}
+	return db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		return txn.Del(ctx, toClear...)
+	})
+}
@@ -553,8 +553,30 @@ func rewriteBackupSpanKey(kr *storageccl.KeyRewriter, key roachpb.Key) (roachpb.
 	return newKey, nil
 }
 
+// makeTableRekeys returns the TableRekeys to use when importing the raw data
+// of the tables, whose IDs in the backup are oldTableIDs.
+func makeTableRekeys(
+	tables []*sqlbase.TableDescriptor, oldTableIDs []sqlbase.ID,
+) ([]roachpb.ImportRequest_TableRekey, error) {
+	var rekeys []roachpb.ImportRequest_TableRekey
+	for i := range tables {
+		tableToSerialize := tables[i]
+		newDescBytes, err := protoutil.Marshal(sqlbase.WrapDescriptor(tableToSerialize))
+		if err != nil {
+			return nil, errors.NewAssertionErrorWithWrappedErrf(err,
+				"marshaling descriptor")
+		}
+		rekeys = append(rekeys, roachpb.ImportRequest_TableRekey{
+			OldID:   uint32(oldTableIDs[i]),
+			NewDesc: newDescBytes,
+		})
+	}
+	return rekeys, nil
+}
+
 // restore imports a SQL table (or tables) from sets of non-overlapping sstable
-// files.
+// files. If online is set, the import entries were recorded by
+// recordOnlineRestoreSpans, and their records are removed as they are imported.
 func restore(
 	restoreCtx context.Context,
 	db *client.DB,
@@ -568,6 +590,7 @@ func restore(
 	spans []roachpb.Span,
 	job *jobs.Job,
 	encryption *roachpb.FileEncryptionOptions,
+	online bool,
 ) (roachpb.BulkOpSummary, error) {
 	// A note about contexts and spans in this method: the top-level context
 	// `restoreCtx` is used for orchestration logging. All operations that carry
@@ -583,18 +606,9 @@ func restore(
 	}
 
 	// Get TableRekeys to use when importing raw data.
-	var rekeys []roachpb.ImportRequest_TableRekey
-	for i := range tables {
-		tableToSerialize := tables[i]
-		newDescBytes, err := protoutil.Marshal(sqlbase.WrapDescriptor(tableToSerialize))
-		if err != nil {
-			return mu.res, errors.NewAssertionErrorWithWrappedErrf(err,
-				"marshaling descriptor")
-		}
-		rekeys = append(rekeys, roachpb.ImportRequest_TableRekey{
-			OldID:   uint32(oldTableIDs[i]),
-			NewDesc: newDescBytes,
-		})
+	rekeys, err := makeTableRekeys(tables, oldTableIDs)
+	if err != nil {
+		return mu.res, err
 	}
 	kr, err := storageccl.MakeKeyRewriterFromRekeys(rekeys)
 	if err != nil {
@@ -710,6 +724,11 @@ func restore(
 					return errors.Wrapf(pErr.GoError(), "importing span %v", importRequest.DataSpan)
 
 				}
+				if online {
+					if err := db.Del(ctx, keys.OnlineRestoreSpanKey(roachpb.RKey(newSpanKey))); err != nil {
+						return errors.Wrapf(err, "removing online restore span %s", newSpanKey)
+					}
+				}
 
 				mu.Lock()
 				mu.res.Add(importRes.(*roachpb.ImportResponse).Imported)
@@ -860,7 +879,11 @@ func isDatabaseEmpty(
 // createImportingTables create the tables that we will restore into. It also
 // fetches the information from the old tables that we need for the restore.
 func createImportingTables(
-	ctx context.Context, p sql.PlanHookState, sqlDescs []sqlbase.Descriptor, r *restoreResumer,
+	ctx context.Context,
+	p sql.PlanHookState,
+	backupManifests []BackupManifest,
+	sqlDescs []sqlbase.Descriptor,
+	r *restoreResumer,
 ) (
 	[]*sqlbase.DatabaseDescriptor,
 	[]*sqlbase.TableDescriptor,
@@ -873,14 +896,19 @@ func createImportingTables(
 	var databases []*sqlbase.DatabaseDescriptor
 	var tables []*sqlbase.TableDescriptor
 	var oldTableIDs []sqlbase.ID
+	dbNames := make(map[sqlbase.ID]string)
 	for _, desc := range sqlDescs {
 		if tableDesc := desc.Table(hlc.Timestamp{}); tableDesc != nil {
 			tables = append(tables, tableDesc)
 			oldTableIDs = append(oldTableIDs, tableDesc.ID)
 		}
 		if dbDesc := desc.GetDatabase(); dbDesc != nil {
+			dbNames[dbDesc.ID] = dbDesc.Name
 			if rewrite, ok := details.TableRewrites[dbDesc.ID]; ok {
 				dbDesc.ID = rewrite.TableID
+				if rewrite.Name != "" {
+					dbDesc.Name = rewrite.Name
+				}
 				databases = append(databases, dbDesc)
 			}
 		}
@@ -907,19 +935,34 @@ func createImportingTables(
 
 	// Assign new IDs and privileges to the tables, and update all references to
 	// use the new IDs.
-	if err := RewriteTableDescs(tables, details.TableRewrites, details.OverrideDB); err != nil {
+	if err := RewriteTableDescs(tables, details.TableRewrites, details.OverrideDB, dbNames); err != nil {
 		return nil, nil, nil, nil, err
 	}
 
 	for _, desc := range tables {
 		desc.Version++
+		// Tables restored online are public right away, and reads of them ingest
+		// the data they need. Sequences are kept offline since their values
+		// aren't read through scans.
+		if details.Online && !desc.IsSequence() {
+			desc.OnlineRestoreJobID = *r.job.ID()
+			continue
+		}
 		desc.State = sqlbase.TableDescriptor_OFFLINE
 		desc.OfflineReason = "restoring"
 	}
 
 	if !details.PrepareCompleted {
+		if details.Online {
+			if err := recordOnlineRestoreSpans(
+				ctx, p.ExecCfg().DB, details, backupManifests, tables, oldTableIDs, spans,
+			); err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
 		err := p.ExecCfg().DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
-			// Write the new TableDescriptors which are set in the OFFLINE state.
+			// Write the new TableDescriptors which are set in the OFFLINE state,
+			// or are public but marked as being restored online.
 			if err := WriteTableDescs(ctx, txn, databases, tables, details.DescriptorCoverage, r.job.Payload().Username, r.settings, nil /* extra */); err != nil {
 				return errors.Wrapf(err, "restoring %d TableDescriptors from %d databases", len(r.tables), len(databases))
 			}
@@ -954,7 +997,7 @@ func (r *restoreResumer) Resume(
 		return err
 	}
 
-	databases, tables, oldTableIDs, spans, err := createImportingTables(ctx, p, sqlDescs, r)
+	databases, tables, oldTableIDs, spans, err := createImportingTables(ctx, p, backupManifests, sqlDescs, r)
 	if err != nil {
 		return err
 	}
@@ -985,6 +1028,7 @@ func (r *restoreResumer) Resume(
 		spans,
 		r.job,
 		details.Encryption,
+		details.Online,
 	)
 	r.res = res
 	if err != nil {
@@ -1031,7 +1075,8 @@ func (r *restoreResumer) insertStats(ctx context.Context) error {
 	return nil
 }
 
-// publishTables updates the RESTORED tables status from OFFLINE to PUBLIC.
+// publishTables updates the RESTORED tables status from OFFLINE to PUBLIC, and
+// unmarks the tables restored online.
 func (r *restoreResumer) publishTables(ctx context.Context) error {
 	details := r.job.Details().(jobspb.RestoreDetails)
 	if details.TablesPublished {
@@ -1047,6 +1092,7 @@ func (r *restoreResumer) publishTables(ctx context.Context) error {
 			tableDesc := *tbl
 			tableDesc.Version++
 			tableDesc.State = sqlbase.TableDescriptor_PUBLIC
+			tableDesc.OnlineRestoreJobID = 0
 			existingDescVal, err := sqlbase.ConditionalGetTableDescFromTxn(ctx, txn, tbl)
 			if err != nil {
 				return errors.Wrap(err, "validating table descriptor has not changed")
@@ -1089,7 +1135,21 @@ func (r *restoreResumer) publishTables(ctx context.Context) error {
 // this by adding the table descriptors in DROP state, which causes the schema
 // change stuff to delete the keys in the background.
 func (r *restoreResumer) OnFailOrCancel(ctx context.Context, phs interface{}) error {
-	return phs.(sql.PlanHookState).ExecCfg().DB.Txn(ctx, r.dropTables)
+	execCfg := phs.(sql.PlanHookState).ExecCfg()
+	if err := execCfg.DB.Txn(ctx, r.dropTables); err != nil {
+		return err
+	}
+	// The entries of the dropped tables that weren't ingested must not be
+	// ingested by reads of their spans anymore.
+	details := r.job.Details().(jobspb.RestoreDetails)
+	if details.Online && details.PrepareCompleted {
+		for _, tbl := range details.TableDescs {
+			if err := clearOnlineRestoreSpans(ctx, execCfg.DB, tbl.TableSpan()); err != nil {
+				return errors.Wrapf(err, "clearing online restore spans of table %d", tbl.ID)
+			}
+		}
+	}
+	return nil
 }
 
 // dropTables implements the OnFailOrCancel logic.
@@ -1234,4 +1294,5 @@ func init() {
 			}
 		},
 	)
+	jobs.RegisterRetryPolicy(jobspb.TypeRestore, jobs.DefaultRetryPolicy)
 }
@@ -11,8 +11,8 @@ package backupccl
 import (
 	"context"
 	"sort"
+	"strings"
 
-	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
 	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
@@ -41,6 +41,9 @@ type TableRewriteMap map[sqlbase.ID]*jobspb.RestoreDetails_TableRewrite
 
 const (
 	restoreOptIntoDB               = "into_db"
+	restoreOptNewDBName            = "new_db_name"
+	restoreOptNewTableNames        = "new_table_names"
+	restoreOptOnline               = "online"
 	restoreOptSkipMissingFKs       = "skip_missing_foreign_keys"
 	restoreOptSkipMissingSequences = "skip_missing_sequences"
 	restoreOptSkipMissingViews     = "skip_missing_views"
@@ -52,28 +55,63 @@ const (
 
 var restoreOptionExpectValues = map[string]sql.KVStringOptValidate{
 	restoreOptIntoDB:               sql.KVStringOptRequireValue,
+	restoreOptNewDBName:            sql.KVStringOptRequireValue,
+	restoreOptNewTableNames:        sql.KVStringOptRequireValue,
+	restoreOptOnline:               sql.KVStringOptRequireNoValue,
 	restoreOptSkipMissingFKs:       sql.KVStringOptRequireNoValue,
 	restoreOptSkipMissingSequences: sql.KVStringOptRequireNoValue,
 	restoreOptSkipMissingViews:     sql.KVStringOptRequireNoValue,
 	backupOptEncPassphrase:         sql.KVStringOptRequireValue,
+	backupOptEncKMS:                sql.KVStringOptRequireValue,
 }
 
-// rewriteViewQueryDBNames rewrites the passed table's ViewQuery replacing all
-// non-empty db qualifiers with `newDB`.
+// backupTableName is the fully qualified name of a table in a backup.
+type backupTableName struct {
+	db, schema, table string
+}
+
+// rewriteViewQueryNames rewrites the passed view's ViewQuery so that its
+// references to restored tables use the names these are restored under:
+// newDBs maps the names of the databases in the backup to the database their
+// tables are restored into, if it changes, and newTableNames maps the names in
+// the backup of the tables restored under new names to these names. References
+// that don't resolve to a restored table or database are left untouched.
+// viewDB is the name of the view's database in the backup, which names that
+// aren't qualified with a database resolve to.
 //
 // TODO: this AST traversal misses tables named in strings (#24556).
-func rewriteViewQueryDBNames(table *sqlbase.TableDescriptor, newDB string) error {
+func rewriteViewQueryNames(
+	table *sqlbase.TableDescriptor,
+	viewDB string,
+	newDBs map[string]string,
+	newTableNames map[backupTableName]string,
+) error {
 	stmt, err := parser.ParseOne(table.ViewQuery)
 	if err != nil {
 		return pgerror.Wrapf(err, pgcode.Syntax,
 			"failed to parse underlying query from view %q", table.Name)
 	}
-	// Re-format to change all DB names to `newDB`.
+	// Re-format to change the DB names and rename tables.
 	f := tree.NewFmtCtx(tree.FmtParsable)
 	f.SetReformatTableNames(func(ctx *tree.FmtCtx, tn *tree.TableName) {
-		// empty catalog e.g. ``"".information_schema.tables` should stay empty.
-		if tn.CatalogName != "" {
-			tn.CatalogName = tree.Name(newDB)
+		name := backupTableName{db: viewDB, schema: tree.PublicSchema, table: string(tn.TableName)}
+		if tn.ExplicitCatalog {
+			name.db, name.schema = string(tn.CatalogName), string(tn.SchemaName)
+		} else if tn.ExplicitSchema && tn.SchemaName != tree.PublicSchemaName {
+			// A two-part name is qualified either by a schema or a database.
+			name.db = string(tn.SchemaName)
+		}
+		if newName, ok := newTableNames[name]; ok {
+			tn.TableName = tree.Name(newName)
+		}
+		// Names that aren't qualified with a database are left unqualified, and an
+		// empty catalog e.g. `"".information_schema.tables` should stay empty.
+		if newDB, ok := newDBs[name.db]; ok {
+			if tn.ExplicitCatalog {
+				tn.CatalogName = tree.Name(newDB)
+			} else if tn.ExplicitSchema && tn.SchemaName != tree.PublicSchemaName {
+				tn.SchemaName = tree.Name(newDB)
+			}
 		}
 		ctx.WithReformatTableNames(nil, func() {
 			ctx.FormatNode(tn)
@@ -84,6 +122,127 @@ func rewriteViewQueryDBNames(table *sqlbase.TableDescriptor, newDB string) error
 	return nil
 }
 
+// rewriteSequenceNames rewrites the names of the sequences passed to nextval in
+// the default expression of the passed column: database qualifiers are
+// replaced with `newDB`, unless it is empty, and the sequences in newSeqNames
+// are renamed.
+func rewriteSequenceNames(
+	col *sqlbase.ColumnDescriptor, newDB string, newSeqNames map[string]string,
+) error {
+	expr, err := parser.ParseExpr(*col.DefaultExpr)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.Syntax,
+			"failed to parse default expression of column %q", col.Name)
+	}
+	expr, err = tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		fn, ok := expr.(*tree.FuncExpr)
+		if !ok {
+			return true, expr, nil
+		}
+		def, err := fn.Func.Resolve(sessiondata.SearchPath{})
+		if err != nil {
+			return false, expr, err
+		}
+		if def.Name != "nextval" || len(fn.Exprs) != 1 {
+			return true, expr, nil
+		}
+		// The name is usually annotated with its type, e.g. 'seq':::STRING.
+		arg := fn.Exprs[0]
+		annotated, isAnnotated := arg.(*tree.AnnotateTypeExpr)
+		if isAnnotated {
+			arg = annotated.Expr
+		}
+		str, ok := arg.(*tree.StrVal)
+		if !ok {
+			return true, expr, nil
+		}
+		seqName, err := parser.ParseTableName(str.RawString())
+		if err != nil {
+			return false, expr, err
+		}
+		if newName, ok := newSeqNames[seqName.Parts[0]]; ok {
+			seqName.Parts[0] = newName
+		}
+		if newDB != "" {
+			// A two-part name is qualified either by a schema or a database.
+			if seqName.NumParts == 3 {
+				seqName.Parts[2] = newDB
+			} else if seqName.NumParts == 2 && seqName.Parts[1] != tree.PublicSchema {
+				seqName.Parts[1] = newDB
+			}
+		}
+		var newArg tree.Expr = tree.NewStrVal(tree.AsString(seqName))
+		if isAnnotated {
+			newAnnotated := *annotated
+			newAnnotated.Expr = newArg
+			newArg = &newAnnotated
+		}
+		newFn := *fn
+		newFn.Exprs = tree.Exprs{newArg}
+		return false, &newFn, nil
+	})
+	if err != nil {
+		return err
+	}
+	newDefault := tree.Serialize(expr)
+	col.DefaultExpr = &newDefault
+	return nil
+}
+
+// resolveNewTableNames resolves the tables named by the restoreOptNewTableNames
+// option, a comma-separated list of `<table>=<new name>`, and returns the new
+// name of each of them by ID. The tables can be qualified by the name of their
+// database in the backup.
+func resolveNewTableNames(
+	databasesByID map[sqlbase.ID]*sqlbase.DatabaseDescriptor,
+	tablesByID map[sqlbase.ID]*sqlbase.TableDescriptor,
+	opts map[string]string,
+) (map[sqlbase.ID]string, error) {
+	newTableNames := make(map[sqlbase.ID]string)
+	option, ok := opts[restoreOptNewTableNames]
+	if !ok {
+		return newTableNames, nil
+	}
+	for _, rename := range strings.Split(option, ",") {
+		parts := strings.Split(rename, "=")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, errors.Errorf("invalid %q option %q: expected <table>=<new name>",
+				restoreOptNewTableNames, rename)
+		}
+		oldName, newName := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		var dbName, tableName string
+		if i := strings.LastIndex(oldName, "."); i >= 0 {
+			dbName, tableName = oldName[:i], oldName[i+1:]
+		} else {
+			tableName = oldName
+		}
+		var found *sqlbase.TableDescriptor
+		for _, table := range tablesByID {
+			if table.Name != tableName {
+				continue
+			}
+			if dbName != "" {
+				if db, ok := databasesByID[table.ParentID]; !ok || db.Name != dbName {
+					continue
+				}
+			}
+			if found != nil {
+				return nil, errors.Errorf(
+					"table %q to rename is ambiguous; qualify it with the name of its database", oldName)
+			}
+			found = table
+		}
+		if found == nil {
+			return nil, errors.Errorf("table %q to rename is not being restored", oldName)
+		}
+		if _, ok := newTableNames[found.ID]; ok {
+			return nil, errors.Errorf("table %q is renamed more than once", oldName)
+		}
+		newTableNames[found.ID] = newName
+	}
+	return newTableNames, nil
+}
+
 // maybeFilterMissingViews filters the set of tables to restore to exclude views
 // whose dependencies are either missing or are themselves unrestorable due to
 // missing dependencies, and returns the resulting set of tables. If the
@@ -149,6 +308,25 @@ func allocateTableRewrites(
 		return nil, errors.Errorf("cannot use %q option when restoring database(s)", restoreOptIntoDB)
 	}
 
+	newDBName, renamingDB := opts[restoreOptNewDBName]
+	if renamingDB {
+		if descriptorCoverage == tree.AllDescriptors || len(restoreDBs) != 1 {
+			return nil, errors.Errorf("%q option can only be used when restoring a single database",
+				restoreOptNewDBName)
+		}
+		if newDBName == "" {
+			return nil, errors.Errorf("%q option requires a database name", restoreOptNewDBName)
+		}
+	}
+	if _, ok := opts[restoreOptNewTableNames]; ok && descriptorCoverage == tree.AllDescriptors {
+		return nil, errors.Errorf("cannot use %q option in a full cluster restore",
+			restoreOptNewTableNames)
+	}
+	newTableNames, err := resolveNewTableNames(databasesByID, tablesByID, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// The logic at the end of this function leaks table IDs, so fail fast if
 	// we can be certain the restore will fail.
 
@@ -233,6 +411,15 @@ func allocateTableRewrites(
 				}
 			}
 		}
+		if renamingDB {
+			found, _, err := sqlbase.LookupDatabaseID(ctx, txn, newDBName)
+			if err != nil {
+				return err
+			}
+			if found {
+				return errors.Errorf("database %q already exists", newDBName)
+			}
+		}
 
 		for _, table := range tablesByID {
 			var targetDB string
@@ -292,7 +479,11 @@ func allocateTableRewrites(
 				}
 				// Check that the table name is _not_ in use.
 				// This would fail the CPut later anyway, but this yields a prettier error.
-				if err := CheckTableExists(ctx, txn, parentID, table.Name); err != nil {
+				tableName := table.Name
+				if newName, ok := newTableNames[table.ID]; ok {
+					tableName = newName
+				}
+				if err := CheckTableExists(ctx, txn, parentID, tableName); err != nil {
 					return err
 				}
 
@@ -344,6 +535,9 @@ func allocateTableRewrites(
 		}
 
 		tableRewrites[db.ID] = &jobspb.RestoreDetails_TableRewrite{TableID: newID}
+		if renamingDB {
+			tableRewrites[db.ID].Name = newDBName
+		}
 		for _, tableID := range needsNewParentIDs[db.Name] {
 			tableRewrites[tableID] = &jobspb.RestoreDetails_TableRewrite{ParentID: newID}
 		}
@@ -377,6 +571,9 @@ func allocateTableRewrites(
 		}
 		tableRewrites[table.ID].TableID = newTableID
 	}
+	for id, name := range newTableNames {
+		tableRewrites[id].Name = name
+	}
 
 	return tableRewrites, nil
 }
@@ -420,31 +617,74 @@ func maybeUpgradeTableDescsInBackupManifests(
 	return nil
 }
 
-// RewriteTableDescs mutates tables to match the ID and privilege specified
-// in tableRewrites, as well as adjusting cross-table references to use the
-// new IDs. overrideDB can be specified to set database names in views.
+// RewriteTableDescs mutates tables to match the ID, name and privilege
+// specified in tableRewrites, as well as adjusting cross-table references to
+// use the new IDs and names. overrideDB can be specified to set database names
+// in views. dbNames holds the names in the backup of the databases of the
+// tables, which views refer to these tables with.
 func RewriteTableDescs(
-	tables []*sqlbase.TableDescriptor, tableRewrites TableRewriteMap, overrideDB string,
+	tables []*sqlbase.TableDescriptor,
+	tableRewrites TableRewriteMap,
+	overrideDB string,
+	dbNames map[sqlbase.ID]string,
 ) error {
+	// If the database of a table is restored under a new name, it is the only
+	// database being restored, so it is the destination of everything being
+	// restored, just like overrideDB.
+	destinationDB := func(table *sqlbase.TableDescriptor) string {
+		if dbRewrite, ok := tableRewrites[table.ParentID]; ok && dbRewrite.Name != "" {
+			return dbRewrite.Name
+		}
+		return overrideDB
+	}
+
+	// The names in the backup of the tables restored under new names, which
+	// views and default expressions refer to them by.
+	oldNames := make(map[sqlbase.ID]string)
+	// The new names of the databases and tables that view queries refer to.
+	newViewDBs := make(map[string]string)
+	newViewTableNames := make(map[backupTableName]string)
+	for _, table := range tables {
+		if newDB := destinationDB(table); newDB != "" {
+			newViewDBs[dbNames[table.ParentID]] = newDB
+		}
+		if tableRewrite, ok := tableRewrites[table.ID]; ok && tableRewrite.Name != "" {
+			oldNames[table.ID] = table.Name
+			name := backupTableName{
+				db: dbNames[table.ParentID], schema: tree.PublicSchema, table: table.Name,
+			}
+			newViewTableNames[name] = tableRewrite.Name
+		}
+	}
+
 	for _, table := range tables {
 		tableRewrite, ok := tableRewrites[table.ID]
 		if !ok {
 			return errors.Errorf("missing table rewrite for table %d", table.ID)
 		}
-		if table.IsView() && overrideDB != "" {
+		newDB := destinationDB(table)
+		if table.IsView() {
 			// restore checks that all dependencies are also being restored, but if
 			// the restore is overriding the destination database, qualifiers in the
 			// view query string may be wrong. Since the destination override is
-			// applied to everything being restored, anything the view query
-			// references will be in the override DB post-restore, so all database
-			// qualifiers in the view query should be replaced with overrideDB.
-			if err := rewriteViewQueryDBNames(table, overrideDB); err != nil {
-				return err
+			// applied to everything being restored, the references in the view
+			// query to the databases being restored should be replaced with their
+			// destination. Likewise, the dependencies restored under new names need
+			// to be renamed.
+			if len(newViewDBs) > 0 || len(newViewTableNames) > 0 {
+				if err := rewriteViewQueryNames(
+					table, dbNames[table.ParentID], newViewDBs, newViewTableNames,
+				); err != nil {
+					return err
+				}
 			}
 		}
 
 		table.ID = tableRewrite.TableID
 		table.ParentID = tableRewrite.ParentID
+		if tableRewrite.Name != "" {
+			table.Name = tableRewrite.Name
+		}
 
 		if err := table.ForeachNonDropIndex(func(index *sqlbase.IndexDescriptor) error {
 			// Verify that for any interleaved index being restored, the interleave
@@ -531,10 +771,14 @@ func RewriteTableDescs(
 		// Rewrite sequence references in column descriptors.
 		for idx := range table.Columns {
 			var newSeqRefs []sqlbase.ID
+			newSeqNames := make(map[string]string)
 			col := &table.Columns[idx]
 			for _, seqID := range col.UsesSequenceIds {
 				if rewrite, ok := tableRewrites[seqID]; ok {
 					newSeqRefs = append(newSeqRefs, rewrite.TableID)
+					if oldName, ok := oldNames[seqID]; ok {
+						newSeqNames[oldName] = rewrite.Name
+					}
 				} else {
 					// The referenced sequence isn't being restored.
 					// Strip the DEFAULT expression and sequence references.
@@ -546,6 +790,13 @@ func RewriteTableDescs(
 				}
 			}
 			col.UsesSequenceIds = newSeqRefs
+			// The default expression refers to the sequences by name, so it needs
+			// to follow them into their new database or to their new names.
+			if len(newSeqRefs) > 0 && col.DefaultExpr != nil && (newDB != "" || len(newSeqNames) > 0) {
+				if err := rewriteSequenceNames(col, newDB, newSeqNames); err != nil {
+					return err
+				}
+			}
 		}
 
 		// since this is a "new" table in eyes of new cluster, any leftover change
@@ -615,6 +866,15 @@ func restorePlanHook(
 		fromFns[i] = fromFn
 	}
 
+	var subdirFn func() (string, error)
+	if restoreStmt.Subdir != nil {
+		var err error
+		subdirFn, err = p.TypeAsString(restoreStmt.Subdir, "RESTORE")
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+
 	optsFn, err := p.TypeAsStringOpts(restoreStmt.Options, restoreOptionExpectValues)
 	if err != nil {
 		return nil, nil, nil, false, err
@@ -655,6 +915,28 @@ func restorePlanHook(
 			}
 		}
 
+		// When restoring from a collection, restore from the requested full
+		// backup along with the incremental backups appended to it.
+		if subdirFn != nil {
+			subdir, err := subdirFn()
+			if err != nil {
+				return err
+			}
+			collectionDefaultURI, _, err := getURIsByLocalityKV(from[0], "")
+			if err != nil {
+				return err
+			}
+			subdir, err = resolveCollectionSubdir(
+				ctx, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI, collectionDefaultURI, subdir, endTime,
+			)
+			if err != nil {
+				return err
+			}
+			if from[0], err = appendPaths(from[0], subdir); err != nil {
+				return err
+			}
+		}
+
 		opts, err := optsFn()
 		if err != nil {
 			return err
@@ -686,14 +968,13 @@ func doRestorePlan(
 		baseStores[i] = store
 	}
 
-	var encryption *roachpb.FileEncryptionOptions
-	if passphrase, ok := opts[backupOptEncPassphrase]; ok {
-		opts, err := readEncryptionOptions(ctx, baseStores[0])
-		if err != nil {
-			return err
-		}
-		encryptionKey := storageccl.GenerateKey([]byte(passphrase), opts.Salt)
-		encryption = &roachpb.FileEncryptionOptions{Key: encryptionKey}
+	encryptionParams, err := makeBackupEncryptionParams(opts, p.ExecCfg().Settings)
+	if err != nil {
+		return err
+	}
+	encryption, err := readBackupEncryption(ctx, baseStores[0], encryptionParams)
+	if err != nil {
+		return err
 	}
 
 	defaultURIs, mainBackupManifests, localityInfo, err := resolveBackupManifests(
@@ -703,6 +984,11 @@ func doRestorePlan(
 		return err
 	}
 
+	_, online := opts[restoreOptOnline]
+	if online && restoreStmt.DescriptorCoverage == tree.AllDescriptors {
+		return errors.Errorf("%q option cannot be used with a full cluster restore", restoreOptOnline)
+	}
+
 	// Validate that the table coverage of the backup matches that of the restore.
 	// This prevents FULL CLUSTER backups to be restored as anything but full
 	// cluster restores and vice-versa.
@@ -790,7 +1076,11 @@ func doRestorePlan(
 	for _, desc := range filteredTablesByID {
 		tables = append(tables, desc)
 	}
-	if err := RewriteTableDescs(tables, tableRewrites, opts[restoreOptIntoDB]); err != nil {
+	dbNames := make(map[sqlbase.ID]string, len(databasesByID))
+	for id, db := range databasesByID {
+		dbNames[id] = db.Name
+	}
+	if err := RewriteTableDescs(tables, tableRewrites, opts[restoreOptIntoDB], dbNames); err != nil {
 		return err
 	}
 
@@ -812,6 +1102,7 @@ func doRestorePlan(
 			OverrideDB:         opts[restoreOptIntoDB],
 			DescriptorCoverage: restoreStmt.DescriptorCoverage,
 			Encryption:         encryption,
+			Online:             online,
 		},
 		Progress: jobspb.RestoreProgress{},
 	})
@@ -12,7 +12,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
 	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
@@ -45,12 +44,27 @@ func showBackupPlanHook(
 		return nil, nil, nil, false, err
 	}
 
+	if backup.Path == nil && backup.InCollection != nil {
+		return showBackupsInCollectionPlanHook(ctx, backup, p)
+	}
+
 	toFn, err := p.TypeAsString(backup.Path, "SHOW BACKUP")
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
+	var collectionFn func() (string, error)
+	if backup.InCollection != nil {
+		collectionFn, err = p.TypeAsString(backup.InCollection, "SHOW BACKUP")
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
 
-	expected := map[string]sql.KVStringOptValidate{backupOptEncPassphrase: sql.KVStringOptRequireValue}
+	expected := map[string]sql.KVStringOptValidate{
+		backupOptEncPassphrase: sql.KVStringOptRequireValue,
+		backupOptEncKMS:        sql.KVStringOptRequireValue,
+		backupOptCheckFiles:    sql.KVStringOptRequireNoValue,
+	}
 	optsFn, err := p.TypeAsStringOpts(backup.Options, expected)
 	if err != nil {
 		return nil, nil, nil, false, err
@@ -75,25 +89,43 @@ func showBackupPlanHook(
 		if err != nil {
 			return err
 		}
+		if collectionFn != nil {
+			collection, err := collectionFn()
+			if err != nil {
+				return err
+			}
+			subdir, err := resolveCollectionSubdir(
+				ctx, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI, collection, str, hlc.Timestamp{},
+			)
+			if err != nil {
+				return err
+			}
+			paths, err := appendPaths([]string{collection}, subdir)
+			if err != nil {
+				return err
+			}
+			str = paths[0]
+		}
 
 		opts, err := optsFn()
 		if err != nil {
 			return err
 		}
 
+		encryptionParams, err := makeBackupEncryptionParams(opts, p.ExecCfg().Settings)
+		if err != nil {
+			return err
+		}
 		var encryption *roachpb.FileEncryptionOptions
-		if passphrase, ok := opts[backupOptEncPassphrase]; ok {
+		if encryptionParams.isSet() {
 			store, err := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, str)
 			if err != nil {
 				return errors.Wrapf(err, "make storage")
 			}
 			defer store.Close()
-			opts, err := readEncryptionOptions(ctx, store)
-			if err != nil {
+			if encryption, err = readBackupEncryption(ctx, store, encryptionParams); err != nil {
 				return err
 			}
-			encryptionKey := storageccl.GenerateKey([]byte(passphrase), opts.Salt)
-			encryption = &roachpb.FileEncryptionOptions{Key: encryptionKey}
 		}
 
 		desc, err := ReadBackupManifestFromURI(
@@ -102,6 +134,15 @@ func showBackupPlanHook(
 		if err != nil {
 			return err
 		}
+		// With check_files, only show the backup once it is known to be
+		// restorable.
+		if _, ok := opts[backupOptCheckFiles]; ok {
+			if err := validateBackup(
+				ctx, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI, str, encryption,
+			); err != nil {
+				return err
+			}
+		}
 		// If we are restoring a backup with old-style foreign keys, skip over the
 		// FKs for which we can't resolve the cross-table references. We can't
 		// display them anyway, because we don't have the referenced table names,
@@ -123,6 +164,45 @@ func showBackupPlanHook(
 	return fn, shower.header, nil, false, nil
 }
 
+// showBackupsInCollectionPlanHook implements PlanHookFn for SHOW BACKUPS IN,
+// which lists the full backups in a collection.
+func showBackupsInCollectionPlanHook(
+	_ context.Context, backup *tree.ShowBackup, p sql.PlanHookState,
+) (sql.PlanHookRowFn, sqlbase.ResultColumns, []sql.PlanNode, bool, error) {
+	collectionFn, err := p.TypeAsString(backup.InCollection, "SHOW BACKUPS")
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, backup.StatementTag())
+		defer tracing.FinishSpan(span)
+
+		collection, err := collectionFn()
+		if err != nil {
+			return err
+		}
+		store, err := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, collection)
+		if err != nil {
+			return errors.Wrapf(err, "make storage")
+		}
+		defer store.Close()
+		subdirs, err := listFullBackupsInCollection(ctx, store)
+		if err != nil {
+			return err
+		}
+		for _, subdir := range subdirs {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case resultsCh <- tree.Datums{tree.NewDString(subdir)}:
+			}
+		}
+		return nil
+	}
+	return fn, sqlbase.ResultColumns{{Name: "path", Typ: types.String}}, nil, false, nil
+}
+
 type backupShower struct {
 	header sqlbase.ResultColumns
 	fn     func(BackupManifest) []tree.Datums
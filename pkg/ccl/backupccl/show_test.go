@@ -11,6 +11,9 @@ package backupccl_test
 import (
 	"database/sql/driver"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -215,6 +218,50 @@ COMMENT ON INDEX tablea_b_idx IS 'index'`
 	}
 }
 
+func TestShowBackupCheckFiles(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const numAccounts = 11
+	_, _, sqlDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+
+	// Take a full backup and append an incremental backup to it.
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1`)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+
+	sqlDB.CheckQueryResults(t,
+		`SELECT table_name FROM [SHOW BACKUP $1 WITH check_files]`, [][]string{{"bank"}})
+
+	fullFiles, err := filepath.Glob(filepath.Join(dir, "foo", "*.sst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	incFiles, err := filepath.Glob(filepath.Join(dir, "foo", "*", "*", "*.sst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fullFiles) == 0 || len(incFiles) == 0 {
+		t.Fatalf("expected files in full and incremental backups, found %v and %v", fullFiles, incFiles)
+	}
+
+	// Remove a file of the full backup, and corrupt a file of the incremental
+	// backup.
+	if err := os.Remove(fullFiles[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(incFiles[0], []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.ExpectErr(t, `failed validation with 2 problem\(s\)`,
+		`SHOW BACKUP $1 WITH check_files`, localFoo)
+	sqlDB.ExpectErr(t, `checksum mismatch`, `SHOW BACKUP $1 WITH check_files`, localFoo)
+
+	// Without check_files, the backup is shown from its manifest alone.
+	sqlDB.CheckQueryResults(t,
+		`SELECT table_name FROM [SHOW BACKUP $1]`, [][]string{{"bank"}})
+}
+
 func eqWhitespace(a, b string) bool {
 	return strings.Replace(a, "\t", "", -1) == strings.Replace(b, "\t", "", -1)
 }
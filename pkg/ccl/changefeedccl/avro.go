@@ -64,6 +64,7 @@ const (
 	avroSchemaLong    = `long`
 	avroSchemaNull    = `null`
 	avroSchemaString  = `string`
+	avroSchemaArray   = `array`
 )
 
 type avroLogicalType struct {
@@ -73,13 +74,25 @@ type avroLogicalType struct {
 	Scale       int            `json:"scale,omitempty"`
 }
 
+// avroArrayType is our representation of the schema of an avro array.
+// Serializing it to JSON gives the standard schema representation.
+type avroArrayType struct {
+	SchemaType string         `json:"type"`
+	Items      avroSchemaType `json:"items"`
+}
+
 func avroUnionKey(t avroSchemaType) string {
 	switch s := t.(type) {
 	case string:
 		return s
 	case avroLogicalType:
 		return avroUnionKey(s.SchemaType) + `.` + s.LogicalType
+	case avroArrayType:
+		return s.SchemaType
 	case *avroRecord:
+		if s.Namespace != `` {
+			return s.Namespace + `.` + s.Name
+		}
 		return s.Name
 	default:
 		panic(fmt.Sprintf(`unsupported type %T %v`, t, t))
@@ -105,10 +118,25 @@ type avroSchemaField struct {
 type avroRecord struct {
 	SchemaType string             `json:"type"`
 	Name       string             `json:"name"`
+	Namespace  string             `json:"namespace,omitempty"`
 	Fields     []*avroSchemaField `json:"fields"`
 	codec      *goavro.Codec
 }
 
+// avroSchemaNaming configures the names of the avro records generated for SQL
+// tables. The zero value names records after their tables, without namespace.
+type avroSchemaNaming struct {
+	// prefix is prepended to the name of every record.
+	prefix string
+	// namespace is the avro namespace of every record.
+	namespace string
+}
+
+// recordName returns the avro name of the record with the given SQL name.
+func (n avroSchemaNaming) recordName(sqlName string) string {
+	return SQLNameToAvroName(n.prefix + sqlName)
+}
+
 // avroDataRecord is an `avroRecord` that represents the schema of a SQL table
 // or index.
 type avroDataRecord struct {
@@ -146,9 +174,26 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 		Default:  nil,
 		typ:      colDesc.Type,
 	}
+	if err := typeToAvroSchema(schema, colDesc.Name, &colDesc.Type); err != nil {
+		return nil, err
+	}
 
+	// Make every field optional by unioning it with null, so that all schema
+	// evolutions for a table are considered "backward compatible" by avro. This
+	// means that the Avro type doesn't mirror the column's nullability, but it
+	// makes it much easier to work with long histories of table data afterward,
+	// especially for things like loading into analytics databases.
+	makeAvroSchemaNullable(schema)
+
+	return schema, nil
+}
+
+// typeToAvroSchema sets the avro type of the given field schema, along with the
+// functions to encode and decode it, to the ones corresponding to the given SQL
+// type. The field is not made nullable.
+func typeToAvroSchema(schema *avroSchemaField, colName string, typ *types.T) error {
 	var avroType avroSchemaType
-	switch colDesc.Type.Family() {
+	switch typ.Family() {
 	case types.IntFamily:
 		avroType = avroSchemaLong
 		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
@@ -181,6 +226,17 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
 			return tree.NewDString(x.(string)), nil
 		}
+	case types.CollatedStringFamily:
+		// The locale is part of the column type, which is embedded in the
+		// schema as metadata, so only the contents are encoded.
+		avroType = avroSchemaString
+		var env tree.CollationEnvironment
+		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+			return d.(*tree.DCollatedString).Contents, nil
+		}
+		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.NewDCollatedString(x.(string), typ.Locale(), &env)
+		}
 	case types.BytesFamily:
 		avroType = avroSchemaBytes
 		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
@@ -198,7 +254,7 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 			date := *d.(*tree.DDate)
 			if !date.IsFinite() {
 				return nil, errors.Errorf(
-					`column %s: infinite date not yet supported with avro`, colDesc.Name)
+					`column %s: infinite date not yet supported with avro`, colName)
 			}
 			// The avro library requires us to return this as a time.Time.
 			return date.ToTime()
@@ -254,32 +310,55 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
 			return tree.MakeDTimestampTZ(x.(time.Time), time.Microsecond), nil
 		}
+	case types.IntervalFamily:
+		// Avro's duration logical type is a fixed of months, days and
+		// milliseconds, which would lose the microseconds of our intervals, so
+		// they're encoded in their string representation instead.
+		avroType = avroSchemaString
+		itm, err := typ.IntervalTypeMetadata()
+		if err != nil {
+			return err
+		}
+		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+			return d.(*tree.DInterval).Duration.String(), nil
+		}
+		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.ParseDIntervalWithTypeMetadata(x.(string), itm)
+		}
 	case types.DecimalFamily:
-		if colDesc.Type.Precision() == 0 {
-			return nil, errors.Errorf(
-				`column %s: decimal with no precision not yet supported with avro`, colDesc.Name)
+		if typ.Precision() == 0 {
+			// Without a fixed scale, the avro decimal logical type can't
+			// roundtrip our decimals, so they're encoded in their string
+			// representation instead.
+			avroType = avroSchemaString
+			schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+				return d.(*tree.DDecimal).Decimal.String(), nil
+			}
+			schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDDecimal(x.(string))
+			}
+			break
 		}
 		avroType = avroLogicalType{
 			SchemaType:  avroSchemaBytes,
 			LogicalType: `decimal`,
-			Precision:   int(colDesc.Type.Precision()),
-			Scale:       int(colDesc.Type.Width()),
+			Precision:   int(typ.Precision()),
+			Scale:       int(typ.Width()),
 		}
 		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
 			dec := d.(*tree.DDecimal).Decimal
 			// TODO(dan): For the cases that the avro defined decimal format
-			// would not roundtrip, serialize the decimal as a string. Also
-			// support the unspecified precision/scale case in this branch. We
-			// can't currently do this without surgery to the avro library we're
+			// would not roundtrip, serialize the decimal as a string. We can't
+			// currently do this without surgery to the avro library we're
 			// using and that's too scary leading up to 2.1.0.
-			rat, err := decimalToRat(dec, colDesc.Type.Width())
+			rat, err := decimalToRat(dec, typ.Width())
 			if err != nil {
 				return nil, err
 			}
 			return &rat, nil
 		}
 		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
-			return &tree.DDecimal{Decimal: ratToDecimal(*x.(*big.Rat), colDesc.Type.Width())}, nil
+			return &tree.DDecimal{Decimal: ratToDecimal(*x.(*big.Rat), typ.Width())}, nil
 		}
 	case types.UuidFamily:
 		// Should be logical type of "uuid", but the avro library doesn't support
@@ -307,53 +386,104 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
 			return tree.ParseDJSON(x.(string))
 		}
-	default:
-		return nil, errors.Errorf(`column %s: type %s not yet supported with avro`,
-			colDesc.Name, colDesc.Type.SQLString())
-	}
-	schema.SchemaType = avroType
-
-	// Make every field optional by unioning it with null, so that all schema
-	// evolutions for a table are considered "backward compatible" by avro. This
-	// means that the Avro type doesn't mirror the column's nullability, but it
-	// makes it much easier to work with long histories of table data afterward,
-	// especially for things like loading into analytics databases.
-	{
-		// The default for a union type is the default for the first element of
-		// the union.
-		schema.SchemaType = []avroSchemaType{avroSchemaNull, avroType}
-		encodeFn := schema.encodeFn
-		decodeFn := schema.decodeFn
-		unionKey := avroUnionKey(avroType)
+	case types.OidFamily:
+		avroType = avroSchemaLong
 		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
-			if d == tree.DNull {
-				return goavro.Union(avroSchemaNull, nil), nil
-			}
-			encoded, err := encodeFn(d)
-			if err != nil {
-				return nil, err
+			return int64(tree.MustBeDOid(d).DInt), nil
+		}
+		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.NewDOidWithName(tree.DInt(x.(int64)), typ, ``), nil
+		}
+	case types.BitFamily:
+		// Encoded as a string of 0s and 1s, which keeps the width of the bit
+		// array.
+		avroType = avroSchemaString
+		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+			return d.(*tree.DBitArray).BitArray.String(), nil
+		}
+		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.ParseDBitArray(x.(string))
+		}
+	case types.ArrayFamily:
+		// Array elements can be NULL, so the items of the avro array are
+		// optional, like the fields of a record.
+		itemSchema := &avroSchemaField{}
+		if err := typeToAvroSchema(itemSchema, colName, typ.ArrayContents()); err != nil {
+			return err
+		}
+		makeAvroSchemaNullable(itemSchema)
+		avroType = avroArrayType{
+			SchemaType: avroSchemaArray,
+			Items:      itemSchema.SchemaType,
+		}
+		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+			arr := d.(*tree.DArray).Array
+			items := make([]interface{}, len(arr))
+			for i, elem := range arr {
+				var err error
+				if items[i], err = itemSchema.encodeFn(elem); err != nil {
+					return nil, err
+				}
 			}
-			return goavro.Union(unionKey, encoded), nil
+			return items, nil
 		}
 		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
-			if x == nil {
-				return tree.DNull, nil
+			arr := tree.NewDArray(typ.ArrayContents())
+			for _, item := range x.([]interface{}) {
+				elem, err := itemSchema.decodeFn(item)
+				if err != nil {
+					return nil, err
+				}
+				if err := arr.Append(elem); err != nil {
+					return nil, err
+				}
 			}
-			return decodeFn(x.(map[string]interface{})[unionKey])
+			return arr, nil
 		}
+	default:
+		return errors.Errorf(`column %s: type %s not yet supported with avro`,
+			colName, typ.SQLString())
 	}
+	schema.SchemaType = avroType
+	return nil
+}
 
-	return schema, nil
+// makeAvroSchemaNullable unions the type of the given field schema with null.
+// The default for a union type is the default for the first element of the
+// union, so the field defaults to null.
+func makeAvroSchemaNullable(schema *avroSchemaField) {
+	avroType := schema.SchemaType
+	schema.SchemaType = []avroSchemaType{avroSchemaNull, avroType}
+	encodeFn := schema.encodeFn
+	decodeFn := schema.decodeFn
+	unionKey := avroUnionKey(avroType)
+	schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+		if d == tree.DNull {
+			return goavro.Union(avroSchemaNull, nil), nil
+		}
+		encoded, err := encodeFn(d)
+		if err != nil {
+			return nil, err
+		}
+		return goavro.Union(unionKey, encoded), nil
+	}
+	schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+		if x == nil {
+			return tree.DNull, nil
+		}
+		return decodeFn(x.(map[string]interface{})[unionKey])
+	}
 }
 
 // indexToAvroSchema converts a column descriptor into its corresponding avro
 // record schema. The fields are kept in the same order as columns in the index.
 func indexToAvroSchema(
-	tableDesc *sqlbase.TableDescriptor, indexDesc *sqlbase.IndexDescriptor,
+	tableDesc *sqlbase.TableDescriptor, indexDesc *sqlbase.IndexDescriptor, naming avroSchemaNaming,
 ) (*avroDataRecord, error) {
 	schema := &avroDataRecord{
 		avroRecord: avroRecord{
-			Name:       SQLNameToAvroName(tableDesc.Name),
+			Name:       naming.recordName(tableDesc.Name),
+			Namespace:  naming.namespace,
 			SchemaType: `record`,
 		},
 		fieldIdxByName:   make(map[string]int),
@@ -396,15 +526,16 @@ const (
 // If a name suffix is provided (as opposed to avroSchemaNoSuffix), it will be
 // appended to the end of the avro record's name.
 func tableToAvroSchema(
-	tableDesc *sqlbase.TableDescriptor, nameSuffix string,
+	tableDesc *sqlbase.TableDescriptor, nameSuffix string, naming avroSchemaNaming,
 ) (*avroDataRecord, error) {
-	name := SQLNameToAvroName(tableDesc.Name)
+	name := naming.recordName(tableDesc.Name)
 	if nameSuffix != avroSchemaNoSuffix {
 		name = name + `_` + nameSuffix
 	}
 	schema := &avroDataRecord{
 		avroRecord: avroRecord{
 			Name:       name,
+			Namespace:  naming.namespace,
 			SchemaType: `record`,
 		},
 		fieldIdxByName:   make(map[string]int),
@@ -513,11 +644,12 @@ func (r *avroDataRecord) rowFromNative(native interface{}) (sqlbase.EncDatumRow,
 // envelopeToAvroSchema creates an avro record schema for an envelope containing
 // before and after versions of a row change and metadata about that row change.
 func envelopeToAvroSchema(
-	topic string, opts avroEnvelopeOpts, before, after *avroDataRecord,
+	topic string, opts avroEnvelopeOpts, before, after *avroDataRecord, naming avroSchemaNaming,
 ) (*avroEnvelopeRecord, error) {
 	schema := &avroEnvelopeRecord{
 		avroRecord: avroRecord{
-			Name:       SQLNameToAvroName(topic) + `_envelope`,
+			Name:       naming.recordName(topic) + `_envelope`,
+			Namespace:  naming.namespace,
 			SchemaType: `record`,
 		},
 		opts: opts,
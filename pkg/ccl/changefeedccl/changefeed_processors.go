@@ -230,10 +230,13 @@ func (ca *changeAggregator) Start(ctx context.Context) context.Context {
 		WithDiff:         withDiff,
 	}
 	// The initial scan semantics are currently defined by whether this is the
-	// first run of a changefeed which did not specify a cursor.
+	// first run of a changefeed which did not opt out of an initial scan, either
+	// with the initial_scan option or by specifying a cursor.
 	kvfeedCfg.NeedsInitialScan = kvfeedCfg.InitialHighWater == (hlc.Timestamp{})
 	if kvfeedCfg.NeedsInitialScan {
 		kvfeedCfg.InitialHighWater = ca.spec.Feed.StatementTime
+		kvfeedCfg.InitialScanOnly =
+			initialScanTypeFromOpts(ca.spec.Feed.Opts) == changefeedbase.OptInitialScanOnly
 	}
 
 	rowsFn := kvsToRows(leaseMgr, ca.spec.Feed, buf.Get)
@@ -384,6 +387,9 @@ type changeFrontier struct {
 	lastEmitResolved time.Time
 	// lastSlowSpanLog is the last time a slow span from `sf` was logged.
 	lastSlowSpanLog time.Time
+	// initialScanOnly is set if the changefeed completes once `sf` reaches the
+	// statement time, i.e. after its initial scan.
+	initialScanOnly bool
 
 	// jobProgressedFn, if non-nil, is called to checkpoint the changefeed's
 	// progress in the corresponding system job entry.
@@ -455,6 +461,8 @@ func newChangeFrontierProcessor(
 		cf.freqEmitResolved = emitNoResolved
 	}
 
+	cf.initialScanOnly = initialScanTypeFromOpts(spec.Feed.Opts) == changefeedbase.OptInitialScanOnly
+
 	var err error
 	if cf.encoder, err = getEncoder(spec.Feed.Opts); err != nil {
 		return nil, err
@@ -569,6 +577,13 @@ func (cf *changeFrontier) Next() (sqlbase.EncDatumRow, *execinfrapb.ProducerMeta
 			return cf.resolvedBuf.Pop(), nil
 		}
 
+		if cf.initialScanOnly && cf.spec.Feed.StatementTime.LessEq(cf.sf.Frontier()) {
+			// The initial scan is done and everything it emitted has been
+			// returned, so the changefeed is complete.
+			cf.MoveToDraining(nil /* err */)
+			break
+		}
+
 		row, meta := cf.input.Next()
 		if meta != nil {
 			if meta.Err != nil {
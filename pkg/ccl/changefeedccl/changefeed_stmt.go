@@ -122,14 +122,12 @@ func changefeedPlanHook(
 		statementTime := hlc.Timestamp{
 			WallTime: p.ExtendedEvalContext().GetStmtTimestamp().UnixNano(),
 		}
-		var initialHighWater hlc.Timestamp
 		if cursor, ok := opts[changefeedbase.OptCursor]; ok {
 			asOf := tree.AsOfClause{Expr: tree.NewStrVal(cursor)}
 			var err error
-			if initialHighWater, err = p.EvalAsOfTimestamp(asOf); err != nil {
+			if statementTime, err = p.EvalAsOfTimestamp(asOf); err != nil {
 				return err
 			}
-			statementTime = initialHighWater
 		}
 
 		// For now, disallow targeting a database or wildcard table selection.
@@ -173,6 +171,7 @@ func changefeedPlanHook(
 			SinkURI:       sinkURI,
 			StatementTime: statementTime,
 		}
+		var initialHighWater hlc.Timestamp
 		progress := jobspb.Progress{
 			Progress: &jobspb.Progress_HighWater{HighWater: &initialHighWater},
 			Details: &jobspb.Progress_Changefeed{
@@ -212,6 +211,11 @@ func changefeedPlanHook(
 		if details, err = validateDetails(details); err != nil {
 			return err
 		}
+		// Without an initial scan, the changefeed starts from a high-water of the
+		// statement time (or cursor), so only changes after it are emitted.
+		if initialScanTypeFromOpts(details.Opts) == changefeedbase.OptInitialScanNo {
+			progress.Progress = &jobspb.Progress_HighWater{HighWater: &statementTime}
+		}
 
 		if _, err := getEncoder(details.Opts); err != nil {
 			return err
@@ -226,7 +230,8 @@ func changefeedPlanHook(
 			telemetrySink = `sinkless`
 		}
 		telemetry.Count(`changefeed.create.sink.` + telemetrySink)
-		telemetry.Count(`changefeed.create.format.` + details.Opts[changefeedbase.OptFormat])
+		telemetry.Count(`changefeed.create.format.` +
+			changefeedbase.UserFacingFormat(details.Opts[changefeedbase.OptFormat]))
 		telemetry.CountBucketed(`changefeed.create.num_tables`, int64(len(targets)))
 
 		if details.SinkURI == `` {
@@ -310,6 +315,9 @@ func changefeedJobDescription(
 	}
 	for k, v := range opts {
 		opt := tree.KVOption{Key: tree.Name(k)}
+		if k == changefeedbase.OptFormat {
+			v = changefeedbase.UserFacingFormat(v)
+		}
 		if len(v) > 0 {
 			opt.Value = tree.NewDString(v)
 		}
@@ -350,19 +358,59 @@ func validateDetails(details jobspb.ChangefeedDetails) (jobspb.ChangefeedDetails
 			`unknown %s: %s`, changefeedbase.OptEnvelope, details.Opts[changefeedbase.OptEnvelope])
 	}
 
+	if s, ok := details.Opts[changefeedbase.OptInitialScan]; ok {
+		switch changefeedbase.InitialScanType(s) {
+		case ``, changefeedbase.OptInitialScanYes:
+			details.Opts[changefeedbase.OptInitialScan] = string(changefeedbase.OptInitialScanYes)
+		case changefeedbase.OptInitialScanNo, changefeedbase.OptInitialScanOnly:
+			// No-op.
+		default:
+			return jobspb.ChangefeedDetails{}, errors.Errorf(
+				`unknown %s: %s`, changefeedbase.OptInitialScan, s)
+		}
+	}
+
 	switch changefeedbase.FormatType(details.Opts[changefeedbase.OptFormat]) {
 	case ``, changefeedbase.OptFormatJSON:
 		details.Opts[changefeedbase.OptFormat] = string(changefeedbase.OptFormatJSON)
-	case changefeedbase.OptFormatAvro:
-		// No-op.
+	case changefeedbase.OptFormatAvro, changefeedbase.OptFormatDeprecatedAvro:
+		// Nodes running older versions only understand the experimental name, so
+		// keep persisting that one in the job details.
+		details.Opts[changefeedbase.OptFormat] = string(changefeedbase.OptFormatDeprecatedAvro)
 	default:
 		return jobspb.ChangefeedDetails{}, errors.Errorf(
 			`unknown %s: %s`, changefeedbase.OptFormat, details.Opts[changefeedbase.OptFormat])
 	}
+	if details.Opts[changefeedbase.OptFormat] != string(changefeedbase.OptFormatDeprecatedAvro) {
+		for _, opt := range []string{
+			changefeedbase.OptAvroSchemaPrefix, changefeedbase.OptAvroSchemaNamespace,
+		} {
+			if _, ok := details.Opts[opt]; ok {
+				return jobspb.ChangefeedDetails{}, errors.Errorf(`%s is only usable with %s=%s`,
+					opt, changefeedbase.OptFormat, changefeedbase.OptFormatAvro)
+			}
+		}
+	}
 
 	return details, nil
 }
 
+// initialScanTypeFromOpts returns whether a changefeed starts with a scan of
+// its targets. Unless specified with the initial_scan option, there is an
+// initial scan only if the changefeed has no cursor.
+func initialScanTypeFromOpts(opts map[string]string) changefeedbase.InitialScanType {
+	if s, ok := opts[changefeedbase.OptInitialScan]; ok {
+		if s == `` {
+			return changefeedbase.OptInitialScanYes
+		}
+		return changefeedbase.InitialScanType(s)
+	}
+	if _, ok := opts[changefeedbase.OptCursor]; ok {
+		return changefeedbase.OptInitialScanNo
+	}
+	return changefeedbase.OptInitialScanYes
+}
+
 func validateChangefeedTable(
 	targets jobspb.ChangefeedTargets, tableDesc *sqlbase.TableDescriptor,
 ) error {
@@ -455,10 +503,17 @@ func (b *changefeedResumer) Resume(
 	// progress high-water when creating a job (currently only the progress
 	// details can be set). I didn't want to pick off the refactor to get this
 	// fix in, but it'd be nice to remove this hack.
-	if _, ok := details.Opts[changefeedbase.OptCursor]; ok {
+	switch initialScanTypeFromOpts(details.Opts) {
+	case changefeedbase.OptInitialScanNo:
 		if h := progress.GetHighWater(); h == nil || *h == (hlc.Timestamp{}) {
 			progress.Progress = &jobspb.Progress_HighWater{HighWater: &details.StatementTime}
 		}
+	case changefeedbase.OptInitialScanOnly:
+		// The high-water reaches the statement time once the initial scan is
+		// done, at which point the changefeed is complete.
+		if h := progress.GetHighWater(); h != nil && details.StatementTime.LessEq(*h) {
+			return nil
+		}
 	}
 
 	// We'd like to avoid failing a changefeed unnecessarily, so when an error
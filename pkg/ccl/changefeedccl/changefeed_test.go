@@ -264,6 +264,69 @@ func TestChangefeedCursor(t *testing.T) {
 	t.Run(`enterprise`, enterpriseTest(testFn))
 }
 
+func TestChangefeedInitialScan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'before')`)
+		var tsLogical string
+		sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&tsLogical)
+
+		// Without an initial scan, only the changes after the statement time are
+		// emitted, even without a cursor.
+		noScan := feed(t, f, `CREATE CHANGEFEED FOR foo WITH initial_scan='no'`)
+		defer closeFeed(t, noScan)
+		// With a cursor, the initial scan is at the cursor.
+		cursorScan := feed(t, f, `CREATE CHANGEFEED FOR foo WITH cursor=$1, initial_scan`, tsLogical)
+		defer closeFeed(t, cursorScan)
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'after')`)
+		assertPayloads(t, noScan, []string{
+			`foo: [2]->{"after": {"a": 2, "b": "after"}}`,
+		})
+		assertPayloads(t, cursorScan, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "before"}}`,
+			`foo: [2]->{"after": {"a": 2, "b": "after"}}`,
+		})
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+}
+
+func TestChangefeedInitialScanOnly(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a'), (2, 'b')`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH initial_scan='only'`)
+		defer closeFeed(t, foo)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (3, 'c')`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "a"}}`,
+			`foo: [2]->{"after": {"a": 2, "b": "b"}}`,
+		})
+
+		// The job completes after the initial scan.
+		jobFeed := foo.(*cdctest.TableFeed)
+		testutils.SucceedsSoon(t, func() error {
+			var status string
+			sqlDB.QueryRow(t, `SELECT status FROM system.jobs WHERE id = $1`, jobFeed.JobID).Scan(&status)
+			if jobs.Status(status) != jobs.StatusSucceeded {
+				return errors.Errorf(`expected job to succeed, got status %s`, status)
+			}
+			return nil
+		})
+	}
+
+	t.Run(`enterprise`, enterpriseTest(testFn))
+}
+
 func TestChangefeedTimestamps(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -1493,6 +1556,10 @@ func TestChangefeedErrors(t *testing.T) {
 		t, `unknown envelope: nope`,
 		`EXPERIMENTAL CHANGEFEED FOR foo WITH envelope=nope`,
 	)
+	sqlDB.ExpectErr(
+		t, `unknown initial_scan: nope`,
+		`EXPERIMENTAL CHANGEFEED FOR foo WITH initial_scan=nope`,
+	)
 	sqlDB.ExpectErr(
 		t, `negative durations are not accepted: resolved='-1s'`,
 		`EXPERIMENTAL CHANGEFEED FOR foo WITH resolved='-1s'`,
@@ -1544,21 +1611,17 @@ func TestChangefeedErrors(t *testing.T) {
 		`EXPERIMENTAL CHANGEFEED FOR information_schema.tables`,
 	)
 
-	// TODO(dan): These two tests shouldn't need initial data in the table
-	// to pass.
-	sqlDB.Exec(t, `CREATE TABLE dec (a DECIMAL PRIMARY KEY)`)
-	sqlDB.Exec(t, `INSERT INTO dec VALUES (1.0)`)
+	// Check that the avro schema options are only accepted with avro, and that
+	// the namespace is validated.
 	sqlDB.ExpectErr(
-		t, `pq: column a: decimal with no precision`,
-		`EXPERIMENTAL CHANGEFEED FOR dec WITH format=$1, confluent_schema_registry=$2`,
-		changefeedbase.OptFormatAvro, `bar`,
+		t, `avro_schema_prefix is only usable with format=avro`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH avro_schema_prefix='crdb_'`, `kafka://nope`,
 	)
-	sqlDB.Exec(t, `CREATE TABLE "oid" (a OID PRIMARY KEY)`)
-	sqlDB.Exec(t, `INSERT INTO "oid" VALUES (3::OID)`)
 	sqlDB.ExpectErr(
-		t, `pq: column a: type OID not yet supported with avro`,
-		`EXPERIMENTAL CHANGEFEED FOR "oid" WITH format=$1, confluent_schema_registry=$2`,
-		changefeedbase.OptFormatAvro, `bar`,
+		t, `avro_schema_namespace must be a dot-separated sequence of avro names: not-a.namespace`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH format=$2, confluent_schema_registry=$3, `+
+			`avro_schema_namespace='not-a.namespace'`,
+		`kafka://nope`, changefeedbase.OptFormatAvro, `bar`,
 	)
 
 	// Check that confluent_schema_registry is only accepted if format is avro.
@@ -1654,17 +1717,18 @@ func TestChangefeedErrors(t *testing.T) {
 		`CREATE CHANGEFEED FOR foo INTO $1`, `kafka://nope/?sasl_password=a`,
 	)
 
-	// The avro format doesn't support key_in_value yet.
+	// The avro format doesn't support key_in_value yet. This also checks that
+	// experimental_avro is still accepted as an alias of avro.
 	sqlDB.ExpectErr(
-		t, `key_in_value is not supported with format=experimental_avro`,
+		t, `key_in_value is not supported with format=avro`,
 		`CREATE CHANGEFEED FOR foo INTO $1 WITH key_in_value, format='experimental_avro'`,
 		`kafka://nope`,
 	)
 
 	// The cloudStorageSink is particular about the options it will work with.
 	sqlDB.ExpectErr(
-		t, `this sink is incompatible with format=experimental_avro`,
-		`CREATE CHANGEFEED FOR foo INTO $1 WITH format='experimental_avro', confluent_schema_registry=$2`,
+		t, `this sink is incompatible with format=avro`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH format='avro', confluent_schema_registry=$2`,
 		`experimental-nodelocal:///bar`, `schemareg-nope`,
 	)
 	sqlDB.ExpectErr(
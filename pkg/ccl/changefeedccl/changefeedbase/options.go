@@ -16,6 +16,10 @@ type EnvelopeType string
 // FormatType configures the encoding format.
 type FormatType string
 
+// InitialScanType configures whether a changefeed starts with a scan of its
+// targets.
+type InitialScanType string
+
 // Constants for the options.
 const (
 	OptConfluentSchemaRegistry = `confluent_schema_registry`
@@ -27,6 +31,9 @@ const (
 	OptUpdatedTimestamps       = `updated`
 	OptDiff                    = `diff`
 	OptCompression             = `compression`
+	OptInitialScan             = `initial_scan`
+	OptAvroSchemaPrefix        = `avro_schema_prefix`
+	OptAvroSchemaNamespace     = `avro_schema_namespace`
 
 	OptEnvelopeKeyOnly       EnvelopeType = `key_only`
 	OptEnvelopeRow           EnvelopeType = `row`
@@ -34,7 +41,20 @@ const (
 	OptEnvelopeWrapped       EnvelopeType = `wrapped`
 
 	OptFormatJSON FormatType = `json`
-	OptFormatAvro FormatType = `experimental_avro`
+	OptFormatAvro FormatType = `avro`
+	// OptFormatDeprecatedAvro is the name avro was introduced under while it
+	// was experimental. It's still accepted as an alias of OptFormatAvro.
+	OptFormatDeprecatedAvro FormatType = `experimental_avro`
+
+	// OptInitialScanYes emits the targets as of the statement time (or cursor)
+	// before emitting changes. It's the default without a cursor.
+	OptInitialScanYes InitialScanType = `yes`
+	// OptInitialScanNo only emits changes after the statement time (or cursor).
+	// It's the default with a cursor.
+	OptInitialScanNo InitialScanType = `no`
+	// OptInitialScanOnly emits the targets as of the statement time (or
+	// cursor), after which the changefeed completes.
+	OptInitialScanOnly InitialScanType = `only`
 
 	SinkParamCACert           = `ca_cert`
 	SinkParamClientCert       = `client_cert`
@@ -52,6 +72,16 @@ const (
 	SinkParamSASLPassword     = `sasl_password`
 )
 
+// UserFacingFormat returns the name users know the given format by. Avro is
+// persisted in the job details under its experimental name, which nodes
+// running older versions understand, but users are shown its current name.
+func UserFacingFormat(format string) string {
+	if FormatType(format) == OptFormatDeprecatedAvro {
+		return string(OptFormatAvro)
+	}
+	return format
+}
+
 // ChangefeedOptionExpectValues is used to parse changefeed options using
 // PlanHookState.TypeAsStringOpts().
 var ChangefeedOptionExpectValues = map[string]sql.KVStringOptValidate{
@@ -64,4 +94,7 @@ var ChangefeedOptionExpectValues = map[string]sql.KVStringOptValidate{
 	OptUpdatedTimestamps:       sql.KVStringOptRequireNoValue,
 	OptDiff:                    sql.KVStringOptRequireNoValue,
 	OptCompression:             sql.KVStringOptRequireValue,
+	OptInitialScan:             sql.KVStringOptAny,
+	OptAvroSchemaPrefix:        sql.KVStringOptRequireValue,
+	OptAvroSchemaNamespace:     sql.KVStringOptRequireValue,
 }
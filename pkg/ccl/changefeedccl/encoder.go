@@ -14,8 +14,10 @@ import (
 	"encoding/binary"
 	gojson "encoding/json"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"path/filepath"
+	"regexp"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
@@ -35,6 +37,9 @@ const (
 	confluentAvroWireFormatMagic = byte(0)
 )
 
+// avroNamespaceRE matches the avro namespaces allowed by the spec.
+var avroNamespaceRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
 // encodeRow holds all the pieces necessary to encode a row change into a key or
 // value.
 type encodeRow struct {
@@ -82,7 +87,7 @@ func getEncoder(opts map[string]string) (Encoder, error) {
 	switch changefeedbase.FormatType(opts[changefeedbase.OptFormat]) {
 	case ``, changefeedbase.OptFormatJSON:
 		return makeJSONEncoder(opts)
-	case changefeedbase.OptFormatAvro:
+	case changefeedbase.OptFormatAvro, changefeedbase.OptFormatDeprecatedAvro:
 		return newConfluentAvroEncoder(opts)
 	default:
 		return nil, errors.Errorf(`unknown %s: %s`, changefeedbase.OptFormat, opts[changefeedbase.OptFormat])
@@ -267,6 +272,7 @@ func (e *jsonEncoder) EncodeResolvedTimestamp(
 // columns in a record.
 type confluentAvroEncoder struct {
 	registryURL                        string
+	naming                             avroSchemaNaming
 	updatedField, beforeField, keyOnly bool
 
 	keyCache      map[tableIDAndVersion]confluentRegisteredKeySchema
@@ -325,6 +331,13 @@ func newConfluentAvroEncoder(opts map[string]string) (*confluentAvroEncoder, err
 			changefeedbase.OptConfluentSchemaRegistry, changefeedbase.OptFormat, changefeedbase.OptFormatAvro)
 	}
 
+	e.naming.prefix = opts[changefeedbase.OptAvroSchemaPrefix]
+	e.naming.namespace = opts[changefeedbase.OptAvroSchemaNamespace]
+	if e.naming.namespace != `` && !avroNamespaceRE.MatchString(e.naming.namespace) {
+		return nil, errors.Errorf(`%s must be a dot-separated sequence of avro names: %s`,
+			changefeedbase.OptAvroSchemaNamespace, e.naming.namespace)
+	}
+
 	e.keyCache = make(map[tableIDAndVersion]confluentRegisteredKeySchema)
 	e.valueCache = make(map[tableIDAndVersionPair]confluentRegisteredEnvelopeSchema)
 	e.resolvedCache = make(map[string]confluentRegisteredEnvelopeSchema)
@@ -337,14 +350,14 @@ func (e *confluentAvroEncoder) EncodeKey(ctx context.Context, row encodeRow) ([]
 	registered, ok := e.keyCache[cacheKey]
 	if !ok {
 		var err error
-		registered.schema, err = indexToAvroSchema(row.tableDesc, &row.tableDesc.PrimaryIndex)
+		registered.schema, err = indexToAvroSchema(row.tableDesc, &row.tableDesc.PrimaryIndex, e.naming)
 		if err != nil {
 			return nil, err
 		}
 
 		// NB: This uses the kafka name escaper because it has to match the name
 		// of the kafka topic.
-		subject := SQLNameToKafkaName(row.tableDesc.Name) + confluentSubjectSuffixKey
+		subject := SQLNameToKafkaName(e.naming.prefix+row.tableDesc.Name) + confluentSubjectSuffixKey
 		registered.registryID, err = e.register(ctx, &registered.schema.avroRecord, subject)
 		if err != nil {
 			return nil, err
@@ -378,26 +391,27 @@ func (e *confluentAvroEncoder) EncodeValue(ctx context.Context, row encodeRow) (
 		var beforeDataSchema *avroDataRecord
 		if e.beforeField && row.prevTableDesc != nil {
 			var err error
-			beforeDataSchema, err = tableToAvroSchema(row.prevTableDesc, `before`)
+			beforeDataSchema, err = tableToAvroSchema(row.prevTableDesc, `before`, e.naming)
 			if err != nil {
 				return nil, err
 			}
 		}
 
-		afterDataSchema, err := tableToAvroSchema(row.tableDesc, avroSchemaNoSuffix)
+		afterDataSchema, err := tableToAvroSchema(row.tableDesc, avroSchemaNoSuffix, e.naming)
 		if err != nil {
 			return nil, err
 		}
 
 		opts := avroEnvelopeOpts{afterField: true, beforeField: e.beforeField, updatedField: e.updatedField}
-		registered.schema, err = envelopeToAvroSchema(row.tableDesc.Name, opts, beforeDataSchema, afterDataSchema)
+		registered.schema, err = envelopeToAvroSchema(
+			row.tableDesc.Name, opts, beforeDataSchema, afterDataSchema, e.naming)
 		if err != nil {
 			return nil, err
 		}
 
 		// NB: This uses the kafka name escaper because it has to match the name
 		// of the kafka topic.
-		subject := SQLNameToKafkaName(row.tableDesc.Name) + confluentSubjectSuffixValue
+		subject := SQLNameToKafkaName(e.naming.prefix+row.tableDesc.Name) + confluentSubjectSuffixValue
 		registered.registryID, err = e.register(ctx, &registered.schema.avroRecord, subject)
 		if err != nil {
 			return nil, err
@@ -435,14 +449,14 @@ func (e *confluentAvroEncoder) EncodeResolvedTimestamp(
 	if !ok {
 		opts := avroEnvelopeOpts{resolvedField: true}
 		var err error
-		registered.schema, err = envelopeToAvroSchema(topic, opts, nil /* before */, nil /* after */)
+		registered.schema, err = envelopeToAvroSchema(topic, opts, nil /* before */, nil /* after */, e.naming)
 		if err != nil {
 			return nil, err
 		}
 
 		// NB: This uses the kafka name escaper because it has to match the name
 		// of the kafka topic.
-		subject := SQLNameToKafkaName(topic) + confluentSubjectSuffixValue
+		subject := SQLNameToKafkaName(e.naming.prefix+topic) + confluentSubjectSuffixValue
 		registered.registryID, err = e.register(ctx, &registered.schema.avroRecord, subject)
 		if err != nil {
 			return nil, err
@@ -482,8 +496,11 @@ func (e *confluentAvroEncoder) register(
 	url.Path = filepath.Join(url.EscapedPath(), `subjects`, subject, `versions`)
 
 	schemaStr := schema.codec.Schema()
+	if err := e.checkCompatibility(ctx, schemaStr, subject); err != nil {
+		return 0, err
+	}
 	if log.V(1) {
-		log.Infof(context.TODO(), "registering avro schema %s %s", url, schemaStr)
+		log.Infof(ctx, "registering avro schema %s %s", url, schemaStr)
 	}
 
 	req := confluentSchemaVersionRequest{Schema: schemaStr}
@@ -510,3 +527,56 @@ func (e *confluentAvroEncoder) register(
 
 	return res.ID, nil
 }
+
+// checkCompatibility checks the given schema against the latest version
+// registered for the subject, according to the compatibility mode configured
+// in the schema registry. This surfaces schema changes that downstream
+// consumers can't handle (say, a column type change in a registry configured
+// for FULL compatibility) before any rows are emitted with the new schema.
+func (e *confluentAvroEncoder) checkCompatibility(
+	ctx context.Context, schemaStr string, subject string,
+) error {
+	type confluentCompatibilityRequest struct {
+		Schema string `json:"schema"`
+	}
+	type confluentCompatibilityResponse struct {
+		IsCompatible bool `json:"is_compatible"`
+	}
+
+	url, err := url.Parse(e.registryURL)
+	if err != nil {
+		return err
+	}
+	url.Path = filepath.Join(
+		url.EscapedPath(), `compatibility`, `subjects`, subject, `versions`, `latest`)
+
+	req := confluentCompatibilityRequest{Schema: schemaStr}
+	var buf bytes.Buffer
+	if err := gojson.NewEncoder(&buf).Encode(req); err != nil {
+		return err
+	}
+	resp, err := httputil.Post(ctx, url.String(), confluentSchemaContentType, &buf)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		// Nothing is registered for the subject yet, so any schema is
+		// compatible.
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf(`checking schema compatibility with %s %s: %s`, url.String(), resp.Status, body)
+	}
+	var res confluentCompatibilityResponse
+	if err := gojson.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return err
+	}
+	if !res.IsCompatible {
+		return errors.Errorf(`avro schema for subject %s is incompatible with the latest version `+
+			`registered in the schema registry at %s according to its compatibility mode`,
+			subject, e.registryURL)
+	}
+	return nil
+}
@@ -16,6 +16,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/cockroach-go/crdb"
@@ -119,46 +121,46 @@ func TestEncoders(t *testing.T) {
 			delete:   `[1]->{"after": null, "before": {"a": 1, "b": "bar"}, "updated": "1.0000000002"}`,
 			resolved: `{"resolved":"1.0000000002"}`,
 		},
-		`format=experimental_avro,envelope=key_only`: {
+		`format=avro,envelope=key_only`: {
 			insert:   `{"a":{"long":1}}->`,
 			delete:   `{"a":{"long":1}}->`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
-		`format=experimental_avro,envelope=key_only,updated`: {
+		`format=avro,envelope=key_only,updated`: {
 			err: `updated is only usable with envelope=wrapped`,
 		},
-		`format=experimental_avro,envelope=key_only,diff`: {
+		`format=avro,envelope=key_only,diff`: {
 			err: `diff is only usable with envelope=wrapped`,
 		},
-		`format=experimental_avro,envelope=key_only,updated,diff`: {
+		`format=avro,envelope=key_only,updated,diff`: {
 			err: `updated is only usable with envelope=wrapped`,
 		},
-		`format=experimental_avro,envelope=row`: {
-			err: `envelope=row is not supported with format=experimental_avro`,
+		`format=avro,envelope=row`: {
+			err: `envelope=row is not supported with format=avro`,
 		},
-		`format=experimental_avro,envelope=row,updated`: {
-			err: `envelope=row is not supported with format=experimental_avro`,
+		`format=avro,envelope=row,updated`: {
+			err: `envelope=row is not supported with format=avro`,
 		},
-		`format=experimental_avro,envelope=row,diff`: {
-			err: `envelope=row is not supported with format=experimental_avro`,
+		`format=avro,envelope=row,diff`: {
+			err: `envelope=row is not supported with format=avro`,
 		},
-		`format=experimental_avro,envelope=row,updated,diff`: {
-			err: `envelope=row is not supported with format=experimental_avro`,
+		`format=avro,envelope=row,updated,diff`: {
+			err: `envelope=row is not supported with format=avro`,
 		},
-		`format=experimental_avro,envelope=wrapped`: {
+		`format=avro,envelope=wrapped`: {
 			insert: `{"a":{"long":1}}->` +
 				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}}}`,
 			delete:   `{"a":{"long":1}}->{"after":null}`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
-		`format=experimental_avro,envelope=wrapped,updated`: {
+		`format=avro,envelope=wrapped,updated`: {
 			insert: `{"a":{"long":1}}->` +
 				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},` +
 				`"updated":{"string":"1.0000000002"}}`,
 			delete:   `{"a":{"long":1}}->{"after":null,"updated":{"string":"1.0000000002"}}`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
-		`format=experimental_avro,envelope=wrapped,diff`: {
+		`format=avro,envelope=wrapped,diff`: {
 			insert: `{"a":{"long":1}}->` +
 				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},` +
 				`"before":null}`,
@@ -167,7 +169,7 @@ func TestEncoders(t *testing.T) {
 				`"before":{"foo_before":{"a":{"long":1},"b":{"string":"bar"}}}}`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
-		`format=experimental_avro,envelope=wrapped,updated,diff`: {
+		`format=avro,envelope=wrapped,updated,diff`: {
 			insert: `{"a":{"long":1}}->` +
 				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},` +
 				`"before":null,` +
@@ -261,13 +263,22 @@ type testSchemaRegistry struct {
 		syncutil.Mutex
 		idAlloc int32
 		schemas map[int32]string
+		// latest is the latest schema registered for each subject.
+		latest map[string]string
+		// incompatible, if set, makes every schema incompatible with the
+		// latest version registered for its subject, unless they're the same.
+		incompatible bool
 	}
 }
 
 func makeTestSchemaRegistry() *testSchemaRegistry {
 	r := &testSchemaRegistry{}
 	r.mu.schemas = make(map[int32]string)
-	r.server = httptest.NewServer(http.HandlerFunc(r.Register))
+	r.mu.latest = make(map[string]string)
+	mux := http.NewServeMux()
+	mux.HandleFunc(`/subjects/`, r.Register)
+	mux.HandleFunc(`/compatibility/subjects/`, r.Compatibility)
+	r.server = httptest.NewServer(mux)
 	return r
 }
 
@@ -275,6 +286,27 @@ func (r *testSchemaRegistry) Close() {
 	r.server.Close()
 }
 
+// setIncompatible configures whether the registry considers every new schema
+// incompatible with the latest version registered for its subject.
+func (r *testSchemaRegistry) setIncompatible(incompatible bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.incompatible = incompatible
+}
+
+// registeredSubjects returns the sorted subjects with a registered schema.
+func (r *testSchemaRegistry) registeredSubjects() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subjects []string
+	for subject := range r.mu.latest {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+	return subjects
+}
+
+// Register handles requests to /subjects/{subject}/versions.
 func (r *testSchemaRegistry) Register(hw http.ResponseWriter, hr *http.Request) {
 	type confluentSchemaVersionRequest struct {
 		Schema string `json:"schema"`
@@ -288,11 +320,13 @@ func (r *testSchemaRegistry) Register(hw http.ResponseWriter, hr *http.Request)
 		if err := gojson.NewDecoder(hr.Body).Decode(&req); err != nil {
 			return err
 		}
+		subject := strings.Split(strings.TrimPrefix(hr.URL.Path, `/subjects/`), `/`)[0]
 
 		r.mu.Lock()
 		id := r.mu.idAlloc
 		r.mu.idAlloc++
 		r.mu.schemas[id] = req.Schema
+		r.mu.latest[subject] = req.Schema
 		r.mu.Unlock()
 
 		res, err := gojson.Marshal(confluentSchemaVersionResponse{ID: id})
@@ -308,6 +342,41 @@ func (r *testSchemaRegistry) Register(hw http.ResponseWriter, hr *http.Request)
 	}
 }
 
+// Compatibility handles requests to
+// /compatibility/subjects/{subject}/versions/latest.
+func (r *testSchemaRegistry) Compatibility(hw http.ResponseWriter, hr *http.Request) {
+	type confluentCompatibilityRequest struct {
+		Schema string `json:"schema"`
+	}
+	type confluentCompatibilityResponse struct {
+		IsCompatible bool `json:"is_compatible"`
+	}
+	defer hr.Body.Close()
+	var req confluentCompatibilityRequest
+	if err := gojson.NewDecoder(hr.Body).Decode(&req); err != nil {
+		http.Error(hw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	subject := strings.Split(strings.TrimPrefix(hr.URL.Path, `/compatibility/subjects/`), `/`)[0]
+
+	r.mu.Lock()
+	latest, registered := r.mu.latest[subject]
+	compatible := !r.mu.incompatible || req.Schema == latest
+	r.mu.Unlock()
+	if !registered {
+		http.Error(hw, `subject not found`, http.StatusNotFound)
+		return
+	}
+
+	res, err := gojson.Marshal(confluentCompatibilityResponse{IsCompatible: compatible})
+	if err != nil {
+		http.Error(hw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	hw.Header().Set(`Content-type`, `application/json`)
+	_, _ = hw.Write(res)
+}
+
 func (r *testSchemaRegistry) encodedAvroToNative(b []byte) (interface{}, error) {
 	if len(b) == 0 || b[0] != confluentAvroWireFormatMagic {
 		return ``, errors.Errorf(`bad magic byte`)
@@ -385,7 +454,7 @@ func TestAvroEncoder(t *testing.T) {
 	t.Run(`enterprise`, enterpriseTest(testFn))
 }
 
-func TestAvroMigrateToUnsupportedColumn(t *testing.T) {
+func TestAvroMigrateToIncompatibleSchema(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
 	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
@@ -404,10 +473,12 @@ func TestAvroMigrateToUnsupportedColumn(t *testing.T) {
 			`foo: {"a":{"long":1}}->{"after":{"foo":{"a":{"long":1}}}}`,
 		})
 
-		sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN b OID`)
-		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 3::OID)`)
-		if _, err := foo.Next(); !testutils.IsError(err, `type OID not yet supported with avro`) {
-			t.Fatalf(`expected "type OID not yet supported with avro" error got: %+v`, err)
+		// Once the registry rejects the new schema, no rows are emitted with it.
+		reg.setIncompatible(true)
+		sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN b INT[]`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, ARRAY[3])`)
+		if _, err := foo.Next(); !testutils.IsError(err, `avro schema for subject foo-value is incompatible`) {
+			t.Fatalf(`expected "avro schema for subject foo-value is incompatible" error got: %+v`, err)
 		}
 	}
 
@@ -415,6 +486,33 @@ func TestAvroMigrateToUnsupportedColumn(t *testing.T) {
 	t.Run(`enterprise`, enterpriseTest(testFn))
 }
 
+func TestAvroSchemaNaming(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		reg := makeTestSchemaRegistry()
+		defer reg.Close()
+
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b INTERVAL, c STRING[])`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, '1 day 02:03:04', ARRAY['x', NULL])`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo `+
+			`WITH format=$1, confluent_schema_registry=$2, `+
+			`avro_schema_prefix='crdb_', avro_schema_namespace='com.example'`,
+			changefeedbase.OptFormatAvro, reg.server.URL)
+		defer closeFeed(t, foo)
+		assertPayloadsAvro(t, reg, foo, []string{
+			`foo: {"a":{"long":1}}->{"after":{"com.example.crdb_foo":{"a":{"long":1},` +
+				`"b":{"string":"1 day 02:03:04"},"c":{"array":[{"string":"x"},null]}}}}`,
+		})
+		require.Equal(t, []string{`crdb_foo-key`, `crdb_foo-value`}, reg.registeredSubjects())
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+}
+
 func TestAvroLedger(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
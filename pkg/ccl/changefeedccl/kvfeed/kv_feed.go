@@ -53,6 +53,11 @@ type Config struct {
 	// InitialHighWater is a point in time at which all data is known to have
 	// been seen.
 	NeedsInitialScan bool
+
+	// If true, the feed stops after the initial scan, without running
+	// rangefeeds. It's the responsibility of the consumer of the feed to stop it
+	// once it has seen the resolved timestamps of the initial scan.
+	InitialScanOnly bool
 }
 
 // Run will run the kvfeed. The feed runs synchronously and returns an
@@ -85,7 +90,7 @@ func Run(ctx context.Context, cfg Config) error {
 		return makeMemBuffer(cfg.MM.MakeBoundAccount(), cfg.Metrics)
 	}
 	f := newKVFeed(
-		cfg.Sink, cfg.Spans, cfg.NeedsInitialScan, cfg.WithDiff, cfg.InitialHighWater,
+		cfg.Sink, cfg.Spans, cfg.NeedsInitialScan, cfg.InitialScanOnly, cfg.WithDiff, cfg.InitialHighWater,
 		sf, sc, pff, bf)
 	g.GoCtx(f.run)
 	return g.Wait()
@@ -99,6 +104,7 @@ type schemaFeed interface {
 type kvFeed struct {
 	spans            []roachpb.Span
 	needsInitialScan bool
+	initialScanOnly  bool
 	withDiff         bool
 	initialHighWater hlc.Timestamp
 	sink             EventBufferWriter
@@ -113,7 +119,7 @@ type kvFeed struct {
 func newKVFeed(
 	sink EventBufferWriter,
 	spans []roachpb.Span,
-	needsInitialScan, withDiff bool,
+	needsInitialScan, initialScanOnly, withDiff bool,
 	initialHighWater hlc.Timestamp,
 	tf schemaFeed,
 	sc kvScanner,
@@ -124,6 +130,7 @@ func newKVFeed(
 		sink:             sink,
 		spans:            spans,
 		needsInitialScan: needsInitialScan,
+		initialScanOnly:  initialScanOnly,
 		withDiff:         withDiff,
 		initialHighWater: initialHighWater,
 		tableFeed:        tf,
@@ -142,6 +149,12 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 		if err = f.scanIfShould(ctx, initialScan, highWater); err != nil {
 			return err
 		}
+		if initialScan && f.initialScanOnly {
+			// The scan emitted resolved timestamps for all the spans, so there is
+			// nothing left to do but wait for the consumer to stop the feed.
+			<-ctx.Done()
+			return ctx.Err()
+		}
 		highWater, err = f.runUntilTableEvent(ctx, highWater)
 		if err != nil {
 			return err
@@ -12,6 +12,7 @@ import (
 	"context"
 	"math"
 	"sort"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -73,6 +74,7 @@ func TestKVFeed(t *testing.T) {
 	type testCase struct {
 		name             string
 		needsInitialScan bool
+		initialScanOnly  bool
 		withDiff         bool
 		initialHighWater hlc.Timestamp
 		spans            []roachpb.Span
@@ -80,8 +82,9 @@ func TestKVFeed(t *testing.T) {
 
 		descs []*sqlbase.TableDescriptor
 
-		expScans  []hlc.Timestamp
-		expEvents int
+		expScans      []hlc.Timestamp
+		expEvents     int
+		expRangefeeds bool
 	}
 	runTest := func(t *testing.T, tc testCase) {
 		settings := cluster.MakeTestingClusterSettings()
@@ -104,9 +107,20 @@ func TestKVFeed(t *testing.T) {
 			}
 		})
 		ref := rawEventFeed(tc.events)
+		var rangefeeds int32
+		pff := rangefeedFactory(func(
+			ctx context.Context,
+			span roachpb.Span,
+			startFrom hlc.Timestamp,
+			withDiff bool,
+			eventC chan<- *roachpb.RangeFeedEvent,
+		) error {
+			atomic.AddInt32(&rangefeeds, 1)
+			return ref.run(ctx, span, startFrom, withDiff, eventC)
+		})
 		tf := newRawTableFeed(tc.descs, tc.initialHighWater)
-		f := newKVFeed(buf, tc.spans, tc.needsInitialScan, tc.withDiff, tc.initialHighWater,
-			&tf, sf, rangefeedFactory(ref.run), bufferFactory)
+		f := newKVFeed(buf, tc.spans, tc.needsInitialScan, tc.initialScanOnly, tc.withDiff,
+			tc.initialHighWater, &tf, sf, pff, bufferFactory)
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 		g := ctxgroup.WithContext(ctx)
@@ -130,6 +144,7 @@ func TestKVFeed(t *testing.T) {
 		require.NoError(t, testG.Wait())
 		cancel()
 		require.Equal(t, context.Canceled, g.Wait())
+		require.Equal(t, tc.expRangefeeds, atomic.LoadInt32(&rangefeeds) > 0)
 	}
 	for _, tc := range []testCase{
 		{
@@ -145,7 +160,8 @@ func TestKVFeed(t *testing.T) {
 			expScans: []hlc.Timestamp{
 				ts(2),
 			},
-			expEvents: 1,
+			expEvents:     1,
+			expRangefeeds: true,
 		},
 		{
 			name:             "one table event",
@@ -169,7 +185,23 @@ func TestKVFeed(t *testing.T) {
 				makeTableDesc(42, 1, ts(1), 2),
 				addColumnDropBackfillMutation(makeTableDesc(42, 2, ts(3), 1)),
 			},
-			expEvents: 2,
+			expEvents:     2,
+			expRangefeeds: true,
+		},
+		{
+			name:             "initial scan only",
+			needsInitialScan: true,
+			initialScanOnly:  true,
+			initialHighWater: ts(2),
+			spans: []roachpb.Span{
+				tableSpan(42),
+			},
+			events: []roachpb.RangeFeedEvent{
+				kvEvent(42, "a", "b", ts(3)),
+			},
+			expScans: []hlc.Timestamp{
+				ts(2),
+			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -338,7 +338,7 @@ func makeCloudStorageSink(
 		}
 	default:
 		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
-			changefeedbase.OptFormat, opts[changefeedbase.OptFormat])
+			changefeedbase.OptFormat, changefeedbase.UserFacingFormat(opts[changefeedbase.OptFormat]))
 	}
 
 	switch changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]) {
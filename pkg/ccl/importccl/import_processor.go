@@ -38,9 +38,10 @@ var csvOutputTypes = []types.T{
 }
 
 type readImportDataProcessor struct {
-	flowCtx *execinfra.FlowCtx
-	spec    execinfrapb.ReadImportDataSpec
-	output  execinfra.RowReceiver
+	flowCtx     *execinfra.FlowCtx
+	processorID int32
+	spec        execinfrapb.ReadImportDataSpec
+	output      execinfra.RowReceiver
 }
 
 var _ execinfra.Processor = &readImportDataProcessor{}
@@ -56,9 +57,10 @@ func newReadImportDataProcessor(
 	output execinfra.RowReceiver,
 ) (execinfra.Processor, error) {
 	cp := &readImportDataProcessor{
-		flowCtx: flowCtx,
-		spec:    spec,
-		output:  output,
+		flowCtx:     flowCtx,
+		processorID: processorID,
+		spec:        spec,
+		output:      output,
 	}
 	return cp, nil
 }
@@ -82,6 +84,8 @@ func (cp *readImportDataProcessor) Run(ctx context.Context) {
 	for prog := range progCh {
 		// Take a copy so that we can send the progress address to the output processor.
 		p := prog
+		p.NodeID = cp.flowCtx.NodeID
+		p.ProcessorID = cp.processorID
 		cp.output.Push(nil, &execinfrapb.ProducerMetadata{BulkProcessorProgress: &p})
 	}
 
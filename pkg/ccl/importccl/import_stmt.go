@@ -765,7 +765,7 @@ func prepareNewTableDescsForIngestion(
 		seqVals[id] = tableDesc.SeqVal
 	}
 
-	if err := backupccl.RewriteTableDescs(tableDescs, tableRewrites, ""); err != nil {
+	if err := backupccl.RewriteTableDescs(tableDescs, tableRewrites, "", nil /* dbNames */); err != nil {
 		return nil, err
 	}
 
@@ -1125,6 +1125,21 @@ func (r *importResumer) dropTables(ctx context.Context, txn *client.Txn) error {
 		}
 	}
 
+	// Delete the data imported into new tables right away with MVCC range
+	// tombstones, so that it disappears before the tables are cleaned up by the
+	// schema change GC.
+	if cluster.Version.IsActive(ctx, r.settings, cluster.VersionMVCCRangeTombstones) {
+		for _, tbl := range details.Tables {
+			if !tbl.IsNew {
+				continue
+			}
+			span := tbl.Desc.TableSpan()
+			if err := txn.DB().DelRangeUsingTombstone(ctx, span.Key, span.EndKey); err != nil {
+				return errors.Wrap(err, "deleting partially imported data")
+			}
+		}
+	}
+
 	b := txn.NewBatch()
 	for _, tbl := range details.Tables {
 		tableDesc := *tbl.Desc
@@ -1200,4 +1215,5 @@ func init() {
 			}
 		},
 	)
+	jobs.RegisterRetryPolicy(jobspb.TypeImport, jobs.DefaultRetryPolicy)
 }
@@ -57,6 +57,7 @@ func declareKeysExport(
 ) {
 	batcheval.DefaultDeclareKeys(desc, header, req, spans)
 	spans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keys.RangeLastGCKey(header.RangeID)})
+	batcheval.DeclareKeysRangeTombstones(header, spans)
 }
 
 // evalExport dumps the requested keys into files of non-overlapping key ranges
@@ -157,42 +158,27 @@ func evalExport(
 	if targetSize > 0 && allowedOverage > 0 {
 		maxSize = targetSize + uint64(allowedOverage)
 	}
-	for start := args.Key; start != nil; {
-		data, summary, resume, err := e.ExportToSst(start, args.EndKey, args.StartTime,
-			h.Timestamp, exportAllRevisions, targetSize, maxSize, io)
-		if err != nil {
-			return result.Result{}, err
-		}
-
-		// NB: This should only happen on the first page of results. If there were
-		// more data to be read that lead to pagination then we'd see it in this
-		// page. Break out of the loop because there must be no data to export.
-		if summary.DataSize == 0 {
-			break
-		}
 
+	// emitFile checksums, encrypts and writes out an exported SST, and adds it
+	// to the reply.
+	emitFile := func(span roachpb.Span, data []byte, summary roachpb.BulkOpSummary) error {
 		var checksum []byte
+		var err error
 		if !args.OmitChecksum {
 			// Compute the checksum before we upload and remove the local file.
 			checksum, err = SHA512ChecksumData(data)
 			if err != nil {
-				return result.Result{}, err
+				return err
 			}
 		}
 
 		if args.Encryption != nil {
 			data, err = EncryptFile(data, args.Encryption.Key)
 			if err != nil {
-				return result.Result{}, err
+				return err
 			}
 		}
 
-		span := roachpb.Span{Key: start}
-		if resume != nil {
-			span.EndKey = resume
-		} else {
-			span.EndKey = args.EndKey
-		}
 		exported := roachpb.ExportResponse_File{
 			Span:       span,
 			Exported:   summary,
@@ -203,16 +189,59 @@ func evalExport(
 		if exportStore != nil {
 			exported.Path = fmt.Sprintf("%d.sst", builtins.GenerateUniqueInt(cArgs.EvalCtx.NodeID()))
 			if err := exportStore.WriteFile(ctx, exported.Path, bytes.NewReader(data)); err != nil {
-				return result.Result{}, err
+				return err
 			}
 		}
 		if args.ReturnSST {
 			exported.SST = data
 		}
 		reply.Files = append(reply.Files, exported)
+		return nil
+	}
+
+	for start := args.Key; start != nil; {
+		data, summary, resume, err := e.ExportToSst(start, args.EndKey, args.StartTime,
+			h.Timestamp, exportAllRevisions, targetSize, maxSize, io)
+		if err != nil {
+			return result.Result{}, err
+		}
+
+		// NB: This should only happen on the first page of results. If there were
+		// more data to be read that lead to pagination then we'd see it in this
+		// page. Break out of the loop because there must be no data to export.
+		if summary.DataSize == 0 {
+			break
+		}
+
+		span := roachpb.Span{Key: start}
+		if resume != nil {
+			span.EndKey = resume
+		} else {
+			span.EndKey = args.EndKey
+		}
+		if err := emitFile(span, data, summary); err != nil {
+			return result.Result{}, err
+		}
 		start = resume
 	}
 
+	// The deletions performed by MVCC range tombstones are not visible to
+	// ExportToSst, so they are exported as point deletions in a separate file.
+	rangeTombstones, err := batcheval.LoadRangeTombstones(ctx, batch, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
+	data, summary, err := engine.ExportRangeTombstonesToSst(
+		e, rangeTombstones, args.Key, args.EndKey, args.StartTime, h.Timestamp)
+	if err != nil {
+		return result.Result{}, err
+	}
+	if data != nil {
+		if err := emitFile(args.Span(), data, summary); err != nil {
+			return result.Result{}, err
+		}
+	}
+
 	return result.Result{}, nil
 }
 
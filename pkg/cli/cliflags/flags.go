@@ -150,7 +150,8 @@ If left unspecified, defaults to 25% of physical memory.
 	SQLAuditLogDirName = FlagInfo{
 		Name: "sql-audit-dir",
 		Description: `
-If non-empty, create a SQL audit log in this drectory.
+If non-empty, create the SQL audit logs (sql-audit and sql-audit-policy) in
+this directory.
 `,
 	}
 
@@ -1018,6 +1019,59 @@ Minimum verbosity of messages written to the log file.
 `,
 	}
 
+	LogFormat = FlagInfo{
+		Name: "log-format",
+		Description: `
+Format of the log entries written to the log files, to stderr and to the log
+sinks. Can be crdb-v1, the traditional line format, or json, which writes
+every entry as a JSON object on a single line.
+`,
+	}
+
+	LogSink = FlagInfo{
+		Name: "log-sink",
+		Description: `
+Destination of the log entries of some channels, specified as
+<PRE>
+
+  [<channel>,...=]<sink>
+
+</PRE>
+where the channels are main, for the main log, or the name of a
+secondary log (e.g. sql-audit, sql-exec, sql-slow), and the sink is one of:
+<PRE>
+
+  file                      the log files of the channel
+  stderr                    the standard error of the process
+  syslog[:///<socket>]      a local syslog daemon, by default at /dev/log
+  fluent://<host>:<port>    a Fluentd or Fluent Bit forward input
+  fluent+unix:///<socket>   a Fluentd or Fluent Bit forward input
+
+</PRE>
+When no channels are given the sink applies to all of them. The flag can
+be specified multiple times. Channels configured with sinks are only sent
+to these sinks, others go to their log files and to stderr as usual.
+`,
+	}
+
+	RedactableLogs = FlagInfo{
+		Name: "redactable-logs",
+		Description: `
+Enclose the values that may contain user data, like keys and SQL constants, in
+redaction markers in the log entries, so that they can be removed before
+sharing the logs with third parties (see debug zip --redact-logs).
+`,
+	}
+
+	ZipRedactLogs = FlagInfo{
+		Name: "redact-logs",
+		Description: `
+Redact the values that may contain user data in the log files included in the
+zip file. The log entries written without --redactable-logs are redacted
+entirely.
+`,
+	}
+
 	WriteSize = FlagInfo{
 		Name: "write-size",
 		Description: `
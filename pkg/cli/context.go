@@ -102,6 +102,8 @@ func initCLIDefaults() {
 	debugCtx.maxResults = 1000
 	debugCtx.ballastSize = base.SizeSpec{InBytes: 1000000000}
 
+	zipCtx.redactLogs = false
+
 	serverCfg.ReadyFn = nil
 	serverCfg.DelayedBootstrapFn = nil
 	serverCfg.SocketFile = ""
@@ -284,6 +286,14 @@ var debugCtx struct {
 	maxResults        int64
 }
 
+// zipCtx captures the command-line parameters of the `zip` command.
+// Defaults set by InitCLIDefaults() above.
+var zipCtx struct {
+	// redactLogs requests the redaction of the unsafe values of the log
+	// entries included in the zip file.
+	redactLogs bool
+}
+
 // startCtx captures the command-line arguments for the `start` command.
 // Defaults set by InitCLIDefaults() above.
 var startCtx struct {
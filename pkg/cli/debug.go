@@ -1182,6 +1182,10 @@ var debugMergeLogsOpts = struct {
 	program *regexp.Regexp
 	file    *regexp.Regexp
 	prefix  string
+	// redact replaces the unsafe values of the log entries with redaction
+	// markers, and keepRedactable keeps the redaction markers in the output.
+	redact         bool
+	keepRedactable bool
 }{
 	program: regexp.MustCompile("^cockroach.*$"),
 	file:    regexp.MustCompile(log.FilePattern),
@@ -1194,7 +1198,7 @@ func runDebugMergeLogs(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	return writeLogStream(s, cmd.OutOrStdout(), o.filter, o.prefix)
+	return writeLogStream(s, cmd.OutOrStdout(), o.filter, o.prefix, o.redact, o.keepRedactable)
 }
 
 // DebugCmdsForRocksDB lists debug commands that access rocksdb through the engine
@@ -1278,4 +1282,8 @@ func init() {
 			"if no such group exists, program-filter is ignored")
 	f.StringVar(&debugMergeLogsOpts.prefix, "prefix", "${host}> ",
 		"expansion template (see regexp.Expand) used as prefix to merged log messages evaluated on file-pattern")
+	f.BoolVar(&debugMergeLogsOpts.redact, "redact", false,
+		"redact the values that may contain user data; entries without redaction markers are redacted entirely")
+	f.BoolVar(&debugMergeLogsOpts.keepRedactable, "redactable-output", false,
+		"keep the redaction markers of the log entries in the output")
 }
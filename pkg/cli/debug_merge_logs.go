@@ -35,8 +35,17 @@ type logStream interface {
 }
 
 // writeLogStream pops messages off of s and writes them to out prepending
-// prefix per message and filtering messages which match filter.
-func writeLogStream(s logStream, out io.Writer, filter *regexp.Regexp, prefix string) error {
+// prefix per message and filtering messages which match filter. The unsafe
+// values of the messages are redacted if redact is set, and their redaction
+// markers are removed unless keepRedactable is set.
+func writeLogStream(
+	s logStream,
+	out io.Writer,
+	filter *regexp.Regexp,
+	prefix string,
+	redact bool,
+	keepRedactable bool,
+) error {
 	const chanSize = 1 << 16        // 64k
 	const maxWriteBufSize = 1 << 18 // 256kB
 
@@ -61,6 +70,12 @@ func writeLogStream(s logStream, out io.Writer, filter *regexp.Regexp, prefix st
 		if _, err = w.Write(prefixBytes); err != nil {
 			return err
 		}
+		if redact {
+			ei.Redact()
+		}
+		if !keepRedactable {
+			ei.StripMarkers()
+		}
 		return ei.Format(w)
 	}
 
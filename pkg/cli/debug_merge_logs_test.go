@@ -122,6 +122,22 @@ var cases = []testCase{
 		name:  "4.filter-npe-origin-stack-only",
 		args:  []string{"testdata/merge_logs/4/npe-repanic.log"}, // (?:panic\(.*)*
 		flags: []string{"--file-pattern", ".*", "--filter", `(?m)^(panic\(.*\n.*\n.*\n.*\n[^p].*)`},
+	},
+	{
+		// The redaction markers are removed by default.
+		name: "5.strip-markers",
+		args: []string{"testdata/merge_logs/5/*/*"},
+	},
+	{
+		name:  "5.redactable-output",
+		args:  []string{"testdata/merge_logs/5/*/*"},
+		flags: []string{"--redactable-output"},
+	},
+	{
+		// The entries without redaction markers are redacted entirely.
+		name:  "5.redact",
+		args:  []string{"testdata/merge_logs/5/*/*"},
+		flags: []string{"--redact", "--redactable-output"},
 	}}
 
 func (c testCase) run(t *testing.T) {
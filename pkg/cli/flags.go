@@ -282,9 +282,13 @@ func init() {
 		case logflags.LogDirName,
 			logflags.LogFileMaxSizeName,
 			logflags.LogFilesCombinedMaxSizeName,
-			logflags.LogFileVerbosityThresholdName:
-			// The --log-dir* and --log-file* flags are specified only for the
-			// `start` and `demo` commands.
+			logflags.LogFileVerbosityThresholdName,
+			logflags.LogFormatName,
+			logflags.LogSinkName,
+			logflags.RedactableLogsName:
+			// The --log-dir*, --log-file*, --log-format, --log-sink and
+			// --redactable-logs flags are specified only for the `start` and
+			// `demo` commands.
 			return
 		}
 		pf.AddFlag(flag)
@@ -418,6 +422,17 @@ func init() {
 		VarFlag(f,
 			pflag.PFlagFromGoFlag(flag.Lookup(logflags.LogFileVerbosityThresholdName)).Value,
 			cliflags.LogFileVerbosity)
+		VarFlag(f,
+			pflag.PFlagFromGoFlag(flag.Lookup(logflags.LogFormatName)).Value,
+			cliflags.LogFormat)
+		VarFlag(f,
+			pflag.PFlagFromGoFlag(flag.Lookup(logflags.LogSinkName)).Value,
+			cliflags.LogSink)
+		VarFlag(f,
+			pflag.PFlagFromGoFlag(flag.Lookup(logflags.RedactableLogsName)).Value,
+			cliflags.RedactableLogs)
+		// The flag is a boolean, which can be specified without a value.
+		f.Lookup(cliflags.RedactableLogs.Name).NoOptDefVal = "true"
 	}
 
 	for _, cmd := range certCmds {
@@ -492,6 +507,12 @@ func init() {
 		// the timeout.
 	}
 
+	// Zip command.
+	{
+		f := debugZipCmd.Flags()
+		BoolFlag(f, &zipCtx.redactLogs, cliflags.ZipRedactLogs, zipCtx.redactLogs)
+	}
+
 	for _, cmd := range timeoutCmds {
 		DurationFlag(cmd.Flags(), &cliCtx.cmdTimeout, cliflags.Timeout, cliCtx.cmdTimeout)
 	}
@@ -0,0 +1,80 @@
+#! /usr/bin/env expect -f
+
+source [file join [file dirname $argv0] common.tcl]
+
+start_server $argv
+
+spawn $argv sql
+eexpect root@
+
+set logfile logs/db/logs/cockroach-sql-audit-policy.log
+
+start_test "Check that the audit policy log is not populated by default"
+send "CREATE DATABASE t; CREATE TABLE t.helloworld(abc INT); CREATE USER maxroach;\r"
+eexpect root@
+system "if test -s $logfile; then false; fi"
+end_test
+
+start_test "Check that DDL and DCL statements get logged when the policy is enabled"
+send "SET CLUSTER SETTING sql.log.audit_policy.enabled = true;\r"
+eexpect root@
+send "CREATE TABLE t.goodbye(abc INT);\r"
+eexpect root@
+system "grep -q '\"event\":\"statement\",\"user\":\"root\".*\"status\":\"OK\".*\"statement_type\":\"ddl\".*CREATE TABLE t.goodbye.*\"databases\":.\"t\".' $logfile"
+send "GRANT ALL ON DATABASE t TO maxroach;\r"
+eexpect root@
+system "grep -q '\"statement_type\":\"dcl\".*GRANT ALL ON DATABASE t TO maxroach' $logfile"
+end_test
+
+start_test "Check that DML statements are not logged by default"
+send "INSERT INTO t.helloworld VALUES (123);\r"
+eexpect root@
+system "if grep -q 'INSERT INTO' $logfile; then false; fi"
+end_test
+
+start_test "Check that DML statements are logged with row counts when selected"
+send "SET CLUSTER SETTING sql.log.audit_policy.statement_types = 'dml';\r"
+eexpect root@
+send "INSERT INTO t.helloworld VALUES (456), (789);\r"
+eexpect root@
+system "grep -q '\"statement_type\":\"dml\".*INSERT INTO t.helloworld.*\"rows_affected\":2' $logfile"
+send "SELECT nonexistent FROM t.helloworld;\r"
+eexpect root@
+system "grep -q '\"status\":\"ERROR\".*\"statement_type\":\"dml\".*SELECT nonexistent' $logfile"
+end_test
+
+start_test "Check that the policy can be restricted to databases and roles"
+send "SET CLUSTER SETTING sql.log.audit_policy.databases = 'other';\r"
+eexpect root@
+send "SELECT * FROM t.helloworld WHERE abc = 999;\r"
+eexpect root@
+system "if grep -q 'abc = 999' $logfile; then false; fi"
+send "SET CLUSTER SETTING sql.log.audit_policy.databases = 't';\r"
+eexpect root@
+send "SET CLUSTER SETTING sql.log.audit_policy.roles = 'maxroach';\r"
+eexpect root@
+send "SELECT * FROM t.helloworld WHERE abc = 998;\r"
+eexpect root@
+system "if grep -q 'abc = 998' $logfile; then false; fi"
+send "SET CLUSTER SETTING sql.log.audit_policy.roles = 'admin';\r"
+eexpect root@
+send "SELECT * FROM t.helloworld WHERE abc = 997;\r"
+eexpect root@
+system "grep -q 'abc = 997' $logfile"
+end_test
+
+start_test "Check that login attempts get logged"
+send "SET CLUSTER SETTING sql.log.audit_policy.roles = '';\r"
+eexpect root@
+send "SET CLUSTER SETTING sql.log.audit_policy.statement_types = 'login';\r"
+eexpect root@
+interrupt
+eexpect eof
+
+system "$argv sql -u maxroach -e 'select 1' >/dev/null"
+system "grep -q '\"event\":\"login\",\"user\":\"maxroach\".*\"status\":\"OK\"' $logfile"
+system "if $argv sql -u nonexistent -e 'select 1' >/dev/null 2>&1; then false; fi"
+system "grep -q '\"event\":\"login\",\"user\":\"nonexistent\".*\"status\":\"ERROR\"' $logfile"
+end_test
+
+stop_server $argv
@@ -55,6 +55,9 @@ requires the cluster to be live.
 
 // Tables containing cluster-wide info that are collected in a debug zip.
 var debugZipTablesPerCluster = []string{
+	"crdb_internal.cluster_contended_indexes",
+	"crdb_internal.cluster_contending_transactions",
+	"crdb_internal.cluster_locks",
 	"crdb_internal.cluster_queries",
 	"crdb_internal.cluster_sessions",
 	"crdb_internal.cluster_settings",
@@ -90,6 +93,7 @@ var debugZipTablesPerNode = []string{
 	"crdb_internal.node_runtime_info",
 	"crdb_internal.node_sessions",
 	"crdb_internal.node_statement_statistics",
+	"crdb_internal.node_transaction_statistics",
 	"crdb_internal.node_txn_stats",
 }
 
@@ -484,8 +488,9 @@ func runDebugZip(cmd *cobra.Command, args []string) error {
 					var entries *serverpb.LogEntriesResponse
 					if err := runZipRequestWithTimeout(baseCtx, fmt.Sprintf("requesting log file %s", file.Name), timeout,
 						func(ctx context.Context) error {
-							entries, err = status.LogFile(
-								ctx, &serverpb.LogFileRequest{NodeId: id, File: file.Name})
+							entries, err = status.LogFile(ctx, &serverpb.LogFileRequest{
+								NodeId: id, File: file.Name, Redact: zipCtx.redactLogs,
+							})
 							return err
 						}); err != nil {
 						if err := z.createError(name, err); err != nil {
@@ -498,6 +503,11 @@ func runDebugZip(cmd *cobra.Command, args []string) error {
 						return err
 					}
 					for _, e := range entries.Entries {
+						if zipCtx.redactLogs {
+							// Servers predating redactable logs ignore the
+							// redaction request.
+							e.Redact()
+						}
 						if err := e.Format(logOut); err != nil {
 							return err
 						}
@@ -128,6 +128,9 @@ requesting data for debug/rangelog... writing: debug/rangelog.json
 requesting data for debug/liveness... writing: debug/liveness.json
 requesting data for debug/settings... writing: debug/settings.json
 requesting data for debug/reports/problemranges... writing: debug/reports/problemranges.json
+retrieving SQL data for crdb_internal.cluster_contended_indexes... writing: debug/crdb_internal.cluster_contended_indexes.txt
+retrieving SQL data for crdb_internal.cluster_contending_transactions... writing: debug/crdb_internal.cluster_contending_transactions.txt
+retrieving SQL data for crdb_internal.cluster_locks... writing: debug/crdb_internal.cluster_locks.txt
 retrieving SQL data for crdb_internal.cluster_queries... writing: debug/crdb_internal.cluster_queries.txt
 retrieving SQL data for crdb_internal.cluster_sessions... writing: debug/crdb_internal.cluster_sessions.txt
 retrieving SQL data for crdb_internal.cluster_settings... writing: debug/crdb_internal.cluster_settings.txt
@@ -155,6 +158,7 @@ retrieving SQL data for crdb_internal.node_queries... writing: debug/nodes/1/crd
 retrieving SQL data for crdb_internal.node_runtime_info... writing: debug/nodes/1/crdb_internal.node_runtime_info.txt
 retrieving SQL data for crdb_internal.node_sessions... writing: debug/nodes/1/crdb_internal.node_sessions.txt
 retrieving SQL data for crdb_internal.node_statement_statistics... writing: debug/nodes/1/crdb_internal.node_statement_statistics.txt
+retrieving SQL data for crdb_internal.node_transaction_statistics... writing: debug/nodes/1/crdb_internal.node_transaction_statistics.txt
 retrieving SQL data for crdb_internal.node_txn_stats... writing: debug/nodes/1/crdb_internal.node_txn_stats.txt
 requesting data for debug/nodes/1/details... writing: debug/nodes/1/details.json
 requesting data for debug/nodes/1/gossip... writing: debug/nodes/1/gossip.json
@@ -204,6 +208,8 @@ requesting database details for system... writing: debug/schema/system@details.j
 requesting table details for system.comments... writing: debug/schema/system/comments.json
 requesting table details for system.descriptor... writing: debug/schema/system/descriptor.json
 requesting table details for system.eventlog... writing: debug/schema/system/eventlog.json
+requesting table details for system.job_messages... writing: debug/schema/system/job_messages.json
+requesting table details for system.job_processor_progress... writing: debug/schema/system/job_processor_progress.json
 requesting table details for system.jobs... writing: debug/schema/system/jobs.json
 requesting table details for system.lease... writing: debug/schema/system/lease.json
 requesting table details for system.locations... writing: debug/schema/system/locations.json
@@ -218,8 +224,14 @@ requesting table details for system.replication_stats... writing: debug/schema/s
 requesting table details for system.reports_meta... writing: debug/schema/system/reports_meta.json
 requesting table details for system.role_members... writing: debug/schema/system/role_members.json
 requesting table details for system.role_options... writing: debug/schema/system/role_options.json
+requesting table details for system.scheduled_jobs... writing: debug/schema/system/scheduled_jobs.json
 requesting table details for system.settings... writing: debug/schema/system/settings.json
+requesting table details for system.statement_bundle_chunks... writing: debug/schema/system/statement_bundle_chunks.json
+requesting table details for system.statement_diagnostics... writing: debug/schema/system/statement_diagnostics.json
+requesting table details for system.statement_diagnostics_requests... writing: debug/schema/system/statement_diagnostics_requests.json
+requesting table details for system.statement_statistics... writing: debug/schema/system/statement_statistics.json
 requesting table details for system.table_statistics... writing: debug/schema/system/table_statistics.json
+requesting table details for system.transaction_statistics... writing: debug/schema/system/transaction_statistics.json
 requesting table details for system.ui... writing: debug/schema/system/ui.json
 requesting table details for system.users... writing: debug/schema/system/users.json
 requesting table details for system.web_sessions... writing: debug/schema/system/web_sessions.json
@@ -288,6 +300,8 @@ requesting database details for system... writing: debug/schema/system-1@details
 requesting table details for system.comments... writing: debug/schema/system-1/comments.json
 requesting table details for system.descriptor... writing: debug/schema/system-1/descriptor.json
 requesting table details for system.eventlog... writing: debug/schema/system-1/eventlog.json
+requesting table details for system.job_messages... writing: debug/schema/system-1/job_messages.json
+requesting table details for system.job_processor_progress... writing: debug/schema/system-1/job_processor_progress.json
 requesting table details for system.jobs... writing: debug/schema/system-1/jobs.json
 requesting table details for system.lease... writing: debug/schema/system-1/lease.json
 requesting table details for system.locations... writing: debug/schema/system-1/locations.json
@@ -302,8 +316,14 @@ requesting table details for system.replication_stats... writing: debug/schema/s
 requesting table details for system.reports_meta... writing: debug/schema/system-1/reports_meta.json
 requesting table details for system.role_members... writing: debug/schema/system-1/role_members.json
 requesting table details for system.role_options... writing: debug/schema/system-1/role_options.json
+requesting table details for system.scheduled_jobs... writing: debug/schema/system-1/scheduled_jobs.json
 requesting table details for system.settings... writing: debug/schema/system-1/settings.json
+requesting table details for system.statement_bundle_chunks... writing: debug/schema/system-1/statement_bundle_chunks.json
+requesting table details for system.statement_diagnostics... writing: debug/schema/system-1/statement_diagnostics.json
+requesting table details for system.statement_diagnostics_requests... writing: debug/schema/system-1/statement_diagnostics_requests.json
+requesting table details for system.statement_statistics... writing: debug/schema/system-1/statement_statistics.json
 requesting table details for system.table_statistics... writing: debug/schema/system-1/table_statistics.json
+requesting table details for system.transaction_statistics... writing: debug/schema/system-1/transaction_statistics.json
 requesting table details for system.ui... writing: debug/schema/system-1/ui.json
 requesting table details for system.users... writing: debug/schema/system-1/users.json
 requesting table details for system.web_sessions... writing: debug/schema/system-1/web_sessions.json
@@ -408,6 +428,9 @@ requesting data for debug/rangelog... writing: debug/rangelog.json
 requesting data for debug/liveness... writing: debug/liveness.json
 requesting data for debug/settings... writing: debug/settings.json
 requesting data for debug/reports/problemranges... writing: debug/reports/problemranges.json
+retrieving SQL data for crdb_internal.cluster_contended_indexes... writing: debug/crdb_internal.cluster_contended_indexes.txt
+retrieving SQL data for crdb_internal.cluster_contending_transactions... writing: debug/crdb_internal.cluster_contending_transactions.txt
+retrieving SQL data for crdb_internal.cluster_locks... writing: debug/crdb_internal.cluster_locks.txt
 retrieving SQL data for crdb_internal.cluster_queries... writing: debug/crdb_internal.cluster_queries.txt
 retrieving SQL data for crdb_internal.cluster_sessions... writing: debug/crdb_internal.cluster_sessions.txt
 retrieving SQL data for crdb_internal.cluster_settings... writing: debug/crdb_internal.cluster_settings.txt
@@ -447,6 +470,7 @@ retrieving SQL data for crdb_internal.node_queries... writing: debug/nodes/1/crd
 retrieving SQL data for crdb_internal.node_runtime_info... writing: debug/nodes/1/crdb_internal.node_runtime_info.txt
 retrieving SQL data for crdb_internal.node_sessions... writing: debug/nodes/1/crdb_internal.node_sessions.txt
 retrieving SQL data for crdb_internal.node_statement_statistics... writing: debug/nodes/1/crdb_internal.node_statement_statistics.txt
+retrieving SQL data for crdb_internal.node_transaction_statistics... writing: debug/nodes/1/crdb_internal.node_transaction_statistics.txt
 retrieving SQL data for crdb_internal.node_txn_stats... writing: debug/nodes/1/crdb_internal.node_txn_stats.txt
 requesting data for debug/nodes/1/details... writing: debug/nodes/1/details.json
 requesting data for debug/nodes/1/gossip... writing: debug/nodes/1/gossip.json
@@ -525,6 +549,9 @@ requesting data for debug/rangelog... writing: debug/rangelog.json
 requesting data for debug/liveness... writing: debug/liveness.json
 requesting data for debug/settings... writing: debug/settings.json
 requesting data for debug/reports/problemranges... writing: debug/reports/problemranges.json
+retrieving SQL data for crdb_internal.cluster_contended_indexes... writing: debug/crdb_internal.cluster_contended_indexes.txt
+retrieving SQL data for crdb_internal.cluster_contending_transactions... writing: debug/crdb_internal.cluster_contending_transactions.txt
+retrieving SQL data for crdb_internal.cluster_locks... writing: debug/crdb_internal.cluster_locks.txt
 retrieving SQL data for crdb_internal.cluster_queries... writing: debug/crdb_internal.cluster_queries.txt
 retrieving SQL data for crdb_internal.cluster_sessions... writing: debug/crdb_internal.cluster_sessions.txt
 retrieving SQL data for crdb_internal.cluster_settings... writing: debug/crdb_internal.cluster_settings.txt
@@ -552,6 +579,7 @@ retrieving SQL data for crdb_internal.node_queries... writing: debug/nodes/1/crd
 retrieving SQL data for crdb_internal.node_runtime_info... writing: debug/nodes/1/crdb_internal.node_runtime_info.txt
 retrieving SQL data for crdb_internal.node_sessions... writing: debug/nodes/1/crdb_internal.node_sessions.txt
 retrieving SQL data for crdb_internal.node_statement_statistics... writing: debug/nodes/1/crdb_internal.node_statement_statistics.txt
+retrieving SQL data for crdb_internal.node_transaction_statistics... writing: debug/nodes/1/crdb_internal.node_transaction_statistics.txt
 retrieving SQL data for crdb_internal.node_txn_stats... writing: debug/nodes/1/crdb_internal.node_txn_stats.txt
 requesting data for debug/nodes/1/details... writing: debug/nodes/1/details.json
 requesting data for debug/nodes/1/gossip... writing: debug/nodes/1/gossip.json
@@ -617,6 +645,8 @@ retrieving SQL data for crdb_internal.node_sessions... writing: debug/nodes/2/cr
   ^- resulted in ...
 retrieving SQL data for crdb_internal.node_statement_statistics... writing: debug/nodes/2/crdb_internal.node_statement_statistics.txt
   ^- resulted in ...
+retrieving SQL data for crdb_internal.node_transaction_statistics... writing: debug/nodes/2/crdb_internal.node_transaction_statistics.txt
+  ^- resulted in ...
 retrieving SQL data for crdb_internal.node_txn_stats... writing: debug/nodes/2/crdb_internal.node_txn_stats.txt
   ^- resulted in ...
 requesting data for debug/nodes/2/details... writing: debug/nodes/2/details.json
@@ -651,6 +681,7 @@ retrieving SQL data for crdb_internal.node_queries... writing: debug/nodes/3/crd
 retrieving SQL data for crdb_internal.node_runtime_info... writing: debug/nodes/3/crdb_internal.node_runtime_info.txt
 retrieving SQL data for crdb_internal.node_sessions... writing: debug/nodes/3/crdb_internal.node_sessions.txt
 retrieving SQL data for crdb_internal.node_statement_statistics... writing: debug/nodes/3/crdb_internal.node_statement_statistics.txt
+retrieving SQL data for crdb_internal.node_transaction_statistics... writing: debug/nodes/3/crdb_internal.node_transaction_statistics.txt
 retrieving SQL data for crdb_internal.node_txn_stats... writing: debug/nodes/3/crdb_internal.node_txn_stats.txt
 requesting data for debug/nodes/3/details... writing: debug/nodes/3/details.json
 requesting data for debug/nodes/3/gossip... writing: debug/nodes/3/gossip.json
@@ -700,6 +731,8 @@ requesting database details for system... writing: debug/schema/system@details.j
 requesting table details for system.comments... writing: debug/schema/system/comments.json
 requesting table details for system.descriptor... writing: debug/schema/system/descriptor.json
 requesting table details for system.eventlog... writing: debug/schema/system/eventlog.json
+requesting table details for system.job_messages... writing: debug/schema/system/job_messages.json
+requesting table details for system.job_processor_progress... writing: debug/schema/system/job_processor_progress.json
 requesting table details for system.jobs... writing: debug/schema/system/jobs.json
 requesting table details for system.lease... writing: debug/schema/system/lease.json
 requesting table details for system.locations... writing: debug/schema/system/locations.json
@@ -714,8 +747,14 @@ requesting table details for system.replication_stats... writing: debug/schema/s
 requesting table details for system.reports_meta... writing: debug/schema/system/reports_meta.json
 requesting table details for system.role_members... writing: debug/schema/system/role_members.json
 requesting table details for system.role_options... writing: debug/schema/system/role_options.json
+requesting table details for system.scheduled_jobs... writing: debug/schema/system/scheduled_jobs.json
 requesting table details for system.settings... writing: debug/schema/system/settings.json
+requesting table details for system.statement_bundle_chunks... writing: debug/schema/system/statement_bundle_chunks.json
+requesting table details for system.statement_diagnostics... writing: debug/schema/system/statement_diagnostics.json
+requesting table details for system.statement_diagnostics_requests... writing: debug/schema/system/statement_diagnostics_requests.json
+requesting table details for system.statement_statistics... writing: debug/schema/system/statement_statistics.json
 requesting table details for system.table_statistics... writing: debug/schema/system/table_statistics.json
+requesting table details for system.transaction_statistics... writing: debug/schema/system/transaction_statistics.json
 requesting table details for system.ui... writing: debug/schema/system/ui.json
 requesting table details for system.users... writing: debug/schema/system/users.json
 requesting table details for system.web_sessions... writing: debug/schema/system/web_sessions.json
@@ -336,6 +336,17 @@ var specs = []stmtSpec{
 		replace: map[string]string{"relation_expr": "table_name", "alter_table_cmds": "'ADD' 'CONSTRAINT' constraint_name constraint_elem opt_validate_behavior"},
 		unlink:  []string{"table_name"},
 	},
+	{
+		name: "alter_backup",
+		stmt: "alter_backup_stmt",
+		replace: map[string]string{
+			"'ALTER' 'BACKUP' string_or_placeholder": "'ALTER' 'BACKUP' location",
+			"'IN' string_or_placeholder":             "'IN' collection",
+			"'NEW_KMS' '=' string_or_placeholder":    "'NEW_KMS' '=' kms_uri",
+			"'OLD_KMS' '=' string_or_placeholder":    "'OLD_KMS' '=' kms_uri",
+		},
+		unlink: []string{"location", "collection", "kms_uri"},
+	},
 	{
 		name:   "alter_column",
 		stmt:   "alter_onetable_stmt",
@@ -562,6 +573,19 @@ var specs = []stmtSpec{
 		match:  []*regexp.Regexp{regexp.MustCompile("'CREATE' 'INVERTED'")},
 		inline: []string{"opt_storing", "storing", "opt_unique", "opt_name", "index_params", "index_elem", "opt_asc_desc"},
 	},
+	{
+		name:   "create_schedule_for_backup_stmt",
+		inline: []string{"opt_description", "opt_backup_targets", "opt_with_options", "cron_expr", "opt_full_backup_clause"},
+		replace: map[string]string{
+			"'CREATE' 'SCHEDULE' string_or_placeholder": "'CREATE' 'SCHEDULE' label",
+			"'TO' string_or_placeholder":                "'TO' location",
+			"'RECURRING' sconst_or_placeholder":         "'RECURRING' crontab",
+			"'FULL' 'BACKUP' sconst_or_placeholder":     "'FULL' 'BACKUP' crontab",
+			"'WITH' 'OPTIONS' '(' kv_option_list ')'":   "",
+			"targets": "( ( 'TABLE' | ) table_pattern ( ( ',' table_pattern ) )* | 'DATABASE' database_name ( ( ',' database_name ) )* )",
+		},
+		unlink: []string{"label", "location", "crontab"},
+	},
 	{
 		name:    "create_sequence_stmt",
 		inline:  []string{"opt_sequence_option_list", "sequence_option_list", "sequence_option_elem"},
@@ -672,6 +696,12 @@ var specs = []stmtSpec{
 		},
 		replace: map[string]string{"standalone_index_name": "index_name"},
 	},
+	{
+		name:    "drop_schedule",
+		stmt:    "drop_schedule_stmt",
+		replace: map[string]string{"a_expr": "schedule_id"},
+		unlink:  []string{"schedule_id"},
+	},
 	{
 		name:    "drop_role_stmt",
 		replace: map[string]string{"string_or_placeholder_list": "name"},
@@ -888,10 +918,16 @@ var specs = []stmtSpec{
 	},
 	{
 		name:    "pause_job",
-		stmt:    "pause_stmt",
+		stmt:    "pause_jobs_stmt",
 		replace: map[string]string{"a_expr": "job_id"},
 		unlink:  []string{"job_id"},
 	},
+	{
+		name:    "pause_schedule",
+		stmt:    "pause_schedules_stmt",
+		replace: map[string]string{"a_expr": "schedule_id"},
+		unlink:  []string{"schedule_id"},
+	},
 	{
 		name: "primary_key_column_level",
 		stmt: "stmt_block",
@@ -961,10 +997,16 @@ var specs = []stmtSpec{
 	},
 	{
 		name:    "resume_job",
-		stmt:    "resume_stmt",
+		stmt:    "resume_jobs_stmt",
 		replace: map[string]string{"a_expr": "job_id"},
 		unlink:  []string{"job_id"},
 	},
+	{
+		name:    "resume_schedule",
+		stmt:    "resume_schedules_stmt",
+		replace: map[string]string{"a_expr": "schedule_id"},
+		unlink:  []string{"schedule_id"},
+	},
 	{
 		name:   "revoke_privileges",
 		stmt:   "revoke_stmt",
@@ -1154,6 +1196,13 @@ var specs = []stmtSpec{
 		replace: map[string]string{"string_or_placeholder": "location"},
 		unlink:  []string{"location"},
 	},
+	{
+		name:    "show_backups",
+		stmt:    "show_backup_stmt",
+		match:   []*regexp.Regexp{regexp.MustCompile("'SHOW' 'BACKUPS'")},
+		replace: map[string]string{"string_or_placeholder": "collection"},
+		unlink:  []string{"collection"},
+	},
 	{
 		name:    "show_jobs",
 		stmt:    "show_jobs_stmt",
@@ -1212,6 +1261,12 @@ var specs = []stmtSpec{
 		replace: map[string]string{"a_expr": "row_vals"},
 		unlink:  []string{"row_vals"},
 	},
+	{
+		name:    "show_schedules",
+		stmt:    "show_schedules_stmt",
+		replace: map[string]string{"a_expr": "schedule_id"},
+		unlink:  []string{"schedule_id"},
+	},
 	{
 		name: "show_schemas",
 		stmt: "show_schemas_stmt",
@@ -259,6 +259,9 @@ func (z *ZoneConfig) ValidateTandemFields() error {
 	if numConstrainedRepls > 0 && z.NumReplicas == nil {
 		return fmt.Errorf("when per-replica constraints are set, num_replicas must be set as well")
 	}
+	if len(z.NonVoterConstraints) > 0 && z.NumNonVoters == nil {
+		return fmt.Errorf("when non_voter_constraints are set, num_non_voters must be set as well")
+	}
 	if (z.RangeMinBytes != nil || z.RangeMaxBytes != nil) &&
 		(z.RangeMinBytes == nil || z.RangeMaxBytes == nil) {
 		return fmt.Errorf("range_min_bytes and range_max_bytes must be set together")
@@ -294,6 +297,10 @@ func (z *ZoneConfig) Validate() error {
 		}
 	}
 
+	if z.NumNonVoters != nil && *z.NumNonVoters < 0 {
+		return fmt.Errorf("num_non_voters cannot be negative")
+	}
+
 	if z.RangeMaxBytes != nil && *z.RangeMaxBytes < base.MinRangeMaxBytes {
 		return fmt.Errorf("RangeMaxBytes %d less than minimum allowed %d",
 			*z.RangeMaxBytes, base.MinRangeMaxBytes)
@@ -313,7 +320,35 @@ func (z *ZoneConfig) Validate() error {
 		return fmt.Errorf("GC.TTLSeconds %d less than minimum allowed 1", z.GC.TTLSeconds)
 	}
 
-	for _, constraints := range z.Constraints {
+	if err := validateConstraints(z.Constraints, z.NumReplicas, "replicas"); err != nil {
+		return err
+	}
+	if err := validateConstraints(z.NonVoterConstraints, z.NumNonVoters, "non-voting replicas"); err != nil {
+		return err
+	}
+
+	for _, leasePref := range z.LeasePreferences {
+		if len(leasePref.Constraints) == 0 {
+			return fmt.Errorf("every lease preference must include at least one constraint")
+		}
+		for _, constraint := range leasePref.Constraints {
+			if constraint.Type == Constraint_DEPRECATED_POSITIVE {
+				return fmt.Errorf("lease preference constraints must either be required " +
+					"(prefixed with a '+') or prohibited (prefixed with a '-')")
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateConstraints validates a set of constraints that apply to numReplicas
+// replicas of a range. replicaKind is used to describe these replicas in
+// error messages.
+func validateConstraints(
+	constraintsList []Constraints, numReplicas *int32, replicaKind string,
+) error {
+	for _, constraints := range constraintsList {
 		for _, constraint := range constraints.Constraints {
 			if constraint.Type == Constraint_DEPRECATED_POSITIVE {
 				return fmt.Errorf("constraints must either be required (prefixed with a '+') or " +
@@ -325,9 +360,9 @@ func (z *ZoneConfig) Validate() error {
 	// We only need to further validate constraints if per-replica constraints
 	// are in use. The old style of constraints that apply to all replicas don't
 	// require validation.
-	if len(z.Constraints) > 1 || (len(z.Constraints) == 1 && z.Constraints[0].NumReplicas != 0) {
+	if len(constraintsList) > 1 || (len(constraintsList) == 1 && constraintsList[0].NumReplicas != 0) {
 		var numConstrainedRepls int64
-		for _, constraints := range z.Constraints {
+		for _, constraints := range constraintsList {
 			if constraints.NumReplicas <= 0 {
 				return fmt.Errorf("constraints must apply to at least one replica")
 			}
@@ -335,31 +370,18 @@ func (z *ZoneConfig) Validate() error {
 			for _, constraint := range constraints.Constraints {
 				// TODO(a-robinson): Relax this constraint to allow prohibited replicas,
 				// as discussed on #23014.
-				if constraint.Type != Constraint_REQUIRED && z.NumReplicas != nil && constraints.NumReplicas != *z.NumReplicas {
+				if constraint.Type != Constraint_REQUIRED && numReplicas != nil && constraints.NumReplicas != *numReplicas {
 					return fmt.Errorf(
 						"only required constraints (prefixed with a '+') can be applied to a subset of replicas")
 				}
 			}
 		}
-		if z.NumReplicas != nil && numConstrainedRepls > int64(*z.NumReplicas) {
-			return fmt.Errorf("the number of replicas specified in constraints (%d) cannot be greater "+
-				"than the number of replicas configured for the zone (%d)",
-				numConstrainedRepls, *z.NumReplicas)
-		}
-	}
-
-	for _, leasePref := range z.LeasePreferences {
-		if len(leasePref.Constraints) == 0 {
-			return fmt.Errorf("every lease preference must include at least one constraint")
-		}
-		for _, constraint := range leasePref.Constraints {
-			if constraint.Type == Constraint_DEPRECATED_POSITIVE {
-				return fmt.Errorf("lease preference constraints must either be required " +
-					"(prefixed with a '+') or prohibited (prefixed with a '-')")
-			}
+		if numReplicas != nil && numConstrainedRepls > int64(*numReplicas) {
+			return fmt.Errorf("the number of %[1]s specified in constraints (%[2]d) cannot be greater "+
+				"than the number of %[1]s configured for the zone (%[3]d)",
+				replicaKind, numConstrainedRepls, *numReplicas)
 		}
 	}
-
 	return nil
 }
 
@@ -371,6 +393,14 @@ func (z *ZoneConfig) InheritFromParent(parent *ZoneConfig) {
 			z.NumReplicas = proto.Int32(*parent.NumReplicas)
 		}
 	}
+	if z.NumNonVoters == nil {
+		if parent.NumNonVoters != nil {
+			z.NumNonVoters = proto.Int32(*parent.NumNonVoters)
+		}
+	}
+	if len(z.NonVoterConstraints) == 0 {
+		z.NonVoterConstraints = parent.NonVoterConstraints
+	}
 	if z.RangeMinBytes == nil {
 		if parent.RangeMinBytes != nil {
 			z.RangeMinBytes = proto.Int64(*parent.RangeMinBytes)
@@ -410,6 +440,15 @@ func (z *ZoneConfig) CopyFromZone(other ZoneConfig, fieldList []tree.Name) {
 				z.NumReplicas = proto.Int32(*other.NumReplicas)
 			}
 		}
+		if fieldName == "num_non_voters" {
+			z.NumNonVoters = nil
+			if other.NumNonVoters != nil {
+				z.NumNonVoters = proto.Int32(*other.NumNonVoters)
+			}
+		}
+		if fieldName == "non_voter_constraints" {
+			z.NonVoterConstraints = other.NonVoterConstraints
+		}
 		if fieldName == "range_min_bytes" {
 			z.RangeMinBytes = nil
 			if other.RangeMinBytes != nil {
@@ -501,6 +540,35 @@ func (z *ZoneConfig) IsSubzonePlaceholder() bool {
 	return z.NumReplicas != nil && *z.NumReplicas == 0
 }
 
+// GetNumNonVoters returns the number of non-voting replicas requested by the
+// ZoneConfig, which is zero if unset.
+func (z *ZoneConfig) GetNumNonVoters() int32 {
+	if z.NumNonVoters == nil {
+		return 0
+	}
+	return *z.NumNonVoters
+}
+
+// NonVoterPlacementConfig returns a copy of the ZoneConfig in which the
+// NumReplicas and Constraints fields describe the placement of the zone's
+// non-voting replicas rather than that of its voting replicas. This allows
+// constraint analysis that operates on NumReplicas and Constraints to be
+// reused for non-voters.
+//
+// If NonVoterConstraints are not set, the non-voters are subject to the zone's
+// Constraints only if those apply to all replicas of the range (i.e. they're
+// not per-replica constraints, which are accounted against NumReplicas).
+func (z *ZoneConfig) NonVoterPlacementConfig() *ZoneConfig {
+	cfg := *z
+	cfg.NumReplicas = proto.Int32(z.GetNumNonVoters())
+	cfg.Constraints = z.NonVoterConstraints
+	if len(cfg.Constraints) == 0 && len(z.Constraints) == 1 && z.Constraints[0].NumReplicas == 0 {
+		cfg.Constraints = z.Constraints
+	}
+	cfg.InheritedConstraints = false
+	return &cfg
+}
+
 // GetSubzone returns the most specific Subzone that applies to the specified
 // index ID and partition, if any exists. The partition can be left unspecified
 // to get the Subzone for an entire index, if it exists. indexID, however, must
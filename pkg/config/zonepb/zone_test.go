@@ -203,6 +203,58 @@ func TestZoneConfigValidate(t *testing.T) {
 			},
 			"every lease preference must include at least one constraint",
 		},
+		{
+			ZoneConfig{
+				NumReplicas:  proto.Int32(3),
+				NumNonVoters: proto.Int32(-1),
+			},
+			"num_non_voters cannot be negative",
+		},
+		{
+			ZoneConfig{
+				NumReplicas:   proto.Int32(3),
+				NumNonVoters:  proto.Int32(1),
+				RangeMaxBytes: DefaultZoneConfig().RangeMaxBytes,
+				GC:            &GCPolicy{TTLSeconds: 1},
+				NonVoterConstraints: []Constraints{
+					{
+						Constraints: []Constraint{{Value: "a", Type: Constraint_REQUIRED}},
+						NumReplicas: 2,
+					},
+				},
+			},
+			"the number of non-voting replicas specified in constraints .+ cannot be greater than",
+		},
+		{
+			ZoneConfig{
+				NumReplicas:   proto.Int32(3),
+				NumNonVoters:  proto.Int32(2),
+				RangeMaxBytes: DefaultZoneConfig().RangeMaxBytes,
+				GC:            &GCPolicy{TTLSeconds: 1},
+				NonVoterConstraints: []Constraints{
+					{
+						Constraints: []Constraint{{Value: "a", Type: Constraint_PROHIBITED}},
+						NumReplicas: 1,
+					},
+				},
+			},
+			"only required constraints .+ can be applied to a subset of replicas",
+		},
+		{
+			ZoneConfig{
+				NumReplicas:   proto.Int32(3),
+				NumNonVoters:  proto.Int32(2),
+				RangeMaxBytes: DefaultZoneConfig().RangeMaxBytes,
+				GC:            &GCPolicy{TTLSeconds: 1},
+				NonVoterConstraints: []Constraints{
+					{
+						Constraints: []Constraint{{Key: "region", Value: "us-west", Type: Constraint_REQUIRED}},
+						NumReplicas: 1,
+					},
+				},
+			},
+			"",
+		},
 		{
 			ZoneConfig{
 				NumReplicas:   proto.Int32(1),
@@ -284,6 +336,16 @@ func TestZoneConfigValidateTandemFields(t *testing.T) {
 			},
 			"lease preferences can not be set unless the constraints are explicitly set as well",
 		},
+		{
+			ZoneConfig{
+				NonVoterConstraints: []Constraints{
+					{
+						Constraints: []Constraint{{Value: "a", Type: Constraint_REQUIRED}},
+					},
+				},
+			},
+			"when non_voter_constraints are set, num_non_voters must be set as well",
+		},
 	}
 
 	for i, c := range testCases {
@@ -294,6 +356,84 @@ func TestZoneConfigValidateTandemFields(t *testing.T) {
 	}
 }
 
+func TestZoneConfigNonVoterPlacementConfig(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	allReplicas := []Constraints{
+		{Constraints: []Constraint{{Key: "ssd", Value: "true", Type: Constraint_REQUIRED}}},
+	}
+	perReplica := []Constraints{
+		{
+			Constraints: []Constraint{{Key: "region", Value: "us-east", Type: Constraint_REQUIRED}},
+			NumReplicas: 2,
+		},
+	}
+	nonVoter := []Constraints{
+		{
+			Constraints: []Constraint{{Key: "region", Value: "us-west", Type: Constraint_REQUIRED}},
+			NumReplicas: 1,
+		},
+	}
+
+	testCases := []struct {
+		zone           ZoneConfig
+		expNumReplicas int32
+		expConstraints []Constraints
+	}{
+		{
+			zone:           ZoneConfig{NumReplicas: proto.Int32(3)},
+			expNumReplicas: 0,
+		},
+		{
+			zone:           ZoneConfig{NumReplicas: proto.Int32(3), NumNonVoters: proto.Int32(2)},
+			expNumReplicas: 2,
+		},
+		{
+			// Constraints that apply to all replicas apply to non-voters too.
+			zone: ZoneConfig{
+				NumReplicas:  proto.Int32(3),
+				NumNonVoters: proto.Int32(2),
+				Constraints:  allReplicas,
+			},
+			expNumReplicas: 2,
+			expConstraints: allReplicas,
+		},
+		{
+			// Per-replica constraints only apply to the voters.
+			zone: ZoneConfig{
+				NumReplicas:  proto.Int32(3),
+				NumNonVoters: proto.Int32(2),
+				Constraints:  perReplica,
+			},
+			expNumReplicas: 2,
+		},
+		{
+			// NonVoterConstraints take precedence.
+			zone: ZoneConfig{
+				NumReplicas:         proto.Int32(3),
+				NumNonVoters:        proto.Int32(2),
+				Constraints:         allReplicas,
+				NonVoterConstraints: nonVoter,
+			},
+			expNumReplicas: 2,
+			expConstraints: nonVoter,
+		},
+	}
+
+	for i, tc := range testCases {
+		cfg := tc.zone.NonVoterPlacementConfig()
+		if *cfg.NumReplicas != tc.expNumReplicas {
+			t.Errorf("%d: expected NumReplicas %d, got %d", i, tc.expNumReplicas, *cfg.NumReplicas)
+		}
+		if !reflect.DeepEqual(cfg.Constraints, tc.expConstraints) {
+			t.Errorf("%d: expected constraints %v, got %v", i, tc.expConstraints, cfg.Constraints)
+		}
+		if *tc.zone.NumReplicas != 3 {
+			t.Errorf("%d: original zone config was modified: %v", i, tc.zone)
+		}
+	}
+}
+
 func TestZoneConfigSubzones(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -396,9 +536,11 @@ func TestZoneConfigMarshalYAML(t *testing.T) {
 	}
 
 	testCases := []struct {
-		constraints      []Constraints
-		leasePreferences []LeasePreference
-		expected         string
+		constraints         []Constraints
+		numNonVoters        *int32
+		nonVoterConstraints []Constraints
+		leasePreferences    []LeasePreference
+		expected            string
 	}{
 		{
 			expected: `range_min_bytes: 1
@@ -627,6 +769,43 @@ gc:
 num_replicas: 1
 constraints: [+duck=foo]
 lease_preferences: [[+duck=bar1, +duck=bar2], [-duck=foo]]
+`,
+		},
+		{
+			numNonVoters: proto.Int32(2),
+			expected: `range_min_bytes: 1
+range_max_bytes: 1
+gc:
+  ttlseconds: 1
+num_replicas: 1
+constraints: []
+num_non_voters: 2
+lease_preferences: []
+`,
+		},
+		{
+			numNonVoters: proto.Int32(2),
+			nonVoterConstraints: []Constraints{
+				{
+					NumReplicas: 1,
+					Constraints: []Constraint{
+						{
+							Type:  Constraint_REQUIRED,
+							Key:   "region",
+							Value: "us-west",
+						},
+					},
+				},
+			},
+			expected: `range_min_bytes: 1
+range_max_bytes: 1
+gc:
+  ttlseconds: 1
+num_replicas: 1
+constraints: []
+num_non_voters: 2
+non_voter_constraints: {+region=us-west: 1}
+lease_preferences: []
 `,
 		},
 	}
@@ -634,6 +813,8 @@ lease_preferences: [[+duck=bar1, +duck=bar2], [-duck=foo]]
 	for _, tc := range testCases {
 		t.Run("", func(t *testing.T) {
 			original.Constraints = tc.constraints
+			original.NumNonVoters = tc.numNonVoters
+			original.NonVoterConstraints = tc.nonVoterConstraints
 			original.LeasePreferences = tc.leasePreferences
 			body, err := yaml.Marshal(original)
 			if err != nil {
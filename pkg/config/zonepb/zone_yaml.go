@@ -204,6 +204,8 @@ type marshalableZoneConfig struct {
 	GC                           *GCPolicy         `json:"gc"`
 	NumReplicas                  *int32            `json:"num_replicas" yaml:"num_replicas"`
 	Constraints                  ConstraintsList   `json:"constraints" yaml:"constraints,flow"`
+	NumNonVoters                 *int32            `json:"num_non_voters" yaml:"num_non_voters,omitempty"`
+	NonVoterConstraints          ConstraintsList   `json:"non_voter_constraints" yaml:"non_voter_constraints,flow,omitempty"`
 	LeasePreferences             []LeasePreference `json:"lease_preferences" yaml:"lease_preferences,flow"`
 	ExperimentalLeasePreferences []LeasePreference `json:"experimental_lease_preferences" yaml:"experimental_lease_preferences,flow,omitempty"`
 	Subzones                     []Subzone         `json:"subzones" yaml:"-"`
@@ -226,6 +228,10 @@ func zoneConfigToMarshalable(c ZoneConfig) marshalableZoneConfig {
 		m.NumReplicas = proto.Int32(*c.NumReplicas)
 	}
 	m.Constraints = ConstraintsList{c.Constraints, c.InheritedConstraints}
+	if c.NumNonVoters != nil {
+		m.NumNonVoters = proto.Int32(*c.NumNonVoters)
+	}
+	m.NonVoterConstraints = ConstraintsList{Constraints: c.NonVoterConstraints}
 	if !c.InheritedLeasePreferences {
 		m.LeasePreferences = c.LeasePreferences
 	}
@@ -255,6 +261,10 @@ func zoneConfigFromMarshalable(m marshalableZoneConfig, c ZoneConfig) ZoneConfig
 	}
 	c.Constraints = m.Constraints.Constraints
 	c.InheritedConstraints = m.Constraints.Inherited
+	if m.NumNonVoters != nil {
+		c.NumNonVoters = proto.Int32(*m.NumNonVoters)
+	}
+	c.NonVoterConstraints = m.NonVoterConstraints.Constraints
 	if m.LeasePreferences != nil {
 		c.LeasePreferences = m.LeasePreferences
 	}
@@ -474,6 +474,18 @@ func (db *DB) DelRange(ctx context.Context, begin, end interface{}) error {
 	return getOneErr(db.Run(ctx, b), b)
 }
 
+// DelRangeUsingTombstone deletes the rows between begin (inclusive) and end
+// (exclusive) by writing an MVCC range tombstone. Unlike ClearRange, the
+// deletion is visible to historical reads, incremental backups and
+// rangefeeds.
+//
+// key can be either a byte slice or a string.
+func (db *DB) DelRangeUsingTombstone(ctx context.Context, begin, end interface{}) error {
+	b := &Batch{}
+	b.DelRangeUsingTombstone(begin, end)
+	return getOneErr(db.Run(ctx, b), b)
+}
+
 // AdminMerge merges the range containing key and the subsequent range. After
 // the merge operation is complete, the range containing key will contain all of
 // the key/value pairs of the subsequent range and the subsequent range will no
@@ -0,0 +1,208 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sqlmigrations/leasemanager"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	schedulerEnabledSetting = settings.RegisterBoolSetting(
+		"jobs.scheduler.enabled",
+		"enable the execution of schedules stored in system.scheduled_jobs",
+		true)
+	schedulerPaceSetting = settings.RegisterValidatedDurationSetting(
+		"jobs.scheduler.pace",
+		"how often to scan system.scheduled_jobs for schedules that are due",
+		time.Minute,
+		func(v time.Duration) error {
+			if v <= 0 {
+				return errors.Errorf("jobs.scheduler.pace must be positive: %s", v)
+			}
+			return nil
+		})
+	schedulerMaxJobsPerIterationSetting = settings.RegisterPositiveIntSetting(
+		"jobs.scheduler.max_jobs_per_iteration",
+		"how many schedules to execute in each scan of system.scheduled_jobs",
+		10)
+)
+
+// ScheduledJobExecutor runs the schedules of a particular executor type.
+type ScheduledJobExecutor interface {
+	// ExecuteJob is invoked when the schedule is due. It runs in the same
+	// transaction that advances the schedule to its next run, and should use it
+	// to create the jobs the schedule stands for; these jobs are leased to the
+	// current node and started by its registry once the transaction commits.
+	// The executor may change the schedule's execution arguments, which are
+	// persisted along with its next run.
+	ExecuteJob(
+		ctx context.Context, ex sqlutil.InternalExecutor, schedule *ScheduledJob, txn *client.Txn,
+	) error
+}
+
+var scheduledJobExecutors = make(map[string]ScheduledJobExecutor)
+
+// RegisterScheduledJobExecutor registers the executor for schedules of the
+// given executor type.
+func RegisterScheduledJobExecutor(executorType string, executor ScheduledJobExecutor) {
+	scheduledJobExecutors[executorType] = executor
+}
+
+// GetScheduledJobExecutor returns the executor registered for the executor
+// type.
+func GetScheduledJobExecutor(executorType string) (ScheduledJobExecutor, error) {
+	executor, ok := scheduledJobExecutors[executorType]
+	if !ok {
+		return nil, errors.Newf("no executor registered for schedules of type %q", executorType)
+	}
+	return executor, nil
+}
+
+// schedulerLeaseDuration is how long the scheduler lease is held without being
+// extended. It is extended at every scan of system.scheduled_jobs, so that a
+// pace longer than the lease duration lets other nodes take it over between
+// scans, which is harmless.
+const schedulerLeaseDuration = 5 * time.Minute
+
+// runScheduler periodically executes the schedules that are due. Every node
+// runs the scheduler, but only the node holding the lease on
+// keys.JobsSchedulerLease executes schedules. A schedule is also executed and
+// advanced to its next run in a single transaction, so that it is not
+// executed twice if the lease moves to another node during a scan.
+func (r *Registry) runScheduler(ctx context.Context, stopper *stop.Stopper) {
+	leaseManager := leasemanager.New(r.db, r.clock, leasemanager.Options{
+		LeaseDuration: schedulerLeaseDuration,
+	})
+	var lease *leasemanager.Lease
+
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		timer.Reset(schedulerPaceSetting.Get(&r.settings.SV))
+		select {
+		case <-stopper.ShouldQuiesce():
+			return
+		case <-timer.C:
+			timer.Read = true
+			if !schedulerEnabledSetting.Get(&r.settings.SV) ||
+				!cluster.Version.IsActive(ctx, r.settings, cluster.VersionScheduledJobs) {
+				continue
+			}
+			var err error
+			if lease, err = r.maybeAcquireSchedulerLease(ctx, leaseManager, lease); err != nil {
+				log.Warningf(ctx, "error acquiring the scheduler lease: %v", err)
+				continue
+			} else if lease == nil {
+				// Another node is executing the schedules.
+				continue
+			}
+			if err := r.executeSchedules(ctx); err != nil {
+				log.Warningf(ctx, "error executing schedules: %v", err)
+			}
+		}
+	}
+}
+
+// maybeAcquireSchedulerLease extends the scheduler lease if it is held, and
+// tries to acquire it otherwise. It returns the lease, or nil if it is held by
+// another node.
+func (r *Registry) maybeAcquireSchedulerLease(
+	ctx context.Context, leaseManager *leasemanager.LeaseManager, lease *leasemanager.Lease,
+) (*leasemanager.Lease, error) {
+	if lease != nil {
+		err := leaseManager.ExtendLease(ctx, lease)
+		if err == nil {
+			return lease, nil
+		}
+		// The lease may have expired and been taken over by another node.
+		log.VEventf(ctx, 1, "unable to extend the scheduler lease: %v", err)
+	}
+	lease, err := leaseManager.AcquireLease(ctx, keys.JobsSchedulerLease)
+	if _, ok := err.(*leasemanager.LeaseNotAvailableError); ok {
+		return nil, nil
+	}
+	return lease, err
+}
+
+func (r *Registry) executeSchedules(ctx context.Context) error {
+	now := r.clock.Now().GoTime()
+	limit := schedulerMaxJobsPerIterationSetting.Get(&r.settings.SV)
+	ids, err := loadDueScheduledJobIDs(ctx, r.ex, now, limit)
+	if err != nil {
+		return err
+	}
+
+	executed := 0
+	for _, id := range ids {
+		var ran bool
+		if err := r.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			var err error
+			ran, err = r.executeSchedule(ctx, id, now, txn)
+			return err
+		}); err != nil {
+			// The schedule is left as is, so that it is retried in the next scan.
+			log.Errorf(ctx, "error executing schedule %d: %v", id, err)
+			continue
+		}
+		if ran {
+			executed++
+		}
+	}
+
+	if executed > 0 {
+		// Let the registry know that there are new jobs to start.
+		select {
+		case r.adoptionCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// executeSchedule executes the schedule if it is still due, and advances it to
+// its next run. It returns whether the schedule was executed.
+func (r *Registry) executeSchedule(
+	ctx context.Context, id int64, now time.Time, txn *client.Txn,
+) (bool, error) {
+	schedule, err := LoadScheduledJob(ctx, r.ex, id, txn)
+	if err != nil {
+		return false, err
+	}
+	// The schedule may have been executed, paused or changed since it was
+	// found to be due.
+	if schedule.IsPaused() || schedule.NextRun().After(now) {
+		return false, nil
+	}
+	executor, err := GetScheduledJobExecutor(schedule.ExecutorType())
+	if err != nil {
+		return false, err
+	}
+	log.Infof(ctx, "executing schedule %d (%s)", schedule.ScheduleID(), schedule.ScheduleName())
+	if err := executor.ExecuteJob(ctx, r.ex, schedule, txn); err != nil {
+		return false, err
+	}
+	if err := schedule.ScheduleNextRun(now); err != nil {
+		return false, err
+	}
+	return true, schedule.Update(ctx, r.ex, txn)
+}
@@ -0,0 +1,258 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sqlmigrations/leasemanager"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+const testScheduleExecutorName = "test-executor"
+
+// countingScheduleExecutor counts how many times each schedule is executed.
+type countingScheduleExecutor struct {
+	syncutil.Mutex
+	executed map[int64]int
+}
+
+var testScheduleExecutor = func() *countingScheduleExecutor {
+	ex := &countingScheduleExecutor{executed: make(map[int64]int)}
+	RegisterScheduledJobExecutor(testScheduleExecutorName, ex)
+	return ex
+}()
+
+// ExecuteJob implements the ScheduledJobExecutor interface.
+func (e *countingScheduleExecutor) ExecuteJob(
+	_ context.Context, _ sqlutil.InternalExecutor, schedule *ScheduledJob, _ *client.Txn,
+) error {
+	e.Lock()
+	defer e.Unlock()
+	e.executed[schedule.ScheduleID()]++
+	return nil
+}
+
+func (e *countingScheduleExecutor) numExecuted(id int64) int {
+	e.Lock()
+	defer e.Unlock()
+	return e.executed[id]
+}
+
+// schedulerTestEnv runs a registry whose scheduler is driven by a manual
+// clock. The scheduler of the test server itself is disabled, so that only
+// the test registry executes schedules.
+type schedulerTestEnv struct {
+	mClock   *hlc.ManualClock
+	settings *cluster.Settings
+	registry *Registry
+	ex       sqlutil.InternalExecutor
+	db       *client.DB
+}
+
+func newSchedulerTestEnv(t *testing.T, s serverutils.TestServerInterface) *schedulerTestEnv {
+	t.Helper()
+	schedulerEnabledSetting.Override(&s.ClusterSettings().SV, false)
+
+	// Start half way through an hour, so that hourly schedules are not due yet.
+	start := time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC)
+	mClock := hlc.NewManualClock(start.UnixNano())
+	clock := hlc.NewClock(mClock.UnixNano, time.Nanosecond)
+	st := cluster.MakeTestingClusterSettings()
+	ex := s.InternalExecutor().(sqlutil.InternalExecutor)
+	// Not using the server.DefaultHistogramWindowInterval constant because
+	// of a dep cycle.
+	const histogramWindowInterval = 60 * time.Second
+	registry := MakeRegistry(
+		log.AmbientContext{}, s.Stopper(), clock, s.DB(), ex, FakeNodeID, st,
+		histogramWindowInterval, FakePHS, "")
+	return &schedulerTestEnv{
+		mClock:   mClock,
+		settings: st,
+		registry: registry,
+		ex:       ex,
+		db:       s.DB(),
+	}
+}
+
+func (env *schedulerTestEnv) now() time.Time {
+	return env.registry.clock.Now().GoTime()
+}
+
+func (env *schedulerTestEnv) createSchedule(
+	t *testing.T, name string, expr string, paused bool,
+) *ScheduledJob {
+	t.Helper()
+	schedule := NewScheduledJob(name, "root", testScheduleExecutorName, nil /* executionArgs */)
+	if err := schedule.SetSchedule(expr, env.now()); err != nil {
+		t.Fatal(err)
+	}
+	if paused {
+		schedule.Pause()
+	}
+	if err := env.db.Txn(context.Background(), func(ctx context.Context, txn *client.Txn) error {
+		return schedule.Create(ctx, env.ex, txn)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return schedule
+}
+
+func (env *schedulerTestEnv) loadSchedule(t *testing.T, id int64) *ScheduledJob {
+	t.Helper()
+	schedule, err := LoadScheduledJob(context.Background(), env.ex, id, nil /* txn */)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schedule
+}
+
+func TestJobSchedulerExecutesDueSchedules(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	env := newSchedulerTestEnv(t, s)
+	hourly := env.createSchedule(t, "hourly", "@hourly", false /* paused */)
+	paused := env.createSchedule(t, "paused", "@hourly", true /* paused */)
+
+	expectExecuted := func(schedule *ScheduledJob, expected int) {
+		t.Helper()
+		if n := testScheduleExecutor.numExecuted(schedule.ScheduleID()); n != expected {
+			t.Errorf("expected schedule %q to be executed %d times, but was executed %d times",
+				schedule.ScheduleName(), expected, n)
+		}
+	}
+
+	// Nothing is due before the top of the hour.
+	if err := env.registry.executeSchedules(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expectExecuted(hourly, 0)
+	expectExecuted(paused, 0)
+
+	// Once the hourly schedule is due, it is executed and advanced to its next
+	// run. The paused schedule stays paused.
+	env.mClock.Increment(time.Hour.Nanoseconds())
+	if err := env.registry.executeSchedules(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expectExecuted(hourly, 1)
+	expectExecuted(paused, 0)
+
+	expectedNextRun := time.Date(2020, 1, 1, 2, 0, 0, 0, time.UTC)
+	if nextRun := env.loadSchedule(t, hourly.ScheduleID()).NextRun(); !nextRun.Equal(expectedNextRun) {
+		t.Errorf("expected next run %s, found %s", expectedNextRun, nextRun)
+	}
+	if !env.loadSchedule(t, paused.ScheduleID()).IsPaused() {
+		t.Error("expected paused schedule to remain paused")
+	}
+
+	// The schedule isn't executed again until its next run.
+	if err := env.registry.executeSchedules(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expectExecuted(hourly, 1)
+
+	env.mClock.Increment(time.Hour.Nanoseconds())
+	if err := env.registry.executeSchedules(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expectExecuted(hourly, 2)
+	expectExecuted(paused, 0)
+}
+
+func TestJobSchedulerRunsPeriodically(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	env := newSchedulerTestEnv(t, s)
+	schedulerPaceSetting.Override(&env.settings.SV, time.Millisecond)
+	hourly := env.createSchedule(t, "hourly", "@hourly", false /* paused */)
+
+	s.Stopper().RunWorker(ctx, func(ctx context.Context) {
+		env.registry.runScheduler(ctx, s.Stopper())
+	})
+
+	// Each time the clock reaches the next run of the schedule, the scheduler
+	// picks it up on its own.
+	for i := 1; i <= 3; i++ {
+		env.mClock.Increment(time.Hour.Nanoseconds())
+		testutils.SucceedsSoon(t, func() error {
+			if n := testScheduleExecutor.numExecuted(hourly.ScheduleID()); n != i {
+				return errors.Errorf("expected %d executions, found %d", i, n)
+			}
+			return nil
+		})
+	}
+}
+
+func TestJobSchedulerRequiresLease(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	env := newSchedulerTestEnv(t, s)
+	schedulerPaceSetting.Override(&env.settings.SV, time.Millisecond)
+	hourly := env.createSchedule(t, "hourly", "@hourly", false /* paused */)
+
+	// Another node holds the scheduler lease.
+	otherManager := leasemanager.New(env.db, env.registry.clock, leasemanager.Options{
+		LeaseDuration: 24 * time.Hour,
+	})
+	otherLease, err := otherManager.AcquireLease(ctx, keys.JobsSchedulerLease)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Stopper().RunWorker(ctx, func(ctx context.Context) {
+		env.registry.runScheduler(ctx, s.Stopper())
+	})
+
+	// The schedule is due, but is not executed while the lease is held
+	// elsewhere.
+	env.mClock.Increment(time.Hour.Nanoseconds())
+	time.Sleep(50 * time.Millisecond)
+	if n := testScheduleExecutor.numExecuted(hourly.ScheduleID()); n != 0 {
+		t.Fatalf("expected no executions, found %d", n)
+	}
+
+	// Once the lease is released, the scheduler takes it over.
+	if err := otherManager.ReleaseLease(ctx, otherLease); err != nil {
+		t.Fatal(err)
+	}
+	testutils.SucceedsSoon(t, func() error {
+		if n := testScheduleExecutor.numExecuted(hourly.ScheduleID()); n != 1 {
+			return errors.Errorf("expected 1 execution, found %d", n)
+		}
+		return nil
+	})
+}
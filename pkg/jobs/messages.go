@@ -0,0 +1,137 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/errors"
+)
+
+// MessageKind is the kind of a message recorded in system.job_messages.
+type MessageKind string
+
+const (
+	// MessageKindInfo is for informational messages about a job's progress.
+	MessageKindInfo MessageKind = "info"
+	// MessageKindError is for errors encountered by a job, including those it
+	// retried or recovered from.
+	MessageKindError MessageKind = "error"
+	// MessageKindTrace is for traces of a job's execution captured with
+	// Registry.CaptureTrace.
+	MessageKindTrace MessageKind = "trace"
+)
+
+// maxTraceMessageSize is the maximum size, in bytes, of a trace stored in a
+// job's messages. Traces are unbounded, so longer ones are truncated.
+const maxTraceMessageSize = 1 << 20 // 1 MiB
+
+var maxMessagesPerJob = settings.RegisterPositiveIntSetting(
+	"jobs.messages.max_per_job",
+	"the maximum number of messages retained in system.job_messages for each job;"+
+		" older messages are deleted first",
+	100,
+)
+
+// RecordMessage appends a message to the job's log in system.job_messages,
+// deleting the job's oldest messages if it would otherwise have more than
+// jobs.messages.max_per_job of them. Messages are written outside of any
+// transaction the job is used with, so that they are kept even if that
+// transaction aborts.
+func (j *Job) RecordMessage(ctx context.Context, kind MessageKind, message string) error {
+	if j.id == nil {
+		return errors.New("job has not been created")
+	}
+	if !cluster.Version.IsActive(ctx, j.registry.settings, cluster.VersionJobObservability) {
+		return nil
+	}
+	const insertStmt = `INSERT INTO system.job_messages (job_id, kind, message) VALUES ($1, $2, $3)`
+	if _, err := j.registry.ex.Exec(
+		ctx, "job-record-message", nil /* txn */, insertStmt, *j.id, string(kind), message,
+	); err != nil {
+		return errors.Wrapf(err, "job %d: recording message", *j.id)
+	}
+	// message_id is unique across jobs, so it identifies the messages past
+	// the limit on its own.
+	const trimStmt = `
+DELETE FROM system.job_messages
+WHERE job_id = $1 AND message_id IN (
+	SELECT message_id FROM system.job_messages
+	WHERE job_id = $1
+	ORDER BY written DESC, message_id DESC
+	OFFSET $2
+)`
+	if _, err := j.registry.ex.Exec(
+		ctx, "job-trim-messages", nil /* txn */, trimStmt,
+		*j.id, maxMessagesPerJob.Get(&j.registry.settings.SV),
+	); err != nil {
+		return errors.Wrapf(err, "job %d: trimming messages", *j.id)
+	}
+	return nil
+}
+
+// truncateTraceMessage truncates a trace to at most maxTraceMessageSize bytes,
+// plus a note that it was truncated, without splitting a UTF-8 sequence.
+func truncateTraceMessage(trace string) string {
+	if len(trace) <= maxTraceMessageSize {
+		return trace
+	}
+	n := maxTraceMessageSize
+	for n > 0 && !utf8.RuneStart(trace[n]) {
+		n--
+	}
+	return trace[:n] + "\n... (trace truncated)"
+}
+
+// ProcessorProgress is the progress of one of the processors a job runs on a
+// node, as recorded in system.job_processor_progress.
+type ProcessorProgress struct {
+	NodeID      roachpb.NodeID
+	ProcessorID int32
+	// Processor is a human-readable name of the kind of processor.
+	Processor         string
+	FractionCompleted float32
+	RunningStatus     RunningStatus
+}
+
+// RecordProcessorProgress records the progress of one of the job's
+// processors, replacing what was previously recorded for it. Like messages,
+// processor progress is written outside of any transaction the job is used
+// with.
+func (j *Job) RecordProcessorProgress(ctx context.Context, p ProcessorProgress) error {
+	if j.id == nil {
+		return errors.New("job has not been created")
+	}
+	if !cluster.Version.IsActive(ctx, j.registry.settings, cluster.VersionJobObservability) {
+		return nil
+	}
+	var runningStatus interface{}
+	if p.RunningStatus != "" {
+		runningStatus = string(p.RunningStatus)
+	}
+	const upsertStmt = `
+UPSERT INTO system.job_processor_progress
+	(job_id, node_id, processor_id, processor, updated, fraction_completed, running_status)
+VALUES ($1, $2, $3, $4, now(), $5, $6)`
+	if _, err := j.registry.ex.Exec(
+		ctx, "job-record-processor-progress", nil /* txn */, upsertStmt,
+		*j.id, int64(p.NodeID), int64(p.ProcessorID), p.Processor,
+		float64(p.FractionCompleted), runningStatus,
+	); err != nil {
+		return errors.Wrapf(err, "job %d: recording progress of processor %d on node %d",
+			*j.id, p.ProcessorID, p.NodeID)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+)
+
+func TestJobRecordMessagesAndProcessorProgress(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	registry := s.JobRegistry().(*jobs.Registry)
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, `SET CLUSTER SETTING jobs.messages.max_per_job = 3`)
+
+	job := registry.NewJob(jobs.Record{
+		Details:  jobspb.ImportDetails{},
+		Progress: jobspb.ImportProgress{},
+	})
+	if err := job.Created(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the most recent messages are retained.
+	for i := 0; i < 5; i++ {
+		if err := job.RecordMessage(ctx, jobs.MessageKindInfo, fmt.Sprintf("message %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.CheckQueryResults(t, fmt.Sprintf(
+		`SELECT kind, message FROM system.job_messages WHERE job_id = %d ORDER BY written, message_id`,
+		*job.ID()),
+		[][]string{{"info", "message 2"}, {"info", "message 3"}, {"info", "message 4"}},
+	)
+
+	// Recording the progress of a processor replaces what was recorded for it.
+	for _, p := range []jobs.ProcessorProgress{
+		{NodeID: 1, ProcessorID: 2, Processor: "test", FractionCompleted: 0.25},
+		{NodeID: 1, ProcessorID: 2, Processor: "test", FractionCompleted: 0.75, RunningStatus: "working"},
+		{NodeID: 1, ProcessorID: 3, Processor: "test", FractionCompleted: 0.5},
+	} {
+		if err := job.RecordProcessorProgress(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.CheckQueryResults(t, fmt.Sprintf(
+		`SELECT node_id, processor_id, fraction_completed, COALESCE(running_status, 'NULL')
+		 FROM system.job_processor_progress WHERE job_id = %d ORDER BY processor_id`, *job.ID()),
+		[][]string{{"1", "2", "0.75", "working"}, {"1", "3", "0.5", "NULL"}},
+	)
+}
+
+func TestRegistryCaptureTrace(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer jobs.ResetConstructors()()
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	registry := s.JobRegistry().(*jobs.Registry)
+	db := sqlutils.MakeSQLRunner(sqlDB)
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+	jobs.RegisterConstructor(jobspb.TypeImport, func(_ *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+		return jobs.FakeResumer{
+			OnResume: func(ctx context.Context) error {
+				close(started)
+				for {
+					_, span := tracing.ChildSpan(ctx, "test-job-operation")
+					span.Finish()
+					select {
+					case <-done:
+						return nil
+					case <-time.After(10 * time.Millisecond):
+					}
+				}
+			},
+		}
+	})
+
+	job, errCh, err := registry.CreateAndStartJob(ctx, nil /* resultsCh */, jobs.Record{
+		Details:  jobspb.ImportDetails{},
+		Progress: jobspb.ImportProgress{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	rec, err := registry.CaptureTrace(ctx, *job.ID(), 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(rec.String(), "test-job-operation") {
+		t.Fatalf("expected operations of the job in the trace, got:\n%s", rec)
+	}
+	db.CheckQueryResults(t, fmt.Sprintf(
+		`SELECT count(*) FROM system.job_messages WHERE job_id = %d AND kind = 'trace'`, *job.ID()),
+		[][]string{{"1"}},
+	)
+
+	close(done)
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.CaptureTrace(ctx, *job.ID(), time.Millisecond); !testutils.IsError(
+		err, "is not running on this node",
+	) {
+		t.Fatalf("expected error capturing the trace of a finished job, got %v", err)
+	}
+}
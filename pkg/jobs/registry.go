@@ -36,7 +36,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/logtags"
 	opentracing "github.com/opentracing/opentracing-go"
 )
 
@@ -118,6 +120,12 @@ type Registry struct {
 		// propagated to jobs via the .Progressed call. This function should not be
 		// used to cancel a job in that way.
 		jobs map[int64]context.CancelFunc
+		// spans holds the root span of each of the jobs in jobs, so that traces
+		// of their execution can be captured (see CaptureTrace).
+		spans map[int64]opentracing.Span
+		// capturing holds the jobs a trace of which is being captured by
+		// CaptureTrace, so that at most one capture per job runs at a time.
+		capturing map[int64]struct{}
 	}
 
 	TestingResumerCreationKnobs map[jobspb.Type]func(Resumer) Resumer
@@ -170,6 +178,8 @@ func MakeRegistry(
 	}
 	r.mu.epoch = 1
 	r.mu.jobs = make(map[int64]context.CancelFunc)
+	r.mu.spans = make(map[int64]opentracing.Span)
+	r.mu.capturing = make(map[int64]struct{})
 	r.metrics.InitHooks(histogramWindowInterval)
 	return r
 }
@@ -365,7 +375,8 @@ const gcInterval = 1 * time.Hour
 
 // Start polls the current node for liveness failures and cancels all registered
 // jobs if it observes a failure. Otherwise it starts all the main daemons of
-// registry that poll the jobs table and start/cancel/gc jobs.
+// registry that poll the jobs table and start/cancel/gc jobs, as well as the
+// scheduler that creates jobs for the schedules in the scheduled_jobs table.
 func (r *Registry) Start(
 	ctx context.Context,
 	stopper *stop.Stopper,
@@ -423,6 +434,10 @@ func (r *Registry) Start(
 			}
 		}
 	})
+
+	stopper.RunWorker(context.Background(), func(ctx context.Context) {
+		r.runScheduler(ctx, stopper)
+	})
 	return nil
 }
 
@@ -535,6 +550,16 @@ func (r *Registry) cleanupOldJobs(ctx context.Context, olderThan time.Time) erro
 			return errors.Errorf("asked to delete %d rows but %d were actually deleted",
 				len(toDelete.Array), nDeleted)
 		}
+		if cluster.Version.IsActive(ctx, r.settings, cluster.VersionJobObservability) {
+			for _, stmt := range []string{
+				`DELETE FROM system.job_messages WHERE job_id = ANY($1)`,
+				`DELETE FROM system.job_processor_progress WHERE job_id = ANY($1)`,
+			} {
+				if _, err := r.ex.Exec(ctx, "gc-jobs", nil /* txn */, stmt, toDelete); err != nil {
+					return errors.Wrap(err, "deleting messages and progress of old jobs")
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -702,6 +727,7 @@ func (r *Registry) stepThroughStateMachine(
 			// mark the job as failed because it can be resumed by another node.
 			return errors.Errorf("job %d: node liveness error: restarting in background", *job.ID())
 		}
+		r.recordErrorMessage(ctx, job, err)
 		// TODO(spaskob): enforce a limit on retries.
 		if e, ok := err.(retryJobError); ok {
 			return errors.Errorf("job %d: %s: restarting in background", *job.ID(), e)
@@ -713,6 +739,31 @@ func (r *Registry) stepThroughStateMachine(
 			}
 			return err
 		}
+		// Retryable errors are retried according to the retry policy of the job's
+		// type. The job keeps its lease and is resumed here from its last
+		// checkpointed progress once its backoff has elapsed, so that a client
+		// waiting for the job only sees its final outcome. Should this node go
+		// away in the meantime, the adoption loop of another node respects the
+		// backoff before resuming the job.
+		if retry, retryErr := job.maybeRetry(ctx, err); retryErr != nil {
+			log.Warningf(ctx, "job %d: failed to record retry: %v", *job.ID(), retryErr)
+		} else if retry {
+			log.Infof(ctx, "job %d: retrying after error: %v", *job.ID(), err)
+			nextRetry := timeutil.FromUnixMicros(job.Payload().NextRetryMicros)
+			select {
+			case <-time.After(nextRetry.Sub(r.clock.PhysicalTime())):
+			case <-ctx.Done():
+				// The job keeps its retry state, so that whichever node resumes it
+				// next respects the backoff.
+				return errors.Wrapf(ctx.Err(),
+					"job %d: interrupted while waiting to retry: restarting in background", *job.ID())
+			}
+			resumer, err := r.createResumer(job, r.settings)
+			if err != nil {
+				return err
+			}
+			return r.stepThroughStateMachine(ctx, phs, resumer, resultsCh, job, StatusRunning, nil)
+		}
 		return r.stepThroughStateMachine(ctx, phs, resumer, resultsCh, job, StatusReverting, err)
 	case StatusPauseRequested:
 		return errors.Errorf("job %s", status)
@@ -765,6 +816,7 @@ func (r *Registry) stepThroughStateMachine(
 			// mark the job as failed because it can be resumed by another node.
 			return errors.Errorf("job %d: node liveness error: restarting in background", *job.ID())
 		}
+		r.recordErrorMessage(ctx, job, err)
 		if e, ok := err.(retryJobError); ok {
 			return errors.Errorf("job %d: %s: restarting in background", *job.ID(), e)
 		}
@@ -793,6 +845,14 @@ func (r *Registry) stepThroughStateMachine(
 	}
 }
 
+// recordErrorMessage records an error returned by a job's resumer in the job's
+// messages. Failing to do so doesn't affect the job.
+func (r *Registry) recordErrorMessage(ctx context.Context, job *Job, jobErr error) {
+	if err := job.RecordMessage(ctx, MessageKindError, jobErr.Error()); err != nil {
+		log.Warningf(ctx, "job %d: failed to record error: %v", *job.ID(), err)
+	}
+}
+
 // resume starts or resumes a job. If no error is returned then the job was
 // asynchronously executed. The job is executed with the ctx, so ctx must
 // only by canceled if the job should also be canceled. resultsCh is passed
@@ -810,8 +870,17 @@ func (r *Registry) resume(
 		defer cleanup()
 		spanName := fmt.Sprintf(`%s-%d`, payload.Type(), *job.ID())
 		var span opentracing.Span
-		ctx, span = r.ac.AnnotateCtxWithSpan(ctx, spanName)
+		if tracer, ok := r.ac.Tracer.(*tracing.Tracer); ok {
+			// The job gets its own recordable root span, so that its execution can
+			// be traced on demand with CaptureTrace.
+			ctx = r.ac.AnnotateCtx(ctx)
+			span = tracer.StartRootSpan(spanName, logtags.FromContext(ctx), tracing.RecordableSpan)
+			ctx = opentracing.ContextWithSpan(ctx, span)
+		} else {
+			ctx, span = r.ac.AnnotateCtxWithSpan(ctx, spanName)
+		}
 		defer span.Finish()
+		r.setSpan(*job.ID(), span)
 
 		// Run the actual job.
 		status, err := job.CurrentStatus(ctx)
@@ -982,6 +1051,13 @@ WHERE status IN ($1, $2, $3, $4, $5) ORDER BY created DESC`
 			}
 		}
 		// Below we know that this node holds the lease on the job.
+		if status == StatusRunning && !runningOnNode &&
+			payload.NextRetryMicros > timeutil.ToUnixMicros(r.clock.PhysicalTime()) {
+			if log.V(2) {
+				log.Infof(ctx, "job %d: skipping: backing off before retry %d", *id, payload.NumRetries)
+			}
+			continue
+		}
 		job := &Job{id: id, registry: r}
 		resumeCtx, cancel := r.makeCtx()
 
@@ -1077,6 +1153,7 @@ func (r *Registry) cancelAll(ctx context.Context) {
 		cancel()
 	}
 	r.mu.jobs = make(map[int64]context.CancelFunc)
+	r.mu.spans = make(map[int64]opentracing.Span)
 }
 
 // register registers an about to be resumed job in memory so that it can be
@@ -1104,4 +1181,74 @@ func (r *Registry) unregister(jobID int64) {
 		cancel()
 		delete(r.mu.jobs, jobID)
 	}
+	delete(r.mu.spans, jobID)
+}
+
+// setSpan records the root span of a job registered with register.
+func (r *Registry) setSpan(jobID int64, span opentracing.Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.mu.jobs[jobID]; ok {
+		r.mu.spans[jobID] = span
+	}
+}
+
+// CaptureTrace records a trace of the given job, which must be running on
+// this node, for the given duration. The trace is also stored in the job's
+// messages, with kind MessageKindTrace, truncated to maxTraceMessageSize.
+// Only one trace of a job can be captured at a time.
+//
+// Only operations the job starts while the trace is captured are recorded,
+// along with the operations its DistSQL processors start on other nodes if
+// their flows were set up during the capture.
+func (r *Registry) CaptureTrace(
+	ctx context.Context, jobID int64, duration time.Duration,
+) (tracing.Recording, error) {
+	span, err := r.startCapturingTrace(jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.mu.capturing, jobID)
+	}()
+
+	tracing.StartRecording(span, tracing.SnowballRecording)
+	select {
+	case <-time.After(duration):
+	case <-ctx.Done():
+		tracing.StopRecording(span)
+		return nil, ctx.Err()
+	}
+	rec := tracing.GetRecording(span)
+	tracing.StopRecording(span)
+
+	job, err := r.LoadJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := job.RecordMessage(ctx, MessageKindTrace, truncateTraceMessage(rec.String())); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// startCapturingTrace marks a trace of the given job as being captured, and
+// returns the job's root span to capture it from.
+func (r *Registry) startCapturingTrace(jobID int64) (opentracing.Span, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	span, ok := r.mu.spans[jobID]
+	if !ok {
+		return nil, errors.Errorf("job %d is not running on this node", jobID)
+	}
+	if !tracing.IsRecordable(span) {
+		return nil, errors.Errorf("job %d cannot be traced", jobID)
+	}
+	if _, ok := r.mu.capturing[jobID]; ok || tracing.IsRecording(span) {
+		return nil, errors.Errorf("a trace of job %d is already being captured", jobID)
+	}
+	r.mu.capturing[jobID] = struct{}{}
+	return span, nil
 }
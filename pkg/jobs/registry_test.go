@@ -14,8 +14,10 @@ import (
 	"context"
 	"math"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
@@ -344,3 +346,25 @@ CREATE DATABASE IF NOT EXISTS t; CREATE TABLE IF NOT EXISTS t.to_be_mutated AS S
 		}
 	}
 }
+
+func TestTruncateTraceMessage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	short := "short trace"
+	if got := truncateTraceMessage(short); got != short {
+		t.Fatalf("expected short trace to be kept as is, got %q", got)
+	}
+
+	// A multi-byte character straddling the limit is dropped entirely.
+	long := strings.Repeat("a", maxTraceMessageSize-1) + "é" + strings.Repeat("b", 10)
+	got := truncateTraceMessage(long)
+	if !strings.HasSuffix(got, "... (trace truncated)") {
+		t.Fatalf("expected truncated trace to be marked as such, got suffix %q", got[len(got)-30:])
+	}
+	if !utf8.ValidString(got) {
+		t.Fatal("expected truncated trace to be valid UTF-8")
+	}
+	if trace := strings.TrimSuffix(got, "\n... (trace truncated)"); trace != long[:maxTraceMessageSize-1] {
+		t.Fatalf("expected trace to be truncated to %d bytes, got %d", maxTraceMessageSize-1, len(trace))
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// RetryPolicy determines how a job is retried when its resumer fails with a
+// retryable error (see IsRetryableError). A retried job is resumed once its
+// backoff has elapsed, from the progress it last checkpointed.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a job is retried before it fails. A
+	// zero RetryPolicy never retries jobs.
+	MaxRetries int
+	// InitialBackoff is the backoff before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum backoff between retries.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the backoff grows by with every retry.
+	Multiplier float64
+}
+
+// DefaultRetryPolicy is a retry policy suitable for long-running bulk jobs,
+// like IMPORT, BACKUP and RESTORE.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     8,
+	InitialBackoff: 30 * time.Second,
+	MaxBackoff:     10 * time.Minute,
+	Multiplier:     2,
+}
+
+// backoff returns the backoff before the given retry, starting at 1.
+func (p RetryPolicy) backoff(retry int32) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(retry-1))
+	if maxBackoff := float64(p.MaxBackoff); backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+var retryPolicies = make(map[jobspb.Type]RetryPolicy)
+
+// RegisterRetryPolicy registers the retry policy of a certain job type. Jobs
+// of types without a retry policy are not retried, unless their resumer
+// returns an error created with NewRetryJobError.
+func RegisterRetryPolicy(typ jobspb.Type, policy RetryPolicy) {
+	retryPolicies[typ] = policy
+}
+
+// TestingSetRetryPolicy overrides the retry policy of a certain job type, and
+// returns a function that restores the previous one.
+func TestingSetRetryPolicy(typ jobspb.Type, policy RetryPolicy) func() {
+	old, ok := retryPolicies[typ]
+	retryPolicies[typ] = policy
+	return func() {
+		if ok {
+			retryPolicies[typ] = old
+		} else {
+			delete(retryPolicies, typ)
+		}
+	}
+}
+
+// errRetryable marks errors that resumers know to be retryable.
+var errRetryable = errors.New("retryable job error")
+
+// MarkRetryableError marks err as retryable, such that a job whose resumer
+// returns it is retried according to the retry policy of its type.
+func MarkRetryableError(err error) error {
+	return errors.Mark(err, errRetryable)
+}
+
+// IsRetryableError returns whether a job whose resumer failed with err can be
+// retried. Retryable errors are those marked with MarkRetryableError, the
+// transient errors of external storage, ambiguous results, and errors
+// resulting from the loss of a node or of a connection to it.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, errRetryable) || cloud.IsTransientError(err) {
+		return true
+	}
+	var ambiguousErr *roachpb.AmbiguousResultError
+	var unavailableErr *roachpb.NodeUnavailableError
+	if errors.As(err, &ambiguousErr) || errors.As(err, &unavailableErr) {
+		return true
+	}
+	switch pgerror.GetPGCode(err) {
+	case pgcode.InternalConnectionFailure, pgcode.DeprecatedInternalConnectionFailure:
+		return true
+	}
+	// When a node dies, the DistSQL flows with processors scheduled on it fail
+	// with the gRPC error of the broken connection.
+	if s, ok := grpcstatus.FromError(errors.UnwrapAll(err)); ok && s.Code() == codes.Unavailable {
+		return true
+	}
+	return false
+}
+
+// maybeRetry records that the job is to be retried after its resumer failed
+// with jobErr, if jobErr is retryable and the job hasn't exhausted the retries
+// allowed by the retry policy of its type. It returns whether the job is to be
+// retried.
+func (j *Job) maybeRetry(ctx context.Context, jobErr error) (bool, error) {
+	payload := j.Payload()
+	policy, ok := retryPolicies[payload.Type()]
+	if !ok || policy.MaxRetries <= 0 || !IsRetryableError(jobErr) {
+		return false, nil
+	}
+	var retry bool
+	err := j.Update(ctx, func(_ *client.Txn, md JobMetadata, ju *JobUpdater) error {
+		if md.Status != StatusRunning {
+			// The job was paused or canceled in the meantime.
+			return nil
+		}
+		if int(md.Payload.NumRetries) >= policy.MaxRetries {
+			return nil
+		}
+		md.Payload.NumRetries++
+		nextRetry := j.registry.clock.PhysicalTime().Add(policy.backoff(md.Payload.NumRetries))
+		md.Payload.NextRetryMicros = timeutil.ToUnixMicros(nextRetry)
+		encodedErr := errors.EncodeError(ctx, jobErr)
+		md.Payload.ResumeErrors = append(md.Payload.ResumeErrors, &encodedErr)
+		ju.UpdatePayload(md.Payload)
+		retry = true
+		return nil
+	})
+	return retry, err
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{errors.New("boom"), false},
+		{context.Canceled, false},
+		{jobs.MarkRetryableError(errors.New("boom")), true},
+		{errors.Wrap(jobs.MarkRetryableError(errors.New("boom")), "wrapped"), true},
+		{roachpb.NewAmbiguousResultError("boom"), true},
+		{errors.Wrap(&roachpb.NodeUnavailableError{}, "wrapped"), true},
+		{errors.Wrap(grpcstatus.Error(codes.Unavailable, "transport is closing"), "wrapped"), true},
+		{grpcstatus.Error(codes.InvalidArgument, "boom"), false},
+		// Errors aren't classified by their messages.
+		{errors.New("rpc error: code = Unavailable desc = transport is closing"), false},
+		{errors.New("result is ambiguous"), false},
+	} {
+		t.Run(fmt.Sprint(tc.err), func(t *testing.T) {
+			if retryable := jobs.IsRetryableError(tc.err); retryable != tc.retryable {
+				t.Fatalf("expected retryable=%t, got %t", tc.retryable, retryable)
+			}
+		})
+	}
+}
+
+func TestJobRetryPolicy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer jobs.ResetConstructors()()
+	defer func(oldInterval time.Duration) {
+		jobs.DefaultAdoptInterval = oldInterval
+	}(jobs.DefaultAdoptInterval)
+	jobs.DefaultAdoptInterval = 10 * time.Millisecond
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	registry := s.JobRegistry().(*jobs.Registry)
+	db := sqlutils.MakeSQLRunner(sqlDB)
+
+	// failures is the number of times the job fails before succeeding, and
+	// resumes is the number of times it was resumed.
+	var failures, resumes int32
+	jobs.RegisterConstructor(jobspb.TypeImport, func(_ *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+		return jobs.FakeResumer{
+			OnResume: func(context.Context) error {
+				if atomic.AddInt32(&resumes, 1) <= atomic.LoadInt32(&failures) {
+					return jobs.MarkRetryableError(errors.New("injected transient error"))
+				}
+				return nil
+			},
+		}
+	})
+	defer jobs.TestingSetRetryPolicy(jobspb.TypeImport, jobs.RetryPolicy{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		Multiplier:     2,
+	})()
+
+	runJob := func(t *testing.T, numFailures int32) (*jobs.Job, string) {
+		atomic.StoreInt32(&failures, numFailures)
+		atomic.StoreInt32(&resumes, 0)
+		job, errCh, err := registry.CreateAndStartJob(ctx, nil /* resultsCh */, jobs.Record{
+			Details:  jobspb.ImportDetails{},
+			Progress: jobspb.ImportProgress{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The client only sees the final outcome of the job, not the errors
+		// that were retried.
+		err = <-errCh
+		if numFailures <= 2 {
+			if err != nil {
+				t.Fatal(err)
+			}
+		} else if !testutils.IsError(err, "injected transient error") {
+			t.Fatalf("expected the job to fail with the injected error, got %v", err)
+		}
+		var status string
+		db.QueryRow(t, `SELECT status FROM system.jobs WHERE id = $1`, *job.ID()).Scan(&status)
+		job, err = registry.LoadJob(ctx, *job.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return job, status
+	}
+
+	t.Run("retries then succeeds", func(t *testing.T) {
+		job, status := runJob(t, 2)
+		if status != string(jobs.StatusSucceeded) {
+			t.Fatalf("expected job to succeed, got status %s", status)
+		}
+		if payload := job.Payload(); payload.NumRetries != 2 || len(payload.ResumeErrors) != 2 {
+			t.Fatalf("expected 2 retries and resume errors, got %d and %d",
+				payload.NumRetries, len(payload.ResumeErrors))
+		}
+		if r := atomic.LoadInt32(&resumes); r != 3 {
+			t.Fatalf("expected the job to be resumed 3 times, got %d", r)
+		}
+	})
+
+	t.Run("exhausts retries", func(t *testing.T) {
+		job, status := runJob(t, 3)
+		if status != string(jobs.StatusFailed) {
+			t.Fatalf("expected job to fail, got status %s", status)
+		}
+		if payload := job.Payload(); payload.NumRetries != 2 {
+			t.Fatalf("expected 2 retries, got %d", payload.NumRetries)
+		}
+		if r := atomic.LoadInt32(&resumes); r != 3 {
+			t.Fatalf("expected the job to be resumed 3 times, got %d", r)
+		}
+	})
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/cron"
+	"github.com/cockroachdb/errors"
+)
+
+// ScheduledJob is a schedule stored in the system.scheduled_jobs table. Each
+// time the schedule is due, the job scheduler hands it over to the
+// ScheduledJobExecutor registered for its executor type, which is expected to
+// create the jobs that do the actual work.
+//
+// A ScheduledJob is not safe for concurrent use.
+type ScheduledJob struct {
+	id            int64
+	name          string
+	created       time.Time
+	owner         string
+	nextRun       time.Time
+	scheduleExpr  string
+	executorType  string
+	executionArgs []byte
+}
+
+// NewScheduledJob creates a new, paused schedule with the given executor type
+// and arguments. The schedule must be stored with Create.
+func NewScheduledJob(name, owner, executorType string, executionArgs []byte) *ScheduledJob {
+	return &ScheduledJob{
+		name:          name,
+		owner:         owner,
+		executorType:  executorType,
+		executionArgs: executionArgs,
+	}
+}
+
+// ScheduleID returns the ID of the schedule, or 0 if it hasn't been created.
+func (s *ScheduledJob) ScheduleID() int64 {
+	return s.id
+}
+
+// ScheduleName returns the name of the schedule.
+func (s *ScheduledJob) ScheduleName() string {
+	return s.name
+}
+
+// Created returns the time at which the schedule was created.
+func (s *ScheduledJob) Created() time.Time {
+	return s.created
+}
+
+// Owner returns the user on whose behalf the schedule runs.
+func (s *ScheduledJob) Owner() string {
+	return s.owner
+}
+
+// NextRun returns the time the schedule is next due, or the zero time if the
+// schedule is paused.
+func (s *ScheduledJob) NextRun() time.Time {
+	return s.nextRun
+}
+
+// ScheduleExpr returns the cron expression of the schedule.
+func (s *ScheduledJob) ScheduleExpr() string {
+	return s.scheduleExpr
+}
+
+// ExecutorType returns the name of the executor that runs the schedule.
+func (s *ScheduledJob) ExecutorType() string {
+	return s.executorType
+}
+
+// ExecutionArgs returns the executor specific arguments of the schedule.
+func (s *ScheduledJob) ExecutionArgs() []byte {
+	return s.executionArgs
+}
+
+// SetExecutionArgs replaces the executor specific arguments of the schedule.
+// Executors may use this to carry state from one run to the next.
+func (s *ScheduledJob) SetExecutionArgs(args []byte) {
+	s.executionArgs = args
+}
+
+// IsPaused returns whether the schedule is paused.
+func (s *ScheduledJob) IsPaused() bool {
+	return s.nextRun.IsZero()
+}
+
+// Pause pauses the schedule. A paused schedule is not run until it is
+// resumed.
+func (s *ScheduledJob) Pause() {
+	s.nextRun = time.Time{}
+}
+
+// SetSchedule validates the cron expression and sets it as the schedule's
+// recurrence. The next run is computed relative to now.
+func (s *ScheduledJob) SetSchedule(expr string, now time.Time) error {
+	s.scheduleExpr = expr
+	return s.ScheduleNextRun(now)
+}
+
+// ScheduleNextRun sets the next run of the schedule to the first time after
+// now that matches its cron expression. This also resumes paused schedules.
+func (s *ScheduledJob) ScheduleNextRun(now time.Time) error {
+	sched, err := cron.Parse(s.scheduleExpr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid schedule for %q", s.name)
+	}
+	next := sched.Next(now)
+	if next.IsZero() {
+		return errors.Newf("schedule %q never runs", s.scheduleExpr)
+	}
+	s.nextRun = next
+	return nil
+}
+
+const scheduledJobsColumns = `schedule_id, schedule_name, created, owner, next_run, schedule_expr, executor_type, execution_args`
+
+// LoadScheduledJob loads the schedule with the given ID. An error is returned
+// if the schedule does not exist.
+func LoadScheduledJob(
+	ctx context.Context, ex sqlutil.InternalExecutor, id int64, txn *client.Txn,
+) (*ScheduledJob, error) {
+	row, err := ex.QueryRow(ctx, "load-schedule", txn,
+		`SELECT `+scheduledJobsColumns+` FROM system.scheduled_jobs WHERE schedule_id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, errors.Newf("schedule %d not found", id)
+	}
+	return scheduledJobFromRow(row)
+}
+
+// loadDueScheduledJobIDs returns the IDs of at most limit schedules whose next
+// run is at or before now, earliest first.
+func loadDueScheduledJobIDs(
+	ctx context.Context, ex sqlutil.InternalExecutor, now time.Time, limit int64,
+) ([]int64, error) {
+	rows, err := ex.Query(ctx, "find-due-schedules", nil, /* txn */
+		`SELECT schedule_id FROM system.scheduled_jobs WHERE next_run <= $1 ORDER BY next_run LIMIT $2`,
+		tree.MakeDTimestampTZ(now, time.Microsecond), limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(rows))
+	for i, row := range rows {
+		ids[i] = int64(tree.MustBeDInt(row[0]))
+	}
+	return ids, nil
+}
+
+func scheduledJobFromRow(row tree.Datums) (*ScheduledJob, error) {
+	if len(row) != 8 {
+		return nil, errors.AssertionFailedf("expected 8 columns, found %d", len(row))
+	}
+	s := &ScheduledJob{
+		id:            int64(tree.MustBeDInt(row[0])),
+		name:          string(tree.MustBeDString(row[1])),
+		created:       row[2].(*tree.DTimestampTZ).Time,
+		owner:         string(tree.MustBeDString(row[3])),
+		executorType:  string(tree.MustBeDString(row[6])),
+		executionArgs: []byte(tree.MustBeDBytes(row[7])),
+	}
+	if row[4] != tree.DNull {
+		s.nextRun = row[4].(*tree.DTimestampTZ).Time
+	}
+	if row[5] != tree.DNull {
+		s.scheduleExpr = string(tree.MustBeDString(row[5]))
+	}
+	return s, nil
+}
+
+func (s *ScheduledJob) nextRunDatum() tree.Datum {
+	if s.IsPaused() {
+		return tree.DNull
+	}
+	return tree.MakeDTimestampTZ(s.nextRun, time.Microsecond)
+}
+
+// Create stores a new schedule and assigns it an ID.
+func (s *ScheduledJob) Create(ctx context.Context, ex sqlutil.InternalExecutor, txn *client.Txn) error {
+	if s.id != 0 {
+		return errors.AssertionFailedf("schedule %d already created", s.id)
+	}
+	row, err := ex.QueryRow(ctx, "create-schedule", txn,
+		`INSERT INTO system.scheduled_jobs
+		   (schedule_name, owner, next_run, schedule_expr, executor_type, execution_args)
+		 VALUES ($1, $2, $3, $4, $5, $6) RETURNING schedule_id, created`,
+		s.name, s.owner, s.nextRunDatum(), s.scheduleExpr, s.executorType, s.executionArgs)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create schedule %q", s.name)
+	}
+	s.id = int64(tree.MustBeDInt(row[0]))
+	s.created = row[1].(*tree.DTimestampTZ).Time
+	return nil
+}
+
+// Update persists the mutable fields of the schedule: its next run, its cron
+// expression and its execution arguments.
+func (s *ScheduledJob) Update(ctx context.Context, ex sqlutil.InternalExecutor, txn *client.Txn) error {
+	n, err := ex.Exec(ctx, "update-schedule", txn,
+		`UPDATE system.scheduled_jobs SET next_run = $2, schedule_expr = $3, execution_args = $4
+		 WHERE schedule_id = $1`,
+		s.id, s.nextRunDatum(), s.scheduleExpr, s.executionArgs)
+	if err != nil {
+		return err
+	}
+	if n != 1 {
+		return errors.Newf("schedule %d not found", s.id)
+	}
+	return nil
+}
+
+// Delete removes the schedule. Jobs previously created by the schedule are
+// unaffected.
+func (s *ScheduledJob) Delete(ctx context.Context, ex sqlutil.InternalExecutor, txn *client.Txn) error {
+	n, err := ex.Exec(ctx, "delete-schedule", txn,
+		`DELETE FROM system.scheduled_jobs WHERE schedule_id = $1`, s.id)
+	if err != nil {
+		return err
+	}
+	if n != 1 {
+		return errors.Newf("schedule %d not found", s.id)
+	}
+	return nil
+}
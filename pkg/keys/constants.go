@@ -80,6 +80,9 @@ var (
 	localRangeFrozenStatusSuffix = []byte("fzn-")
 	// LocalRangeLastGCSuffix is the suffix for the last GC.
 	LocalRangeLastGCSuffix = []byte("lgc-")
+	// LocalMVCCRangeTombstoneSuffix is the suffix for MVCC range tombstones,
+	// which delete all versions of the keys in a span below a timestamp.
+	LocalMVCCRangeTombstoneSuffix = []byte("mrtb")
 	// LocalRangeAppliedStateSuffix is the suffix for the range applied state
 	// key.
 	LocalRangeAppliedStateSuffix = []byte("rask")
@@ -153,6 +156,10 @@ var (
 	// NOTE: if this value changes, it must be updated in C++
 	// (storage/engine/rocksdb/db.cc).
 	LocalTransactionSuffix = roachpb.RKey("txn-")
+	// LocalOnlineRestoreSpanSuffix is the suffix for keys storing the spans of
+	// an online RESTORE whose data hasn't been ingested yet. The value is an
+	// ImportRequest whose span is the pending span.
+	LocalOnlineRestoreSpanSuffix = roachpb.RKey("ores")
 
 	// 4. Store local keys
 	//
@@ -235,6 +242,9 @@ var (
 	// DescIDGenerator is the global descriptor ID generator sequence used for
 	// table and namespace IDs.
 	DescIDGenerator = roachpb.Key(makeKey(SystemPrefix, roachpb.RKey("desc-idgen")))
+	// JobsSchedulerLease is the key that nodes must take a lease on in order
+	// to execute the schedules stored in system.scheduled_jobs.
+	JobsSchedulerLease = roachpb.Key(makeKey(SystemPrefix, roachpb.RKey("jobs-scheduler-lease")))
 	// NodeIDGenerator is the global node ID generator sequence.
 	NodeIDGenerator = roachpb.Key(makeKey(SystemPrefix, roachpb.RKey("node-idgen")))
 	// RangeIDGenerator is the global range ID generator sequence.
@@ -363,6 +373,17 @@ const (
 
 	RoleOptionsTableID = 33
 
+	ScheduledJobsTableID = 34
+
+	JobMessagesTableID          = 35
+	JobProcessorProgressTableID = 36
+
+	StatementBundleChunksTableID        = 37
+	StatementDiagnosticsRequestsTableID = 38
+	StatementDiagnosticsTableID         = 39
+	StatementStatisticsTableID          = 40
+	TransactionStatisticsTableID        = 41
+
 	// CommentType is type for system.comments
 	DatabaseCommentType = 0
 	TableCommentType    = 1
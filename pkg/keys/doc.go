@@ -190,6 +190,7 @@ var _ = [...]interface{}{
 	//   as a whole. They are replicated and addressable. Typical examples are
 	//   the range descriptor and transaction records. They all share
 	//   `LocalRangePrefix`.
+	OnlineRestoreSpanKey,    // "ores"
 	QueueLastProcessedKey,   // "qlpt"
 	RangeDescriptorJointKey, // "rdjt"
 	RangeDescriptorKey,      // "rdsc"
@@ -219,6 +220,7 @@ var _ = [...]interface{}{
 	NodeLivenessPrefix,  // "\x00liveness-"
 	BootstrapVersionKey, // "bootstrap-version"
 	DescIDGenerator,     // "desc-idgen"
+	JobsSchedulerLease,  // "jobs-scheduler-lease"
 	NodeIDGenerator,     // "node-idgen"
 	RangeIDGenerator,    // "range-idgen"
 	StatusPrefix,        // "status-"
@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
 )
@@ -216,6 +217,58 @@ func DecodeAbortSpanKey(key roachpb.Key, dest []byte) (uuid.UUID, error) {
 	return txnID, err
 }
 
+// MVCCRangeTombstonePrefix returns the prefix of the system-local keys of
+// the MVCC range tombstones of a range.
+func MVCCRangeTombstonePrefix(rangeID roachpb.RangeID) roachpb.Key {
+	return MakeRangeIDPrefixBuf(rangeID).MVCCRangeTombstonePrefix()
+}
+
+// MVCCRangeTombstoneKey returns a system-local key for the MVCC range
+// tombstone of a range that starts at startKey and was written at the
+// supplied timestamp. The keys of the tombstones of a range sort by start key
+// and then by timestamp.
+func MVCCRangeTombstoneKey(
+	rangeID roachpb.RangeID, startKey roachpb.Key, ts hlc.Timestamp,
+) roachpb.Key {
+	return MakeRangeIDPrefixBuf(rangeID).MVCCRangeTombstoneKey(startKey, ts)
+}
+
+// DecodeMVCCRangeTombstoneKey decodes the provided MVCC range tombstone key,
+// returning the start key and the timestamp of the tombstone.
+func DecodeMVCCRangeTombstoneKey(key roachpb.Key) (roachpb.Key, hlc.Timestamp, error) {
+	_, _, suffix, detail, err := DecodeRangeIDKey(key)
+	if err != nil {
+		return nil, hlc.Timestamp{}, err
+	}
+	if !bytes.Equal(suffix, LocalMVCCRangeTombstoneSuffix) {
+		return nil, hlc.Timestamp{}, errors.Errorf("key %s does not contain the MVCC range tombstone suffix %s",
+			key, LocalMVCCRangeTombstoneSuffix)
+	}
+	return decodeMVCCRangeTombstoneDetail(detail)
+}
+
+func decodeMVCCRangeTombstoneDetail(detail []byte) (roachpb.Key, hlc.Timestamp, error) {
+	detail, startKey, err := encoding.DecodeBytesAscending(detail, nil)
+	if err != nil {
+		return nil, hlc.Timestamp{}, err
+	}
+	var ts hlc.Timestamp
+	var logical uint32
+	detail, ts.WallTime, err = encoding.DecodeVarintAscending(detail)
+	if err != nil {
+		return nil, hlc.Timestamp{}, err
+	}
+	detail, logical, err = encoding.DecodeUint32Ascending(detail)
+	if err != nil {
+		return nil, hlc.Timestamp{}, err
+	}
+	ts.Logical = int32(logical)
+	if len(detail) > 0 {
+		return nil, hlc.Timestamp{}, errors.Errorf("key has leftover bytes after decode: %s; indicates corrupt key", detail)
+	}
+	return startKey, ts, nil
+}
+
 // RangeAppliedStateKey returns a system-local key for the range applied state key.
 // This key has subsumed the responsibility of the following three keys:
 // - RaftAppliedIndexLegacyKey
@@ -397,6 +450,13 @@ func QueueLastProcessedKey(key roachpb.RKey, queue string) roachpb.Key {
 	return MakeRangeKey(key, LocalQueueLastProcessedSuffix, roachpb.RKey(queue))
 }
 
+// OnlineRestoreSpanKey returns a range-local key for the span of an online
+// RESTORE that starts at the specified key and whose data hasn't been
+// ingested yet.
+func OnlineRestoreSpanKey(key roachpb.RKey) roachpb.Key {
+	return MakeRangeKey(key, LocalOnlineRestoreSpanSuffix, nil)
+}
+
 // IsLocal performs a cheap check that returns true iff a range-local key is
 // passed, that is, a key for which `Addr` would return a non-identical RKey
 // (or a decoding error).
@@ -897,6 +957,21 @@ func (b RangeIDPrefixBuf) RangeLastGCKey() roachpb.Key {
 	return append(b.replicatedPrefix(), LocalRangeLastGCSuffix...)
 }
 
+// MVCCRangeTombstonePrefix returns the prefix of the system-local keys of
+// the MVCC range tombstones of the range.
+func (b RangeIDPrefixBuf) MVCCRangeTombstonePrefix() roachpb.Key {
+	return append(b.replicatedPrefix(), LocalMVCCRangeTombstoneSuffix...)
+}
+
+// MVCCRangeTombstoneKey returns a system-local key for an MVCC range
+// tombstone.
+func (b RangeIDPrefixBuf) MVCCRangeTombstoneKey(startKey roachpb.Key, ts hlc.Timestamp) roachpb.Key {
+	key := b.MVCCRangeTombstonePrefix()
+	key = encoding.EncodeBytesAscending(key, startKey)
+	key = encoding.EncodeVarintAscending(key, ts.WallTime)
+	return encoding.EncodeUint32Ascending(key, uint32(ts.Logical))
+}
+
 // RangeTombstoneKey returns a system-local key for a range tombstone.
 func (b RangeIDPrefixBuf) RangeTombstoneKey() roachpb.Key {
 	return append(b.unreplicatedPrefix(), LocalRangeTombstoneSuffix...)
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
@@ -110,6 +111,31 @@ func TestAbortSpanEncodeDecode(t *testing.T) {
 	}
 }
 
+func TestMVCCRangeTombstoneEncodeDecode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	const rangeID = 123
+	testStartKey := roachpb.Key("a")
+	testTS := hlc.Timestamp{WallTime: 100, Logical: 2}
+	key := MVCCRangeTombstoneKey(rangeID, testStartKey, testTS)
+	if !bytes.HasPrefix(key, MVCCRangeTombstonePrefix(rangeID)) {
+		t.Fatalf("expected key %s to have prefix %s", key, MVCCRangeTombstonePrefix(rangeID))
+	}
+	startKey, ts, err := DecodeMVCCRangeTombstoneKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !startKey.Equal(testStartKey) || ts != testTS {
+		t.Fatalf("expected %s@%s, got %s@%s", testStartKey, testTS, startKey, ts)
+	}
+	// Tombstones sort by start key and then by timestamp.
+	if later := MVCCRangeTombstoneKey(rangeID, testStartKey, testTS.Next()); bytes.Compare(key, later) >= 0 {
+		t.Fatalf("expected %s < %s", key, later)
+	}
+	if next := MVCCRangeTombstoneKey(rangeID, testStartKey.Next(), hlc.Timestamp{}); bytes.Compare(key, next) >= 0 {
+		t.Fatalf("expected %s < %s", key, next)
+	}
+}
+
 func TestKeyAddress(t *testing.T) {
 	testCases := []struct {
 		key        roachpb.Key
@@ -151,6 +151,7 @@ var (
 		psFunc func(rangeID roachpb.RangeID, input string) (string, roachpb.Key)
 	}{
 		{name: "AbortSpan", suffix: LocalAbortSpanSuffix, ppFunc: abortSpanKeyPrint, psFunc: abortSpanKeyParse},
+		{name: "MVCCRangeTombstone", suffix: LocalMVCCRangeTombstoneSuffix, ppFunc: mvccRangeTombstoneKeyPrint},
 		{name: "RangeTombstone", suffix: LocalRangeTombstoneSuffix},
 		{name: "RaftHardState", suffix: LocalRaftHardStateSuffix},
 		{name: "RangeAppliedState", suffix: LocalRangeAppliedStateSuffix},
@@ -175,6 +176,7 @@ var (
 		{name: "RangeDescriptor", suffix: LocalRangeDescriptorSuffix, atEnd: true},
 		{name: "Transaction", suffix: LocalTransactionSuffix, atEnd: false},
 		{name: "QueueLastProcessed", suffix: LocalQueueLastProcessedSuffix, atEnd: false},
+		{name: "OnlineRestoreSpan", suffix: LocalOnlineRestoreSpanSuffix, atEnd: true},
 	}
 )
 
@@ -516,6 +518,14 @@ func abortSpanKeyPrint(key roachpb.Key) string {
 	return fmt.Sprintf("/%q", txnID)
 }
 
+func mvccRangeTombstoneKeyPrint(key roachpb.Key) string {
+	startKey, ts, err := decodeMVCCRangeTombstoneDetail(key)
+	if err != nil {
+		return fmt.Sprintf("/%q/err:%v", key, err)
+	}
+	return fmt.Sprintf("/%s/%s", startKey, ts)
+}
+
 func print(_ []encoding.Direction, key roachpb.Key) string {
 	return fmt.Sprintf("/%q", []byte(key))
 }
@@ -115,6 +115,24 @@ var CanSendToFollower = func(
 	return false
 }
 
+type nearestReplicaRoutingKey struct{}
+
+// WithNearestReplicaRouting returns a context which instructs the DistSender to
+// send the read-only, transactional batches it is used with to the nearest
+// replica of each range instead of to its lease holder. A replica that can't
+// serve such a batch redirects it to the lease holder. It is used by bounded
+// staleness reads, whose timestamp is picked such that the replicas local to
+// the gateway can serve them.
+func WithNearestReplicaRouting(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nearestReplicaRoutingKey{}, nearestReplicaRoutingKey{})
+}
+
+// nearestReplicaRouting returns whether the context was created by
+// WithNearestReplicaRouting.
+func nearestReplicaRouting(ctx context.Context) bool {
+	return ctx.Value(nearestReplicaRoutingKey{}) != nil
+}
+
 var rangeDescriptorCacheSize = settings.RegisterIntSetting(
 	"kv.range_descriptor_cache.size",
 	"maximum number of entries in the range descriptor and leaseholder caches",
@@ -495,16 +513,26 @@ func (ds *DistSender) getDescriptor(
 func (ds *DistSender) sendSingleRange(
 	ctx context.Context, ba roachpb.BatchRequest, desc *roachpb.RangeDescriptor, withCommit bool,
 ) (*roachpb.BatchResponse, *roachpb.Error) {
+	canSendToFollower := ds.clusterID != nil &&
+		CanSendToFollower(ds.clusterID.Get(), ds.st, ba)
+	if !canSendToFollower && nearestReplicaRouting(ctx) {
+		canSendToFollower = ba.IsReadOnly() && ba.IsAllTransactional()
+	}
+
 	// Try to send the call. Learner replicas won't serve reads/writes, so send
 	// only to the `Voters` replicas. This is just an optimization to save a
-	// network hop, everything would still work if we had `All` here.
-	replicas := NewReplicaSlice(ds.gossip, desc.Replicas().Voters())
+	// network hop, everything would still work if we had `All` here. Non-voters
+	// can serve follower reads, so they're included when the request may be
+	// served by a follower.
+	replicaDescs := desc.Replicas().Voters()
+	if canSendToFollower {
+		replicaDescs = desc.Replicas().VotersAndNonVoters()
+	}
+	replicas := NewReplicaSlice(ds.gossip, replicaDescs)
 
 	// If this request needs to go to a lease holder and we know who that is, move
 	// it to the front.
 	var cachedLeaseHolder roachpb.ReplicaDescriptor
-	canSendToFollower := ds.clusterID != nil &&
-		CanSendToFollower(ds.clusterID.Get(), ds.st, ba)
 	if !canSendToFollower && ba.RequiresLeaseHolder() {
 		if storeID, ok := ds.leaseHolderCache.Lookup(ctx, desc.RangeID); ok {
 			if i := replicas.FindReplica(storeID); i >= 0 {
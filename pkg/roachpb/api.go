@@ -1048,6 +1048,14 @@ func (drr *DeleteRangeRequest) flags() int {
 	if drr.Inline {
 		return isWrite | isRange | isAlone
 	}
+	// Range tombstones cannot be written transactionally either. They consult
+	// the timestamp cache so that they don't delete keys underneath reads that
+	// have already been served, and they update it so that no other writes can
+	// be performed underneath them, since the range tombstone is not visible to
+	// those writes.
+	if drr.UseRangeTombstone {
+		return isWrite | isRange | isAlone | consultsTSCache | updatesTSCache
+	}
 	// DeleteRange updates the timestamp cache as it doesn't leave intents or
 	// tombstones for keys which don't yet exist, but still wants to prevent
 	// anybody from writing under it. Note that, even if we didn't update the ts
@@ -1256,6 +1264,18 @@ func (rc ReplicationChanges) Removals() []ReplicationTarget {
 	return rc.byType(REMOVE_REPLICA)
 }
 
+// NonVoterAdditions returns a slice of all contained replication changes that
+// add non-voting replicas.
+func (rc ReplicationChanges) NonVoterAdditions() []ReplicationTarget {
+	return rc.byType(ADD_NON_VOTER)
+}
+
+// NonVoterRemovals returns a slice of all contained replication changes that
+// remove non-voting replicas.
+func (rc ReplicationChanges) NonVoterRemovals() []ReplicationTarget {
+	return rc.byType(REMOVE_NON_VOTER)
+}
+
 // Changes returns the changes requested by this AdminChangeReplicasRequest, taking
 // the deprecated method of doing so into account.
 func (acrr *AdminChangeReplicasRequest) Changes() []ReplicationChange {
@@ -73,6 +73,25 @@ func (s *TxnStats) Add(other TxnStats) {
 	s.CommittedCount += other.CommittedCount
 }
 
+// Add combines other into this TransactionStatistics.
+func (s *TransactionStatistics) Add(other *TransactionStatistics) {
+	if other.MaxRetries > s.MaxRetries {
+		s.MaxRetries = other.MaxRetries
+	}
+	s.NumRetries.Add(other.NumRetries, s.Count, other.Count)
+	s.ServiceLat.Add(other.ServiceLat, s.Count, other.Count)
+	if other.CommitLatCount > 0 {
+		s.CommitLat.Add(other.CommitLat, s.CommitLatCount, other.CommitLatCount)
+	}
+	s.RowsRead.Add(other.RowsRead, s.Count, other.Count)
+	s.RowsWritten.Add(other.RowsWritten, s.Count, other.Count)
+	s.BytesRead.Add(other.BytesRead, s.Count, other.Count)
+
+	s.CommittedCount += other.CommittedCount
+	s.CommitLatCount += other.CommitLatCount
+	s.Count += other.Count
+}
+
 // Add combines other into this StatementStatistics.
 func (s *StatementStatistics) Add(other *StatementStatistics) {
 	s.FirstAttemptCount += other.FirstAttemptCount
@@ -85,6 +104,9 @@ func (s *StatementStatistics) Add(other *StatementStatistics) {
 	s.RunLat.Add(other.RunLat, s.Count, other.Count)
 	s.ServiceLat.Add(other.ServiceLat, s.Count, other.Count)
 	s.OverheadLat.Add(other.OverheadLat, s.Count, other.Count)
+	s.KVBytesRead.Add(other.KVBytesRead, s.Count, other.Count)
+	s.ExecTimeExclWaits.Add(other.ExecTimeExclWaits, s.Count, other.Count)
+	s.NetworkBytes.Add(other.NetworkBytes, s.Count, other.Count)
 
 	if other.SensitiveInfo.LastErr != "" {
 		s.SensitiveInfo.LastErr = other.SensitiveInfo.LastErr
@@ -111,6 +133,9 @@ func (s *StatementStatistics) AlmostEqual(other *StatementStatistics, eps float6
 		s.RunLat.AlmostEqual(other.RunLat, eps) &&
 		s.ServiceLat.AlmostEqual(other.ServiceLat, eps) &&
 		s.OverheadLat.AlmostEqual(other.OverheadLat, eps) &&
+		s.KVBytesRead.AlmostEqual(other.KVBytesRead, eps) &&
+		s.ExecTimeExclWaits.AlmostEqual(other.ExecTimeExclWaits, eps) &&
+		s.NetworkBytes.AlmostEqual(other.NetworkBytes, eps) &&
 		s.SensitiveInfo.Equal(other.SensitiveInfo) &&
 		s.BytesRead == other.BytesRead &&
 		s.RowsRead == other.RowsRead
@@ -1424,6 +1424,12 @@ func confChangeImpl(
 			if err := checkNotExists(rDesc); err != nil {
 				return nil, err
 			}
+		case NON_VOTER:
+			// Non-voters are removed directly (i.e. never via joint consensus),
+			// so they must be gone from the descriptor.
+			if err := checkNotExists(rDesc); err != nil {
+				return nil, err
+			}
 		default:
 			return nil, errors.Errorf("can't remove replica in state %v", rDesc.GetType())
 		}
@@ -1453,6 +1459,9 @@ func confChangeImpl(
 			// Demotions (i.e. transitioning from voter to learner) are not
 			// represented in `added`; they're handled in `removed` above.
 			changeType = raftpb.ConfChangeAddLearnerNode
+		case NON_VOTER:
+			// We're adding a non-voter, which raft treats as a learner.
+			changeType = raftpb.ConfChangeAddLearnerNode
 		default:
 			// A voter that is demoting was just removed and re-added in the
 			// `removals` handler. We should not see it again here.
@@ -1735,6 +1735,7 @@ func TestChangeReplicasTrigger_ConfChange(t *testing.T) {
 	vo1 := sl(VOTER_OUTGOING, 1)
 	vi1 := sl(VOTER_INCOMING, 1)
 	vl1 := sl(LEARNER, 1)
+	vn1 := sl(NON_VOTER, 1)
 
 	testCases := []struct {
 		crt mockCRT
@@ -1767,9 +1768,16 @@ func TestChangeReplicasTrigger_ConfChange(t *testing.T) {
 			NodeID: 1,
 		}},
 
+		// Adding a non-voter via the V1 path.
+		{crt: mk(in{add: vn1, repls: vn1}), exp: raftpb.ConfChange{
+			Type:   raftpb.ConfChangeAddLearnerNode,
+			NodeID: 1,
+		}},
+
 		// Removing a voter or learner via the V1 path but falsely the replica is still in the descriptor.
 		{crt: mk(in{del: vf1, repls: vf1}), err: "(n3,s2):1 must no longer be present in descriptor"},
 		{crt: mk(in{del: vl1, repls: vl1}), err: "(n3,s2):1LEARNER must no longer be present in descriptor"},
+		{crt: mk(in{del: vn1, repls: vn1}), err: "(n3,s2):1NON_VOTER must no longer be present in descriptor"},
 		// Well-formed examples.
 		{crt: mk(in{del: vf1}), exp: raftpb.ConfChange{
 			Type:   raftpb.ConfChangeRemoveNode,
@@ -1779,6 +1787,10 @@ func TestChangeReplicasTrigger_ConfChange(t *testing.T) {
 			Type:   raftpb.ConfChangeRemoveNode,
 			NodeID: 1,
 		}},
+		{crt: mk(in{del: vn1}), exp: raftpb.ConfChange{
+			Type:   raftpb.ConfChangeRemoveNode,
+			NodeID: 1,
+		}},
 		// Adding a voter via the V2 path but without joint consensus.
 		{crt: mk(in{v2: true, add: vf1, repls: vf1}), exp: raftpb.ConfChangeV2{
 			Transition: raftpb.ConfChangeTransitionAuto,
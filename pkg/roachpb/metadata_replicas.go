@@ -55,6 +55,13 @@ func ReplicaTypeLearner() *ReplicaType {
 	return &t
 }
 
+// ReplicaTypeNonVoter returns a NON_VOTER pointer suitable for use in
+// a nullable proto field.
+func ReplicaTypeNonVoter() *ReplicaType {
+	t := NON_VOTER
+	return &t
+}
+
 // ReplicaDescriptors is a set of replicas, usually the nodes/stores on which
 // replicas of a range are stored.
 type ReplicaDescriptors struct {
@@ -89,8 +96,8 @@ func (d ReplicaDescriptors) String() string {
 	return buf.String()
 }
 
-// All returns every replica in the set, including both voter replicas and
-// learner replicas. Voter replicas are ordered first in the returned slice.
+// All returns every replica in the set, including voter replicas, learner
+// replicas and non-voting replicas.
 func (d ReplicaDescriptors) All() []ReplicaDescriptor {
 	return d.wrapped
 }
@@ -108,12 +115,20 @@ func predLearner(rDesc ReplicaDescriptor) bool {
 	return rDesc.GetType() == LEARNER
 }
 
+func predNonVoter(rDesc ReplicaDescriptor) bool {
+	return rDesc.GetType() == NON_VOTER
+}
+
+func predVoterFullOrIncomingOrNonVoter(rDesc ReplicaDescriptor) bool {
+	return predVoterFullOrIncoming(rDesc) || predNonVoter(rDesc)
+}
+
 // Voters returns the current and future voter replicas in the set. This means
 // that during an atomic replication change, only the replicas that will be
 // voters once the change completes will be returned; "outgoing" voters will not
 // be returned even though they do in the current state retain their voting
 // rights. When no atomic membership change is ongoing, this is simply the set
-// of all non-learners.
+// of all replicas that are neither learners nor non-voters.
 //
 // This may allocate, but it also may return the underlying slice as a
 // performance optimization, so it's not safe to modify the returned value.
@@ -215,6 +230,29 @@ func (d ReplicaDescriptors) Learners() []ReplicaDescriptor {
 	return d.Filter(predLearner)
 }
 
+// NonVoters returns the non-voting replicas in the set. Non-voters are
+// replicas that receive the raft log like a learner but are never promoted;
+// they are added and removed by the allocator to satisfy the num_non_voters
+// zone config setting and exist to serve follower reads. See the NON_VOTER
+// ReplicaType for details.
+//
+// This may allocate, but it also may return the underlying slice as a
+// performance optimization, so it's not safe to modify the returned value.
+func (d ReplicaDescriptors) NonVoters() []ReplicaDescriptor {
+	return d.Filter(predNonVoter)
+}
+
+// VotersAndNonVoters returns the (current and future) voters along with the
+// non-voters in the set, i.e. all the replicas which, once any ongoing atomic
+// replication change completes, will be long-lived members of the range. These
+// are the replicas eligible to serve follower reads.
+//
+// This may allocate, but it also may return the underlying slice as a
+// performance optimization, so it's not safe to modify the returned value.
+func (d ReplicaDescriptors) VotersAndNonVoters() []ReplicaDescriptor {
+	return d.Filter(predVoterFullOrIncomingOrNonVoter)
+}
+
 // Filter returns only the replica descriptors for which the supplied method
 // returns true. The memory returned may be shared with the receiver.
 func (d ReplicaDescriptors) Filter(pred func(rDesc ReplicaDescriptor) bool) []ReplicaDescriptor {
@@ -287,7 +325,7 @@ func (d ReplicaDescriptors) InAtomicReplicationChange() bool {
 		switch rDesc.GetType() {
 		case VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING:
 			return true
-		case VOTER_FULL, LEARNER:
+		case VOTER_FULL, LEARNER, NON_VOTER:
 		default:
 			panic(fmt.Sprintf("unknown replica type %d", rDesc.GetType()))
 		}
@@ -318,7 +356,8 @@ func (d ReplicaDescriptors) ConfState() raftpb.ConfState {
 		case VOTER_DEMOTING:
 			cs.VotersOutgoing = append(cs.VotersOutgoing, id)
 			cs.LearnersNext = append(cs.LearnersNext, id)
-		case LEARNER:
+		case LEARNER, NON_VOTER:
+			// NON_VOTERs are learners as far as raft is concerned.
 			cs.Learners = append(cs.Learners, id)
 		default:
 			panic(fmt.Sprintf("unknown ReplicaType %d", typ))
@@ -34,6 +34,7 @@ var vi = ReplicaTypeVoterIncoming()
 var vo = ReplicaTypeVoterOutgoing()
 var vd = ReplicaTypeVoterDemoting()
 var l = ReplicaTypeLearner()
+var nv = ReplicaTypeNonVoter()
 
 func TestVotersLearnersAll(t *testing.T) {
 
@@ -49,6 +50,9 @@ func TestVotersLearnersAll(t *testing.T) {
 		{rd(vi, 1)},
 		{rd(vo, 1)},
 		{rd(l, 1), rd(vo, 2), rd(vi, 3), rd(vi, 4)},
+		{rd(nv, 1)},
+		{rd(v, 1), rd(nv, 2), rd(l, 3)},
+		{rd(nv, 1), rd(vo, 2), rd(vi, 3), rd(nv, 4)},
 	}
 	for _, test := range tests {
 		t.Run("", func(t *testing.T) {
@@ -67,10 +71,15 @@ func TestVotersLearnersAll(t *testing.T) {
 				seen[learner] = struct{}{}
 				assert.Equal(t, LEARNER, learner.GetType())
 			}
+			for _, nonVoter := range r.NonVoters() {
+				seen[nonVoter] = struct{}{}
+				assert.Equal(t, NON_VOTER, nonVoter.GetType())
+			}
+			assert.Equal(t, len(r.Voters())+len(r.NonVoters()), len(r.VotersAndNonVoters()))
 
 			all := r.All()
-			// Make sure that VOTER_OUTGOING is the only type that is skipped both
-			// by Learners() and Voters()
+			// Make sure that VOTER_OUTGOING is the only type that is skipped by
+			// Learners(), NonVoters() and Voters()
 			for _, rd := range all {
 				typ := rd.GetType()
 				if _, seen := seen[rd]; !seen {
@@ -189,6 +198,16 @@ func TestReplicaDescriptorsConfState(t *testing.T) {
 			[]ReplicaDescriptor{rd(vo, 1), rd(vd, 2), rd(vi, 3), rd(vi, 4), rd(l, 5)},
 			"Voters:[3 4] VotersOutgoing:[1 2] Learners:[5] LearnersNext:[2] AutoLeave:false",
 		},
+		// Non-voters are learners as far as raft is concerned, both in and out of
+		// joint configurations.
+		{
+			[]ReplicaDescriptor{rd(v, 1), rd(nv, 2), rd(l, 3)},
+			"Voters:[1] VotersOutgoing:[] Learners:[2 3] LearnersNext:[] AutoLeave:false",
+		},
+		{
+			[]ReplicaDescriptor{rd(v, 1), rd(vi, 2), rd(nv, 3)},
+			"Voters:[1 2] VotersOutgoing:[1] Learners:[3] LearnersNext:[] AutoLeave:false",
+		},
 	}
 
 	for _, test := range tests {
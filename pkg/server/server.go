@@ -59,11 +59,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
 	"github.com/cockroachdb/cockroach/pkg/sqlmigrations"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/bulk"
 	"github.com/cockroachdb/cockroach/pkg/storage/closedts/container"
 	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/cockroach/pkg/storage/contention"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
@@ -72,6 +74,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/reports"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagebase"
 	"github.com/cockroachdb/cockroach/pkg/ts"
+	"github.com/cockroachdb/cockroach/pkg/ts/promql"
 	"github.com/cockroachdb/cockroach/pkg/ui"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
@@ -197,7 +200,10 @@ type Server struct {
 	debug            *debug.Server
 	// sessionRegistry can be queried for info on running SQL sessions. It is
 	// shared between the sql.Server and the statusServer.
-	sessionRegistry     *sql.SessionRegistry
+	sessionRegistry *sql.SessionRegistry
+	// contentionRegistry records the contention events observed on this
+	// node. It is shared between the node's stores and the statusServer.
+	contentionRegistry  *contention.Registry
 	jobRegistry         *jobs.Registry
 	statsRefresher      *stats.Refresher
 	replicationReporter *reports.Reporter
@@ -208,6 +214,8 @@ type Server struct {
 	sqlMemMetrics         sql.MemoryMetrics
 	protectedtsProvider   protectedts.Provider
 	protectedtsReconciler *ptreconcile.Reconciler
+
+	stmtDiagnosticsRegistry *stmtdiagnostics.Registry
 }
 
 // NewServer creates a Server from a server.Config.
@@ -492,6 +500,8 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 	// Similarly for execCfg.
 	var execCfg sql.ExecutorConfig
 
+	s.contentionRegistry = contention.NewRegistry()
+
 	// TODO(bdarnell): make StoreConfig configurable.
 	storeCfg := storage.StoreConfig{
 		DefaultZoneConfig:       &s.cfg.DefaultZoneConfig,
@@ -515,6 +525,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		LogRangeEvents:          s.cfg.EventLogEnabled,
 		RangeDescriptorCache:    s.distSender.RangeDescriptorCache(),
 		TimeSeriesDataStore:     s.tsDB,
+		ContentionRegistry:      s.contentionRegistry,
 
 		// Initialize the closed timestamp subsystem. Note that it won't
 		// be ready until it is .Start()ed, but the grpc server can be
@@ -680,6 +691,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		s.node.stores,
 		s.stopper,
 		s.sessionRegistry,
+		s.contentionRegistry,
 	)
 	s.authentication = newAuthenticationServer(s)
 	for _, gw := range []grpcGatewayServer{s.admin, s.status, s.authentication, &s.tsServer} {
@@ -779,8 +791,14 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 			true /*enableGc*/, false /*forceSyncWrites*/, true, /* enableMsgCount */
 		),
 
+		AuditPolicyLogger: log.NewSecondaryLogger(
+			loggerCtx, s.cfg.SQLAuditLogDirName, "sql-audit-policy",
+			true /*enableGc*/, true /*forceSyncWrites*/, true, /* enableMsgCount */
+		),
+
 		QueryCache:                 querycache.New(s.cfg.SQLQueryCacheSize),
 		ProtectedTimestampProvider: s.protectedtsProvider,
+		LocalReadTimestamp:         s.node.stores.LocalReadTimestamp,
 	}
 
 	if sqlSchemaChangerTestingKnobs := s.cfg.TestingKnobs.SQLSchemaChanger; sqlSchemaChangerTestingKnobs != nil {
@@ -808,6 +826,9 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 	)
 	execCfg.StatsRefresher = s.statsRefresher
 
+	s.stmtDiagnosticsRegistry = stmtdiagnostics.NewRegistry(internalExecutor, s.db, s.st)
+	execCfg.StmtDiagnosticsRecorder = s.stmtDiagnosticsRegistry
+
 	// Set up internal memory metrics for use by internal SQL executors.
 	s.sqlMemMetrics = sql.MakeMemMetrics("sql", cfg.HistogramWindowInterval())
 	s.registry.AddMetricStruct(s.sqlMemMetrics)
@@ -1721,9 +1742,21 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 
 	s.mux.Handle(adminPrefix, authHandler)
+	// Serve the zip files of the statement diagnostics bundles.
+	var stmtBundleHandler http.Handler = http.HandlerFunc(s.admin.handleStatementBundle)
+	if s.cfg.RequireWebSession() {
+		stmtBundleHandler = newAuthenticationMux(s.authentication, stmtBundleHandler)
+	}
+	s.mux.Handle(stmtBundlePrefix, stmtBundleHandler)
 	// Exempt the health check endpoint from authentication.
 	s.mux.Handle("/_admin/v1/health", gwMux)
 	s.mux.Handle(ts.URLPrefix, authHandler)
+	// Serve the Prometheus-compatible queries of the time series.
+	var promHandler http.Handler = promql.NewHandler(&s.tsServer, s.recorder.TimeSeriesNames)
+	if s.cfg.RequireWebSession() {
+		promHandler = newAuthenticationMux(s.authentication, promHandler)
+	}
+	s.mux.Handle(promql.URLPrefix, promHandler)
 	s.mux.Handle(statusPrefix, authHandler)
 	s.mux.Handle(loginPath, gwMux)
 	s.mux.Handle(logoutPath, authHandler)
@@ -1776,6 +1809,8 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}
 
+	s.stmtDiagnosticsRegistry.Start(ctx, s.stopper)
+
 	log.Event(ctx, "server ready")
 
 	return nil
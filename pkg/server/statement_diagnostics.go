@@ -0,0 +1,209 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// stmtBundlePrefix is the prefix of the URLs from which the zip files of the
+// statement diagnostics bundles are downloaded, followed by the ID of the
+// bundle.
+const stmtBundlePrefix = adminPrefix + "stmtbundle/"
+
+// CreateStatementDiagnosticsReport registers a request for a statement
+// diagnostics bundle.
+func (s *statusServer) CreateStatementDiagnosticsReport(
+	ctx context.Context, req *serverpb.CreateStatementDiagnosticsReportRequest,
+) (*serverpb.CreateStatementDiagnosticsReportResponse, error) {
+	if _, err := s.admin.requireAdminUser(ctx); err != nil {
+		return nil, err
+	}
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	if req.StatementFingerprint == "" {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "statement fingerprint is required")
+	}
+	id, err := s.admin.server.stmtDiagnosticsRegistry.InsertRequest(ctx, req.StatementFingerprint)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.statementDiagnosticsRequests(ctx, "WHERE id = $1", int64(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) != 1 {
+		return nil, s.admin.serverErrorf("statement diagnostics request %d not found", id)
+	}
+	return &serverpb.CreateStatementDiagnosticsReportResponse{Report: &reports[0]}, nil
+}
+
+// StatementDiagnosticsRequests lists the statement diagnostics requests,
+// newest first.
+func (s *statusServer) StatementDiagnosticsRequests(
+	ctx context.Context, req *serverpb.StatementDiagnosticsReportsRequest,
+) (*serverpb.StatementDiagnosticsReportsResponse, error) {
+	if _, err := s.admin.requireAdminUser(ctx); err != nil {
+		return nil, err
+	}
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	reports, err := s.statementDiagnosticsRequests(ctx, "ORDER BY requested_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	return &serverpb.StatementDiagnosticsReportsResponse{Reports: reports}, nil
+}
+
+func (s *statusServer) statementDiagnosticsRequests(
+	ctx context.Context, clause string, qargs ...interface{},
+) ([]serverpb.StatementDiagnosticsReport, error) {
+	rows, err := s.admin.server.internalExecutor.Query(ctx, "stmt-diag-get-requests", nil, /* txn */
+		`SELECT id, completed, statement_fingerprint, statement_diagnostics_id, requested_at
+		FROM system.statement_diagnostics_requests `+clause,
+		qargs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]serverpb.StatementDiagnosticsReport, len(rows))
+	for i, row := range rows {
+		reports[i] = serverpb.StatementDiagnosticsReport{
+			Id:                   int64(tree.MustBeDInt(row[0])),
+			Completed:            bool(tree.MustBeDBool(row[1])),
+			StatementFingerprint: string(tree.MustBeDString(row[2])),
+			RequestedAt:          tree.MustBeDTimestampTZ(row[4]).Time,
+		}
+		if row[3] != tree.DNull {
+			reports[i].StatementDiagnosticsId = int64(tree.MustBeDInt(row[3]))
+		}
+	}
+	return reports, nil
+}
+
+// StatementDiagnostics returns a collected statement diagnostics bundle. The
+// zip file of the bundle is served separately, from stmtBundlePrefix.
+func (s *statusServer) StatementDiagnostics(
+	ctx context.Context, req *serverpb.StatementDiagnosticsRequest,
+) (*serverpb.StatementDiagnosticsResponse, error) {
+	if _, err := s.admin.requireAdminUser(ctx); err != nil {
+		return nil, err
+	}
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	row, err := s.admin.server.internalExecutor.QueryRow(ctx, "stmt-diag-get", nil, /* txn */
+		`SELECT statement_fingerprint, statement, collected_at, trace, error
+		FROM system.statement_diagnostics WHERE id = $1`,
+		req.StatementDiagnosticsId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, grpcstatus.Errorf(
+			codes.NotFound, "statement diagnostics %d not found", req.StatementDiagnosticsId)
+	}
+	diagnostics := &serverpb.StatementDiagnostics{
+		Id:                   req.StatementDiagnosticsId,
+		StatementFingerprint: string(tree.MustBeDString(row[0])),
+		Statement:            string(tree.MustBeDString(row[1])),
+		CollectedAt:          tree.MustBeDTimestampTZ(row[2]).Time,
+	}
+	if row[3] != tree.DNull {
+		diagnostics.Trace = tree.MustBeDJSON(row[3]).JSON.String()
+	}
+	if row[4] != tree.DNull {
+		diagnostics.Error = string(tree.MustBeDString(row[4]))
+	}
+	return &serverpb.StatementDiagnosticsResponse{Diagnostics: diagnostics}, nil
+}
+
+// handleStatementBundle serves the zip file of the statement diagnostics
+// bundle whose ID follows stmtBundlePrefix in the URL. Like the RPCs, it
+// requires the admin role.
+func (s *adminServer) handleStatementBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := s.server.AnnotateCtx(r.Context())
+	id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, stmtBundlePrefix), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid statement diagnostics ID", http.StatusBadRequest)
+		return
+	}
+	userName := security.RootUser
+	if u, ok := ctx.Value(webSessionUserKey{}).(string); ok {
+		userName = u
+	}
+	if isAdmin, err := s.hasAdminRole(ctx, userName); err != nil {
+		log.Errorf(ctx, "checking the role of %s: %v", userName, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	} else if !isAdmin {
+		http.Error(w, "this operation requires admin privilege", http.StatusForbidden)
+		return
+	}
+
+	bundle, err := s.getStatementBundle(ctx, id)
+	if err != nil {
+		log.Errorf(ctx, "reading statement diagnostics bundle %d: %v", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if bundle == nil {
+		http.Error(w, fmt.Sprintf("statement diagnostics bundle %d not found", id), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=stmt-bundle-%d.zip", id))
+	_, _ = w.Write(bundle)
+}
+
+// getStatementBundle reassembles the zip file of a statement diagnostics
+// bundle from its chunks. It returns nil if there is no such bundle.
+func (s *adminServer) getStatementBundle(ctx context.Context, id int64) ([]byte, error) {
+	row, err := s.server.internalExecutor.QueryRow(ctx, "stmt-bundle-get-chunk-ids", nil, /* txn */
+		"SELECT bundle_chunks FROM system.statement_diagnostics WHERE id = $1", id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil || row[0] == tree.DNull {
+		return nil, nil
+	}
+	var bundle bytes.Buffer
+	for _, chunkID := range tree.MustBeDArray(row[0]).Array {
+		chunkRow, err := s.server.internalExecutor.QueryRow(ctx, "stmt-bundle-get-chunk", nil, /* txn */
+			"SELECT data FROM system.statement_bundle_chunks WHERE id = $1", chunkID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if chunkRow == nil {
+			return nil, fmt.Errorf("chunk %s of bundle %d not found", chunkID, id)
+		}
+		bundle.WriteString(string(tree.MustBeDBytes(chunkRow[0])))
+	}
+	return bundle.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+)
+
+// TestStatementDiagnosticsBundle requests a bundle through the status
+// server, executes the statement and downloads the bundle.
+func TestStatementDiagnosticsBundle(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+
+	var createResp serverpb.CreateStatementDiagnosticsReportResponse
+	if err := serverutils.PostJSONProto(s, statusPrefix+"stmtdiagreports",
+		&serverpb.CreateStatementDiagnosticsReportRequest{
+			StatementFingerprint: "SELECT v FROM t WHERE k = _",
+		}, &createResp,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if createResp.Report == nil || createResp.Report.Completed {
+		t.Fatalf("unexpected report: %+v", createResp.Report)
+	}
+
+	db.Exec(t, `SELECT v FROM t WHERE k = 1`)
+
+	// The bundle is built and inserted asynchronously.
+	var report serverpb.StatementDiagnosticsReport
+	testutils.SucceedsSoon(t, func() error {
+		var reportsResp serverpb.StatementDiagnosticsReportsResponse
+		if err := getStatusJSONProto(s, "stmtdiagreports", &reportsResp); err != nil {
+			return err
+		}
+		if len(reportsResp.Reports) != 1 {
+			return errors.Errorf("expected one report, got %+v", reportsResp.Reports)
+		}
+		report = reportsResp.Reports[0]
+		if report.Id != createResp.Report.Id || !report.Completed || report.StatementDiagnosticsId == 0 {
+			return errors.Errorf("unexpected report: %+v", report)
+		}
+		return nil
+	})
+
+	var diagResp serverpb.StatementDiagnosticsResponse
+	if err := getStatusJSONProto(
+		s, fmt.Sprintf("stmtdiag/%d", report.StatementDiagnosticsId), &diagResp,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if diag := diagResp.Diagnostics; diag.Statement != "SELECT v FROM t WHERE k = 1" ||
+		diag.Trace == "" || diag.Error != "" {
+		t.Fatalf("unexpected diagnostics: %+v", diag)
+	}
+
+	body, err := getText(s, fmt.Sprintf("%s%s%d", s.AdminURL(), stmtBundlePrefix, report.StatementDiagnosticsId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("%v, body is:\n%s", err, body)
+	}
+	files := make(map[string]string)
+	for _, f := range z.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(contents)
+	}
+	for name, expected := range map[string]string{
+		"statement.txt":                "SELECT v FROM t WHERE k = 1",
+		"opt.txt":                      "scan t",
+		"opt-v.txt":                    "scan t",
+		"trace.txt":                    "traced statement",
+		"trace.json":                   "traced statement",
+		"schema.sql":                   "CREATE TABLE t (",
+		"stats-defaultdb.public.t.sql": "ALTER TABLE defaultdb.public.t INJECT STATISTICS",
+		"env.sql":                      "SET application_name",
+	} {
+		if contents, ok := files[name]; !ok {
+			t.Errorf("missing %s in bundle", name)
+		} else if !strings.Contains(contents, expected) {
+			t.Errorf("expected %s to contain %q, got:\n%s", name, expected, contents)
+		}
+	}
+
+	// Only the first execution is collected.
+	db.Exec(t, `SELECT v FROM t WHERE k = 2`)
+	db.CheckQueryResults(t,
+		`SELECT count(*) FROM system.statement_diagnostics`, [][]string{{"1"}})
+}
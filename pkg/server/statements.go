@@ -13,10 +13,14 @@ package server
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -32,14 +36,20 @@ func (s *statusServer) Statements(
 	ctx = propagateGatewayMetadata(ctx)
 	ctx = s.AnnotateCtx(ctx)
 
+	if req.Combined {
+		return s.combinedStatements(ctx, req)
+	}
+
 	response := &serverpb.StatementsResponse{
 		Statements:            []serverpb.StatementsResponse_CollectedStatementStatistics{},
+		Transactions:          []serverpb.StatementsResponse_ExtendedCollectedTransactionStatistics{},
 		LastReset:             timeutil.Now(),
 		InternalAppNamePrefix: sqlbase.InternalAppNamePrefix,
 	}
 
 	localReq := &serverpb.StatementsRequest{
-		NodeID: "local",
+		NodeID:    "local",
+		Unflushed: req.Unflushed,
 	}
 
 	if len(req.NodeID) > 0 {
@@ -48,7 +58,7 @@ func (s *statusServer) Statements(
 			return nil, status.Errorf(codes.InvalidArgument, err.Error())
 		}
 		if local {
-			return s.StatementsLocal(ctx)
+			return s.StatementsLocal(ctx, req.Unflushed)
 		}
 		status, err := s.dialNode(ctx, requestedNodeID)
 		if err != nil {
@@ -72,6 +82,7 @@ func (s *statusServer) Statements(
 		func(nodeID roachpb.NodeID, resp interface{}) {
 			statementsResp := resp.(*serverpb.StatementsResponse)
 			response.Statements = append(response.Statements, statementsResp.Statements...)
+			response.Transactions = append(response.Transactions, statementsResp.Transactions...)
 			if response.LastReset.After(statementsResp.LastReset) {
 				response.LastReset = statementsResp.LastReset
 			}
@@ -86,12 +97,23 @@ func (s *statusServer) Statements(
 	return response, nil
 }
 
-func (s *statusServer) StatementsLocal(ctx context.Context) (*serverpb.StatementsResponse, error) {
-	stmtStats := s.admin.server.pgServer.SQLServer.GetUnscrubbedStmtStats()
+func (s *statusServer) StatementsLocal(
+	ctx context.Context, unflushed bool,
+) (*serverpb.StatementsResponse, error) {
+	var stmtStats []roachpb.CollectedStatementStatistics
+	var txnStats []roachpb.CollectedTransactionStatistics
+	if unflushed {
+		stmtStats = s.admin.server.pgServer.SQLServer.GetUnflushedStmtStats()
+		txnStats = s.admin.server.pgServer.SQLServer.GetUnflushedTxnStats()
+	} else {
+		stmtStats = s.admin.server.pgServer.SQLServer.GetUnscrubbedStmtStats()
+		txnStats = s.admin.server.pgServer.SQLServer.GetUnscrubbedTxnStats()
+	}
 	lastReset := s.admin.server.pgServer.SQLServer.GetStmtStatsLastReset()
 
 	resp := &serverpb.StatementsResponse{
 		Statements:            make([]serverpb.StatementsResponse_CollectedStatementStatistics, len(stmtStats)),
+		Transactions:          make([]serverpb.StatementsResponse_ExtendedCollectedTransactionStatistics, len(txnStats)),
 		LastReset:             lastReset,
 		InternalAppNamePrefix: sqlbase.InternalAppNamePrefix,
 	}
@@ -106,5 +128,153 @@ func (s *statusServer) StatementsLocal(ctx context.Context) (*serverpb.Statement
 		}
 	}
 
+	for i, txn := range txnStats {
+		resp.Transactions[i] = serverpb.StatementsResponse_ExtendedCollectedTransactionStatistics{
+			StatsData: txn,
+			NodeID:    s.gossip.NodeID.Get(),
+		}
+	}
+
 	return resp, nil
 }
+
+// combinedStatements returns the statement and transaction statistics
+// flushed to system.statement_statistics and system.transaction_statistics in
+// the requested time range, merged with the in-memory statistics of the nodes
+// which haven't been flushed yet if the range is not bounded above. The
+// statistics are merged per statement or transaction fingerprint and node.
+func (s *statusServer) combinedStatements(
+	ctx context.Context, req *serverpb.StatementsRequest,
+) (*serverpb.StatementsResponse, error) {
+	var requestedNodeID roachpb.NodeID
+	if len(req.NodeID) > 0 {
+		nodeID, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if local {
+			nodeID = s.gossip.NodeID.Get()
+		}
+		requestedNodeID = nodeID
+	}
+
+	response := &serverpb.StatementsResponse{
+		Statements:            []serverpb.StatementsResponse_CollectedStatementStatistics{},
+		Transactions:          []serverpb.StatementsResponse_ExtendedCollectedTransactionStatistics{},
+		LastReset:             timeutil.Now(),
+		InternalAppNamePrefix: sqlbase.InternalAppNamePrefix,
+	}
+	merged := make(map[serverpb.StatementsResponse_ExtendedStatementStatisticsKey]*roachpb.StatementStatistics)
+	add := func(key serverpb.StatementsResponse_ExtendedStatementStatisticsKey, stats *roachpb.StatementStatistics) {
+		if existing, ok := merged[key]; ok {
+			existing.Add(stats)
+		} else {
+			merged[key] = stats
+		}
+	}
+	type txnKey struct {
+		app           string
+		fingerprintID uint64
+		nodeID        roachpb.NodeID
+	}
+	mergedTxns := make(map[txnKey]*serverpb.StatementsResponse_ExtendedCollectedTransactionStatistics)
+	addTxn := func(txn *serverpb.StatementsResponse_ExtendedCollectedTransactionStatistics) {
+		key := txnKey{app: txn.StatsData.App, fingerprintID: txn.StatsData.FingerprintID, nodeID: txn.NodeID}
+		if existing, ok := mergedTxns[key]; ok {
+			existing.StatsData.Stats.Add(&txn.StatsData.Stats)
+		} else {
+			mergedTxns[key] = txn
+		}
+	}
+
+	if req.End == 0 {
+		inMemory, err := s.Statements(ctx, &serverpb.StatementsRequest{
+			NodeID: req.NodeID, Unflushed: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.LastReset = inMemory.LastReset
+		for i := range inMemory.Statements {
+			add(inMemory.Statements[i].Key, &inMemory.Statements[i].Stats)
+		}
+		for i := range inMemory.Transactions {
+			addTxn(&inMemory.Transactions[i])
+		}
+	}
+
+	var filter string
+	var qargs []interface{}
+	addFilter := func(cond string, arg interface{}) {
+		qargs = append(qargs, arg)
+		filter += fmt.Sprintf(" AND %s $%d", cond, len(qargs))
+	}
+	if req.Start != 0 {
+		addFilter("aggregated_ts >=", tree.MakeDTimestampTZ(timeutil.Unix(req.Start, 0), time.Microsecond))
+	}
+	if req.End != 0 {
+		addFilter("aggregated_ts <", tree.MakeDTimestampTZ(timeutil.Unix(req.End, 0), time.Microsecond))
+	}
+	if requestedNodeID != 0 {
+		addFilter("node_id =", int64(requestedNodeID))
+	}
+
+	if cluster.Version.IsActive(ctx, s.st, cluster.VersionPersistedSQLStats) {
+		rows, err := s.admin.server.internalExecutor.Query(ctx, "combined-stmt-stats", nil, /* txn */
+			`SELECT aggregated_ts, node_id, app_name, fingerprint, failed, distsql, implicit_txn, statistics
+			FROM system.statement_statistics WHERE true`+filter, qargs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if ts := tree.MustBeDTimestampTZ(row[0]).Time; ts.Before(response.LastReset) {
+				response.LastReset = ts
+			}
+			var stats roachpb.StatementStatistics
+			if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[7])), &stats); err != nil {
+				return nil, err
+			}
+			add(serverpb.StatementsResponse_ExtendedStatementStatisticsKey{
+				KeyData: roachpb.StatementStatisticsKey{
+					Query:       string(tree.MustBeDString(row[3])),
+					App:         string(tree.MustBeDString(row[2])),
+					DistSQL:     bool(tree.MustBeDBool(row[5])),
+					Failed:      bool(tree.MustBeDBool(row[4])),
+					Opt:         true,
+					ImplicitTxn: bool(tree.MustBeDBool(row[6])),
+				},
+				NodeID: roachpb.NodeID(tree.MustBeDInt(row[1])),
+			}, &stats)
+		}
+	}
+
+	if cluster.Version.IsActive(ctx, s.st, cluster.VersionPersistedTxnStats) {
+		rows, err := s.admin.server.internalExecutor.Query(ctx, "combined-txn-stats", nil, /* txn */
+			`SELECT aggregated_ts, node_id, statistics
+			FROM system.transaction_statistics WHERE true`+filter, qargs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if ts := tree.MustBeDTimestampTZ(row[0]).Time; ts.Before(response.LastReset) {
+				response.LastReset = ts
+			}
+			txn := &serverpb.StatementsResponse_ExtendedCollectedTransactionStatistics{
+				NodeID: roachpb.NodeID(tree.MustBeDInt(row[1])),
+			}
+			if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[2])), &txn.StatsData); err != nil {
+				return nil, err
+			}
+			addTxn(txn)
+		}
+	}
+
+	for key, stats := range merged {
+		response.Statements = append(response.Statements,
+			serverpb.StatementsResponse_CollectedStatementStatistics{Key: key, Stats: *stats})
+	}
+	for _, txn := range mergedTxns {
+		response.Transactions = append(response.Transactions, *txn)
+	}
+	return response, nil
+}
@@ -17,8 +17,10 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/tests"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
@@ -179,3 +181,103 @@ func TestSQLStatCollection(t *testing.T) {
 		t.Fatal("expected to find stats for insert query in reported pool, but didn't")
 	}
 }
+
+func TestSQLStatsFlush(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	params, _ := tests.CreateTestServerParams()
+	s, sqlDB, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(ctx)
+	db := sqlutils.MakeSQLRunner(sqlDB)
+
+	// Flush the stats explicitly below, into a time bucket wide enough for all
+	// the flushes to land in it.
+	db.Exec(t, `SET CLUSTER SETTING sql.stats.flush.enabled = false`)
+	db.Exec(t, `SET CLUSTER SETTING sql.stats.aggregation.interval = '10000h'`)
+	db.Exec(t, `CREATE DATABASE t; CREATE TABLE t.test (x INT PRIMARY KEY)`)
+
+	sqlServer := s.(*TestServer).Server.pgServer.SQLServer
+	sqlServer.FlushSQLStats(ctx)
+
+	findInsert := func(stats []roachpb.CollectedStatementStatistics) int64 {
+		var count int64
+		for _, stat := range stats {
+			if strings.HasPrefix(stat.Key.Query, "INSERT INTO t.test VALUES") {
+				count += stat.Stats.Count
+			}
+		}
+		return count
+	}
+
+	db.Exec(t, `INSERT INTO t.test VALUES (1)`)
+	db.Exec(t, `INSERT INTO t.test VALUES (2)`)
+	sqlServer.FlushSQLStats(ctx)
+	// The flushed stats are kept in memory, but not flushed again.
+	if count := findInsert(sqlServer.GetUnscrubbedStmtStats()); count != 2 {
+		t.Fatalf("expected 2 in-memory executions, found %d", count)
+	}
+	if count := findInsert(sqlServer.GetUnflushedStmtStats()); count != 0 {
+		t.Fatalf("expected the flushed stats to be cleared, found %d executions", count)
+	}
+	db.Exec(t, `INSERT INTO t.test VALUES (3)`)
+	sqlServer.FlushSQLStats(ctx)
+
+	// The flushes are merged into the row of the time bucket.
+	db.CheckQueryResults(t, `
+SELECT count(*), sum(count) FROM system.statement_statistics
+  WHERE fingerprint LIKE 'INSERT INTO t.test VALUES%'`,
+		[][]string{{"1", "3"}})
+	db.CheckQueryResults(t, `
+SELECT sum(count) FROM crdb_internal.statement_statistics
+  WHERE key LIKE 'INSERT INTO t.test VALUES%'`,
+		[][]string{{"3"}})
+
+	// The combined statements are the flushed ones plus the unflushed ones.
+	db.Exec(t, `INSERT INTO t.test VALUES (4)`)
+	var resp serverpb.StatementsResponse
+	if err := getStatusJSONProto(s, "statements?combined=true", &resp); err != nil {
+		t.Fatal(err)
+	}
+	var combined int64
+	for _, stmt := range resp.Statements {
+		if strings.HasPrefix(stmt.Key.KeyData.Query, "INSERT INTO t.test VALUES") {
+			combined += stmt.Stats.Count
+		}
+	}
+	if combined != 4 {
+		t.Fatalf("expected 4 combined executions, found %d", combined)
+	}
+	var combinedTxns int64
+	for _, txn := range resp.Transactions {
+		fingerprints := txn.StatsData.StatementFingerprints
+		if len(fingerprints) == 1 && strings.HasPrefix(fingerprints[0], "INSERT INTO t.test VALUES") {
+			combinedTxns += txn.StatsData.Stats.Count
+		}
+	}
+	if combinedTxns != 4 {
+		t.Fatalf("expected 4 combined transactions, found %d", combinedTxns)
+	}
+	if count := findInsert(sqlServer.GetUnflushedStmtStats()); count != 1 {
+		t.Fatalf("expected 1 unflushed execution, found %d", count)
+	}
+	if count := findInsert(sqlServer.GetUnscrubbedStmtStats()); count != 4 {
+		t.Fatalf("expected 4 in-memory executions, found %d", count)
+	}
+
+	// The unflushed stats survive a reset of the in-memory stats.
+	sqlServer.ResetSQLStats(ctx)
+	if count := findInsert(sqlServer.GetUnscrubbedStmtStats()); count != 0 {
+		t.Fatalf("expected the in-memory stats to be reset, found %d executions", count)
+	}
+	sqlServer.FlushSQLStats(ctx)
+	db.CheckQueryResults(t, `
+SELECT sum(count) FROM system.statement_statistics
+  WHERE fingerprint LIKE 'INSERT INTO t.test VALUES%'`,
+		[][]string{{"4"}})
+
+	// Expired statistics are deleted when flushing.
+	db.Exec(t, `SET CLUSTER SETTING sql.stats.persisted_rows.retention = '1ms'`)
+	sqlServer.FlushSQLStats(ctx)
+	db.CheckQueryResults(t, `SELECT count(*) FROM system.statement_statistics`, [][]string{{"0"}})
+	db.CheckQueryResults(t, `SELECT count(*) FROM system.transaction_statistics`, [][]string{{"0"}})
+}
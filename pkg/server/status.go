@@ -28,9 +28,11 @@ import (
 	"regexp"
 	"runtime"
 	"runtime/pprof"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/build"
@@ -48,6 +50,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/storage/contention"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
@@ -128,19 +131,20 @@ func propagateGatewayMetadata(ctx context.Context) context.Context {
 type statusServer struct {
 	log.AmbientContext
 
-	st              *cluster.Settings
-	cfg             *base.Config
-	admin           *adminServer
-	db              *client.DB
-	gossip          *gossip.Gossip
-	metricSource    metricMarshaler
-	nodeLiveness    *storage.NodeLiveness
-	storePool       *storage.StorePool
-	rpcCtx          *rpc.Context
-	stores          *storage.Stores
-	stopper         *stop.Stopper
-	sessionRegistry *sql.SessionRegistry
-	si              systemInfoOnce
+	st                 *cluster.Settings
+	cfg                *base.Config
+	admin              *adminServer
+	db                 *client.DB
+	gossip             *gossip.Gossip
+	metricSource       metricMarshaler
+	nodeLiveness       *storage.NodeLiveness
+	storePool          *storage.StorePool
+	rpcCtx             *rpc.Context
+	stores             *storage.Stores
+	stopper            *stop.Stopper
+	sessionRegistry    *sql.SessionRegistry
+	contentionRegistry *contention.Registry
+	si                 systemInfoOnce
 }
 
 // newStatusServer allocates and returns a statusServer.
@@ -158,22 +162,24 @@ func newStatusServer(
 	stores *storage.Stores,
 	stopper *stop.Stopper,
 	sessionRegistry *sql.SessionRegistry,
+	contentionRegistry *contention.Registry,
 ) *statusServer {
 	ambient.AddLogTag("status", nil)
 	server := &statusServer{
-		AmbientContext:  ambient,
-		st:              st,
-		cfg:             cfg,
-		admin:           adminServer,
-		db:              db,
-		gossip:          gossip,
-		metricSource:    metricSource,
-		nodeLiveness:    nodeLiveness,
-		storePool:       storePool,
-		rpcCtx:          rpcCtx,
-		stores:          stores,
-		stopper:         stopper,
-		sessionRegistry: sessionRegistry,
+		AmbientContext:     ambient,
+		st:                 st,
+		cfg:                cfg,
+		admin:              adminServer,
+		db:                 db,
+		gossip:             gossip,
+		metricSource:       metricSource,
+		nodeLiveness:       nodeLiveness,
+		storePool:          storePool,
+		rpcCtx:             rpcCtx,
+		stores:             stores,
+		stopper:            stopper,
+		sessionRegistry:    sessionRegistry,
+		contentionRegistry: contentionRegistry,
 	}
 
 	return server
@@ -827,6 +833,9 @@ func (s *statusServer) LogFile(
 			}
 			return nil, err
 		}
+		if req.Redact {
+			entry.Redact()
+		}
 		resp.Entries = append(resp.Entries, entry)
 	}
 
@@ -1486,6 +1495,165 @@ func (s *statusServer) localHotRanges(ctx context.Context) serverpb.HotRangesRes
 	return resp
 }
 
+// Locks returns the locks held on each range, along with the requests waiting
+// on them, for either a single node or for all nodes in the cluster.
+func (s *statusServer) Locks(
+	ctx context.Context, req *serverpb.LocksRequest,
+) (*serverpb.LocksResponse, error) {
+	if _, err := s.admin.requireAdminUser(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	response := &serverpb.LocksResponse{
+		NodeID:        s.gossip.NodeID.Get(),
+		LocksByNodeID: make(map[roachpb.NodeID]serverpb.LocksResponse_NodeResponse),
+	}
+
+	if len(req.NodeID) > 0 {
+		requestedNodeID, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+
+		// Only locks from the local node.
+		if local {
+			response.LocksByNodeID[requestedNodeID] = s.localLocks(ctx)
+			return response, nil
+		}
+
+		// Only locks from one non-local node.
+		status, err := s.dialNode(ctx, requestedNodeID)
+		if err != nil {
+			return nil, err
+		}
+		return status.Locks(ctx, req)
+	}
+
+	// Locks from all nodes.
+	dialFn := func(ctx context.Context, nodeID roachpb.NodeID) (interface{}, error) {
+		client, err := s.dialNode(ctx, nodeID)
+		return client, err
+	}
+	remoteRequest := serverpb.LocksRequest{NodeID: "local"}
+	nodeFn := func(ctx context.Context, client interface{}, _ roachpb.NodeID) (interface{}, error) {
+		status := client.(serverpb.StatusClient)
+		return status.Locks(ctx, &remoteRequest)
+	}
+	responseFn := func(nodeID roachpb.NodeID, resp interface{}) {
+		locksResp := resp.(*serverpb.LocksResponse)
+		response.LocksByNodeID[nodeID] = locksResp.LocksByNodeID[nodeID]
+	}
+	errorFn := func(nodeID roachpb.NodeID, err error) {
+		response.LocksByNodeID[nodeID] = serverpb.LocksResponse_NodeResponse{
+			ErrorMessage: err.Error(),
+		}
+	}
+
+	if err := s.iterateNodes(ctx, "locks", dialFn, nodeFn, responseFn, errorFn); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *statusServer) localLocks(ctx context.Context) serverpb.LocksResponse_NodeResponse {
+	var resp serverpb.LocksResponse_NodeResponse
+	includeRawKeys := debug.GatewayRemoteAllowed(ctx, s.st)
+	err := s.stores.VisitStores(func(store *storage.Store) error {
+		storeResp := serverpb.LocksResponse_StoreResponse{StoreID: store.StoreID()}
+		store.VisitReplicas(func(r *storage.Replica) bool {
+			locks := r.LockTableInfo()
+			if len(locks) == 0 {
+				return true
+			}
+			if !includeRawKeys {
+				for i := range locks {
+					locks[i].Key = nil
+				}
+			}
+			storeResp.Ranges = append(storeResp.Ranges, serverpb.LocksResponse_RangeLocks{
+				RangeID: r.RangeID,
+				Locks:   locks,
+			})
+			return true
+		})
+		sort.Slice(storeResp.Ranges, func(i, j int) bool {
+			return storeResp.Ranges[i].RangeID < storeResp.Ranges[j].RangeID
+		})
+		resp.Stores = append(resp.Stores, storeResp)
+		return nil
+	})
+	if err != nil {
+		return serverpb.LocksResponse_NodeResponse{ErrorMessage: err.Error()}
+	}
+	return resp
+}
+
+// ContentionEvents returns a summary of the contention events recorded over
+// the past hour, aggregated either for a single node or for all nodes in the
+// cluster.
+func (s *statusServer) ContentionEvents(
+	ctx context.Context, req *serverpb.ContentionEventsRequest,
+) (*serverpb.ContentionEventsResponse, error) {
+	if _, err := s.admin.requireAdminUser(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	localReq := &serverpb.ContentionEventsRequest{NodeID: "local"}
+
+	if len(req.NodeID) > 0 {
+		requestedNodeID, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if local {
+			return &serverpb.ContentionEventsResponse{
+				Summary: s.contentionRegistry.Summary(timeutil.Now()),
+			}, nil
+		}
+		status, err := s.dialNode(ctx, requestedNodeID)
+		if err != nil {
+			return nil, err
+		}
+		return status.ContentionEvents(ctx, localReq)
+	}
+
+	var summaries []storagepb.ContentionSummary
+	var nodeErrors []serverpb.ContentionEventsResponse_NodeError
+	dialFn := func(ctx context.Context, nodeID roachpb.NodeID) (interface{}, error) {
+		client, err := s.dialNode(ctx, nodeID)
+		return client, err
+	}
+	nodeFn := func(ctx context.Context, client interface{}, _ roachpb.NodeID) (interface{}, error) {
+		status := client.(serverpb.StatusClient)
+		return status.ContentionEvents(ctx, localReq)
+	}
+	responseFn := func(_ roachpb.NodeID, resp interface{}) {
+		summaries = append(summaries, resp.(*serverpb.ContentionEventsResponse).Summary)
+	}
+	errorFn := func(nodeID roachpb.NodeID, err error) {
+		nodeErrors = append(nodeErrors, serverpb.ContentionEventsResponse_NodeError{
+			NodeID:  nodeID,
+			Message: err.Error(),
+		})
+	}
+
+	if err := s.iterateNodes(ctx, "contention events", dialFn, nodeFn, responseFn, errorFn); err != nil {
+		return nil, err
+	}
+
+	return &serverpb.ContentionEventsResponse{
+		Summary: contention.MergeSummaries(summaries...),
+		Errors:  nodeErrors,
+	}, nil
+}
+
 // Range returns rangeInfos for all nodes in the cluster about a specific
 // range. It also returns the range history for that range as well.
 func (s *statusServer) Range(
@@ -2058,3 +2226,58 @@ func (s *statusServer) JobStatus(
 		Job: j.ToProto(),
 	}, nil
 }
+
+const (
+	// defaultJobTraceDuration is how long JobTrace captures a trace for when
+	// the request doesn't specify it.
+	defaultJobTraceDuration = 10 * time.Second
+	// maxJobTraceDuration is the longest JobTrace captures a trace for. Longer
+	// requested durations are clamped to it.
+	maxJobTraceDuration = 5 * time.Minute
+)
+
+// JobTrace captures a trace of a running job on the node it is running on. The
+// trace is also recorded in the job's messages.
+func (s *statusServer) JobTrace(
+	ctx context.Context, req *serverpb.JobTraceRequest,
+) (*serverpb.JobTraceResponse, error) {
+	if _, err := s.admin.requireAdminUser(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	j, err := s.admin.server.jobRegistry.LoadJob(ctx, req.JobId)
+	if err != nil {
+		return nil, err
+	}
+	lease := j.Payload().Lease
+	if lease == nil {
+		return nil, grpcstatus.Errorf(
+			codes.FailedPrecondition, "job %d is not running on any node", req.JobId)
+	}
+	if lease.NodeID != s.gossip.NodeID.Get() {
+		status, err := s.dialNode(ctx, lease.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		return status.JobTrace(ctx, req)
+	}
+
+	duration := defaultJobTraceDuration
+	if req.DurationSeconds > 0 {
+		duration = time.Duration(req.DurationSeconds) * time.Second
+	}
+	if duration > maxJobTraceDuration {
+		duration = maxJobTraceDuration
+	}
+	rec, err := s.admin.server.jobRegistry.CaptureTrace(ctx, req.JobId, duration)
+	if err != nil {
+		return nil, grpcstatus.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &serverpb.JobTraceResponse{
+		NodeID:    lease.NodeID,
+		Recording: rec.String(),
+	}, nil
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
@@ -74,6 +75,17 @@ var recordHistogramQuantiles = []quantile{
 	{"-p50", 50},
 }
 
+// recordHistogramBuckets controls whether histograms are also recorded as
+// buckets in the time series database. Every non-empty bucket of every
+// histogram is stored as its own series, which multiplies the number of
+// series that a node writes, so this is off by default.
+var recordHistogramBuckets = settings.RegisterBoolSetting(
+	"timeseries.storage.histogram_buckets.enabled",
+	"if set, the buckets of histogram metrics are stored as timeseries data, which "+
+		"allows querying arbitrary quantiles but greatly increases the number of stored series",
+	false,
+)
+
 // storeMetrics is the minimum interface of the storage.Store object needed by
 // MetricsRecorder to provide status summaries. This is used instead of Store
 // directly in order to simplify testing.
@@ -306,11 +318,13 @@ func (mr *MetricsRecorder) GetTimeSeriesData() []tspb.TimeSeriesData {
 
 	// Record time series from node-level registries.
 	now := mr.clock.PhysicalNow()
+	recordBuckets := recordHistogramBuckets.Get(&mr.settings.SV)
 	recorder := registryRecorder{
 		registry:       mr.mu.nodeRegistry,
 		format:         nodeTimeSeriesPrefix,
 		source:         strconv.FormatInt(int64(mr.mu.desc.NodeID), 10),
 		timestampNanos: now,
+		recordBuckets:  recordBuckets,
 	}
 	recorder.record(&data)
 
@@ -321,6 +335,7 @@ func (mr *MetricsRecorder) GetTimeSeriesData() []tspb.TimeSeriesData {
 			format:         storeTimeSeriesPrefix,
 			source:         strconv.FormatInt(int64(storeID), 10),
 			timestampNanos: now,
+			recordBuckets:  recordBuckets,
 		}
 		storeRecorder.record(&data)
 	}
@@ -328,6 +343,32 @@ func (mr *MetricsRecorder) GetTimeSeriesData() []tspb.TimeSeriesData {
 	return data
 }
 
+// TimeSeriesNames returns the names of the time series recorded by
+// GetTimeSeriesData, keyed by their name in the Prometheus exposition
+// format, e.g. cr.node.sql.service.latency-p99 for sql_service_latency_p99.
+func (mr *MetricsRecorder) TimeSeriesNames() map[string]string {
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	if mr.mu.nodeRegistry == nil {
+		// We haven't yet processed initialization information; do nothing.
+		return nil
+	}
+
+	names := make(map[string]string)
+	eachRecordableValue(mr.mu.nodeRegistry, func(name string, _ float64) {
+		names[metric.ExportedName(name)] = fmt.Sprintf(nodeTimeSeriesPrefix, name)
+	})
+	// All stores have the same metrics.
+	for _, r := range mr.mu.storeRegistries {
+		eachRecordableValue(r, func(name string, _ float64) {
+			names[metric.ExportedName(name)] = fmt.Sprintf(storeTimeSeriesPrefix, name)
+		})
+		break
+	}
+	return names
+}
+
 // GetMetricsMetadata returns the metadata from all metrics tracked in the node's
 // nodeRegistry and a randomly selected storeRegistry.
 func (mr *MetricsRecorder) GetMetricsMetadata() map[string]metric.Metadata {
@@ -514,6 +555,8 @@ type registryRecorder struct {
 	format         string
 	source         string
 	timestampNanos int64
+	// recordBuckets is set if histograms should also be recorded as buckets.
+	recordBuckets bool
 }
 
 func extractValue(mtr interface{}) (float64, error) {
@@ -554,8 +597,9 @@ func eachRecordableValue(reg *metric.Registry, fn func(string, float64)) {
 			//
 			// Additionally, we can only aggregate max/min of the quantiles;
 			// roll-ups don't know that and so they will return mathematically
-			// nonsensical values, but that seems acceptable for the time
-			// being.
+			// nonsensical values. Queries for arbitrary quantiles, which merge
+			// histograms correctly, use the buckets recorded by
+			// eachHistogramBucket instead.
 			curr, _ := histogram.Windowed()
 			for _, pt := range recordHistogramQuantiles {
 				fn(name+pt.suffix, float64(curr.ValueAtQuantile(pt.quantile)))
@@ -571,6 +615,31 @@ func eachRecordableValue(reg *metric.Registry, fn func(string, float64)) {
 	})
 }
 
+// eachHistogramBucket visits each histogram in the registry, calling the
+// supplied function once for each non-empty bucket of its cumulative
+// histogram, in the bucket layout of the time series database (see
+// tspb.HistogramBucket).
+func eachHistogramBucket(reg *metric.Registry, fn func(name string, bucket int, count float64)) {
+	var counts [tspb.NumHistogramBuckets]int64
+	reg.Each(func(name string, mtr interface{}) {
+		histogram, ok := mtr.(*metric.Histogram)
+		if !ok {
+			return
+		}
+		counts = [tspb.NumHistogramBuckets]int64{}
+		for _, bar := range histogram.Snapshot().Distribution() {
+			if bar.Count > 0 {
+				counts[tspb.HistogramBucket(float64(bar.To))] += bar.Count
+			}
+		}
+		for bucket, count := range counts {
+			if count > 0 {
+				fn(name, bucket, float64(count))
+			}
+		}
+	})
+}
+
 func (rr registryRecorder) record(dest *[]tspb.TimeSeriesData) {
 	eachRecordableValue(rr.registry, func(name string, val float64) {
 		*dest = append(*dest, tspb.TimeSeriesData{
@@ -584,6 +653,21 @@ func (rr registryRecorder) record(dest *[]tspb.TimeSeriesData) {
 			},
 		})
 	})
+	if !rr.recordBuckets {
+		return
+	}
+	eachHistogramBucket(rr.registry, func(name string, bucket int, count float64) {
+		*dest = append(*dest, tspb.TimeSeriesData{
+			Name:   fmt.Sprintf(rr.format, tspb.HistogramSeriesName(name)),
+			Source: tspb.HistogramBucketSource(rr.source, bucket),
+			Datapoints: []tspb.TimeSeriesDatapoint{
+				{
+					TimestampNanos: rr.timestampNanos,
+					Value:          count,
+				},
+			},
+		})
+	})
 }
 
 // GetTotalMemory returns either the total system memory (in bytes) or if
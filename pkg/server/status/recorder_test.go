@@ -249,6 +249,26 @@ func TestMetricsRecorder(t *testing.T) {
 		}
 	}
 
+	// addExpectedBuckets generates expected data for the buckets of a
+	// histogram holding a single value.
+	var expectedBuckets []tspb.TimeSeriesData
+	addExpectedBuckets := func(prefix, name string, source, time, val int64, isNode bool) {
+		tsPrefix := "cr.node."
+		if !isNode {
+			tsPrefix = "cr.store."
+		}
+		expectedBuckets = append(expectedBuckets, tspb.TimeSeriesData{
+			Name:   tspb.HistogramSeriesName(tsPrefix + prefix + name),
+			Source: tspb.HistogramBucketSource(strconv.FormatInt(source, 10), tspb.HistogramBucket(float64(val))),
+			Datapoints: []tspb.TimeSeriesDatapoint{
+				{
+					TimestampNanos: time,
+					Value:          1,
+				},
+			},
+		})
+	}
+
 	// Add metric for node ID.
 	g := metric.NewGauge(metric.Metadata{Name: "node-id"})
 	g.Update(int64(nodeDesc.NodeID))
@@ -276,6 +296,7 @@ func TestMetricsRecorder(t *testing.T) {
 				h := metric.NewHistogram(metric.Metadata{Name: reg.prefix + data.name}, time.Second, 1000, 2)
 				reg.reg.AddMetric(h)
 				h.RecordValue(data.val)
+				addExpectedBuckets(reg.prefix, data.name, reg.source, 100, data.val, reg.isNode)
 				for _, q := range recordHistogramQuantiles {
 					addExpected(reg.prefix, data.name+q.suffix, reg.source, 100, data.val, reg.isNode)
 				}
@@ -283,6 +304,7 @@ func TestMetricsRecorder(t *testing.T) {
 				l := metric.NewLatency(metric.Metadata{Name: reg.prefix + data.name}, time.Hour)
 				reg.reg.AddMetric(l)
 				l.RecordValue(data.val)
+				addExpectedBuckets(reg.prefix, data.name, reg.source, 100, data.val, reg.isNode)
 				// Latency is simply three histograms (at different resolution
 				// time scales).
 				for _, q := range recordHistogramQuantiles {
@@ -307,6 +329,27 @@ func TestMetricsRecorder(t *testing.T) {
 		t.Errorf("recorder did not yield expected time series collection; diff:\n %v", pretty.Diff(e, a))
 	}
 
+	// Histogram buckets are only recorded once enabled.
+	recordHistogramBuckets.Override(&st.SV, true)
+	actual = recorder.GetTimeSeriesData()
+	allExpected := append(append([]tspb.TimeSeriesData(nil), expected...), expectedBuckets...)
+	sort.Sort(byTimeAndName(actual))
+	sort.Sort(byTimeAndName(allExpected))
+	if a, e := actual, allExpected; !reflect.DeepEqual(a, e) {
+		t.Errorf("recorder did not yield expected histogram buckets; diff:\n %v", pretty.Diff(e, a))
+	}
+
+	// Every recorded time series is named in the Prometheus format.
+	tsNames := make(map[string]bool)
+	for _, name := range recorder.TimeSeriesNames() {
+		tsNames[name] = true
+	}
+	for _, data := range expected {
+		if !tsNames[data.Name] {
+			t.Errorf("time series %s is missing from the time series names", data.Name)
+		}
+	}
+
 	totalMemory, err := GetTotalMemory(context.Background())
 	if err != nil {
 		t.Error("couldn't get total memory", err)
@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
@@ -39,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/storage/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
@@ -52,6 +54,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/gogo/protobuf/proto"
 	"github.com/kr/pretty"
 	"github.com/pkg/errors"
@@ -884,6 +887,90 @@ func TestHotRangesResponse(t *testing.T) {
 	}
 }
 
+func TestLocksResponse(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ts := startServer(t)
+	defer ts.Stopper().Stop(context.TODO())
+	ctx := context.Background()
+
+	// Lay down an intent with one transaction and block a second transaction
+	// on it, so that the lock shows up in the lock table with a waiter.
+	key := roachpb.Key("a")
+	holder := ts.db.NewTxn(ctx, "holder")
+	if err := holder.Put(ctx, key, "holder"); err != nil {
+		t.Fatal(err)
+	}
+	holderID := holder.ID()
+	waiterIDs := make(chan uuid.UUID, 1)
+	waiterErr := make(chan error, 1)
+	go func() {
+		waiterErr <- ts.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			select {
+			case waiterIDs <- txn.ID():
+			default:
+			}
+			return txn.Put(ctx, key, "waiter")
+		})
+	}()
+	waiterID := <-waiterIDs
+
+	testutils.SucceedsSoon(t, func() error {
+		var locksResp serverpb.LocksResponse
+		if err := getStatusJSONProto(ts, "locks", &locksResp); err != nil {
+			t.Fatal(err)
+		}
+		if len(locksResp.LocksByNodeID) == 0 {
+			t.Fatalf("didn't get lock responses from any nodes")
+		}
+
+		var found bool
+		for nodeID, nodeResp := range locksResp.LocksByNodeID {
+			if nodeResp.ErrorMessage != "" {
+				t.Fatalf("unexpected error in lock response from n%d: %s", nodeID, nodeResp.ErrorMessage)
+			}
+			if len(nodeResp.Stores) == 0 {
+				t.Fatalf("didn't get any stores in lock response from n%d", nodeID)
+			}
+			for _, storeResp := range nodeResp.Stores {
+				for _, r := range storeResp.Ranges {
+					if r.RangeID == 0 || len(r.Locks) == 0 {
+						t.Fatalf("unexpected empty range in lock response from n%d,s%d: %+v",
+							nodeID, storeResp.StoreID, r)
+					}
+					for _, l := range r.Locks {
+						if !l.Key.Equal(key) {
+							continue
+						}
+						if l.LockHolder == nil || l.LockHolder.ID != holderID {
+							t.Fatalf("expected lock on %s to be held by %s, found %+v", key, holderID, l)
+						}
+						if l.Durability != lock.Replicated || l.Strength != lock.Exclusive {
+							t.Fatalf("expected replicated exclusive lock on %s, found %+v", key, l)
+						}
+						for _, w := range l.Waiters {
+							if w.WaitingTxn != nil && w.WaitingTxn.ID == waiterID {
+								found = true
+							}
+						}
+					}
+				}
+			}
+		}
+		if !found {
+			return errors.Errorf("lock on %s held by %s with waiter %s not found", key, holderID, waiterID)
+		}
+		return nil
+	})
+
+	// Committing the holder releases the lock and lets the waiter proceed.
+	if err := holder.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-waiterErr; err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestRangesResponse(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer storage.EnableLeaseHistory(100)()
@@ -1428,6 +1515,115 @@ func TestStatusAPIStatements(t *testing.T) {
 	}
 }
 
+func TestStatusAPITransactions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+	db := sqlutils.MakeSQLRunner(sqlDB)
+
+	db.Exec(t, `CREATE TABLE t (k INT8 PRIMARY KEY, v INT8)`)
+	for i := 0; i < 2; i++ {
+		tx, err := sqlDB.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.Exec(`INSERT INTO t VALUES ($1, $1)`, i); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.Exec(`SELECT v FROM t WHERE k = $1`, i); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var resp serverpb.StatementsResponse
+	if err := getStatusJSONProto(s, "statements", &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedStatements := []string{
+		`INSERT INTO t VALUES ($1, $1)`,
+		`SELECT v FROM t WHERE k = $1`,
+	}
+	var found bool
+	for _, txn := range resp.Transactions {
+		if !reflect.DeepEqual(txn.StatsData.StatementFingerprints, expectedStatements) {
+			continue
+		}
+		found = true
+		stats := txn.StatsData.Stats
+		if txn.NodeID != s.NodeID() || txn.StatsData.FingerprintID == 0 {
+			t.Errorf("unexpected transaction key: %+v", txn)
+		}
+		if stats.Count != 2 || stats.CommittedCount != 2 {
+			t.Errorf("expected 2 committed transactions, got %+v", stats)
+		}
+		if stats.CommitLatCount != 2 || stats.CommitLat.Mean <= 0 {
+			t.Errorf("expected the commits of 2 transactions to be timed, got %+v", stats)
+		}
+		if stats.RowsWritten.Mean != 1 || stats.ServiceLat.Mean <= 0 {
+			t.Errorf("unexpected transaction statistics: %+v", stats)
+		}
+	}
+	if !found {
+		t.Fatalf("transaction %v not found in:\n%s", expectedStatements, pretty.Sprint(resp.Transactions))
+	}
+}
+
+func TestStatusAPIStatementResourceUsage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCluster := serverutils.StartTestCluster(t, 3, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+	})
+	defer testCluster.Stopper().Stop(context.Background())
+	// Use a single connection, so that the session settings and the session
+	// totals apply to all the statements.
+	sqlDB := testCluster.ServerConn(0)
+	sqlDB.SetMaxOpenConns(1)
+	db := sqlutils.MakeSQLRunner(sqlDB)
+
+	// Spread the table across the nodes so that the query runs remote flows.
+	db.Exec(t, `CREATE TABLE t (k INT8 PRIMARY KEY)`)
+	db.Exec(t, `INSERT INTO t SELECT generate_series(1, 30)`)
+	db.Exec(t, `ALTER TABLE t SPLIT AT VALUES (10), (20)`)
+	db.Exec(t, `ALTER TABLE t EXPERIMENTAL_RELOCATE VALUES (ARRAY[2], 10), (ARRAY[3], 20)`)
+	db.Exec(t, `SET distsql = always`)
+
+	testutils.SucceedsSoon(t, func() error {
+		// The first executions populate the range cache of the gateway, after
+		// which the table readers are planned on the leaseholders.
+		db.Exec(t, `SELECT sum(k) FROM t`)
+
+		var resp serverpb.StatementsResponse
+		if err := getStatusJSONProto(testCluster.Server(0), "statements", &resp); err != nil {
+			return err
+		}
+		for _, stmt := range resp.Statements {
+			if stmt.Key.KeyData.Query != `SELECT sum(k) FROM t` || !stmt.Key.KeyData.DistSQL {
+				continue
+			}
+			stats := stmt.Stats
+			if stats.KVBytesRead.Mean <= 0 || stats.ExecTimeExclWaits.Mean <= 0 || stats.NetworkBytes.Mean <= 0 {
+				return errors.Errorf("unexpected resource usage: %+v", stats)
+			}
+			return nil
+		}
+		return errors.New("statement not found")
+	})
+
+	// The session accumulates the resources used by its statements.
+	db.CheckQueryResults(t, `
+SELECT kv_bytes_read > 0, exec_time_excl_waits > '0s', network_bytes > 0
+  FROM crdb_internal.node_sessions
+ WHERE active_queries LIKE '%crdb_internal.node_sessions%'`,
+		[][]string{{"true", "true", "true"}},
+	)
+}
+
 func TestListSessionsSecurity(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
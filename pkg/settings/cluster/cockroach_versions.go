@@ -54,6 +54,13 @@ const (
 	VersionNoExplicitForeignKeyIndexIDs
 	VersionHashShardedIndexes
 	VersionCreateRolePrivilege
+	VersionNonVoterReplicas
+	VersionMVCCRangeTombstones
+	VersionScheduledJobs
+	VersionJobObservability
+	VersionStatementDiagnostics
+	VersionPersistedSQLStats
+	VersionPersistedTxnStats
 
 	// Add new versions here (step one of two).
 )
@@ -417,6 +424,47 @@ var versionsSingleton = keyedVersions([]keyedVersion{
 		Key:     VersionCreateRolePrivilege,
 		Version: roachpb.Version{Major: 19, Minor: 2, Unstable: 13},
 	},
+	{
+		// VersionNonVoterReplicas enables the use of replicas of type NON_VOTER,
+		// which are placed according to the num_non_voters zone config field.
+		Key:     VersionNonVoterReplicas,
+		Version: roachpb.Version{Major: 19, Minor: 2, Unstable: 14},
+	},
+	{
+		// VersionMVCCRangeTombstones enables DeleteRange requests that write
+		// MVCC range tombstones, and reads that take them into account.
+		Key:     VersionMVCCRangeTombstones,
+		Version: roachpb.Version{Major: 19, Minor: 2, Unstable: 15},
+	},
+	{
+		// VersionScheduledJobs adds the system.scheduled_jobs table and the job
+		// scheduler that runs the schedules stored in it.
+		Key:     VersionScheduledJobs,
+		Version: roachpb.Version{Major: 19, Minor: 2, Unstable: 16},
+	},
+	{
+		// VersionJobObservability adds the system.job_messages and
+		// system.job_processor_progress tables.
+		Key:     VersionJobObservability,
+		Version: roachpb.Version{Major: 19, Minor: 2, Unstable: 17},
+	},
+	{
+		// VersionStatementDiagnostics adds the system.statement_bundle_chunks,
+		// system.statement_diagnostics_requests and system.statement_diagnostics
+		// tables.
+		Key:     VersionStatementDiagnostics,
+		Version: roachpb.Version{Major: 19, Minor: 2, Unstable: 18},
+	},
+	{
+		// VersionPersistedSQLStats adds the system.statement_statistics table.
+		Key:     VersionPersistedSQLStats,
+		Version: roachpb.Version{Major: 19, Minor: 2, Unstable: 19},
+	},
+	{
+		// VersionPersistedTxnStats adds the system.transaction_statistics table.
+		Key:     VersionPersistedTxnStats,
+		Version: roachpb.Version{Major: 19, Minor: 2, Unstable: 20},
+	},
 	// Add new versions here (step two of two).
 
 })
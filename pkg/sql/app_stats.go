@@ -18,6 +18,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
+	"hash/fnv"
 	"strings"
 	"time"
 
@@ -41,6 +43,10 @@ type stmtKey struct {
 	implicitTxn bool
 }
 
+// txnKey is the fingerprint of a transaction, computed from the fingerprints
+// of its statements.
+type txnKey uint64
+
 // appStats holds per-application statistics.
 type appStats struct {
 	syncutil.Mutex
@@ -48,6 +54,8 @@ type appStats struct {
 	st    *cluster.Settings
 	stmts map[stmtKey]*stmtStats
 	txns  transactionStats
+	// txnFingerprints holds the per-fingerprint transaction statistics.
+	txnFingerprints map[txnKey]*txnFingerprintStats
 }
 
 // stmtStats holds per-statement statistics.
@@ -55,6 +63,79 @@ type stmtStats struct {
 	syncutil.Mutex
 
 	data roachpb.StatementStatistics
+	// unflushed holds the statistics recorded since the statement statistics
+	// were last flushed to system.statement_statistics.
+	unflushed roachpb.StatementStatistics
+}
+
+// txnFingerprintStats holds per-transaction-fingerprint statistics.
+type txnFingerprintStats struct {
+	syncutil.Mutex
+
+	// stmtFingerprints are the fingerprints of the statements of the
+	// transaction, in execution order.
+	stmtFingerprints []string
+	data             roachpb.TransactionStatistics
+	// unflushed holds the statistics recorded since the transaction
+	// statistics were last flushed to system.transaction_statistics.
+	unflushed roachpb.TransactionStatistics
+}
+
+// maxTxnStmtFingerprints is the maximum number of statement fingerprints
+// kept for the display of a transaction fingerprint. The fingerprint itself
+// is computed from all the statements of the transaction.
+const maxTxnStmtFingerprints = 100
+
+// txnSummary accumulates the statement fingerprints of the current
+// transaction attempt and the rows its statements read and wrote, to be
+// recorded in the per-fingerprint transaction statistics once the
+// transaction finishes.
+type txnSummary struct {
+	hash             hash.Hash64
+	stmtFingerprints []string
+	rowsRead         int64
+	rowsWritten      int64
+	bytesRead        int64
+}
+
+// reset clears the summary for a new transaction or transaction attempt.
+func (s *txnSummary) reset() {
+	if s.hash != nil {
+		s.hash.Reset()
+	}
+	s.stmtFingerprints = s.stmtFingerprints[:0]
+	s.rowsRead, s.rowsWritten, s.bytesRead = 0, 0, 0
+}
+
+// addStatement adds an executed statement to the summary.
+func (s *txnSummary) addStatement(stmt *Statement, rowsAffected int, stats *topLevelQueryStats) {
+	fingerprint := stmtFingerprint(stmt)
+	if s.hash == nil {
+		s.hash = fnv.New64a()
+	}
+	// The hash only fails when writing to a closed file, see
+	// https://golang.org/pkg/hash.
+	_, _ = s.hash.Write([]byte(fingerprint))
+	// Separate the statements so that the concatenations of different
+	// fingerprints don't collide.
+	_, _ = s.hash.Write([]byte{0})
+	if len(s.stmtFingerprints) < maxTxnStmtFingerprints {
+		s.stmtFingerprints = append(s.stmtFingerprints, fingerprint)
+	}
+	if stmt.AST.StatementType() == tree.RowsAffected {
+		s.rowsWritten += int64(rowsAffected)
+	}
+	s.rowsRead += stats.rowsRead
+	s.bytesRead += stats.bytesRead
+}
+
+// fingerprint returns the fingerprint of the transaction, or false if the
+// transaction didn't execute any statement.
+func (s *txnSummary) fingerprint() (txnKey, bool) {
+	if s.hash == nil || len(s.stmtFingerprints) == 0 {
+		return 0, false
+	}
+	return txnKey(s.hash.Sum64()), true
 }
 
 // transactionStats holds per-application transaction statistics.
@@ -130,7 +211,7 @@ func (a *appStats) recordStatement(
 	numRows int,
 	err error,
 	parseLat, planLat, runLat, svcLat, ovhLat float64,
-	bytesRead, rowsRead int64,
+	stats *topLevelQueryStats,
 ) {
 	if !stmtStatsEnable.Get(&a.st.SV) {
 		return
@@ -143,30 +224,43 @@ func (a *appStats) recordStatement(
 	// Get the statistics object.
 	s := a.getStatsForStmt(stmt, distSQLUsed, implicitTxn, err, true /* createIfNonexistent */)
 
-	// Collect the per-statement statistics.
-	s.Lock()
-	s.data.Count++
-	if err != nil {
-		s.data.SensitiveInfo.LastErr = err.Error()
-	}
-	// Only update MostRecentPlanDescription if we sampled a new PlanDescription.
+	var planTimestamp time.Time
 	if samplePlanDescription != nil {
-		s.data.SensitiveInfo.MostRecentPlanDescription = *samplePlanDescription
-		s.data.SensitiveInfo.MostRecentPlanTimestamp = timeutil.Now()
-	}
-	if automaticRetryCount == 0 {
-		s.data.FirstAttemptCount++
-	} else if int64(automaticRetryCount) > s.data.MaxRetries {
-		s.data.MaxRetries = int64(automaticRetryCount)
-	}
-	s.data.NumRows.Record(s.data.Count, float64(numRows))
-	s.data.ParseLat.Record(s.data.Count, parseLat)
-	s.data.PlanLat.Record(s.data.Count, planLat)
-	s.data.RunLat.Record(s.data.Count, runLat)
-	s.data.ServiceLat.Record(s.data.Count, svcLat)
-	s.data.OverheadLat.Record(s.data.Count, ovhLat)
-	s.data.BytesRead = bytesRead
-	s.data.RowsRead = rowsRead
+		planTimestamp = timeutil.Now()
+	}
+	record := func(d *roachpb.StatementStatistics) {
+		d.Count++
+		if err != nil {
+			d.SensitiveInfo.LastErr = err.Error()
+		}
+		// Only update MostRecentPlanDescription if we sampled a new PlanDescription.
+		if samplePlanDescription != nil {
+			d.SensitiveInfo.MostRecentPlanDescription = *samplePlanDescription
+			d.SensitiveInfo.MostRecentPlanTimestamp = planTimestamp
+		}
+		if automaticRetryCount == 0 {
+			d.FirstAttemptCount++
+		} else if int64(automaticRetryCount) > d.MaxRetries {
+			d.MaxRetries = int64(automaticRetryCount)
+		}
+		d.NumRows.Record(d.Count, float64(numRows))
+		d.ParseLat.Record(d.Count, parseLat)
+		d.PlanLat.Record(d.Count, planLat)
+		d.RunLat.Record(d.Count, runLat)
+		d.ServiceLat.Record(d.Count, svcLat)
+		d.OverheadLat.Record(d.Count, ovhLat)
+		d.BytesRead = stats.bytesRead
+		d.RowsRead = stats.rowsRead
+		d.KVBytesRead.Record(d.Count, float64(stats.bytesRead))
+		d.ExecTimeExclWaits.Record(d.Count, stats.execTimeExclWaits.Seconds())
+		d.NetworkBytes.Record(d.Count, float64(stats.networkBytes))
+	}
+
+	// Collect the per-statement statistics, both since the last reset and
+	// since the last flush.
+	s.Lock()
+	record(&s.data)
+	record(&s.unflushed)
 	s.Unlock()
 }
 
@@ -176,15 +270,22 @@ func (a *appStats) getStatsForStmt(
 ) *stmtStats {
 	// Extend the statement key with various characteristics, so
 	// that we use separate buckets for the different situations.
-	key := stmtKey{failed: err != nil, distSQLUsed: distSQLUsed, implicitTxn: implicitTxn}
+	key := stmtKey{
+		stmt:        stmtFingerprint(stmt),
+		failed:      err != nil,
+		distSQLUsed: distSQLUsed,
+		implicitTxn: implicitTxn,
+	}
+	return a.getStatsForStmtWithKey(key, createIfNonexistent)
+}
+
+// stmtFingerprint returns the anonymized string of the statement.
+func stmtFingerprint(stmt *Statement) string {
 	if stmt.AnonymizedStr != "" {
 		// Use the cached anonymized string.
-		key.stmt = stmt.AnonymizedStr
-	} else {
-		key.stmt = anonymizeStmt(stmt.AST)
+		return stmt.AnonymizedStr
 	}
-
-	return a.getStatsForStmtWithKey(key, createIfNonexistent)
+	return anonymizeStmt(stmt.AST)
 }
 
 func (a *appStats) getStatsForStmtWithKey(key stmtKey, createIfNonexistent bool) *stmtStats {
@@ -200,6 +301,21 @@ func (a *appStats) getStatsForStmtWithKey(key stmtKey, createIfNonexistent bool)
 	return s
 }
 
+func (a *appStats) getStatsForTxnWithKey(
+	key txnKey, stmtFingerprints []string, createIfNonexistent bool,
+) *txnFingerprintStats {
+	a.Lock()
+	s, ok := a.txnFingerprints[key]
+	if !ok && createIfNonexistent {
+		s = &txnFingerprintStats{
+			stmtFingerprints: append([]string(nil), stmtFingerprints...),
+		}
+		a.txnFingerprints[key] = s
+	}
+	a.Unlock()
+	return s
+}
+
 // Add combines one appStats into another. Add manages locks on a, so taking
 // a lock on a will cause a deadlock.
 func (a *appStats) Add(other *appStats) {
@@ -208,6 +324,10 @@ func (a *appStats) Add(other *appStats) {
 	for k, v := range other.stmts {
 		statMap[k] = v
 	}
+	txnMap := make(map[txnKey]*txnFingerprintStats)
+	for k, v := range other.txnFingerprints {
+		txnMap[k] = v
+	}
 	other.Unlock()
 
 	// Copy the statement stats for each statement key.
@@ -228,6 +348,18 @@ func (a *appStats) Add(other *appStats) {
 		s.Unlock()
 	}
 
+	// Merge the per-fingerprint transaction stats. The statement
+	// fingerprints of an entry never change once it is created.
+	for k, v := range txnMap {
+		v.Lock()
+		data := v.data
+		v.Unlock()
+		s := a.getStatsForTxnWithKey(k, v.stmtFingerprints, true /* createIfNonexistent */)
+		s.Lock()
+		s.data.Add(&data)
+		s.Unlock()
+	}
+
 	// Create a copy of the other's transactions statistics.
 	other.txns.mu.Lock()
 	txnStats := other.txns.mu.TxnStats
@@ -273,11 +405,50 @@ func (s *transactionStats) recordTransaction(txnTimeSec float64, ev txnEvent, im
 	}
 }
 
-func (a *appStats) recordTransaction(txnTimeSec float64, ev txnEvent, implicit bool) {
+func (a *appStats) recordTransaction(
+	txnTimeSec float64,
+	ev txnEvent,
+	implicit bool,
+	summary *txnSummary,
+	automaticRetryCount int,
+	commitLat float64,
+	commitTimed bool,
+) {
 	if !txnStatsEnable.Get(&a.st.SV) {
 		return
 	}
 	a.txns.recordTransaction(txnTimeSec, ev, implicit)
+
+	key, ok := summary.fingerprint()
+	if !ok {
+		return
+	}
+	record := func(d *roachpb.TransactionStatistics) {
+		d.Count++
+		if ev == txnCommit {
+			d.CommittedCount++
+			if commitTimed {
+				d.CommitLatCount++
+				d.CommitLat.Record(d.CommitLatCount, commitLat)
+			}
+		}
+		if int64(automaticRetryCount) > d.MaxRetries {
+			d.MaxRetries = int64(automaticRetryCount)
+		}
+		d.NumRetries.Record(d.Count, float64(automaticRetryCount))
+		d.ServiceLat.Record(d.Count, txnTimeSec)
+		d.RowsRead.Record(d.Count, float64(summary.rowsRead))
+		d.RowsWritten.Record(d.Count, float64(summary.rowsWritten))
+		d.BytesRead.Record(d.Count, float64(summary.bytesRead))
+	}
+
+	// Collect the per-fingerprint statistics, both since the last reset and
+	// since the last flush.
+	s := a.getStatsForTxnWithKey(key, summary.stmtFingerprints, true /* createIfNonexistent */)
+	s.Lock()
+	defer s.Unlock()
+	record(&s.data)
+	record(&s.unflushed)
 }
 
 // shouldSaveLogicalPlanDescription returns whether we should save this as a
@@ -321,8 +492,9 @@ func (s *sqlStats) getStatsForApplication(appName string) *appStats {
 		return a
 	}
 	a := &appStats{
-		st:    s.st,
-		stmts: make(map[stmtKey]*stmtStats),
+		st:              s.st,
+		stmts:           make(map[stmtKey]*stmtStats),
+		txnFingerprints: make(map[txnKey]*txnFingerprintStats),
 	}
 	s.apps[appName] = a
 	return a
@@ -374,14 +546,87 @@ func (s *sqlStats) resetStats(ctx context.Context) {
 		}
 
 		// Clear the map, to release the memory; make the new map somewhat already
-		// large for the likely future workload.
-		a.stmts = make(map[stmtKey]*stmtStats, len(a.stmts)/2)
+		// large for the likely future workload. The statistics which haven't
+		// been flushed yet are carried over, to be flushed next time.
+		stmts := make(map[stmtKey]*stmtStats, len(a.stmts)/2)
+		for key, stats := range a.stmts {
+			stats.Lock()
+			if stats.unflushed.Count > 0 {
+				stmts[key] = &stmtStats{unflushed: stats.unflushed}
+			}
+			stats.Unlock()
+		}
+		a.stmts = stmts
+		txns := make(map[txnKey]*txnFingerprintStats, len(a.txnFingerprints)/2)
+		for key, stats := range a.txnFingerprints {
+			stats.Lock()
+			if stats.unflushed.Count > 0 {
+				txns[key] = &txnFingerprintStats{
+					stmtFingerprints: stats.stmtFingerprints,
+					unflushed:        stats.unflushed,
+				}
+			}
+			stats.Unlock()
+		}
+		a.txnFingerprints = txns
 		a.Unlock()
 	}
 	s.lastReset = timeutil.Now()
 	s.Unlock()
 }
 
+// popUnflushedStmtStats returns, by application, the per-statement
+// statistics recorded since the last flush, and clears them. The statistics
+// since the last reset are left alone.
+func (s *sqlStats) popUnflushedStmtStats() map[string]map[stmtKey]roachpb.StatementStatistics {
+	s.Lock()
+	defer s.Unlock()
+	ret := make(map[string]map[stmtKey]roachpb.StatementStatistics)
+	for appName, a := range s.apps {
+		a.Lock()
+		for key, stats := range a.stmts {
+			stats.Lock()
+			if stats.unflushed.Count > 0 {
+				if ret[appName] == nil {
+					ret[appName] = make(map[stmtKey]roachpb.StatementStatistics)
+				}
+				ret[appName][key] = stats.unflushed
+				stats.unflushed = roachpb.StatementStatistics{}
+			}
+			stats.Unlock()
+		}
+		a.Unlock()
+	}
+	return ret
+}
+
+// popUnflushedTxnStats returns the per-fingerprint transaction statistics
+// recorded since the last flush, and clears them. The statistics since the
+// last reset are left alone.
+func (s *sqlStats) popUnflushedTxnStats() []roachpb.CollectedTransactionStatistics {
+	s.Lock()
+	defer s.Unlock()
+	var ret []roachpb.CollectedTransactionStatistics
+	for appName, a := range s.apps {
+		a.Lock()
+		for key, stats := range a.txnFingerprints {
+			stats.Lock()
+			if stats.unflushed.Count > 0 {
+				ret = append(ret, roachpb.CollectedTransactionStatistics{
+					App:                   appName,
+					FingerprintID:         uint64(key),
+					StatementFingerprints: stats.stmtFingerprints,
+					Stats:                 stats.unflushed,
+				})
+				stats.unflushed = roachpb.TransactionStatistics{}
+			}
+			stats.Unlock()
+		}
+		a.Unlock()
+	}
+	return ret
+}
+
 func (s *sqlStats) getLastReset() time.Time {
 	s.Lock()
 	defer s.Unlock()
@@ -397,8 +642,12 @@ func dumpStmtStats(ctx context.Context, appName string, stats map[stmtKey]*stmtS
 	fmt.Fprintf(&buf, "Statistics for %q:\n", appName)
 	for key, s := range stats {
 		s.Lock()
-		json, err := json.Marshal(s.data)
+		data := s.data
 		s.Unlock()
+		if data.Count == 0 {
+			continue
+		}
+		json, err := json.Marshal(data)
 		if err != nil {
 			log.Errorf(ctx, "error while marshaling stats for %q // %q: %v", appName, key.String(), err)
 			continue
@@ -443,17 +692,23 @@ func scrubStmtStatKey(vt VirtualTabler, key string) (string, bool) {
 func (s *sqlStats) getScrubbedStmtStats(
 	vt *VirtualSchemaHolder,
 ) []roachpb.CollectedStatementStatistics {
-	return s.getStmtStats(vt, true /* scrub */)
+	return s.getStmtStats(vt, true /* scrub */, false /* unflushed */)
 }
 
 func (s *sqlStats) getUnscrubbedStmtStats(
 	vt *VirtualSchemaHolder,
 ) []roachpb.CollectedStatementStatistics {
-	return s.getStmtStats(vt, false /* scrub */)
+	return s.getStmtStats(vt, false /* scrub */, false /* unflushed */)
+}
+
+func (s *sqlStats) getUnflushedStmtStats(
+	vt *VirtualSchemaHolder,
+) []roachpb.CollectedStatementStatistics {
+	return s.getStmtStats(vt, false /* scrub */, true /* unflushed */)
 }
 
 func (s *sqlStats) getStmtStats(
-	vt *VirtualSchemaHolder, scrub bool,
+	vt *VirtualSchemaHolder, scrub bool, unflushed bool,
 ) []roachpb.CollectedStatementStatistics {
 	s.Lock()
 	defer s.Unlock()
@@ -486,7 +741,15 @@ func (s *sqlStats) getStmtStats(
 				}
 				stats.Lock()
 				data := stats.data
+				if unflushed {
+					data = stats.unflushed
+				}
 				stats.Unlock()
+				if data.Count == 0 {
+					// The entry only holds statistics carried over a reset to
+					// be flushed, or none since the last flush.
+					continue
+				}
 
 				if scrub {
 					// Quantize the counts to avoid leaking information that way.
@@ -502,6 +765,46 @@ func (s *sqlStats) getStmtStats(
 	return ret
 }
 
+// getUnscrubbedTxnStats returns the per-fingerprint transaction statistics
+// of all the applications.
+func (s *sqlStats) getUnscrubbedTxnStats() []roachpb.CollectedTransactionStatistics {
+	return s.getTxnStats(false /* unflushed */)
+}
+
+// getUnflushedTxnStats returns the per-fingerprint transaction statistics of
+// all the applications which haven't been flushed yet.
+func (s *sqlStats) getUnflushedTxnStats() []roachpb.CollectedTransactionStatistics {
+	return s.getTxnStats(true /* unflushed */)
+}
+
+func (s *sqlStats) getTxnStats(unflushed bool) []roachpb.CollectedTransactionStatistics {
+	s.Lock()
+	defer s.Unlock()
+	var ret []roachpb.CollectedTransactionStatistics
+	for appName, a := range s.apps {
+		a.Lock()
+		for key, stats := range a.txnFingerprints {
+			stats.Lock()
+			data := stats.data
+			if unflushed {
+				data = stats.unflushed
+			}
+			stats.Unlock()
+			if data.Count == 0 {
+				continue
+			}
+			ret = append(ret, roachpb.CollectedTransactionStatistics{
+				App:                   appName,
+				FingerprintID:         uint64(key),
+				StatementFingerprints: stats.stmtFingerprints,
+				Stats:                 data,
+			})
+		}
+		a.Unlock()
+	}
+	return ret
+}
+
 // quantizeCounts ensures that the counts are bucketed into "simple" values.
 func quantizeCounts(d *roachpb.StatementStatistics) {
 	oldCount := d.Count
@@ -0,0 +1,398 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// sqlStatsFlushEnabled determines whether the statement and transaction
+// statistics of each node are periodically flushed to system tables.
+var sqlStatsFlushEnabled = settings.RegisterPublicBoolSetting(
+	"sql.stats.flush.enabled",
+	"if set, the statement and transaction statistics of each node are periodically flushed to "+
+		"system.statement_statistics and system.transaction_statistics",
+	true,
+)
+
+var sqlStatsFlushInterval = func() *settings.DurationSetting {
+	s := settings.RegisterValidatedDurationSetting(
+		"sql.stats.flush.interval",
+		"the interval at which the statement and transaction statistics of each node are flushed to "+
+			"system.statement_statistics and system.transaction_statistics",
+		10*time.Minute,
+		validatePositiveDuration,
+	)
+	s.SetVisibility(settings.Public)
+	return s
+}()
+
+var sqlStatsAggregationInterval = func() *settings.DurationSetting {
+	s := settings.RegisterValidatedDurationSetting(
+		"sql.stats.aggregation.interval",
+		"the width of the time buckets in which the flushed statement and transaction statistics are aggregated",
+		time.Hour,
+		validatePositiveDuration,
+	)
+	s.SetVisibility(settings.Public)
+	return s
+}()
+
+var sqlStatsRetention = settings.RegisterPublicNonNegativeDurationSetting(
+	"sql.stats.persisted_rows.retention",
+	"the amount of time the flushed statement and transaction statistics are kept (0 keeps them forever)",
+	7*24*time.Hour,
+)
+
+func validatePositiveDuration(v time.Duration) error {
+	if v <= 0 {
+		return errors.Errorf("cannot be set to a non-positive duration: %s", v)
+	}
+	return nil
+}
+
+// sqlStatsDeleteBatchSize is the maximum number of expired rows deleted by a
+// single statement.
+const sqlStatsDeleteBatchSize = 1000
+
+// sqlStatsFlushBatchSize is the maximum number of rows written by a single
+// statement when flushing the statistics.
+const sqlStatsFlushBatchSize = 100
+
+// unflushedStmtStats are the statistics of a statement to be flushed.
+type unflushedStmtStats struct {
+	appName string
+	key     stmtKey
+	data    roachpb.StatementStatistics
+}
+
+// PeriodicallyFlushSQLStats spawns a loop to flush the statement and
+// transaction statistics to system tables at the interval set by
+// sql.stats.flush.interval.
+func (s *Server) PeriodicallyFlushSQLStats(ctx context.Context, stopper *stop.Stopper) {
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(sqlStatsFlushInterval.Get(&s.cfg.Settings.SV))
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-timer.C:
+				timer.Read = true
+			}
+			if !sqlStatsFlushEnabled.Get(&s.cfg.Settings.SV) {
+				continue
+			}
+			s.FlushSQLStats(ctx)
+		}
+	})
+}
+
+// FlushSQLStats writes the statement and transaction statistics collected
+// since the last flush to system.statement_statistics and
+// system.transaction_statistics, in the time bucket of the current time. The
+// in-memory statistics since the last reset are left alone. The statistics
+// which could not be written are kept for the next flush. It also deletes the
+// persisted statistics which are older than sql.stats.persisted_rows.retention.
+func (s *Server) FlushSQLStats(ctx context.Context) {
+	if !cluster.Version.IsActive(ctx, s.cfg.Settings, cluster.VersionPersistedSQLStats) {
+		return
+	}
+	sv := &s.cfg.Settings.SV
+	now := timeutil.Now()
+	aggregatedTs := now.Truncate(sqlStatsAggregationInterval.Get(sv))
+	persistTxnStats := cluster.Version.IsActive(ctx, s.cfg.Settings, cluster.VersionPersistedTxnStats)
+
+	if err := s.flushStmtStats(ctx, aggregatedTs); err != nil {
+		log.Warningf(ctx, "failed to flush statement statistics: %v", err)
+	}
+	if persistTxnStats {
+		if err := s.flushTxnStats(ctx, aggregatedTs); err != nil {
+			log.Warningf(ctx, "failed to flush transaction statistics: %v", err)
+		}
+	}
+
+	if retention := sqlStatsRetention.Get(sv); retention > 0 {
+		before := now.Add(-retention)
+		if err := s.deleteExpiredStats(ctx, "statement_statistics", before); err != nil {
+			log.Warningf(ctx, "failed to delete expired statement statistics: %v", err)
+		}
+		if persistTxnStats {
+			if err := s.deleteExpiredStats(ctx, "transaction_statistics", before); err != nil {
+				log.Warningf(ctx, "failed to delete expired transaction statistics: %v", err)
+			}
+		}
+	}
+}
+
+// flushStmtStats writes the statement statistics collected since the last
+// flush, in batches. It returns the last error encountered.
+func (s *Server) flushStmtStats(ctx context.Context, aggregatedTs time.Time) error {
+	var toFlush []unflushedStmtStats
+	for appName, stmts := range s.sqlStats.popUnflushedStmtStats() {
+		for key, data := range stmts {
+			toFlush = append(toFlush, unflushedStmtStats{appName: appName, key: key, data: data})
+		}
+	}
+	var flushErr error
+	for len(toFlush) > 0 {
+		batch := toFlush
+		if len(batch) > sqlStatsFlushBatchSize {
+			batch = batch[:sqlStatsFlushBatchSize]
+		}
+		toFlush = toFlush[len(batch):]
+		if err := s.persistStmtStats(ctx, aggregatedTs, batch); err != nil {
+			flushErr = err
+			// Put the statistics back, to be flushed next time.
+			for i := range batch {
+				stmt := &batch[i]
+				a := s.sqlStats.getStatsForApplication(stmt.appName)
+				restored := a.getStatsForStmtWithKey(stmt.key, true /* createIfNonexistent */)
+				restored.Lock()
+				stmt.data.Add(&restored.unflushed)
+				restored.unflushed = stmt.data
+				restored.Unlock()
+			}
+		}
+	}
+	return flushErr
+}
+
+// flushTxnStats writes the transaction statistics collected since the last
+// flush, in batches. It returns the last error encountered.
+func (s *Server) flushTxnStats(ctx context.Context, aggregatedTs time.Time) error {
+	toFlush := s.sqlStats.popUnflushedTxnStats()
+	var flushErr error
+	for len(toFlush) > 0 {
+		batch := toFlush
+		if len(batch) > sqlStatsFlushBatchSize {
+			batch = batch[:sqlStatsFlushBatchSize]
+		}
+		toFlush = toFlush[len(batch):]
+		if err := s.persistTxnStats(ctx, aggregatedTs, batch); err != nil {
+			flushErr = err
+			// Put the statistics back, to be flushed next time.
+			for i := range batch {
+				txn := &batch[i]
+				a := s.sqlStats.getStatsForApplication(txn.App)
+				restored := a.getStatsForTxnWithKey(
+					txnKey(txn.FingerprintID), txn.StatementFingerprints, true, /* createIfNonexistent */
+				)
+				restored.Lock()
+				txn.Stats.Add(&restored.unflushed)
+				restored.unflushed = txn.Stats
+				restored.Unlock()
+			}
+		}
+	}
+	return flushErr
+}
+
+// persistStmtStats adds the given statistics to the rows of the statements in
+// the given time bucket for this node.
+func (s *Server) persistStmtStats(
+	ctx context.Context, aggregatedTs time.Time, stmts []unflushedStmtStats,
+) error {
+	ie := s.cfg.InternalExecutor
+	ts := tree.MakeDTimestampTZ(aggregatedTs, time.Microsecond)
+	nodeID := int64(s.cfg.NodeID.Get())
+
+	var keyTuples bytes.Buffer
+	qargs := []interface{}{ts, nodeID}
+	for i := range stmts {
+		stmt := &stmts[i]
+		if i > 0 {
+			keyTuples.WriteString(", ")
+		}
+		n := len(qargs)
+		fmt.Fprintf(&keyTuples, "($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5)
+		qargs = append(qargs,
+			stmt.appName, stmt.key.stmt, stmt.key.failed, stmt.key.distSQLUsed, stmt.key.implicitTxn,
+		)
+	}
+
+	return s.cfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		// Only this node writes the rows with its node ID, so there is no
+		// contention between the read and the write.
+		rows, err := ie.Query(ctx, "stmt-stats-get", txn, fmt.Sprintf(
+			`SELECT app_name, fingerprint, failed, distsql, implicit_txn, statistics
+			FROM system.statement_statistics
+			WHERE aggregated_ts = $1 AND node_id = $2
+			AND (app_name, fingerprint, failed, distsql, implicit_txn) IN (%s)`, keyTuples.String()),
+			qargs...,
+		)
+		if err != nil {
+			return err
+		}
+		type rowKey struct {
+			appName string
+			key     stmtKey
+		}
+		existing := make(map[rowKey]roachpb.StatementStatistics, len(rows))
+		for _, row := range rows {
+			var stats roachpb.StatementStatistics
+			if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[5])), &stats); err != nil {
+				return err
+			}
+			existing[rowKey{
+				appName: string(tree.MustBeDString(row[0])),
+				key: stmtKey{
+					stmt:        string(tree.MustBeDString(row[1])),
+					failed:      bool(tree.MustBeDBool(row[2])),
+					distSQLUsed: bool(tree.MustBeDBool(row[3])),
+					implicitTxn: bool(tree.MustBeDBool(row[4])),
+				},
+			}] = stats
+		}
+
+		var values bytes.Buffer
+		upsertArgs := make([]interface{}, 0, len(stmts)*9)
+		for i := range stmts {
+			stmt := &stmts[i]
+			merged := stmt.data
+			if stats, ok := existing[rowKey{appName: stmt.appName, key: stmt.key}]; ok {
+				stats.Add(&stmt.data)
+				merged = stats
+			}
+			encoded, err := protoutil.Marshal(&merged)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				values.WriteString(", ")
+			}
+			n := len(upsertArgs)
+			fmt.Fprintf(&values, "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+				n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9)
+			upsertArgs = append(upsertArgs,
+				ts, stmt.appName, stmt.key.stmt, stmt.key.failed, stmt.key.distSQLUsed,
+				stmt.key.implicitTxn, nodeID, merged.Count, encoded,
+			)
+		}
+		_, err = ie.Exec(ctx, "stmt-stats-upsert", txn, fmt.Sprintf(
+			`UPSERT INTO system.statement_statistics
+			(aggregated_ts, app_name, fingerprint, failed, distsql, implicit_txn, node_id, count, statistics)
+			VALUES %s`, values.String()),
+			upsertArgs...,
+		)
+		return err
+	})
+}
+
+// persistTxnStats adds the given statistics to the rows of the transaction
+// fingerprints in the given time bucket for this node.
+func (s *Server) persistTxnStats(
+	ctx context.Context, aggregatedTs time.Time, txns []roachpb.CollectedTransactionStatistics,
+) error {
+	ie := s.cfg.InternalExecutor
+	ts := tree.MakeDTimestampTZ(aggregatedTs, time.Microsecond)
+	nodeID := int64(s.cfg.NodeID.Get())
+
+	var keyTuples bytes.Buffer
+	qargs := []interface{}{ts, nodeID}
+	for i := range txns {
+		txn := &txns[i]
+		if i > 0 {
+			keyTuples.WriteString(", ")
+		}
+		n := len(qargs)
+		fmt.Fprintf(&keyTuples, "($%d, $%d)", n+1, n+2)
+		// The fingerprint is stored in a signed column.
+		qargs = append(qargs, txn.App, int64(txn.FingerprintID))
+	}
+
+	return s.cfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		// Only this node writes the rows with its node ID, so there is no
+		// contention between the read and the write.
+		rows, err := ie.Query(ctx, "txn-stats-get", txn, fmt.Sprintf(
+			`SELECT statistics FROM system.transaction_statistics
+			WHERE aggregated_ts = $1 AND node_id = $2
+			AND (app_name, fingerprint_id) IN (%s)`, keyTuples.String()),
+			qargs...,
+		)
+		if err != nil {
+			return err
+		}
+		type rowKey struct {
+			appName string
+			key     txnKey
+		}
+		existing := make(map[rowKey]roachpb.TransactionStatistics, len(rows))
+		for _, row := range rows {
+			var stats roachpb.CollectedTransactionStatistics
+			if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[0])), &stats); err != nil {
+				return err
+			}
+			existing[rowKey{appName: stats.App, key: txnKey(stats.FingerprintID)}] = stats.Stats
+		}
+
+		var values bytes.Buffer
+		upsertArgs := make([]interface{}, 0, len(txns)*6)
+		for i := range txns {
+			merged := txns[i]
+			if stats, ok := existing[rowKey{appName: merged.App, key: txnKey(merged.FingerprintID)}]; ok {
+				stats.Add(&merged.Stats)
+				merged.Stats = stats
+			}
+			encoded, err := protoutil.Marshal(&merged)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				values.WriteString(", ")
+			}
+			n := len(upsertArgs)
+			fmt.Fprintf(&values, "($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6)
+			upsertArgs = append(upsertArgs,
+				ts, merged.App, int64(merged.FingerprintID), nodeID, merged.Stats.Count, encoded,
+			)
+		}
+		_, err = ie.Exec(ctx, "txn-stats-upsert", txn, fmt.Sprintf(
+			`UPSERT INTO system.transaction_statistics
+			(aggregated_ts, app_name, fingerprint_id, node_id, count, statistics)
+			VALUES %s`, values.String()),
+			upsertArgs...,
+		)
+		return err
+	})
+}
+
+// deleteExpiredStats deletes the persisted statistics of the time buckets
+// which started before the given time from the given system table.
+func (s *Server) deleteExpiredStats(ctx context.Context, table string, before time.Time) error {
+	for {
+		n, err := s.cfg.InternalExecutor.Exec(ctx, "sql-stats-delete-expired", nil, /* txn */
+			fmt.Sprintf(`DELETE FROM system.%s WHERE aggregated_ts < $1 LIMIT $2`, table),
+			tree.MakeDTimestampTZ(before, time.Microsecond), sqlStatsDeleteBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+		if n < sqlStatsDeleteBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// This file contains the audit policy, which selects the SQL activity
+// to report to the sql-audit-policy log channel.
+//
+// Unlike the table-level auditing configured with ALTER TABLE
+// EXPERIMENTAL_AUDIT (see exec_log.go), the policy is declared with
+// cluster settings. Only admin users can change cluster settings, so
+// the policy cannot be disabled by the users being audited.
+//
+// An activity is reported when the policy is enabled and the activity
+// matches all of:
+// - the statement types: ddl, dcl (GRANT, REVOKE and user and role
+//   management), dml (reads and writes of table data) or login (login
+//   attempts);
+// - the roles: the user is one of the roles or a member, directly or
+//   indirectly, of one of the roles. Failed login attempts for users
+//   that don't exist always match;
+// - the databases: the statement uses an object of one of the
+//   databases. Login attempts always match.
+// An empty list of roles or databases matches any activity.
+//
+// Each activity is logged as a JSON object, for example:
+//
+//   {"event":"statement","user":"maxroach","client_address":"127.0.0.1:62503",
+//    "application_name":"cockroach","status":"OK","statement_type":"ddl",
+//    "statement_tag":"CREATE TABLE","statement":"CREATE TABLE t (x INT8)",
+//    "databases":["defaultdb"],"rows_affected":0,"retries":0,"latency_ms":12.34}
+//   {"event":"login","user":"maxroach","client_address":"127.0.0.1:62504",
+//    "application_name":"","status":"ERROR","error":"password authentication
+//    failed for user maxroach","auth_method":"password"}
+//
+// Internal statements, such as those issued by jobs, are not audited.
+
+var auditPolicyEnabled = settings.RegisterPublicBoolSetting(
+	"sql.log.audit_policy.enabled",
+	"set to true to log the SQL activity selected by the audit policy "+
+		"to the sql-audit-policy log channel on each node",
+	false,
+)
+
+var auditPolicyRoles = settings.RegisterPublicStringSetting(
+	"sql.log.audit_policy.roles",
+	"comma-separated list of roles whose members' activity is audited; "+
+		"empty to audit all users",
+	"",
+)
+
+var auditPolicyDatabases = settings.RegisterPublicStringSetting(
+	"sql.log.audit_policy.databases",
+	"comma-separated list of databases whose objects' use is audited; "+
+		"empty to audit statements regardless of the databases they use",
+	"",
+)
+
+var auditPolicyStatementTypes = func() *settings.StringSetting {
+	s := settings.RegisterValidatedStringSetting(
+		"sql.log.audit_policy.statement_types",
+		"comma-separated list of the types of activity audited (ddl, dcl, dml, login)",
+		auditTypeDDL+","+auditTypeDCL+","+auditTypeLogin,
+		func(_ *settings.Values, s string) error {
+			for t := range parseAuditPolicyList(strings.ToLower(s)) {
+				switch t {
+				case auditTypeDDL, auditTypeDCL, auditTypeDML, auditTypeLogin:
+				default:
+					return errors.Errorf("invalid statement type: %q", t)
+				}
+			}
+			return nil
+		},
+	)
+	s.SetVisibility(settings.Public)
+	return s
+}()
+
+// The types of activity selected by sql.log.audit_policy.statement_types.
+const (
+	auditTypeDDL   = "ddl"
+	auditTypeDCL   = "dcl"
+	auditTypeDML   = "dml"
+	auditTypeLogin = "login"
+)
+
+// parseAuditPolicyList parses a comma-separated list of the audit
+// policy cluster settings.
+func parseAuditPolicyList(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, elem := range strings.Split(s, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			set[elem] = struct{}{}
+		}
+	}
+	return set
+}
+
+// auditPolicy is the audit policy declared by the cluster settings.
+type auditPolicy struct {
+	types     map[string]struct{}
+	roles     map[string]struct{}
+	databases map[string]struct{}
+}
+
+// loadAuditPolicy returns the current audit policy, or false if the
+// policy is disabled.
+func loadAuditPolicy(sv *settings.Values) (auditPolicy, bool) {
+	if !auditPolicyEnabled.Get(sv) {
+		return auditPolicy{}, false
+	}
+	return auditPolicy{
+		types:     parseAuditPolicyList(strings.ToLower(auditPolicyStatementTypes.Get(sv))),
+		roles:     parseAuditPolicyList(strings.ToLower(auditPolicyRoles.Get(sv))),
+		databases: parseAuditPolicyList(auditPolicyDatabases.Get(sv)),
+	}, true
+}
+
+// coversType returns whether the policy audits the given type of
+// activity.
+func (ap *auditPolicy) coversType(typ string) bool {
+	_, ok := ap.types[typ]
+	return ok
+}
+
+// coversUser returns whether the policy audits the activity of the
+// given user, which is a member of the given roles.
+func (ap *auditPolicy) coversUser(user string, memberOf map[string]bool) bool {
+	if len(ap.roles) == 0 {
+		return true
+	}
+	if _, ok := ap.roles[user]; ok {
+		return true
+	}
+	for role := range memberOf {
+		if _, ok := ap.roles[role]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// coversDatabases returns whether the policy audits a statement using
+// objects of the given databases.
+func (ap *auditPolicy) coversDatabases(databases []string) bool {
+	if len(ap.databases) == 0 {
+		return true
+	}
+	for _, db := range databases {
+		if _, ok := ap.databases[db]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// auditStatementType returns the type of activity of the given
+// statement, or the empty string if the statement is never audited
+// (e.g. SET or SHOW).
+func auditStatementType(stmt tree.Statement) string {
+	switch stmt.(type) {
+	case *tree.Grant, *tree.Revoke, *tree.GrantRole, *tree.RevokeRole,
+		*tree.CreateUser, *tree.CreateRole, *tree.DropUser, *tree.DropRole,
+		*tree.AlterUserSetPassword, *tree.AlterRolePrivileges:
+		return auditTypeDCL
+	case *tree.Select, *tree.ParenSelect:
+		return auditTypeDML
+	}
+	if tree.CanModifySchema(stmt) {
+		return auditTypeDDL
+	}
+	if tree.CanWriteData(stmt) {
+		return auditTypeDML
+	}
+	return ""
+}
+
+// auditPolicyEventCommon contains the fields common to all the events
+// of the audit policy.
+type auditPolicyEventCommon struct {
+	Event           string `json:"event"`
+	User            string `json:"user"`
+	ClientAddress   string `json:"client_address,omitempty"`
+	ApplicationName string `json:"application_name"`
+	// Status is OK or ERROR.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (ev *auditPolicyEventCommon) setErr(err error) {
+	ev.Status = "OK"
+	if err != nil {
+		ev.Status = "ERROR"
+		ev.Error = err.Error()
+	}
+}
+
+// auditStatementEvent is the event logged for an audited statement.
+type auditStatementEvent struct {
+	auditPolicyEventCommon
+	StatementType string   `json:"statement_type"`
+	StatementTag  string   `json:"statement_tag"`
+	Statement     string   `json:"statement"`
+	Databases     []string `json:"databases,omitempty"`
+	RowsAffected  int      `json:"rows_affected"`
+	Retries       int      `json:"retries"`
+	LatencyMs     float64  `json:"latency_ms"`
+}
+
+// auditLoginEvent is the event logged for an audited login attempt.
+type auditLoginEvent struct {
+	auditPolicyEventCommon
+	AuthMethod string `json:"auth_method,omitempty"`
+}
+
+// logAuditPolicyEvent writes the given event to the audit policy log.
+func logAuditPolicyEvent(ctx context.Context, logger *log.SecondaryLogger, ev interface{}) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		// The events only contain strings and numbers: this can't happen.
+		log.Errorf(ctx, "unable to encode audit event %+v: %v", ev, err)
+		return
+	}
+	logger.Logf(ctx, "%s", b)
+}
+
+// maybeRecordAuditPolicyDatabase records the database of the given
+// descriptor as used by the current statement, for the audit policy to
+// match it against the audited databases. Like maybeAudit(), it is
+// called from CheckPrivilege().
+func (p *planner) maybeRecordAuditPolicyDatabase(desc sqlbase.DescriptorProto) {
+	if !auditPolicyEnabled.Get(&p.execCfg.Settings.SV) {
+		return
+	}
+	var dbID sqlbase.ID
+	switch t := desc.(type) {
+	case *sqlbase.DatabaseDescriptor:
+		dbID = t.ID
+	case *sqlbase.TableDescriptor:
+		dbID = t.ParentID
+	case *sqlbase.MutableTableDescriptor:
+		dbID = t.ParentID
+	case *sqlbase.ImmutableTableDescriptor:
+		dbID = t.ParentID
+	default:
+		return
+	}
+	for _, id := range p.curPlan.auditPolicyDatabaseIDs {
+		if id == dbID {
+			return
+		}
+	}
+	p.curPlan.auditPolicyDatabaseIDs = append(p.curPlan.auditPolicyDatabaseIDs, dbID)
+}
+
+// maybePrepareAuditPolicyEvent checks whether the audit policy covers the
+// current statement and, if so, prepares the event that maybeLogStatement()
+// logs once the statement has executed. It must be called after planning,
+// while the statement's transaction is open, since it looks up the user's
+// role memberships and the names of the databases used by the statement.
+//
+// Errors during these lookups are not returned: the statement is then
+// audited as if it matched the policy, since auditing can't miss any
+// statement.
+func (p *planner) maybePrepareAuditPolicyEvent(ctx context.Context, execType executorType) {
+	if execType == executorTypeInternal {
+		return
+	}
+	policy, ok := loadAuditPolicy(&p.execCfg.Settings.SV)
+	if !ok {
+		return
+	}
+	stmt := p.stmt.AST
+	stmtType := auditStatementType(stmt)
+	if stmtType == "" || !policy.coversType(stmtType) {
+		return
+	}
+
+	databases := make([]string, 0, len(p.curPlan.auditPolicyDatabaseIDs))
+	lookupFailed := false
+	for _, id := range p.curPlan.auditPolicyDatabaseIDs {
+		desc, err := p.Tables().databaseCache.getDatabaseDescByID(ctx, p.txn, id)
+		if err != nil {
+			log.Warningf(ctx, "unable to look up database %d for auditing: %v", id, err)
+			lookupFailed = true
+			continue
+		}
+		databases = append(databases, desc.Name)
+	}
+	if !lookupFailed && !policy.coversDatabases(databases) {
+		return
+	}
+
+	user := p.SessionData().User
+	if len(policy.roles) != 0 {
+		memberOf, err := p.MemberOfWithAdminOption(ctx, user)
+		if err != nil {
+			log.Warningf(ctx, "unable to look up the roles of %s for auditing: %v", user, err)
+		} else if !policy.coversUser(user, memberOf) {
+			return
+		}
+	}
+
+	ev := &auditStatementEvent{
+		auditPolicyEventCommon: auditPolicyEventCommon{
+			Event:           "statement",
+			User:            user,
+			ApplicationName: p.SessionData().ApplicationName,
+		},
+		StatementType: stmtType,
+		StatementTag:  stmt.StatementTag(),
+		Statement:     stmt.String(),
+		Databases:     databases,
+	}
+	if addr := p.SessionData().RemoteAddr; addr != nil {
+		ev.ClientAddress = addr.String()
+	}
+	p.curPlan.auditPolicyEvent = ev
+}
+
+// MaybeLogLoginAttempt logs a login attempt with the given session
+// arguments to the audit policy log, if the policy covers it. userExists
+// indicates whether the user was found, and authErr is the error that
+// caused the attempt to fail, if any.
+func MaybeLogLoginAttempt(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	args *SessionArgs,
+	userExists bool,
+	authMethod string,
+	authErr error,
+) {
+	policy, ok := loadAuditPolicy(&execCfg.Settings.SV)
+	if !ok || !policy.coversType(auditTypeLogin) {
+		return
+	}
+	if len(policy.roles) != 0 && userExists {
+		memberOf, err := resolveMemberOfWithAdminOption(ctx, execCfg.InternalExecutor, args.User)
+		if err != nil {
+			log.Warningf(ctx, "unable to look up the roles of %s for auditing: %v", args.User, err)
+		} else if !policy.coversUser(args.User, memberOf) {
+			return
+		}
+	}
+
+	ev := &auditLoginEvent{
+		auditPolicyEventCommon: auditPolicyEventCommon{
+			Event:           "login",
+			User:            args.User,
+			ApplicationName: args.SessionDefaults["application_name"],
+		},
+		AuthMethod: authMethod,
+	}
+	if args.RemoteAddr != nil {
+		ev.ClientAddress = args.RemoteAddr.String()
+	}
+	ev.setErr(authErr)
+	logAuditPolicyEvent(ctx, execCfg.AuditPolicyLogger, ev)
+}
+
+// logAuditPolicyStatement completes and logs the audit event prepared for
+// the current statement by maybePrepareAuditPolicyEvent().
+func (p *planner) logAuditPolicyStatement(
+	ctx context.Context, numRetries, rows int, err error, startTime time.Time,
+) {
+	ev := p.curPlan.auditPolicyEvent
+	ev.RowsAffected = rows
+	ev.Retries = numRetries
+	ev.LatencyMs = float64(timeutil.Since(startTime).Nanoseconds()) / 1e6
+	ev.setErr(err)
+	logAuditPolicyEvent(ctx, p.execCfg.AuditPolicyLogger, ev)
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestAuditStatementType(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testData := []struct {
+		sql      string
+		expected string
+	}{
+		{`CREATE TABLE t (x INT)`, auditTypeDDL},
+		{`ALTER TABLE t ADD COLUMN y INT`, auditTypeDDL},
+		{`CREATE TABLE t AS SELECT 1`, auditTypeDDL},
+		{`DROP DATABASE d`, auditTypeDDL},
+		{`GRANT SELECT ON t TO u`, auditTypeDCL},
+		{`REVOKE ALL ON DATABASE d FROM u`, auditTypeDCL},
+		{`GRANT r TO u`, auditTypeDCL},
+		{`CREATE USER u`, auditTypeDCL},
+		{`DROP ROLE r`, auditTypeDCL},
+		{`ALTER USER u WITH PASSWORD 'abc'`, auditTypeDCL},
+		{`SELECT * FROM t`, auditTypeDML},
+		{`INSERT INTO t VALUES (1)`, auditTypeDML},
+		{`UPDATE t SET x = 1`, auditTypeDML},
+		{`DELETE FROM t`, auditTypeDML},
+		{`SET application_name = 'a'`, ""},
+		{`SHOW TABLES`, ""},
+	}
+	for _, tc := range testData {
+		t.Run(tc.sql, func(t *testing.T) {
+			stmt, err := parser.ParseOne(tc.sql)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if typ := auditStatementType(stmt.AST); typ != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, typ)
+			}
+		})
+	}
+}
+
+func TestAuditPolicy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	st := cluster.MakeTestingClusterSettings()
+	sv := &st.SV
+
+	if _, ok := loadAuditPolicy(sv); ok {
+		t.Fatal("expected the audit policy to be disabled by default")
+	}
+
+	auditPolicyEnabled.Override(sv, true)
+	policy, ok := loadAuditPolicy(sv)
+	if !ok {
+		t.Fatal("expected the audit policy to be enabled")
+	}
+	for _, typ := range []string{auditTypeDDL, auditTypeDCL, auditTypeLogin} {
+		if !policy.coversType(typ) {
+			t.Errorf("expected %s to be audited by default", typ)
+		}
+	}
+	if policy.coversType(auditTypeDML) {
+		t.Error("expected dml not to be audited by default")
+	}
+	if !policy.coversUser("u", nil) || !policy.coversDatabases(nil) {
+		t.Error("expected all users and databases to be audited by default")
+	}
+
+	u := settings.NewUpdater(sv)
+	for key, value := range map[string]string{
+		"sql.log.audit_policy.statement_types": " DML , login",
+		"sql.log.audit_policy.roles":           "Auditors,u1",
+		"sql.log.audit_policy.databases":       "bank,hr",
+	} {
+		if err := u.Set(key, value, "s"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	policy, _ = loadAuditPolicy(sv)
+	if !policy.coversType(auditTypeDML) || !policy.coversType(auditTypeLogin) ||
+		policy.coversType(auditTypeDDL) {
+		t.Errorf("unexpected statement types %v", policy.types)
+	}
+
+	userTests := []struct {
+		user     string
+		memberOf map[string]bool
+		expected bool
+	}{
+		{"u1", nil, true},
+		{"u2", nil, false},
+		{"u2", map[string]bool{"auditors": false}, true},
+		{"u2", map[string]bool{"admin": true}, false},
+	}
+	for _, tc := range userTests {
+		if covered := policy.coversUser(tc.user, tc.memberOf); covered != tc.expected {
+			t.Errorf("%s member of %v: expected %t, got %t", tc.user, tc.memberOf, tc.expected, covered)
+		}
+	}
+
+	dbTests := []struct {
+		databases []string
+		expected  bool
+	}{
+		{nil, false},
+		{[]string{"defaultdb"}, false},
+		{[]string{"defaultdb", "hr"}, true},
+		{[]string{"bank"}, true},
+	}
+	for _, tc := range dbTests {
+		if covered := policy.coversDatabases(tc.databases); covered != tc.expected {
+			t.Errorf("%v: expected %t, got %t", tc.databases, tc.expected, covered)
+		}
+	}
+
+	if err := u.Set("sql.log.audit_policy.statement_types", "ddl,select", "s"); err == nil {
+		t.Error("expected an error for an invalid statement type")
+	}
+}
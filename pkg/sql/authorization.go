@@ -76,6 +76,7 @@ func (p *planner) CheckPrivilege(
 	// descriptors (since every use of descriptors presumably need a
 	// permission check).
 	p.maybeAudit(descriptor, privilege)
+	p.maybeRecordAuditPolicyDatabase(descriptor)
 
 	user := p.SessionData().User
 	privs := descriptor.GetPrivileges()
@@ -220,7 +221,7 @@ func (p *planner) MemberOfWithAdminOption(
 		}
 
 		// Lookup memberships outside the lock.
-		memberships, err := p.resolveMemberOfWithAdminOption(ctx, member)
+		memberships, err := resolveMemberOfWithAdminOption(ctx, p.ExecCfg().InternalExecutor, member)
 		if err != nil {
 			return nil, err
 		}
@@ -245,8 +246,8 @@ func (p *planner) MemberOfWithAdminOption(
 // TODO(mberhault): this is the naive way and performs a full lookup for each user,
 // we could save detailed memberships (as opposed to fully expanded) and reuse them
 // across users. We may then want to lookup more than just this user.
-func (p *planner) resolveMemberOfWithAdminOption(
-	ctx context.Context, member string,
+func resolveMemberOfWithAdminOption(
+	ctx context.Context, ie *InternalExecutor, member string,
 ) (map[string]bool, error) {
 	ret := map[string]bool{}
 
@@ -264,7 +265,7 @@ func (p *planner) resolveMemberOfWithAdminOption(
 		}
 		visited[m] = struct{}{}
 
-		rows, err := p.ExecCfg().InternalExecutor.Query(
+		rows, err := ie.Query(
 			ctx, "expand-roles", nil /* txn */, lookupRolesStmt, m,
 		)
 		if err != nil {
@@ -699,7 +699,11 @@ func (sc *SchemaChanger) truncateIndexes(
 					return err
 				}
 				done = true
-				return td.clearIndex(ctx, &desc)
+				// Indexes are cleared only once their GC TTL has expired. Use
+				// MVCC range tombstones once all nodes understand them.
+				useRangeTombstones := cluster.Version.IsActive(
+					ctx, sc.settings, cluster.VersionMVCCRangeTombstones)
+				return td.clearIndex(ctx, &desc, useRangeTombstones)
 			}); err != nil {
 				return err
 			}
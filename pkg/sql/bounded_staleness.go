@@ -0,0 +1,105 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// negotiateBoundedStaleness picks the timestamp of a bounded staleness read
+// once its plan is known. The read is performed at the newest timestamp at
+// which the replicas on this node can serve all of the spans the plan reads,
+// as long as that timestamp respects the staleness bound. Otherwise, the read
+// falls back to reading the current data from the leaseholders.
+//
+// The transaction starts out at the oldest timestamp allowed by the bound. If
+// the negotiated timestamp differs, the statement is planned again so that
+// the descriptors it uses are read at that timestamp as well.
+func (ex *connExecutor) negotiateBoundedStaleness(ctx context.Context, p *planner) error {
+	minTS := *p.semaCtx.AsOfMinTimestamp
+	spans, err := collectReadSpans(ctx, &p.curPlan)
+	if err != nil {
+		return err
+	}
+
+	var ts hlc.Timestamp
+	var local bool
+	if fn := ex.server.cfg.LocalReadTimestamp; fn != nil {
+		ts, local = fn(ctx, spans)
+	}
+	if local && minTS.LessEq(ts) {
+		log.VEventf(ctx, 2, "bounded staleness read negotiated timestamp %s", ts)
+	} else {
+		local = false
+		ts = ex.server.cfg.Clock.Now()
+		log.VEventf(ctx, 2, "bounded staleness read cannot be served locally at or above %s; "+
+			"falling back to reading at %s", minTS, ts)
+	}
+
+	if ts != *p.semaCtx.AsOfTimestamp {
+		p.semaCtx.AsOfTimestamp = &ts
+		p.extendedEvalCtx.SetTxnTimestamp(ts.GoTime())
+		ex.state.setHistoricalTimestamp(ctx, ts)
+
+		p.curPlan.close(ctx)
+		p.curPlan.init(p.stmt, ex.appStats)
+		if err := p.makeOptimizerPlan(ctx); err != nil {
+			log.VEventf(ctx, 1, "optimizer plan failed: %v", err)
+			return err
+		}
+	}
+	if local {
+		p.curPlan.flags.Set(planFlagNearestReplica)
+	}
+	return nil
+}
+
+// collectReadSpans returns the spans of the table indexes read by a plan and
+// its subqueries. Scans whose spans are only known at execution time, such as
+// those performed by index and lookup joins, contribute the span of the entire
+// index.
+func collectReadSpans(ctx context.Context, plan *planTop) (roachpb.Spans, error) {
+	var spans roachpb.Spans
+	observer := planObserver{
+		enterNode: func(_ context.Context, _ string, n planNode) (bool, error) {
+			var scan *scanNode
+			switch n := n.(type) {
+			case *scanNode:
+				scan = n
+			case *indexJoinNode:
+				scan = n.table
+			case *lookupJoinNode:
+				scan = n.table
+			default:
+				return true, nil
+			}
+			if len(scan.spans) > 0 {
+				spans = append(spans, scan.spans...)
+			} else {
+				spans = append(spans, scan.desc.IndexSpan(scan.index.ID))
+			}
+			return true, nil
+		},
+	}
+	if err := walkPlan(ctx, plan.plan, observer); err != nil {
+		return nil, err
+	}
+	for i := range plan.subqueryPlans {
+		if err := walkPlan(ctx, plan.subqueryPlans[i].plan, observer); err != nil {
+			return nil, err
+		}
+	}
+	return spans, nil
+}
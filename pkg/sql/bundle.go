@@ -0,0 +1,326 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	jsonb "github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// diagnosticsBundle is a statement diagnostics bundle: a zip file with the
+// information needed to investigate an execution of a statement, and the
+// trace of the execution as a JSON datum.
+//
+// The zip file contains:
+//  - statement.txt: the statement and the values of its placeholders;
+//  - opt.txt and opt-v.txt: the optimizer plan, as shown by EXPLAIN (OPT)
+//    and EXPLAIN (OPT, VERBOSE);
+//  - trace.txt and trace.json: the trace of the execution;
+//  - schema.sql: the definitions of the tables, views and sequences used by
+//    the statement;
+//  - stats-<table>.sql: the statistics of each table, as an ALTER TABLE ...
+//    INJECT STATISTICS statement;
+//  - env.sql: the version of the node, the cluster settings with a
+//    non-default value and the session variables.
+type diagnosticsBundle struct {
+	zip   []byte
+	trace tree.Datum
+}
+
+// makeStatementBundleBuilder starts building the diagnostics bundle of the
+// execution of the planner's current statement, given the recording of its
+// trace. The plan must have been built with planner.collectBundle set.
+//
+// Only the parts of the bundle that depend on the planner are collected here,
+// so that the planner can be reused once it returns; the bundle is completed
+// by bundleBuilder.build, which runs queries and can be called
+// asynchronously.
+func makeStatementBundleBuilder(
+	ctx context.Context, p *planner, ie *InternalExecutor, trace tracing.Recording,
+) *bundleBuilder {
+	b := &bundleBuilder{ie: ie}
+	b.addStatement(p)
+	b.addOptPlans(p)
+	b.trace = b.addTrace(trace)
+	b.addEnv(p)
+	b.resolveDataSources(ctx, p)
+	return b
+}
+
+type bundleBuilder struct {
+	ie *InternalExecutor
+
+	// trace is the trace of the execution, as a JSON datum.
+	trace tree.Datum
+	// optPlanned is set if the statement was planned by the optimizer, in
+	// which case its data sources are known.
+	optPlanned bool
+	// dataSources are the sequences, tables and views used by the statement,
+	// in the order in which their definitions are written in schema.sql.
+	dataSources []bundleDataSource
+	// schemaErrors are the errors encountered while resolving the names of the
+	// data sources, written at the top of schema.sql.
+	schemaErrors bytes.Buffer
+
+	buf bytes.Buffer
+	z   *zip.Writer
+	err error
+}
+
+// bundleDataSource is a data source used by the statement.
+type bundleDataSource struct {
+	// kind is the kind of the data source, as in SHOW CREATE <kind>.
+	kind string
+	name tree.TableName
+}
+
+// build completes the bundle by querying the definitions of the data sources
+// used by the statement and the statistics of its tables.
+//
+// The bundle is built on a best effort basis: the errors encountered while
+// querying the schemas, statistics and environment are written in the
+// corresponding files. An error is returned only if the bundle could not be
+// built at all.
+func (b *bundleBuilder) build(ctx context.Context) (diagnosticsBundle, error) {
+	b.addSchemaAndStats(ctx)
+	zip, err := b.finish()
+	if err != nil {
+		return diagnosticsBundle{trace: b.trace}, err
+	}
+	return diagnosticsBundle{zip: zip, trace: b.trace}, nil
+}
+
+func (b *bundleBuilder) addFile(name, contents string) {
+	if b.z == nil {
+		b.z = zip.NewWriter(&b.buf)
+	}
+	if b.err != nil {
+		return
+	}
+	w, err := b.z.Create(name)
+	if err != nil {
+		b.err = err
+		return
+	}
+	_, b.err = w.Write([]byte(contents))
+}
+
+func (b *bundleBuilder) finish() ([]byte, error) {
+	if b.z == nil {
+		return nil, errors.New("empty statement diagnostics bundle")
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.z.Close(); err != nil {
+		return nil, err
+	}
+	return b.buf.Bytes(), nil
+}
+
+func (b *bundleBuilder) addStatement(p *planner) {
+	var buf bytes.Buffer
+	buf.WriteString(tree.Pretty(p.stmt.AST))
+	buf.WriteString(";\n")
+	if placeholders := p.extendedEvalCtx.Placeholders; placeholders != nil &&
+		len(placeholders.Values) > 0 {
+		buf.WriteString("\n-- Arguments:\n")
+		for i, v := range placeholders.Values {
+			fmt.Fprintf(&buf, "--  %s: %s\n", tree.PlaceholderIdx(i), v)
+		}
+	}
+	b.addFile("statement.txt", buf.String())
+}
+
+func (b *bundleBuilder) addOptPlans(p *planner) {
+	plan := &p.curPlan
+	if plan.mem == nil {
+		// The statement was not planned by the optimizer.
+		return
+	}
+	formatOptPlan := func(flags memo.ExprFmtFlags) string {
+		f := memo.MakeExprFmtCtx(flags, plan.mem, plan.catalog)
+		f.FormatExpr(plan.mem.RootExpr())
+		return f.Buffer.String()
+	}
+	b.addFile("opt.txt", formatOptPlan(memo.ExprFmtHideAll))
+	b.addFile("opt-v.txt", formatOptPlan(
+		memo.ExprFmtHideQualifications|memo.ExprFmtHideScalars|memo.ExprFmtHideTypes|
+			memo.ExprFmtHideNotNull,
+	))
+}
+
+// addTrace adds the trace of the execution and returns it as a JSON datum.
+func (b *bundleBuilder) addTrace(trace tracing.Recording) tree.Datum {
+	b.addFile("trace.txt", trace.String())
+	encoded, err := json.MarshalIndent(trace, "", "  ")
+	if err != nil {
+		b.addFile("trace.json", fmt.Sprintf("error encoding the trace: %v\n", err))
+		return tree.DNull
+	}
+	b.addFile("trace.json", string(encoded))
+	j, err := jsonb.ParseJSON(string(encoded))
+	if err != nil {
+		return tree.DNull
+	}
+	return tree.NewDJSON(j)
+}
+
+// resolveDataSources resolves the names of the data sources used by the
+// statement.
+func (b *bundleBuilder) resolveDataSources(ctx context.Context, p *planner) {
+	plan := &p.curPlan
+	if plan.mem == nil {
+		return
+	}
+	b.optPlanned = true
+	md := plan.mem.Metadata()
+	seen := make(map[tree.TableName]bool)
+	addDataSource := func(kind string, ds cat.DataSource) {
+		tn, err := plan.catalog.FullyQualifiedName(ctx, ds)
+		if err != nil {
+			fmt.Fprintf(&b.schemaErrors, "-- error getting the name of %s: %v\n", ds.Name(), err)
+			return
+		}
+		if seen[tn] {
+			return
+		}
+		seen[tn] = true
+		b.dataSources = append(b.dataSources, bundleDataSource{kind: kind, name: tn})
+	}
+	for _, s := range md.AllSequences() {
+		addDataSource("SEQUENCE", s)
+	}
+	for _, t := range md.AllTables() {
+		addDataSource("TABLE", t.Table)
+	}
+	for _, v := range md.AllViews() {
+		addDataSource("VIEW", v)
+	}
+}
+
+// addSchemaAndStats adds the definitions of the data sources used by the
+// statement and the statistics of its tables.
+func (b *bundleBuilder) addSchemaAndStats(ctx context.Context) {
+	if !b.optPlanned {
+		return
+	}
+	var schema bytes.Buffer
+	schema.Write(b.schemaErrors.Bytes())
+	for i := range b.dataSources {
+		ds := &b.dataSources[i]
+		tn := ds.name.String()
+		createStatement, err := b.query(ctx,
+			fmt.Sprintf("SELECT create_statement FROM [SHOW CREATE %s %s]", ds.kind, tn),
+		)
+		if err != nil {
+			fmt.Fprintf(&schema, "-- error getting the definition of %s: %v\n", tn, err)
+		} else {
+			fmt.Fprintf(&schema, "%s;\n", createStatement)
+		}
+		if ds.kind != "TABLE" {
+			continue
+		}
+		var stats string
+		if s, err := b.query(ctx, fmt.Sprintf(
+			`SELECT jsonb_pretty(COALESCE(json_agg(stat), '[]'))
+			FROM (SELECT json_array_elements(statistics) AS stat
+			      FROM [SHOW STATISTICS USING JSON FOR TABLE %s])`,
+			tn,
+		)); err != nil {
+			stats = fmt.Sprintf("-- error getting the statistics of %s: %v\n", tn, err)
+		} else {
+			var buf bytes.Buffer
+			lex.EncodeSQLString(&buf, s)
+			stats = fmt.Sprintf("ALTER TABLE %s INJECT STATISTICS %s;\n", tn, buf.String())
+		}
+		b.addFile(fmt.Sprintf("stats-%s.sql", bundleFileName(&ds.name)), stats)
+	}
+	b.addFile("schema.sql", schema.String())
+}
+
+// addEnv adds the environment of the execution.
+func (b *bundleBuilder) addEnv(p *planner) {
+	var env bytes.Buffer
+	fmt.Fprintf(&env, "-- Version: %s\n", build.GetInfo().Short())
+
+	env.WriteString("\n-- Cluster settings with a non-default value:\n")
+	sv := &p.ExecCfg().Settings.SV
+	for _, name := range settings.Keys() {
+		setting, ok := settings.Lookup(name, settings.LookupForReporting)
+		if !ok {
+			continue
+		}
+		// Masked settings, whose value might be sensitive, are not writable.
+		ws, ok := setting.(settings.WritableSetting)
+		if !ok || ws.Encoded(sv) == ws.EncodedDefault() {
+			continue
+		}
+		var buf bytes.Buffer
+		lex.EncodeSQLString(&buf, setting.String(sv))
+		fmt.Fprintf(&env, "SET CLUSTER SETTING %s = %s;\n", name, buf.String())
+	}
+
+	env.WriteString("\n-- Session variables:\n")
+	for _, name := range varNames {
+		v := varGen[name]
+		if v.Hidden || v.Set == nil {
+			continue
+		}
+		var buf bytes.Buffer
+		lex.EncodeSQLString(&buf, v.Get(&p.extendedEvalCtx))
+		fmt.Fprintf(&env, "SET %s = %s;\n", name, buf.String())
+	}
+	b.addFile("env.sql", env.String())
+}
+
+// query runs a query returning a single string.
+func (b *bundleBuilder) query(ctx context.Context, query string) (string, error) {
+	row, err := b.ie.QueryRowEx(
+		ctx, "stmt-diag-bundle", nil, /* txn */
+		sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
+		query,
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(row) != 1 {
+		return "", errors.AssertionFailedf("expected a single column, returned %d", len(row))
+	}
+	s, ok := row[0].(*tree.DString)
+	if !ok {
+		return "", errors.AssertionFailedf("expected a DString, returned %T", row[0])
+	}
+	return string(*s), nil
+}
+
+// bundleFileName returns a name for the files of the given table.
+func bundleFileName(tn *tree.TableName) string {
+	return strings.Join([]string{
+		tn.CatalogName.Normalize(), tn.SchemaName.Normalize(), tn.TableName.Normalize(),
+	}, ".")
+}
@@ -1227,6 +1227,15 @@ func (rf *cFetcher) GetRangesInfo() []roachpb.RangeInfo {
 	return rf.fetcher.GetRangesInfo()
 }
 
+// getBytesRead returns the total number of bytes read from KV.
+func (rf *cFetcher) getBytesRead() int64 {
+	if rf.fetcher == nil {
+		// Not yet initialized.
+		return 0
+	}
+	return rf.fetcher.GetBytesRead()
+}
+
 // getCurrentColumnFamilyID returns the column family id of the key in
 // rf.machine.nextKV.Key.
 func (rf *cFetcher) getCurrentColumnFamilyID() (sqlbase.FamilyID, error) {
@@ -46,6 +46,8 @@ type colBatchScan struct {
 	maxResults uint64
 	// init is true after Init() has been called.
 	init bool
+	// rowsRead is the number of rows returned so far.
+	rowsRead int64
 }
 
 var _ Operator = &colBatchScan{}
@@ -72,6 +74,7 @@ func (s *colBatchScan) Next(ctx context.Context) coldata.Batch {
 	if bat.Selection() != nil {
 		execerror.VectorizedInternalPanic("unexpectedly a selection vector is set on the batch coming from CFetcher")
 	}
+	s.rowsRead += int64(bat.Length())
 	return bat
 }
 
@@ -93,6 +96,9 @@ func (s *colBatchScan) DrainMeta(ctx context.Context) []execinfrapb.ProducerMeta
 	if tfs := execinfra.GetLeafTxnFinalState(ctx, s.flowCtx.Txn); tfs != nil {
 		trailingMeta = append(trailingMeta, execinfrapb.ProducerMetadata{LeafTxnFinalState: tfs})
 	}
+	metrics := execinfrapb.GetMetricsMeta()
+	metrics.BytesRead, metrics.RowsRead = s.rf.getBytesRead(), s.rowsRead
+	trailingMeta = append(trailingMeta, execinfrapb.ProducerMetadata{Metrics: metrics})
 	return trailingMeta
 }
 
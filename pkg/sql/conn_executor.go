@@ -340,6 +340,10 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.PeriodicallyClearSQLStats(ctx, stopper, maxSQLStatReset, &s.reportedStats)
 	// Start a second loop to clear SQL stats at the requested interval.
 	s.PeriodicallyClearSQLStats(ctx, stopper, sqlStatReset, &s.sqlStats)
+	// Start a loop to flush the SQL stats to system.statement_statistics. Since
+	// flushing clears the flushed stats, the loops above only clear the stats
+	// when flushing is disabled or lags behind.
+	s.PeriodicallyFlushSQLStats(ctx, stopper)
 }
 
 // ResetSQLStats resets the executor's collected sql statistics.
@@ -370,6 +374,26 @@ func (s *Server) GetUnscrubbedStmtStats() []roachpb.CollectedStatementStatistics
 	return s.sqlStats.getUnscrubbedStmtStats(s.cfg.VirtualSchemas)
 }
 
+// GetUnflushedStmtStats returns the same thing as GetUnscrubbedStmtStats,
+// except only the statistics which haven't been flushed to
+// system.statement_statistics yet are included.
+func (s *Server) GetUnflushedStmtStats() []roachpb.CollectedStatementStatistics {
+	return s.sqlStats.getUnflushedStmtStats(s.cfg.VirtualSchemas)
+}
+
+// GetUnscrubbedTxnStats returns the per-fingerprint transaction statistics by
+// app.
+func (s *Server) GetUnscrubbedTxnStats() []roachpb.CollectedTransactionStatistics {
+	return s.sqlStats.getUnscrubbedTxnStats()
+}
+
+// GetUnflushedTxnStats returns the same thing as GetUnscrubbedTxnStats, except
+// only the statistics which haven't been flushed to
+// system.transaction_statistics yet are included.
+func (s *Server) GetUnflushedTxnStats() []roachpb.CollectedTransactionStatistics {
+	return s.sqlStats.getUnflushedTxnStats()
+}
+
 // GetScrubbedReportingStats does the same thing as GetScrubbedStmtStats but
 // returns statistics from the reported stats pool.
 func (s *Server) GetScrubbedReportingStats() []roachpb.CollectedStatementStatistics {
@@ -718,6 +742,7 @@ func (s *Server) newConnExecutorWithTxn(
 		nil, /* historicalTimestamp */
 		txn.UserPriority(),
 		tree.ReadWrite,
+		txnTraceOptions{},
 		txn,
 		ex.transitionCtx)
 
@@ -958,6 +983,11 @@ type connExecutor struct {
 		// stateOpen.
 		autoRetryCounter int
 
+		// txnSummary accumulates the statement fingerprints and the rows read
+		// and written by the current transaction attempt, for the
+		// per-fingerprint transaction statistics.
+		txnSummary txnSummary
+
 		// txnRewindPos is the position within stmtBuf to which we'll rewind when
 		// performing automatic retries. This is more or less the position where the
 		// current transaction started.
@@ -1044,6 +1074,10 @@ type connExecutor struct {
 		// LastActiveQuery contains a reference to the AST of the last
 		// query that ran on this session.
 		LastActiveQuery tree.Statement
+
+		// resourceUsage accumulates the resources consumed by the statements
+		// executed on this session.
+		resourceUsage topLevelQueryStats
 	}
 
 	// curStmt is the statement that's currently being prepared or executed, if
@@ -1171,6 +1205,8 @@ func (ex *connExecutor) resetExtraTxnState(
 			ex.extraTxnState.onTxnFinish = nil
 		}
 	}
+	// The statements of a restarted transaction are executed again.
+	ex.extraTxnState.txnSummary.reset()
 
 	return nil
 }
@@ -1753,7 +1789,7 @@ func (ex *connExecutor) execCopyIn(
 			ctx, cmd.Conn, cmd.Stmt, txnOpt, ex.server.cfg, resetPlanner,
 			// execInsertPlan
 			func(ctx context.Context, p *planner, res RestrictedCommandResult) error {
-				_, _, err := ex.execWithDistSQLEngine(ctx, p, tree.RowsAffected, res, false /* distribute */, nil /* progressAtomic */)
+				_, err := ex.execWithDistSQLEngine(ctx, p, tree.RowsAffected, res, false /* distribute */, nil /* progressAtomic */)
 				return err
 			},
 		)
@@ -2050,6 +2086,7 @@ func (ex *connExecutor) resetPlanner(
 	p.semaCtx.Location = &ex.sessionData.DataConversion.Location
 	p.semaCtx.SearchPath = ex.sessionData.SearchPath
 	p.semaCtx.AsOfTimestamp = nil
+	p.semaCtx.AsOfMinTimestamp = nil
 	p.semaCtx.Annotations = tree.MakeAnnotations(numAnnotations)
 
 	ex.resetEvalCtx(&p.extendedEvalCtx, txn, stmtTS)
@@ -2057,6 +2094,7 @@ func (ex *connExecutor) resetPlanner(
 	p.autoCommit = false
 	p.isPreparing = false
 	p.avoidCachedDescriptors = false
+	p.collectBundle = false
 }
 
 // txnStateTransitionsApplyWrapper is a wrapper on top of Machine built with the
@@ -2092,6 +2130,7 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 	case noEvent:
 	case txnStart:
 		ex.extraTxnState.autoRetryCounter = 0
+		ex.extraTxnState.txnSummary.reset()
 		ex.extraTxnState.onTxnFinish = ex.recordTransactionStart()
 	case txnCommit:
 		if res.Err() != nil {
@@ -2290,16 +2329,19 @@ func (ex *connExecutor) serialize() serverpb.Session {
 	}
 
 	return serverpb.Session{
-		Username:        ex.sessionData.User,
-		ClientAddress:   remoteStr,
-		ApplicationName: ex.applicationName.Load().(string),
-		Start:           ex.phaseTimes[sessionInit].UTC(),
-		ActiveQueries:   activeQueries,
-		KvTxnID:         kvTxnID,
-		LastActiveQuery: lastActiveQuery,
-		ID:              ex.sessionID.GetBytes(),
-		AllocBytes:      ex.mon.AllocBytes(),
-		MaxAllocBytes:   ex.mon.MaximumBytes(),
+		Username:          ex.sessionData.User,
+		ClientAddress:     remoteStr,
+		ApplicationName:   ex.applicationName.Load().(string),
+		Start:             ex.phaseTimes[sessionInit].UTC(),
+		ActiveQueries:     activeQueries,
+		KvTxnID:           kvTxnID,
+		LastActiveQuery:   lastActiveQuery,
+		ID:                ex.sessionID.GetBytes(),
+		AllocBytes:        ex.mon.AllocBytes(),
+		MaxAllocBytes:     ex.mon.MaximumBytes(),
+		KVBytesRead:       ex.mu.resourceUsage.bytesRead,
+		ExecTimeExclWaits: ex.mu.resourceUsage.execTimeExclWaits,
+		NetworkBytes:      ex.mu.resourceUsage.networkBytes,
 	}
 }
 
@@ -19,6 +19,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -26,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/fsm"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -34,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
+	"github.com/opentracing/opentracing-go"
 )
 
 // execStmt executes one statement by dispatching according to the current
@@ -318,32 +321,38 @@ func (ex *connExecutor) execStmtInOpenState(
 	ex.resetPlanner(ctx, p, ex.state.mu.txn, stmtTS, stmt.NumAnnotations)
 
 	if os.ImplicitTxn.Get() {
-		asOfTs, err := p.isAsOf(stmt.AST)
+		asOf, err := p.isAsOf(stmt.AST)
 		if err != nil {
 			return makeErrEvent(err)
 		}
-		if asOfTs != nil {
-			p.semaCtx.AsOfTimestamp = asOfTs
-			p.extendedEvalCtx.SetTxnTimestamp(asOfTs.GoTime())
-			ex.state.setHistoricalTimestamp(ctx, *asOfTs)
+		if asOf != nil {
+			// For bounded staleness reads, the transaction starts out at the
+			// oldest allowed timestamp; the timestamp is negotiated once the
+			// statement has been planned.
+			p.setAsOf(*asOf)
+			p.extendedEvalCtx.SetTxnTimestamp(asOf.Timestamp.GoTime())
+			ex.state.setHistoricalTimestamp(ctx, asOf.Timestamp)
 		}
 	} else {
 		// If we're in an explicit txn, we allow AOST but only if it matches with
 		// the transaction's timestamp. This is useful for running AOST statements
 		// using the InternalExecutor inside an external transaction; one might want
 		// to do that to force p.avoidCachedDescriptors to be set below.
-		ts, err := p.isAsOf(stmt.AST)
+		asOf, err := p.isAsOf(stmt.AST)
 		if err != nil {
 			return makeErrEvent(err)
 		}
-		if ts != nil {
-			if readTs := ex.state.getReadTimestamp(); *ts != readTs {
+		if asOf != nil {
+			if asOf.BoundedStaleness {
+				return makeErrEvent(tree.ErrBoundedStalenessNotAllowed)
+			}
+			if readTs := ex.state.getReadTimestamp(); asOf.Timestamp != readTs {
 				err = pgerror.Newf(pgcode.Syntax,
 					"inconsistent AS OF SYSTEM TIME timestamp; expected: %s", readTs)
 				err = errors.WithHint(err, "try SET TRANSACTION AS OF SYSTEM TIME")
 				return makeErrEvent(err)
 			}
-			p.semaCtx.AsOfTimestamp = ts
+			p.semaCtx.AsOfTimestamp = &asOf.Timestamp
 		}
 	}
 
@@ -409,6 +418,37 @@ func (ex *connExecutor) execStmtInOpenState(
 	p.discardRows = discardRows
 	p.cancelChecker = sqlbase.NewCancelChecker(ctx)
 	p.autoCommit = os.ImplicitTxn.Get() && !ex.server.cfg.TestingKnobs.DisableAutoCommit
+
+	if requestID, fingerprint, ok := ex.shouldCollectDiagnostics(stmt); ok {
+		origCtx := ctx
+		var sp opentracing.Span
+		ctx, sp = tracing.StartSnowballTrace(ctx, ex.server.cfg.AmbientCtx.Tracer, "traced statement")
+		p.extendedEvalCtx.Context = ctx
+		p.collectBundle = true
+		defer func() {
+			sp.Finish()
+			b := makeStatementBundleBuilder(
+				origCtx, p, ex.server.cfg.InternalExecutor, tracing.GetRecording(sp),
+			)
+			// Completing the bundle runs queries and storing it writes to system
+			// tables, so it is done asynchronously rather than delaying the
+			// statement's result.
+			taskCtx := ex.server.cfg.AmbientCtx.AnnotateCtx(context.Background())
+			if err := ex.server.cfg.DistSQLSrv.Stopper.RunAsyncTask(
+				taskCtx, "stmt-diag-bundle", func(ctx context.Context) {
+					bundle, collectionErr := b.build(ctx)
+					if _, err := ex.server.cfg.StmtDiagnosticsRecorder.InsertStatementDiagnostics(
+						ctx, requestID, fingerprint, stmt.SQL, bundle.trace, bundle.zip, collectionErr,
+					); err != nil {
+						log.Warningf(ctx, "unable to record statement diagnostics: %v", err)
+					}
+				},
+			); err != nil {
+				log.Warningf(origCtx, "unable to record statement diagnostics: %v", err)
+			}
+		}()
+	}
+
 	if err := ex.dispatchToExecutionEngine(ctx, p, res); err != nil {
 		return nil, nil, err
 	}
@@ -578,10 +618,12 @@ func (ex *connExecutor) commitSQLTransactionInternal(
 		return ev, payload, false
 	}
 
+	ex.statsCollector.phaseTimes[transactionStartCommit] = timeutil.Now()
 	if err := ex.state.mu.txn.Commit(ctx); err != nil {
 		ev, payload = ex.makeErrEvent(err, stmt)
 		return ev, payload, false
 	}
+	ex.statsCollector.phaseTimes[transactionEndCommit] = timeutil.Now()
 
 	// Now that we've committed, if we modified any table we need to make sure
 	// to release the leases for them so that the schema change can proceed and
@@ -636,6 +678,7 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 		res.DisableBuffering()
 	}
 
+	planner.maybePrepareAuditPolicyEvent(ctx, ex.executorType)
 	defer func() {
 		planner.maybeLogStatement(
 			ctx,
@@ -669,6 +712,10 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 	distributePlan := false
 	distributePlan = shouldDistributePlan(
 		ctx, ex.sessionData.DistSQLMode, ex.server.cfg.DistSQLPlanner, planner.curPlan.plan)
+	if planner.curPlan.flags.IsSet(planFlagNearestReplica) {
+		distributePlan = false
+		ctx = kv.WithNearestReplicaRouting(ctx)
+	}
 	ex.sessionTracing.TracePlanCheckEnd(ctx, nil, distributePlan)
 
 	if ex.server.cfg.TestingKnobs.BeforeExecute != nil {
@@ -706,7 +753,7 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 		planner.curPlan.flags.Set(planFlagDistSQLLocal)
 	}
 	ex.sessionTracing.TraceExecStart(ctx, "distributed")
-	bytesRead, rowsRead, err := ex.execWithDistSQLEngine(ctx, planner, stmt.AST.StatementType(), res, distributePlan, progAtomic)
+	stats, err := ex.execWithDistSQLEngine(ctx, planner, stmt.AST.StatementType(), res, distributePlan, progAtomic)
 	ex.sessionTracing.TraceExecEnd(ctx, res.Err(), res.RowsAffected())
 	ex.statsCollector.phaseTimes[plannerEndExecStmt] = timeutil.Now()
 
@@ -714,7 +761,7 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 	// plan has not been closed earlier.
 	ex.recordStatementSummary(
 		ctx, planner,
-		ex.extraTxnState.autoRetryCounter, res.RowsAffected(), res.Err(), bytesRead, rowsRead,
+		ex.extraTxnState.autoRetryCounter, res.RowsAffected(), res.Err(), stats,
 	)
 	if ex.server.cfg.TestingKnobs.AfterExecute != nil {
 		ex.server.cfg.TestingKnobs.AfterExecute(ctx, stmt.String(), res.Err())
@@ -732,6 +779,9 @@ func (ex *connExecutor) makeExecPlan(ctx context.Context, planner *planner) erro
 		log.VEventf(ctx, 1, "optimizer plan failed: %v", err)
 		return err
 	}
+	if planner.semaCtx.AsOfMinTimestamp != nil {
+		return ex.negotiateBoundedStaleness(ctx, planner)
+	}
 	return nil
 }
 
@@ -746,7 +796,7 @@ func (ex *connExecutor) execWithDistSQLEngine(
 	res RestrictedCommandResult,
 	distribute bool,
 	progressAtomic *uint64,
-) (bytesRead, rowsRead int64, _ error) {
+) (topLevelQueryStats, error) {
 	recv := MakeDistSQLReceiver(
 		ctx, res, stmtType,
 		ex.server.cfg.RangeDescriptorCache, ex.server.cfg.LeaseHolderCache,
@@ -786,7 +836,7 @@ func (ex *connExecutor) execWithDistSQLEngine(
 		if !ex.server.cfg.DistSQLPlanner.PlanAndRunSubqueries(
 			ctx, planner, evalCtxFactory, planner.curPlan.subqueryPlans, recv, distribute,
 		) {
-			return recv.bytesRead, recv.rowsRead, recv.commErr
+			return recv.stats, recv.commErr
 		}
 	}
 	recv.discardRows = planner.discardRows
@@ -799,7 +849,7 @@ func (ex *connExecutor) execWithDistSQLEngine(
 	// need to have access to the main query tree.
 	defer cleanup()
 	if recv.commErr != nil || res.Err() != nil {
-		return recv.bytesRead, recv.rowsRead, recv.commErr
+		return recv.stats, recv.commErr
 	}
 
 	if len(planner.curPlan.postqueryPlans) != 0 {
@@ -808,7 +858,7 @@ func (ex *connExecutor) execWithDistSQLEngine(
 		)
 	}
 
-	return recv.bytesRead, recv.rowsRead, recv.commErr
+	return recv.stats, recv.commErr
 }
 
 // beginTransactionTimestampsAndReadMode computes the timestamps and
@@ -879,11 +929,12 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		if err != nil {
 			return ex.makeErrEvent(err, s)
 		}
-		return eventTxnStart{ImplicitTxn: fsm.False},
-			makeEventTxnStartPayload(
-				pri, mode, sqlTs,
-				historicalTs,
-				ex.transitionCtx)
+		txnPayload := makeEventTxnStartPayload(
+			pri, mode, sqlTs,
+			historicalTs,
+			ex.transitionCtx)
+		txnPayload.traceOpts = ex.txnTraceOptions(ctx, stmt)
+		return eventTxnStart{ImplicitTxn: fsm.False}, txnPayload
 	case *tree.CommitTransaction, *tree.ReleaseSavepoint,
 		*tree.RollbackTransaction, *tree.SetTransaction, *tree.Savepoint:
 		return ex.makeErrEvent(errNoTransactionInProgress, stmt.AST)
@@ -895,14 +946,67 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		// NB: Implicit transactions are created without a historical timestamp even
 		// though the statement might contain an AOST clause. In these cases the
 		// clause is evaluated and applied execStmtInOpenState.
-		return eventTxnStart{ImplicitTxn: fsm.True},
-			makeEventTxnStartPayload(
-				roachpb.NormalUserPriority,
-				mode,
-				ex.server.cfg.Clock.PhysicalTime(),
-				nil, /* historicalTimestamp */
-				ex.transitionCtx)
+		txnPayload := makeEventTxnStartPayload(
+			roachpb.NormalUserPriority,
+			mode,
+			ex.server.cfg.Clock.PhysicalTime(),
+			nil, /* historicalTimestamp */
+			ex.transitionCtx)
+		txnPayload.traceOpts = ex.txnTraceOptions(ctx, stmt)
+		return eventTxnStart{ImplicitTxn: fsm.True}, txnPayload
+	}
+}
+
+// txnTraceOptions returns the options of the span of a transaction started
+// by the given statement. The transaction's trace continues the client's
+// trace if the statement's comments or the session carry a trace context,
+// and is otherwise sampled according to the session's application name and
+// the statement's fingerprint (see trace.opentelemetry.sample_rate).
+func (ex *connExecutor) txnTraceOptions(ctx context.Context, stmt Statement) txnTraceOptions {
+	traceParent := ex.sessionData.TraceParent
+	if tp, ok := traceParentFromComments(stmt.Comments); ok {
+		traceParent = tp
+	}
+	if traceParent != "" {
+		tr := ex.transitionCtx.tracer.(*tracing.Tracer)
+		remoteParent, err := tr.ExtractTraceParent(traceParent)
+		if err == nil {
+			return txnTraceOptions{remoteParent: remoteParent}
+		}
+		// The session variable is validated, but the comments are not.
+		log.VEventf(ctx, 2, "ignoring trace context: %v", err)
+	}
+	return txnTraceOptions{
+		samplingHint: &tracing.SamplingHint{
+			AppName: ex.sessionData.ApplicationName,
+			StmtFingerprint: func() string {
+				if stmt.AnonymizedStr != "" {
+					return stmt.AnonymizedStr
+				}
+				return anonymizeStmt(stmt.AST)
+			},
+		},
+	}
+}
+
+// shouldCollectDiagnostics returns whether a statement diagnostics bundle
+// should be collected for the execution of the given statement and, if so,
+// the request it is collected for and the statement's fingerprint. Bundles
+// are not collected for schema changes, since collecting them reads the
+// descriptors the statement's transaction may have modified.
+func (ex *connExecutor) shouldCollectDiagnostics(
+	stmt Statement,
+) (stmtdiagnostics.RequestID, string, bool) {
+	recorder := ex.server.cfg.StmtDiagnosticsRecorder
+	if recorder == nil || !recorder.HasRequests() || stmt.AST.StatementType() == tree.DDL {
+		return 0, "", false
+	}
+	fingerprint := stmt.AnonymizedStr
+	if fingerprint == "" {
+		fingerprint = anonymizeStmt(stmt.AST)
 	}
+	requestID, ok := recorder.ShouldCollectDiagnostics(fingerprint)
+	return requestID, fingerprint, ok
 }
 
 // execStmtInAbortedState executes a statement in a txn that's in state
@@ -1201,10 +1305,20 @@ func (ex *connExecutor) recordTransaction(ev txnEvent, implicit bool) {
 	txnStart := phaseTimes[transactionStart]
 	txnEnd := phaseTimes[transactionEnd]
 	txnTime := txnEnd.Sub(txnStart)
+	// The commit phases are only set if the transaction was committed by
+	// the statement which finished it, and not by the execution engine
+	// together with the last write of an implicit transaction.
+	commitStart, commitEnd := phaseTimes[transactionStartCommit], phaseTimes[transactionEndCommit]
+	commitTimed := !commitStart.IsZero() && !commitEnd.IsZero()
+	commitLat := commitEnd.Sub(commitStart)
 	ex.metrics.EngineMetrics.SQLTxnLatency.RecordValue(txnTime.Nanoseconds())
 	ex.statsCollector.recordTransaction(
 		txnTime.Seconds(),
 		ev,
 		implicit,
+		&ex.extraTxnState.txnSummary,
+		ex.extraTxnState.autoRetryCounter,
+		commitLat.Seconds(),
+		commitTimed,
 	)
 }
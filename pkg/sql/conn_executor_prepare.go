@@ -199,13 +199,13 @@ func (ex *connExecutor) populatePrepared(
 	}
 	p.extendedEvalCtx.PrepareOnly = true
 
-	protoTS, err := p.isAsOf(stmt.AST)
+	asOf, err := p.isAsOf(stmt.AST)
 	if err != nil {
 		return 0, err
 	}
-	if protoTS != nil {
-		p.semaCtx.AsOfTimestamp = protoTS
-		txn.SetFixedTimestamp(ctx, *protoTS)
+	if asOf != nil {
+		p.setAsOf(*asOf)
+		txn.SetFixedTimestamp(ctx, asOf.Timestamp)
 	}
 
 	// PREPARE has a limited subset of statements it can be run with. Postgres
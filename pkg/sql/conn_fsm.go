@@ -124,6 +124,8 @@ type eventTxnStartPayload struct {
 	txnSQLTimestamp     time.Time
 	readOnly            tree.ReadWriteMode
 	historicalTimestamp *hlc.Timestamp
+	// traceOpts are the options of the transaction's span.
+	traceOpts txnTraceOptions
 }
 
 func makeEventTxnStartPayload(
@@ -437,6 +439,7 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 					payload.txnSQLTimestamp,
 					payload.historicalTimestamp,
 					payload.pri, payload.readOnly,
+					payload.traceOpts,
 					nil, /* txn */
 					args.Payload.(eventTxnStartPayload).tranCtx,
 				)
@@ -533,6 +536,7 @@ func (ts *txnState) noTxnToOpen(
 		payload.historicalTimestamp,
 		payload.pri,
 		payload.readOnly,
+		payload.traceOpts,
 		nil, /* txn */
 		payload.tranCtx,
 	)
@@ -0,0 +1,143 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+type controlSchedulesNode struct {
+	// scheduleID is the ID of the schedule to act upon, if the statement names
+	// a single schedule. Otherwise, query selects the IDs of the schedules.
+	scheduleID tree.Datum
+	query      string
+	command    tree.ScheduleCommand
+	numRows    int
+}
+
+// ControlSchedules pauses, resumes or drops the schedules selected by the
+// statement.
+// Privileges: admin.
+func (p *planner) ControlSchedules(
+	ctx context.Context, n *tree.ControlSchedules,
+) (planNode, error) {
+	if !cluster.Version.IsActive(ctx, p.ExecCfg().Settings, cluster.VersionScheduledJobs) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s SCHEDULES requires all nodes to be upgraded to %s",
+			tree.ScheduleCommandToStatement[n.Command],
+			cluster.VersionByKey(cluster.VersionScheduledJobs))
+	}
+	if err := p.RequireAdminRole(ctx, n.StatementTag()); err != nil {
+		return nil, err
+	}
+
+	if values, ok := n.Schedules.Select.(*tree.ValuesClause); ok &&
+		len(values.Rows) == 1 && len(values.Rows[0]) == 1 {
+		typedExpr, err := p.analyzeExpr(
+			ctx, values.Rows[0][0], nil, tree.IndexedVarHelper{}, types.Int, true, n.StatementTag(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		scheduleID, err := typedExpr.Eval(p.EvalContext())
+		if err != nil {
+			return nil, err
+		}
+		return &controlSchedulesNode{scheduleID: scheduleID, command: n.Command}, nil
+	}
+
+	// The schedule IDs are selected by a query of their own, which is run
+	// through the internal executor. Substitute placeholders with their values.
+	fmtCtx := tree.NewFmtCtx(tree.FmtParsable)
+	fmtCtx.SetPlaceholderFormat(func(ctx *tree.FmtCtx, placeholder *tree.Placeholder) {
+		d, err := placeholder.Eval(p.EvalContext())
+		if err != nil {
+			panic(fmt.Sprintf("failed to serialize placeholder: %s", err))
+		}
+		d.Format(ctx)
+	})
+	fmtCtx.FormatNode(n.Schedules)
+
+	return &controlSchedulesNode{
+		query:   fmtCtx.CloseAndGetString(),
+		command: n.Command,
+	}, nil
+}
+
+// FastPathResults implements the planNodeFastPath interface.
+func (n *controlSchedulesNode) FastPathResults() (int, bool) {
+	return n.numRows, true
+}
+
+func (n *controlSchedulesNode) startExec(params runParams) error {
+	ex := params.ExecCfg().InternalExecutor
+	rows := []tree.Datums{{n.scheduleID}}
+	if n.scheduleID == nil {
+		var err error
+		rows, err = ex.Query(params.ctx, "select-schedules", params.p.txn, n.query)
+		if err != nil {
+			return err
+		}
+	}
+	now := params.ExecCfg().Clock.Now().GoTime()
+
+	for _, row := range rows {
+		if len(row) == 0 || row[0] == tree.DNull {
+			continue
+		}
+		scheduleID, ok := tree.AsDInt(row[0])
+		if !ok {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"%q: expected schedule ID of type INT, found %s", row[0], row[0].ResolvedType())
+		}
+
+		schedule, err := jobs.LoadScheduledJob(params.ctx, ex, int64(scheduleID), params.p.txn)
+		if err != nil {
+			return err
+		}
+		switch n.command {
+		case tree.PauseSchedule:
+			schedule.Pause()
+			err = schedule.Update(params.ctx, ex, params.p.txn)
+		case tree.ResumeSchedule:
+			// Resuming a schedule that isn't paused leaves its next run as is.
+			if schedule.IsPaused() {
+				if err = schedule.ScheduleNextRun(now); err == nil {
+					err = schedule.Update(params.ctx, ex, params.p.txn)
+				}
+			}
+		case tree.DropSchedule:
+			err = schedule.Delete(params.ctx, ex, params.p.txn)
+		default:
+			err = errors.AssertionFailedf("unhandled command %d", n.command)
+		}
+		if err != nil {
+			return err
+		}
+		n.numRows++
+	}
+	return nil
+}
+
+func (*controlSchedulesNode) Next(runParams) (bool, error) { return false, nil }
+
+func (*controlSchedulesNode) Values() tree.Datums { return nil }
+
+func (*controlSchedulesNode) Close(context.Context) {}
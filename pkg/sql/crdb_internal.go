@@ -33,12 +33,15 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -64,41 +67,49 @@ const crdbInternalName = "crdb_internal"
 var crdbInternal = virtualSchema{
 	name: crdbInternalName,
 	tableDefs: map[sqlbase.ID]virtualSchemaDef{
-		sqlbase.CrdbInternalBackwardDependenciesTableID: crdbInternalBackwardDependenciesTable,
-		sqlbase.CrdbInternalBuildInfoTableID:            crdbInternalBuildInfoTable,
-		sqlbase.CrdbInternalBuiltinFunctionsTableID:     crdbInternalBuiltinFunctionsTable,
-		sqlbase.CrdbInternalClusterQueriesTableID:       crdbInternalClusterQueriesTable,
-		sqlbase.CrdbInternalClusterSessionsTableID:      crdbInternalClusterSessionsTable,
-		sqlbase.CrdbInternalClusterSettingsTableID:      crdbInternalClusterSettingsTable,
-		sqlbase.CrdbInternalCreateStmtsTableID:          crdbInternalCreateStmtsTable,
-		sqlbase.CrdbInternalFeatureUsageID:              crdbInternalFeatureUsage,
-		sqlbase.CrdbInternalForwardDependenciesTableID:  crdbInternalForwardDependenciesTable,
-		sqlbase.CrdbInternalGossipNodesTableID:          crdbInternalGossipNodesTable,
-		sqlbase.CrdbInternalGossipAlertsTableID:         crdbInternalGossipAlertsTable,
-		sqlbase.CrdbInternalGossipLivenessTableID:       crdbInternalGossipLivenessTable,
-		sqlbase.CrdbInternalGossipNetworkTableID:        crdbInternalGossipNetworkTable,
-		sqlbase.CrdbInternalIndexColumnsTableID:         crdbInternalIndexColumnsTable,
-		sqlbase.CrdbInternalJobsTableID:                 crdbInternalJobsTable,
-		sqlbase.CrdbInternalKVNodeStatusTableID:         crdbInternalKVNodeStatusTable,
-		sqlbase.CrdbInternalKVStoreStatusTableID:        crdbInternalKVStoreStatusTable,
-		sqlbase.CrdbInternalLeasesTableID:               crdbInternalLeasesTable,
-		sqlbase.CrdbInternalLocalQueriesTableID:         crdbInternalLocalQueriesTable,
-		sqlbase.CrdbInternalLocalSessionsTableID:        crdbInternalLocalSessionsTable,
-		sqlbase.CrdbInternalLocalMetricsTableID:         crdbInternalLocalMetricsTable,
-		sqlbase.CrdbInternalPartitionsTableID:           crdbInternalPartitionsTable,
-		sqlbase.CrdbInternalPredefinedCommentsTableID:   crdbInternalPredefinedCommentsTable,
-		sqlbase.CrdbInternalRangesNoLeasesTableID:       crdbInternalRangesNoLeasesTable,
-		sqlbase.CrdbInternalRangesViewID:                crdbInternalRangesView,
-		sqlbase.CrdbInternalRuntimeInfoTableID:          crdbInternalRuntimeInfoTable,
-		sqlbase.CrdbInternalSchemaChangesTableID:        crdbInternalSchemaChangesTable,
-		sqlbase.CrdbInternalSessionTraceTableID:         crdbInternalSessionTraceTable,
-		sqlbase.CrdbInternalSessionVariablesTableID:     crdbInternalSessionVariablesTable,
-		sqlbase.CrdbInternalStmtStatsTableID:            crdbInternalStmtStatsTable,
-		sqlbase.CrdbInternalTableColumnsTableID:         crdbInternalTableColumnsTable,
-		sqlbase.CrdbInternalTableIndexesTableID:         crdbInternalTableIndexesTable,
-		sqlbase.CrdbInternalTablesTableID:               crdbInternalTablesTable,
-		sqlbase.CrdbInternalTxnStatsTableID:             crdbInternalTxnStatsTable,
-		sqlbase.CrdbInternalZonesTableID:                crdbInternalZonesTable,
+		sqlbase.CrdbInternalBackwardDependenciesTableID:  crdbInternalBackwardDependenciesTable,
+		sqlbase.CrdbInternalBuildInfoTableID:             crdbInternalBuildInfoTable,
+		sqlbase.CrdbInternalBuiltinFunctionsTableID:      crdbInternalBuiltinFunctionsTable,
+		sqlbase.CrdbInternalClusterContendedIndexesID:    crdbInternalClusterContendedIndexesTable,
+		sqlbase.CrdbInternalClusterContendingTxnsID:      crdbInternalClusterContendingTxnsTable,
+		sqlbase.CrdbInternalClusterLocksTableID:          crdbInternalClusterLocksTable,
+		sqlbase.CrdbInternalClusterQueriesTableID:        crdbInternalClusterQueriesTable,
+		sqlbase.CrdbInternalClusterSessionsTableID:       crdbInternalClusterSessionsTable,
+		sqlbase.CrdbInternalClusterSettingsTableID:       crdbInternalClusterSettingsTable,
+		sqlbase.CrdbInternalCreateStmtsTableID:           crdbInternalCreateStmtsTable,
+		sqlbase.CrdbInternalFeatureUsageID:               crdbInternalFeatureUsage,
+		sqlbase.CrdbInternalForwardDependenciesTableID:   crdbInternalForwardDependenciesTable,
+		sqlbase.CrdbInternalGossipNodesTableID:           crdbInternalGossipNodesTable,
+		sqlbase.CrdbInternalGossipAlertsTableID:          crdbInternalGossipAlertsTable,
+		sqlbase.CrdbInternalGossipLivenessTableID:        crdbInternalGossipLivenessTable,
+		sqlbase.CrdbInternalGossipNetworkTableID:         crdbInternalGossipNetworkTable,
+		sqlbase.CrdbInternalIndexColumnsTableID:          crdbInternalIndexColumnsTable,
+		sqlbase.CrdbInternalJobMessagesTableID:           crdbInternalJobMessagesTable,
+		sqlbase.CrdbInternalJobProcessorProgressTableID:  crdbInternalJobProcessorProgressTable,
+		sqlbase.CrdbInternalJobsTableID:                  crdbInternalJobsTable,
+		sqlbase.CrdbInternalKVNodeStatusTableID:          crdbInternalKVNodeStatusTable,
+		sqlbase.CrdbInternalKVStoreStatusTableID:         crdbInternalKVStoreStatusTable,
+		sqlbase.CrdbInternalLeasesTableID:                crdbInternalLeasesTable,
+		sqlbase.CrdbInternalLocalQueriesTableID:          crdbInternalLocalQueriesTable,
+		sqlbase.CrdbInternalLocalSessionsTableID:         crdbInternalLocalSessionsTable,
+		sqlbase.CrdbInternalLocalMetricsTableID:          crdbInternalLocalMetricsTable,
+		sqlbase.CrdbInternalNodeTxnStatisticsTableID:     crdbInternalNodeTxnStatisticsTable,
+		sqlbase.CrdbInternalPartitionsTableID:            crdbInternalPartitionsTable,
+		sqlbase.CrdbInternalPredefinedCommentsTableID:    crdbInternalPredefinedCommentsTable,
+		sqlbase.CrdbInternalRangesNoLeasesTableID:        crdbInternalRangesNoLeasesTable,
+		sqlbase.CrdbInternalRangesViewID:                 crdbInternalRangesView,
+		sqlbase.CrdbInternalRuntimeInfoTableID:           crdbInternalRuntimeInfoTable,
+		sqlbase.CrdbInternalSchemaChangesTableID:         crdbInternalSchemaChangesTable,
+		sqlbase.CrdbInternalSessionTraceTableID:          crdbInternalSessionTraceTable,
+		sqlbase.CrdbInternalSessionVariablesTableID:      crdbInternalSessionVariablesTable,
+		sqlbase.CrdbInternalStatementStatisticsTableID:   crdbInternalStatementStatisticsTable,
+		sqlbase.CrdbInternalStmtStatsTableID:             crdbInternalStmtStatsTable,
+		sqlbase.CrdbInternalTableColumnsTableID:          crdbInternalTableColumnsTable,
+		sqlbase.CrdbInternalTableIndexesTableID:          crdbInternalTableIndexesTable,
+		sqlbase.CrdbInternalTablesTableID:                crdbInternalTablesTable,
+		sqlbase.CrdbInternalTransactionStatisticsTableID: crdbInternalTransactionStatisticsTable,
+		sqlbase.CrdbInternalTxnStatsTableID:              crdbInternalTxnStatsTable,
+		sqlbase.CrdbInternalZonesTableID:                 crdbInternalZonesTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -572,6 +583,92 @@ CREATE TABLE crdb_internal.jobs (
 	},
 }
 
+var crdbInternalJobMessagesTable = virtualSchemaTable{
+	comment: `messages recorded by jobs in system.job_messages (KV scan)`,
+	schema: `
+CREATE TABLE crdb_internal.job_messages (
+	job_id     INT,
+	written    TIMESTAMPTZ,
+	kind       STRING,
+	message    STRING
+)`,
+	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		const query = `
+SELECT m.job_id, m.written, m.kind, m.message, j.payload
+FROM system.job_messages AS m JOIN system.jobs AS j ON m.job_id = j.id
+ORDER BY m.job_id, m.written DESC, m.message_id DESC`
+		return populateJobObservabilityRows(ctx, p, "crdb-internal-job-messages-table", query, addRow)
+	},
+}
+
+var crdbInternalJobProcessorProgressTable = virtualSchemaTable{
+	comment: `progress of the processors of jobs from system.job_processor_progress (KV scan)`,
+	schema: `
+CREATE TABLE crdb_internal.job_processor_progress (
+	job_id             INT,
+	node_id            INT,
+	processor_id       INT,
+	processor          STRING,
+	updated            TIMESTAMPTZ,
+	fraction_completed FLOAT,
+	running_status     STRING
+)`,
+	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		const query = `
+SELECT pp.job_id, pp.node_id, pp.processor_id, pp.processor, pp.updated,
+       pp.fraction_completed, pp.running_status, j.payload
+FROM system.job_processor_progress AS pp JOIN system.jobs AS j ON pp.job_id = j.id`
+		return populateJobObservabilityRows(
+			ctx, p, "crdb-internal-job-processor-progress-table", query, addRow,
+		)
+	},
+}
+
+// populateJobObservabilityRows adds the rows returned by the given query over
+// system.job_messages or system.job_processor_progress. The query's last
+// column must be the payload of the job the row belongs to; it is used to
+// filter out the rows of jobs the current user cannot see, like
+// crdb_internal.jobs does, and is not added to the rows.
+func populateJobObservabilityRows(
+	ctx context.Context,
+	p *planner,
+	opName string,
+	query string,
+	addRow func(...tree.Datum) error,
+) error {
+	if !cluster.Version.IsActive(ctx, p.ExecCfg().Settings, cluster.VersionJobObservability) {
+		return nil
+	}
+	currentUser := p.SessionData().User
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Beware: we're querying the system tables as root; we need to be careful
+	// to filter out results that the current user is not able to see.
+	rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
+		ctx, opName, p.txn,
+		sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
+		query)
+	if err != nil {
+		return err
+	}
+	for _, r := range rows {
+		payloadBytes := r[len(r)-1]
+		if !isAdmin {
+			payload, err := jobs.UnmarshalPayload(payloadBytes)
+			if err != nil || payload.Username != currentUser {
+				continue
+			}
+		}
+		if err := addRow(r[:len(r)-1]...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type stmtList []stmtKey
 
 func (s stmtList) Len() int {
@@ -589,30 +686,36 @@ var crdbInternalStmtStatsTable = virtualSchemaTable{
 		`This table is wiped periodically (by default, at least every two hours)`,
 	schema: `
 CREATE TABLE crdb_internal.node_statement_statistics (
-  node_id             INT NOT NULL,
-  application_name    STRING NOT NULL,
-  flags               STRING NOT NULL,
-  key                 STRING NOT NULL,
-  anonymized          STRING,
-  count               INT NOT NULL,
-  first_attempt_count INT NOT NULL,
-  max_retries         INT NOT NULL,
-  last_error          STRING,
-  rows_avg            FLOAT NOT NULL,
-  rows_var            FLOAT NOT NULL,
-  parse_lat_avg       FLOAT NOT NULL,
-  parse_lat_var       FLOAT NOT NULL,
-  plan_lat_avg        FLOAT NOT NULL,
-  plan_lat_var        FLOAT NOT NULL,
-  run_lat_avg         FLOAT NOT NULL,
-  run_lat_var         FLOAT NOT NULL,
-  service_lat_avg     FLOAT NOT NULL,
-  service_lat_var     FLOAT NOT NULL,
-  overhead_lat_avg    FLOAT NOT NULL,
-  overhead_lat_var    FLOAT NOT NULL,
-  bytes_read          INT NOT NULL,
-  rows_read           INT NOT NULL,
-  implicit_txn        BOOL NOT NULL
+  node_id                  INT NOT NULL,
+  application_name         STRING NOT NULL,
+  flags                    STRING NOT NULL,
+  key                      STRING NOT NULL,
+  anonymized               STRING,
+  count                    INT NOT NULL,
+  first_attempt_count      INT NOT NULL,
+  max_retries              INT NOT NULL,
+  last_error               STRING,
+  rows_avg                 FLOAT NOT NULL,
+  rows_var                 FLOAT NOT NULL,
+  parse_lat_avg            FLOAT NOT NULL,
+  parse_lat_var            FLOAT NOT NULL,
+  plan_lat_avg             FLOAT NOT NULL,
+  plan_lat_var             FLOAT NOT NULL,
+  run_lat_avg              FLOAT NOT NULL,
+  run_lat_var              FLOAT NOT NULL,
+  service_lat_avg          FLOAT NOT NULL,
+  service_lat_var          FLOAT NOT NULL,
+  overhead_lat_avg         FLOAT NOT NULL,
+  overhead_lat_var         FLOAT NOT NULL,
+  bytes_read               INT NOT NULL,
+  rows_read                INT NOT NULL,
+  implicit_txn             BOOL NOT NULL,
+  kv_bytes_read_avg        FLOAT NOT NULL,
+  kv_bytes_read_var        FLOAT NOT NULL,
+  exec_time_excl_waits_avg FLOAT NOT NULL,
+  exec_time_excl_waits_var FLOAT NOT NULL,
+  network_bytes_avg        FLOAT NOT NULL,
+  network_bytes_var        FLOAT NOT NULL
 )`,
 	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		if err := p.RequireAdminRole(ctx, "access application statistics"); err != nil {
@@ -691,6 +794,12 @@ CREATE TABLE crdb_internal.node_statement_statistics (
 					tree.NewDInt(tree.DInt(s.data.BytesRead)),
 					tree.NewDInt(tree.DInt(s.data.RowsRead)),
 					tree.MakeDBool(tree.DBool(stmtKey.implicitTxn)),
+					tree.NewDFloat(tree.DFloat(s.data.KVBytesRead.Mean)),
+					tree.NewDFloat(tree.DFloat(s.data.KVBytesRead.GetVariance(s.data.Count))),
+					tree.NewDFloat(tree.DFloat(s.data.ExecTimeExclWaits.Mean)),
+					tree.NewDFloat(tree.DFloat(s.data.ExecTimeExclWaits.GetVariance(s.data.Count))),
+					tree.NewDFloat(tree.DFloat(s.data.NetworkBytes.Mean)),
+					tree.NewDFloat(tree.DFloat(s.data.NetworkBytes.GetVariance(s.data.Count))),
 				)
 				s.Unlock()
 				if err != nil {
@@ -702,6 +811,327 @@ CREATE TABLE crdb_internal.node_statement_statistics (
 	},
 }
 
+var crdbInternalStatementStatisticsTable = virtualSchemaTable{
+	comment: `statement statistics flushed to system.statement_statistics, aggregated across nodes by time bucket (KV scan)`,
+	schema: `
+CREATE TABLE crdb_internal.statement_statistics (
+  aggregated_ts            TIMESTAMPTZ NOT NULL,
+  application_name         STRING NOT NULL,
+  flags                    STRING NOT NULL,
+  key                      STRING NOT NULL,
+  count                    INT NOT NULL,
+  first_attempt_count      INT NOT NULL,
+  max_retries              INT NOT NULL,
+  last_error               STRING,
+  rows_avg                 FLOAT NOT NULL,
+  rows_var                 FLOAT NOT NULL,
+  parse_lat_avg            FLOAT NOT NULL,
+  parse_lat_var            FLOAT NOT NULL,
+  plan_lat_avg             FLOAT NOT NULL,
+  plan_lat_var             FLOAT NOT NULL,
+  run_lat_avg              FLOAT NOT NULL,
+  run_lat_var              FLOAT NOT NULL,
+  service_lat_avg          FLOAT NOT NULL,
+  service_lat_var          FLOAT NOT NULL,
+  overhead_lat_avg         FLOAT NOT NULL,
+  overhead_lat_var         FLOAT NOT NULL,
+  bytes_read               INT NOT NULL,
+  rows_read                INT NOT NULL,
+  implicit_txn             BOOL NOT NULL,
+  kv_bytes_read_avg        FLOAT NOT NULL,
+  kv_bytes_read_var        FLOAT NOT NULL,
+  exec_time_excl_waits_avg FLOAT NOT NULL,
+  exec_time_excl_waits_var FLOAT NOT NULL,
+  network_bytes_avg        FLOAT NOT NULL,
+  network_bytes_var        FLOAT NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "access application statistics"); err != nil {
+			return err
+		}
+		if !cluster.Version.IsActive(ctx, p.ExecCfg().Settings, cluster.VersionPersistedSQLStats) {
+			return nil
+		}
+
+		// The rows of the different nodes for the same statement and time
+		// bucket are adjacent in the primary key order.
+		rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
+			ctx, "crdb-internal-statement-statistics", p.txn,
+			sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
+			`SELECT aggregated_ts, app_name, fingerprint, failed, distsql, implicit_txn, statistics
+			FROM system.statement_statistics
+			ORDER BY aggregated_ts, app_name, fingerprint, failed, distsql, implicit_txn`,
+		)
+		if err != nil {
+			return err
+		}
+
+		emit := func(row tree.Datums, key stmtKey, s *roachpb.StatementStatistics) error {
+			errString := tree.DNull
+			if s.SensitiveInfo.LastErr != "" {
+				errString = tree.NewDString(s.SensitiveInfo.LastErr)
+			}
+			return addRow(
+				row[0],
+				row[1],
+				tree.NewDString(key.flags()),
+				tree.NewDString(key.stmt),
+				tree.NewDInt(tree.DInt(s.Count)),
+				tree.NewDInt(tree.DInt(s.FirstAttemptCount)),
+				tree.NewDInt(tree.DInt(s.MaxRetries)),
+				errString,
+				tree.NewDFloat(tree.DFloat(s.NumRows.Mean)),
+				tree.NewDFloat(tree.DFloat(s.NumRows.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.ParseLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.ParseLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.PlanLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.PlanLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.RunLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.RunLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.ServiceLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.ServiceLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.OverheadLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.OverheadLat.GetVariance(s.Count))),
+				tree.NewDInt(tree.DInt(s.BytesRead)),
+				tree.NewDInt(tree.DInt(s.RowsRead)),
+				tree.MakeDBool(tree.DBool(key.implicitTxn)),
+				tree.NewDFloat(tree.DFloat(s.KVBytesRead.Mean)),
+				tree.NewDFloat(tree.DFloat(s.KVBytesRead.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.ExecTimeExclWaits.Mean)),
+				tree.NewDFloat(tree.DFloat(s.ExecTimeExclWaits.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.NetworkBytes.Mean)),
+				tree.NewDFloat(tree.DFloat(s.NetworkBytes.GetVariance(s.Count))),
+			)
+		}
+
+		var prevRow tree.Datums
+		var prevKey stmtKey
+		var merged roachpb.StatementStatistics
+		for _, row := range rows {
+			key := stmtKey{
+				stmt:        string(tree.MustBeDString(row[2])),
+				failed:      bool(tree.MustBeDBool(row[3])),
+				distSQLUsed: bool(tree.MustBeDBool(row[4])),
+				implicitTxn: bool(tree.MustBeDBool(row[5])),
+			}
+			var s roachpb.StatementStatistics
+			if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[6])), &s); err != nil {
+				return err
+			}
+			if prevRow != nil && key == prevKey &&
+				row[0].Compare(p.EvalContext(), prevRow[0]) == 0 &&
+				row[1].Compare(p.EvalContext(), prevRow[1]) == 0 {
+				merged.Add(&s)
+				continue
+			}
+			if prevRow != nil {
+				if err := emit(prevRow, prevKey, &merged); err != nil {
+					return err
+				}
+			}
+			prevRow, prevKey, merged = row, key, s
+		}
+		if prevRow != nil {
+			return emit(prevRow, prevKey, &merged)
+		}
+		return nil
+	},
+}
+
+var crdbInternalNodeTxnStatisticsTable = virtualSchemaTable{
+	comment: `per-fingerprint transaction statistics (in-memory, not durable; local node only). ` +
+		`This table is wiped periodically (by default, at least every two hours)`,
+	schema: `
+CREATE TABLE crdb_internal.node_transaction_statistics (
+  node_id             INT NOT NULL,
+  application_name    STRING NOT NULL,
+  fingerprint_id      STRING NOT NULL,
+  statements          STRING[] NOT NULL,
+  count               INT NOT NULL,
+  committed_count     INT NOT NULL,
+  max_retries         INT NOT NULL,
+  retries_avg         FLOAT NOT NULL,
+  service_lat_avg     FLOAT NOT NULL,
+  service_lat_var     FLOAT NOT NULL,
+  commit_lat_avg      FLOAT NOT NULL,
+  commit_lat_var      FLOAT NOT NULL,
+  rows_read_avg       FLOAT NOT NULL,
+  rows_written_avg    FLOAT NOT NULL,
+  bytes_read_avg      FLOAT NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "access application statistics"); err != nil {
+			return err
+		}
+
+		sqlStats := p.extendedEvalCtx.sqlStatsCollector.sqlStats
+		if sqlStats == nil {
+			return errors.AssertionFailedf(
+				"cannot access sql statistics from this context")
+		}
+
+		nodeID := tree.NewDInt(tree.DInt(int64(p.execCfg.NodeID.Get())))
+
+		// Retrieve the application names and sort them to ensure the
+		// output is deterministic.
+		var appNames []string
+		sqlStats.Lock()
+		for n := range sqlStats.apps {
+			appNames = append(appNames, n)
+		}
+		sqlStats.Unlock()
+		sort.Strings(appNames)
+
+		for _, appName := range appNames {
+			appStats := sqlStats.getStatsForApplication(appName)
+
+			// Retrieve the fingerprints and sort them to ensure the output is
+			// deterministic.
+			var keys []txnKey
+			appStats.Lock()
+			for k := range appStats.txnFingerprints {
+				keys = append(keys, k)
+			}
+			appStats.Unlock()
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, key := range keys {
+				s := appStats.getStatsForTxnWithKey(key, nil, false /* createIfNonexistent */)
+				if s == nil {
+					// The statistics were cleared in the meantime.
+					continue
+				}
+				statements := tree.NewDArray(types.String)
+				for _, fingerprint := range s.stmtFingerprints {
+					if err := statements.Append(tree.NewDString(fingerprint)); err != nil {
+						return err
+					}
+				}
+				s.Lock()
+				data := s.data
+				s.Unlock()
+				err := addRow(
+					nodeID,
+					tree.NewDString(appName),
+					tree.NewDString(fmt.Sprintf("%016x", uint64(key))),
+					statements,
+					tree.NewDInt(tree.DInt(data.Count)),
+					tree.NewDInt(tree.DInt(data.CommittedCount)),
+					tree.NewDInt(tree.DInt(data.MaxRetries)),
+					tree.NewDFloat(tree.DFloat(data.NumRetries.Mean)),
+					tree.NewDFloat(tree.DFloat(data.ServiceLat.Mean)),
+					tree.NewDFloat(tree.DFloat(data.ServiceLat.GetVariance(data.Count))),
+					tree.NewDFloat(tree.DFloat(data.CommitLat.Mean)),
+					tree.NewDFloat(tree.DFloat(data.CommitLat.GetVariance(data.CommitLatCount))),
+					tree.NewDFloat(tree.DFloat(data.RowsRead.Mean)),
+					tree.NewDFloat(tree.DFloat(data.RowsWritten.Mean)),
+					tree.NewDFloat(tree.DFloat(data.BytesRead.Mean)),
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalTransactionStatisticsTable = virtualSchemaTable{
+	comment: `per-fingerprint transaction statistics flushed to system.transaction_statistics, aggregated across nodes by time bucket (KV scan)`,
+	schema: `
+CREATE TABLE crdb_internal.transaction_statistics (
+  aggregated_ts       TIMESTAMPTZ NOT NULL,
+  application_name    STRING NOT NULL,
+  fingerprint_id      STRING NOT NULL,
+  statements          STRING[] NOT NULL,
+  count               INT NOT NULL,
+  committed_count     INT NOT NULL,
+  max_retries         INT NOT NULL,
+  retries_avg         FLOAT NOT NULL,
+  service_lat_avg     FLOAT NOT NULL,
+  service_lat_var     FLOAT NOT NULL,
+  commit_lat_avg      FLOAT NOT NULL,
+  commit_lat_var      FLOAT NOT NULL,
+  rows_read_avg       FLOAT NOT NULL,
+  rows_written_avg    FLOAT NOT NULL,
+  bytes_read_avg      FLOAT NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "access application statistics"); err != nil {
+			return err
+		}
+		if !cluster.Version.IsActive(ctx, p.ExecCfg().Settings, cluster.VersionPersistedTxnStats) {
+			return nil
+		}
+
+		// The rows of the different nodes for the same fingerprint and time
+		// bucket are adjacent in the primary key order.
+		rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
+			ctx, "crdb-internal-transaction-statistics", p.txn,
+			sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
+			`SELECT aggregated_ts, app_name, fingerprint_id, statistics
+			FROM system.transaction_statistics
+			ORDER BY aggregated_ts, app_name, fingerprint_id`,
+		)
+		if err != nil {
+			return err
+		}
+
+		emit := func(row tree.Datums, s *roachpb.CollectedTransactionStatistics) error {
+			statements := tree.NewDArray(types.String)
+			for _, fingerprint := range s.StatementFingerprints {
+				if err := statements.Append(tree.NewDString(fingerprint)); err != nil {
+					return err
+				}
+			}
+			data := &s.Stats
+			return addRow(
+				row[0],
+				row[1],
+				tree.NewDString(fmt.Sprintf("%016x", s.FingerprintID)),
+				statements,
+				tree.NewDInt(tree.DInt(data.Count)),
+				tree.NewDInt(tree.DInt(data.CommittedCount)),
+				tree.NewDInt(tree.DInt(data.MaxRetries)),
+				tree.NewDFloat(tree.DFloat(data.NumRetries.Mean)),
+				tree.NewDFloat(tree.DFloat(data.ServiceLat.Mean)),
+				tree.NewDFloat(tree.DFloat(data.ServiceLat.GetVariance(data.Count))),
+				tree.NewDFloat(tree.DFloat(data.CommitLat.Mean)),
+				tree.NewDFloat(tree.DFloat(data.CommitLat.GetVariance(data.CommitLatCount))),
+				tree.NewDFloat(tree.DFloat(data.RowsRead.Mean)),
+				tree.NewDFloat(tree.DFloat(data.RowsWritten.Mean)),
+				tree.NewDFloat(tree.DFloat(data.BytesRead.Mean)),
+			)
+		}
+
+		var prevRow tree.Datums
+		var merged roachpb.CollectedTransactionStatistics
+		for _, row := range rows {
+			var s roachpb.CollectedTransactionStatistics
+			if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[3])), &s); err != nil {
+				return err
+			}
+			if prevRow != nil &&
+				row[0].Compare(p.EvalContext(), prevRow[0]) == 0 &&
+				row[1].Compare(p.EvalContext(), prevRow[1]) == 0 &&
+				row[2].Compare(p.EvalContext(), prevRow[2]) == 0 {
+				merged.Stats.Add(&s.Stats)
+				continue
+			}
+			if prevRow != nil {
+				if err := emit(prevRow, &merged); err != nil {
+					return err
+				}
+			}
+			prevRow, merged = row, s
+		}
+		if prevRow != nil {
+			return emit(prevRow, &merged)
+		}
+		return nil
+	},
+}
+
 var crdbInternalTxnStatsTable = virtualSchemaTable{
 	comment: `per-application transaction statistics (in-memory, not durable; local node only). ` +
 		`This table is wiped periodically (by default, at least every two hours)`,
@@ -993,20 +1423,245 @@ func populateQueriesTable(
 	return nil
 }
 
+// crdbInternalClusterLocksTable exposes the locks held on each range across
+// the cluster, along with the requests waiting on them. Each lock produces one
+// row for its holder (or reservation holder) and one row per waiter.
+var crdbInternalClusterLocksTable = virtualSchemaTable{
+	comment: "locks held and waited on, per range (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.cluster_locks (
+  node_id         INT NOT NULL,  -- the node that reported the lock
+  store_id        INT NOT NULL,  -- the store that reported the lock
+  range_id        INT NOT NULL,  -- the range containing the lock
+  lock_key        BYTES,         -- the locked key; NULL if keys are redacted
+  lock_key_pretty STRING,        -- the locked key, pretty-printed
+  txn_id          UUID,          -- the holder or waiter; NULL if non-transactional
+  ts              DECIMAL,       -- the write timestamp of the txn
+  lock_strength   STRING NOT NULL,
+  durability      STRING,        -- the durability of a held lock; NULL otherwise
+  granted         BOOL NOT NULL, -- true for the holder or reservation holder
+  reserved        BOOL NOT NULL, -- true if granted as a reservation only
+  active          BOOL NOT NULL, -- false for a waiter that is only queued
+  duration        INTERVAL NOT NULL -- how long the lock was held or waited on
+)`,
+	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.cluster_locks"); err != nil {
+			return err
+		}
+
+		response, err := p.ExecCfg().StatusServer.Locks(ctx, &serverpb.LocksRequest{})
+		if err != nil {
+			return err
+		}
+
+		nodeIDs := make([]roachpb.NodeID, 0, len(response.LocksByNodeID))
+		for nodeID := range response.LocksByNodeID {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+		sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i] < nodeIDs[j] })
+
+		for _, nodeID := range nodeIDs {
+			nodeResp := response.LocksByNodeID[nodeID]
+			if nodeResp.ErrorMessage != "" {
+				log.Warningf(ctx, "unable to retrieve locks from n%d: %s", nodeID, nodeResp.ErrorMessage)
+				continue
+			}
+			for _, storeResp := range nodeResp.Stores {
+				for _, r := range storeResp.Ranges {
+					for i := range r.Locks {
+						if err := populateLockRows(
+							addRow,
+							tree.NewDInt(tree.DInt(nodeID)),
+							tree.NewDInt(tree.DInt(storeResp.StoreID)),
+							tree.NewDInt(tree.DInt(r.RangeID)),
+							&r.Locks[i],
+						); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+		return nil
+	},
+}
+
+func populateLockRows(
+	addRow func(...tree.Datum) error,
+	nodeID, storeID, rangeID tree.Datum,
+	l *storagepb.LockStateInfo,
+) error {
+	lockKey, lockKeyPretty := tree.DNull, tree.DNull
+	if l.Key != nil {
+		lockKey = tree.NewDBytes(tree.DBytes(l.Key))
+		lockKeyPretty = tree.NewDString(keys.PrettyPrint(nil /* valDirs */, l.Key))
+	}
+	txnDatums := func(txn *enginepb.TxnMeta) (tree.Datum, tree.Datum) {
+		if txn == nil {
+			return tree.DNull, tree.DNull
+		}
+		return tree.NewDUuid(tree.DUuid{UUID: txn.ID}), tree.TimestampToDecimal(txn.WriteTimestamp)
+	}
+	intervalDatum := func(d time.Duration) tree.Datum {
+		return &tree.DInterval{Duration: duration.MakeDuration(d.Nanoseconds(), 0, 0)}
+	}
+
+	holder, reserved := l.LockHolder, false
+	durability := tree.Datum(tree.NewDString(l.Durability.String()))
+	if holder == nil {
+		holder, reserved, durability = l.ReservationHolder, true, tree.DNull
+	}
+	txnID, ts := txnDatums(holder)
+	if err := addRow(
+		nodeID,
+		storeID,
+		rangeID,
+		lockKey,
+		lockKeyPretty,
+		txnID,
+		ts,
+		tree.NewDString(l.Strength.String()),
+		durability,
+		tree.DBoolTrue, // granted
+		tree.MakeDBool(tree.DBool(reserved)),
+		tree.DBoolTrue, // active
+		intervalDatum(l.HoldDuration),
+	); err != nil {
+		return err
+	}
+
+	for _, w := range l.Waiters {
+		txnID, ts := txnDatums(w.WaitingTxn)
+		if err := addRow(
+			nodeID,
+			storeID,
+			rangeID,
+			lockKey,
+			lockKeyPretty,
+			txnID,
+			ts,
+			tree.NewDString(w.Strength.String()),
+			tree.DNull,      // durability
+			tree.DBoolFalse, // granted
+			tree.DBoolFalse, // reserved
+			tree.MakeDBool(tree.DBool(w.ActiveWaiter)),
+			intervalDatum(w.WaitDuration),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getClusterContentionSummary retrieves the contention events recorded over
+// the past hour across the cluster. Nodes that could not be reached are
+// logged and skipped.
+func getClusterContentionSummary(
+	ctx context.Context, p *planner, table string,
+) (storagepb.ContentionSummary, error) {
+	if err := p.RequireAdminRole(ctx, "read crdb_internal."+table); err != nil {
+		return storagepb.ContentionSummary{}, err
+	}
+	response, err := p.ExecCfg().StatusServer.ContentionEvents(ctx, &serverpb.ContentionEventsRequest{})
+	if err != nil {
+		return storagepb.ContentionSummary{}, err
+	}
+	for _, nodeErr := range response.Errors {
+		log.Warningf(ctx, "unable to retrieve contention events from n%d: %s", nodeErr.NodeID, nodeErr.Message)
+	}
+	return response.Summary, nil
+}
+
+// crdbInternalClusterContendedIndexesTable exposes the table indexes on which
+// requests spent the most time waiting across the cluster over the past hour.
+var crdbInternalClusterContendedIndexesTable = virtualSchemaTable{
+	comment: "contention per table index over the past hour (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.cluster_contended_indexes (
+  table_id                   INT NOT NULL,
+  index_id                   INT NOT NULL,
+  table_name                 STRING, -- NULL if the table was dropped
+  index_name                 STRING, -- NULL if the index was dropped
+  num_contention_events      INT NOT NULL,
+  cumulative_contention_time INTERVAL NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		summary, err := getClusterContentionSummary(ctx, p, "cluster_contended_indexes")
+		if err != nil {
+			return err
+		}
+		for _, idx := range summary.Indexes {
+			tableName, indexName := tree.DNull, tree.DNull
+			table, err := sqlbase.GetTableDescFromID(ctx, p.txn, sqlbase.ID(idx.TableID))
+			if err != nil && err != sqlbase.ErrDescriptorNotFound {
+				return err
+			}
+			if table != nil {
+				tableName = tree.NewDString(table.Name)
+				if index, err := table.FindIndexByID(sqlbase.IndexID(idx.IndexID)); err == nil {
+					indexName = tree.NewDString(index.Name)
+				}
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(idx.TableID)),
+				tree.NewDInt(tree.DInt(idx.IndexID)),
+				tableName,
+				indexName,
+				tree.NewDInt(tree.DInt(idx.NumContentionEvents)),
+				&tree.DInterval{Duration: duration.MakeDuration(idx.CumulativeContentionTime.Nanoseconds(), 0, 0)},
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// crdbInternalClusterContendingTxnsTable exposes the transactions on which
+// requests spent the most time waiting across the cluster over the past hour.
+var crdbInternalClusterContendingTxnsTable = virtualSchemaTable{
+	comment: "transactions that caused contention over the past hour (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.cluster_contending_transactions (
+  txn_id                     UUID NOT NULL, -- the transaction that was waited on
+  num_contention_events      INT NOT NULL,
+  cumulative_contention_time INTERVAL NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		summary, err := getClusterContentionSummary(ctx, p, "cluster_contending_transactions")
+		if err != nil {
+			return err
+		}
+		for _, txn := range summary.Txns {
+			if err := addRow(
+				tree.NewDUuid(tree.DUuid{UUID: txn.TxnID}),
+				tree.NewDInt(tree.DInt(txn.NumContentionEvents)),
+				&tree.DInterval{Duration: duration.MakeDuration(txn.CumulativeContentionTime.Nanoseconds(), 0, 0)},
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 const sessionsSchemaPattern = `
 CREATE TABLE crdb_internal.%s (
-  node_id            INT NOT NULL,   -- the node on which the query is running
-  session_id         STRING,         -- the ID of the session
-  user_name          STRING,         -- the user running the query
-  client_address     STRING,         -- the address of the client that issued the query
-  application_name   STRING,         -- the name of the application as per SET application_name
-  active_queries     STRING,         -- the currently running queries as SQL
-  last_active_query  STRING,         -- the query that finished last on this session as SQL
-  session_start      TIMESTAMP,      -- the time when the session was opened
-  oldest_query_start TIMESTAMP,      -- the time when the oldest query in the session was started
-  kv_txn             STRING,         -- the ID of the current KV transaction
-  alloc_bytes        INT,            -- the number of bytes allocated by the session
-  max_alloc_bytes    INT             -- the high water mark of bytes allocated by the session
+  node_id              INT NOT NULL,   -- the node on which the query is running
+  session_id           STRING,         -- the ID of the session
+  user_name            STRING,         -- the user running the query
+  client_address       STRING,         -- the address of the client that issued the query
+  application_name     STRING,         -- the name of the application as per SET application_name
+  active_queries       STRING,         -- the currently running queries as SQL
+  last_active_query    STRING,         -- the query that finished last on this session as SQL
+  session_start        TIMESTAMP,      -- the time when the session was opened
+  oldest_query_start   TIMESTAMP,      -- the time when the oldest query in the session was started
+  kv_txn               STRING,         -- the ID of the current KV transaction
+  alloc_bytes          INT,            -- the number of bytes allocated by the session
+  max_alloc_bytes      INT,            -- the high water mark of bytes allocated by the session
+  kv_bytes_read        INT,            -- the number of bytes read from KV by the session
+  exec_time_excl_waits INTERVAL,       -- the execution time, excluding waits, of the session's statements on all nodes
+  network_bytes        INT             -- the number of bytes sent between nodes by the session's statements
 )
 `
 
@@ -1086,6 +1741,9 @@ func populateSessionsTable(
 			kvTxnIDDatum,
 			tree.NewDInt(tree.DInt(session.AllocBytes)),
 			tree.NewDInt(tree.DInt(session.MaxAllocBytes)),
+			tree.NewDInt(tree.DInt(session.KVBytesRead)),
+			&tree.DInterval{Duration: duration.MakeDuration(session.ExecTimeExclWaits.Nanoseconds(), 0, 0)},
+			tree.NewDInt(tree.DInt(session.NetworkBytes)),
 		); err != nil {
 			return err
 		}
@@ -1109,6 +1767,9 @@ func populateSessionsTable(
 				tree.DNull,                             // kv_txn
 				tree.DNull,                             // alloc_bytes
 				tree.DNull,                             // max_alloc_bytes
+				tree.DNull,                             // kv_bytes_read
+				tree.DNull,                             // exec_time_excl_waits
+				tree.DNull,                             // network_bytes
 			); err != nil {
 				return err
 			}
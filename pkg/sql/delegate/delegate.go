@@ -72,6 +72,9 @@ func TryDelegate(
 	case *tree.ShowRanges:
 		return d.delegateShowRanges(t)
 
+	case *tree.ShowSchedules:
+		return d.delegateShowSchedules(t)
+
 	case *tree.ShowRangeForRow:
 		return d.delegateShowRangeForRow(t)
 
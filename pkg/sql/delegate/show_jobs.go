@@ -21,10 +21,30 @@ func (d *delegator) delegateShowJobs(n *tree.ShowJobs) (tree.Statement, error) {
 	const (
 		selectClause = `SELECT job_id, job_type, description, statement, user_name, status,
 				       running_status, created, started, finished, modified,
-				       fraction_completed, error, coordinator_id
-				FROM crdb_internal.jobs`
+				       fraction_completed, error, coordinator_id%s
+				FROM crdb_internal.jobs AS j`
+		// detailsColumns are the columns added by WITH DETAILS.
+		detailsColumns = `,
+				       (SELECT json_agg(json_build_object(
+				                 'node_id', pp.node_id,
+				                 'processor_id', pp.processor_id,
+				                 'processor', pp.processor,
+				                 'updated', pp.updated,
+				                 'fraction_completed', pp.fraction_completed,
+				                 'running_status', pp.running_status))
+				          FROM crdb_internal.job_processor_progress AS pp
+				         WHERE pp.job_id = j.job_id) AS processor_progress,
+				       (SELECT json_agg(json_build_object(
+				                 'written', m.written,
+				                 'kind', m.kind,
+				                 'message', m.message))
+				          FROM crdb_internal.job_messages AS m
+				         WHERE m.job_id = j.job_id) AS messages`
 	)
-	var typePredicate, whereClause, orderbyClause string
+	var details, typePredicate, whereClause, orderbyClause string
+	if n.WithDetails {
+		details = detailsColumns
+	}
 	if n.Jobs == nil {
 		// Display all [only automatic] jobs without selecting specific jobs.
 		if n.Automatic {
@@ -47,7 +67,7 @@ func (d *delegator) delegateShowJobs(n *tree.ShowJobs) (tree.Statement, error) {
 		whereClause = fmt.Sprintf(`WHERE job_id in (%s)`, n.Jobs.String())
 	}
 
-	sqlStmt := fmt.Sprintf("%s %s %s", selectClause, whereClause, orderbyClause)
+	sqlStmt := fmt.Sprintf("%s %s %s", fmt.Sprintf(selectClause, details), whereClause, orderbyClause)
 	if n.Block {
 		sqlStmt = fmt.Sprintf(
 			`SELECT * FROM [%s]
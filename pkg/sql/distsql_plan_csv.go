@@ -283,13 +283,29 @@ func DistIngest(
 		)
 	}
 
-	metaFn := func(_ context.Context, meta *execinfrapb.ProducerMetadata) error {
+	metaFn := func(ctx context.Context, meta *execinfrapb.ProducerMetadata) error {
 		if meta.BulkProcessorProgress != nil {
 			for i, v := range meta.BulkProcessorProgress.ResumePos {
 				atomic.StoreInt64(&rowProgress[i], v)
 			}
+			var processorFraction float32
 			for i, v := range meta.BulkProcessorProgress.CompletedFraction {
 				atomic.StoreUint32(&fractionProgress[i], math.Float32bits(v))
+				processorFraction += v
+			}
+			if n := len(meta.BulkProcessorProgress.CompletedFraction); n > 0 {
+				processorFraction /= float32(n)
+			}
+			if meta.BulkProcessorProgress.NodeID != 0 {
+				if err := job.RecordProcessorProgress(ctx, jobs.ProcessorProgress{
+					NodeID:            meta.BulkProcessorProgress.NodeID,
+					ProcessorID:       meta.BulkProcessorProgress.ProcessorID,
+					Processor:         "readImportData",
+					FractionCompleted: processorFraction,
+				}); err != nil {
+					log.Warningf(ctx, "failed to record progress of processor %d on node %d: %v",
+						meta.BulkProcessorProgress.ProcessorID, meta.BulkProcessorProgress.NodeID, err)
+				}
 			}
 
 			if alwaysFlushProgress {
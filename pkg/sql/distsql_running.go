@@ -16,6 +16,7 @@ import (
 	"math"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -463,15 +464,35 @@ type DistSQLReceiver struct {
 	// this node's clock.
 	updateClock func(observedTs hlc.Timestamp)
 
-	// bytesRead and rowsRead track the corresponding metrics while executing the
-	// statement.
-	bytesRead int64
-	rowsRead  int64
+	// stats tracks the resources consumed while executing the statement.
+	stats topLevelQueryStats
 
 	expectedRowsRead int64
 	progressAtomic   *uint64
 }
 
+// topLevelQueryStats describes the resources consumed by the execution of a
+// query, as reported by the metadata of its flows.
+type topLevelQueryStats struct {
+	// bytesRead and rowsRead are the bytes and rows read from KV by the table
+	// readers.
+	bytesRead int64
+	rowsRead  int64
+	// execTimeExclWaits is the execution time, excluding waits, of the
+	// processors of the flows on all the nodes. See execstats.ExecTimer.
+	execTimeExclWaits time.Duration
+	// networkBytes is the number of bytes sent by the outboxes of the flows.
+	networkBytes int64
+}
+
+// add adds the resources consumed by another query to s.
+func (s *topLevelQueryStats) add(other *topLevelQueryStats) {
+	s.bytesRead += other.bytesRead
+	s.rowsRead += other.rowsRead
+	s.execTimeExclWaits += other.execTimeExclWaits
+	s.networkBytes += other.networkBytes
+}
+
 // rowResultWriter is a subset of CommandResult to be used with the
 // DistSQLReceiver. It's implemented by RowResultWriter.
 type rowResultWriter interface {
@@ -650,10 +671,12 @@ func (r *DistSQLReceiver) Push(
 			}
 		}
 		if meta.Metrics != nil {
-			r.bytesRead += meta.Metrics.BytesRead
-			r.rowsRead += meta.Metrics.RowsRead
+			r.stats.bytesRead += meta.Metrics.BytesRead
+			r.stats.rowsRead += meta.Metrics.RowsRead
+			r.stats.execTimeExclWaits += time.Duration(meta.Metrics.ExecTimeExclWaitsNanos)
+			r.stats.networkBytes += meta.Metrics.NetworkBytes
 			if r.progressAtomic != nil && r.expectedRowsRead != 0 {
-				progress := float64(r.rowsRead) / float64(r.expectedRowsRead)
+				progress := float64(r.stats.rowsRead) / float64(r.expectedRowsRead)
 				atomic.StoreUint64(r.progressAtomic, math.Float64bits(progress))
 			}
 			meta.Metrics.Release()
@@ -896,6 +919,7 @@ func (dsp *DistSQLPlanner) planAndRunSubquery(
 	subqueryRecv.resultWriter = subqueryRowReceiver
 	subqueryPlans[planIdx].started = true
 	dsp.Run(subqueryPlanCtx, planner.txn, &subqueryPhysPlan, subqueryRecv, evalCtx, nil /* finishedSetupFn */)()
+	recv.stats.add(&subqueryRecv.stats)
 	if subqueryRecv.commErr != nil {
 		return subqueryRecv.commErr
 	}
@@ -1074,6 +1098,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	// but it may not be the case when we support cascades through the optimizer.
 	postqueryRecv.resultWriter = &errOnlyResultWriter{}
 	dsp.Run(postqueryPlanCtx, planner.txn, &postqueryPhysPlan, postqueryRecv, evalCtx, nil /* finishedSetupFn */)()
+	recv.stats.add(&postqueryRecv.stats)
 	if postqueryRecv.commErr != nil {
 		return postqueryRecv.commErr
 	}
@@ -24,7 +24,7 @@ import (
 )
 
 // This file contains facilities to report SQL activities to separate
-// log files.
+// log files. See also audit_policy.go for the audit policy.
 //
 // The log format is currently as follows:
 //
@@ -131,6 +131,10 @@ func (p *planner) maybeLogStatementInternal(
 	// Instead, make the logger work. This is critical for auditing - we
 	// can't miss any statement.
 
+	if p.curPlan.auditPolicyEvent != nil {
+		p.logAuditPolicyStatement(ctx, numRetries, rows, err, startTime)
+	}
+
 	logV := log.V(2)
 	logExecuteEnabled := logStatementsExecuteEnabled.Get(&p.execCfg.Settings.SV)
 	slowLogThreshold := slowQueryLogThreshold.Get(&p.execCfg.Settings.SV)
@@ -51,6 +51,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
@@ -543,6 +544,7 @@ type ExecutorConfig struct {
 	ExecLogger        *log.SecondaryLogger
 	AuditLogger       *log.SecondaryLogger
 	SlowQueryLogger   *log.SecondaryLogger
+	AuditPolicyLogger *log.SecondaryLogger
 	InternalExecutor  *InternalExecutor
 	QueryCache        *querycache.C
 
@@ -563,6 +565,16 @@ type ExecutorConfig struct {
 
 	// ProtectedTimestampProvider encapsulates the protected timestamp subsystem.
 	ProtectedTimestampProvider protectedts.Provider
+
+	// StmtDiagnosticsRecorder tracks the requests for statement diagnostics
+	// bundles and records the bundles collected for them.
+	StmtDiagnosticsRecorder *stmtdiagnostics.Registry
+
+	// LocalReadTimestamp returns the newest timestamp at which the replicas on
+	// this node can serve reads of all of the given spans, or false if some of
+	// the spans have no such replica. It is used to negotiate the timestamp of
+	// bounded staleness reads.
+	LocalReadTimestamp func(context.Context, roachpb.Spans) (hlc.Timestamp, bool)
 }
 
 // Organization returns the value of cluster.organization.
@@ -842,15 +854,29 @@ func checkResultType(typ *types.T) error {
 // EvalAsOfTimestamp evaluates and returns the timestamp from an AS OF SYSTEM
 // TIME clause.
 func (p *planner) EvalAsOfTimestamp(asOf tree.AsOfClause) (_ hlc.Timestamp, err error) {
-	ts, err := tree.EvalAsOfTimestamp(asOf, &p.semaCtx, p.EvalContext())
+	asOfSystemTime, err := p.evalAsOf(asOf)
 	if err != nil {
 		return hlc.Timestamp{}, err
 	}
-	if now := p.execCfg.Clock.Now(); now.Less(ts) {
-		return hlc.Timestamp{}, errors.Errorf(
-			"AS OF SYSTEM TIME: cannot specify timestamp in the future (%s > %s)", ts, now)
+	if asOfSystemTime.BoundedStaleness {
+		return hlc.Timestamp{}, tree.ErrBoundedStalenessNotAllowed
+	}
+	return asOfSystemTime.Timestamp, nil
+}
+
+// evalAsOf is like EvalAsOfTimestamp, but it also accepts bounded staleness
+// clauses.
+func (p *planner) evalAsOf(asOf tree.AsOfClause) (tree.AsOfSystemTime, error) {
+	asOfSystemTime, err := tree.EvalAsOf(asOf, &p.semaCtx, p.EvalContext())
+	if err != nil {
+		return tree.AsOfSystemTime{}, err
+	}
+	if now := p.execCfg.Clock.Now(); now.Less(asOfSystemTime.Timestamp) {
+		return tree.AsOfSystemTime{}, errors.Errorf(
+			"AS OF SYSTEM TIME: cannot specify timestamp in the future (%s > %s)",
+			asOfSystemTime.Timestamp, now)
 	}
-	return ts, nil
+	return asOfSystemTime, nil
 }
 
 // ParseHLC parses a string representation of an `hlc.Timestamp`.
@@ -874,12 +900,25 @@ func ParseHLC(s string) (hlc.Timestamp, error) {
 	return tree.DecimalToHLC(dec)
 }
 
+// setAsOf records the AS OF SYSTEM TIME of the statement being planned in the
+// semantic context. For bounded staleness reads, the staleness bound is also
+// the initial timestamp of the read.
+func (p *planner) setAsOf(asOf tree.AsOfSystemTime) {
+	ts := asOf.Timestamp
+	p.semaCtx.AsOfTimestamp = &ts
+	if asOf.BoundedStaleness {
+		minTS := asOf.Timestamp
+		p.semaCtx.AsOfMinTimestamp = &minTS
+	}
+}
+
 // isAsOf analyzes a statement to bypass the logic in newPlan(), since
 // that requires the transaction to be started already. If the returned
-// timestamp is not nil, it is the timestamp to which a transaction
-// should be set. The statements that will be checked are Select,
-// ShowTrace (of a Select statement), Scrub, Export, and CreateStats.
-func (p *planner) isAsOf(stmt tree.Statement) (*hlc.Timestamp, error) {
+// AS OF SYSTEM TIME is not nil, its timestamp is the timestamp to which a
+// transaction should be set. The statements that will be checked are Select,
+// ShowTrace (of a Select statement), Scrub, Export, and CreateStats. Only
+// selects may request a bounded staleness read.
+func (p *planner) isAsOf(stmt tree.Statement) (*tree.AsOfSystemTime, error) {
 	var asOf tree.AsOfClause
 	switch s := stmt.(type) {
 	case *tree.Select:
@@ -898,7 +937,8 @@ func (p *planner) isAsOf(stmt tree.Statement) (*hlc.Timestamp, error) {
 			return nil, nil
 		}
 
-		asOf = sc.From.AsOf
+		asOfSystemTime, err := p.evalAsOf(sc.From.AsOf)
+		return &asOfSystemTime, err
 	case *tree.Scrub:
 		if s.AsOf.Expr == nil {
 			return nil, nil
@@ -917,7 +957,7 @@ func (p *planner) isAsOf(stmt tree.Statement) (*hlc.Timestamp, error) {
 		return nil, nil
 	}
 	ts, err := p.EvalAsOfTimestamp(asOf)
-	return &ts, err
+	return &tree.AsOfSystemTime{Timestamp: ts}, err
 }
 
 // isSavepoint returns true if stmt is a SAVEPOINT statement.
@@ -1821,6 +1861,10 @@ func (m *sessionDataMutator) SetApplicationName(appName string) {
 	m.notifyOnDataChangeListeners("application_name", appName)
 }
 
+func (m *sessionDataMutator) SetTraceParent(traceParent string) {
+	m.data.TraceParent = traceParent
+}
+
 func (m *sessionDataMutator) SetBytesEncodeFormat(val sessiondata.BytesEncodeFormat) {
 	m.data.DataConversion.BytesEncodeFormat = val
 }
@@ -1967,16 +2011,27 @@ func (s *sqlStatsCollector) recordStatement(
 	numRows int,
 	err error,
 	parseLat, planLat, runLat, svcLat, ovhLat float64,
-	bytesRead, rowsRead int64,
+	stats *topLevelQueryStats,
 ) {
 	s.appStats.recordStatement(
 		stmt, samplePlanDescription, distSQLUsed, implicitTxn, automaticRetryCount, numRows, err,
-		parseLat, planLat, runLat, svcLat, ovhLat, bytesRead, rowsRead)
+		parseLat, planLat, runLat, svcLat, ovhLat, stats)
 }
 
-// recordTransaction records stats for one transaction.
-func (s *sqlStatsCollector) recordTransaction(txnTimeSec float64, ev txnEvent, implicit bool) {
-	s.appStats.recordTransaction(txnTimeSec, ev, implicit)
+// recordTransaction records stats for one transaction. summary describes the
+// statements of the last attempt of the transaction. commitLatSec is only
+// meaningful if commitTimed is set.
+func (s *sqlStatsCollector) recordTransaction(
+	txnTimeSec float64,
+	ev txnEvent,
+	implicit bool,
+	summary *txnSummary,
+	automaticRetryCount int,
+	commitLatSec float64,
+	commitTimed bool,
+) {
+	s.appStats.recordTransaction(
+		txnTimeSec, ev, implicit, summary, automaticRetryCount, commitLatSec, commitTimed)
 }
 
 func (s *sqlStatsCollector) reset(sqlStats *sqlStats, appStats *appStats, phaseTimes *phaseTimes) {
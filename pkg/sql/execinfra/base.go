@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -164,17 +165,29 @@ type RowSourcedProcessor interface {
 // Run reads records from the source and outputs them to the receiver, properly
 // draining the source of metadata and closing both the source and receiver.
 //
+// If ctx carries an execution timer (see execstats.WithExecTimer), the time
+// spent blocked pushing rows to dst is excluded from it, and the execution time
+// it accounted is pushed to dst as Metrics metadata once src has been drained.
+//
 // src needs to have been Start()ed before calling this.
 func Run(ctx context.Context, src RowSource, dst RowReceiver) {
+	timer := execstats.ExecTimerFromContext(ctx)
+	push := dst.Push
+	if r, ok := dst.(execTimedRowReceiver); ok && timer != nil {
+		push = func(row sqlbase.EncDatumRow, meta *execinfrapb.ProducerMetadata) ConsumerStatus {
+			return r.pushWithExecTimer(row, meta, timer)
+		}
+	}
 	for {
 		row, meta := src.Next()
 		// Emit the row; stop if no more rows are needed.
 		if row != nil || meta != nil {
-			switch dst.Push(row, meta) {
+			switch push(row, meta) {
 			case NeedMoreRows:
 				continue
 			case DrainRequested:
 				DrainAndForwardMetadata(ctx, src, dst)
+				pushExecTime(timer, dst)
 				dst.ProducerDone()
 				return
 			case ConsumerClosed:
@@ -184,11 +197,33 @@ func Run(ctx context.Context, src RowSource, dst RowReceiver) {
 			}
 		}
 		// row == nil && meta == nil: the source has been fully drained.
+		pushExecTime(timer, dst)
 		dst.ProducerDone()
 		return
 	}
 }
 
+// execTimedRowReceiver is implemented by the RowReceivers whose Push can block
+// on another goroutine, so that the time spent blocked can be excluded from the
+// execution time of the pushing goroutine.
+type execTimedRowReceiver interface {
+	pushWithExecTimer(
+		row sqlbase.EncDatumRow, meta *execinfrapb.ProducerMetadata, timer *execstats.ExecTimer,
+	) ConsumerStatus
+}
+
+// pushExecTime pushes the execution time accounted by timer to dst, if timer
+// is set.
+func pushExecTime(timer *execstats.ExecTimer, dst RowReceiver) {
+	if timer == nil {
+		return
+	}
+	meta := execinfrapb.GetProducerMeta()
+	meta.Metrics = execinfrapb.GetMetricsMeta()
+	meta.Metrics.ExecTimeExclWaitsNanos = timer.Elapsed().Nanoseconds()
+	dst.Push(nil /* row */, meta)
+}
+
 // Releasable is an interface for objects than can be Released back into a
 // memory pool when finished.
 type Releasable interface {
@@ -415,6 +450,10 @@ type RowChannel struct {
 	// numSenders is an atomic counter that keeps track of how many senders have
 	// yet to call ProducerDone().
 	numSenders int32
+
+	// execTimer is the execution timer of the consumer, set in Start. The time
+	// the consumer spends waiting for rows is recorded in it.
+	execTimer *execstats.ExecTimer
 }
 
 var _ RowReceiver = &RowChannel{}
@@ -440,16 +479,23 @@ func (rc *RowChannel) InitWithBufSizeAndNumSenders(types []types.T, chanBufSize,
 // Push is part of the RowReceiver interface.
 func (rc *RowChannel) Push(
 	row sqlbase.EncDatumRow, meta *execinfrapb.ProducerMetadata,
+) ConsumerStatus {
+	return rc.pushWithExecTimer(row, meta, nil /* timer */)
+}
+
+// pushWithExecTimer is part of the execTimedRowReceiver interface.
+func (rc *RowChannel) pushWithExecTimer(
+	row sqlbase.EncDatumRow, meta *execinfrapb.ProducerMetadata, timer *execstats.ExecTimer,
 ) ConsumerStatus {
 	consumerStatus := ConsumerStatus(
 		atomic.LoadUint32((*uint32)(&rc.ConsumerStatus)))
 	switch consumerStatus {
 	case NeedMoreRows:
-		rc.dataChan <- RowChannelMsg{Row: row, Meta: meta}
+		rc.send(RowChannelMsg{Row: row, Meta: meta}, timer)
 	case DrainRequested:
 		// If we're draining, only forward metadata.
 		if meta != nil {
-			rc.dataChan <- RowChannelMsg{Meta: meta}
+			rc.send(RowChannelMsg{Meta: meta}, timer)
 		}
 	case ConsumerClosed:
 		// If the consumer is gone, swallow all the rows and the metadata.
@@ -457,6 +503,22 @@ func (rc *RowChannel) Push(
 	return consumerStatus
 }
 
+// send sends msg on the channel, recording the time spent blocked on a full
+// channel in timer, if set.
+func (rc *RowChannel) send(msg RowChannelMsg, timer *execstats.ExecTimer) {
+	if timer == nil {
+		rc.dataChan <- msg
+		return
+	}
+	select {
+	case rc.dataChan <- msg:
+	default:
+		start := timer.StartWait()
+		rc.dataChan <- msg
+		timer.RecordWait(start)
+	}
+}
+
 // ProducerDone is part of the RowReceiver interface.
 func (rc *RowChannel) ProducerDone() {
 	newVal := atomic.AddInt32(&rc.numSenders, -1)
@@ -474,11 +536,26 @@ func (rc *RowChannel) OutputTypes() []types.T {
 }
 
 // Start is part of the RowSource interface.
-func (rc *RowChannel) Start(ctx context.Context) context.Context { return ctx }
+func (rc *RowChannel) Start(ctx context.Context) context.Context {
+	rc.execTimer = execstats.ExecTimerFromContext(ctx)
+	return ctx
+}
 
 // Next is part of the RowSource interface.
 func (rc *RowChannel) Next() (sqlbase.EncDatumRow, *execinfrapb.ProducerMetadata) {
-	d, ok := <-rc.C
+	var d RowChannelMsg
+	var ok bool
+	if rc.execTimer == nil {
+		d, ok = <-rc.C
+	} else {
+		select {
+		case d, ok = <-rc.C:
+		default:
+			start := rc.execTimer.StartWait()
+			d, ok = <-rc.C
+			rc.execTimer.RecordWait(start)
+		}
+	}
 	if !ok {
 		// No more rows.
 		return nil, nil
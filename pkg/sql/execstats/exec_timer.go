@@ -0,0 +1,78 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package execstats contains the utilities used to account for the resources
+// consumed by the execution of SQL statements.
+package execstats
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// ExecTimer measures the execution time, excluding waits, of the goroutine
+// running a processor of a flow: the wall time elapsed since the timer was
+// created minus the time during which the goroutine reported being blocked,
+// waiting for KV requests, or waiting to receive rows from or push rows to
+// other goroutines.
+//
+// This is not CPU time, which Go doesn't expose per goroutine: the waits that
+// aren't reported (on locks, disk, or the Go scheduler) are included.
+type ExecTimer struct {
+	start time.Time
+	// waitNanos is the total time spent blocked, accessed atomically since
+	// goroutines spawned by the processor can share its context.
+	waitNanos int64
+}
+
+type execTimerKey struct{}
+
+// WithExecTimer returns a context carrying a new ExecTimer, started now.
+func WithExecTimer(ctx context.Context) context.Context {
+	return context.WithValue(ctx, execTimerKey{}, &ExecTimer{start: timeutil.Now()})
+}
+
+// ExecTimerFromContext returns the ExecTimer carried by ctx, or nil.
+func ExecTimerFromContext(ctx context.Context) *ExecTimer {
+	t, _ := ctx.Value(execTimerKey{}).(*ExecTimer)
+	return t
+}
+
+// StartWait returns the time at which a wait is starting, to be passed to
+// RecordWait when it ends. It returns the zero time on a nil timer, to avoid
+// reading the clock when the resources aren't being accounted.
+func (t *ExecTimer) StartWait() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return timeutil.Now()
+}
+
+// RecordWait records that the goroutine was blocked since start, as returned
+// by StartWait. It is a no-op on a nil timer.
+func (t *ExecTimer) RecordWait(start time.Time) {
+	if t == nil {
+		return
+	}
+	atomic.AddInt64(&t.waitNanos, int64(timeutil.Since(start)))
+}
+
+// Elapsed returns the execution time, excluding waits, since the timer was
+// created.
+func (t *ExecTimer) Elapsed() time.Duration {
+	d := timeutil.Since(t.start) - time.Duration(atomic.LoadInt64(&t.waitNanos))
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execstats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+func TestExecTimer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// A context without a timer doesn't record anything.
+	nilTimer := ExecTimerFromContext(context.Background())
+	if nilTimer != nil {
+		t.Fatalf("unexpected timer %v", nilTimer)
+	}
+	if start := nilTimer.StartWait(); !start.IsZero() {
+		t.Fatalf("expected zero start time, got %s", start)
+	}
+	nilTimer.RecordWait(time.Time{})
+
+	before := timeutil.Now()
+	timer := ExecTimerFromContext(WithExecTimer(context.Background()))
+	if timer == nil {
+		t.Fatal("expected a timer")
+	}
+	start := timer.StartWait()
+	time.Sleep(10 * time.Millisecond)
+	timer.RecordWait(start)
+
+	elapsed := timer.Elapsed()
+	if elapsed < 0 || elapsed > timeutil.Since(before)-10*time.Millisecond {
+		t.Fatalf("expected the wait to be excluded from the execution time, got %s", elapsed)
+	}
+}
@@ -48,8 +48,10 @@ const (
 	plannerEndExecStmt      // Execution ends.
 
 	// Transaction phases.
-	transactionStart // Transaction starts.
-	transactionEnd   // Transaction ends.
+	transactionStart       // Transaction starts.
+	transactionStartCommit // Transaction commit starts.
+	transactionEndCommit   // Transaction commit ends.
+	transactionEnd         // Transaction ends.
 
 	// sessionNumPhases must be listed last so that it can be used to
 	// define arrays sufficiently large to hold all the other values.
@@ -107,8 +109,7 @@ func (ex *connExecutor) recordStatementSummary(
 	automaticRetryCount int,
 	rowsAffected int,
 	err error,
-	bytesRead int64,
-	rowsRead int64,
+	stats topLevelQueryStats,
 ) {
 	phaseTimes := &ex.statsCollector.phaseTimes
 
@@ -153,8 +154,12 @@ func (ex *connExecutor) recordStatementSummary(
 		stmt, planner.curPlan.instrumentation.savedPlanForStats,
 		flags.IsSet(planFlagDistributed), flags.IsSet(planFlagImplicitTxn),
 		automaticRetryCount, rowsAffected, err,
-		parseLat, planLat, runLat, svcLat, execOverhead, bytesRead, rowsRead,
+		parseLat, planLat, runLat, svcLat, execOverhead, &stats,
 	)
+	ex.extraTxnState.txnSummary.addStatement(stmt, rowsAffected, &stats)
+	ex.mu.Lock()
+	ex.mu.resourceUsage.add(&stats)
+	ex.mu.Unlock()
 
 	if log.V(2) {
 		// ages since significant epochs
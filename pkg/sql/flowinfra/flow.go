@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -313,7 +314,7 @@ func (f *FlowBase) startInternal(
 	for i := 0; i < len(processors); i++ {
 		f.waitGroup.Add(1)
 		go func(i int) {
-			processors[i].Run(ctx)
+			processors[i].Run(execstats.WithExecTimer(ctx))
 			f.waitGroup.Done()
 		}(i)
 	}
@@ -367,7 +368,7 @@ func (f *FlowBase) Run(ctx context.Context, doneFn func()) error {
 		}
 		return err
 	}
-	headProc.Run(ctx)
+	headProc.Run(execstats.WithExecTimer(ctx))
 	return nil
 }
 
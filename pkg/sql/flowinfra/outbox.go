@@ -155,9 +155,7 @@ func (m *Outbox) flush(ctx context.Context) error {
 		return nil
 	}
 	msg := m.encoder.FormMessage(ctx)
-	if m.statsCollectionEnabled {
-		m.stats.BytesSent += int64(msg.Size())
-	}
+	m.stats.BytesSent += int64(msg.Size())
 
 	if log.V(3) {
 		log.Infof(ctx, "flushing outbox")
@@ -278,7 +276,13 @@ func (m *Outbox) mainLoop(ctx context.Context) error {
 		select {
 		case msg, ok := <-m.RowChannel.C:
 			if !ok {
-				// No more data.
+				// No more data. Report the bytes sent on the stream, which doesn't
+				// include the messages still to be sent.
+				metrics := execinfrapb.GetMetricsMeta()
+				metrics.NetworkBytes = m.stats.BytesSent
+				if err := m.addRow(ctx, nil, &execinfrapb.ProducerMetadata{Metrics: metrics}); err != nil {
+					return err
+				}
 				if m.statsCollectionEnabled {
 					err := m.flush(ctx)
 					if err != nil {
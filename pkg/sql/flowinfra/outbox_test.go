@@ -178,6 +178,11 @@ func TestOutbox(t *testing.T) {
 		t.Fatalf("%+v", err)
 	}
 
+	// The outbox reports the bytes it sent in its last metadata record.
+	if n := len(metas); n == 0 || metas[n-1].Metrics == nil || metas[n-1].Metrics.NetworkBytes <= 0 {
+		t.Fatalf("expected the last metadata record to report the bytes sent, got: %+v", metas)
+	}
+	metas = metas[:len(metas)-1]
 	if len(metas) != 2 {
 		t.Fatalf("expected 2 metadata records, got: %d", len(metas))
 	}
@@ -0,0 +1,28 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// checkNotOnlineRestoring returns an error if the table is being restored
+// online: until its data is ingested, the table can be read but not modified.
+func checkNotOnlineRestoring(tableDesc *sqlbase.TableDescriptor) error {
+	if tableDesc.OnlineRestoreJobID == 0 {
+		return nil
+	}
+	return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+		"table %q is being restored online by job %d and cannot be modified until its data is ingested",
+		tableDesc.Name, tableDesc.OnlineRestoreJobID)
+}
@@ -65,6 +65,8 @@ func buildOpaque(
 		plan, err = p.CommentOnIndex(ctx, n)
 	case *tree.CommentOnTable:
 		plan, err = p.CommentOnTable(ctx, n)
+	case *tree.ControlSchedules:
+		plan, err = p.ControlSchedules(ctx, n)
 	case *tree.CreateDatabase:
 		plan, err = p.CreateDatabase(ctx, n)
 	case *tree.CreateIndex:
@@ -163,6 +165,7 @@ func init() {
 		&tree.CommentOnDatabase{},
 		&tree.CommentOnIndex{},
 		&tree.CommentOnTable{},
+		&tree.ControlSchedules{},
 		&tree.CreateDatabase{},
 		&tree.CreateIndex{},
 		&tree.CreateUser{},
@@ -199,7 +202,9 @@ func init() {
 		&tree.Truncate{},
 
 		// CCL statements (without Export which has an optimizer operator).
+		&tree.AlterBackup{},
 		&tree.Backup{},
+		&tree.ScheduledBackup{},
 		&tree.ShowBackup{},
 		&tree.Restore{},
 		&tree.CreateChangefeed{},
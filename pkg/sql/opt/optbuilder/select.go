@@ -1137,7 +1137,7 @@ func (b *Builder) buildFromWithLateral(
 // validateAsOf ensures that any AS OF SYSTEM TIME timestamp is consistent with
 // that of the root statement.
 func (b *Builder) validateAsOf(asOf tree.AsOfClause) {
-	ts, err := tree.EvalAsOfTimestamp(asOf, b.semaCtx, b.evalCtx)
+	asOfSystemTime, err := tree.EvalAsOf(asOf, b.semaCtx, b.evalCtx)
 	if err != nil {
 		panic(err)
 	}
@@ -1147,7 +1147,19 @@ func (b *Builder) validateAsOf(asOf tree.AsOfClause) {
 			"AS OF SYSTEM TIME must be provided on a top-level statement"))
 	}
 
-	if *b.semaCtx.AsOfTimestamp != ts {
+	// The timestamp of a bounded staleness read is negotiated at execution
+	// time, so only its lower bound can be compared.
+	expected := b.semaCtx.AsOfTimestamp
+	if asOfSystemTime.BoundedStaleness {
+		if b.semaCtx.AsOfMinTimestamp == nil {
+			panic(tree.ErrBoundedStalenessNotAllowed)
+		}
+		expected = b.semaCtx.AsOfMinTimestamp
+	} else if b.semaCtx.AsOfMinTimestamp != nil {
+		expected = nil
+	}
+
+	if expected == nil || *expected != asOfSystemTime.Timestamp {
 		panic(unimplementedWithIssueDetailf(35712, "",
 			"cannot specify AS OF SYSTEM TIME with different timestamps"))
 	}
@@ -871,8 +871,9 @@ func (ef *execFactory) ConstructPlan(
 		plan: root.(planNode),
 		// TODO(radu): these fields can be modified by planning various opaque
 		// statements. We should have a cleaner way of plumbing these.
-		avoidBuffering: ef.planner.curPlan.avoidBuffering,
-		auditEvents:    ef.planner.curPlan.auditEvents,
+		avoidBuffering:         ef.planner.curPlan.avoidBuffering,
+		auditEvents:            ef.planner.curPlan.auditEvents,
+		auditPolicyDatabaseIDs: ef.planner.curPlan.auditPolicyDatabaseIDs,
 	}
 	if len(subqueries) > 0 {
 		res.subqueryPlans = make([]subquery, len(subqueries))
@@ -1211,6 +1212,9 @@ func (ef *execFactory) ConstructInsert(
 	// Derive insert table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkNotOnlineRestoring(tabDesc.TableDesc()); err != nil {
+		return nil, err
+	}
 	colDescs := makeColDescList(table, insertColOrdSet)
 
 	var fkTables row.FkTableMetadata
@@ -1283,6 +1287,9 @@ func (ef *execFactory) ConstructInsertFastPath(
 	// Derive insert table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkNotOnlineRestoring(tabDesc.TableDesc()); err != nil {
+		return nil, err
+	}
 	colDescs := makeColDescList(table, insertColOrdSet)
 
 	// Create the table inserter, which does the bulk of the work.
@@ -1360,6 +1367,9 @@ func (ef *execFactory) ConstructUpdate(
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkNotOnlineRestoring(tabDesc.TableDesc()); err != nil {
+		return nil, err
+	}
 	fetchColDescs := makeColDescList(table, fetchColOrdSet)
 
 	// Add each column to update as a sourceSlot. The CBO only uses scalarSlot,
@@ -1513,6 +1523,9 @@ func (ef *execFactory) ConstructUpsert(
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkNotOnlineRestoring(tabDesc.TableDesc()); err != nil {
+		return nil, err
+	}
 	insertColDescs := makeColDescList(table, insertColOrdSet)
 	fetchColDescs := makeColDescList(table, fetchColOrdSet)
 	updateColDescs := makeColDescList(table, updateColOrdSet)
@@ -1633,6 +1646,9 @@ func (ef *execFactory) ConstructDelete(
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkNotOnlineRestoring(tabDesc.TableDesc()); err != nil {
+		return nil, err
+	}
 	fetchColDescs := makeColDescList(table, fetchColOrdSet)
 
 	// Determine the foreign key tables involved in the delete.
@@ -1719,6 +1735,9 @@ func (ef *execFactory) ConstructDeleteRange(
 	allowAutoCommit bool,
 ) (exec.Node, error) {
 	tabDesc := table.(*optTable).desc
+	if err := checkNotOnlineRestoring(tabDesc.TableDesc()); err != nil {
+		return nil, err
+	}
 	indexDesc := &tabDesc.PrimaryIndex
 	sb := span.MakeBuilder(tabDesc.TableDesc(), indexDesc)
 
@@ -63,6 +63,9 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER SEQUENCE blah RENAME ??`, `ALTER SEQUENCE`},
 		{`ALTER SEQUENCE blah RENAME TO blih ??`, `ALTER SEQUENCE`},
 
+		{`ALTER BACKUP ??`, `ALTER BACKUP`},
+		{`ALTER BACKUP 'foo' ADD ??`, `ALTER BACKUP`},
+
 		{`ALTER USER IF ??`, `ALTER USER`},
 		{`ALTER USER foo WITH PASSWORD ??`, `ALTER USER`},
 
@@ -117,6 +120,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE STATISTICS ??`, `CREATE STATISTICS`},
 
+		{`CREATE SCHEDULE ??`, `CREATE SCHEDULE FOR BACKUP`},
+		{`CREATE SCHEDULE FOR BACKUP ??`, `CREATE SCHEDULE FOR BACKUP`},
+
 		{`CREATE TABLE blah (??`, `CREATE TABLE`},
 		{`CREATE TABLE IF NOT ??`, `CREATE TABLE`},
 		{`CREATE TABLE blah (x, y) AS ??`, `CREATE TABLE`},
@@ -161,6 +167,9 @@ func TestContextualHelp(t *testing.T) {
 		{`DROP USER IF ??`, `DROP USER`},
 		{`DROP USER IF EXISTS bloh ??`, `DROP USER`},
 
+		{`DROP SCHEDULE ??`, `DROP SCHEDULES`},
+		{`DROP SCHEDULES ??`, `DROP SCHEDULES`},
+
 		{`EXPLAIN (??`, `EXPLAIN`},
 		{`EXPLAIN SELECT 1 ??`, `SELECT`},
 		{`EXPLAIN INSERT INTO xx (SELECT 1) ??`, `INSERT`},
@@ -209,9 +218,13 @@ func TestContextualHelp(t *testing.T) {
 		{`GRANT ALL ON foo TO ??`, `GRANT`},
 		{`GRANT ALL ON foo TO bar ??`, `GRANT`},
 
-		{`PAUSE ??`, `PAUSE JOBS`},
+		{`PAUSE ??`, `PAUSE`},
+		{`PAUSE JOB ??`, `PAUSE JOBS`},
+		{`PAUSE SCHEDULE ??`, `PAUSE SCHEDULES`},
 
-		{`RESUME ??`, `RESUME JOBS`},
+		{`RESUME ??`, `RESUME`},
+		{`RESUME JOBS ??`, `RESUME JOBS`},
+		{`RESUME SCHEDULES ??`, `RESUME SCHEDULES`},
 
 		{`REVOKE ALL ??`, `REVOKE`},
 		{`REVOKE ALL ON foo FROM ??`, `REVOKE`},
@@ -251,7 +264,12 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW JOBS ??`, `SHOW JOBS`},
 		{`SHOW AUTOMATIC JOBS ??`, `SHOW JOBS`},
 
+		{`SHOW SCHEDULE ??`, `SHOW SCHEDULES`},
+		{`SHOW SCHEDULES ??`, `SHOW SCHEDULES`},
+
 		{`SHOW BACKUP 'foo' ??`, `SHOW BACKUP`},
+		{`SHOW BACKUPS ??`, `SHOW BACKUP`},
+		{`SHOW BACKUP 'foo' IN ??`, `SHOW BACKUP`},
 
 		{`SHOW CLUSTER SETTING all ??`, `SHOW CLUSTER SETTING`},
 		{`SHOW ALL CLUSTER ??`, `SHOW CLUSTER SETTING`},
@@ -373,8 +391,10 @@ func TestContextualHelp(t *testing.T) {
 		{`BACKUP foo TO 'bar' ??`, `BACKUP`},
 		{`BACKUP DATABASE ??`, `BACKUP`},
 		{`BACKUP foo TO 'bar' AS OF ??`, `BACKUP`},
+		{`BACKUP foo INTO 'bar' IN ??`, `BACKUP`},
 
 		{`RESTORE foo FROM 'bar' ??`, `RESTORE`},
+		{`RESTORE foo FROM 'bar' IN ??`, `RESTORE`},
 		{`RESTORE DATABASE ??`, `RESTORE`},
 
 		{`IMPORT TABLE foo CREATE USING 'foo.sql' CSV DATA ('foo') ??`, `IMPORT`},
@@ -54,6 +54,11 @@ type Statement struct {
 	// NumAnnotations indicates the number of annotations in the tree. It is equal
 	// to the maximum annotation index.
 	NumAnnotations tree.AnnotationIdx
+
+	// Comments are the block comments preceding, within or following the
+	// statement, before the next statement. They are not part of SQL, and
+	// may carry metadata from the client, e.g. a trace context.
+	Comments []string
 }
 
 // Statements is a list of parsed statements.
@@ -152,12 +157,14 @@ func (p *Parser) parseWithDepth(depth int, sql string, nakedIntType *types.T) (S
 	p.scanner.init(sql)
 	defer p.scanner.cleanup()
 	for {
+		p.scanner.comments = nil
 		sql, tokens, done := p.scanOneStmt()
 		stmt, err := p.parse(depth+1, sql, tokens, nakedIntType)
 		if err != nil {
 			return nil, err
 		}
 		if stmt.AST != nil {
+			stmt.Comments = p.scanner.comments
 			stmts = append(stmts, stmt)
 		}
 		if done {
@@ -390,6 +390,7 @@ func TestParse(t *testing.T) {
 		{`EXPLAIN SHOW JOBS SELECT a`},
 		{`SHOW JOBS WHEN COMPLETE SELECT a`},
 		{`EXPLAIN SHOW JOBS WHEN COMPLETE SELECT a`},
+		{`SHOW JOBS SELECT a WITH DETAILS`},
 
 		{`EXPLAIN SELECT 1`},
 		{`EXPLAIN EXPLAIN SELECT 1`},
@@ -1335,6 +1336,11 @@ func TestParse(t *testing.T) {
 		{`SHOW BACKUP RANGES 'bar'`},
 		{`SHOW BACKUP FILES 'bar'`},
 		{`SHOW BACKUP FILES 'bar' WITH foo = 'bar'`},
+		{`SHOW BACKUP 'bar' WITH check_files`},
+		{`SHOW BACKUPS IN 'bar'`},
+		{`SHOW BACKUPS IN $1`},
+		{`SHOW BACKUP 'latest' IN 'bar'`},
+		{`SHOW BACKUP $1 IN $2 WITH foo = 'bar'`},
 
 		{`BACKUP TABLE foo TO 'bar' AS OF SYSTEM TIME '1' INCREMENTAL FROM 'baz'`},
 		{`BACKUP TABLE foo TO $1 INCREMENTAL FROM 'bar', $2, 'baz'`},
@@ -1347,6 +1353,15 @@ func TestParse(t *testing.T) {
 		{`BACKUP DATABASE foo TO ($1, $2)`},
 		{`BACKUP DATABASE foo TO ($1, $2) INCREMENTAL FROM 'baz'`},
 
+		{`BACKUP DATABASE foo INTO 'bar'`},
+		{`BACKUP DATABASE foo INTO 'bar' AS OF SYSTEM TIME '1' WITH revision_history`},
+		{`BACKUP TABLE foo INTO 'latest' IN 'bar'`},
+		{`BACKUP TABLE foo TO 'bar' WITH kms = 'local-kms:///key'`},
+		{`ALTER BACKUP 'foo' ADD NEW_KMS = 'local-kms:///new' WITH OLD_KMS = 'local-kms:///old'`},
+		{`ALTER BACKUP $1 IN $2 ADD NEW_KMS = $3 WITH OLD_KMS = $4`},
+		{`BACKUP TABLE foo INTO $1 IN ($2, $3)`},
+		{`EXPLAIN BACKUP DATABASE foo INTO 'bar'`},
+
 		{`RESTORE TABLE foo FROM 'bar'`},
 		{`EXPLAIN RESTORE TABLE foo FROM 'bar'`},
 		{`RESTORE TABLE foo FROM $1`},
@@ -1365,7 +1380,24 @@ func TestParse(t *testing.T) {
 		{`RESTORE DATABASE foo FROM ($1, $2), ($3, $4)`},
 		{`RESTORE DATABASE foo FROM ($1, $2), ($3, $4) AS OF SYSTEM TIME '1'`},
 
+		{`RESTORE TABLE foo FROM 'latest' IN 'bar'`},
+		{`RESTORE DATABASE foo FROM $1 IN ($2, $3) AS OF SYSTEM TIME '1'`},
+		{`RESTORE TABLE foo FROM '2020/01/02-150405.00' IN 'bar' WITH into_db = 'baz'`},
+		{`RESTORE DATABASE foo FROM 'bar' WITH new_db_name = 'baz'`},
+		{`RESTORE TABLE foo.bar, foo.baz FROM 'bar' WITH new_table_names = 'bar=bar2,foo.baz=baz2'`},
+		{`RESTORE TABLE foo.bar FROM 'bar' WITH online`},
+
 		{`BACKUP TABLE foo TO 'bar' WITH key1, key2 = 'value'`},
+
+		{`CREATE SCHEDULE FOR BACKUP TO 'bar' RECURRING '@daily'`},
+		{`CREATE SCHEDULE 'foo' FOR BACKUP TABLE foo, bar TO 'baz' RECURRING '@hourly' FULL BACKUP '@daily'`},
+		{`CREATE SCHEDULE $1 FOR BACKUP DATABASE foo TO $2 WITH revision_history RECURRING $3 FULL BACKUP ALWAYS`},
+		{`SHOW SCHEDULES`},
+		{`SHOW SCHEDULE $1`},
+		{`PAUSE SCHEDULES SELECT a`},
+		{`RESUME SCHEDULES SELECT a`},
+		{`DROP SCHEDULES SELECT a`},
+		{`EXPLAIN PAUSE SCHEDULES SELECT a`},
 		{`RESTORE TABLE foo FROM 'bar' WITH key1, key2 = 'value'`},
 
 		{`IMPORT TABLE foo CREATE USING 'nodelocal:///some/file' CSV DATA ('path/to/some/file', $1) WITH temp = 'path/to/temp'`},
@@ -1874,10 +1906,14 @@ func TestParse2(t *testing.T) {
 		{`EXPLAIN RESUME JOB a`, `EXPLAIN RESUME JOBS VALUES (a)`},
 		{`PAUSE JOB a`, `PAUSE JOBS VALUES (a)`},
 		{`EXPLAIN PAUSE JOB a`, `EXPLAIN PAUSE JOBS VALUES (a)`},
+		{`PAUSE SCHEDULE a`, `PAUSE SCHEDULES VALUES (a)`},
+		{`RESUME SCHEDULE a`, `RESUME SCHEDULES VALUES (a)`},
+		{`DROP SCHEDULE a`, `DROP SCHEDULES VALUES (a)`},
 		{`SHOW JOB a`, `SHOW JOBS VALUES (a)`},
 		{`EXPLAIN SHOW JOB a`, `EXPLAIN SHOW JOBS VALUES (a)`},
 		{`SHOW JOB WHEN COMPLETE a`, `SHOW JOBS WHEN COMPLETE VALUES (a)`},
 		{`EXPLAIN SHOW JOB WHEN COMPLETE a`, `EXPLAIN SHOW JOBS WHEN COMPLETE VALUES (a)`},
+		{`SHOW JOB a WITH DETAILS`, `SHOW JOBS VALUES (a) WITH DETAILS`},
 		{`CANCEL QUERY a`, `CANCEL QUERIES VALUES (a)`},
 		{`CANCEL QUERY IF EXISTS a`, `CANCEL QUERIES IF EXISTS VALUES (a)`},
 		{`CANCEL SESSION a`, `CANCEL SESSIONS VALUES (a)`},
@@ -3361,6 +3397,26 @@ func TestParseOne(t *testing.T) {
 	}
 }
 
+func TestParseComments(t *testing.T) {
+	stmts, err := parser.Parse(`/* a */ SELECT /* b */ 1 -- c
+/* d */; /* e */ SELECT 2 /* f /* g */ */`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{
+		{"/* a */", "/* b */", "/* d */"},
+		{"/* e */", "/* f /* g */ */"},
+	}
+	if len(stmts) != len(expected) {
+		t.Fatalf("expected %d statements, got %d", len(expected), len(stmts))
+	}
+	for i, stmt := range stmts {
+		if !reflect.DeepEqual(stmt.Comments, expected[i]) {
+			t.Errorf("%d: expected comments %q, got %q", i, expected[i], stmt.Comments)
+		}
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	testCases := []struct {
 		name, query string
@@ -35,6 +35,9 @@ type scanner struct {
 	in            string
 	pos           int
 	bytesPrealloc []byte
+	// comments are the block comments scanned since it was last reset,
+	// including their delimiters.
+	comments []string
 }
 
 func makeScanner(str string) scanner {
@@ -46,6 +49,7 @@ func makeScanner(str string) scanner {
 func (s *scanner) init(str string) {
 	s.in = str
 	s.pos = 0
+	s.comments = nil
 	// Preallocate some buffer space for identifiers etc.
 	s.bytesPrealloc = make([]byte, len(str))
 }
@@ -453,6 +457,7 @@ func (s *scanner) scanComment(lval *sqlSymType) (present, ok bool) {
 					s.pos++
 					depth--
 					if depth == 0 {
+						s.comments = append(s.comments, s.in[start:s.pos])
 						return true, true
 					}
 					continue
@@ -499,6 +499,9 @@ func (u *sqlSymUnion) partitionedBackup() tree.PartitionedBackup {
 func (u *sqlSymUnion) partitionedBackups() []tree.PartitionedBackup {
     return u.val.([]tree.PartitionedBackup)
 }
+func (u *sqlSymUnion) fullBackupClause() *tree.FullBackupClause {
+    return u.val.(*tree.FullBackupClause)
+}
 func newNameFromStr(s string) *tree.Name {
     return (*tree.Name)(&s)
 }
@@ -522,10 +525,10 @@ func newNameFromStr(s string) *tree.Name {
 
 // Ordinary key words in alphabetical order.
 %token <str> ABORT ACTION ADD ADMIN AGGREGATE
-%token <str> ALL ALTER ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC
+%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC
 %token <str> ASYMMETRIC AT AUTHORIZATION AUTOMATIC
 
-%token <str> BACKUP BEGIN BETWEEN BIGINT BIGSERIAL BIT
+%token <str> BACKUP BACKUPS BEGIN BETWEEN BIGINT BIGSERIAL BIT
 %token <str> BUCKET_COUNT
 %token <str> BLOB BOOL BOOLEAN BOTH BY BYTEA BYTES
 
@@ -539,7 +542,7 @@ func newNameFromStr(s string) *tree.Name {
 %token <str> CURRENT_USER CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEC DECIMAL DEFAULT
-%token <str> DEALLOCATE DEFERRABLE DEFERRED DELETE DESC
+%token <str> DEALLOCATE DEFERRABLE DEFERRED DELETE DESC DETAILS
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
 %token <str> ELSE ENCODING END ENUM ESCAPE EXCEPT EXCLUDE
@@ -572,10 +575,10 @@ func newNameFromStr(s string) *tree.Name {
 
 %token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE MINUTE MONTH
 
-%token <str> NAN NAME NAMES NATURAL NEXT NO NOCREATEROLE NO_INDEX_JOIN NONE NORMAL
+%token <str> NAN NAME NAMES NATURAL NEW_KMS NEXT NO NOCREATEROLE NO_INDEX_JOIN NONE NORMAL
 %token <str> NOT NOTHING NOTNULL NOWAIT NULL NULLIF NULLS NUMERIC
 
-%token <str> OF OFF OFFSET OID OIDS OIDVECTOR ON ONLY OPT OPTION OPTIONS OR
+%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OPERATOR
 
 %token <str> PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PHYSICAL PLACING
@@ -584,13 +587,13 @@ func newNameFromStr(s string) *tree.Name {
 
 %token <str> QUERIES QUERY
 
-%token <str> RANGE RANGES READ REAL RECURSIVE REF REFERENCES
+%token <str> RANGE RANGES READ REAL RECURRING RECURSIVE REF REFERENCES
 %token <str> REGCLASS REGPROC REGPROCEDURE REGNAMESPACE REGTYPE
 %token <str> REMOVE_PATH RENAME REPEATABLE REPLACE
 %token <str> RELEASE RESET RESTORE RESTRICT RESUME RETURNING REVOKE RIGHT
 %token <str> ROLE ROLES ROLLBACK ROLLUP ROW ROWS RSHIFT RULE
 
-%token <str> SAVEPOINT SCATTER SCHEMA SCHEMAS SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
+%token <str> SAVEPOINT SCATTER SCHEDULE SCHEDULES SCHEMA SCHEMAS SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
 %token <str> SERIAL SERIAL2 SERIAL4 SERIAL8
 %token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETTING SETTINGS
 %token <str> SHARE SHOW SIMILAR SIMPLE SKIP SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
@@ -635,6 +638,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> stmt
 
 %type <tree.Statement> alter_stmt
+%type <tree.Statement> alter_backup_stmt
 %type <tree.Statement> alter_ddl_stmt
 %type <tree.Statement> alter_table_stmt
 %type <tree.Statement> alter_index_stmt
@@ -708,6 +712,7 @@ func newNameFromStr(s string) *tree.Name {
 
 %type <tree.Statement> create_stmt
 %type <tree.Statement> create_changefeed_stmt
+%type <tree.Statement> create_schedule_for_backup_stmt
 %type <tree.Statement> create_ddl_stmt
 %type <tree.Statement> create_database_stmt
 %type <tree.Statement> create_index_stmt
@@ -736,6 +741,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> drop_user_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
+%type <tree.Statement> drop_schedule_stmt
 
 %type <tree.Statement> explain_stmt
 %type <tree.Statement> prepare_stmt
@@ -748,9 +754,13 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> insert_stmt
 %type <tree.Statement> import_stmt
 %type <tree.Statement> pause_stmt
+%type <tree.Statement> pause_jobs_stmt
+%type <tree.Statement> pause_schedules_stmt
 %type <tree.Statement> release_stmt
 %type <tree.Statement> reset_stmt reset_session_stmt reset_csetting_stmt
 %type <tree.Statement> resume_stmt
+%type <tree.Statement> resume_jobs_stmt
+%type <tree.Statement> resume_schedules_stmt
 %type <tree.Statement> restore_stmt
 %type <tree.PartitionedBackup> partitioned_backup
 %type <[]tree.PartitionedBackup> partitioned_backup_list
@@ -786,6 +796,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> show_ranges_stmt
 %type <tree.Statement> show_range_for_row_stmt
 %type <tree.Statement> show_roles_stmt
+%type <tree.Statement> show_schedules_stmt
 %type <tree.Statement> show_schemas_stmt
 %type <tree.Statement> show_sequences_stmt
 %type <tree.Statement> show_session_stmt
@@ -1001,6 +1012,10 @@ func newNameFromStr(s string) *tree.Name {
 %type <str> non_reserved_word_or_sconst
 %type <tree.Expr> zone_value
 %type <tree.Expr> string_or_placeholder
+%type <tree.Expr> sconst_or_placeholder
+%type <tree.Expr> opt_description cron_expr
+%type <*tree.TargetList> opt_backup_targets
+%type <*tree.FullBackupClause> opt_full_backup_clause
 %type <tree.Expr> string_or_placeholder_list
 
 %type <str> unreserved_keyword type_func_name_keyword cockroachdb_extra_type_func_name_keyword
@@ -1147,11 +1162,12 @@ stmt:
 
 // %Help: ALTER
 // %Category: Group
-// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE
+// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE, ALTER BACKUP
 alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_user_stmt     // EXTEND WITH HELP: ALTER USER
 | alter_role_stmt
+| alter_backup_stmt   // EXTEND WITH HELP: ALTER BACKUP
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1839,6 +1855,28 @@ opt_validate_behavior:
     $$.val = tree.ValidationDefault
   }
 
+// %Help: ALTER BACKUP - add a KMS master key to an encrypted backup
+// %Category: CCL
+// %Text:
+// ALTER BACKUP <location> [IN <collection>]
+//        ADD NEW_KMS = '<kms_uri>'[,...] WITH OLD_KMS = '<kms_uri>'[,...]
+//
+// The data key of the backup is decrypted with one of the OLD_KMS master
+// keys, and encrypted with each of the NEW_KMS master keys. The data of
+// the backup is not rewritten. Only local-kms:///<path> KMS URIs are
+// supported.
+// %SeeAlso: BACKUP, RESTORE
+alter_backup_stmt:
+  ALTER BACKUP string_or_placeholder ADD NEW_KMS '=' string_or_placeholder WITH OLD_KMS '=' string_or_placeholder
+  {
+    $$.val = &tree.AlterBackup{Backup: $3.expr(), NewKMSURI: $7.expr(), OldKMSURI: $11.expr()}
+  }
+| ALTER BACKUP string_or_placeholder IN string_or_placeholder ADD NEW_KMS '=' string_or_placeholder WITH OLD_KMS '=' string_or_placeholder
+  {
+    $$.val = &tree.AlterBackup{Backup: $3.expr(), InCollection: $5.expr(), NewKMSURI: $9.expr(), OldKMSURI: $13.expr()}
+  }
+| ALTER BACKUP error // SHOW HELP: ALTER BACKUP
+
 // %Help: BACKUP - back up data to external storage
 // %Category: CCL
 // %Text:
@@ -1846,6 +1884,9 @@ opt_validate_behavior:
 //        [ AS OF SYSTEM TIME <expr> ]
 //        [ INCREMENTAL FROM <location...> ]
 //        [ WITH <option> [= <value>] [, ...] ]
+// BACKUP <targets...> INTO [<subdir> IN] <collection...>
+//        [ AS OF SYSTEM TIME <expr> ]
+//        [ WITH <option> [= <value>] [, ...] ]
 //
 // Targets:
 //    TABLE <pattern> [, ...]
@@ -1854,9 +1895,20 @@ opt_validate_behavior:
 // Location:
 //    "[scheme]://[host]/[path to backup]?[parameters]"
 //
+// Collection:
+//    "[scheme]://[host]/[path to collection]?[parameters]"
+//    BACKUP INTO takes a new full backup in a dated subdirectory of the
+//    collection. BACKUP INTO <subdir> IN appends an incremental backup to
+//    the full backup in <subdir>, or to the most recent full backup if
+//    <subdir> is LATEST.
+//
 // Options:
 //    INTO_DB
 //    SKIP_MISSING_FOREIGN_KEYS
+//    ENCRYPTION_PASSPHRASE
+//    KMS: comma-separated URIs of the KMS master keys that encrypt the data
+//         key of the backup; only local-kms:///<path>, a key stored in a
+//         file under the external IO directory, is supported
 //
 // %SeeAlso: RESTORE, WEBDOCS/backup.html
 backup_stmt:
@@ -1868,6 +1920,22 @@ backup_stmt:
   {
     $$.val = &tree.Backup{Targets: $2.targetList(), To: $4.partitionedBackup(), IncrementalFrom: $6.exprs(), AsOf: $5.asOfClause(), Options: $7.kvOptions()}
   }
+| BACKUP INTO partitioned_backup opt_as_of_clause opt_with_options
+  {
+    $$.val = &tree.Backup{DescriptorCoverage: tree.AllDescriptors, To: $3.partitionedBackup(), Nested: true, AsOf: $4.asOfClause(), Options: $5.kvOptions()}
+  }
+| BACKUP INTO string_or_placeholder IN partitioned_backup opt_as_of_clause opt_with_options
+  {
+    $$.val = &tree.Backup{DescriptorCoverage: tree.AllDescriptors, To: $5.partitionedBackup(), Nested: true, Subdir: $3.expr(), AsOf: $6.asOfClause(), Options: $7.kvOptions()}
+  }
+| BACKUP targets INTO partitioned_backup opt_as_of_clause opt_with_options
+  {
+    $$.val = &tree.Backup{Targets: $2.targetList(), To: $4.partitionedBackup(), Nested: true, AsOf: $5.asOfClause(), Options: $6.kvOptions()}
+  }
+| BACKUP targets INTO string_or_placeholder IN partitioned_backup opt_as_of_clause opt_with_options
+  {
+    $$.val = &tree.Backup{Targets: $2.targetList(), To: $6.partitionedBackup(), Nested: true, Subdir: $4.expr(), AsOf: $7.asOfClause(), Options: $8.kvOptions()}
+  }
 | BACKUP error // SHOW HELP: BACKUP
 
 // %Help: RESTORE - restore data from external storage
@@ -1876,6 +1944,9 @@ backup_stmt:
 // RESTORE <targets...> FROM <location...>
 //         [ AS OF SYSTEM TIME <expr> ]
 //         [ WITH <option> [= <value>] [, ...] ]
+// RESTORE <targets...> FROM <subdir> IN <collection...>
+//         [ AS OF SYSTEM TIME <expr> ]
+//         [ WITH <option> [= <value>] [, ...] ]
 //
 // Targets:
 //    TABLE <pattern> [, ...]
@@ -1884,9 +1955,25 @@ backup_stmt:
 // Locations:
 //    "[scheme]://[host]/[path to backup]?[parameters]"
 //
+// Subdir:
+//    The full backup of the collection to restore from, along with the
+//    incremental backups appended to it. LATEST denotes the most recent
+//    full backup, or with AS OF SYSTEM TIME, the most recent one taken
+//    before the requested time.
+//
 // Options:
 //    INTO_DB
+//    NEW_DB_NAME: the name to restore the database under, when restoring a
+//                 single database
+//    NEW_TABLE_NAMES: comma-separated <table>=<new name> pairs, naming the
+//                     tables to restore under new names
+//    ONLINE: make the tables public while their data is being restored; reads
+//            of data that isn't restored yet fetch it from the backup
 //    SKIP_MISSING_FOREIGN_KEYS
+//    ENCRYPTION_PASSPHRASE
+//    KMS: comma-separated URIs of the KMS master keys that encrypt the data
+//         key of the backup; only local-kms:///<path>, a key stored in a
+//         file under the external IO directory, is supported
 //
 // %SeeAlso: BACKUP, WEBDOCS/restore.html
 restore_stmt:
@@ -1898,6 +1985,14 @@ restore_stmt:
   {
     $$.val = &tree.Restore{Targets: $2.targetList(), From: $4.partitionedBackups(), AsOf: $5.asOfClause(), Options: $6.kvOptions()}
   }
+| RESTORE FROM string_or_placeholder IN partitioned_backup opt_as_of_clause opt_with_options
+  {
+    $$.val = &tree.Restore{DescriptorCoverage: tree.AllDescriptors, Subdir: $3.expr(), From: []tree.PartitionedBackup{$5.partitionedBackup()}, AsOf: $6.asOfClause(), Options: $7.kvOptions()}
+  }
+| RESTORE targets FROM string_or_placeholder IN partitioned_backup opt_as_of_clause opt_with_options
+  {
+    $$.val = &tree.Restore{Targets: $2.targetList(), Subdir: $4.expr(), From: []tree.PartitionedBackup{$6.partitionedBackup()}, AsOf: $7.asOfClause(), Options: $8.kvOptions()}
+  }
 | RESTORE error // SHOW HELP: RESTORE
 
 partitioned_backup:
@@ -1920,6 +2015,88 @@ partitioned_backup_list:
     $$.val = append($1.partitionedBackups(), $3.partitionedBackup())
   }
 
+// %Help: CREATE SCHEDULE FOR BACKUP - back up data periodically
+// %Category: CCL
+// %Text:
+// CREATE SCHEDULE [<description>]
+// FOR BACKUP [<targets>] TO <location>
+// [WITH <backup_option>[=<value>] [, ...]]
+// RECURRING <cron expr>
+// [FULL BACKUP <cron expr> | FULL BACKUP ALWAYS]
+//
+// Targets:
+//    empty targets: back up the entire cluster
+//    TABLE <pattern> [, ...]
+//    DATABASE <databasename> [, ...]
+//
+// Location:
+//    "[scheme]://[host]/[path prefix to backup]?[parameters]"
+//    The location is a backup collection, as with BACKUP INTO: full
+//    backups are stored in subdirectories of it, and incremental backups
+//    are appended to the most recent full backup.
+//
+// RECURRING <cron expr>:
+//    When to back up, as a cron expression (in UTC) such as '@daily'
+//    or '0 3 * * *'.
+//
+// FULL BACKUP <cron expr>:
+//    When to take full backups; the backups taken in between are
+//    incremental. If omitted, or if ALWAYS is specified, every backup
+//    is a full backup.
+//
+// %SeeAlso: BACKUP, SHOW SCHEDULES, PAUSE SCHEDULES, RESUME SCHEDULES, DROP SCHEDULES
+create_schedule_for_backup_stmt:
+  CREATE SCHEDULE opt_description FOR BACKUP opt_backup_targets TO string_or_placeholder opt_with_options cron_expr opt_full_backup_clause
+  {
+    $$.val = &tree.ScheduledBackup{
+      ScheduleName:  $3.expr(),
+      Targets:       $6.targetListPtr(),
+      To:            $8.expr(),
+      BackupOptions: $9.kvOptions(),
+      Recurrence:    $10.expr(),
+      FullBackup:    $11.fullBackupClause(),
+    }
+  }
+| CREATE SCHEDULE error  // SHOW HELP: CREATE SCHEDULE FOR BACKUP
+
+opt_description:
+  string_or_placeholder
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+opt_backup_targets:
+  targets
+  {
+    $$.val = &tree.TargetList{}
+    *$$.val.(*tree.TargetList) = $1.targetList()
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.TargetList)(nil)
+  }
+
+cron_expr:
+  RECURRING sconst_or_placeholder
+  {
+    $$.val = $2.expr()
+  }
+
+opt_full_backup_clause:
+  FULL BACKUP sconst_or_placeholder
+  {
+    $$.val = &tree.FullBackupClause{Recurrence: $3.expr()}
+  }
+| FULL BACKUP ALWAYS
+  {
+    $$.val = &tree.FullBackupClause{AlwaysFull: true}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.FullBackupClause)(nil)
+  }
+
 import_format:
   name
   {
@@ -2031,6 +2208,18 @@ string_or_placeholder:
     $$.val = p
   }
 
+sconst_or_placeholder:
+  SCONST
+  {
+    $$.val = tree.NewStrVal($1)
+  }
+| PLACEHOLDER
+  {
+    p := $1.placeholder()
+    sqllex.(*lexer).UpdateNumPlaceholders(p)
+    $$.val = p
+  }
+
 string_or_placeholder_list:
   string_or_placeholder
   {
@@ -2253,12 +2442,13 @@ comment_text:
 // %Text:
 // CREATE DATABASE, CREATE TABLE, CREATE INDEX, CREATE TABLE AS,
 // CREATE USER, CREATE VIEW, CREATE SEQUENCE, CREATE STATISTICS,
-// CREATE ROLE
+// CREATE ROLE, CREATE SCHEDULE FOR BACKUP
 create_stmt:
   create_user_stmt     // EXTEND WITH HELP: CREATE USER
 | create_role_stmt     // EXTEND WITH HELP: CREATE ROLE
 | create_ddl_stmt      // help texts in sub-rule
 | create_stats_stmt    // EXTEND WITH HELP: CREATE STATISTICS
+| create_schedule_for_backup_stmt // EXTEND WITH HELP: CREATE SCHEDULE FOR BACKUP
 | create_unsupported   {}
 | CREATE error         // SHOW HELP: CREATE
 
@@ -2520,11 +2710,12 @@ discard_stmt:
 // %Category: Group
 // %Text:
 // DROP DATABASE, DROP INDEX, DROP TABLE, DROP VIEW, DROP SEQUENCE,
-// DROP USER, DROP ROLE
+// DROP USER, DROP ROLE, DROP SCHEDULES
 drop_stmt:
   drop_ddl_stmt      // help texts in sub-rule
 | drop_role_stmt     // EXTEND WITH HELP: DROP ROLE
 | drop_user_stmt     // EXTEND WITH HELP: DROP USER
+| drop_schedule_stmt // EXTEND WITH HELP: DROP SCHEDULES
 | drop_unsupported   {}
 | DROP error         // SHOW HELP: DROP
 
@@ -2535,6 +2726,33 @@ drop_ddl_stmt:
 | drop_view_stmt     // EXTEND WITH HELP: DROP VIEW
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 
+// %Help: DROP SCHEDULES - destroy specified schedules
+// %Category: Misc
+// %Text:
+// DROP SCHEDULES <selectclause>
+//   selectclause: select statement returning schedule id to drop.
+// DROP SCHEDULE <scheduleID>
+// %SeeAlso: PAUSE SCHEDULES, RESUME SCHEDULES, SHOW SCHEDULES
+drop_schedule_stmt:
+  DROP SCHEDULE a_expr
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: &tree.Select{
+        Select: &tree.ValuesClause{Rows: []tree.Exprs{tree.Exprs{$3.expr()}}},
+      },
+      Command: tree.DropSchedule,
+    }
+  }
+| DROP SCHEDULE error // SHOW HELP: DROP SCHEDULES
+| DROP SCHEDULES select_stmt
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: $3.slct(),
+      Command:   tree.DropSchedule,
+    }
+  }
+| DROP SCHEDULES error // SHOW HELP: DROP SCHEDULES
+
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
 // %Text: DROP VIEW [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
@@ -2726,10 +2944,10 @@ preparable_stmt:
 | explain_stmt      // EXTEND WITH HELP: EXPLAIN
 | import_stmt       // EXTEND WITH HELP: IMPORT
 | insert_stmt       // EXTEND WITH HELP: INSERT
-| pause_stmt        // EXTEND WITH HELP: PAUSE JOBS
+| pause_stmt        // help texts in sub-rule
 | reset_stmt        // help texts in sub-rule
 | restore_stmt      // EXTEND WITH HELP: RESTORE
-| resume_stmt       // EXTEND WITH HELP: RESUME JOBS
+| resume_stmt       // help texts in sub-rule
 | export_stmt       // EXTEND WITH HELP: EXPORT
 | scrub_stmt        // help texts in sub-rule
 | select_stmt       // help texts in sub-rule
@@ -3367,7 +3585,7 @@ zone_value:
 // SHOW BACKUP, SHOW CLUSTER SETTING, SHOW COLUMNS, SHOW CONSTRAINTS,
 // SHOW CREATE, SHOW DATABASES, SHOW HISTOGRAM, SHOW INDEXES, SHOW
 // PARTITIONS, SHOW JOBS, SHOW QUERIES, SHOW RANGE, SHOW RANGES,
-// SHOW ROLES, SHOW SCHEMAS, SHOW SEQUENCES, SHOW SESSION, SHOW SESSIONS,
+// SHOW ROLES, SHOW SCHEDULES, SHOW SCHEMAS, SHOW SEQUENCES, SHOW SESSION, SHOW SESSIONS,
 // SHOW STATISTICS, SHOW SYNTAX, SHOW TABLES, SHOW TRACE SHOW TRANSACTION, SHOW USERS
 show_stmt:
   show_backup_stmt          // EXTEND WITH HELP: SHOW BACKUP
@@ -3386,6 +3604,7 @@ show_stmt:
 | show_ranges_stmt          // EXTEND WITH HELP: SHOW RANGES
 | show_range_for_row_stmt
 | show_roles_stmt           // EXTEND WITH HELP: SHOW ROLES
+| show_schedules_stmt       // EXTEND WITH HELP: SHOW SCHEDULES
 | show_schemas_stmt         // EXTEND WITH HELP: SHOW SCHEMAS
 | show_sequences_stmt       // EXTEND WITH HELP: SHOW SEQUENCES
 | show_session_stmt         // EXTEND WITH HELP: SHOW SESSION
@@ -3466,10 +3685,33 @@ show_histogram_stmt:
 
 // %Help: SHOW BACKUP - list backup contents
 // %Category: CCL
-// %Text: SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// %Text:
+// SHOW BACKUP [SCHEMAS|FILES|RANGES] <location> [ WITH <option> [= <value>] [, ...] ]
+// SHOW BACKUP <subdir> IN <collection> [ WITH <option> [= <value>] [, ...] ]
+// SHOW BACKUPS IN <collection>
+//
+// Options:
+//    ENCRYPTION_PASSPHRASE
+//    CHECK_FILES: verify that the files of the backup and of the incremental
+//                 backups appended to it exist and match their checksums
 // %SeeAlso: WEBDOCS/show-backup.html
 show_backup_stmt:
-  SHOW BACKUP string_or_placeholder opt_with_options
+  SHOW BACKUPS IN string_or_placeholder
+  {
+    $$.val = &tree.ShowBackup{
+      InCollection: $4.expr(),
+    }
+  }
+| SHOW BACKUP string_or_placeholder IN string_or_placeholder opt_with_options
+  {
+    $$.val = &tree.ShowBackup{
+      Details:      tree.BackupDefaultDetails,
+      Path:         $3.expr(),
+      InCollection: $5.expr(),
+      Options:      $6.kvOptions(),
+    }
+  }
+| SHOW BACKUP string_or_placeholder opt_with_options
   {
     $$.val = &tree.ShowBackup{
       Details: tree.BackupDefaultDetails,
@@ -3505,6 +3747,7 @@ show_backup_stmt:
     }
   }
 | SHOW BACKUP error // SHOW HELP: SHOW BACKUP
+| SHOW BACKUPS error // SHOW HELP: SHOW BACKUP
 
 // %Help: SHOW CLUSTER SETTING - display cluster settings
 // %Category: Cfg
@@ -3676,11 +3919,30 @@ opt_cluster:
 | LOCAL
   { $$.val = false }
 
+// %Help: SHOW SCHEDULES - list periodic schedules
+// %Category: Misc
+// %Text:
+// SHOW SCHEDULES
+// SHOW SCHEDULE <scheduleID>
+// %SeeAlso: PAUSE SCHEDULES, RESUME SCHEDULES, DROP SCHEDULES
+show_schedules_stmt:
+  SHOW SCHEDULES
+  {
+    $$.val = &tree.ShowSchedules{}
+  }
+| SHOW SCHEDULES error // SHOW HELP: SHOW SCHEDULES
+| SHOW SCHEDULE a_expr
+  {
+    $$.val = &tree.ShowSchedules{ScheduleID: $3.expr()}
+  }
+| SHOW SCHEDULE error // SHOW HELP: SHOW SCHEDULES
+
 // %Help: SHOW JOBS - list background jobs
 // %Category: Misc
 // %Text:
 // SHOW [AUTOMATIC] JOBS
-// SHOW JOB <jobid>
+// SHOW JOBS <selectclause> [WITH DETAILS]
+// SHOW JOB <jobid> [WITH DETAILS]
 // %SeeAlso: CANCEL JOBS, PAUSE JOBS, RESUME JOBS
 show_jobs_stmt:
   SHOW AUTOMATIC JOBS
@@ -3697,6 +3959,10 @@ show_jobs_stmt:
   {
     $$.val = &tree.ShowJobs{Jobs: $3.slct()}
   }
+| SHOW JOBS select_stmt WITH DETAILS
+  {
+    $$.val = &tree.ShowJobs{Jobs: $3.slct(), WithDetails: true}
+  }
 | SHOW JOBS WHEN COMPLETE select_stmt
   {
     $$.val = &tree.ShowJobs{Jobs: $5.slct(), Block: true}
@@ -3710,6 +3976,15 @@ show_jobs_stmt:
       },
     }
   }
+| SHOW JOB a_expr WITH DETAILS
+  {
+    $$.val = &tree.ShowJobs{
+      Jobs: &tree.Select{
+        Select: &tree.ValuesClause{Rows: []tree.Exprs{tree.Exprs{$3.expr()}}},
+      },
+      WithDetails: true,
+    }
+  }
 | SHOW JOB WHEN COMPLETE a_expr
   {
     $$.val = &tree.ShowJobs{
@@ -4213,13 +4488,21 @@ for_grantee_clause:
     $$.val = tree.NameList(nil)
   }
 
+// %Help: PAUSE
+// %Category: Group
+// %Text: PAUSE JOBS, PAUSE SCHEDULES
+pause_stmt:
+  pause_jobs_stmt       // EXTEND WITH HELP: PAUSE JOBS
+| pause_schedules_stmt  // EXTEND WITH HELP: PAUSE SCHEDULES
+| PAUSE error           // SHOW HELP: PAUSE
+
 // %Help: PAUSE JOBS - pause background jobs
 // %Category: Misc
 // %Text:
 // PAUSE JOBS <selectclause>
 // PAUSE JOB <jobid>
 // %SeeAlso: SHOW JOBS, CANCEL JOBS, RESUME JOBS
-pause_stmt:
+pause_jobs_stmt:
   PAUSE JOB a_expr
   {
     $$.val = &tree.ControlJobs{
@@ -4233,7 +4516,35 @@ pause_stmt:
   {
     $$.val = &tree.ControlJobs{Jobs: $3.slct(), Command: tree.PauseJob}
   }
-| PAUSE error // SHOW HELP: PAUSE JOBS
+| PAUSE JOB error // SHOW HELP: PAUSE JOBS
+| PAUSE JOBS error // SHOW HELP: PAUSE JOBS
+
+// %Help: PAUSE SCHEDULES - pause scheduled jobs
+// %Category: Misc
+// %Text:
+// PAUSE SCHEDULES <selectclause>
+//   selectclause: select statement returning schedule id to pause.
+// PAUSE SCHEDULE <scheduleID>
+// %SeeAlso: RESUME SCHEDULES, SHOW SCHEDULES, DROP SCHEDULES
+pause_schedules_stmt:
+  PAUSE SCHEDULE a_expr
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: &tree.Select{
+        Select: &tree.ValuesClause{Rows: []tree.Exprs{tree.Exprs{$3.expr()}}},
+      },
+      Command: tree.PauseSchedule,
+    }
+  }
+| PAUSE SCHEDULE error // SHOW HELP: PAUSE SCHEDULES
+| PAUSE SCHEDULES select_stmt
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: $3.slct(),
+      Command:   tree.PauseSchedule,
+    }
+  }
+| PAUSE SCHEDULES error // SHOW HELP: PAUSE SCHEDULES
 
 // %Help: CREATE TABLE - create a new table
 // %Category: DDL
@@ -5506,13 +5817,21 @@ release_stmt:
   }
 | RELEASE error // SHOW HELP: RELEASE
 
+// %Help: RESUME
+// %Category: Group
+// %Text: RESUME JOBS, RESUME SCHEDULES
+resume_stmt:
+  resume_jobs_stmt       // EXTEND WITH HELP: RESUME JOBS
+| resume_schedules_stmt  // EXTEND WITH HELP: RESUME SCHEDULES
+| RESUME error           // SHOW HELP: RESUME
+
 // %Help: RESUME JOBS - resume background jobs
 // %Category: Misc
 // %Text:
 // RESUME JOBS <selectclause>
 // RESUME JOB <jobid>
 // %SeeAlso: SHOW JOBS, CANCEL JOBS, PAUSE JOBS
-resume_stmt:
+resume_jobs_stmt:
   RESUME JOB a_expr
   {
     $$.val = &tree.ControlJobs{
@@ -5526,7 +5845,35 @@ resume_stmt:
   {
     $$.val = &tree.ControlJobs{Jobs: $3.slct(), Command: tree.ResumeJob}
   }
-| RESUME error // SHOW HELP: RESUME JOBS
+| RESUME JOB error // SHOW HELP: RESUME JOBS
+| RESUME JOBS error // SHOW HELP: RESUME JOBS
+
+// %Help: RESUME SCHEDULES - resume scheduled jobs
+// %Category: Misc
+// %Text:
+// RESUME SCHEDULES <selectclause>
+//   selectclause: select statement returning schedule id to resume.
+// RESUME SCHEDULE <scheduleID>
+// %SeeAlso: PAUSE SCHEDULES, SHOW SCHEDULES, DROP SCHEDULES
+resume_schedules_stmt:
+  RESUME SCHEDULE a_expr
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: &tree.Select{
+        Select: &tree.ValuesClause{Rows: []tree.Exprs{tree.Exprs{$3.expr()}}},
+      },
+      Command: tree.ResumeSchedule,
+    }
+  }
+| RESUME SCHEDULE error // SHOW HELP: RESUME SCHEDULES
+| RESUME SCHEDULES select_stmt
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: $3.slct(),
+      Command:   tree.ResumeSchedule,
+    }
+  }
+| RESUME SCHEDULES error // SHOW HELP: RESUME SCHEDULES
 
 // %Help: SAVEPOINT - start a retryable block
 // %Category: Txn
@@ -9740,10 +10087,12 @@ unreserved_keyword:
 | ADMIN
 | AGGREGATE
 | ALTER
+| ALWAYS
 | AT
 | AUTOMATIC
 | AUTHORIZATION
 | BACKUP
+| BACKUPS
 | BEGIN
 | BIGSERIAL
 | BLOB
@@ -9783,6 +10132,7 @@ unreserved_keyword:
 | DEALLOCATE
 | DELETE
 | DEFERRED
+| DETAILS
 | DISCARD
 | DOMAIN
 | DOUBLE
@@ -9861,6 +10211,7 @@ unreserved_keyword:
 | NAMES
 | NAN
 | NAME
+| NEW_KMS
 | NEXT
 | NO
 | NORMAL
@@ -9874,6 +10225,7 @@ unreserved_keyword:
 | OID
 | OIDS
 | OIDVECTOR
+| OLD_KMS
 | OPERATOR
 | OPT
 | OPTION
@@ -9901,6 +10253,7 @@ unreserved_keyword:
 | RANGE
 | RANGES
 | READ
+| RECURRING
 | RECURSIVE
 | REF
 | REGCLASS
@@ -9928,6 +10281,8 @@ unreserved_keyword:
 | STATUS
 | SAVEPOINT
 | SCATTER
+| SCHEDULE
+| SCHEDULES
 | SCHEMA
 | SCHEMAS
 | SCRUB
@@ -60,14 +60,15 @@ type authOptions struct {
 }
 
 // handleAuthentication checks the connection's user. Errors are sent to the
-// client and also returned.
+// client and also returned. The attempt is logged if the SQL audit policy
+// covers it.
 //
 // TODO(knz): handleAuthentication should discuss with the client to arrange
 // authentication and update c.sessionArgs with the authenticated user's name,
 // if different from the one given initially.
 func (c *conn) handleAuthentication(
 	ctx context.Context, ac AuthConn, authOpt authOptions, execCfg *sql.ExecutorConfig,
-) error {
+) (retErr error) {
 	if authOpt.testingSkipAuth {
 		return nil
 	}
@@ -75,6 +76,12 @@ func (c *conn) handleAuthentication(
 		return authOpt.testingAuthHook(ctx)
 	}
 
+	var userExists bool
+	var authMethod string
+	defer func() {
+		sql.MaybeLogLoginAttempt(ctx, execCfg, &c.sessionArgs, userExists, authMethod, retErr)
+	}()
+
 	sendError := func(err error) error {
 		_ /* err */ = writeErr(ctx, &execCfg.Settings.SV, err, &c.msgBuilder, c.conn)
 		return err
@@ -91,12 +98,16 @@ func (c *conn) handleAuthentication(
 	if !exists {
 		return sendError(errors.Errorf(security.ErrPasswordUserAuthFailed, c.sessionArgs.User))
 	}
+	userExists = true
 
 	// Retrieve the authentication method.
 	tlsState, hbaEntry, methodFn, err := c.findAuthenticationMethod(authOpt)
 	if err != nil {
 		return sendError(err)
 	}
+	if hbaEntry != nil {
+		authMethod = hbaEntry.Method.Value
+	}
 
 	// Ask the method to authenticate.
 	authenticationHook, err := methodFn(ctx, ac, tlsState, pwRetrievalFn, execCfg, hbaEntry)
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -227,6 +228,7 @@ var _ planNodeFastPath = &rowCountNode{}
 var _ planNodeFastPath = &serializeNode{}
 var _ planNodeFastPath = &setZoneConfigNode{}
 var _ planNodeFastPath = &controlJobsNode{}
+var _ planNodeFastPath = &controlSchedulesNode{}
 
 var _ planNodeReadingOwnWrites = &alterIndexNode{}
 var _ planNodeReadingOwnWrites = &alterSequenceNode{}
@@ -294,6 +296,15 @@ type planTop struct {
 	// current statement is causing an auditing event. See exec_log.go.
 	auditEvents []auditEvent
 
+	// auditPolicyDatabaseIDs contains the IDs of the databases used by the
+	// current statement, when the audit policy is enabled. See
+	// audit_policy.go.
+	auditPolicyDatabaseIDs []sqlbase.ID
+
+	// auditPolicyEvent is non-nil if the current statement is covered by
+	// the audit policy. See audit_policy.go.
+	auditPolicyEvent *auditStatementEvent
+
 	// flags is populated during planning and execution.
 	flags planFlags
 
@@ -305,6 +316,12 @@ type planTop struct {
 	avoidBuffering bool
 
 	instrumentation planInstrumentation
+
+	// mem and catalog are retained from the optimizer when the planner
+	// collects a statement diagnostics bundle (see bundle.go); they are nil
+	// otherwise.
+	mem     *memo.Memo
+	catalog *optCatalog
 }
 
 // postquery is a query tree that is executed after the main one. It can only
@@ -443,6 +460,11 @@ const (
 	// planFlagImplicitTxn marks that the plan was run inside of an implicit
 	// transaction.
 	planFlagImplicitTxn
+
+	// planFlagNearestReplica marks that the plan performs a bounded staleness
+	// read at a timestamp that the replicas on the gateway node can serve. The
+	// plan must then run on the gateway, reading from the nearest replicas.
+	planFlagNearestReplica
 )
 
 func (pf planFlags) IsSet(flag planFlags) bool {
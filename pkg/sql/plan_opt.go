@@ -181,6 +181,10 @@ func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 	}
 
 	p.curPlan = *result
+	if p.collectBundle {
+		p.curPlan.mem = execMemo
+		p.curPlan.catalog = &opc.catalog
+	}
 
 	return nil
 }
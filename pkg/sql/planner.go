@@ -160,6 +160,10 @@ type planner struct {
 	// See EXECUTE .. DISCARD ROWS.
 	discardRows bool
 
+	// collectBundle is set if the plan of the current statement is kept for a
+	// statement diagnostics bundle. See bundle.go.
+	collectBundle bool
+
 	// cancelChecker is used by planNodes to check for cancellation of the associated
 	// query.
 	cancelChecker *sqlbase.CancelChecker
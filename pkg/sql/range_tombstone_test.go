@@ -0,0 +1,60 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWritesAfterRangeTombstone verifies that rows deleted by an MVCC range
+// tombstone are deleted for SQL writes as well as for reads.
+func TestWritesAfterRangeTombstone(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, sqlDB, kvDB := serverutils.StartServer(t, base.TestServerArgs{UseDatabase: "test"})
+	defer s.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, `CREATE DATABASE IF NOT EXISTS test`)
+	db.Exec(t, `CREATE TABLE test (k INT PRIMARY KEY, u INT UNIQUE, v INT, INDEX (v))`)
+	db.Exec(t, `INSERT INTO test SELECT i, i, i FROM generate_series(1, 100) AS g(i)`)
+
+	var ts string
+	db.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&ts)
+	desc := sqlbase.GetTableDescriptor(kvDB, "test", "test")
+	span := desc.TableSpan()
+	require.NoError(t, kvDB.DelRangeUsingTombstone(ctx, span.Key, span.EndKey))
+
+	db.CheckQueryResults(t, `SELECT count(*) FROM test`, [][]string{{"0"}})
+	db.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT count(*) FROM test AS OF SYSTEM TIME %s`, ts), [][]string{{"100"}})
+
+	// The primary and unique index entries are written with conditional puts
+	// and the other index entries with initial puts, none of which may see
+	// the deleted rows.
+	db.Exec(t, `INSERT INTO test SELECT i, i, -i FROM generate_series(1, 100) AS g(i)`)
+	db.Exec(t, `UPSERT INTO test VALUES (101, 101, 1)`)
+	db.CheckQueryResults(t, `SELECT count(*), sum(u), sum(v) FROM test`,
+		[][]string{{"101", "5151", "-5049"}})
+	db.CheckQueryResults(t, `SELECT k FROM test@test_v_idx WHERE v = 1`, [][]string{{"101"}})
+	db.ExpectErr(t, "duplicate key value", `INSERT INTO test VALUES (1, 200, 200)`)
+	db.ExpectErr(t, "duplicate key value", `INSERT INTO test VALUES (200, 1, 200)`)
+}
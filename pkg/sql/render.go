@@ -113,16 +113,27 @@ func (p *planner) getTimestamp(asOf tree.AsOfClause) (hlc.Timestamp, bool, error
 		// level. We accept AS OF SYSTEM TIME in multiple places (e.g. in
 		// subqueries or view queries) but they must all point to the same
 		// timestamp.
-		ts, err := p.EvalAsOfTimestamp(asOf)
+		asOfSystemTime, err := p.evalAsOf(asOf)
 		if err != nil {
 			return hlc.MaxTimestamp, false, err
 		}
-		if ts != *p.semaCtx.AsOfTimestamp {
+		// The timestamp of a bounded staleness read is negotiated at
+		// execution time, so only its lower bound can be compared.
+		expected := p.semaCtx.AsOfTimestamp
+		if asOfSystemTime.BoundedStaleness {
+			if p.semaCtx.AsOfMinTimestamp == nil {
+				return hlc.MaxTimestamp, false, tree.ErrBoundedStalenessNotAllowed
+			}
+			expected = p.semaCtx.AsOfMinTimestamp
+		} else if p.semaCtx.AsOfMinTimestamp != nil {
+			expected = nil
+		}
+		if expected == nil || *expected != asOfSystemTime.Timestamp {
 			return hlc.MaxTimestamp, false,
 				unimplemented.NewWithIssue(35712,
 					"cannot specify AS OF SYSTEM TIME with different timestamps")
 		}
-		return ts, true, nil
+		return *p.semaCtx.AsOfTimestamp, true, nil
 	}
 	return hlc.MaxTimestamp, false, nil
 }
@@ -159,6 +159,9 @@ func resolveExistingObjectImpl(
 	}
 
 	if lookupFlags.RequireMutable {
+		if err := checkNotOnlineRestoring(obj.TableDesc()); err != nil {
+			return nil, err
+		}
 		return descI.(*MutableTableDescriptor), nil
 	}
 
@@ -470,6 +470,13 @@ func (sc *SchemaChanger) truncateTable(
 	// performance implications of many range tombstones has been reduced
 	// dramatically making this simplistic throttling sufficient.
 
+	// Once all nodes understand them, the data is deleted with MVCC range
+	// tombstones rather than ClearRange. Unlike ClearRange, range tombstones
+	// preserve MVCC history, so that the deletion is visible to incremental
+	// backups and changefeeds. The deleted data is removed by MVCC GC once the
+	// tombstones fall below the GC threshold.
+	useRangeTombstones := cluster.Version.IsActive(ctx, sc.settings, cluster.VersionMVCCRangeTombstones)
+
 	// These numbers were chosen empirically for the clearrange roachtest and
 	// could certainly use more tuning.
 	const batchSize = 100
@@ -494,13 +501,18 @@ func (sc *SchemaChanger) truncateTable(
 				endKey = tableSpan.EndKey
 			}
 			var b client.Batch
-			b.AddRawRequest(&roachpb.ClearRangeRequest{
-				RequestHeader: roachpb.RequestHeader{
-					Key:    lastKey.AsRawKey(),
-					EndKey: endKey.AsRawKey(),
-				},
-			})
-			log.VEventf(ctx, 2, "ClearRange %s - %s", lastKey, endKey)
+			if useRangeTombstones {
+				b.DelRangeUsingTombstone(lastKey.AsRawKey(), endKey.AsRawKey())
+				log.VEventf(ctx, 2, "DelRangeUsingTombstone %s - %s", lastKey, endKey)
+			} else {
+				b.AddRawRequest(&roachpb.ClearRangeRequest{
+					RequestHeader: roachpb.RequestHeader{
+						Key:    lastKey.AsRawKey(),
+						EndKey: endKey.AsRawKey(),
+					},
+				})
+				log.VEventf(ctx, 2, "ClearRange %s - %s", lastKey, endKey)
+			}
 			if err := sc.db.Run(ctx, &b); err != nil {
 				return err
 			}
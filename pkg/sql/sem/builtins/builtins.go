@@ -1743,6 +1743,60 @@ historical reads against a time which is recent but sufficiently old for reads
 to be performed against the closest replica as opposed to the currently
 leaseholder for a given range.
 
+Note that this function requires an enterprise license on a CCL distribution to
+return without an error.`,
+		},
+	),
+
+	tree.WithMinTimestampFunctionName: makeBuiltin(
+		tree.FunctionProperties{Impure: true},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"min_timestamp", types.TimestampTZ}},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				if err := checkBoundedStaleness(ctx, tree.WithMinTimestampFunctionName); err != nil {
+					return nil, err
+				}
+				return args[0], nil
+			},
+			Info: `When used in an AS OF SYSTEM TIME clause, performs a bounded
+staleness read at the most recent timestamp, no older than min_timestamp, at
+which the replicas closest to the gateway can serve the query. If no such
+timestamp exists, the query reads the current data from the leaseholders.
+
+Bounded staleness reads are only allowed in single-statement read-only
+transactions.
+
+Note that this function requires an enterprise license on a CCL distribution to
+return without an error.`,
+		},
+	),
+
+	tree.WithMaxStalenessFunctionName: makeBuiltin(
+		tree.FunctionProperties{Impure: true},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"max_staleness", types.Interval}},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				if err := checkBoundedStaleness(ctx, tree.WithMaxStalenessFunctionName); err != nil {
+					return nil, err
+				}
+				d := args[0].(*tree.DInterval).Duration
+				if d.Compare(duration.Duration{}) < 0 {
+					return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+						"interval value %s must not be negative", args[0])
+				}
+				ts := duration.Add(ctx.GetStmtTimestamp(), d.Mul(-1))
+				return tree.MakeDTimestampTZ(ts, time.Microsecond), nil
+			},
+			Info: `When used in an AS OF SYSTEM TIME clause, performs a bounded
+staleness read at the most recent timestamp, no staler than max_staleness, at
+which the replicas closest to the gateway can serve the query. If no such
+timestamp exists, the query reads the current data from the leaseholders.
+
+Bounded staleness reads are only allowed in single-statement read-only
+transactions.
+
 Note that this function requires an enterprise license on a CCL distribution to
 return without an error.`,
 		},
@@ -5609,6 +5663,17 @@ func checkPrivilegedUser(ctx *tree.EvalContext) error {
 // if an enterprise license is not installed.
 var EvalFollowerReadOffset func(clusterID uuid.UUID, _ *cluster.Settings) (time.Duration, error)
 
+// checkBoundedStaleness returns an error if bounded staleness reads, which
+// rely on follower reads, are not available.
+func checkBoundedStaleness(ctx *tree.EvalContext, name string) error {
+	if EvalFollowerReadOffset == nil {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			name+" is only available in ccl distribution")
+	}
+	_, err := EvalFollowerReadOffset(ctx.ClusterID, ctx.Settings)
+	return err
+}
+
 func recentTimestamp(ctx *tree.EvalContext) (time.Time, error) {
 	if EvalFollowerReadOffset == nil {
 		return time.Time{}, pgerror.New(pgcode.FeatureNotSupported,
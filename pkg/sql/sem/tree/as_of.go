@@ -29,13 +29,59 @@ import (
 // reads.
 const FollowerReadTimestampFunctionName = "experimental_follower_read_timestamp"
 
-var errInvalidExprForAsOf = errors.Errorf("AS OF SYSTEM TIME: only constant expressions or " +
+const (
+	// WithMinTimestampFunctionName is the name of the function which can be
+	// used with AOST clauses to perform a bounded staleness read no older than
+	// a given timestamp.
+	WithMinTimestampFunctionName = "with_min_timestamp"
+	// WithMaxStalenessFunctionName is the name of the function which can be
+	// used with AOST clauses to perform a bounded staleness read no staler than
+	// a given interval.
+	WithMaxStalenessFunctionName = "with_max_staleness"
+)
+
+var errInvalidExprForAsOf = errors.Errorf("AS OF SYSTEM TIME: only constant expressions, " +
+	WithMinTimestampFunctionName + ", " + WithMaxStalenessFunctionName + " or " +
 	FollowerReadTimestampFunctionName + " are allowed")
 
-// EvalAsOfTimestamp evaluates the timestamp argument to an AS OF SYSTEM TIME query.
+// ErrBoundedStalenessNotAllowed is returned when a bounded staleness AS OF
+// SYSTEM TIME clause is used anywhere but in a single-statement, read-only
+// transaction.
+var ErrBoundedStalenessNotAllowed = pgerror.Newf(pgcode.FeatureNotSupported,
+	"AS OF SYSTEM TIME: %s and %s can only be used in single-statement read-only transactions",
+	WithMinTimestampFunctionName, WithMaxStalenessFunctionName)
+
+// AsOfSystemTime is the result of evaluating an AS OF SYSTEM TIME clause.
+type AsOfSystemTime struct {
+	// Timestamp is the timestamp at which to read. For bounded staleness reads,
+	// it is the oldest timestamp at which the read may be performed; the actual
+	// timestamp is negotiated when the statement is executed.
+	Timestamp hlc.Timestamp
+	// BoundedStaleness is set if the clause used with_min_timestamp or
+	// with_max_staleness.
+	BoundedStaleness bool
+}
+
+// EvalAsOfTimestamp evaluates the timestamp argument to an AS OF SYSTEM TIME
+// query. Bounded staleness clauses are rejected; use EvalAsOf to accept them.
 func EvalAsOfTimestamp(
 	asOf AsOfClause, semaCtx *SemaContext, evalCtx *EvalContext,
-) (tsss hlc.Timestamp, err error) {
+) (hlc.Timestamp, error) {
+	asOfSystemTime, err := EvalAsOf(asOf, semaCtx, evalCtx)
+	if err != nil {
+		return hlc.Timestamp{}, err
+	}
+	if asOfSystemTime.BoundedStaleness {
+		return hlc.Timestamp{}, ErrBoundedStalenessNotAllowed
+	}
+	return asOfSystemTime.Timestamp, nil
+}
+
+// EvalAsOf evaluates an AS OF SYSTEM TIME clause, which may request a bounded
+// staleness read.
+func EvalAsOf(
+	asOf AsOfClause, semaCtx *SemaContext, evalCtx *EvalContext,
+) (AsOfSystemTime, error) {
 	// We need to save and restore the previous value of the field in
 	// semaCtx in case we are recursively called within a subquery
 	// context.
@@ -44,41 +90,50 @@ func EvalAsOfTimestamp(
 	scalarProps.Require("AS OF SYSTEM TIME", RejectSpecial|RejectSubqueries)
 
 	// In order to support the follower reads feature we permit this expression
-	// to be a simple invocation of the `FollowerReadTimestampFunction`.
+	// to be a simple invocation of the `FollowerReadTimestampFunction`, or of
+	// one of the bounded staleness functions.
 	// Over time we could expand the set of allowed functions or expressions.
 	// All non-function expressions must be const and must TypeCheck into a
 	// string.
 	var te TypedExpr
+	var boundedStaleness bool
 	if fe, ok := asOf.Expr.(*FuncExpr); ok {
 		def, err := fe.Func.Resolve(semaCtx.SearchPath)
 		if err != nil {
-			return hlc.Timestamp{}, errInvalidExprForAsOf
+			return AsOfSystemTime{}, errInvalidExprForAsOf
 		}
-		if def.Name != FollowerReadTimestampFunctionName {
-			return hlc.Timestamp{}, errInvalidExprForAsOf
+		switch def.Name {
+		case FollowerReadTimestampFunctionName:
+		case WithMinTimestampFunctionName, WithMaxStalenessFunctionName:
+			boundedStaleness = true
+		default:
+			return AsOfSystemTime{}, errInvalidExprForAsOf
 		}
 		if te, err = fe.TypeCheck(semaCtx, types.TimestampTZ); err != nil {
-			return hlc.Timestamp{}, err
+			return AsOfSystemTime{}, err
 		}
 	} else {
 		var err error
 		te, err = asOf.Expr.TypeCheck(semaCtx, types.String)
 		if err != nil {
-			return hlc.Timestamp{}, err
+			return AsOfSystemTime{}, err
 		}
 		if !IsConst(evalCtx, te) {
-			return hlc.Timestamp{}, errInvalidExprForAsOf
+			return AsOfSystemTime{}, errInvalidExprForAsOf
 		}
 	}
 
 	d, err := te.Eval(evalCtx)
 	if err != nil {
-		return hlc.Timestamp{}, err
+		return AsOfSystemTime{}, err
 	}
 
 	stmtTimestamp := evalCtx.GetStmtTimestamp()
 	ts, err := DatumToHLC(evalCtx, stmtTimestamp, d)
-	return ts, errors.Wrap(err, "AS OF SYSTEM TIME")
+	if err != nil {
+		return AsOfSystemTime{}, errors.Wrap(err, "AS OF SYSTEM TIME")
+	}
+	return AsOfSystemTime{Timestamp: ts, BoundedStaleness: boundedStaleness}, nil
 }
 
 // DatumToHLC performs the conversion from a Datum to an HLC timestamp.
@@ -37,6 +37,13 @@ type Backup struct {
 	IncrementalFrom    Exprs
 	AsOf               AsOfClause
 	Options            KVOptions
+
+	// Nested is set for BACKUP INTO statements, for which To is a collection
+	// of backups rather than the location of the backup itself.
+	Nested bool
+	// Subdir is the full backup of the collection to append an incremental
+	// backup to, or nil if a new full backup is to be taken.
+	Subdir Expr
 }
 
 var _ Statement = &Backup{}
@@ -47,7 +54,15 @@ func (node *Backup) Format(ctx *FmtCtx) {
 	if node.DescriptorCoverage == RequestedDescriptors {
 		ctx.FormatNode(&node.Targets)
 	}
-	ctx.WriteString(" TO ")
+	if node.Nested {
+		ctx.WriteString(" INTO ")
+		if node.Subdir != nil {
+			ctx.FormatNode(node.Subdir)
+			ctx.WriteString(" IN ")
+		}
+	} else {
+		ctx.WriteString(" TO ")
+	}
 	ctx.FormatNode(&node.To)
 	if node.AsOf.Expr != nil {
 		ctx.WriteString(" ")
@@ -70,6 +85,10 @@ type Restore struct {
 	From               []PartitionedBackup
 	AsOf               AsOfClause
 	Options            KVOptions
+
+	// Subdir is the full backup to restore from when From is a collection of
+	// backups, and nil otherwise.
+	Subdir Expr
 }
 
 var _ Statement = &Restore{}
@@ -81,6 +100,10 @@ func (node *Restore) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.Targets)
 	}
 	ctx.WriteString(" FROM ")
+	if node.Subdir != nil {
+		ctx.FormatNode(node.Subdir)
+		ctx.WriteString(" IN ")
+	}
 	for i := range node.From {
 		if i > 0 {
 			ctx.WriteString(", ")
@@ -97,6 +120,33 @@ func (node *Restore) Format(ctx *FmtCtx) {
 	}
 }
 
+// AlterBackup represents an ALTER BACKUP statement, which adds a KMS master key
+// that can decrypt an existing backup.
+type AlterBackup struct {
+	Backup Expr
+	// InCollection is the collection that Backup is a subdirectory of, if
+	// any.
+	InCollection Expr
+	NewKMSURI    Expr
+	OldKMSURI    Expr
+}
+
+var _ Statement = &AlterBackup{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterBackup) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER BACKUP ")
+	ctx.FormatNode(node.Backup)
+	if node.InCollection != nil {
+		ctx.WriteString(" IN ")
+		ctx.FormatNode(node.InCollection)
+	}
+	ctx.WriteString(" ADD NEW_KMS = ")
+	ctx.FormatNode(node.NewKMSURI)
+	ctx.WriteString(" WITH OLD_KMS = ")
+	ctx.FormatNode(node.OldKMSURI)
+}
+
 // KVOption is a key-value option.
 type KVOption struct {
 	Key   Name
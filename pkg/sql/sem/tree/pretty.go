@@ -1884,7 +1884,16 @@ func (node *Backup) doc(p *PrettyCfg) pretty.Doc {
 
 	items = append(items, p.row("BACKUP", pretty.Nil))
 	items = append(items, node.Targets.docRow(p))
-	items = append(items, p.row("TO", p.Doc(&node.To)))
+	if node.Nested {
+		if node.Subdir != nil {
+			items = append(items, p.row("INTO", p.Doc(node.Subdir)))
+			items = append(items, p.row("IN", p.Doc(&node.To)))
+		} else {
+			items = append(items, p.row("INTO", p.Doc(&node.To)))
+		}
+	} else {
+		items = append(items, p.row("TO", p.Doc(&node.To)))
+	}
 
 	if node.AsOf.Expr != nil {
 		items = append(items, node.AsOf.docRow(p))
@@ -1907,7 +1916,12 @@ func (node *Restore) doc(p *PrettyCfg) pretty.Doc {
 	for i := range node.From {
 		from[i] = p.Doc(&node.From[i])
 	}
-	items = append(items, p.row("FROM", p.commaSeparated(from...)))
+	if node.Subdir != nil {
+		items = append(items, p.row("FROM", p.Doc(node.Subdir)))
+		items = append(items, p.row("IN", p.commaSeparated(from...)))
+	} else {
+		items = append(items, p.row("FROM", p.commaSeparated(from...)))
+	}
 
 	if node.AsOf.Expr != nil {
 		items = append(items, node.AsOf.docRow(p))
@@ -0,0 +1,116 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// FullBackupClause describes how often a scheduled backup takes a full
+// backup.
+type FullBackupClause struct {
+	AlwaysFull bool
+	Recurrence Expr
+}
+
+// ScheduledBackup represents a CREATE SCHEDULE FOR BACKUP statement.
+type ScheduledBackup struct {
+	ScheduleName Expr
+	Recurrence   Expr
+	// FullBackup is nil if the statement doesn't specify when to take full
+	// backups.
+	FullBackup *FullBackupClause
+	// Targets is nil for cluster backups.
+	Targets       *TargetList
+	To            Expr
+	BackupOptions KVOptions
+}
+
+var _ Statement = &ScheduledBackup{}
+
+// Format implements the NodeFormatter interface.
+func (node *ScheduledBackup) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SCHEDULE ")
+	if node.ScheduleName != nil {
+		ctx.FormatNode(node.ScheduleName)
+		ctx.WriteString(" ")
+	}
+	ctx.WriteString("FOR BACKUP ")
+	if node.Targets != nil {
+		ctx.FormatNode(node.Targets)
+		ctx.WriteString(" ")
+	}
+	ctx.WriteString("TO ")
+	ctx.FormatNode(node.To)
+	if node.BackupOptions != nil {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.BackupOptions)
+	}
+	ctx.WriteString(" RECURRING ")
+	ctx.FormatNode(node.Recurrence)
+	if node.FullBackup != nil {
+		ctx.WriteString(" FULL BACKUP ")
+		if node.FullBackup.AlwaysFull {
+			ctx.WriteString("ALWAYS")
+		} else {
+			ctx.FormatNode(node.FullBackup.Recurrence)
+		}
+	}
+}
+
+// ShowSchedules represents a SHOW SCHEDULES statement.
+type ShowSchedules struct {
+	// ScheduleID is the ID of the schedule to show, or nil to show all of
+	// them.
+	ScheduleID Expr
+}
+
+var _ Statement = &ShowSchedules{}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowSchedules) Format(ctx *FmtCtx) {
+	if node.ScheduleID != nil {
+		ctx.WriteString("SHOW SCHEDULE ")
+		ctx.FormatNode(node.ScheduleID)
+		return
+	}
+	ctx.WriteString("SHOW SCHEDULES")
+}
+
+// ScheduleCommand determines which type of action to effect on the selected
+// schedule(s).
+type ScheduleCommand int
+
+// ScheduleCommand values
+const (
+	PauseSchedule ScheduleCommand = iota
+	ResumeSchedule
+	DropSchedule
+)
+
+// ScheduleCommandToStatement translates a schedule command to a statement
+// prefix.
+var ScheduleCommandToStatement = map[ScheduleCommand]string{
+	PauseSchedule:  "PAUSE",
+	ResumeSchedule: "RESUME",
+	DropSchedule:   "DROP",
+}
+
+// ControlSchedules represents a PAUSE/RESUME/DROP SCHEDULES statement.
+type ControlSchedules struct {
+	Schedules *Select
+	Command   ScheduleCommand
+}
+
+var _ Statement = &ControlSchedules{}
+
+// Format implements the NodeFormatter interface.
+func (n *ControlSchedules) Format(ctx *FmtCtx) {
+	ctx.WriteString(ScheduleCommandToStatement[n.Command])
+	ctx.WriteString(" SCHEDULES ")
+	ctx.FormatNode(n.Schedules)
+}
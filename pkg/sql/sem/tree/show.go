@@ -87,10 +87,19 @@ type ShowBackup struct {
 	Details              BackupDetails
 	ShouldIncludeSchemas bool
 	Options              KVOptions
+
+	// InCollection is the collection of backups that Path is a part of. If Path
+	// is nil, the statement lists the backups in the collection.
+	InCollection Expr
 }
 
 // Format implements the NodeFormatter interface.
 func (node *ShowBackup) Format(ctx *FmtCtx) {
+	if node.Path == nil {
+		ctx.WriteString("SHOW BACKUPS IN ")
+		ctx.FormatNode(node.InCollection)
+		return
+	}
 	ctx.WriteString("SHOW BACKUP ")
 	if node.Details == BackupRangeDetails {
 		ctx.WriteString("RANGES ")
@@ -101,6 +110,10 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 		ctx.WriteString("SCHEMAS ")
 	}
 	ctx.FormatNode(node.Path)
+	if node.InCollection != nil {
+		ctx.WriteString(" IN ")
+		ctx.FormatNode(node.InCollection)
+	}
 	if len(node.Options) > 0 {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
@@ -226,6 +239,10 @@ type ShowJobs struct {
 
 	// Whether to block and wait for completion of all running jobs to be displayed.
 	Block bool
+
+	// If WithDetails is true, the progress of each job's processors and the
+	// messages it recorded are shown as well.
+	WithDetails bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -242,6 +259,9 @@ func (node *ShowJobs) Format(ctx *FmtCtx) {
 		ctx.WriteString(" ")
 		ctx.FormatNode(node.Jobs)
 	}
+	if node.WithDetails {
+		ctx.WriteString(" WITH DETAILS")
+	}
 }
 
 // ShowSessions represents a SHOW SESSIONS statement
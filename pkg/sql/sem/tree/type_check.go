@@ -58,6 +58,12 @@ type SemaContext struct {
 	// globally for the entire txn and this field would not be needed.
 	AsOfTimestamp *hlc.Timestamp
 
+	// AsOfMinTimestamp is set for bounded staleness AS OF SYSTEM TIME queries
+	// to the oldest timestamp at which the query is allowed to read. In that
+	// case, AsOfTimestamp is the timestamp negotiated for the query, which is
+	// no older.
+	AsOfMinTimestamp *hlc.Timestamp
+
 	Properties SemaProperties
 }
 
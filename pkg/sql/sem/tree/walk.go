@@ -763,6 +763,15 @@ func (stmt *Backup) walkStmt(v Visitor) Statement {
 			ret.IncrementalFrom[i] = e
 		}
 	}
+	if stmt.Subdir != nil {
+		e, changed := WalkExpr(v, stmt.Subdir)
+		if changed {
+			if ret == stmt {
+				ret = stmt.copyNode()
+			}
+			ret.Subdir = e
+		}
+	}
 	{
 		opts, changed := walkKVOptions(v, stmt.Options)
 		if changed {
@@ -917,6 +926,22 @@ func (stmt *ControlJobs) walkStmt(v Visitor) Statement {
 	return stmt
 }
 
+// copyNode makes a copy of this Statement without recursing in any child Statements.
+func (stmt *ControlSchedules) copyNode() *ControlSchedules {
+	stmtCopy := *stmt
+	return &stmtCopy
+}
+
+// walkStmt is part of the walkableStmt interface.
+func (stmt *ControlSchedules) walkStmt(v Visitor) Statement {
+	sel, changed := walkStmt(v, stmt.Schedules)
+	if changed {
+		stmt = stmt.copyNode()
+		stmt.Schedules = sel.(*Select)
+	}
+	return stmt
+}
+
 // copyNode makes a copy of this Statement without recursing in any child Statements.
 func (stmt *Import) copyNode() *Import {
 	stmtCopy := *stmt
@@ -998,6 +1023,15 @@ func (stmt *Restore) walkStmt(v Visitor) Statement {
 			}
 		}
 	}
+	if stmt.Subdir != nil {
+		e, changed := WalkExpr(v, stmt.Subdir)
+		if changed {
+			if ret == stmt {
+				ret = stmt.copyNode()
+			}
+			ret.Subdir = e
+		}
+	}
 	{
 		opts, changed := walkKVOptions(v, stmt.Options)
 		if changed {
@@ -1358,6 +1392,7 @@ var _ walkableStmt = &ValuesClause{}
 var _ walkableStmt = &CancelQueries{}
 var _ walkableStmt = &CancelSessions{}
 var _ walkableStmt = &ControlJobs{}
+var _ walkableStmt = &ControlSchedules{}
 var _ walkableStmt = &BeginTransaction{}
 
 // walkStmt walks the entire parsed stmt calling WalkExpr on each
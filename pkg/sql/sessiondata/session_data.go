@@ -43,6 +43,9 @@ type SessionData struct {
 	// StmtTimeout is the duration a query is permitted to run before it is
 	// canceled by the session. If set to 0, there is no timeout.
 	StmtTimeout time.Duration
+	// TraceParent is the W3C trace context of the client, if any. The traces
+	// of the session's transactions are part of that trace.
+	TraceParent string
 	// User is the name of the user logged into the session.
 	User string
 	// SafeUpdates causes errors when the client
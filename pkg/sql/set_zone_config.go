@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -73,6 +74,14 @@ var supportedZoneConfigOptions = map[tree.Name]struct {
 		c.Constraints = constraintsList.Constraints
 		c.InheritedConstraints = false
 	}},
+	"num_non_voters": {types.Int, func(c *zonepb.ZoneConfig, d tree.Datum) { c.NumNonVoters = proto.Int32(int32(tree.MustBeDInt(d))) }},
+	"non_voter_constraints": {types.String, func(c *zonepb.ZoneConfig, d tree.Datum) {
+		constraintsList := zonepb.ConstraintsList{
+			Constraints: c.NonVoterConstraints,
+		}
+		loadYAML(&constraintsList, string(tree.MustBeDString(d)))
+		c.NonVoterConstraints = constraintsList.Constraints
+	}},
 	"lease_preferences": {types.String, func(c *zonepb.ZoneConfig, d tree.Datum) {
 		loadYAML(&c.LeasePreferences, string(tree.MustBeDString(d)))
 		c.InheritedLeasePreferences = false
@@ -565,6 +574,15 @@ func (n *setZoneConfigNode) startExec(params runParams) error {
 				return err
 			}
 
+			// Non-voting replicas can only be configured once all nodes know how
+			// to handle them.
+			if newZone.NumNonVoters != nil || len(newZone.NonVoterConstraints) > 0 {
+				if !cluster.Version.IsActive(params.ctx, params.EvalContext().Settings, cluster.VersionNonVoterReplicas) {
+					return pgerror.Newf(pgcode.FeatureNotSupported,
+						"num_non_voters and non_voter_constraints require the cluster to be fully upgraded")
+				}
+			}
+
 			// Validate that the result makes sense.
 			if err := validateZoneAttrsAndLocalities(
 				params.ctx,
@@ -757,7 +775,8 @@ func validateNoRepeatKeysInZone(zone *zonepb.ZoneConfig) error {
 func validateZoneAttrsAndLocalities(
 	ctx context.Context, getNodes nodeGetter, zone *zonepb.ZoneConfig,
 ) error {
-	if len(zone.Constraints) == 0 && len(zone.LeasePreferences) == 0 {
+	if len(zone.Constraints) == 0 && len(zone.NonVoterConstraints) == 0 &&
+		len(zone.LeasePreferences) == 0 {
 		return nil
 	}
 
@@ -787,6 +806,11 @@ func validateZoneAttrsAndLocalities(
 			addToValidate(constraint)
 		}
 	}
+	for _, constraints := range zone.NonVoterConstraints {
+		for _, constraint := range constraints.Constraints {
+			addToValidate(constraint)
+		}
+	}
 	for _, leasePreferences := range zone.LeasePreferences {
 		for _, constraint := range leasePreferences.Constraints {
 			addToValidate(constraint)
@@ -170,6 +170,13 @@ func zoneConfigToSQL(zs *tree.ZoneSpecifier, zone *zonepb.ZoneConfig) (string, e
 		return "", err
 	}
 	constraints = strings.TrimSpace(constraints)
+	nonVoterConstraints, err := yamlMarshalFlow(zonepb.ConstraintsList{
+		Constraints: zone.NonVoterConstraints,
+	})
+	if err != nil {
+		return "", err
+	}
+	nonVoterConstraints = strings.TrimSpace(nonVoterConstraints)
 	prefs, err := yamlMarshalFlow(zone.LeasePreferences)
 	if err != nil {
 		return "", err
@@ -205,6 +212,16 @@ func zoneConfigToSQL(zs *tree.ZoneSpecifier, zone *zonepb.ZoneConfig) (string, e
 		f.Printf("\tconstraints = %s", lex.EscapeSQLString(constraints))
 		useComma = true
 	}
+	if zone.NumNonVoters != nil {
+		writeComma(f, useComma)
+		f.Printf("\tnum_non_voters = %d", *zone.NumNonVoters)
+		useComma = true
+	}
+	if len(zone.NonVoterConstraints) > 0 {
+		writeComma(f, useComma)
+		f.Printf("\tnon_voter_constraints = %s", lex.EscapeSQLString(nonVoterConstraints))
+		useComma = true
+	}
 	if !zone.InheritedLeasePreferences {
 		writeComma(f, useComma)
 		f.Printf("\tlease_preferences = %s", lex.EscapeSQLString(prefs))
@@ -52,6 +52,9 @@ const (
 	CrdbInternalBackwardDependenciesTableID
 	CrdbInternalBuildInfoTableID
 	CrdbInternalBuiltinFunctionsTableID
+	CrdbInternalClusterContendedIndexesID
+	CrdbInternalClusterContendingTxnsID
+	CrdbInternalClusterLocksTableID
 	CrdbInternalClusterQueriesTableID
 	CrdbInternalClusterSessionsTableID
 	CrdbInternalClusterSettingsTableID
@@ -63,6 +66,8 @@ const (
 	CrdbInternalGossipLivenessTableID
 	CrdbInternalGossipNetworkTableID
 	CrdbInternalIndexColumnsTableID
+	CrdbInternalJobMessagesTableID
+	CrdbInternalJobProcessorProgressTableID
 	CrdbInternalJobsTableID
 	CrdbInternalKVNodeStatusTableID
 	CrdbInternalKVStoreStatusTableID
@@ -70,6 +75,7 @@ const (
 	CrdbInternalLocalQueriesTableID
 	CrdbInternalLocalSessionsTableID
 	CrdbInternalLocalMetricsTableID
+	CrdbInternalNodeTxnStatisticsTableID
 	CrdbInternalPartitionsTableID
 	CrdbInternalPredefinedCommentsTableID
 	CrdbInternalRangesNoLeasesTableID
@@ -78,10 +84,12 @@ const (
 	CrdbInternalSchemaChangesTableID
 	CrdbInternalSessionTraceTableID
 	CrdbInternalSessionVariablesTableID
+	CrdbInternalStatementStatisticsTableID
 	CrdbInternalStmtStatsTableID
 	CrdbInternalTableColumnsTableID
 	CrdbInternalTableIndexesTableID
 	CrdbInternalTablesTableID
+	CrdbInternalTransactionStatisticsTableID
 	CrdbInternalTxnStatsTableID
 	CrdbInternalZonesTableID
 	InformationSchemaID
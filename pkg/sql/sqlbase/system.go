@@ -250,6 +250,124 @@ CREATE TABLE system.protected_ts_records (
    verified  BOOL NOT NULL DEFAULT (false),
    FAMILY "primary" (id, ts, meta_type, meta, num_spans, spans, verified)
 );`
+
+	// scheduled_jobs stores the schedules run by the job scheduler. A schedule
+	// whose next_run is NULL is paused.
+	ScheduledJobsTableSchema = `
+CREATE TABLE system.scheduled_jobs (
+   schedule_id    INT8 DEFAULT unique_rowid() PRIMARY KEY,
+   schedule_name  STRING NOT NULL,
+   created        TIMESTAMPTZ NOT NULL DEFAULT now(),
+   owner          STRING NOT NULL,
+   next_run       TIMESTAMPTZ,
+   schedule_expr  STRING,
+   executor_type  STRING NOT NULL,
+   execution_args BYTES NOT NULL,
+   INDEX "next_run_idx" (next_run),
+   FAMILY "primary" (schedule_id, schedule_name, created, owner, next_run, schedule_expr, executor_type, execution_args)
+);`
+
+	// job_messages stores the bounded log of events and messages of each job,
+	// newest first.
+	JobMessagesTableSchema = `
+CREATE TABLE system.job_messages (
+   job_id     INT8 NOT NULL,
+   written    TIMESTAMPTZ NOT NULL DEFAULT now(),
+   message_id INT8 NOT NULL DEFAULT unique_rowid(),
+   kind       STRING NOT NULL,
+   message    STRING NOT NULL,
+   PRIMARY KEY (job_id, written DESC, message_id),
+   FAMILY "primary" (job_id, written, message_id, kind, message)
+);`
+
+	// job_processor_progress stores the latest progress reported by each of the
+	// processors running a job.
+	JobProcessorProgressTableSchema = `
+CREATE TABLE system.job_processor_progress (
+   job_id             INT8 NOT NULL,
+   node_id            INT8 NOT NULL,
+   processor_id       INT8 NOT NULL,
+   processor          STRING NOT NULL,
+   updated            TIMESTAMPTZ NOT NULL DEFAULT now(),
+   fraction_completed FLOAT8 NOT NULL,
+   running_status     STRING,
+   PRIMARY KEY (job_id, node_id, processor_id),
+   FAMILY "primary" (job_id, node_id, processor_id, processor, updated, fraction_completed, running_status)
+);`
+
+	// statement_bundle_chunks stores the chunks of the zip files of statement
+	// diagnostics bundles.
+	StatementBundleChunksTableSchema = `
+CREATE TABLE system.statement_bundle_chunks (
+   id          INT8 NOT NULL DEFAULT unique_rowid(),
+   description STRING,
+   data        BYTES NOT NULL,
+   PRIMARY KEY (id),
+   FAMILY "primary" (id, description, data)
+);`
+
+	// statement_diagnostics_requests stores the requests to collect a
+	// diagnostics bundle for the next execution of a statement fingerprint.
+	StatementDiagnosticsRequestsTableSchema = `
+CREATE TABLE system.statement_diagnostics_requests (
+   id                       INT8 NOT NULL DEFAULT unique_rowid(),
+   completed                BOOL NOT NULL DEFAULT false,
+   statement_fingerprint    STRING NOT NULL,
+   statement_diagnostics_id INT8,
+   requested_at             TIMESTAMPTZ NOT NULL,
+   PRIMARY KEY (id),
+   FAMILY "primary" (id, completed, statement_fingerprint, statement_diagnostics_id, requested_at)
+);`
+
+	// statement_diagnostics stores the diagnostics collected for the
+	// executions of statements.
+	StatementDiagnosticsTableSchema = `
+CREATE TABLE system.statement_diagnostics (
+   id                    INT8 NOT NULL DEFAULT unique_rowid(),
+   statement_fingerprint STRING NOT NULL,
+   statement             STRING NOT NULL,
+   collected_at          TIMESTAMPTZ NOT NULL,
+   trace                 JSONB,
+   bundle_chunks         INT8[],
+   error                 STRING,
+   PRIMARY KEY (id),
+   FAMILY "primary" (id, statement_fingerprint, statement, collected_at, trace, bundle_chunks, error)
+);`
+
+	// statement_statistics stores the statement statistics flushed by each
+	// node, aggregated by time bucket. The statistics column holds an encoded
+	// roachpb.StatementStatistics.
+	StatementStatisticsTableSchema = `
+CREATE TABLE system.statement_statistics (
+   aggregated_ts TIMESTAMPTZ NOT NULL,
+   app_name      STRING NOT NULL,
+   fingerprint   STRING NOT NULL,
+   failed        BOOL NOT NULL,
+   distsql       BOOL NOT NULL,
+   implicit_txn  BOOL NOT NULL,
+   node_id       INT8 NOT NULL,
+   count         INT8 NOT NULL,
+   statistics    BYTES NOT NULL,
+   PRIMARY KEY (aggregated_ts, app_name, fingerprint, failed, distsql, implicit_txn, node_id),
+   FAMILY "primary" (aggregated_ts, app_name, fingerprint, failed, distsql, implicit_txn, node_id, count, statistics)
+);`
+
+	// transaction_statistics stores the per-fingerprint transaction statistics
+	// flushed by each node, aggregated by time bucket. The fingerprint_id
+	// column holds the bits of the unsigned fingerprint. The statistics column
+	// holds an encoded roachpb.CollectedTransactionStatistics, which also
+	// carries the statement fingerprints of the transaction.
+	TransactionStatisticsTableSchema = `
+CREATE TABLE system.transaction_statistics (
+   aggregated_ts  TIMESTAMPTZ NOT NULL,
+   app_name       STRING NOT NULL,
+   fingerprint_id INT8 NOT NULL,
+   node_id        INT8 NOT NULL,
+   count          INT8 NOT NULL,
+   statistics     BYTES NOT NULL,
+   PRIMARY KEY (aggregated_ts, app_name, fingerprint_id, node_id),
+   FAMILY "primary" (aggregated_ts, app_name, fingerprint_id, node_id, count, statistics)
+);`
 )
 
 func pk(name string) IndexDescriptor {
@@ -299,6 +417,14 @@ var SystemAllowedPrivileges = map[ID]privilege.List{
 	keys.ReportsMetaTableID:                   privilege.ReadWriteData,
 	keys.ProtectedTimestampsMetaTableID:       privilege.ReadData,
 	keys.ProtectedTimestampsRecordsTableID:    privilege.ReadData,
+	keys.ScheduledJobsTableID:                 privilege.ReadWriteData,
+	keys.JobMessagesTableID:                   privilege.ReadWriteData,
+	keys.JobProcessorProgressTableID:          privilege.ReadWriteData,
+	keys.StatementBundleChunksTableID:         privilege.ReadWriteData,
+	keys.StatementDiagnosticsRequestsTableID:  privilege.ReadWriteData,
+	keys.StatementDiagnosticsTableID:          privilege.ReadWriteData,
+	keys.StatementStatisticsTableID:           privilege.ReadWriteData,
+	keys.TransactionStatisticsTableID:         privilege.ReadWriteData,
 }
 
 // Helpers used to make some of the TableDescriptor literals below more concise.
@@ -1288,6 +1414,373 @@ var (
 		FormatVersion:  InterleavedFormatVersion,
 		NextMutationID: 1,
 	}
+
+	nowTZString = "now():::TIMESTAMPTZ"
+
+	// ScheduledJobsTable is the descriptor for the scheduled_jobs table.
+	ScheduledJobsTable = TableDescriptor{
+		Name:                    "scheduled_jobs",
+		ID:                      keys.ScheduledJobsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []ColumnDescriptor{
+			{Name: "schedule_id", ID: 1, Type: *types.Int, DefaultExpr: &uniqueRowIDString},
+			{Name: "schedule_name", ID: 2, Type: *types.String},
+			{Name: "created", ID: 3, Type: *types.TimestampTZ, DefaultExpr: &nowTZString},
+			{Name: "owner", ID: 4, Type: *types.String},
+			{Name: "next_run", ID: 5, Type: *types.TimestampTZ, Nullable: true},
+			{Name: "schedule_expr", ID: 6, Type: *types.String, Nullable: true},
+			{Name: "executor_type", ID: 7, Type: *types.String},
+			{Name: "execution_args", ID: 8, Type: *types.Bytes},
+		},
+		NextColumnID: 9,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name: "primary",
+				ID:   0,
+				ColumnNames: []string{
+					"schedule_id",
+					"schedule_name",
+					"created",
+					"owner",
+					"next_run",
+					"schedule_expr",
+					"executor_type",
+					"execution_args",
+				},
+				ColumnIDs: []ColumnID{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: pk("schedule_id"),
+		Indexes: []IndexDescriptor{
+			{
+				Name:             "next_run_idx",
+				ID:               2,
+				Unique:           false,
+				ColumnNames:      []string{"next_run"},
+				ColumnDirections: []IndexDescriptor_Direction{IndexDescriptor_ASC},
+				ColumnIDs:        []ColumnID{5},
+				ExtraColumnIDs:   []ColumnID{1},
+				Version:          SecondaryIndexFamilyFormatVersion,
+			},
+		},
+		NextIndexID:    3,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.ScheduledJobsTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// JobMessagesTable is the descriptor for the job_messages table.
+	JobMessagesTable = TableDescriptor{
+		Name:                    "job_messages",
+		ID:                      keys.JobMessagesTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []ColumnDescriptor{
+			{Name: "job_id", ID: 1, Type: *types.Int},
+			{Name: "written", ID: 2, Type: *types.TimestampTZ, DefaultExpr: &nowTZString},
+			{Name: "message_id", ID: 3, Type: *types.Int, DefaultExpr: &uniqueRowIDString},
+			{Name: "kind", ID: 4, Type: *types.String},
+			{Name: "message", ID: 5, Type: *types.String},
+		},
+		NextColumnID: 6,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name:        "primary",
+				ID:          0,
+				ColumnNames: []string{"job_id", "written", "message_id", "kind", "message"},
+				ColumnIDs:   []ColumnID{1, 2, 3, 4, 5},
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: IndexDescriptor{
+			Name:        "primary",
+			ID:          1,
+			Unique:      true,
+			ColumnNames: []string{"job_id", "written", "message_id"},
+			ColumnDirections: []IndexDescriptor_Direction{
+				IndexDescriptor_ASC, IndexDescriptor_DESC, IndexDescriptor_ASC,
+			},
+			ColumnIDs: []ColumnID{1, 2, 3},
+			Version:   SecondaryIndexFamilyFormatVersion,
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.JobMessagesTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// JobProcessorProgressTable is the descriptor for the job_processor_progress
+	// table.
+	JobProcessorProgressTable = TableDescriptor{
+		Name:                    "job_processor_progress",
+		ID:                      keys.JobProcessorProgressTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []ColumnDescriptor{
+			{Name: "job_id", ID: 1, Type: *types.Int},
+			{Name: "node_id", ID: 2, Type: *types.Int},
+			{Name: "processor_id", ID: 3, Type: *types.Int},
+			{Name: "processor", ID: 4, Type: *types.String},
+			{Name: "updated", ID: 5, Type: *types.TimestampTZ, DefaultExpr: &nowTZString},
+			{Name: "fraction_completed", ID: 6, Type: *types.Float},
+			{Name: "running_status", ID: 7, Type: *types.String, Nullable: true},
+		},
+		NextColumnID: 8,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name: "primary",
+				ID:   0,
+				ColumnNames: []string{
+					"job_id",
+					"node_id",
+					"processor_id",
+					"processor",
+					"updated",
+					"fraction_completed",
+					"running_status",
+				},
+				ColumnIDs: []ColumnID{1, 2, 3, 4, 5, 6, 7},
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: IndexDescriptor{
+			Name:        "primary",
+			ID:          1,
+			Unique:      true,
+			ColumnNames: []string{"job_id", "node_id", "processor_id"},
+			ColumnDirections: []IndexDescriptor_Direction{
+				IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC,
+			},
+			ColumnIDs: []ColumnID{1, 2, 3},
+			Version:   SecondaryIndexFamilyFormatVersion,
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.JobProcessorProgressTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// StatementBundleChunksTable is the descriptor for the
+	// statement_bundle_chunks table.
+	StatementBundleChunksTable = TableDescriptor{
+		Name:                    "statement_bundle_chunks",
+		ID:                      keys.StatementBundleChunksTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []ColumnDescriptor{
+			{Name: "id", ID: 1, Type: *types.Int, DefaultExpr: &uniqueRowIDString},
+			{Name: "description", ID: 2, Type: *types.String, Nullable: true},
+			{Name: "data", ID: 3, Type: *types.Bytes},
+		},
+		NextColumnID: 4,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name:        "primary",
+				ID:          0,
+				ColumnNames: []string{"id", "description", "data"},
+				ColumnIDs:   []ColumnID{1, 2, 3},
+			},
+		},
+		NextFamilyID:   1,
+		PrimaryIndex:   pk("id"),
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.StatementBundleChunksTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// StatementDiagnosticsRequestsTable is the descriptor for the
+	// statement_diagnostics_requests table.
+	StatementDiagnosticsRequestsTable = TableDescriptor{
+		Name:                    "statement_diagnostics_requests",
+		ID:                      keys.StatementDiagnosticsRequestsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []ColumnDescriptor{
+			{Name: "id", ID: 1, Type: *types.Int, DefaultExpr: &uniqueRowIDString},
+			{Name: "completed", ID: 2, Type: *types.Bool, DefaultExpr: &falseBoolString},
+			{Name: "statement_fingerprint", ID: 3, Type: *types.String},
+			{Name: "statement_diagnostics_id", ID: 4, Type: *types.Int, Nullable: true},
+			{Name: "requested_at", ID: 5, Type: *types.TimestampTZ},
+		},
+		NextColumnID: 6,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name: "primary",
+				ID:   0,
+				ColumnNames: []string{
+					"id",
+					"completed",
+					"statement_fingerprint",
+					"statement_diagnostics_id",
+					"requested_at",
+				},
+				ColumnIDs: []ColumnID{1, 2, 3, 4, 5},
+			},
+		},
+		NextFamilyID:   1,
+		PrimaryIndex:   pk("id"),
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.StatementDiagnosticsRequestsTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// StatementDiagnosticsTable is the descriptor for the statement_diagnostics
+	// table.
+	StatementDiagnosticsTable = TableDescriptor{
+		Name:                    "statement_diagnostics",
+		ID:                      keys.StatementDiagnosticsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []ColumnDescriptor{
+			{Name: "id", ID: 1, Type: *types.Int, DefaultExpr: &uniqueRowIDString},
+			{Name: "statement_fingerprint", ID: 2, Type: *types.String},
+			{Name: "statement", ID: 3, Type: *types.String},
+			{Name: "collected_at", ID: 4, Type: *types.TimestampTZ},
+			{Name: "trace", ID: 5, Type: *types.Jsonb, Nullable: true},
+			{Name: "bundle_chunks", ID: 6, Type: *types.IntArray, Nullable: true},
+			{Name: "error", ID: 7, Type: *types.String, Nullable: true},
+		},
+		NextColumnID: 8,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name: "primary",
+				ID:   0,
+				ColumnNames: []string{
+					"id",
+					"statement_fingerprint",
+					"statement",
+					"collected_at",
+					"trace",
+					"bundle_chunks",
+					"error",
+				},
+				ColumnIDs: []ColumnID{1, 2, 3, 4, 5, 6, 7},
+			},
+		},
+		NextFamilyID:   1,
+		PrimaryIndex:   pk("id"),
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.StatementDiagnosticsTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// StatementStatisticsTable is the descriptor for the statement_statistics
+	// table.
+	StatementStatisticsTable = TableDescriptor{
+		Name:                    "statement_statistics",
+		ID:                      keys.StatementStatisticsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []ColumnDescriptor{
+			{Name: "aggregated_ts", ID: 1, Type: *types.TimestampTZ},
+			{Name: "app_name", ID: 2, Type: *types.String},
+			{Name: "fingerprint", ID: 3, Type: *types.String},
+			{Name: "failed", ID: 4, Type: *types.Bool},
+			{Name: "distsql", ID: 5, Type: *types.Bool},
+			{Name: "implicit_txn", ID: 6, Type: *types.Bool},
+			{Name: "node_id", ID: 7, Type: *types.Int},
+			{Name: "count", ID: 8, Type: *types.Int},
+			{Name: "statistics", ID: 9, Type: *types.Bytes},
+		},
+		NextColumnID: 10,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name: "primary",
+				ID:   0,
+				ColumnNames: []string{
+					"aggregated_ts",
+					"app_name",
+					"fingerprint",
+					"failed",
+					"distsql",
+					"implicit_txn",
+					"node_id",
+					"count",
+					"statistics",
+				},
+				ColumnIDs: []ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: IndexDescriptor{
+			Name:   "primary",
+			ID:     1,
+			Unique: true,
+			ColumnNames: []string{
+				"aggregated_ts", "app_name", "fingerprint", "failed", "distsql", "implicit_txn", "node_id",
+			},
+			ColumnDirections: []IndexDescriptor_Direction{
+				IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC,
+				IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC,
+			},
+			ColumnIDs: []ColumnID{1, 2, 3, 4, 5, 6, 7},
+			Version:   SecondaryIndexFamilyFormatVersion,
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.StatementStatisticsTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// TransactionStatisticsTable is the descriptor for the
+	// transaction_statistics table.
+	TransactionStatisticsTable = TableDescriptor{
+		Name:                    "transaction_statistics",
+		ID:                      keys.TransactionStatisticsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []ColumnDescriptor{
+			{Name: "aggregated_ts", ID: 1, Type: *types.TimestampTZ},
+			{Name: "app_name", ID: 2, Type: *types.String},
+			{Name: "fingerprint_id", ID: 3, Type: *types.Int},
+			{Name: "node_id", ID: 4, Type: *types.Int},
+			{Name: "count", ID: 5, Type: *types.Int},
+			{Name: "statistics", ID: 6, Type: *types.Bytes},
+		},
+		NextColumnID: 7,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name: "primary",
+				ID:   0,
+				ColumnNames: []string{
+					"aggregated_ts",
+					"app_name",
+					"fingerprint_id",
+					"node_id",
+					"count",
+					"statistics",
+				},
+				ColumnIDs: []ColumnID{1, 2, 3, 4, 5, 6},
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: IndexDescriptor{
+			Name:             "primary",
+			ID:               1,
+			Unique:           true,
+			ColumnNames:      []string{"aggregated_ts", "app_name", "fingerprint_id", "node_id"},
+			ColumnDirections: []IndexDescriptor_Direction{IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC},
+			ColumnIDs:        []ColumnID{1, 2, 3, 4},
+			Version:          SecondaryIndexFamilyFormatVersion,
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.TransactionStatisticsTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
 )
 
 // Create a kv pair for the zone config for the given key and config value.
@@ -1341,6 +1834,14 @@ func addSystemDescriptorsToSchema(target *MetadataSchema) {
 	target.AddDescriptor(keys.SystemDatabaseID, &ReplicationCriticalLocalitiesTable)
 	target.AddDescriptor(keys.SystemDatabaseID, &ProtectedTimestampsMetaTable)
 	target.AddDescriptor(keys.SystemDatabaseID, &ProtectedTimestampsRecordsTable)
+	target.AddDescriptor(keys.SystemDatabaseID, &ScheduledJobsTable)
+	target.AddDescriptor(keys.SystemDatabaseID, &JobMessagesTable)
+	target.AddDescriptor(keys.SystemDatabaseID, &JobProcessorProgressTable)
+	target.AddDescriptor(keys.SystemDatabaseID, &StatementBundleChunksTable)
+	target.AddDescriptor(keys.SystemDatabaseID, &StatementDiagnosticsRequestsTable)
+	target.AddDescriptor(keys.SystemDatabaseID, &StatementDiagnosticsTable)
+	target.AddDescriptor(keys.SystemDatabaseID, &StatementStatisticsTable)
+	target.AddDescriptor(keys.SystemDatabaseID, &TransactionStatisticsTable)
 }
 
 // addSystemDatabaseToSchema populates the supplied MetadataSchema with the
@@ -0,0 +1,272 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package stmtdiagnostics manages the requests for statement diagnostics
+// bundles and the bundles collected for them.
+//
+// A request is registered for a statement fingerprint, as shown in the
+// statement statistics, by inserting a row in
+// system.statement_diagnostics_requests. Every node periodically reads the
+// pending requests, and the next execution of a statement with a requested
+// fingerprint on any node collects the diagnostics: the statement is
+// executed with tracing enabled, and its trace, plan, schemas, statistics
+// and environment are stored as a zip file in
+// system.statement_bundle_chunks, referenced from a row of
+// system.statement_diagnostics. The request is completed in the same
+// transaction, so that only one execution is collected even if several
+// nodes execute the statement concurrently.
+package stmtdiagnostics
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var pollingInterval = settings.RegisterNonNegativeDurationSetting(
+	"sql.stmt_diagnostics.poll_interval",
+	"the interval at which each node polls for new statement diagnostics requests"+
+		" (0 disables polling)",
+	10*time.Second,
+)
+
+// bundleChunkSize is the maximum size of the chunks of the bundles stored in
+// system.statement_bundle_chunks.
+const bundleChunkSize = 128 << 10
+
+// RequestID is the ID of a statement diagnostics request, as stored in
+// system.statement_diagnostics_requests.
+type RequestID int64
+
+// Registry keeps track of the pending statement diagnostics requests and
+// records the diagnostics collected for them.
+type Registry struct {
+	ie sqlutil.InternalExecutor
+	db *client.DB
+	st *cluster.Settings
+
+	mu struct {
+		syncutil.Mutex
+		// requests maps the pending requests to their statement fingerprints.
+		requests map[RequestID]string
+		// ongoing contains the requests for which this node is collecting
+		// diagnostics. They are kept out of requests until the collection
+		// finishes, even if they are read again from the system table.
+		ongoing map[RequestID]struct{}
+	}
+}
+
+// NewRegistry creates a Registry. Start must be called for the registry to
+// learn about the requests registered on other nodes.
+func NewRegistry(ie sqlutil.InternalExecutor, db *client.DB, st *cluster.Settings) *Registry {
+	r := &Registry{ie: ie, db: db, st: st}
+	r.mu.requests = make(map[RequestID]string)
+	r.mu.ongoing = make(map[RequestID]struct{})
+	return r
+}
+
+// Start starts polling for the pending requests every
+// sql.stmt_diagnostics.poll_interval.
+func (r *Registry) Start(ctx context.Context, stopper *stop.Stopper) {
+	ctx, _ = stopper.WithCancelOnQuiesce(ctx)
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			interval := pollingInterval.Get(&r.st.SV)
+			if interval == 0 {
+				// Check again for a new interval in a while.
+				interval = time.Minute
+			} else if err := r.pollRequests(ctx); err != nil {
+				log.Warningf(ctx, "error polling for statement diagnostics requests: %v", err)
+			}
+			timer.Reset(interval)
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-timer.C:
+				timer.Read = true
+			}
+		}
+	})
+}
+
+// InsertRequest registers a request to collect diagnostics for the next
+// execution of a statement with the given fingerprint.
+func (r *Registry) InsertRequest(ctx context.Context, fingerprint string) (RequestID, error) {
+	if !cluster.Version.IsActive(ctx, r.st, cluster.VersionStatementDiagnostics) {
+		return 0, errors.New("statement diagnostics are not supported until the cluster is upgraded")
+	}
+	row, err := r.ie.QueryRow(ctx, "stmt-diag-insert-request", nil, /* txn */
+		`INSERT INTO system.statement_diagnostics_requests (statement_fingerprint, requested_at)
+		VALUES ($1, now()) RETURNING id`,
+		fingerprint,
+	)
+	if err != nil {
+		return 0, err
+	}
+	id := RequestID(*row[0].(*tree.DInt))
+
+	// Start collecting on this node right away; the other nodes pick the
+	// request up when they next poll.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.requests[id] = fingerprint
+	return id, nil
+}
+
+// HasRequests returns whether there are pending requests, allowing callers
+// to skip computing statement fingerprints when there are none.
+func (r *Registry) HasRequests() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.mu.requests) > 0
+}
+
+// ShouldCollectDiagnostics returns whether diagnostics should be collected
+// for the execution of a statement with the given fingerprint and, if so,
+// the request they are collected for. The request is claimed by the caller,
+// which must call InsertStatementDiagnostics once the collection is done,
+// whether it succeeded or not.
+func (r *Registry) ShouldCollectDiagnostics(fingerprint string) (RequestID, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, f := range r.mu.requests {
+		if f == fingerprint {
+			delete(r.mu.requests, id)
+			r.mu.ongoing[id] = struct{}{}
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// InsertStatementDiagnostics records the diagnostics collected for the given
+// request and completes it. The bundle is the zip file of the diagnostics and
+// trace is the JSON representation of the statement's trace; collectionErr
+// is the error encountered while collecting them, if any. If the request was
+// completed by another execution in the meantime, nothing is recorded and the
+// returned ID is 0.
+func (r *Registry) InsertStatementDiagnostics(
+	ctx context.Context,
+	requestID RequestID,
+	fingerprint string,
+	statement string,
+	trace tree.Datum,
+	bundle []byte,
+	collectionErr error,
+) (int64, error) {
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.mu.ongoing, requestID)
+	}()
+
+	var diagID int64
+	err := r.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		diagID = 0
+		row, err := r.ie.QueryRow(ctx, "stmt-diag-check-completed", txn,
+			"SELECT count(1) FROM system.statement_diagnostics_requests WHERE id = $1 AND completed = false",
+			requestID,
+		)
+		if err != nil {
+			return err
+		}
+		if *row[0].(*tree.DInt) == 0 {
+			// The request was completed by another execution.
+			return nil
+		}
+
+		chunks := tree.NewDArray(types.Int)
+		for len(bundle) > 0 {
+			chunk := bundle
+			if len(chunk) > bundleChunkSize {
+				chunk = chunk[:bundleChunkSize]
+			}
+			bundle = bundle[len(chunk):]
+			row, err := r.ie.QueryRow(ctx, "stmt-diag-insert-chunk", txn,
+				`INSERT INTO system.statement_bundle_chunks (description, data) VALUES ($1, $2) RETURNING id`,
+				"statement diagnostics bundle", tree.NewDBytes(tree.DBytes(chunk)),
+			)
+			if err != nil {
+				return err
+			}
+			if err := chunks.Append(row[0]); err != nil {
+				return err
+			}
+		}
+
+		errorDatum := tree.DNull
+		if collectionErr != nil {
+			errorDatum = tree.NewDString(collectionErr.Error())
+		}
+		if trace == nil {
+			trace = tree.DNull
+		}
+		row, err = r.ie.QueryRow(ctx, "stmt-diag-insert", txn,
+			`INSERT INTO system.statement_diagnostics
+				(statement_fingerprint, statement, collected_at, trace, bundle_chunks, error)
+			VALUES ($1, $2, now(), $3, $4, $5) RETURNING id`,
+			fingerprint, statement, trace, chunks, errorDatum,
+		)
+		if err != nil {
+			return err
+		}
+		diagID = int64(*row[0].(*tree.DInt))
+
+		_, err = r.ie.Exec(ctx, "stmt-diag-mark-completed", txn,
+			`UPDATE system.statement_diagnostics_requests
+			SET completed = true, statement_diagnostics_id = $1 WHERE id = $2`,
+			diagID, requestID,
+		)
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "recording statement diagnostics for request %d", requestID)
+	}
+	return diagID, nil
+}
+
+// pollRequests reads the pending requests from the system table.
+func (r *Registry) pollRequests(ctx context.Context) error {
+	if !cluster.Version.IsActive(ctx, r.st, cluster.VersionStatementDiagnostics) {
+		return nil
+	}
+	rows, err := r.ie.Query(ctx, "stmt-diag-poll", nil, /* txn */
+		`SELECT id, statement_fingerprint FROM system.statement_diagnostics_requests
+		WHERE completed = false`,
+	)
+	if err != nil {
+		return err
+	}
+	requests := make(map[RequestID]string, len(rows))
+	for _, row := range rows {
+		requests[RequestID(*row[0].(*tree.DInt))] = string(*row[1].(*tree.DString))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.mu.ongoing {
+		delete(requests, id)
+	}
+	r.mu.requests = requests
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stmtdiagnostics_test
+
+import (
+	"context"
+	gosql "database/sql"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/pkg/errors"
+)
+
+func TestDiagnosticsRequest(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 2, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+	db0 := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	db1 := sqlutils.MakeSQLRunner(tc.ServerConn(1))
+	db0.Exec(t, `SET CLUSTER SETTING sql.stmt_diagnostics.poll_interval = '10ms'`)
+	db0.Exec(t, `CREATE TABLE test (x INT PRIMARY KEY)`)
+
+	registry := tc.Server(0).ExecutorConfig().(sql.ExecutorConfig).StmtDiagnosticsRecorder
+
+	checkCompleted := func(id int64) error {
+		var completed bool
+		var diagID gosql.NullInt64
+		db0.QueryRow(t, `SELECT completed, statement_diagnostics_id
+			FROM system.statement_diagnostics_requests WHERE id = $1`, id,
+		).Scan(&completed, &diagID)
+		if !completed {
+			return errors.Errorf("request %d not completed", id)
+		}
+		if !diagID.Valid {
+			return errors.Errorf("request %d completed without diagnostics", id)
+		}
+		var statement string
+		var hasTrace, hasBundle bool
+		db0.QueryRow(t, `SELECT statement, trace IS NOT NULL, array_length(bundle_chunks, 1) > 0
+			FROM system.statement_diagnostics WHERE id = $1`, diagID.Int64,
+		).Scan(&statement, &hasTrace, &hasBundle)
+		if statement != "INSERT INTO test VALUES (1)" && statement != "INSERT INTO test VALUES (2)" {
+			return errors.Errorf("unexpected statement %q", statement)
+		}
+		if !hasTrace || !hasBundle {
+			return errors.Errorf("missing trace or bundle for request %d", id)
+		}
+		return nil
+	}
+
+	// The statement executed on the node the request was registered on.
+	id, err := registry.InsertRequest(ctx, "INSERT INTO test VALUES (_)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db0.Exec(t, `SELECT x FROM test`)
+	db0.Exec(t, `INSERT INTO test VALUES (1)`)
+	// The bundle is built and inserted asynchronously.
+	testutils.SucceedsSoon(t, func() error {
+		return checkCompleted(int64(id))
+	})
+
+	// The statement executed on another node, once it has polled the request.
+	id, err = registry.InsertRequest(ctx, "INSERT INTO test VALUES (_)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.SucceedsSoon(t, func() error {
+		db1.Exec(t, `DELETE FROM test WHERE x = 2`)
+		db1.Exec(t, `INSERT INTO test VALUES (2)`)
+		return checkCompleted(int64(id))
+	})
+}
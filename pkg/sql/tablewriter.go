@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -126,7 +127,11 @@ func (tb *tableWriterBase) init(txn *client.Txn) {
 func (tb *tableWriterBase) flushAndStartNewBatch(
 	ctx context.Context, tableDesc *sqlbase.ImmutableTableDescriptor,
 ) error {
-	if err := tb.txn.Run(ctx, tb.b); err != nil {
+	timer := execstats.ExecTimerFromContext(ctx)
+	waitStart := timer.StartWait()
+	err := tb.txn.Run(ctx, tb.b)
+	timer.RecordWait(waitStart)
+	if err != nil {
 		return row.ConvertBatchError(ctx, tableDesc, tb.b)
 	}
 	tb.b = tb.txn.NewBatch()
@@ -141,6 +146,8 @@ func (tb *tableWriterBase) curBatchSize() int { return tb.batchSize }
 func (tb *tableWriterBase) finalize(
 	ctx context.Context, tableDesc *sqlbase.ImmutableTableDescriptor,
 ) (err error) {
+	timer := execstats.ExecTimerFromContext(ctx)
+	waitStart := timer.StartWait()
 	if tb.autoCommit == autoCommitEnabled {
 		log.Event(ctx, "autocommit enabled")
 		// An auto-txn can commit the transaction with the batch. This is an
@@ -150,6 +157,7 @@ func (tb *tableWriterBase) finalize(
 	} else {
 		err = tb.txn.Run(ctx, tb.b)
 	}
+	timer.RecordWait(waitStart)
 
 	if err != nil {
 		return row.ConvertBatchError(ctx, tableDesc, tb.b)
@@ -244,16 +244,25 @@ func (td *tableDeleter) deleteIndexFast(
 	return td.b.Results[0].ResumeSpanAsValue(), nil
 }
 
-func (td *tableDeleter) clearIndex(ctx context.Context, idx *sqlbase.IndexDescriptor) error {
+// clearIndex removes all the data of the index. If useRangeTombstones is set,
+// the data is deleted with MVCC range tombstones, which unlike ClearRange keep
+// the deletion visible to incremental backups and changefeeds.
+func (td *tableDeleter) clearIndex(
+	ctx context.Context, idx *sqlbase.IndexDescriptor, useRangeTombstones bool,
+) error {
 	if idx.IsInterleaved() {
 		return errors.Errorf("unexpected interleaved index %d", idx.ID)
 	}
 
 	sp := td.rd.Helper.TableDesc.IndexSpan(idx.ID)
 
-	// ClearRange cannot be run in a transaction, so create a
-	// non-transactional batch to send the request.
+	// ClearRange and range tombstones cannot be used in a transaction, so
+	// create a non-transactional batch to send the request.
 	b := &client.Batch{}
+	if useRangeTombstones {
+		b.DelRangeUsingTombstone(sp.Key, sp.EndKey)
+		return td.txn.DB().Run(ctx, b)
+	}
 	b.AddRawRequest(&roachpb.ClearRangeRequest{
 		RequestHeader: roachpb.RequestHeader{
 			Key:    sp.Key,
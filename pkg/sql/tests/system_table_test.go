@@ -120,6 +120,14 @@ func TestSystemTableLiterals(t *testing.T) {
 		{keys.ProtectedTimestampsMetaTableID, sqlbase.ProtectedTimestampsMetaTableSchema, sqlbase.ProtectedTimestampsMetaTable},
 		{keys.ProtectedTimestampsRecordsTableID, sqlbase.ProtectedTimestampsRecordsTableSchema, sqlbase.ProtectedTimestampsRecordsTable},
 		{keys.RoleOptionsTableID, sqlbase.RoleOptionsTableSchema, sqlbase.RoleOptionsTable},
+		{keys.ScheduledJobsTableID, sqlbase.ScheduledJobsTableSchema, sqlbase.ScheduledJobsTable},
+		{keys.JobMessagesTableID, sqlbase.JobMessagesTableSchema, sqlbase.JobMessagesTable},
+		{keys.JobProcessorProgressTableID, sqlbase.JobProcessorProgressTableSchema, sqlbase.JobProcessorProgressTable},
+		{keys.StatementBundleChunksTableID, sqlbase.StatementBundleChunksTableSchema, sqlbase.StatementBundleChunksTable},
+		{keys.StatementDiagnosticsRequestsTableID, sqlbase.StatementDiagnosticsRequestsTableSchema, sqlbase.StatementDiagnosticsRequestsTable},
+		{keys.StatementDiagnosticsTableID, sqlbase.StatementDiagnosticsTableSchema, sqlbase.StatementDiagnosticsTable},
+		{keys.StatementStatisticsTableID, sqlbase.StatementStatisticsTableSchema, sqlbase.StatementStatisticsTable},
+		{keys.TransactionStatisticsTableID, sqlbase.TransactionStatisticsTableSchema, sqlbase.TransactionStatisticsTable},
 	} {
 		privs := *test.pkg.Privileges
 		gen, err := sql.CreateTestTableDescriptor(
@@ -12,6 +12,7 @@ package sql
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
@@ -124,6 +125,32 @@ const (
 	explicitTxn
 )
 
+// txnTraceOptions are the options of the span of a new SQL transaction.
+type txnTraceOptions struct {
+	// remoteParent, if set, is the span context of the client which started
+	// the transaction. The transaction's span is its child.
+	remoteParent opentracing.SpanContext
+	// samplingHint, if set, informs the sampling decision of the transaction's
+	// trace when its span is a root span.
+	samplingHint *tracing.SamplingHint
+}
+
+// traceParentCommentRE matches a trace context in a statement's comment, in
+// the format of sqlcommenter, e.g.
+// /*traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'*/.
+var traceParentCommentRE = regexp.MustCompile(`(?:^/\*|,)\s*traceparent\s*=\s*'([^']*)'`)
+
+// traceParentFromComments returns the trace context found in the given
+// statement comments, if any.
+func traceParentFromComments(comments []string) (string, bool) {
+	for _, c := range comments {
+		if m := traceParentCommentRE.FindStringSubmatch(c); m != nil {
+			return m[1], true
+		}
+	}
+	return "", false
+}
+
 // resetForNewSQLTxn (re)initializes the txnState for a new transaction.
 // It creates a new client.Txn and initializes it using the session defaults.
 //
@@ -136,6 +163,7 @@ const (
 //   and should be fixed to this timestamp.
 // priority: The transaction's priority.
 // readOnly: The read-only character of the new txn.
+// traceOpts: The options of the txn's span.
 // txn: If not nil, this txn will be used instead of creating a new txn. If so,
 //      all the other arguments need to correspond to the attributes of this txn.
 // tranCtx: A bag of extra execution context.
@@ -146,6 +174,7 @@ func (ts *txnState) resetForNewSQLTxn(
 	historicalTimestamp *hlc.Timestamp,
 	priority roachpb.UserPriority,
 	readOnly tree.ReadWriteMode,
+	traceOpts txnTraceOptions,
 	txn *client.Txn,
 	tranCtx transitionCtx,
 ) {
@@ -173,10 +202,17 @@ func (ts *txnState) resetForNewSQLTxn(
 			opentracing.ChildOf(parentSp.Context()), tracing.Recordable,
 			tracing.LogTagsFromCtx(connCtx),
 		)
+	} else if traceOpts.remoteParent != nil {
+		// Create a child span of the client's span for this SQL txn.
+		sp = tranCtx.tracer.StartSpan(
+			opName,
+			opentracing.ChildOf(traceOpts.remoteParent), tracing.Recordable,
+			tracing.LogTagsFromCtx(connCtx),
+		)
 	} else {
 		// Create a root span for this SQL txn.
-		sp = tranCtx.tracer.(*tracing.Tracer).StartRootSpan(
-			opName, logtags.FromContext(connCtx), tracing.RecordableSpan)
+		sp = tranCtx.tracer.(*tracing.Tracer).StartRootSpanWithHint(
+			opName, logtags.FromContext(connCtx), tracing.RecordableSpan, traceOpts.samplingHint)
 	}
 
 	if txnType == implicitTxn {
@@ -789,3 +789,25 @@ func TestTransitions(t *testing.T) {
 		})
 	}
 }
+
+func TestTraceParentFromComments(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testCases := []struct {
+		comments []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"/* hello */"}, ""},
+		{[]string{"/*traceparent='" + traceParent + "'*/"}, traceParent},
+		{[]string{"/* a */", "/*action='run',traceparent='" + traceParent + "',tracestate='x'*/"}, traceParent},
+		{[]string{"/*action='traceparent=x'*/"}, ""},
+	}
+	for i, tc := range testCases {
+		tp, ok := traceParentFromComments(tc.comments)
+		if ok != (tc.expected != "") || tp != tc.expected {
+			t.Errorf("%d: expected %q, got %q", i, tc.expected, tp)
+		}
+	}
+}
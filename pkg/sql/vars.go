@@ -802,6 +802,26 @@ var varGen = map[string]sessionVar{
 		GlobalDefault: globalFalse,
 	},
 
+	// CockroachDB extension. The W3C trace context of the client, whose trace
+	// the session's transactions are part of; a traceparent in the comments
+	// of the statement starting a transaction takes precedence.
+	`traceparent`: {
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			if s != "" {
+				if err := tracing.ValidateTraceParent(s); err != nil {
+					return errors.WithHint(newVarValueError(`traceparent`, s),
+						"expected a W3C trace context: 00-<trace id>-<parent id>-<flags>")
+				}
+			}
+			m.SetTraceParent(s)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return evalCtx.SessionData.TraceParent
+		},
+		GlobalDefault: func(_ *settings.Values) string { return "" },
+	},
+
 	// CockroachDB extension.
 	`tracing`: {
 		Get: func(evalCtx *extendedEvalContext) string {
@@ -850,6 +850,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&commentOnIndexNode{}):       "comment on index",
 	reflect.TypeOf(&commentOnTableNode{}):       "comment on table",
 	reflect.TypeOf(&controlJobsNode{}):          "control jobs",
+	reflect.TypeOf(&controlSchedulesNode{}):     "control schedules",
 	reflect.TypeOf(&createDatabaseNode{}):       "create database",
 	reflect.TypeOf(&createIndexNode{}):          "create index",
 	reflect.TypeOf(&createSequenceNode{}):       "create sequence",
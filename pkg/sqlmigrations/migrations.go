@@ -296,6 +296,45 @@ var backwardCompatibleMigrations = []migrationDescriptor{
 		includedInBootstrap: cluster.VersionByKey(cluster.VersionCreateRolePrivilege),
 		newDescriptorIDs:    staticIDs(keys.RoleOptionsTableID),
 	},
+	{
+		// Introduced in v20.1.
+		name:                "create system.scheduled_jobs table",
+		workFn:              createScheduledJobsTable,
+		includedInBootstrap: cluster.VersionByKey(cluster.VersionScheduledJobs),
+		newDescriptorIDs:    staticIDs(keys.ScheduledJobsTableID),
+	},
+	{
+		// Introduced in v20.1.
+		name:                "create system.job_messages and system.job_processor_progress tables",
+		workFn:              createJobObservabilityTables,
+		includedInBootstrap: cluster.VersionByKey(cluster.VersionJobObservability),
+		newDescriptorIDs:    staticIDs(keys.JobMessagesTableID, keys.JobProcessorProgressTableID),
+	},
+	{
+		// Introduced in v20.1.
+		name:                "create statement diagnostics tables",
+		workFn:              createStatementDiagnosticsTables,
+		includedInBootstrap: cluster.VersionByKey(cluster.VersionStatementDiagnostics),
+		newDescriptorIDs: staticIDs(
+			keys.StatementBundleChunksTableID,
+			keys.StatementDiagnosticsRequestsTableID,
+			keys.StatementDiagnosticsTableID,
+		),
+	},
+	{
+		// Introduced in v20.1.
+		name:                "create system.statement_statistics table",
+		workFn:              createStatementStatisticsTable,
+		includedInBootstrap: cluster.VersionByKey(cluster.VersionPersistedSQLStats),
+		newDescriptorIDs:    staticIDs(keys.StatementStatisticsTableID),
+	},
+	{
+		// Introduced in v20.1.
+		name:                "create system.transaction_statistics table",
+		workFn:              createTransactionStatisticsTable,
+		includedInBootstrap: cluster.VersionByKey(cluster.VersionPersistedTxnStats),
+		newDescriptorIDs:    staticIDs(keys.TransactionStatisticsTableID),
+	},
 }
 
 func staticIDs(ids ...sqlbase.ID) func(ctx context.Context, db db) ([]sqlbase.ID, error) {
@@ -706,6 +745,40 @@ func createProtectedTimestampsRecordsTable(ctx context.Context, r runner) error
 		"failed to create system.protected_ts_records")
 }
 
+func createScheduledJobsTable(ctx context.Context, r runner) error {
+	return errors.Wrap(createSystemTable(ctx, r, sqlbase.ScheduledJobsTable),
+		"failed to create system.scheduled_jobs")
+}
+
+func createJobObservabilityTables(ctx context.Context, r runner) error {
+	if err := createSystemTable(ctx, r, sqlbase.JobMessagesTable); err != nil {
+		return errors.Wrap(err, "failed to create system.job_messages")
+	}
+	return errors.Wrap(createSystemTable(ctx, r, sqlbase.JobProcessorProgressTable),
+		"failed to create system.job_processor_progress")
+}
+
+func createStatementDiagnosticsTables(ctx context.Context, r runner) error {
+	if err := createSystemTable(ctx, r, sqlbase.StatementBundleChunksTable); err != nil {
+		return errors.Wrap(err, "failed to create system.statement_bundle_chunks")
+	}
+	if err := createSystemTable(ctx, r, sqlbase.StatementDiagnosticsRequestsTable); err != nil {
+		return errors.Wrap(err, "failed to create system.statement_diagnostics_requests")
+	}
+	return errors.Wrap(createSystemTable(ctx, r, sqlbase.StatementDiagnosticsTable),
+		"failed to create system.statement_diagnostics")
+}
+
+func createStatementStatisticsTable(ctx context.Context, r runner) error {
+	return errors.Wrap(createSystemTable(ctx, r, sqlbase.StatementStatisticsTable),
+		"failed to create system.statement_statistics")
+}
+
+func createTransactionStatisticsTable(ctx context.Context, r runner) error {
+	return errors.Wrap(createSystemTable(ctx, r, sqlbase.TransactionStatisticsTable),
+		"failed to create system.transaction_statistics")
+}
+
 func createNewSystemNamespaceDescriptor(ctx context.Context, r runner) error {
 
 	return r.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
@@ -57,6 +57,9 @@ const (
 	removeDeadReplicaPriority               float64 = 1000
 	removeDecommissioningReplicaPriority    float64 = 200
 	removeExtraReplicaPriority              float64 = 100
+	addMissingNonVoterPriority              float64 = 60
+	removeDeadNonVoterPriority              float64 = 50
+	removeExtraNonVoterPriority             float64 = 10
 )
 
 // MinLeaseTransferStatsDuration configures the minimum amount of time a
@@ -108,6 +111,9 @@ const (
 	AllocatorConsiderRebalance
 	AllocatorRangeUnavailable
 	AllocatorFinalizeAtomicReplicationChange
+	AllocatorAddNonVoter
+	AllocatorRemoveNonVoter
+	AllocatorRemoveDeadNonVoter
 )
 
 var allocatorActionNames = map[AllocatorAction]string{
@@ -122,6 +128,9 @@ var allocatorActionNames = map[AllocatorAction]string{
 	AllocatorConsiderRebalance:               "consider rebalance",
 	AllocatorRangeUnavailable:                "range unavailable",
 	AllocatorFinalizeAtomicReplicationChange: "finalize conf change",
+	AllocatorAddNonVoter:                     "add non-voter",
+	AllocatorRemoveNonVoter:                  "remove non-voter",
+	AllocatorRemoveDeadNonVoter:              "remove dead non-voter",
 }
 
 func (a AllocatorAction) String() string {
@@ -355,7 +364,13 @@ func (a *Allocator) ComputeAction(
 		return AllocatorRemoveLearner, removeLearnerReplicaPriority
 	}
 	// computeAction expects to operate only on voters.
-	return a.computeAction(ctx, zone, desc.RangeID, desc.Replicas().Voters())
+	action, priority := a.computeAction(ctx, zone, desc.RangeID, desc.Replicas().Voters())
+	if action != AllocatorConsiderRebalance {
+		return action, priority
+	}
+	// Non-voters are only considered once the voters are in order, since they
+	// don't contribute to the range's availability.
+	return a.computeNonVoterAction(ctx, zone, desc)
 }
 
 func (a *Allocator) computeAction(
@@ -456,6 +471,53 @@ func (a *Allocator) computeAction(
 	return AllocatorConsiderRebalance, 0
 }
 
+// computeNonVoterAction determines whether non-voting replicas need to be
+// added to or removed from the range. It returns AllocatorConsiderRebalance if
+// the range has the number of live non-voters its zone config asks for.
+func (a *Allocator) computeNonVoterAction(
+	ctx context.Context, zone *zonepb.ZoneConfig, desc *roachpb.RangeDescriptor,
+) (AllocatorAction, float64) {
+	nonVoterReplicas := desc.Replicas().NonVoters()
+	have := len(nonVoterReplicas)
+	// A node can hold at most one replica of a range, so there's no point in
+	// asking for more non-voters than there are nodes without a voter.
+	need := int(zone.GetNumNonVoters())
+	if maxNeed := a.storePool.ClusterNodeCount() - len(desc.Replicas().Voters()); need > maxNeed {
+		need = maxNeed
+	}
+	if need < 0 {
+		need = 0
+	}
+
+	if have < need {
+		priority := addMissingNonVoterPriority
+		action := AllocatorAddNonVoter
+		log.VEventf(ctx, 3, "%s - missing non-voter need=%d, have=%d, priority=%.2f",
+			action, need, have, priority)
+		return action, priority
+	}
+
+	_, deadNonVoterReplicas := a.storePool.liveAndDeadReplicas(desc.RangeID, nonVoterReplicas)
+	if len(deadNonVoterReplicas) > 0 {
+		// Unlike dead voters, dead non-voters are removed before being replaced
+		// since they don't count towards the range's quorum.
+		priority := removeDeadNonVoterPriority
+		action := AllocatorRemoveDeadNonVoter
+		log.VEventf(ctx, 3, "%s - dead=%d, priority=%.2f",
+			action, len(deadNonVoterReplicas), priority)
+		return action, priority
+	}
+
+	if have > need {
+		priority := removeExtraNonVoterPriority
+		action := AllocatorRemoveNonVoter
+		log.VEventf(ctx, 3, "%s - need=%d, have=%d, priority=%.2f", action, need, have, priority)
+		return action, priority
+	}
+
+	return AllocatorConsiderRebalance, 0
+}
+
 type decisionDetails struct {
 	Target   string
 	Existing string `json:",omitempty"`
@@ -498,6 +560,52 @@ func (a *Allocator) AllocateTarget(
 	}
 }
 
+// AllocateNonVoterTarget returns a suitable store for a new non-voting replica.
+// Non-voters are placed according to the zone's non-voter constraints (see
+// ZoneConfig.NonVoterPlacementConfig), and nodes holding any existing replica,
+// voting or not, are ruled out as targets.
+func (a *Allocator) AllocateNonVoterTarget(
+	ctx context.Context,
+	zone *zonepb.ZoneConfig,
+	rangeID roachpb.RangeID,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+) (*roachpb.StoreDescriptor, string, error) {
+	sl, aliveStoreCount, throttled := a.storePool.getStoreList(rangeID, storeFilterThrottled)
+
+	placement := zone.NonVoterPlacementConfig()
+	analyzedConstraints := constraint.AnalyzeConstraints(
+		ctx, a.storePool.getStoreDescriptor, existingNonVoters, placement)
+	existingReplicas := append(
+		append([]roachpb.ReplicaDescriptor(nil), existingVoters...), existingNonVoters...)
+	target, details := a.selectAllocateTarget(
+		ctx, sl, analyzedConstraints, existingReplicas, a.scorerOptions())
+
+	if target != nil {
+		return target, details, nil
+	}
+
+	if len(throttled) > 0 {
+		return nil, "", errors.Errorf(
+			"%d matching stores are currently throttled: %v", len(throttled), throttled,
+		)
+	}
+	return nil, "", &allocatorError{
+		constraints:      placement.Constraints,
+		existingReplicas: len(existingReplicas),
+		aliveStores:      aliveStoreCount,
+		throttledStores:  len(throttled),
+	}
+}
+
+// RemoveNonVoterTarget returns a non-voting replica to remove from the range,
+// chosen among the existing non-voters according to the zone's non-voter
+// constraints.
+func (a Allocator) RemoveNonVoterTarget(
+	ctx context.Context, zone *zonepb.ZoneConfig, existingNonVoters []roachpb.ReplicaDescriptor,
+) (roachpb.ReplicaDescriptor, string, error) {
+	return a.RemoveTarget(ctx, zone.NonVoterPlacementConfig(), existingNonVoters, existingNonVoters)
+}
+
 func (a *Allocator) allocateTargetFromList(
 	ctx context.Context,
 	sl StoreList,
@@ -507,8 +615,20 @@ func (a *Allocator) allocateTargetFromList(
 ) (*roachpb.StoreDescriptor, string) {
 	analyzedConstraints := constraint.AnalyzeConstraints(
 		ctx, a.storePool.getStoreDescriptor, candidateReplicas, zone)
+	return a.selectAllocateTarget(ctx, sl, analyzedConstraints, candidateReplicas, options)
+}
+
+// selectAllocateTarget picks a store from the list that satisfies the given
+// constraints and doesn't share a node with any of the existing replicas.
+func (a *Allocator) selectAllocateTarget(
+	ctx context.Context,
+	sl StoreList,
+	analyzedConstraints constraint.AnalyzedConstraints,
+	existingReplicas []roachpb.ReplicaDescriptor,
+	options scorerOptions,
+) (*roachpb.StoreDescriptor, string) {
 	candidates := allocateCandidates(
-		sl, analyzedConstraints, candidateReplicas, a.storePool.getLocalities(candidateReplicas),
+		sl, analyzedConstraints, existingReplicas, a.storePool.getLocalities(existingReplicas),
 		options,
 	)
 	log.VEventf(ctx, 3, "allocate candidates: %s", candidates)
@@ -4823,6 +4823,93 @@ func TestAllocatorRemoveLearner(t *testing.T) {
 	require.Equal(t, AllocatorRemoveLearner, action)
 }
 
+func TestAllocatorComputeActionNonVoters(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	zone := zonepb.ZoneConfig{
+		NumReplicas:  proto.Int32(3),
+		NumNonVoters: proto.Int32(2),
+	}
+	desc := func(voters []roachpb.StoreID, nonVoters ...roachpb.StoreID) *roachpb.RangeDescriptor {
+		repls := replicas(append(append([]roachpb.StoreID(nil), voters...), nonVoters...)...)
+		for i := len(voters); i < len(repls); i++ {
+			repls[i].Type = roachpb.ReplicaTypeNonVoter()
+		}
+		return &roachpb.RangeDescriptor{InternalReplicas: repls}
+	}
+
+	testCases := []struct {
+		zone     *zonepb.ZoneConfig
+		desc     *roachpb.RangeDescriptor
+		expected AllocatorAction
+	}{
+		// Voters are taken care of before non-voters.
+		{&zone, desc([]roachpb.StoreID{1, 2}), AllocatorAdd},
+		{&zone, desc([]roachpb.StoreID{1, 2, 3}), AllocatorAddNonVoter},
+		{&zone, desc([]roachpb.StoreID{1, 2, 3}, 4), AllocatorAddNonVoter},
+		{&zone, desc([]roachpb.StoreID{1, 2, 3}, 4, 5), AllocatorConsiderRebalance},
+		{&zone, desc([]roachpb.StoreID{1, 2, 3}, 4, 5, 6), AllocatorRemoveNonVoter},
+		{&zone, desc([]roachpb.StoreID{1, 2, 3}, 4, 7), AllocatorRemoveDeadNonVoter},
+		{&simpleZoneConfig, desc([]roachpb.StoreID{1, 2, 3}, 4), AllocatorRemoveNonVoter},
+	}
+
+	stopper, _, sp, a, _ := createTestAllocator(10, false /* deterministic */)
+	ctx := context.Background()
+	defer stopper.Stop(ctx)
+	live, dead := []roachpb.StoreID{1, 2, 3, 4, 5, 6}, []roachpb.StoreID{7}
+	mockStorePool(sp, live, nil, dead, nil, nil)
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			action, _ := a.ComputeAction(ctx, tc.zone, tc.desc)
+			require.Equal(t, tc.expected, action, "%s", tc.desc)
+		})
+	}
+}
+
+func TestAllocatorNonVoterTargets(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	stopper, g, _, a, _ := createTestAllocator(10, false /* deterministic */)
+	ctx := context.Background()
+	defer stopper.Stop(ctx)
+	sg := gossiputil.NewStoreGossiper(g)
+	sg.GossipStores(multiDiversityDCStores, t)
+
+	zone := zonepb.ZoneConfig{
+		NumReplicas:  proto.Int32(3),
+		NumNonVoters: proto.Int32(2),
+		NonVoterConstraints: []zonepb.Constraints{{
+			Constraints: []zonepb.Constraint{
+				{Type: zonepb.Constraint_REQUIRED, Key: "datacenter", Value: "d"},
+			},
+		}},
+	}
+	voters := replicas(1, 3, 5)
+
+	// The non-voter has to go to datacenter d.
+	for i := 0; i < 10; i++ {
+		target, details, err := a.AllocateNonVoterTarget(ctx, &zone, firstRangeID, voters, nil)
+		require.NoError(t, err)
+		if target.StoreID != 7 && target.StoreID != 8 {
+			t.Fatalf("expected non-voter on s7 or s8, got s%d; details: %s", target.StoreID, details)
+		}
+	}
+
+	// Nodes that already hold a replica are ruled out.
+	target, _, err := a.AllocateNonVoterTarget(ctx, &zone, firstRangeID, voters, replicas(7))
+	require.NoError(t, err)
+	require.Equal(t, roachpb.StoreID(8), target.StoreID)
+
+	_, _, err = a.AllocateNonVoterTarget(ctx, &zone, firstRangeID, voters, replicas(7, 8))
+	require.Error(t, err)
+
+	// A non-voter violating the constraints is the one to be removed.
+	removed, _, err := a.RemoveNonVoterTarget(ctx, &zone, replicas(7, 2))
+	require.NoError(t, err)
+	require.Equal(t, roachpb.StoreID(2), removed.StoreID)
+}
+
 func TestAllocatorComputeActionDynamicNumReplicas(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -555,7 +555,7 @@ func TestSpanSetMVCCResolveWriteIntentRangeUsingIter(t *testing.T) {
 	defer iterAndBuf.Cleanup()
 
 	if _, _, err := engine.MVCCResolveWriteIntentRangeUsingIter(
-		ctx, batch, iterAndBuf, nil /* ms */, intent, 0,
+		ctx, batch, iterAndBuf, nil /* ms */, intent, 0, nil, /* rangeTombstones */
 	); err != nil {
 		t.Fatal(err)
 	}
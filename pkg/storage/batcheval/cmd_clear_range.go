@@ -43,6 +43,13 @@ func declareKeysClearRange(
 	// We look up the range descriptor key to check whether the span
 	// is equal to the entire range for fast stats updating.
 	spans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keys.RangeDescriptorKey(desc.StartKey)})
+	// The MVCC range tombstones covering the span are removed along with the
+	// data they delete.
+	tombstonePrefix := keys.MVCCRangeTombstonePrefix(header.RangeID)
+	spans.AddNonMVCC(spanset.SpanReadWrite, roachpb.Span{
+		Key:    tombstonePrefix,
+		EndKey: tombstonePrefix.PrefixEnd(),
+	})
 }
 
 // ClearRange wipes all MVCC versions of keys covered by the specified
@@ -73,6 +80,13 @@ func ClearRange(
 	}
 	cArgs.Stats.Subtract(statsDelta)
 
+	// The MVCC range tombstones covering the span have nothing left to delete.
+	if err := engine.MVCCClearRangeTombstones(
+		ctx, readWriter, cArgs.Stats, cArgs.EvalCtx.GetRangeID(), roachpb.Span{Key: from, EndKey: to},
+	); err != nil {
+		return result.Result{}, err
+	}
+
 	// If the total size of data to be cleared is less than
 	// clearRangeBytesThreshold, clear the individual values manually,
 	// instead of using a range tombstone (inefficient for small ranges).
@@ -143,6 +157,18 @@ func computeStatsDelta(
 		if err != nil {
 			return enginepb.MVCCStats{}, err
 		}
+		// Data deleted by range tombstones is not live.
+		tombstones, err := LoadRangeTombstones(
+			ctx, readWriter, cArgs, roachpb.Span{Key: from, EndKey: to})
+		if err != nil {
+			return enginepb.MVCCStats{}, err
+		}
+		tombstoneMS, err := engine.ComputeRangeTombstoneStats(
+			readWriter, tombstones, from, to, delta.LastUpdateNanos)
+		if err != nil {
+			return enginepb.MVCCStats{}, err
+		}
+		computed.Add(tombstoneMS)
 		// If we took the fast path but race is enabled, assert stats were correctly computed.
 		if fast {
 			delta.ContainsEstimates = computed.ContainsEstimates
@@ -19,7 +19,7 @@ import (
 )
 
 func init() {
-	RegisterReadWriteCommand(roachpb.ConditionalPut, DefaultDeclareKeys, ConditionalPut)
+	RegisterReadWriteCommand(roachpb.ConditionalPut, declareKeysMVCC, ConditionalPut)
 }
 
 // ConditionalPut sets the value for a specified key only if
@@ -31,6 +31,10 @@ func ConditionalPut(
 	args := cArgs.Args.(*roachpb.ConditionalPutRequest)
 	h := cArgs.Header
 
+	rangeTombstones, err := LoadRangeTombstones(ctx, readWriter, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
 	if h.DistinctSpans {
 		if b, ok := readWriter.(engine.Batch); ok {
 			// Use the distinct batch for both blind and normal ops so that we don't
@@ -42,7 +46,7 @@ func ConditionalPut(
 	}
 	handleMissing := engine.CPutMissingBehavior(args.AllowIfDoesNotExist)
 	if args.Blind {
-		return result.Result{}, engine.MVCCBlindConditionalPut(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, args.Value, args.ExpValue, handleMissing, h.Txn)
+		return result.Result{}, engine.MVCCBlindConditionalPut(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, args.Value, args.ExpValue, handleMissing, h.Txn, rangeTombstones)
 	}
-	return result.Result{}, engine.MVCCConditionalPut(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, args.Value, args.ExpValue, handleMissing, h.Txn)
+	return result.Result{}, engine.MVCCConditionalPut(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, args.Value, args.ExpValue, handleMissing, h.Txn, rangeTombstones)
 }
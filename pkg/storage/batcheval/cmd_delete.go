@@ -19,7 +19,7 @@ import (
 )
 
 func init() {
-	RegisterReadWriteCommand(roachpb.Delete, DefaultDeclareKeys, Delete)
+	RegisterReadWriteCommand(roachpb.Delete, declareKeysMVCC, Delete)
 }
 
 // Delete deletes the key and value specified by key.
@@ -29,5 +29,10 @@ func Delete(
 	args := cArgs.Args.(*roachpb.DeleteRequest)
 	h := cArgs.Header
 
-	return result.Result{}, engine.MVCCDelete(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, h.Txn)
+	rangeTombstones, err := LoadRangeTombstones(ctx, readWriter, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
+	return result.Result{}, engine.MVCCDeleteWithRangeTombstones(
+		ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, h.Txn, rangeTombstones)
 }
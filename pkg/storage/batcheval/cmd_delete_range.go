@@ -15,10 +15,12 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
 )
 
 func init() {
@@ -36,6 +38,12 @@ func declareKeysDeleteRange(
 	} else {
 		spans.AddMVCC(access, req.Header().Span(), header.Timestamp)
 	}
+	if args.UseRangeTombstone {
+		prefix := keys.MVCCRangeTombstonePrefix(header.RangeID)
+		spans.AddNonMVCC(access, roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()})
+	} else if !args.Inline {
+		DeclareKeysRangeTombstones(header, spans)
+	}
 }
 
 // DeleteRange deletes the range of key/value pairs specified by
@@ -47,12 +55,43 @@ func DeleteRange(
 	h := cArgs.Header
 	reply := resp.(*roachpb.DeleteRangeResponse)
 
+	if args.UseRangeTombstone {
+		if h.Txn != nil {
+			return result.Result{}, errors.New("MVCC range tombstones cannot be written transactionally")
+		}
+		if args.Inline || args.ReturnKeys {
+			return result.Result{}, errors.New(
+				"MVCC range tombstones cannot be combined with inline or returned keys")
+		}
+		if !cluster.Version.IsActive(ctx, cArgs.EvalCtx.ClusterSettings(), cluster.VersionMVCCRangeTombstones) {
+			return result.Result{}, errors.New("MVCC range tombstones are not supported until upgrade is finalized")
+		}
+		if err := engine.MVCCPutRangeTombstone(ctx, readWriter, cArgs.Stats,
+			cArgs.EvalCtx.GetRangeID(), engine.MVCCRangeTombstone{
+				StartKey:  args.Key,
+				EndKey:    args.EndKey,
+				Timestamp: h.Timestamp,
+			}); err != nil {
+			return result.Result{}, err
+		}
+		var res result.Result
+		res.Replicated.AddedRangeTombstones = true
+		return res, nil
+	}
+
 	var timestamp hlc.Timestamp
+	var rangeTombstones engine.MVCCRangeTombstones
 	if !args.Inline {
 		timestamp = h.Timestamp
+		var err error
+		rangeTombstones, err = LoadRangeTombstones(ctx, readWriter, cArgs, args.Span())
+		if err != nil {
+			return result.Result{}, err
+		}
 	}
 	deleted, resumeSpan, num, err := engine.MVCCDeleteRange(
 		ctx, readWriter, cArgs.Stats, args.Key, args.EndKey, h.MaxSpanRequestKeys, timestamp, h.Txn, args.ReturnKeys,
+		rangeTombstones,
 	)
 	if err == nil {
 		reply.Keys = deleted
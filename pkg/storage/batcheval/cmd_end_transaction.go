@@ -77,6 +77,8 @@ func declareKeysEndTxn(
 		// the range descriptor because they need to determine which intents are
 		// within the local range.
 		spans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keys.RangeDescriptorKey(desc.StartKey)})
+		// Resolving local intents takes the MVCC range tombstones into account.
+		DeclareKeysRangeTombstones(header, spans)
 
 		// The spans may extend beyond this Range, but it's ok for the
 		// purpose of acquiring latches. The parts in our Range will
@@ -142,14 +144,28 @@ func declareKeysEndTxn(
 					Key:    abortspan.MinKey(header.RangeID),
 					EndKey: abortspan.MaxKey(header.RangeID),
 				})
+
+				// Splits hand the MVCC range tombstones covering the RHS
+				// over to the RHS.
+				leftTombstonePrefix := keys.MVCCRangeTombstonePrefix(header.RangeID)
+				spans.AddNonMVCC(spanset.SpanReadWrite, roachpb.Span{
+					Key:    leftTombstonePrefix,
+					EndKey: leftTombstonePrefix.PrefixEnd(),
+				})
 			}
 			if mt := et.InternalCommitTrigger.MergeTrigger; mt != nil {
-				// Merges copy over the RHS abort span to the LHS, and compute
-				// replicated range ID stats over the RHS in the merge trigger.
+				// Merges copy over the RHS abort span and MVCC range
+				// tombstones to the LHS, and compute replicated range ID stats
+				// over the RHS in the merge trigger.
 				spans.AddNonMVCC(spanset.SpanReadWrite, roachpb.Span{
 					Key:    abortspan.MinKey(mt.LeftDesc.RangeID),
 					EndKey: abortspan.MaxKey(mt.LeftDesc.RangeID).PrefixEnd(),
 				})
+				leftTombstonePrefix := keys.MVCCRangeTombstonePrefix(mt.LeftDesc.RangeID)
+				spans.AddNonMVCC(spanset.SpanReadWrite, roachpb.Span{
+					Key:    leftTombstonePrefix,
+					EndKey: leftTombstonePrefix.PrefixEnd(),
+				})
 				spans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{
 					Key:    keys.MakeRangeIDReplicatedPrefix(mt.RightDesc.RangeID),
 					EndKey: keys.MakeRangeIDReplicatedPrefix(mt.RightDesc.RangeID).PrefixEnd(),
@@ -463,6 +479,15 @@ func resolveLocalIntents(
 		desc = &mergeTrigger.LeftDesc
 	}
 
+	var rangeTombstones engine.MVCCRangeTombstones
+	if evalCtx.HasRangeTombstones() {
+		var err error
+		rangeTombstones, err = engine.MVCCGetRangeTombstones(ctx, readWriter, evalCtx.GetRangeID())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	iter := readWriter.NewIterator(engine.IterOptions{
 		UpperBound: desc.EndKey.AsRawKey(),
 	})
@@ -491,7 +516,8 @@ func resolveLocalIntents(
 					return nil
 				}
 				resolveMS := ms
-				ok, err := engine.MVCCResolveWriteIntentUsingIter(ctx, readWriter, iterAndBuf, resolveMS, intent)
+				ok, err := engine.MVCCResolveWriteIntentUsingIter(
+					ctx, readWriter, iterAndBuf, resolveMS, intent, rangeTombstones)
 				if ok {
 					resolveAllowance--
 				}
@@ -504,7 +530,8 @@ func resolveLocalIntents(
 			externalIntents = append(externalIntents, outSpans...)
 			if inSpan != nil {
 				intent.Span = *inSpan
-				num, resumeSpan, err := engine.MVCCResolveWriteIntentRangeUsingIter(ctx, readWriter, iterAndBuf, ms, intent, resolveAllowance)
+				num, resumeSpan, err := engine.MVCCResolveWriteIntentRangeUsingIter(
+					ctx, readWriter, iterAndBuf, ms, intent, resolveAllowance, rangeTombstones)
 				if err != nil {
 					return err
 				}
@@ -841,6 +868,24 @@ func splitTrigger(
 			split.RightDesc.StartKey, split.RightDesc.EndKey, desc)
 	}
 
+	// Hand the parts of the MVCC range tombstones that cover the RHS over to
+	// the RHS. This must happen before the LHS stats are computed, since it
+	// modifies the LHS.
+	rightSpan := roachpb.Span{
+		Key:    split.RightDesc.StartKey.AsRawKey(),
+		EndKey: split.RightDesc.EndKey.AsRawKey(),
+	}
+	if _, err := engine.MVCCCopyRangeTombstones(
+		ctx, batch, &bothDeltaMS, split.LeftDesc.RangeID, split.RightDesc.RangeID, rightSpan,
+	); err != nil {
+		return enginepb.MVCCStats{}, result.Result{}, errors.Wrap(err, "unable to copy MVCC range tombstones")
+	}
+	if err := engine.MVCCClearRangeTombstones(
+		ctx, batch, &bothDeltaMS, split.LeftDesc.RangeID, rightSpan,
+	); err != nil {
+		return enginepb.MVCCStats{}, result.Result{}, errors.Wrap(err, "unable to clear MVCC range tombstones")
+	}
+
 	// Compute the absolute stats for the (post-split) LHS. No more
 	// modifications to it are allowed after this line.
 
@@ -1042,7 +1087,7 @@ func splitTriggerHelper(
 
 // mergeTrigger is called on a successful commit of an AdminMerge transaction.
 // It calculates stats for the LHS by merging in RHS stats, and copies over the
-// abort span entries from the RHS.
+// abort span entries and MVCC range tombstones from the RHS.
 func mergeTrigger(
 	ctx context.Context,
 	rec EvalContext,
@@ -1066,11 +1111,21 @@ func mergeTrigger(
 	); err != nil {
 		return result.Result{}, err
 	}
+	rightSpan := roachpb.Span{
+		Key:    merge.RightDesc.StartKey.AsRawKey(),
+		EndKey: merge.RightDesc.EndKey.AsRawKey(),
+	}
+	copiedTombstones, err := engine.MVCCCopyRangeTombstones(
+		ctx, batch, ms, merge.RightDesc.RangeID, merge.LeftDesc.RangeID, rightSpan,
+	)
+	if err != nil {
+		return result.Result{}, err
+	}
 
 	// The stats for the merged range are the sum of the LHS and RHS stats, less
 	// the RHS's replicated range ID stats. The only replicated range ID keys we
-	// copy from the RHS are the keys in the abort span, and we've already
-	// accounted for those stats above.
+	// copy from the RHS are the keys in the abort span and the MVCC range
+	// tombstones, and we've already accounted for those stats above.
 	ms.Add(merge.RightMVCCStats)
 	{
 		ridPrefix := keys.MakeRangeIDReplicatedPrefix(merge.RightDesc.RangeID)
@@ -1087,6 +1142,7 @@ func mergeTrigger(
 	pd.Replicated.Merge = &storagepb.Merge{
 		MergeTrigger: *merge,
 	}
+	pd.Replicated.AddedRangeTombstones = copiedTombstones > 0
 	return pd, nil
 }
 
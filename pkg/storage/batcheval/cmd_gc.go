@@ -46,6 +46,7 @@ func declareKeysGC(
 		spans.AddNonMVCC(spanset.SpanReadWrite, roachpb.Span{Key: keys.RangeLastGCKey(header.RangeID)})
 	}
 	spans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keys.RangeDescriptorKey(desc.StartKey)})
+	DeclareKeysRangeTombstones(header, spans)
 }
 
 // GC iterates through the list of keys to garbage collect
@@ -70,9 +71,18 @@ func GC(
 		}
 	}
 
+	// Values deleted by MVCC range tombstones at or below the GC threshold can
+	// be garbage collected even if they are the latest value of their key.
+	gcThreshold := cArgs.EvalCtx.GetGCThreshold()
+	gcThreshold.Forward(args.Threshold)
+	rangeTombstones, err := engine.MVCCGetRangeTombstones(ctx, readWriter, cArgs.EvalCtx.GetRangeID())
+	if err != nil {
+		return result.Result{}, err
+	}
+
 	// Garbage collect the specified keys by expiration timestamps.
-	if err := engine.MVCCGarbageCollect(
-		ctx, readWriter, cArgs.Stats, keys, h.Timestamp,
+	if err := engine.MVCCGarbageCollectWithRangeTombstones(
+		ctx, readWriter, cArgs.Stats, keys, h.Timestamp, rangeTombstones.AtOrBelow(gcThreshold),
 	); err != nil {
 		return result.Result{}, err
 	}
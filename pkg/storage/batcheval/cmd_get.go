@@ -20,7 +20,7 @@ import (
 )
 
 func init() {
-	RegisterReadOnlyCommand(roachpb.Get, DefaultDeclareKeys, Get)
+	RegisterReadOnlyCommand(roachpb.Get, declareKeysMVCC, Get)
 }
 
 // Get returns the value for a specified key.
@@ -31,9 +31,14 @@ func Get(
 	h := cArgs.Header
 	reply := resp.(*roachpb.GetResponse)
 
+	rangeTombstones, err := LoadRangeTombstones(ctx, reader, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
 	val, intent, err := engine.MVCCGet(ctx, reader, args.Key, h.Timestamp, engine.MVCCGetOptions{
-		Inconsistent: h.ReadConsistency != roachpb.CONSISTENT,
-		Txn:          h.Txn,
+		Inconsistent:    h.ReadConsistency != roachpb.CONSISTENT,
+		Txn:             h.Txn,
+		RangeTombstones: rangeTombstones,
 	})
 	if err != nil {
 		return result.Result{}, err
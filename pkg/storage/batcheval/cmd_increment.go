@@ -19,7 +19,7 @@ import (
 )
 
 func init() {
-	RegisterReadWriteCommand(roachpb.Increment, DefaultDeclareKeys, Increment)
+	RegisterReadWriteCommand(roachpb.Increment, declareKeysMVCC, Increment)
 }
 
 // Increment increments the value (interpreted as varint64 encoded) and
@@ -32,7 +32,12 @@ func Increment(
 	h := cArgs.Header
 	reply := resp.(*roachpb.IncrementResponse)
 
-	newVal, err := engine.MVCCIncrement(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, h.Txn, args.Increment)
+	rangeTombstones, err := LoadRangeTombstones(ctx, readWriter, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
+	newVal, err := engine.MVCCIncrement(
+		ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, h.Txn, args.Increment, rangeTombstones)
 	reply.NewValue = newVal
 	return result.Result{}, err
 }
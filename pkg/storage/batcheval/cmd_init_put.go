@@ -19,7 +19,7 @@ import (
 )
 
 func init() {
-	RegisterReadWriteCommand(roachpb.InitPut, DefaultDeclareKeys, InitPut)
+	RegisterReadWriteCommand(roachpb.InitPut, declareKeysMVCC, InitPut)
 }
 
 // InitPut sets the value for a specified key only if it doesn't exist. It
@@ -32,6 +32,10 @@ func InitPut(
 	args := cArgs.Args.(*roachpb.InitPutRequest)
 	h := cArgs.Header
 
+	rangeTombstones, err := LoadRangeTombstones(ctx, readWriter, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
 	if h.DistinctSpans {
 		if b, ok := readWriter.(engine.Batch); ok {
 			// Use the distinct batch for both blind and normal ops so that we don't
@@ -42,7 +46,7 @@ func InitPut(
 		}
 	}
 	if args.Blind {
-		return result.Result{}, engine.MVCCBlindInitPut(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, args.Value, args.FailOnTombstones, h.Txn)
+		return result.Result{}, engine.MVCCBlindInitPut(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, args.Value, args.FailOnTombstones, h.Txn, rangeTombstones)
 	}
-	return result.Result{}, engine.MVCCInitPut(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, args.Value, args.FailOnTombstones, h.Txn)
+	return result.Result{}, engine.MVCCInitPut(ctx, readWriter, cArgs.Stats, args.Key, h.Timestamp, args.Value, args.FailOnTombstones, h.Txn, rangeTombstones)
 }
@@ -11,6 +11,7 @@
 package batcheval
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -36,6 +37,9 @@ func declareKeysPut(
 	} else {
 		spans.AddMVCC(access, req.Header().Span(), header.Timestamp)
 	}
+	if !args.Inline {
+		DeclareKeysRangeTombstones(header, spans)
+	}
 }
 
 // Put sets the value for a specified key.
@@ -47,8 +51,17 @@ func Put(
 	ms := cArgs.Stats
 
 	var ts hlc.Timestamp
+	var rangeTombstones engine.MVCCRangeTombstones
 	if !args.Inline {
 		ts = h.Timestamp
+		var err error
+		rangeTombstones, err = LoadRangeTombstones(ctx, readWriter, cArgs, args.Span())
+		if err != nil {
+			return result.Result{}, err
+		}
+		if err := rangeTombstones.CheckWrite(args.Key, ts); err != nil {
+			return result.Result{}, err
+		}
 	}
 	if h.DistinctSpans {
 		if b, ok := readWriter.(engine.Batch); ok {
@@ -59,8 +72,23 @@ func Put(
 			defer readWriter.Close()
 		}
 	}
+	var res result.Result
+	if isOnlineRestoreSpanKey(args.Key) {
+		res.Replicated.AddedOnlineRestoreSpans = true
+	}
 	if args.Blind {
-		return result.Result{}, engine.MVCCBlindPut(ctx, readWriter, ms, args.Key, ts, args.Value, h.Txn)
+		return res, engine.MVCCBlindPut(ctx, readWriter, ms, args.Key, ts, args.Value, h.Txn)
+	}
+	return res, engine.MVCCPutWithRangeTombstones(
+		ctx, readWriter, ms, args.Key, ts, args.Value, h.Txn, rangeTombstones)
+}
+
+// isOnlineRestoreSpanKey returns whether the key records a span of an online
+// RESTORE whose data hasn't been ingested yet.
+func isOnlineRestoreSpanKey(key roachpb.Key) bool {
+	if !bytes.HasPrefix(key, keys.LocalRangePrefix) {
+		return false
 	}
-	return result.Result{}, engine.MVCCPut(ctx, readWriter, ms, args.Key, ts, args.Value, h.Txn)
+	_, suffix, _, err := keys.DecodeRangeKey(key)
+	return err == nil && bytes.Equal(suffix, keys.LocalOnlineRestoreSpanSuffix)
 }
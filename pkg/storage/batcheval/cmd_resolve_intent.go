@@ -31,6 +31,7 @@ func declareKeysResolveIntentCombined(
 	// TODO(nvanbenschoten): declare this span at the txn's MinTimestamp. See
 	// lockTable.UpdateLocks for more.
 	DefaultDeclareKeys(desc, header, req, spans)
+	DeclareKeysRangeTombstones(header, spans)
 	var status roachpb.TransactionStatus
 	var txnID uuid.UUID
 	switch t := req.(type) {
@@ -81,13 +82,22 @@ func ResolveIntent(
 		return result.Result{}, ErrTransactionUnsupported
 	}
 
+	rangeTombstones, err := LoadRangeTombstones(ctx, readWriter, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
+
 	update := args.AsLockUpdate()
-	ok, err := engine.MVCCResolveWriteIntent(ctx, readWriter, ms, update)
+	iterAndBuf := engine.GetBufUsingIter(readWriter.NewIterator(engine.IterOptions{Prefix: true}))
+	defer iterAndBuf.Cleanup()
+	ok, err := engine.MVCCResolveWriteIntentUsingIter(
+		ctx, readWriter, iterAndBuf, ms, update, rangeTombstones)
 	if err != nil {
 		return result.Result{}, err
 	}
 
 	var res result.Result
+	res.Local.ResolvedLocks = []roachpb.LockUpdate{update}
 	res.Local.Metrics = resolveToMetricType(args.Status, args.Poison)
 
 	if WriteAbortSpanOnResolve(args.Status, args.Poison, ok) {
@@ -20,7 +20,7 @@ import (
 )
 
 func init() {
-	RegisterReadOnlyCommand(roachpb.ReverseScan, DefaultDeclareKeys, ReverseScan)
+	RegisterReadOnlyCommand(roachpb.ReverseScan, declareKeysMVCC, ReverseScan)
 }
 
 // ReverseScan scans the key range specified by start key through
@@ -35,14 +35,18 @@ func ReverseScan(
 	reply := resp.(*roachpb.ReverseScanResponse)
 
 	var res engine.MVCCScanResult
-	var err error
+	rangeTombstones, err := LoadRangeTombstones(ctx, reader, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
 
 	opts := engine.MVCCScanOptions{
-		Inconsistent: h.ReadConsistency != roachpb.CONSISTENT,
-		Txn:          h.Txn,
-		MaxKeys:      h.MaxSpanRequestKeys,
-		TargetBytes:  h.TargetBytes,
-		Reverse:      true,
+		Inconsistent:    h.ReadConsistency != roachpb.CONSISTENT,
+		Txn:             h.Txn,
+		MaxKeys:         h.MaxSpanRequestKeys,
+		TargetBytes:     h.TargetBytes,
+		RangeTombstones: rangeTombstones,
+		Reverse:         true,
 	}
 
 	switch args.ScanFormat {
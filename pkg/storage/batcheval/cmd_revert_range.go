@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
@@ -35,6 +36,7 @@ func declareKeysRevertRange(
 	// is equal to the entire range for fast stats updating.
 	spans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keys.RangeDescriptorKey(desc.StartKey)})
 	spans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keys.RangeLastGCKey(desc.RangeID)})
+	DeclareKeysRangeTombstones(header, spans)
 }
 
 // isEmptyKeyTimeRange checks if the span has no writes in (since,until].
@@ -88,6 +90,19 @@ func RevertRange(
 
 	log.VEventf(ctx, 2, "clearing keys with timestamp (%v, %v]", args.TargetTime, cArgs.Header.Timestamp)
 
+	tombstones, err := LoadRangeTombstones(
+		ctx, readWriter, cArgs, roachpb.Span{Key: args.Key, EndKey: args.EndKey})
+	if err != nil {
+		return result.Result{}, err
+	}
+	if len(tombstones) > 0 {
+		// MVCCClearTimeRange does not know which of the versions it uncovers are
+		// deleted by range tombstones, so the stats it computes may count them
+		// as live. Mark the stats as estimates so that they get recomputed.
+		_ = cluster.VersionContainsEstimatesCounter // see for info on ContainsEstimates migration
+		cArgs.Stats.ContainsEstimates++
+	}
+
 	resume, err := engine.MVCCClearTimeRange(ctx, readWriter, cArgs.Stats, args.Key, args.EndKey,
 		args.TargetTime, cArgs.Header.Timestamp, cArgs.Header.MaxSpanRequestKeys)
 	if err != nil {
@@ -20,7 +20,7 @@ import (
 )
 
 func init() {
-	RegisterReadOnlyCommand(roachpb.Scan, DefaultDeclareKeys, Scan)
+	RegisterReadOnlyCommand(roachpb.Scan, declareKeysMVCC, Scan)
 }
 
 // Scan scans the key range specified by start key through end key
@@ -35,14 +35,18 @@ func Scan(
 	reply := resp.(*roachpb.ScanResponse)
 
 	var res engine.MVCCScanResult
-	var err error
+	rangeTombstones, err := LoadRangeTombstones(ctx, reader, cArgs, args.Span())
+	if err != nil {
+		return result.Result{}, err
+	}
 
 	opts := engine.MVCCScanOptions{
-		Inconsistent: h.ReadConsistency != roachpb.CONSISTENT,
-		Txn:          h.Txn,
-		MaxKeys:      h.MaxSpanRequestKeys,
-		TargetBytes:  h.TargetBytes,
-		Reverse:      false,
+		Inconsistent:    h.ReadConsistency != roachpb.CONSISTENT,
+		Txn:             h.Txn,
+		MaxKeys:         h.MaxSpanRequestKeys,
+		TargetBytes:     h.TargetBytes,
+		RangeTombstones: rangeTombstones,
+		Reverse:         false,
 	}
 
 	switch args.ScanFormat {
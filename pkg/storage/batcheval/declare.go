@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
 )
@@ -37,6 +38,39 @@ func DefaultDeclareKeys(
 	}
 }
 
+// declareKeysMVCC is like DefaultDeclareKeys, but also declares the MVCC
+// range tombstones of the range, which MVCC reads and writes take into
+// account.
+func declareKeysMVCC(
+	desc *roachpb.RangeDescriptor, header roachpb.Header, req roachpb.Request, spans *spanset.SpanSet,
+) {
+	DefaultDeclareKeys(desc, header, req, spans)
+	DeclareKeysRangeTombstones(header, spans)
+}
+
+// DeclareKeysRangeTombstones declares read access to the MVCC range
+// tombstones of the range.
+func DeclareKeysRangeTombstones(header roachpb.Header, spans *spanset.SpanSet) {
+	prefix := keys.MVCCRangeTombstonePrefix(header.RangeID)
+	spans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()})
+}
+
+// LoadRangeTombstones returns the MVCC range tombstones of the range that
+// overlap the given span. The lookup is skipped on ranges that are known not
+// to have any range tombstones, which saves a seek on every read.
+func LoadRangeTombstones(
+	ctx context.Context, reader engine.Reader, cArgs CommandArgs, span roachpb.Span,
+) (engine.MVCCRangeTombstones, error) {
+	if !cArgs.EvalCtx.HasRangeTombstones() {
+		return nil, nil
+	}
+	tombstones, err := engine.MVCCGetRangeTombstones(ctx, reader, cArgs.EvalCtx.GetRangeID())
+	if err != nil {
+		return nil, err
+	}
+	return tombstones.Overlapping(span), nil
+}
+
 // DeclareKeysForBatch adds all keys that the batch with the provided header
 // touches to the given SpanSet. This does not include keys touched during the
 // processing of the batch's individual commands.
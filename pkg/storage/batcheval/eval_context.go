@@ -90,6 +90,9 @@ type EvalContext interface {
 	GetSplitQPS() float64
 
 	GetGCThreshold() hlc.Timestamp
+	// HasRangeTombstones returns whether the range may have MVCC range
+	// tombstones. If it returns false, the range has none.
+	HasRangeTombstones() bool
 	GetLastReplicaGCTimestamp(context.Context) (hlc.Timestamp, error)
 	GetLease() (roachpb.Lease, roachpb.Lease)
 
@@ -108,6 +111,7 @@ type MockEvalCtx struct {
 	QPS              float64
 	AbortSpan        *abortspan.AbortSpan
 	GCThreshold      hlc.Timestamp
+	RangeTombstones  bool
 	Term, FirstIndex uint64
 	CanCreateTxn     func() (bool, hlc.Timestamp, roachpb.TransactionAbortedReason)
 	Lease            roachpb.Lease
@@ -196,6 +200,9 @@ func (m *mockEvalCtxImpl) CanCreateTxnRecord(
 func (m *mockEvalCtxImpl) GetGCThreshold() hlc.Timestamp {
 	return m.GCThreshold
 }
+func (m *mockEvalCtxImpl) HasRangeTombstones() bool {
+	return m.RangeTombstones
+}
 func (m *mockEvalCtxImpl) GetLastReplicaGCTimestamp(context.Context) (hlc.Timestamp, error) {
 	panic("unimplemented")
 }
@@ -32,6 +32,10 @@ type LocalResult struct {
 	// with. They should be handed off to asynchronous intent processing on
 	// the proposer, so that an attempt to resolve them is made.
 	EncounteredIntents []roachpb.Intent
+	// ResolvedLocks stores the locks that were resolved by calls to
+	// ResolveIntent and ResolveIntentRange. The Replica's lock tracker is
+	// informed of them so that it stops reporting the locks as held.
+	ResolvedLocks []roachpb.LockUpdate
 	// UpdatedTxns stores transaction records that have been updated by
 	// calls to EndTxn, PushTxn, and RecoverTxn.
 	UpdatedTxns []*roachpb.Transaction
@@ -67,6 +71,7 @@ func (lResult *LocalResult) IsZero() bool {
 	// NB: keep in order.
 	return lResult.Reply == nil &&
 		lResult.EncounteredIntents == nil &&
+		lResult.ResolvedLocks == nil &&
 		lResult.UpdatedTxns == nil &&
 		lResult.EndTxns == nil &&
 		!lResult.GossipFirstRange &&
@@ -81,11 +86,11 @@ func (lResult *LocalResult) String() string {
 		return "LocalResult: nil"
 	}
 	return fmt.Sprintf("LocalResult (reply: %v, #encountered intents: %d, "+
-		"#updated txns: %d #end txns: %d, "+
+		"#resolved locks: %d #updated txns: %d #end txns: %d, "+
 		"GossipFirstRange:%t MaybeGossipSystemConfig:%t MaybeAddToSplitQueue:%t "+
 		"MaybeGossipNodeLiveness:%s MaybeWatchForMerge:%t",
 		lResult.Reply, len(lResult.EncounteredIntents),
-		len(lResult.UpdatedTxns), len(lResult.EndTxns),
+		len(lResult.ResolvedLocks), len(lResult.UpdatedTxns), len(lResult.EndTxns),
 		lResult.GossipFirstRange, lResult.MaybeGossipSystemConfig, lResult.MaybeAddToSplitQueue,
 		lResult.MaybeGossipNodeLiveness, lResult.MaybeWatchForMerge)
 }
@@ -277,6 +282,12 @@ func (p *Result) MergeAndDestroy(q Result) error {
 	}
 	q.Replicated.PrevLeaseProposal = nil
 
+	p.Replicated.AddedRangeTombstones = p.Replicated.AddedRangeTombstones || q.Replicated.AddedRangeTombstones
+	q.Replicated.AddedRangeTombstones = false
+
+	p.Replicated.AddedOnlineRestoreSpans = p.Replicated.AddedOnlineRestoreSpans || q.Replicated.AddedOnlineRestoreSpans
+	q.Replicated.AddedOnlineRestoreSpans = false
+
 	if p.Local.EncounteredIntents == nil {
 		p.Local.EncounteredIntents = q.Local.EncounteredIntents
 	} else {
@@ -284,6 +295,13 @@ func (p *Result) MergeAndDestroy(q Result) error {
 	}
 	q.Local.EncounteredIntents = nil
 
+	if p.Local.ResolvedLocks == nil {
+		p.Local.ResolvedLocks = q.Local.ResolvedLocks
+	} else {
+		p.Local.ResolvedLocks = append(p.Local.ResolvedLocks, q.Local.ResolvedLocks...)
+	}
+	q.Local.ResolvedLocks = nil
+
 	if p.Local.UpdatedTxns == nil {
 		p.Local.UpdatedTxns = q.Local.UpdatedTxns
 	} else {
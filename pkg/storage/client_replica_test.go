@@ -1869,6 +1869,58 @@ func TestClearRange(t *testing.T) {
 // possible and hard to prevent entirely. The Replica will only learn that it is
 // the new leaseholder when it applies the snapshot. When doing so, it should
 // make sure to apply the lease-related side-effects to its in-memory state.
+// TestRangeTombstoneWrites verifies that writes respect the MVCC range
+// tombstones of a range: values deleted by a range tombstone read as missing
+// to conditional writes, and writes below a range tombstone don't disappear.
+func TestRangeTombstoneWrites(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	store := createTestStoreWithConfig(t, stopper, storage.TestStoreConfig(nil))
+	db := store.DB()
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, db.Put(ctx, key, "old"))
+	}
+	_, err := db.Inc(ctx, "i", 5)
+	require.NoError(t, err)
+	belowTombstone := store.Clock().Now()
+	require.NoError(t, db.DelRangeUsingTombstone(ctx, "a", "z"))
+
+	kv, err := db.Get(ctx, "a")
+	require.NoError(t, err)
+	require.False(t, kv.Exists())
+
+	// A conditional put expecting no value succeeds, while one expecting the
+	// deleted value fails.
+	require.NoError(t, db.CPut(ctx, "a", "new", nil /* expValue */))
+	oldVal := roachpb.MakeValueFromString("old")
+	err = db.CPut(ctx, "b", "new", &oldVal)
+	require.IsType(t, &roachpb.ConditionFailedError{}, errors.Cause(err))
+
+	// An initial put treats the deleted value like a deletion tombstone.
+	require.NoError(t, db.InitPut(ctx, "c", "new", false /* failOnTombstones */))
+	err = db.InitPut(ctx, "d", "new", true /* failOnTombstones */)
+	require.IsType(t, &roachpb.ConditionFailedError{}, errors.Cause(err))
+
+	// An increment starts over from zero.
+	kv, err = db.Inc(ctx, "i", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), kv.ValueInt())
+
+	// A put below the range tombstone is pushed above it instead of being
+	// deleted by it right away.
+	put := putArgs(roachpb.Key("e"), []byte("new"))
+	_, pErr := client.SendWrappedWith(
+		ctx, store.TestSender(), roachpb.Header{Timestamp: belowTombstone}, put)
+	require.Nil(t, pErr)
+	kv, err = db.Get(ctx, "e")
+	require.NoError(t, err)
+	require.True(t, kv.Exists())
+}
+
 func TestLeaseTransferInSnapshotUpdatesTimestampCache(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -262,13 +262,29 @@ func SanitizeExternalStorageURI(path string, extraParams []string) (string, erro
 	return uri.String(), nil
 }
 
-// MakeExternalStorage creates an ExternalStorage from the given config.
+// MakeExternalStorage creates an ExternalStorage from the given config. The
+// errors it returns that are likely to be transient are marked as such (see
+// IsTransientError).
 func MakeExternalStorage(
 	ctx context.Context,
 	dest roachpb.ExternalStorage,
 	conf base.ExternalIOConfig,
 	settings *cluster.Settings,
 	blobClientFactory blobs.BlobClientFactory,
+) (ExternalStorage, error) {
+	s, err := makeExternalStorage(ctx, dest, conf, settings, blobClientFactory)
+	if err != nil {
+		return nil, err
+	}
+	return transientErrorMarkingStorage{s}, nil
+}
+
+func makeExternalStorage(
+	ctx context.Context,
+	dest roachpb.ExternalStorage,
+	conf base.ExternalIOConfig,
+	settings *cluster.Settings,
+	blobClientFactory blobs.BlobClientFactory,
 ) (ExternalStorage, error) {
 	switch dest.Provider {
 	case roachpb.ExternalStorageProvider_LocalFile:
@@ -372,7 +372,11 @@ func (h *httpStorage) req(
 	default:
 		body, _ := ioutil.ReadAll(resp.Body)
 		_ = resp.Body.Close()
-		return nil, errors.Errorf("error response from server: %s %q", resp.Status, body)
+		err := errors.Errorf("error response from server: %s %q", resp.Status, body)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			err = errors.Mark(err, errTransient)
+		}
+		return nil, err
 	}
 	return resp, nil
 }
@@ -330,3 +330,33 @@ func TestExternalStorageCanUseHTTPProxy(t *testing.T) {
 
 	require.EqualValues(t, "proxied-http://my-server/file", string(data))
 }
+
+func TestHttpTransientErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "unavailable") {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer s.Close()
+
+	ctx := context.Background()
+	store, err := ExternalStorageFromURI(
+		ctx, s.URL, base.ExternalIOConfig{}, testSettings, blobs.TestEmptyBlobClientFactory,
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	_, err = store.ReadFile(ctx, "unavailable")
+	require.Error(t, err)
+	require.True(t, IsTransientError(err), "expected %v to be transient", err)
+
+	_, err = store.ReadFile(ctx, "missing")
+	require.Error(t, err)
+	require.False(t, IsTransientError(err), "expected %v not to be transient", err)
+}
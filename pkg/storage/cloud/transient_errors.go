@@ -0,0 +1,117 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cloud
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/api/googleapi"
+)
+
+// errTransient marks the errors returned by an ExternalStorage that are likely
+// to be transient, like server errors from the storage provider.
+var errTransient = errors.New("transient external storage error")
+
+// IsTransientError returns whether err was returned by an ExternalStorage for
+// a failure that is likely to be transient, such that the operation that
+// failed can be retried. Since errors are marked as transient, this also works
+// for errors that were sent over the network, e.g. by DistSQL processors.
+func IsTransientError(err error) bool {
+	return errors.Is(err, errTransient)
+}
+
+// markTransient marks err as transient if it's a server error or a network
+// timeout.
+func markTransient(err error) error {
+	if err == nil || !isTransient(err) {
+		return err
+	}
+	return errors.Mark(err, errTransient)
+}
+
+func isTransient(err error) bool {
+	var httpErr *retryableHTTPError
+	if errors.As(err, &httpErr) {
+		return true
+	}
+	var awsErr awserr.RequestFailure
+	if errors.As(err, &awsErr) && awsErr.StatusCode() >= http.StatusInternalServerError {
+		return true
+	}
+	var gcsErr *googleapi.Error
+	if errors.As(err, &gcsErr) && gcsErr.Code >= http.StatusInternalServerError {
+		return true
+	}
+	var azureErr azblob.StorageError
+	if errors.As(err, &azureErr) && azureErr.Response() != nil &&
+		azureErr.Response().StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// transientErrorMarkingStorage wraps an ExternalStorage to mark the errors it
+// returns that are likely to be transient (see IsTransientError).
+type transientErrorMarkingStorage struct {
+	ExternalStorage
+}
+
+var _ ExternalStorage = transientErrorMarkingStorage{}
+
+func (s transientErrorMarkingStorage) ReadFile(
+	ctx context.Context, basename string,
+) (io.ReadCloser, error) {
+	r, err := s.ExternalStorage.ReadFile(ctx, basename)
+	if err != nil {
+		return nil, markTransient(err)
+	}
+	return transientErrorMarkingReader{r}, nil
+}
+
+func (s transientErrorMarkingStorage) WriteFile(
+	ctx context.Context, basename string, content io.ReadSeeker,
+) error {
+	return markTransient(s.ExternalStorage.WriteFile(ctx, basename, content))
+}
+
+func (s transientErrorMarkingStorage) ListFiles(
+	ctx context.Context, patternSuffix string,
+) ([]string, error) {
+	files, err := s.ExternalStorage.ListFiles(ctx, patternSuffix)
+	return files, markTransient(err)
+}
+
+func (s transientErrorMarkingStorage) Delete(ctx context.Context, basename string) error {
+	return markTransient(s.ExternalStorage.Delete(ctx, basename))
+}
+
+func (s transientErrorMarkingStorage) Size(ctx context.Context, basename string) (int64, error) {
+	size, err := s.ExternalStorage.Size(ctx, basename)
+	return size, markTransient(err)
+}
+
+// transientErrorMarkingReader marks the errors encountered while reading a
+// file that are likely to be transient, like a connection timing out.
+type transientErrorMarkingReader struct {
+	io.ReadCloser
+}
+
+func (r transientErrorMarkingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	return n, markTransient(err)
+}
@@ -280,6 +280,10 @@ type MetricExporter interface {
 	// lockTable.
 	LockTableDebug() string
 
+	// LockTableInfo returns information about each of the locks held or
+	// reserved in the lockTable, along with the requests waiting on them.
+	LockTableInfo() []storagepb.LockStateInfo
+
 	// TODO(nvanbenschoten): fill out this interface to provide observability
 	// into the state of the concurrency manager.
 	// LatchMetrics()
@@ -517,6 +521,11 @@ type lockTable interface {
 	// Clear removes all locks and lock wait-queues from the lockTable.
 	Clear()
 
+	// Info returns a snapshot of the locks that are held or reserved in the
+	// lockTable and the requests that are waiting on each of them. The
+	// snapshot is ordered by span scope and then by key.
+	Info() []storagepb.LockStateInfo
+
 	// String returns a debug string representing the state of the lockTable.
 	String() string
 }
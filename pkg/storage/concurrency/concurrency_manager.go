@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/storage/contention"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanlatch"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagebase"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
@@ -58,6 +59,8 @@ type Store interface {
 	// Metrics.
 	GetTxnWaitMetrics() *txnwait.Metrics
 	GetSlowLatchGauge() *metric.Gauge
+	// Observability.
+	GetContentionRegistry() *contention.Registry
 }
 
 // NewManager creates a new concurrency Manager structure.
@@ -78,6 +81,7 @@ func NewManager(store Store, rng *roachpb.RangeDescriptor) Manager {
 			stopper:                  store.Stopper(),
 			ir:                       store.IntentResolver(),
 			dependencyCyclePushDelay: defaultDependencyCyclePushDelay,
+			contention:               store.GetContentionRegistry(),
 		},
 		// TODO(nvanbenschoten): move pkg/storage/txnwait to a new
 		// pkg/storage/concurrency/txnwait package.
@@ -348,6 +352,11 @@ func (m *managerImpl) LockTableDebug() string {
 	return m.lt.String()
 }
 
+// LockTableInfo implements the MetricExporter interface.
+func (m *managerImpl) LockTableInfo() []storagepb.LockStateInfo {
+	return m.lt.Info()
+}
+
 // ContainsKey implements the txnwait.ReplicaInterface interface.
 func (m *managerImpl) ContainsKey(key roachpb.Key) bool {
 	return storagebase.ContainsKey(m.rng, key)
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/batcheval"
 	"github.com/cockroachdb/cockroach/pkg/storage/concurrency"
 	"github.com/cockroachdb/cockroach/pkg/storage/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/storage/contention"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/intentresolver"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
@@ -367,14 +368,15 @@ func newCluster() *cluster {
 // cluster implements the Store interface. Many of these methods are only used
 // by the txnWaitQueue, whose functionality is fully mocked out in this test by
 // cluster's implementation of concurrency.IntentResolver.
-func (c *cluster) NodeDescriptor() *roachpb.NodeDescriptor    { return c.nodeDesc }
-func (c *cluster) DB() *client.DB                             { return nil }
-func (c *cluster) Clock() *hlc.Clock                          { return nil }
-func (c *cluster) Stopper() *stop.Stopper                     { return nil }
-func (c *cluster) IntentResolver() concurrency.IntentResolver { return c }
-func (c *cluster) GetTxnWaitKnobs() txnwait.TestingKnobs      { return txnwait.TestingKnobs{} }
-func (c *cluster) GetTxnWaitMetrics() *txnwait.Metrics        { return txnwait.NewMetrics(time.Minute) }
-func (c *cluster) GetSlowLatchGauge() *metric.Gauge           { return nil }
+func (c *cluster) NodeDescriptor() *roachpb.NodeDescriptor     { return c.nodeDesc }
+func (c *cluster) DB() *client.DB                              { return nil }
+func (c *cluster) Clock() *hlc.Clock                           { return nil }
+func (c *cluster) Stopper() *stop.Stopper                      { return nil }
+func (c *cluster) IntentResolver() concurrency.IntentResolver  { return c }
+func (c *cluster) GetTxnWaitKnobs() txnwait.TestingKnobs       { return txnwait.TestingKnobs{} }
+func (c *cluster) GetTxnWaitMetrics() *txnwait.Metrics         { return txnwait.NewMetrics(time.Minute) }
+func (c *cluster) GetSlowLatchGauge() *metric.Gauge            { return nil }
+func (c *cluster) GetContentionRegistry() *contention.Registry { return nil }
 
 // PushTransaction implements the concurrency.IntentResolver interface.
 func (c *cluster) PushTransaction(
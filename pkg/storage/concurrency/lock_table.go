@@ -16,14 +16,17 @@ import (
 	"sort"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
+	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/google/btree"
@@ -216,6 +219,9 @@ type lockTableGuardImpl struct {
 	txn   *enginepb.TxnMeta
 	spans *spanset.SpanSet
 	ts    hlc.Timestamp
+	// The time at which the request first entered the lockTable. Only used for
+	// observability.
+	startTime time.Time
 
 	// A request whose startWait is set to true in ScanAndEnqueue is actively
 	// waiting at a particular key. This is the first key encountered when
@@ -378,6 +384,9 @@ type lockState struct {
 		locked bool
 		// LockStrength is always Exclusive
 		holder [lock.MaxDurability + 1]lockHolderInfo
+		// The time at which the lock transitioned to held. Only used for
+		// observability.
+		startTime time.Time
 	}
 
 	// Information about the requests waiting on the lock.
@@ -963,6 +972,7 @@ func (l *lockState) acquireLock(
 	}
 	l.reservation = nil
 	l.holder.locked = true
+	l.holder.startTime = timeutil.Now()
 	l.holder.holder[durability].txn = txn
 	l.holder.holder[durability].ts = ts
 	l.holder.holder[durability].seqs = append([]enginepb.TxnSeq(nil), txn.Sequence)
@@ -1018,6 +1028,7 @@ func (l *lockState) discoveredLock(
 		informWaiters = false
 	} else {
 		l.holder.locked = true
+		l.holder.startTime = timeutil.Now()
 		l.holder.holder[lock.Replicated].txn = txn
 		l.holder.holder[lock.Replicated].ts = ts
 	}
@@ -1392,12 +1403,13 @@ func (t *lockTableImpl) ScanAndEnqueue(req Request, guard lockTableGuard) lockTa
 	if guard == nil {
 		seqNum := atomic.AddUint64(&t.seqNum, 1)
 		g = &lockTableGuardImpl{
-			seqNum: seqNum,
-			table:  t,
-			spans:  req.Spans,
-			ts:     req.Timestamp,
-			sa:     spanset.NumSpanAccess - 1,
-			index:  -1,
+			seqNum:    seqNum,
+			table:     t,
+			spans:     req.Spans,
+			ts:        req.Timestamp,
+			startTime: timeutil.Now(),
+			sa:        spanset.NumSpanAccess - 1,
+			index:     -1,
 		}
 		if req.Txn != nil {
 			g.txn = &req.Txn.TxnMeta
@@ -1736,6 +1748,66 @@ func (t *lockTableImpl) Clear() {
 	t.tryClearLocks(true /* force */)
 }
 
+// Info implements the lockTable interface.
+func (t *lockTableImpl) Info() []storagepb.LockStateInfo {
+	now := timeutil.Now()
+	var infos []storagepb.LockStateInfo
+	for i := 0; i < len(t.locks); i++ {
+		tree := &t.locks[i]
+		tree.mu.RLock()
+		tree.Ascend(func(it btree.Item) bool {
+			l := it.(*lockState)
+			if info, ok := l.info(now); ok {
+				infos = append(infos, info)
+			}
+			return true
+		})
+		tree.mu.RUnlock()
+	}
+	return infos
+}
+
+// Returns a snapshot of the state of this lock, measuring hold and wait
+// durations relative to now. Returns false if the lock is empty.
+// Acquires l.mu.
+func (l *lockState) info(now time.Time) (storagepb.LockStateInfo, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.isEmptyLock() {
+		return storagepb.LockStateInfo{}, false
+	}
+	info := storagepb.LockStateInfo{
+		Key:      l.key,
+		Strength: lock.Exclusive,
+	}
+	if txn, _, dur := l.getLockerInfo(); txn != nil {
+		info.LockHolder = txn
+		info.Durability = dur
+		info.HoldDuration = now.Sub(l.holder.startTime)
+	} else if l.reservation != nil {
+		info.ReservationHolder = l.reservation.txn
+	}
+	for e := l.waitingReaders.Front(); e != nil; e = e.Next() {
+		g := e.Value.(*lockTableGuardImpl)
+		info.Waiters = append(info.Waiters, storagepb.LockWaiter{
+			WaitingTxn:   g.txn,
+			ActiveWaiter: true,
+			Strength:     lock.None,
+			WaitDuration: now.Sub(g.startTime),
+		})
+	}
+	for e := l.queuedWriters.Front(); e != nil; e = e.Next() {
+		qg := e.Value.(*queuedGuard)
+		info.Waiters = append(info.Waiters, storagepb.LockWaiter{
+			WaitingTxn:   qg.guard.txn,
+			ActiveWaiter: qg.active,
+			Strength:     lock.Exclusive,
+			WaitDuration: now.Sub(qg.guard.startTime),
+		})
+	}
+	return info, true
+}
+
 // For tests.
 func (t *lockTableImpl) String() string {
 	var buf strings.Builder
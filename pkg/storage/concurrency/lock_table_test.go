@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/datadriven"
 	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
 	"golang.org/x/sync/errgroup"
 )
@@ -371,6 +372,69 @@ func TestLockTableBasic(t *testing.T) {
 	})
 }
 
+func TestLockTableInfo(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	lt := newLockTable(1000)
+	ts := hlc.Timestamp{WallTime: 10}
+	txn1 := &enginepb.TxnMeta{ID: uuid.MakeV4(), WriteTimestamp: ts}
+	txn2 := &roachpb.Transaction{TxnMeta: enginepb.TxnMeta{ID: uuid.MakeV4(), WriteTimestamp: ts}}
+	keyA, keyB := roachpb.Key("a"), roachpb.Key("b")
+
+	require.Empty(t, lt.Info())
+
+	// txn1 holds an unreplicated lock on a.
+	require.NoError(t, lt.AcquireLock(txn1, keyA, lock.Exclusive, lock.Unreplicated))
+
+	// A transactional write and a non-transactional read queue on a.
+	var writeSpans, readSpans spanset.SpanSet
+	writeSpans.AddMVCC(spanset.SpanReadWrite, roachpb.Span{Key: keyA}, ts)
+	readSpans.AddMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keyA}, ts)
+	writer := lt.ScanAndEnqueue(Request{Txn: txn2, Timestamp: ts, Spans: &writeSpans}, nil)
+	require.True(t, writer.ShouldWait())
+	reader := lt.ScanAndEnqueue(Request{Timestamp: ts, Spans: &readSpans}, nil)
+	require.True(t, reader.ShouldWait())
+
+	infos := lt.Info()
+	require.Len(t, infos, 1)
+	info := infos[0]
+	require.Equal(t, keyA, info.Key)
+	require.Equal(t, txn1.ID, info.LockHolder.ID)
+	require.Equal(t, lock.Unreplicated, info.Durability)
+	require.Equal(t, lock.Exclusive, info.Strength)
+	require.Nil(t, info.ReservationHolder)
+	require.Len(t, info.Waiters, 2)
+	// Readers are reported before writers.
+	require.Nil(t, info.Waiters[0].WaitingTxn)
+	require.True(t, info.Waiters[0].ActiveWaiter)
+	require.Equal(t, lock.None, info.Waiters[0].Strength)
+	require.Equal(t, txn2.ID, info.Waiters[1].WaitingTxn.ID)
+	require.True(t, info.Waiters[1].ActiveWaiter)
+	require.Equal(t, lock.Exclusive, info.Waiters[1].Strength)
+
+	// When txn1 releases its lock, txn2's request is granted a reservation and
+	// the reader stops waiting.
+	require.NoError(t, lt.UpdateLocks(&roachpb.LockUpdate{
+		Span: roachpb.Span{Key: keyA}, Txn: *txn1, Status: roachpb.COMMITTED,
+	}))
+	lt.Dequeue(reader)
+
+	// txn1 then acquires a lock on b, which sorts after a.
+	require.NoError(t, lt.AcquireLock(txn1, keyB, lock.Exclusive, lock.Unreplicated))
+
+	infos = lt.Info()
+	require.Len(t, infos, 2)
+	require.Equal(t, keyA, infos[0].Key)
+	require.Nil(t, infos[0].LockHolder)
+	require.Equal(t, txn2.ID, infos[0].ReservationHolder.ID)
+	require.Empty(t, infos[0].Waiters)
+	require.Equal(t, keyB, infos[1].Key)
+	require.Equal(t, txn1.ID, infos[1].LockHolder.ID)
+	require.Empty(t, infos[1].Waiters)
+
+	lt.Dequeue(writer)
+}
+
 func nextUUID(counter *uint128.Uint128) uuid.UUID {
 	*counter = counter.Add(1)
 	return uuid.FromUint128(*counter)
@@ -15,11 +15,14 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/contention"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/intentresolver"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
+	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
 
 // Default delay before pushing in order to detect dependency cycles.
@@ -39,6 +42,9 @@ type lockTableWaiterImpl struct {
 	// How long to wait until pushing conflicting transactions to detect
 	// dependency cycles.
 	dependencyCyclePushDelay time.Duration
+
+	// Records the time spent waiting on conflicting transactions. May be nil.
+	contention *contention.Registry
 }
 
 // IntentResolver is an interface used by lockTableWaiterImpl to push
@@ -67,11 +73,17 @@ func (w *lockTableWaiterImpl) WaitOn(
 	var timer *timeutil.Timer
 	var timerC <-chan time.Time
 	var timerWaitingState waitingState
+	tracker := contentionEventTracker{registry: w.contention}
+	if req.Txn != nil {
+		tracker.waiter = req.Txn.ID
+	}
+	defer tracker.emit()
 	for {
 		select {
 		case <-newStateC:
 			timerC = nil
 			state := guard.CurState()
+			tracker.notify(state)
 			switch state.stateKind {
 			case waitFor:
 				// waitFor indicates that the request is waiting on another
@@ -232,6 +244,51 @@ func (w *lockTableWaiterImpl) pushTxn(ctx context.Context, req Request, ws waiti
 	return w.ir.ResolveIntent(ctx, resolve, opts)
 }
 
+// contentionEventTracker tracks the conflicting lock that a request is waiting
+// on and records a contention event each time the request stops waiting on it.
+type contentionEventTracker struct {
+	registry *contention.Registry
+	// The ID of the waiting transaction. Empty for non-transactional requests.
+	waiter uuid.UUID
+
+	// The conflicting lock currently being waited on, if any.
+	key   roachpb.Key
+	txn   *enginepb.TxnMeta
+	start time.Time
+}
+
+// notify informs the tracker of the request's new waiting state.
+func (t *contentionEventTracker) notify(ws waitingState) {
+	switch ws.stateKind {
+	case waitFor, waitForDistinguished, waitElsewhere:
+		if t.txn != nil && t.txn.ID == ws.txn.ID && t.key.Equal(ws.key) {
+			// Still waiting on the same lock.
+			return
+		}
+		t.emit()
+		t.key, t.txn, t.start = ws.key, ws.txn, timeutil.Now()
+	default:
+		t.emit()
+	}
+}
+
+// emit records a contention event for the lock currently being waited on, if
+// any, and forgets about it.
+func (t *contentionEventTracker) emit() {
+	if t.txn == nil {
+		return
+	}
+	end := timeutil.Now()
+	t.registry.AddContentionEvent(storagepb.ContentionEvent{
+		Key:          t.key,
+		HolderTxn:    *t.txn,
+		WaitingTxnID: t.waiter,
+		Duration:     end.Sub(t.start),
+		EndTime:      end,
+	})
+	t.key, t.txn = nil, nil
+}
+
 func hasMinPriority(txn *enginepb.TxnMeta) bool {
 	return txn.Priority == enginepb.MinTxnPriority
 }
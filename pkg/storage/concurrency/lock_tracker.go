@@ -0,0 +1,174 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package concurrency
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
+	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// LockTracker maintains a lockTable with the locks that requests discover
+// through the Replica's request path, along with the requests waiting on them,
+// so that the state of a range's locks can be reported. Unlike the Manager, it
+// neither sequences requests nor makes them wait: requests still wait for
+// conflicting transactions by pushing them and waiting in the txnwait.Queue,
+// and the Replica informs the LockTracker of the locks they wait on and of the
+// locks that are released.
+//
+// TODO(nvanbenschoten): remove once the Manager sequences requests on the
+// Replica. Its lockTable will then hold this state.
+type LockTracker struct {
+	// mu serializes updates to the lockTable, which expects a lock that is
+	// discovered to not be held by a different transaction.
+	mu syncutil.Mutex
+	lt *lockTableImpl
+}
+
+// LockWaitGuard is returned from LockTracker.OnLocksDiscovered. It is passed
+// back to LockTracker.FinishWaiting once the request stops waiting.
+type LockWaitGuard struct {
+	ltg lockTableGuard
+}
+
+// NewLockTracker creates a new LockTracker.
+func NewLockTracker() *LockTracker {
+	return &LockTracker{lt: newLockTable(maxTrackedLocks).(*lockTableImpl)}
+}
+
+// maxTrackedLocks is the number of locks above which a LockTracker forgets the
+// locks it is tracking. Locks that are removed without going through the
+// Replica's request path, for instance by a ClearRange, are never released
+// otherwise.
+const maxTrackedLocks = 10000
+
+// OnLocksDiscovered informs the tracker that the request discovered the locks
+// of the given intents and is about to wait for their holders. The request
+// remains in the locks' wait-queues until FinishWaiting is called with the
+// returned guard.
+func (t *LockTracker) OnLocksDiscovered(
+	ctx context.Context, req Request, intents []roachpb.Intent,
+) *LockWaitGuard {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.numLocks()+int64(len(intents)) > maxTrackedLocks {
+		t.lt.Clear()
+	}
+	g := t.lt.ScanAndEnqueue(req, nil /* guard */)
+	for i := range intents {
+		intent := &intents[i]
+		t.releaseOtherHolder(intent)
+		if err := t.lt.AddDiscoveredLock(intent, g); err != nil {
+			log.Warningf(ctx, "unable to track lock on %s: %v", intent.Key, err)
+		}
+	}
+	// Scan again so that the request becomes an active waiter on the first of
+	// the locks that it conflicts with, like a request re-sequenced after
+	// discovering the locks.
+	g = t.lt.ScanAndEnqueue(req, g)
+	return &LockWaitGuard{ltg: g}
+}
+
+// FinishWaiting removes the request from the wait-queues that it entered in
+// OnLocksDiscovered.
+func (t *LockTracker) FinishWaiting(g *LockWaitGuard) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lt.Dequeue(g.ltg)
+}
+
+// OnLockUpdated informs the tracker that a lock was updated or released, such
+// as by the resolution of an intent.
+func (t *LockTracker) OnLockUpdated(ctx context.Context, up *roachpb.LockUpdate) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.lt.UpdateLocks(up); err != nil {
+		log.Warningf(ctx, "unable to update tracked locks on %s: %v", up.Span, err)
+	}
+}
+
+// OnTransactionUpdated informs the tracker of an update to a transaction
+// record. The locks that a finalized transaction holds on the range are
+// released: EndTxn resolves the intents on its own range without going
+// through ResolveIntent.
+func (t *LockTracker) OnTransactionUpdated(ctx context.Context, txn *roachpb.Transaction) {
+	if !txn.Status.IsFinalized() {
+		return
+	}
+	for _, span := range []roachpb.Span{
+		{Key: keys.LocalRangePrefix, EndKey: keys.LocalRangeMax},
+		{Key: keys.LocalMax, EndKey: roachpb.KeyMax},
+	} {
+		t.OnLockUpdated(ctx, &roachpb.LockUpdate{Span: span, Txn: txn.TxnMeta, Status: txn.Status})
+	}
+}
+
+// Clear removes all locks and wait-queues from the tracker. It is called when
+// the replica stops being the leaseholder, or when the range splits or merges.
+func (t *LockTracker) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lt.Clear()
+}
+
+// Info returns the state of the tracked locks and of the requests waiting on
+// them.
+func (t *LockTracker) Info() []storagepb.LockStateInfo {
+	return t.lt.Info()
+}
+
+// numLocks returns the number of locks in the lockTable.
+func (t *LockTracker) numLocks() int64 {
+	var n int64
+	for i := range t.lt.locks {
+		n += atomic.LoadInt64(&t.lt.locks[i].numLocks)
+	}
+	return n
+}
+
+// releaseOtherHolder releases the lock on the intent's key if the tracker
+// believes that it is held by a different transaction than the intent's. The
+// tracker missed the release of that lock, and the lockTable doesn't allow a
+// lock held by one transaction to be discovered by another.
+// REQUIRES: t.mu is locked.
+func (t *LockTracker) releaseOtherHolder(intent *roachpb.Intent) {
+	ss := spanset.SpanGlobal
+	if keys.IsLocal(intent.Key) {
+		ss = spanset.SpanLocal
+	}
+	tree := &t.lt.locks[ss]
+	tree.mu.RLock()
+	i := tree.Get(&lockState{key: intent.Key})
+	tree.mu.RUnlock()
+	if i == nil {
+		return
+	}
+	l := i.(*lockState)
+	l.mu.Lock()
+	holder, _, _ := l.getLockerInfo()
+	l.mu.Unlock()
+	if holder == nil || holder.ID == intent.Txn.ID {
+		return
+	}
+	// The update only applies to locks held by the given transaction, so it
+	// can't return an error.
+	_ = t.lt.UpdateLocks(&roachpb.LockUpdate{
+		Span:   roachpb.Span{Key: intent.Key},
+		Txn:    *holder,
+		Status: roachpb.ABORTED,
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package concurrency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockTracker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	lt := NewLockTracker()
+	ts := hlc.Timestamp{WallTime: 10}
+	makeTxn := func() *roachpb.Transaction {
+		return &roachpb.Transaction{
+			TxnMeta:       enginepb.TxnMeta{ID: uuid.MakeV4(), WriteTimestamp: ts},
+			ReadTimestamp: ts,
+		}
+	}
+	holder, writer, other := makeTxn(), makeTxn(), makeTxn()
+	keyA, keyB := roachpb.Key("a"), roachpb.Key("b")
+	var writeSpans, readSpans spanset.SpanSet
+	writeSpans.AddMVCC(spanset.SpanReadWrite, roachpb.Span{Key: keyA, EndKey: keyB.Next()}, ts)
+	readSpans.AddMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keyA}, ts)
+
+	require.Empty(t, lt.Info())
+
+	// A transactional write discovers holder's intents on a and b, and a
+	// non-transactional read discovers the intent on a.
+	wg := lt.OnLocksDiscovered(ctx, Request{Txn: writer, Timestamp: ts, Spans: &writeSpans},
+		[]roachpb.Intent{
+			roachpb.MakeIntent(&holder.TxnMeta, keyA),
+			roachpb.MakeIntent(&holder.TxnMeta, keyB),
+		})
+	rg := lt.OnLocksDiscovered(ctx, Request{Timestamp: ts, Spans: &readSpans},
+		[]roachpb.Intent{roachpb.MakeIntent(&holder.TxnMeta, keyA)})
+
+	infos := lt.Info()
+	require.Len(t, infos, 2)
+	require.Equal(t, keyA, infos[0].Key)
+	require.Equal(t, holder.ID, infos[0].LockHolder.ID)
+	require.Equal(t, lock.Replicated, infos[0].Durability)
+	require.Len(t, infos[0].Waiters, 2)
+	require.Nil(t, infos[0].Waiters[0].WaitingTxn)
+	require.Equal(t, lock.None, infos[0].Waiters[0].Strength)
+	require.Equal(t, writer.ID, infos[0].Waiters[1].WaitingTxn.ID)
+	require.True(t, infos[0].Waiters[1].ActiveWaiter)
+	require.Equal(t, lock.Exclusive, infos[0].Waiters[1].Strength)
+	// The writer waits on a, and is only queued on b.
+	require.Equal(t, keyB, infos[1].Key)
+	require.Len(t, infos[1].Waiters, 1)
+	require.False(t, infos[1].Waiters[0].ActiveWaiter)
+
+	// Once the requests are done waiting, the locks remain held.
+	lt.FinishWaiting(wg)
+	lt.FinishWaiting(rg)
+	infos = lt.Info()
+	require.Len(t, infos, 2)
+	require.Empty(t, infos[0].Waiters)
+	require.Empty(t, infos[1].Waiters)
+
+	// Resolving the intent on a releases its lock.
+	up := roachpb.MakeLockUpdate(holder, roachpb.Span{Key: keyA})
+	up.Status = roachpb.COMMITTED
+	lt.OnLockUpdated(ctx, &up)
+	infos = lt.Info()
+	require.Len(t, infos, 1)
+	require.Equal(t, keyB, infos[0].Key)
+
+	// If the release of the lock on b is missed, another transaction's intent
+	// can still be discovered on b.
+	g := lt.OnLocksDiscovered(ctx, Request{Txn: writer, Timestamp: ts, Spans: &writeSpans},
+		[]roachpb.Intent{roachpb.MakeIntent(&other.TxnMeta, keyB)})
+	lt.FinishWaiting(g)
+	infos = lt.Info()
+	require.Len(t, infos, 1)
+	require.Equal(t, other.ID, infos[0].LockHolder.ID)
+
+	// Updates to a pending transaction leave its locks alone, but the locks
+	// of a finalized transaction are released.
+	lt.OnTransactionUpdated(ctx, other)
+	require.Len(t, lt.Info(), 1)
+	other.Status = roachpb.ABORTED
+	lt.OnTransactionUpdated(ctx, other)
+	require.Empty(t, lt.Info())
+
+	// Clear forgets all locks.
+	g = lt.OnLocksDiscovered(ctx, Request{Txn: writer, Timestamp: ts, Spans: &writeSpans},
+		[]roachpb.Intent{roachpb.MakeIntent(&holder.TxnMeta, keyA)})
+	lt.Clear()
+	lt.FinishWaiting(g)
+	require.Empty(t, lt.Info())
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package contention records the contention events observed by the requests
+// evaluated on a node, so that operators can find out which indexes and which
+// transactions caused the most waiting.
+package contention
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+const (
+	// Capacity is the maximum number of contention events retained by a
+	// Registry. Once it is reached, the oldest events are discarded.
+	Capacity = 10000
+	// Retention is how long contention events are taken into account in the
+	// summaries produced by a Registry.
+	Retention = time.Hour
+)
+
+// Registry is a bounded, in-memory buffer of the most recent contention events
+// on a node. It is shared by all of the node's stores. A nil Registry discards
+// all events.
+//
+// Registry is thread safe.
+type Registry struct {
+	mu struct {
+		syncutil.Mutex
+		// events is a ring buffer of contention events, in the order in which
+		// they were added. next is the position of the next event to add.
+		events []storagepb.ContentionEvent
+		next   int
+	}
+}
+
+// NewRegistry creates a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// AddContentionEvent records a contention event.
+func (r *Registry) AddContentionEvent(ev storagepb.ContentionEvent) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.mu.events) < Capacity {
+		r.mu.events = append(r.mu.events, ev)
+		return
+	}
+	r.mu.events[r.mu.next] = ev
+	r.mu.next = (r.mu.next + 1) % Capacity
+}
+
+// Summary aggregates the contention events that ended within Retention of now.
+func (r *Registry) Summary(now time.Time) storagepb.ContentionSummary {
+	var s summarizer
+	if r == nil {
+		return s.summary()
+	}
+	cutoff := now.Add(-Retention)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.mu.events {
+		if ev := &r.mu.events[i]; !ev.EndTime.Before(cutoff) {
+			s.addEvent(ev)
+		}
+	}
+	return s.summary()
+}
+
+// MergeSummaries combines the given summaries, which are typically produced by
+// the registries of different nodes, into one.
+func MergeSummaries(summaries ...storagepb.ContentionSummary) storagepb.ContentionSummary {
+	var s summarizer
+	for i := range summaries {
+		for _, idx := range summaries[i].Indexes {
+			s.addIndex(idx)
+		}
+		for _, txn := range summaries[i].Txns {
+			s.addTxn(txn)
+		}
+	}
+	return s.summary()
+}
+
+type indexKey struct {
+	tableID, indexID uint32
+}
+
+// summarizer accumulates contention by index and by holder transaction.
+type summarizer struct {
+	indexes map[indexKey]*storagepb.IndexContention
+	txns    map[uuid.UUID]*storagepb.TxnContention
+}
+
+func (s *summarizer) addEvent(ev *storagepb.ContentionEvent) {
+	if tableID, indexID, ok := decodeIndex(ev.Key); ok {
+		s.addIndex(storagepb.IndexContention{
+			TableID:                  tableID,
+			IndexID:                  indexID,
+			NumContentionEvents:      1,
+			CumulativeContentionTime: ev.Duration,
+		})
+	}
+	s.addTxn(storagepb.TxnContention{
+		TxnID:                    ev.HolderTxn.ID,
+		NumContentionEvents:      1,
+		CumulativeContentionTime: ev.Duration,
+	})
+}
+
+func (s *summarizer) addIndex(idx storagepb.IndexContention) {
+	if s.indexes == nil {
+		s.indexes = make(map[indexKey]*storagepb.IndexContention)
+	}
+	k := indexKey{tableID: idx.TableID, indexID: idx.IndexID}
+	if cur, ok := s.indexes[k]; ok {
+		cur.NumContentionEvents += idx.NumContentionEvents
+		cur.CumulativeContentionTime += idx.CumulativeContentionTime
+	} else {
+		s.indexes[k] = &idx
+	}
+}
+
+func (s *summarizer) addTxn(txn storagepb.TxnContention) {
+	if s.txns == nil {
+		s.txns = make(map[uuid.UUID]*storagepb.TxnContention)
+	}
+	if cur, ok := s.txns[txn.TxnID]; ok {
+		cur.NumContentionEvents += txn.NumContentionEvents
+		cur.CumulativeContentionTime += txn.CumulativeContentionTime
+	} else {
+		s.txns[txn.TxnID] = &txn
+	}
+}
+
+func (s *summarizer) summary() storagepb.ContentionSummary {
+	res := storagepb.ContentionSummary{
+		Indexes: make([]storagepb.IndexContention, 0, len(s.indexes)),
+		Txns:    make([]storagepb.TxnContention, 0, len(s.txns)),
+	}
+	for _, idx := range s.indexes {
+		res.Indexes = append(res.Indexes, *idx)
+	}
+	sort.Slice(res.Indexes, func(i, j int) bool {
+		a, b := &res.Indexes[i], &res.Indexes[j]
+		if a.CumulativeContentionTime != b.CumulativeContentionTime {
+			return a.CumulativeContentionTime > b.CumulativeContentionTime
+		}
+		if a.TableID != b.TableID {
+			return a.TableID < b.TableID
+		}
+		return a.IndexID < b.IndexID
+	})
+	for _, txn := range s.txns {
+		res.Txns = append(res.Txns, *txn)
+	}
+	sort.Slice(res.Txns, func(i, j int) bool {
+		a, b := &res.Txns[i], &res.Txns[j]
+		if a.CumulativeContentionTime != b.CumulativeContentionTime {
+			return a.CumulativeContentionTime > b.CumulativeContentionTime
+		}
+		return a.TxnID.String() < b.TxnID.String()
+	})
+	return res
+}
+
+// decodeIndex returns the table and index IDs of the given key, or false if
+// the key is not part of a table index.
+func decodeIndex(key roachpb.Key) (tableID, indexID uint32, ok bool) {
+	if keys.IsLocal(key) {
+		return 0, 0, false
+	}
+	rest, tID, err := keys.DecodeTablePrefix(key)
+	if err != nil || tID > uint64(^uint32(0)) {
+		return 0, 0, false
+	}
+	_, iID, err := encoding.DecodeUvarintAscending(rest)
+	if err != nil || iID > uint64(^uint32(0)) {
+		return 0, 0, false
+	}
+	return uint32(tID), uint32(iID), true
+}
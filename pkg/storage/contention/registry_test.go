@@ -0,0 +1,134 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package contention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func makeIndexKey(tableID, indexID uint32) roachpb.Key {
+	k := keys.MakeTablePrefix(tableID)
+	k = encoding.EncodeUvarintAscending(k, uint64(indexID))
+	return encoding.EncodeStringAscending(k, "pk")
+}
+
+func makeEvent(
+	key roachpb.Key, holder uuid.UUID, d time.Duration, end time.Time,
+) storagepb.ContentionEvent {
+	return storagepb.ContentionEvent{
+		Key:          key,
+		HolderTxn:    enginepb.TxnMeta{ID: holder},
+		WaitingTxnID: uuid.MakeV4(),
+		Duration:     d,
+		EndTime:      end,
+	}
+}
+
+func TestRegistrySummary(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	now := time.Unix(1000000, 0)
+	txn1, txn2 := uuid.MakeV4(), uuid.MakeV4()
+	r := NewRegistry()
+	r.AddContentionEvent(makeEvent(makeIndexKey(53, 1), txn1, time.Second, now))
+	r.AddContentionEvent(makeEvent(makeIndexKey(53, 1), txn2, 2*time.Second, now))
+	r.AddContentionEvent(makeEvent(makeIndexKey(53, 2), txn1, 4*time.Second, now))
+	// Events on keys outside of tables only count towards their transaction.
+	r.AddContentionEvent(makeEvent(keys.RangeDescriptorKey(roachpb.RKey("a")), txn2, time.Second, now))
+	// Events older than Retention are ignored.
+	r.AddContentionEvent(makeEvent(makeIndexKey(54, 1), txn2, time.Minute, now.Add(-2*Retention)))
+
+	require.Equal(t, storagepb.ContentionSummary{
+		Indexes: []storagepb.IndexContention{
+			{TableID: 53, IndexID: 2, NumContentionEvents: 1, CumulativeContentionTime: 4 * time.Second},
+			{TableID: 53, IndexID: 1, NumContentionEvents: 2, CumulativeContentionTime: 3 * time.Second},
+		},
+		Txns: []storagepb.TxnContention{
+			{TxnID: txn1, NumContentionEvents: 2, CumulativeContentionTime: 5 * time.Second},
+			{TxnID: txn2, NumContentionEvents: 2, CumulativeContentionTime: 3 * time.Second},
+		},
+	}, r.Summary(now))
+
+	// A nil registry discards events and produces an empty summary.
+	var nilRegistry *Registry
+	nilRegistry.AddContentionEvent(makeEvent(makeIndexKey(53, 1), txn1, time.Second, now))
+	require.Empty(t, nilRegistry.Summary(now).Indexes)
+	require.Empty(t, nilRegistry.Summary(now).Txns)
+}
+
+func TestRegistryCapacity(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	now := time.Unix(1000000, 0)
+	old, recent := uuid.MakeV4(), uuid.MakeV4()
+	r := NewRegistry()
+	for i := 0; i < Capacity; i++ {
+		r.AddContentionEvent(makeEvent(makeIndexKey(53, 1), old, time.Millisecond, now))
+	}
+	for i := 0; i < Capacity/2; i++ {
+		r.AddContentionEvent(makeEvent(makeIndexKey(53, 1), recent, time.Millisecond, now))
+	}
+
+	s := r.Summary(now)
+	require.Len(t, s.Txns, 2)
+	var total int64
+	for _, txn := range s.Txns {
+		total += txn.NumContentionEvents
+		require.EqualValues(t, Capacity/2, txn.NumContentionEvents)
+	}
+	require.EqualValues(t, Capacity, total)
+	require.EqualValues(t, Capacity, s.Indexes[0].NumContentionEvents)
+}
+
+func TestMergeSummaries(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	txn1, txn2 := uuid.MakeV4(), uuid.MakeV4()
+	a := storagepb.ContentionSummary{
+		Indexes: []storagepb.IndexContention{
+			{TableID: 53, IndexID: 1, NumContentionEvents: 1, CumulativeContentionTime: time.Second},
+		},
+		Txns: []storagepb.TxnContention{
+			{TxnID: txn1, NumContentionEvents: 1, CumulativeContentionTime: time.Second},
+		},
+	}
+	b := storagepb.ContentionSummary{
+		Indexes: []storagepb.IndexContention{
+			{TableID: 53, IndexID: 1, NumContentionEvents: 2, CumulativeContentionTime: time.Second},
+			{TableID: 54, IndexID: 1, NumContentionEvents: 1, CumulativeContentionTime: 3 * time.Second},
+		},
+		Txns: []storagepb.TxnContention{
+			{TxnID: txn1, NumContentionEvents: 1, CumulativeContentionTime: time.Second},
+			{TxnID: txn2, NumContentionEvents: 3, CumulativeContentionTime: 3 * time.Second},
+		},
+	}
+
+	require.Equal(t, storagepb.ContentionSummary{
+		Indexes: []storagepb.IndexContention{
+			{TableID: 54, IndexID: 1, NumContentionEvents: 1, CumulativeContentionTime: 3 * time.Second},
+			{TableID: 53, IndexID: 1, NumContentionEvents: 3, CumulativeContentionTime: 2 * time.Second},
+		},
+		Txns: []storagepb.TxnContention{
+			{TxnID: txn2, NumContentionEvents: 3, CumulativeContentionTime: 3 * time.Second},
+			{TxnID: txn1, NumContentionEvents: 2, CumulativeContentionTime: 2 * time.Second},
+		},
+	}, MergeSummaries(a, b))
+}
@@ -213,7 +213,7 @@ func (m mvccCPutOp) run(ctx context.Context) string {
 	writer := m.m.getReadWriter(m.writer)
 	txn.Sequence++
 
-	err := engine.MVCCConditionalPut(ctx, writer, nil, m.key, txn.WriteTimestamp, m.value, &m.expVal, true, txn)
+	err := engine.MVCCConditionalPut(ctx, writer, nil, m.key, txn.WriteTimestamp, m.value, &m.expVal, true, txn, nil /* rangeTombstones */)
 	if err != nil {
 		return fmt.Sprintf("error: %s", err)
 	}
@@ -238,7 +238,7 @@ func (m mvccInitPutOp) run(ctx context.Context) string {
 	writer := m.m.getReadWriter(m.writer)
 	txn.Sequence++
 
-	err := engine.MVCCInitPut(ctx, writer, nil, m.key, txn.WriteTimestamp, m.value, false, txn)
+	err := engine.MVCCInitPut(ctx, writer, nil, m.key, txn.WriteTimestamp, m.value, false, txn, nil /* rangeTombstones */)
 	if err != nil {
 		return fmt.Sprintf("error: %s", err)
 	}
@@ -263,7 +263,9 @@ func (m mvccDeleteRangeOp) run(ctx context.Context) string {
 	writer := m.m.getReadWriter(m.writer)
 	txn.Sequence++
 
-	keys, _, _, err := engine.MVCCDeleteRange(ctx, writer, nil, m.key, m.endKey, 0, txn.WriteTimestamp, txn, true)
+	keys, _, _, err := engine.MVCCDeleteRange(
+		ctx, writer, nil, m.key, m.endKey, 0, txn.WriteTimestamp, txn, true, nil, /* rangeTombstones */
+	)
 	if err != nil {
 		return fmt.Sprintf("error: %s", err)
 	}
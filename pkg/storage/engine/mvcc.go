@@ -711,6 +711,9 @@ type MVCCGetOptions struct {
 	Tombstones       bool
 	FailOnMoreRecent bool
 	Txn              *roachpb.Transaction
+	// RangeTombstones are the MVCC range tombstones of the range being read.
+	// Versions deleted by them are treated like deletion tombstones.
+	RangeTombstones MVCCRangeTombstones
 }
 
 func (opts *MVCCGetOptions) validate() error {
@@ -765,8 +768,10 @@ func mvccGet(
 		return nil, nil, err
 	}
 
-	// If the iterator has a specialized implementation, defer to that.
-	if mvccIter, ok := iter.(MVCCIterator); ok && mvccIter.MVCCOpsSpecialized() {
+	// If the iterator has a specialized implementation, defer to that. The
+	// specialized implementations do not understand range tombstones.
+	if mvccIter, ok := iter.(MVCCIterator); ok && mvccIter.MVCCOpsSpecialized() &&
+		len(opts.RangeTombstones) == 0 {
 		return mvccIter.MVCCGet(key, timestamp, opts)
 	}
 
@@ -784,6 +789,7 @@ func mvccGet(
 		inconsistent:     opts.Inconsistent,
 		tombstones:       opts.Tombstones,
 		failOnMoreRecent: opts.FailOnMoreRecent,
+		rangeTombstones:  opts.RangeTombstones,
 	}
 
 	mvccScanner.init(opts.Txn)
@@ -1170,7 +1176,27 @@ func MVCCPut(
 		iter = rw.NewIterator(IterOptions{Prefix: true})
 		defer iter.Close()
 	}
-	return mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, value, txn, nil /* valueFn */)
+	return mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, value, txn, nil /* valueFn */, nil /* rangeTombstones */)
+}
+
+// MVCCPutWithRangeTombstones is like MVCCPut, but it takes the supplied MVCC
+// range tombstones into account: the write fails with a WriteTooOldError if
+// one of them would delete it, and an existing value deleted by them is
+// accounted for as non-live in the stats.
+func MVCCPutWithRangeTombstones(
+	ctx context.Context,
+	rw ReadWriter,
+	ms *enginepb.MVCCStats,
+	key roachpb.Key,
+	timestamp hlc.Timestamp,
+	value roachpb.Value,
+	txn *roachpb.Transaction,
+	rangeTombstones MVCCRangeTombstones,
+) error {
+	iter := rw.NewIterator(IterOptions{Prefix: true})
+	defer iter.Close()
+	return mvccPutUsingIter(
+		ctx, rw, iter, ms, key, timestamp, value, txn, nil /* valueFn */, rangeTombstones)
 }
 
 // MVCCBlindPut is a fast-path of MVCCPut. See the MVCCPut comments for details
@@ -1192,7 +1218,7 @@ func MVCCBlindPut(
 	value roachpb.Value,
 	txn *roachpb.Transaction,
 ) error {
-	return mvccPutUsingIter(ctx, writer, nil, ms, key, timestamp, value, txn, nil /* valueFn */)
+	return mvccPutUsingIter(ctx, writer, nil, ms, key, timestamp, value, txn, nil /* valueFn */, nil /* rangeTombstones */)
 }
 
 // MVCCDelete marks the key deleted so that it will not be returned in
@@ -1212,7 +1238,25 @@ func MVCCDelete(
 	iter := rw.NewIterator(IterOptions{Prefix: true})
 	defer iter.Close()
 
-	return mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, noValue, txn, nil /* valueFn */)
+	return mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, noValue, txn, nil /* valueFn */, nil /* rangeTombstones */)
+}
+
+// MVCCDeleteWithRangeTombstones is like MVCCDelete, but it takes the supplied
+// MVCC range tombstones into account like MVCCPutWithRangeTombstones.
+func MVCCDeleteWithRangeTombstones(
+	ctx context.Context,
+	rw ReadWriter,
+	ms *enginepb.MVCCStats,
+	key roachpb.Key,
+	timestamp hlc.Timestamp,
+	txn *roachpb.Transaction,
+	rangeTombstones MVCCRangeTombstones,
+) error {
+	iter := rw.NewIterator(IterOptions{Prefix: true})
+	defer iter.Close()
+
+	return mvccPutUsingIter(
+		ctx, rw, iter, ms, key, timestamp, noValue, txn, nil /* valueFn */, rangeTombstones)
 }
 
 var noValue = roachpb.Value{}
@@ -1221,6 +1265,11 @@ var noValue = roachpb.Value{}
 // Iterator. The function takes a value and a valueFn, only one of which
 // should be provided. If the valueFn is nil, value's raw bytes will be set
 // for the key, else the bytes provided by the valueFn will be used.
+//
+// If MVCC range tombstones are supplied, the valueFn is passed a deletion
+// tombstone in place of an existing value deleted by them, the write fails
+// with a WriteTooOldError if one of them would delete it, and the stats
+// account for an existing value deleted by them as non-live.
 func mvccPutUsingIter(
 	ctx context.Context,
 	writer Writer,
@@ -1231,6 +1280,7 @@ func mvccPutUsingIter(
 	value roachpb.Value,
 	txn *roachpb.Transaction,
 	valueFn func(*roachpb.Value) ([]byte, error),
+	rangeTombstones MVCCRangeTombstones,
 ) error {
 	var rawBytes []byte
 	if valueFn == nil {
@@ -1240,10 +1290,24 @@ func mvccPutUsingIter(
 		rawBytes = value.RawBytes
 	}
 
+	if len(rangeTombstones) > 0 && timestamp != (hlc.Timestamp{}) {
+		if err := rangeTombstones.CheckWrite(key, timestamp); err != nil {
+			return err
+		}
+		// All range tombstones covering the key are now at or below the
+		// timestamp, so they delete every version the valueFn can be passed.
+		if valueFn != nil {
+			origValueFn := valueFn
+			valueFn = func(exVal *roachpb.Value) ([]byte, error) {
+				return origValueFn(rangeTombstones.hideDeleted(key, exVal, timestamp))
+			}
+		}
+	}
+
 	buf := newPutBuffer()
 
 	err := mvccPutInternal(ctx, writer, iter, ms, key, timestamp, rawBytes,
-		txn, buf, valueFn)
+		txn, buf, valueFn, rangeTombstones)
 
 	// Using defer would be more convenient, but it is measurably slower.
 	buf.release()
@@ -1418,6 +1482,7 @@ func mvccPutInternal(
 	txn *roachpb.Transaction,
 	buf *putBuffer,
 	valueFn func(*roachpb.Value) ([]byte, error),
+	rangeTombstones MVCCRangeTombstones,
 ) error {
 	if len(key) == 0 {
 		return emptyKeyError()
@@ -1612,11 +1677,14 @@ func mvccPutInternal(
 					// move the intent above it. A similar phenomenon occurs in
 					// MVCCResolveWriteIntent.
 					latestKey := MVCCKey{Key: key, Timestamp: metaTimestamp}
-					_, prevUnsafeVal, haveNextVersion, err := unsafeNextVersion(iter, latestKey)
+					prevUnsafeKey, prevUnsafeVal, haveNextVersion, err := unsafeNextVersion(iter, latestKey)
 					if err != nil {
 						return err
 					}
-					if haveNextVersion {
+					// A version deleted by a range tombstone became non-live at
+					// the tombstone, below the intent.
+					if haveNextVersion &&
+						!rangeTombstones.Deletes(key, prevUnsafeKey.Timestamp, hlc.MaxTimestamp) {
 						prevValSize = int64(len(prevUnsafeVal))
 					}
 					iter = nil // prevent accidental use below
@@ -1770,7 +1838,7 @@ func mvccPutInternal(
 	// Update MVCC stats.
 	if ms != nil {
 		ms.Add(updateStatsOnPut(key, prevValSize, origMetaKeySize, origMetaValSize,
-			metaKeySize, metaValSize, meta, newMeta))
+			metaKeySize, metaValSize, rangeTombstones.origMetaForStats(key, meta), newMeta))
 	}
 
 	// Log the logical MVCC operation.
@@ -1792,7 +1860,8 @@ func mvccPutInternal(
 // value. The newly incremented value is returned.
 //
 // An initial value is read from the key using the same operational
-// timestamp as we use to write a value.
+// timestamp as we use to write a value. A value deleted by one of the
+// supplied MVCC range tombstones reads as missing.
 //
 // Note that, when writing transactionally, the txn's timestamps
 // dictate the timestamp of the operation, and the timestamp paramater is
@@ -1805,6 +1874,7 @@ func MVCCIncrement(
 	timestamp hlc.Timestamp,
 	txn *roachpb.Transaction,
 	inc int64,
+	rangeTombstones MVCCRangeTombstones,
 ) (int64, error) {
 	iter := rw.NewIterator(IterOptions{Prefix: true})
 	defer iter.Close()
@@ -1835,7 +1905,7 @@ func MVCCIncrement(
 		newValue.SetInt(newInt64Val)
 		newValue.InitChecksum(key)
 		return newValue.RawBytes, nil
-	})
+	}, rangeTombstones)
 
 	return newInt64Val, err
 }
@@ -1857,7 +1927,8 @@ const (
 // containing the actual value.
 //
 // The condition check reads a value from the key using the same operational
-// timestamp as we use to write a value.
+// timestamp as we use to write a value. A value deleted by one of the
+// supplied MVCC range tombstones reads as deleted.
 //
 // Note that, when writing transactionally, the txn's timestamps
 // dictate the timestamp of the operation, and the timestamp paramater is
@@ -1872,11 +1943,13 @@ func MVCCConditionalPut(
 	expVal *roachpb.Value,
 	allowIfDoesNotExist CPutMissingBehavior,
 	txn *roachpb.Transaction,
+	rangeTombstones MVCCRangeTombstones,
 ) error {
 	iter := rw.NewIterator(IterOptions{Prefix: true})
 	defer iter.Close()
 
-	return mvccConditionalPutUsingIter(ctx, rw, iter, ms, key, timestamp, value, expVal, allowIfDoesNotExist, txn)
+	return mvccConditionalPutUsingIter(
+		ctx, rw, iter, ms, key, timestamp, value, expVal, allowIfDoesNotExist, txn, rangeTombstones)
 }
 
 // MVCCBlindConditionalPut is a fast-path of MVCCConditionalPut. See the
@@ -1898,8 +1971,10 @@ func MVCCBlindConditionalPut(
 	expVal *roachpb.Value,
 	allowIfDoesNotExist CPutMissingBehavior,
 	txn *roachpb.Transaction,
+	rangeTombstones MVCCRangeTombstones,
 ) error {
-	return mvccConditionalPutUsingIter(ctx, writer, nil, ms, key, timestamp, value, expVal, allowIfDoesNotExist, txn)
+	return mvccConditionalPutUsingIter(
+		ctx, writer, nil, ms, key, timestamp, value, expVal, allowIfDoesNotExist, txn, rangeTombstones)
 }
 
 func mvccConditionalPutUsingIter(
@@ -1913,6 +1988,7 @@ func mvccConditionalPutUsingIter(
 	expVal *roachpb.Value,
 	allowNoExisting CPutMissingBehavior,
 	txn *roachpb.Transaction,
+	rangeTombstones MVCCRangeTombstones,
 ) error {
 	return mvccPutUsingIter(
 		ctx, writer, iter, ms, key, timestamp, noValue, txn,
@@ -1930,14 +2006,15 @@ func mvccConditionalPutUsingIter(
 				}
 			}
 			return value.RawBytes, nil
-		})
+		}, rangeTombstones)
 }
 
 // MVCCInitPut sets the value for a specified key if the key doesn't exist. It
 // returns a ConditionFailedError when the write fails or if the key exists with
 // an existing value that is different from the supplied value. If
 // failOnTombstones is set to true, tombstones count as mismatched values and
-// will cause a ConditionFailedError.
+// will cause a ConditionFailedError. A value deleted by one of the supplied
+// MVCC range tombstones counts as a tombstone.
 //
 // Note that, when writing transactionally, the txn's timestamps
 // dictate the timestamp of the operation, and the timestamp paramater is
@@ -1951,10 +2028,12 @@ func MVCCInitPut(
 	value roachpb.Value,
 	failOnTombstones bool,
 	txn *roachpb.Transaction,
+	rangeTombstones MVCCRangeTombstones,
 ) error {
 	iter := rw.NewIterator(IterOptions{Prefix: true})
 	defer iter.Close()
-	return mvccInitPutUsingIter(ctx, rw, iter, ms, key, timestamp, value, failOnTombstones, txn)
+	return mvccInitPutUsingIter(
+		ctx, rw, iter, ms, key, timestamp, value, failOnTombstones, txn, rangeTombstones)
 }
 
 // MVCCBlindInitPut is a fast-path of MVCCInitPut. See the MVCCInitPut
@@ -1974,8 +2053,10 @@ func MVCCBlindInitPut(
 	value roachpb.Value,
 	failOnTombstones bool,
 	txn *roachpb.Transaction,
+	rangeTombstones MVCCRangeTombstones,
 ) error {
-	return mvccInitPutUsingIter(ctx, rw, nil, ms, key, timestamp, value, failOnTombstones, txn)
+	return mvccInitPutUsingIter(
+		ctx, rw, nil, ms, key, timestamp, value, failOnTombstones, txn, rangeTombstones)
 }
 
 func mvccInitPutUsingIter(
@@ -1988,6 +2069,7 @@ func mvccInitPutUsingIter(
 	value roachpb.Value,
 	failOnTombstones bool,
 	txn *roachpb.Transaction,
+	rangeTombstones MVCCRangeTombstones,
 ) error {
 	return mvccPutUsingIter(
 		ctx, rw, iter, ms, key, timestamp, noValue, txn,
@@ -2003,7 +2085,7 @@ func mvccInitPutUsingIter(
 				}
 			}
 			return value.RawBytes, nil
-		})
+		}, rangeTombstones)
 }
 
 // mvccKeyFormatter is an fmt.Formatter for MVCC Keys.
@@ -2259,6 +2341,7 @@ func MVCCDeleteRange(
 	timestamp hlc.Timestamp,
 	txn *roachpb.Transaction,
 	returnKeys bool,
+	rangeTombstones MVCCRangeTombstones,
 ) ([]roachpb.Key, *roachpb.Span, int64, error) {
 	// In order to detect the potential write intent by another concurrent
 	// transaction with a newer timestamp, we need to use the max timestamp for
@@ -2273,8 +2356,9 @@ func MVCCDeleteRange(
 		prevSeqTxn.Sequence--
 		scanTxn = prevSeqTxn
 	}
-	res, err := MVCCScan(
-		ctx, rw, key, endKey, scanTs, MVCCScanOptions{Txn: scanTxn, MaxKeys: max})
+	res, err := MVCCScan(ctx, rw, key, endKey, scanTs, MVCCScanOptions{
+		Txn: scanTxn, MaxKeys: max, RangeTombstones: rangeTombstones,
+	})
 	if err != nil {
 		return nil, nil, 0, err
 	}
@@ -2283,8 +2367,13 @@ func MVCCDeleteRange(
 	iter := rw.NewIterator(IterOptions{Prefix: true})
 
 	for i := range res.KVs {
+		if timestamp != (hlc.Timestamp{}) {
+			if err = rangeTombstones.CheckWrite(res.KVs[i].Key, timestamp); err != nil {
+				break
+			}
+		}
 		err = mvccPutInternal(
-			ctx, rw, iter, ms, res.KVs[i].Key, timestamp, nil, txn, buf, nil)
+			ctx, rw, iter, ms, res.KVs[i].Key, timestamp, nil, txn, buf, nil, rangeTombstones)
 		if err != nil {
 			break
 		}
@@ -2322,8 +2411,10 @@ func mvccScanToBytes(
 		return MVCCScanResult{ResumeSpan: resumeSpan}, nil
 	}
 
-	// If the iterator has a specialized implementation, defer to that.
-	if mvccIter, ok := iter.(MVCCIterator); ok && mvccIter.MVCCOpsSpecialized() {
+	// If the iterator has a specialized implementation, defer to that. The
+	// specialized implementations do not understand range tombstones.
+	if mvccIter, ok := iter.(MVCCIterator); ok && mvccIter.MVCCOpsSpecialized() &&
+		len(opts.RangeTombstones) == 0 {
 		return mvccIter.MVCCScan(key, endKey, timestamp, opts)
 	}
 
@@ -2341,6 +2432,7 @@ func mvccScanToBytes(
 		inconsistent:     opts.Inconsistent,
 		tombstones:       opts.Tombstones,
 		failOnMoreRecent: opts.FailOnMoreRecent,
+		rangeTombstones:  opts.RangeTombstones,
 	}
 
 	mvccScanner.init(opts.Txn)
@@ -2463,6 +2555,9 @@ type MVCCScanOptions struct {
 	//
 	// The zero value indicates no limit.
 	TargetBytes int64
+	// RangeTombstones are the MVCC range tombstones of the range being
+	// scanned. Versions deleted by them are treated like deletion tombstones.
+	RangeTombstones MVCCRangeTombstones
 }
 
 func (opts *MVCCScanOptions) validate() error {
@@ -2635,7 +2730,8 @@ func MVCCResolveWriteIntent(
 	ctx context.Context, rw ReadWriter, ms *enginepb.MVCCStats, intent roachpb.LockUpdate,
 ) (bool, error) {
 	iterAndBuf := GetBufUsingIter(rw.NewIterator(IterOptions{Prefix: true}))
-	ok, err := MVCCResolveWriteIntentUsingIter(ctx, rw, iterAndBuf, ms, intent)
+	ok, err := MVCCResolveWriteIntentUsingIter(
+		ctx, rw, iterAndBuf, ms, intent, nil /* rangeTombstones */)
 	// Using defer would be more convenient, but it is measurably slower.
 	iterAndBuf.Cleanup()
 	return ok, err
@@ -2643,12 +2739,15 @@ func MVCCResolveWriteIntent(
 
 // MVCCResolveWriteIntentUsingIter is a variant of MVCCResolveWriteIntent that
 // uses iterator and buffer passed as parameters (e.g. when used in a loop).
+// The stats account for the versions under the intent that are deleted by the
+// supplied MVCC range tombstones as non-live.
 func MVCCResolveWriteIntentUsingIter(
 	ctx context.Context,
 	rw ReadWriter,
 	iterAndBuf IterAndBuf,
 	ms *enginepb.MVCCStats,
 	intent roachpb.LockUpdate,
+	rangeTombstones MVCCRangeTombstones,
 ) (bool, error) {
 	if len(intent.Key) == 0 {
 		return false, emptyKeyError()
@@ -2657,7 +2756,7 @@ func MVCCResolveWriteIntentUsingIter(
 		return false, errors.Errorf("can't resolve range intent as point intent")
 	}
 	return mvccResolveWriteIntent(
-		ctx, rw, iterAndBuf.iter, ms, intent, iterAndBuf.buf, false, /* forRange */
+		ctx, rw, iterAndBuf.iter, ms, intent, iterAndBuf.buf, false /* forRange */, rangeTombstones,
 	)
 }
 
@@ -2692,6 +2791,7 @@ func mvccResolveWriteIntent(
 	intent roachpb.LockUpdate,
 	buf *putBuffer,
 	forRange bool,
+	rangeTombstones MVCCRangeTombstones,
 ) (bool, error) {
 	metaKey := MakeMVCCMetadataKey(intent.Key)
 	meta := &buf.meta
@@ -2911,10 +3011,14 @@ func mvccResolveWriteIntent(
 			//
 			// Look for the first real versioned key, i.e. the key just below
 			// the (old) meta's timestamp.
+			//
+			// A version deleted by a range tombstone became non-live at the
+			// tombstone instead, below the intent.
 			iter.Next()
 			if valid, err := iter.Valid(); err != nil {
 				return false, err
-			} else if valid && iter.UnsafeKey().Key.Equal(oldKey.Key) {
+			} else if valid && iter.UnsafeKey().Key.Equal(oldKey.Key) &&
+				!rangeTombstones.Deletes(oldKey.Key, iter.UnsafeKey().Timestamp, hlc.MaxTimestamp) {
 				prevValSize = int64(len(iter.UnsafeValue()))
 			}
 		}
@@ -2988,6 +3092,16 @@ func mvccResolveWriteIntent(
 		KeyBytes: MVCCVersionTimestampSize,
 		ValBytes: valueSize,
 	}
+	restoredNanos := unsafeNextKey.Timestamp.WallTime
+	// A version deleted by a range tombstone is restored like a deletion
+	// written at the oldest tombstone deleting it, which is when it stopped
+	// being live.
+	if !buf.newMeta.Deleted {
+		if deletedSince, ok := rangeTombstones.deletedSince(intent.Key, unsafeNextKey.Timestamp); ok {
+			buf.newMeta.Deleted = true
+			restoredNanos = deletedSince.WallTime
+		}
+	}
 	if err := rw.Clear(metaKey); err != nil {
 		return false, err
 	}
@@ -2997,7 +3111,7 @@ func mvccResolveWriteIntent(
 	// Update stat counters with older version.
 	if ms != nil {
 		ms.Add(updateStatsOnClear(intent.Key, origMetaKeySize, origMetaValSize,
-			metaKeySize, metaValSize, meta, &buf.newMeta, unsafeNextKey.Timestamp.WallTime))
+			metaKeySize, metaValSize, meta, &buf.newMeta, restoredNanos))
 	}
 
 	return true, nil
@@ -3088,14 +3202,17 @@ func MVCCResolveWriteIntentRange(
 ) (int64, *roachpb.Span, error) {
 	iterAndBuf := GetIterAndBuf(rw, IterOptions{UpperBound: intent.EndKey})
 	defer iterAndBuf.Cleanup()
-	return MVCCResolveWriteIntentRangeUsingIter(ctx, rw, iterAndBuf, ms, intent, max)
+	return MVCCResolveWriteIntentRangeUsingIter(
+		ctx, rw, iterAndBuf, ms, intent, max, nil /* rangeTombstones */)
 }
 
 // MVCCResolveWriteIntentRangeUsingIter commits or aborts (rolls back)
 // the range of write intents specified by start and end keys for a
 // given txn. ResolveWriteIntentRange will skip write intents of other
 // txns. Returns the number of intents resolved and a resume span if
-// the max keys limit was exceeded. A max of zero means unbounded.
+// the max keys limit was exceeded. A max of zero means unbounded. The stats
+// account for the versions under the intents that are deleted by the supplied
+// MVCC range tombstones as non-live.
 func MVCCResolveWriteIntentRangeUsingIter(
 	ctx context.Context,
 	rw ReadWriter,
@@ -3103,6 +3220,7 @@ func MVCCResolveWriteIntentRangeUsingIter(
 	ms *enginepb.MVCCStats,
 	intent roachpb.LockUpdate,
 	max int64,
+	rangeTombstones MVCCRangeTombstones,
 ) (int64, *roachpb.Span, error) {
 	encKey := MakeMVCCMetadataKey(intent.Key)
 	encEndKey := MakeMVCCMetadataKey(intent.EndKey)
@@ -3136,7 +3254,7 @@ func MVCCResolveWriteIntentRangeUsingIter(
 		if !key.IsValue() {
 			intent.Key = key.Key
 			ok, err = mvccResolveWriteIntent(
-				ctx, rw, iterAndBuf.iter, ms, intent, iterAndBuf.buf, true, /* forRange */
+				ctx, rw, iterAndBuf.iter, ms, intent, iterAndBuf.buf, true /* forRange */, rangeTombstones,
 			)
 		}
 		if err != nil {
@@ -3168,6 +3286,21 @@ func MVCCGarbageCollect(
 	ms *enginepb.MVCCStats,
 	keys []roachpb.GCRequest_GCKey,
 	timestamp hlc.Timestamp,
+) error {
+	return MVCCGarbageCollectWithRangeTombstones(ctx, rw, ms, keys, timestamp, nil)
+}
+
+// MVCCGarbageCollectWithRangeTombstones is like MVCCGarbageCollect, but it
+// also allows the latest value of a key to be garbage collected if it has
+// been deleted by one of the supplied range tombstones. The range tombstones
+// must be at or below the GC threshold of the range.
+func MVCCGarbageCollectWithRangeTombstones(
+	ctx context.Context,
+	rw ReadWriter,
+	ms *enginepb.MVCCStats,
+	keys []roachpb.GCRequest_GCKey,
+	timestamp hlc.Timestamp,
+	rangeTombstones MVCCRangeTombstones,
 ) error {
 	// We're allowed to use a prefix iterator because we always Seek() the
 	// iterator when handling a new user key.
@@ -3193,6 +3326,15 @@ func MVCCGarbageCollect(
 		}
 		inlinedValue := meta.IsInline()
 		implicitMeta := iter.UnsafeKey().IsValue()
+		// A value deleted by a range tombstone is garbage collected like a
+		// deletion written at the oldest tombstone deleting it, which is when it
+		// stopped being live.
+		var rangeDeletedSince hlc.Timestamp
+		var rangeDeleted bool
+		if !meta.Deleted && !inlinedValue {
+			rangeDeletedSince, rangeDeleted = rangeTombstones.deletedSince(
+				gcKey.Key, hlc.Timestamp(meta.Timestamp))
+		}
 		// First, check whether all values of the key are being deleted.
 		//
 		// Note that we naively can't terminate GC'ing keys loop early if we
@@ -3206,7 +3348,7 @@ func MVCCGarbageCollect(
 			// not marked deleted. However, for inline values we allow it;
 			// they are internal and GCing them directly saves the extra
 			// deletion step.
-			if !meta.Deleted && !inlinedValue {
+			if !meta.Deleted && !inlinedValue && !rangeDeleted {
 				return errors.Errorf("request to GC non-deleted, latest value of %q", gcKey.Key)
 			}
 			if meta.Txn != nil {
@@ -3217,7 +3359,11 @@ func MVCCGarbageCollect(
 					updateStatsForInline(ms, gcKey.Key, metaKeySize, metaValSize, 0, 0)
 					ms.AgeTo(timestamp.WallTime)
 				} else {
-					ms.Add(updateStatsOnGC(gcKey.Key, metaKeySize, metaValSize, meta, meta.Timestamp.WallTime))
+					nonLiveNanos := meta.Timestamp.WallTime
+					if rangeDeleted {
+						nonLiveNanos = rangeDeletedSince.WallTime
+					}
+					ms.Add(updateStatsOnGC(gcKey.Key, metaKeySize, metaValSize, meta, nonLiveNanos))
 				}
 			}
 			if !implicitMeta {
@@ -3265,15 +3411,19 @@ func MVCCGarbageCollect(
 					// when it's a deletion.
 					valSize := int64(len(iter.UnsafeValue()))
 
-					// A non-deletion becomes non-live when its newer neighbor shows up.
+					// A non-deletion becomes non-live when its newer neighbor shows up,
+					// or when a range tombstone deletes it if that happened earlier.
 					// A deletion tombstone becomes non-live right when it is created.
 					fromNS := prevNanos
 					if valSize == 0 {
 						fromNS = unsafeIterKey.Timestamp.WallTime
+					} else if deletedSince, ok := rangeTombstones.deletedSince(
+						gcKey.Key, unsafeIterKey.Timestamp,
+					); ok && deletedSince.WallTime < fromNS {
+						fromNS = deletedSince.WallTime
 					}
 
-					ms.Add(updateStatsOnGC(gcKey.Key, MVCCVersionTimestampSize,
-						valSize, nil, fromNS))
+					ms.Add(updateStatsOnGC(gcKey.Key, MVCCVersionTimestampSize, valSize, nil, fromNS))
 				}
 				count++
 				if err := rw.Clear(unsafeIterKey); err != nil {
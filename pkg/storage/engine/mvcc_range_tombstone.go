@@ -0,0 +1,683 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/pkg/errors"
+)
+
+// MVCCRangeTombstone deletes all versions of the keys in [StartKey, EndKey)
+// that are older than Timestamp. To readers at or above Timestamp, the keys
+// appear deleted, while readers below Timestamp continue to see the old
+// versions until they are garbage collected.
+//
+// Range tombstones are stored in the replicated range-ID local keyspace of the
+// range that contains their span, which means that they are only visible to
+// readers that know which range they are reading from. They are split and
+// merged along with their range. Reads, writes, rangefeeds, GC and exports
+// take range tombstones into account: writes that read the existing value of
+// a key, like conditional puts, see versions deleted by a range tombstone as
+// deleted, and writes below a range tombstone covering their key fail with a
+// WriteTooOldError, since the tombstone would delete them right away. Range
+// deletions that do not preserve MVCC history, like ClearRange, remove the
+// range tombstones covering their span.
+//
+// In the MVCC stats, a version deleted by a range tombstone stops being live
+// at the timestamp of the oldest tombstone deleting it, as if a deletion had
+// been written at that timestamp, unless it was shadowed by a newer version
+// before. This lets the deleted data accrue GCBytesAge, so that the GC queue
+// picks up ranges with range tombstones on its own.
+type MVCCRangeTombstone struct {
+	StartKey, EndKey roachpb.Key
+	Timestamp        hlc.Timestamp
+}
+
+// Span returns the span of keys deleted by the tombstone.
+func (t MVCCRangeTombstone) Span() roachpb.Span {
+	return roachpb.Span{Key: t.StartKey, EndKey: t.EndKey}
+}
+
+// Covers returns whether the tombstone deletes versions of the key.
+func (t MVCCRangeTombstone) Covers(key roachpb.Key) bool {
+	return bytes.Compare(t.StartKey, key) <= 0 && bytes.Compare(key, t.EndKey) < 0
+}
+
+func (t MVCCRangeTombstone) String() string {
+	return fmt.Sprintf("%s@%s", t.Span(), t.Timestamp)
+}
+
+// MVCCRangeTombstones is a set of range tombstones, ordered by start key and
+// then by timestamp.
+type MVCCRangeTombstones []MVCCRangeTombstone
+
+// Deletes returns whether a version of the key written at valueTS is deleted
+// by one of the tombstones to a reader at readTS.
+func (ts MVCCRangeTombstones) Deletes(key roachpb.Key, valueTS, readTS hlc.Timestamp) bool {
+	_, ok := ts.deletedAt(key, valueTS, readTS)
+	return ok
+}
+
+// deletedAt is like Deletes, but it also returns the timestamp of the newest
+// tombstone that deletes the version.
+func (ts MVCCRangeTombstones) deletedAt(
+	key roachpb.Key, valueTS, readTS hlc.Timestamp,
+) (hlc.Timestamp, bool) {
+	var deletedAt hlc.Timestamp
+	for i := range ts {
+		t := &ts[i]
+		if valueTS.Less(t.Timestamp) && t.Timestamp.LessEq(readTS) && t.Covers(key) {
+			deletedAt.Forward(t.Timestamp)
+		}
+	}
+	return deletedAt, !deletedAt.IsEmpty()
+}
+
+// deletedSince returns the timestamp of the oldest tombstone that deletes
+// versions of the key written at valueTS, which is when such a version stops
+// being live.
+func (ts MVCCRangeTombstones) deletedSince(
+	key roachpb.Key, valueTS hlc.Timestamp,
+) (hlc.Timestamp, bool) {
+	var deletedSince hlc.Timestamp
+	for i := range ts {
+		t := &ts[i]
+		if valueTS.Less(t.Timestamp) && t.Covers(key) &&
+			(deletedSince.IsEmpty() || t.Timestamp.Less(deletedSince)) {
+			deletedSince = t.Timestamp
+		}
+	}
+	return deletedSince, !deletedSince.IsEmpty()
+}
+
+// origMetaForStats returns the metadata of the existing value of a key to
+// account for in the stats when the value is replaced. A committed value
+// deleted by one of the tombstones is accounted for like a deletion written at
+// the oldest tombstone deleting it, which is when it stopped being live.
+func (ts MVCCRangeTombstones) origMetaForStats(
+	key roachpb.Key, meta *enginepb.MVCCMetadata,
+) *enginepb.MVCCMetadata {
+	if len(ts) == 0 || meta == nil || meta.Txn != nil || meta.Deleted || meta.IsInline() {
+		return meta
+	}
+	deletedSince, ok := ts.deletedSince(key, hlc.Timestamp(meta.Timestamp))
+	if !ok {
+		return meta
+	}
+	orig := *meta
+	orig.Deleted = true
+	orig.Timestamp = hlc.LegacyTimestamp(deletedSince)
+	return &orig
+}
+
+// CheckWrite returns a WriteTooOldError if a version of the key written at the
+// given timestamp would be deleted by one of the tombstones right away. The
+// error carries the timestamp of the newest such tombstone, at which the write
+// can be retried.
+func (ts MVCCRangeTombstones) CheckWrite(key roachpb.Key, timestamp hlc.Timestamp) error {
+	if deletedAt, ok := ts.deletedAt(key, timestamp, hlc.MaxTimestamp); ok {
+		return roachpb.NewWriteTooOldError(timestamp, deletedAt)
+	}
+	return nil
+}
+
+// hideDeleted returns a deletion tombstone in place of the existing value of
+// the key if the value is deleted by one of the tombstones to a reader at
+// readTS. Values without a timestamp, which come from the intent history of
+// the reading transaction, are never deleted by range tombstones.
+func (ts MVCCRangeTombstones) hideDeleted(
+	key roachpb.Key, val *roachpb.Value, readTS hlc.Timestamp,
+) *roachpb.Value {
+	if val == nil || val.Timestamp.IsEmpty() {
+		return val
+	}
+	if deletedAt, ok := ts.deletedAt(key, val.Timestamp, readTS); ok {
+		return &roachpb.Value{Timestamp: deletedAt}
+	}
+	return val
+}
+
+// Overlapping returns the tombstones whose span overlaps the given span.
+func (ts MVCCRangeTombstones) Overlapping(span roachpb.Span) MVCCRangeTombstones {
+	var res MVCCRangeTombstones
+	for _, t := range ts {
+		if t.Span().Overlaps(span) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// AtOrBelow returns the tombstones written at or below the given timestamp.
+func (ts MVCCRangeTombstones) AtOrBelow(timestamp hlc.Timestamp) MVCCRangeTombstones {
+	var res MVCCRangeTombstones
+	for _, t := range ts {
+		if t.Timestamp.LessEq(timestamp) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// timestampsCovering returns the timestamps of the tombstones covering the key
+// in descending order, which is the order in which versions of a key are
+// stored.
+func (ts MVCCRangeTombstones) timestampsCovering(key roachpb.Key) []hlc.Timestamp {
+	var res []hlc.Timestamp
+	for i := range ts {
+		if ts[i].Covers(key) {
+			res = append(res, ts[i].Timestamp)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[j].Less(res[i]) })
+	return res
+}
+
+// MVCCGetRangeTombstones returns the range tombstones of the range with the
+// given ID.
+func MVCCGetRangeTombstones(
+	ctx context.Context, reader Reader, rangeID roachpb.RangeID,
+) (MVCCRangeTombstones, error) {
+	prefix := keys.MVCCRangeTombstonePrefix(rangeID)
+	var tombstones MVCCRangeTombstones
+	_, err := MVCCIterate(ctx, reader, prefix, prefix.PrefixEnd(), hlc.Timestamp{}, MVCCScanOptions{},
+		func(kv roachpb.KeyValue) (bool, error) {
+			startKey, ts, err := keys.DecodeMVCCRangeTombstoneKey(kv.Key)
+			if err != nil {
+				return false, err
+			}
+			endKey, err := kv.Value.GetBytes()
+			if err != nil {
+				return false, errors.Wrapf(err, "decoding MVCC range tombstone %s", kv.Key)
+			}
+			tombstones = append(tombstones, MVCCRangeTombstone{
+				StartKey:  startKey,
+				EndKey:    endKey,
+				Timestamp: ts,
+			})
+			return false, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return tombstones, nil
+}
+
+// MVCCHasRangeTombstones returns whether the range with the given ID has any
+// range tombstones.
+func MVCCHasRangeTombstones(
+	ctx context.Context, reader Reader, rangeID roachpb.RangeID,
+) (bool, error) {
+	prefix := keys.MVCCRangeTombstonePrefix(rangeID)
+	var found bool
+	_, err := MVCCIterate(ctx, reader, prefix, prefix.PrefixEnd(), hlc.Timestamp{}, MVCCScanOptions{},
+		func(roachpb.KeyValue) (bool, error) {
+			found = true
+			return true, nil
+		})
+	return found, err
+}
+
+// MVCCPutRangeTombstone writes a range tombstone to the range with the given
+// ID, deleting all keys in the tombstone's span at its timestamp.
+//
+// Writing the tombstone only adds a single key to the range, but it visits
+// every key in the span once. A WriteIntentError is returned if an intent is
+// found in the span, and a WriteTooOldError if a version at or above the
+// tombstone's timestamp is found. Every key that is live at the time of the
+// tombstone stops being live in the MVCC stats, and a logical MVCC write of a
+// deletion is logged for it so that rangefeeds observe the deletion of the
+// key.
+//
+// Range tombstones cannot be written transactionally. Writers must make sure
+// that no other writes can be performed underneath the tombstone after it has
+// been written, for example by bumping the timestamp cache over its span.
+func MVCCPutRangeTombstone(
+	ctx context.Context,
+	rw ReadWriter,
+	ms *enginepb.MVCCStats,
+	rangeID roachpb.RangeID,
+	tombstone MVCCRangeTombstone,
+) error {
+	if len(tombstone.StartKey) == 0 || tombstone.EndKey.Compare(tombstone.StartKey) <= 0 {
+		return errors.Errorf("invalid span for MVCC range tombstone: %s", tombstone.Span())
+	}
+	if tombstone.Timestamp.IsEmpty() {
+		return errors.Errorf("MVCC range tombstone %s must have a timestamp", tombstone.Span())
+	}
+
+	existing, err := MVCCGetRangeTombstones(ctx, rw, rangeID)
+	if err != nil {
+		return err
+	}
+	existing = existing.Overlapping(tombstone.Span())
+
+	iter := rw.NewIterator(IterOptions{LowerBound: tombstone.StartKey, UpperBound: tombstone.EndKey})
+	defer iter.Close()
+
+	var intents []roachpb.Intent
+	var meta enginepb.MVCCMetadata
+	var deltaMS enginepb.MVCCStats
+	for iter.SeekGE(MakeMVCCMetadataKey(tombstone.StartKey)); ; iter.NextKey() {
+		if ok, err := iter.Valid(); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+		unsafeKey := iter.UnsafeKey()
+		if !unsafeKey.IsValue() {
+			if err := protoutil.Unmarshal(iter.UnsafeValue(), &meta); err != nil {
+				return errors.Wrapf(err, "unmarshaling mvcc meta: %v", unsafeKey)
+			}
+			if meta.IsInline() {
+				return errors.Errorf("cannot write MVCC range tombstone %s over inline value at %s",
+					tombstone, unsafeKey.Key)
+			}
+			if meta.Txn != nil {
+				intents = append(intents, roachpb.MakeIntent(meta.Txn, append(roachpb.Key(nil), unsafeKey.Key...)))
+			}
+			continue
+		}
+		if tombstone.Timestamp.LessEq(unsafeKey.Timestamp) {
+			return roachpb.NewWriteTooOldError(tombstone.Timestamp, unsafeKey.Timestamp.Next())
+		}
+		valSize := int64(len(iter.UnsafeValue()))
+		if valSize == 0 || len(intents) > 0 {
+			continue
+		}
+		// The latest version of the key is live, unless an existing tombstone
+		// deleted it already. If that tombstone is above the new one, the
+		// version now stops being live earlier.
+		prevDeletedSince, deleted := existing.deletedSince(unsafeKey.Key, unsafeKey.Timestamp)
+		if deleted && prevDeletedSince.LessEq(tombstone.Timestamp) {
+			continue
+		}
+		latest := enginepb.MVCCMetadata{KeyBytes: MVCCVersionTimestampSize, ValBytes: valSize}
+		metaKeySize := int64(MakeMVCCMetadataKey(unsafeKey.Key).EncodedSize())
+		keyMS := updateStatsOnRangeDeletion(
+			unsafeKey.Key, metaKeySize, 0, &latest, tombstone.Timestamp.WallTime)
+		if deleted {
+			keyMS.Subtract(updateStatsOnRangeDeletion(
+				unsafeKey.Key, metaKeySize, 0, &latest, prevDeletedSince.WallTime))
+		}
+		deltaMS.Add(keyMS)
+		rw.LogLogicalOp(MVCCWriteValueOpType, MVCCLogicalOpDetails{
+			Key:       unsafeKey.Key,
+			Timestamp: tombstone.Timestamp,
+		})
+	}
+	if len(intents) > 0 {
+		return &roachpb.WriteIntentError{Intents: intents}
+	}
+	if ms != nil {
+		ms.Add(deltaMS)
+	}
+	return putRangeTombstoneRecord(ctx, rw, ms, rangeID, tombstone)
+}
+
+// updateStatsOnRangeDeletion returns the stats delta for the latest version of
+// a key, which is not a deletion, becoming non-live at deletedNanos because a
+// range tombstone deletes it. Unlike a deletion, the range tombstone doesn't
+// add a version to the key, so the metadata and the version stay in place and
+// start accruing GCBytesAge.
+func updateStatsOnRangeDeletion(
+	key roachpb.Key, metaKeySize, metaValSize int64, meta *enginepb.MVCCMetadata, deletedNanos int64,
+) enginepb.MVCCStats {
+	var ms enginepb.MVCCStats
+	if isSysLocal(key) {
+		return ms
+	}
+	ms.AgeTo(deletedNanos)
+	ms.LiveBytes -= meta.KeyBytes + meta.ValBytes + metaKeySize + metaValSize
+	ms.LiveCount--
+	return ms
+}
+
+// ComputeRangeTombstoneStats returns the stats adjustment to add to the stats
+// computed by ComputeStats over [start, end) to account for the given range
+// tombstones. ComputeStats treats the latest version of a key as live, and an
+// older version as non-live since the timestamp of the version shadowing it,
+// while a version deleted by a range tombstone is non-live since the oldest
+// tombstone deleting it if that is earlier.
+func ComputeRangeTombstoneStats(
+	reader Reader, tombstones MVCCRangeTombstones, start, end roachpb.Key, nowNanos int64,
+) (enginepb.MVCCStats, error) {
+	ms := enginepb.MVCCStats{LastUpdateNanos: nowNanos}
+	tombstones = tombstones.Overlapping(roachpb.Span{Key: start, EndKey: end})
+	if len(tombstones) == 0 {
+		return ms, nil
+	}
+	// Only visit the keys that the tombstones may delete.
+	lower, upper := tombstones[0].StartKey, tombstones[0].EndKey
+	for _, t := range tombstones[1:] {
+		if t.StartKey.Compare(lower) < 0 {
+			lower = t.StartKey
+		}
+		if upper.Compare(t.EndKey) < 0 {
+			upper = t.EndKey
+		}
+	}
+	if lower.Compare(start) < 0 {
+		lower = start
+	}
+	if end.Compare(upper) < 0 {
+		upper = end
+	}
+
+	iter := reader.NewIterator(IterOptions{LowerBound: lower, UpperBound: upper})
+	defer iter.Close()
+
+	var prevKey roachpb.Key
+	// shadowedAt is the timestamp of the version newer than the current one.
+	var shadowedAt hlc.Timestamp
+	for iter.SeekGE(MakeMVCCMetadataKey(lower)); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil {
+			return enginepb.MVCCStats{}, err
+		} else if !ok {
+			break
+		}
+		unsafeKey := iter.UnsafeKey()
+		if !unsafeKey.IsValue() || isSysLocal(unsafeKey.Key) {
+			// Intents are never deleted by range tombstones, and there are no
+			// versions under inline values.
+			continue
+		}
+		latest := !bytes.Equal(unsafeKey.Key, prevKey)
+		if latest {
+			prevKey = append(prevKey[:0], unsafeKey.Key...)
+		}
+		valSize := int64(len(iter.UnsafeValue()))
+		// Deletions are non-live since their own timestamp regardless.
+		if valSize > 0 {
+			if deletedSince, ok := tombstones.deletedSince(unsafeKey.Key, unsafeKey.Timestamp); ok {
+				if latest {
+					version := enginepb.MVCCMetadata{KeyBytes: MVCCVersionTimestampSize, ValBytes: valSize}
+					metaKeySize := int64(MakeMVCCMetadataKey(unsafeKey.Key).EncodedSize())
+					keyMS := updateStatsOnRangeDeletion(
+						unsafeKey.Key, metaKeySize, 0, &version, deletedSince.WallTime)
+					keyMS.AgeTo(nowNanos)
+					ms.Add(keyMS)
+				} else if deletedSince.Less(shadowedAt) {
+					ms.GCBytesAge += (MVCCVersionTimestampSize + valSize) *
+						(shadowedAt.WallTime/1e9 - deletedSince.WallTime/1e9)
+				}
+			}
+		}
+		shadowedAt = unsafeKey.Timestamp
+	}
+	return ms, nil
+}
+
+func putRangeTombstoneRecord(
+	ctx context.Context,
+	rw ReadWriter,
+	ms *enginepb.MVCCStats,
+	rangeID roachpb.RangeID,
+	tombstone MVCCRangeTombstone,
+) error {
+	var value roachpb.Value
+	value.SetBytes(tombstone.EndKey)
+	key := keys.MVCCRangeTombstoneKey(rangeID, tombstone.StartKey, tombstone.Timestamp)
+	return MVCCPut(ctx, rw, ms, key, hlc.Timestamp{}, value, nil /* txn */)
+}
+
+// MVCCClearRangeTombstones removes the parts of the range tombstones of the
+// range with the given ID that overlap the given span. Tombstones that extend
+// beyond the span are truncated.
+func MVCCClearRangeTombstones(
+	ctx context.Context,
+	rw ReadWriter,
+	ms *enginepb.MVCCStats,
+	rangeID roachpb.RangeID,
+	span roachpb.Span,
+) error {
+	tombstones, err := MVCCGetRangeTombstones(ctx, rw, rangeID)
+	if err != nil {
+		return err
+	}
+	for _, t := range tombstones.Overlapping(span) {
+		key := keys.MVCCRangeTombstoneKey(rangeID, t.StartKey, t.Timestamp)
+		if err := MVCCDelete(ctx, rw, ms, key, hlc.Timestamp{}, nil /* txn */); err != nil {
+			return err
+		}
+		if t.StartKey.Compare(span.Key) < 0 {
+			left := MVCCRangeTombstone{StartKey: t.StartKey, EndKey: span.Key, Timestamp: t.Timestamp}
+			if err := putRangeTombstoneRecord(ctx, rw, ms, rangeID, left); err != nil {
+				return err
+			}
+		}
+		if span.EndKey.Compare(t.EndKey) < 0 {
+			right := MVCCRangeTombstone{StartKey: span.EndKey, EndKey: t.EndKey, Timestamp: t.Timestamp}
+			if err := putRangeTombstoneRecord(ctx, rw, ms, rangeID, right); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MVCCCopyRangeTombstones copies the parts of the range tombstones of the
+// range with ID srcRangeID that overlap the given span to the range with ID
+// dstRangeID, and returns how many were copied. It is used to hand tombstones
+// over to a different range when ranges are split and merged.
+func MVCCCopyRangeTombstones(
+	ctx context.Context,
+	rw ReadWriter,
+	ms *enginepb.MVCCStats,
+	srcRangeID, dstRangeID roachpb.RangeID,
+	span roachpb.Span,
+) (int, error) {
+	tombstones, err := MVCCGetRangeTombstones(ctx, rw, srcRangeID)
+	if err != nil {
+		return 0, err
+	}
+	overlapping := tombstones.Overlapping(span)
+	for _, t := range overlapping {
+		if t.StartKey.Compare(span.Key) < 0 {
+			t.StartKey = span.Key
+		}
+		if span.EndKey.Compare(t.EndKey) < 0 {
+			t.EndKey = span.EndKey
+		}
+		if err := putRangeTombstoneRecord(ctx, rw, ms, dstRangeID, t); err != nil {
+			return 0, err
+		}
+	}
+	return len(overlapping), nil
+}
+
+// ExportRangeTombstonesToSst exports the deletions performed by range
+// tombstones in (startTS, endTS] to an SSTable. For every key in
+// [startKey, endKey) that is covered by such a tombstone, a point deletion is
+// written at the tombstone's timestamp. This allows consumers of exported
+// data, like incremental backups, to learn about the deletions without
+// knowing about range tombstones.
+func ExportRangeTombstonesToSst(
+	reader Reader,
+	tombstones MVCCRangeTombstones,
+	startKey, endKey roachpb.Key,
+	startTS, endTS hlc.Timestamp,
+) ([]byte, roachpb.BulkOpSummary, error) {
+	var inWindow MVCCRangeTombstones
+	for _, t := range tombstones.Overlapping(roachpb.Span{Key: startKey, EndKey: endKey}) {
+		if startTS.Less(t.Timestamp) && t.Timestamp.LessEq(endTS) {
+			inWindow = append(inWindow, t)
+		}
+	}
+	if len(inWindow) == 0 {
+		return nil, roachpb.BulkOpSummary{}, nil
+	}
+
+	sstFile := &MemFile{}
+	sstWriter := MakeBackupSSTWriter(sstFile)
+	defer sstWriter.Close()
+
+	var rows RowCounter
+	iter := reader.NewIterator(IterOptions{UpperBound: endKey})
+	defer iter.Close()
+	for iter.SeekGE(MakeMVCCMetadataKey(startKey)); ; iter.NextKey() {
+		if ok, err := iter.Valid(); err != nil {
+			return nil, roachpb.BulkOpSummary{}, err
+		} else if !ok {
+			break
+		}
+		key := iter.UnsafeKey().Key
+		for _, ts := range inWindow.timestampsCovering(key) {
+			if err := rows.Count(key); err != nil {
+				return nil, roachpb.BulkOpSummary{}, errors.Wrapf(err, "decoding %s", key)
+			}
+			if err := sstWriter.Put(MVCCKey{Key: key, Timestamp: ts}, nil); err != nil {
+				return nil, roachpb.BulkOpSummary{}, errors.Wrapf(err, "adding key %s", key)
+			}
+			rows.BulkOpSummary.DataSize += int64(len(key))
+		}
+	}
+
+	if rows.BulkOpSummary.DataSize == 0 {
+		return nil, roachpb.BulkOpSummary{}, nil
+	}
+	if err := sstWriter.Finish(); err != nil {
+		return nil, roachpb.BulkOpSummary{}, err
+	}
+	return sstFile.Data(), rows.BulkOpSummary, nil
+}
+
+// rangeTombstoneIterator wraps a SimpleIterator and surfaces the deletions
+// performed by range tombstones as point deletions. For every key that the
+// wrapped iterator visits and that is covered by a tombstone, an additional
+// version with an empty value is synthesized at the tombstone's timestamp.
+type rangeTombstoneIterator struct {
+	iter       SimpleIterator
+	tombstones MVCCRangeTombstones
+
+	// curKey is the key the iterator is positioned on, and pending holds the
+	// timestamps of the synthesized deletions of curKey that have not been
+	// surfaced yet, in descending order.
+	curKey  roachpb.Key
+	pending []hlc.Timestamp
+	// synthetic is set if the iterator is positioned on pending[0] rather
+	// than on the wrapped iterator's entry.
+	synthetic bool
+}
+
+var _ SimpleIterator = &rangeTombstoneIterator{}
+
+// NewRangeTombstoneIterator returns a SimpleIterator that surfaces the
+// deletions performed by the given range tombstones as point deletions
+// interleaved with the versions returned by the wrapped iterator. The wrapped
+// iterator must visit every key that may be deleted by the tombstones, so it
+// must not be a time-bound iterator.
+func NewRangeTombstoneIterator(
+	iter SimpleIterator, tombstones MVCCRangeTombstones,
+) SimpleIterator {
+	if len(tombstones) == 0 {
+		return iter
+	}
+	return &rangeTombstoneIterator{iter: iter, tombstones: tombstones}
+}
+
+// Close implements the SimpleIterator interface.
+func (i *rangeTombstoneIterator) Close() {
+	i.iter.Close()
+}
+
+// SeekGE implements the SimpleIterator interface.
+func (i *rangeTombstoneIterator) SeekGE(key MVCCKey) {
+	i.iter.SeekGE(key)
+	i.curKey, i.pending = nil, nil
+	i.settle()
+	if bytes.Equal(i.curKey, key.Key) && !key.Timestamp.IsEmpty() {
+		// Versions above the seek timestamp must not be surfaced.
+		for len(i.pending) > 0 && key.Timestamp.Less(i.pending[0]) {
+			i.pending = i.pending[1:]
+		}
+		i.settle()
+	}
+}
+
+// Valid implements the SimpleIterator interface.
+func (i *rangeTombstoneIterator) Valid() (bool, error) {
+	if i.synthetic {
+		return true, nil
+	}
+	return i.iter.Valid()
+}
+
+// Next implements the SimpleIterator interface.
+func (i *rangeTombstoneIterator) Next() {
+	if i.synthetic {
+		i.pending = i.pending[1:]
+	} else {
+		i.iter.Next()
+	}
+	i.settle()
+}
+
+// NextKey implements the SimpleIterator interface.
+func (i *rangeTombstoneIterator) NextKey() {
+	i.pending = nil
+	if ok, _ := i.iter.Valid(); ok && bytes.Equal(i.iter.UnsafeKey().Key, i.curKey) {
+		i.iter.NextKey()
+	}
+	i.settle()
+}
+
+// UnsafeKey implements the SimpleIterator interface.
+func (i *rangeTombstoneIterator) UnsafeKey() MVCCKey {
+	if i.synthetic {
+		return MVCCKey{Key: i.curKey, Timestamp: i.pending[0]}
+	}
+	return i.iter.UnsafeKey()
+}
+
+// UnsafeValue implements the SimpleIterator interface.
+func (i *rangeTombstoneIterator) UnsafeValue() []byte {
+	if i.synthetic {
+		return nil
+	}
+	return i.iter.UnsafeValue()
+}
+
+// settle decides whether the iterator is positioned on the wrapped iterator's
+// entry or on a synthesized deletion, after the wrapped iterator has moved.
+func (i *rangeTombstoneIterator) settle() {
+	ok, err := i.iter.Valid()
+	if err != nil {
+		i.synthetic = false
+		return
+	}
+	if ok {
+		key := i.iter.UnsafeKey()
+		if !bytes.Equal(key.Key, i.curKey) {
+			if len(i.pending) > 0 {
+				// Surface the remaining deletions of the previous key first.
+				i.synthetic = true
+				return
+			}
+			i.curKey = append(i.curKey[:0], key.Key...)
+			i.pending = i.tombstones.timestampsCovering(i.curKey)
+		}
+		// The metadata key sorts before all versions, and versions sort in
+		// descending timestamp order.
+		i.synthetic = len(i.pending) > 0 && key.IsValue() && key.Timestamp.Less(i.pending[0])
+		return
+	}
+	i.synthetic = len(i.pending) > 0
+}
@@ -0,0 +1,336 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMVCCRangeTombstonesDeletes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tombstones := MVCCRangeTombstones{
+		{StartKey: roachpb.Key("b"), EndKey: roachpb.Key("d"), Timestamp: hlc.Timestamp{WallTime: 5}},
+	}
+	testCases := []struct {
+		key             string
+		valueTS, readTS int64
+		expected        bool
+	}{
+		{"a", 1, 10, false},
+		{"b", 1, 10, true},
+		{"c", 4, 5, true},
+		{"c", 5, 10, false},
+		{"c", 1, 4, false},
+		{"d", 1, 10, false},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, tombstones.Deletes(roachpb.Key(tc.key),
+			hlc.Timestamp{WallTime: tc.valueTS}, hlc.Timestamp{WallTime: tc.readTS}), "%+v", tc)
+	}
+}
+
+func TestMVCCRangeTombstoneReads(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	for _, engineImpl := range mvccEngineImpls {
+		t.Run(engineImpl.name, func(t *testing.T) {
+			engine := engineImpl.create()
+			defer engine.Close()
+
+			ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+			for _, k := range []string{"a", "b", "c", "d"} {
+				require.NoError(t, MVCCPut(ctx, engine, nil, roachpb.Key(k), ts(1), value1, nil))
+			}
+			require.NoError(t, MVCCPut(ctx, engine, nil, roachpb.Key("c"), ts(3), value2, nil))
+
+			var ms enginepb.MVCCStats
+			tombstone := MVCCRangeTombstone{
+				StartKey: roachpb.Key("b"), EndKey: roachpb.Key("d"), Timestamp: ts(5),
+			}
+			require.NoError(t, MVCCPutRangeTombstone(ctx, engine, &ms, 1, tombstone))
+			require.NotZero(t, ms.SysBytes)
+
+			tombstones, err := MVCCGetRangeTombstones(ctx, engine, 1)
+			require.NoError(t, err)
+			require.Equal(t, MVCCRangeTombstones{tombstone}, tombstones)
+
+			// Writing underneath an existing version fails.
+			err = MVCCPutRangeTombstone(ctx, engine, nil, 1, MVCCRangeTombstone{
+				StartKey: roachpb.Key("a"), EndKey: roachpb.Key("b"), Timestamp: ts(1),
+			})
+			require.IsType(t, &roachpb.WriteTooOldError{}, err)
+
+			scan := func(readTS hlc.Timestamp) []string {
+				res, err := MVCCScan(ctx, engine, roachpb.Key("a"), roachpb.Key("z"), readTS,
+					MVCCScanOptions{RangeTombstones: tombstones})
+				require.NoError(t, err)
+				var keys []string
+				for _, kv := range res.KVs {
+					keys = append(keys, string(kv.Key))
+				}
+				return keys
+			}
+			require.Equal(t, []string{"a", "b", "c", "d"}, scan(ts(4)))
+			require.Equal(t, []string{"a", "d"}, scan(ts(5)))
+
+			val, _, err := MVCCGet(ctx, engine, roachpb.Key("c"), ts(6),
+				MVCCGetOptions{RangeTombstones: tombstones})
+			require.NoError(t, err)
+			require.Nil(t, val)
+			val, _, err = MVCCGet(ctx, engine, roachpb.Key("c"), ts(6),
+				MVCCGetOptions{RangeTombstones: tombstones, Tombstones: true})
+			require.NoError(t, err)
+			require.NotNil(t, val)
+			require.False(t, val.IsPresent())
+
+			// A version written above the tombstone is visible.
+			require.NoError(t, MVCCPut(ctx, engine, nil, roachpb.Key("b"), ts(7), value3, nil))
+			require.Equal(t, []string{"a", "b", "d"}, scan(ts(7)))
+		})
+	}
+}
+
+func TestMVCCRangeTombstoneWrites(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	for _, engineImpl := range mvccEngineImpls {
+		t.Run(engineImpl.name, func(t *testing.T) {
+			engine := engineImpl.create()
+			defer engine.Close()
+
+			ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+			for _, k := range []string{"a", "b", "c"} {
+				require.NoError(t, MVCCPut(ctx, engine, nil, roachpb.Key(k), ts(1), value1, nil))
+			}
+			_, err := MVCCIncrement(ctx, engine, nil, roachpb.Key("i"), ts(1), nil, 5, nil)
+			require.NoError(t, err)
+			tombstones := MVCCRangeTombstones{
+				{StartKey: roachpb.Key("a"), EndKey: roachpb.Key("z"), Timestamp: ts(5)},
+			}
+			require.NoError(t, MVCCPutRangeTombstone(ctx, engine, nil, 1, tombstones[0]))
+
+			// Writes below the tombstone would be deleted by it right away.
+			err = MVCCConditionalPut(ctx, engine, nil, roachpb.Key("a"), ts(4), value2, nil,
+				CPutAllowIfMissing, nil, tombstones)
+			require.IsType(t, &roachpb.WriteTooOldError{}, err)
+			require.Equal(t, ts(5), err.(*roachpb.WriteTooOldError).ActualTimestamp)
+			require.Equal(t, ts(5), tombstones.CheckWrite(roachpb.Key("x"), ts(4)).(*roachpb.WriteTooOldError).ActualTimestamp)
+			require.NoError(t, tombstones.CheckWrite(roachpb.Key("x"), ts(5)))
+			require.NoError(t, tombstones.CheckWrite(roachpb.Key("z"), ts(4)))
+
+			// Above the tombstone, the deleted values read as deletion tombstones.
+			require.NoError(t, MVCCConditionalPut(ctx, engine, nil, roachpb.Key("a"), ts(6), value2, nil,
+				CPutFailIfMissing, nil, tombstones))
+			err = MVCCConditionalPut(ctx, engine, nil, roachpb.Key("b"), ts(6), value2, &value1,
+				CPutFailIfMissing, nil, tombstones)
+			require.IsType(t, &roachpb.ConditionFailedError{}, err)
+			require.NoError(t, MVCCInitPut(ctx, engine, nil, roachpb.Key("b"), ts(6), value2,
+				false /* failOnTombstones */, nil, tombstones))
+			err = MVCCInitPut(ctx, engine, nil, roachpb.Key("c"), ts(6), value2,
+				true /* failOnTombstones */, nil, tombstones)
+			require.IsType(t, &roachpb.ConditionFailedError{}, err)
+			newVal, err := MVCCIncrement(ctx, engine, nil, roachpb.Key("i"), ts(6), nil, 1, tombstones)
+			require.NoError(t, err)
+			require.Equal(t, int64(1), newVal)
+
+			// Without the tombstones, the deleted values are still visible.
+			err = MVCCInitPut(ctx, engine, nil, roachpb.Key("c"), ts(7), value2,
+				false /* failOnTombstones */, nil, nil /* rangeTombstones */)
+			require.IsType(t, &roachpb.ConditionFailedError{}, err)
+		})
+	}
+}
+
+func TestMVCCRangeTombstoneStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	for _, engineImpl := range mvccEngineImpls {
+		t.Run(engineImpl.name, func(t *testing.T) {
+			engine := engineImpl.create()
+			defer engine.Close()
+
+			ts := func(sec int64) hlc.Timestamp { return hlc.Timestamp{WallTime: sec * 1e9} }
+			var ms enginepb.MVCCStats
+			// assertStats checks ms against the stats computed from scratch,
+			// adjusted for the data deleted by the range tombstones.
+			assertStats := func(debug string, now hlc.Timestamp, expLiveCount int64) {
+				t.Helper()
+				tombstones, err := MVCCGetRangeTombstones(ctx, engine, 1)
+				require.NoError(t, err)
+				iter := engine.NewIterator(IterOptions{UpperBound: roachpb.KeyMax})
+				defer iter.Close()
+				expMS, err := ComputeStatsGo(iter, roachpb.KeyMin, roachpb.KeyMax, now.WallTime)
+				require.NoError(t, err)
+				tombstoneMS, err := ComputeRangeTombstoneStats(
+					engine, tombstones, roachpb.KeyMin, roachpb.KeyMax, now.WallTime)
+				require.NoError(t, err)
+				expMS.Add(tombstoneMS)
+
+				actualMS := ms
+				actualMS.AgeTo(now.WallTime)
+				require.Equal(t, expMS, actualMS, debug)
+				require.Equal(t, expLiveCount, actualMS.LiveCount, debug)
+			}
+
+			for _, k := range []string{"a", "b", "c", "d"} {
+				require.NoError(t, MVCCPut(ctx, engine, &ms, roachpb.Key(k), ts(1), value1, nil))
+			}
+			require.NoError(t, MVCCPut(ctx, engine, &ms, roachpb.Key("c"), ts(3), value2, nil))
+			assertStats("initial", ts(3), 4)
+
+			// The keys live at the time of the tombstone stop being live.
+			require.NoError(t, MVCCPutRangeTombstone(ctx, engine, &ms, 1, MVCCRangeTombstone{
+				StartKey: roachpb.Key("b"), EndKey: roachpb.Key("d"), Timestamp: ts(5),
+			}))
+			assertStats("after tombstone", ts(6), 2)
+
+			// Writing a deleted key makes it live again, while the version below
+			// stays non-live since the tombstone.
+			tombstones, err := MVCCGetRangeTombstones(ctx, engine, 1)
+			require.NoError(t, err)
+			require.NoError(t, MVCCPutWithRangeTombstones(
+				ctx, engine, &ms, roachpb.Key("b"), ts(7), value3, nil, tombstones))
+			assertStats("after put", ts(8), 3)
+
+			// A newer overlapping tombstone only accounts for the keys that were
+			// still live.
+			require.NoError(t, MVCCPutRangeTombstone(ctx, engine, &ms, 1, MVCCRangeTombstone{
+				StartKey: roachpb.Key("a"), EndKey: roachpb.Key("c"), Timestamp: ts(9),
+			}))
+			assertStats("after second tombstone", ts(10), 1)
+
+			// Garbage collecting the data deleted by the first tombstone.
+			tombstones, err = MVCCGetRangeTombstones(ctx, engine, 1)
+			require.NoError(t, err)
+			require.NoError(t, MVCCGarbageCollectWithRangeTombstones(ctx, engine, &ms,
+				[]roachpb.GCRequest_GCKey{
+					{Key: roachpb.Key("b"), Timestamp: ts(1)},
+					{Key: roachpb.Key("c"), Timestamp: ts(3)},
+				}, ts(11), tombstones.AtOrBelow(ts(6))))
+			assertStats("after GC", ts(12), 1)
+		})
+	}
+}
+
+func TestMVCCRangeTombstoneSplitAndMerge(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	for _, engineImpl := range mvccEngineImpls {
+		t.Run(engineImpl.name, func(t *testing.T) {
+			engine := engineImpl.create()
+			defer engine.Close()
+
+			ts := hlc.Timestamp{WallTime: 5}
+			tombstone := MVCCRangeTombstone{
+				StartKey: roachpb.Key("a"), EndKey: roachpb.Key("z"), Timestamp: ts,
+			}
+			has, err := MVCCHasRangeTombstones(ctx, engine, 1)
+			require.NoError(t, err)
+			require.False(t, has)
+			require.NoError(t, MVCCPutRangeTombstone(ctx, engine, nil, 1, tombstone))
+			has, err = MVCCHasRangeTombstones(ctx, engine, 1)
+			require.NoError(t, err)
+			require.True(t, has)
+
+			// Split at "m".
+			rightSpan := roachpb.Span{Key: roachpb.Key("m"), EndKey: roachpb.KeyMax}
+			copied, err := MVCCCopyRangeTombstones(ctx, engine, nil, 1, 2, rightSpan)
+			require.NoError(t, err)
+			require.Equal(t, 1, copied)
+			require.NoError(t, MVCCClearRangeTombstones(ctx, engine, nil, 1, rightSpan))
+
+			left, err := MVCCGetRangeTombstones(ctx, engine, 1)
+			require.NoError(t, err)
+			require.Equal(t, MVCCRangeTombstones{
+				{StartKey: roachpb.Key("a"), EndKey: roachpb.Key("m"), Timestamp: ts},
+			}, left)
+			right, err := MVCCGetRangeTombstones(ctx, engine, 2)
+			require.NoError(t, err)
+			require.Equal(t, MVCCRangeTombstones{
+				{StartKey: roachpb.Key("m"), EndKey: roachpb.Key("z"), Timestamp: ts},
+			}, right)
+
+			// Merge the ranges back together.
+			copied, err = MVCCCopyRangeTombstones(ctx, engine, nil, 2, 1, rightSpan)
+			require.NoError(t, err)
+			require.Equal(t, 1, copied)
+			merged, err := MVCCGetRangeTombstones(ctx, engine, 1)
+			require.NoError(t, err)
+			require.Len(t, merged, 2)
+			for _, k := range []string{"a", "l", "m", "y"} {
+				require.True(t, merged.Deletes(roachpb.Key(k), hlc.Timestamp{WallTime: 1}, ts), k)
+			}
+		})
+	}
+}
+
+func TestRangeTombstoneIterator(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	for _, engineImpl := range mvccEngineImpls {
+		t.Run(engineImpl.name, func(t *testing.T) {
+			engine := engineImpl.create()
+			defer engine.Close()
+
+			ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+			require.NoError(t, MVCCPut(ctx, engine, nil, roachpb.Key("a"), ts(1), value1, nil))
+			require.NoError(t, MVCCPut(ctx, engine, nil, roachpb.Key("b"), ts(1), value1, nil))
+			require.NoError(t, MVCCPut(ctx, engine, nil, roachpb.Key("b"), ts(7), value2, nil))
+			require.NoError(t, MVCCPut(ctx, engine, nil, roachpb.Key("c"), ts(1), value1, nil))
+
+			tombstones := MVCCRangeTombstones{
+				{StartKey: roachpb.Key("b"), EndKey: roachpb.Key("c"), Timestamp: ts(5)},
+			}
+			iter := NewRangeTombstoneIterator(
+				engine.NewIterator(IterOptions{UpperBound: roachpb.Key("z")}), tombstones)
+			defer iter.Close()
+
+			var actual []MVCCKey
+			var deletions int
+			for iter.SeekGE(MakeMVCCMetadataKey(roachpb.Key("a"))); ; iter.Next() {
+				ok, err := iter.Valid()
+				require.NoError(t, err)
+				if !ok {
+					break
+				}
+				key := iter.UnsafeKey()
+				actual = append(actual, MVCCKey{
+					Key:       append(roachpb.Key(nil), key.Key...),
+					Timestamp: key.Timestamp,
+				})
+				if len(iter.UnsafeValue()) == 0 {
+					deletions++
+				}
+			}
+			require.Equal(t, []MVCCKey{
+				{Key: roachpb.Key("a"), Timestamp: ts(1)},
+				{Key: roachpb.Key("b"), Timestamp: ts(7)},
+				{Key: roachpb.Key("b"), Timestamp: ts(5)},
+				{Key: roachpb.Key("b"), Timestamp: ts(1)},
+				{Key: roachpb.Key("c"), Timestamp: ts(1)},
+			}, actual)
+			require.Equal(t, 1, deletions)
+		})
+	}
+}
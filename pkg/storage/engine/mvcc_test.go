@@ -1818,7 +1818,7 @@ func TestMVCCDeleteRange(t *testing.T) {
 
 			// Attempt to delete two keys.
 			deleted, resumeSpan, num, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey6, 2, hlc.Timestamp{WallTime: 2}, nil, false,
+				ctx, engine, nil, testKey2, testKey6, 2, hlc.Timestamp{WallTime: 2}, nil, false, nil, /* rangeTombstones */
 			)
 			if err != nil {
 				t.Fatal(err)
@@ -1875,7 +1875,7 @@ func TestMVCCDeleteRange(t *testing.T) {
 
 			// Attempt to delete no keys.
 			deleted, resumeSpan, num, err = MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey6, -1, hlc.Timestamp{WallTime: 2}, nil, false)
+				ctx, engine, nil, testKey2, testKey6, -1, hlc.Timestamp{WallTime: 2}, nil, false, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -1903,7 +1903,7 @@ func TestMVCCDeleteRange(t *testing.T) {
 			}
 
 			deleted, resumeSpan, num, err = MVCCDeleteRange(
-				ctx, engine, nil, testKey4, keyMax, 0, hlc.Timestamp{WallTime: 2}, nil, false)
+				ctx, engine, nil, testKey4, keyMax, 0, hlc.Timestamp{WallTime: 2}, nil, false, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -1928,7 +1928,7 @@ func TestMVCCDeleteRange(t *testing.T) {
 			}
 
 			deleted, resumeSpan, num, err = MVCCDeleteRange(
-				ctx, engine, nil, keyMin, testKey2, 0, hlc.Timestamp{WallTime: 2}, nil, false)
+				ctx, engine, nil, keyMin, testKey2, 0, hlc.Timestamp{WallTime: 2}, nil, false, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -1983,7 +1983,7 @@ func TestMVCCDeleteRangeReturnKeys(t *testing.T) {
 
 			// Attempt to delete two keys.
 			deleted, resumeSpan, num, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey6, 2, hlc.Timestamp{WallTime: 2}, nil, true)
+				ctx, engine, nil, testKey2, testKey6, 2, hlc.Timestamp{WallTime: 2}, nil, true, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2018,7 +2018,7 @@ func TestMVCCDeleteRangeReturnKeys(t *testing.T) {
 
 			// Attempt to delete no keys.
 			deleted, resumeSpan, num, err = MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey6, -1, hlc.Timestamp{WallTime: 2}, nil, true)
+				ctx, engine, nil, testKey2, testKey6, -1, hlc.Timestamp{WallTime: 2}, nil, true, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2046,7 +2046,7 @@ func TestMVCCDeleteRangeReturnKeys(t *testing.T) {
 			}
 
 			deleted, resumeSpan, num, err = MVCCDeleteRange(
-				ctx, engine, nil, testKey4, keyMax, math.MaxInt64, hlc.Timestamp{WallTime: 2}, nil, true)
+				ctx, engine, nil, testKey4, keyMax, math.MaxInt64, hlc.Timestamp{WallTime: 2}, nil, true, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2077,7 +2077,7 @@ func TestMVCCDeleteRangeReturnKeys(t *testing.T) {
 			}
 
 			deleted, resumeSpan, num, err = MVCCDeleteRange(
-				ctx, engine, nil, keyMin, testKey2, math.MaxInt64, hlc.Timestamp{WallTime: 2}, nil, true)
+				ctx, engine, nil, keyMin, testKey2, math.MaxInt64, hlc.Timestamp{WallTime: 2}, nil, true, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2128,14 +2128,14 @@ func TestMVCCDeleteRangeFailed(t *testing.T) {
 			}
 
 			if _, _, _, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey4, math.MaxInt64, hlc.Timestamp{WallTime: 1}, nil, false,
+				ctx, engine, nil, testKey2, testKey4, math.MaxInt64, hlc.Timestamp{WallTime: 1}, nil, false, nil, /* rangeTombstones */
 			); err == nil {
 				t.Fatal("expected error on uncommitted write intent")
 			}
 
 			txn.Sequence++
 			if _, _, _, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey4, math.MaxInt64, txn.ReadTimestamp, txn, false,
+				ctx, engine, nil, testKey2, testKey4, math.MaxInt64, txn.ReadTimestamp, txn, false, nil, /* rangeTombstones */
 			); err != nil {
 				t.Fatal(err)
 			}
@@ -2169,7 +2169,7 @@ func TestMVCCDeleteRangeConcurrentTxn(t *testing.T) {
 			}
 
 			if _, _, _, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey4, math.MaxInt64, txn1ts.ReadTimestamp, txn1ts, false,
+				ctx, engine, nil, testKey2, testKey4, math.MaxInt64, txn1ts.ReadTimestamp, txn1ts, false, nil, /* rangeTombstones */
 			); err == nil {
 				t.Fatal("expected error on uncommitted write intent")
 			}
@@ -2212,7 +2212,7 @@ func TestMVCCUncommittedDeleteRangeVisible(t *testing.T) {
 
 			txn := makeTxn(*txn1, hlc.Timestamp{WallTime: 2})
 			if _, _, _, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey1, testKey4, math.MaxInt64, txn.ReadTimestamp, txn, false,
+				ctx, engine, nil, testKey1, testKey4, math.MaxInt64, txn.ReadTimestamp, txn, false, nil, /* rangeTombstones */
 			); err != nil {
 				t.Fatal(err)
 			}
@@ -2259,7 +2259,7 @@ func TestMVCCDeleteRangeInline(t *testing.T) {
 
 			// Attempt to delete two inline keys, should succeed.
 			deleted, resumeSpan, num, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey6, 2, hlc.Timestamp{Logical: 0}, nil, true,
+				ctx, engine, nil, testKey2, testKey6, 2, hlc.Timestamp{Logical: 0}, nil, true, nil, /* rangeTombstones */
 			)
 			if err != nil {
 				t.Fatal(err)
@@ -2278,21 +2278,21 @@ func TestMVCCDeleteRangeInline(t *testing.T) {
 
 			// Attempt to delete inline keys at a timestamp; should fail.
 			if _, _, _, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey1, testKey6, 1, hlc.Timestamp{WallTime: 2}, nil, true,
+				ctx, engine, nil, testKey1, testKey6, 1, hlc.Timestamp{WallTime: 2}, nil, true, nil, /* rangeTombstones */
 			); !testutils.IsError(err, inlineMismatchErrString) {
 				t.Fatalf("got error %v, expected error with text '%s'", err, inlineMismatchErrString)
 			}
 
 			// Attempt to delete non-inline key at zero timestamp; should fail.
 			if _, _, _, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey6, keyMax, 1, hlc.Timestamp{Logical: 0}, nil, true,
+				ctx, engine, nil, testKey6, keyMax, 1, hlc.Timestamp{Logical: 0}, nil, true, nil, /* rangeTombstones */
 			); !testutils.IsError(err, inlineMismatchErrString) {
 				t.Fatalf("got error %v, expected error with text '%s'", err, inlineMismatchErrString)
 			}
 
 			// Attempt to delete inline keys in a transaction; should fail.
 			if _, _, _, err := MVCCDeleteRange(
-				ctx, engine, nil, testKey2, testKey6, 2, hlc.Timestamp{Logical: 0}, txn1, true,
+				ctx, engine, nil, testKey2, testKey6, 2, hlc.Timestamp{Logical: 0}, txn1, true, nil, /* rangeTombstones */
 			); !testutils.IsError(err, "writes not allowed within transactions") {
 				t.Errorf("unexpected error: %+v", err)
 			}
@@ -2684,13 +2684,13 @@ func TestMVCCInitPut(t *testing.T) {
 			engine := engineImpl.create()
 			defer engine.Close()
 
-			err := MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 1}, value1, false, nil)
+			err := MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 1}, value1, false, nil, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
 
 			// A repeat of the command will still succeed
-			err = MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 2}, value1, false, nil)
+			err = MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 2}, value1, false, nil, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2702,7 +2702,7 @@ func TestMVCCInitPut(t *testing.T) {
 			}
 
 			// Reinserting the value fails if we fail on tombstones.
-			err = MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 4}, value1, true, nil)
+			err = MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 4}, value1, true, nil, nil /* rangeTombstones */)
 			switch e := err.(type) {
 			case *roachpb.ConditionFailedError:
 				if !bytes.Equal(e.ActualValue.RawBytes, nil) {
@@ -2715,13 +2715,13 @@ func TestMVCCInitPut(t *testing.T) {
 			}
 
 			// But doesn't if we *don't* fail on tombstones.
-			err = MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 5}, value1, false, nil)
+			err = MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 5}, value1, false, nil, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
 
 			// A repeat of the command with a different value will fail.
-			err = MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 6}, value2, false, nil)
+			err = MVCCInitPut(ctx, engine, nil, testKey1, hlc.Timestamp{Logical: 6}, value2, false, nil, nil /* rangeTombstones */)
 			switch e := err.(type) {
 			case *roachpb.ConditionFailedError:
 				if !bytes.Equal(e.ActualValue.RawBytes, value1.RawBytes) {
@@ -2781,14 +2781,14 @@ func TestMVCCInitPutWithTxn(t *testing.T) {
 
 			txn := *txn1
 			txn.Sequence++
-			err := MVCCInitPut(ctx, engine, nil, testKey1, txn.ReadTimestamp, value1, false, &txn)
+			err := MVCCInitPut(ctx, engine, nil, testKey1, txn.ReadTimestamp, value1, false, &txn, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
 
 			// A repeat of the command will still succeed.
 			txn.Sequence++
-			err = MVCCInitPut(ctx, engine, nil, testKey1, txn.ReadTimestamp, value1, false, &txn)
+			err = MVCCInitPut(ctx, engine, nil, testKey1, txn.ReadTimestamp, value1, false, &txn, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2797,7 +2797,7 @@ func TestMVCCInitPutWithTxn(t *testing.T) {
 			// will still succeed.
 			txn.Sequence++
 			txn.Epoch = 2
-			err = MVCCInitPut(ctx, engine, nil, testKey1, txn.ReadTimestamp, value2, false, &txn)
+			err = MVCCInitPut(ctx, engine, nil, testKey1, txn.ReadTimestamp, value2, false, &txn, nil /* rangeTombstones */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2812,7 +2812,7 @@ func TestMVCCInitPutWithTxn(t *testing.T) {
 			}
 
 			// Write value4 with an old timestamp without txn...should get an error.
-			err = MVCCInitPut(ctx, engine, nil, testKey1, clock.Now(), value4, false, nil)
+			err = MVCCInitPut(ctx, engine, nil, testKey1, clock.Now(), value4, false, nil, nil /* rangeTombstones */)
 			switch e := err.(type) {
 			case *roachpb.ConditionFailedError:
 				if !bytes.Equal(e.ActualValue.RawBytes, value2.RawBytes) {
@@ -3253,7 +3253,7 @@ func TestMVCCConditionalPutOldTimestamp(t *testing.T) {
 			}
 
 			// Check nothing is written if the value doesn't match.
-			err = MVCCConditionalPut(ctx, engine, nil, testKey1, hlc.Timestamp{WallTime: 2}, value3, &value1, CPutFailIfMissing, nil)
+			err = MVCCConditionalPut(ctx, engine, nil, testKey1, hlc.Timestamp{WallTime: 2}, value3, &value1, CPutFailIfMissing, nil, nil /* rangeTombstones */)
 			if err == nil {
 				t.Errorf("unexpected success on conditional put")
 			}
@@ -3263,7 +3263,7 @@ func TestMVCCConditionalPutOldTimestamp(t *testing.T) {
 
 			// But if value does match the most recently written version, we'll get
 			// a write too old error but still write updated value.
-			err = MVCCConditionalPut(ctx, engine, nil, testKey1, hlc.Timestamp{WallTime: 2}, value3, &value2, CPutFailIfMissing, nil)
+			err = MVCCConditionalPut(ctx, engine, nil, testKey1, hlc.Timestamp{WallTime: 2}, value3, &value2, CPutFailIfMissing, nil, nil /* rangeTombstones */)
 			if err == nil {
 				t.Errorf("unexpected success on conditional put")
 			}
@@ -3804,7 +3804,7 @@ func TestMVCCDeleteRangeWithSequence(t *testing.T) {
 					const origSeq = 6
 					txn.Sequence = origSeq
 					origDeleted, _, origNum, err := MVCCDeleteRange(
-						ctx, engine, nil, prefix, prefix.PrefixEnd(), math.MaxInt64, txn.WriteTimestamp, &txn, true,
+						ctx, engine, nil, prefix, prefix.PrefixEnd(), math.MaxInt64, txn.WriteTimestamp, &txn, true, nil, /* rangeTombstones */
 					)
 					if err != nil {
 						t.Fatal(err)
@@ -3812,7 +3812,7 @@ func TestMVCCDeleteRangeWithSequence(t *testing.T) {
 
 					txn.Sequence = tc.sequence
 					deleted, _, num, err := MVCCDeleteRange(
-						ctx, engine, nil, prefix, prefix.PrefixEnd(), math.MaxInt64, txn.WriteTimestamp, &txn, true,
+						ctx, engine, nil, prefix, prefix.PrefixEnd(), math.MaxInt64, txn.WriteTimestamp, &txn, true, nil, /* rangeTombstones */
 					)
 					if tc.expErr != "" && err != nil {
 						if !testutils.IsError(err, tc.expErr) {
@@ -112,6 +112,9 @@ type pebbleMVCCScanner struct {
 	txnIgnoredSeqNums []enginepb.IgnoredSeqNumRange
 	// Metadata object for unmarshalling intents.
 	meta enginepb.MVCCMetadata
+	// Range tombstones copied over from MVCC{Scan,Get}Options. Versions
+	// deleted by one of them are treated as deletion tombstones.
+	rangeTombstones MVCCRangeTombstones
 	// Bools copied over from MVCC{Scan,Get}Options. See the comment on the
 	// package level MVCCScan for what these mean.
 	inconsistent, tombstones bool
@@ -552,6 +555,17 @@ func (p *pebbleMVCCScanner) advanceKeyAtNewKey(key []byte) bool {
 // p.tombstones is true. Advances to the next key unless we've reached the max
 // results limit.
 func (p *pebbleMVCCScanner) addAndAdvance(val []byte) bool {
+	if len(p.rangeTombstones) > 0 && len(val) > 0 && !p.curTS.IsEmpty() {
+		if p.rangeTombstones.Deletes(p.curKey, p.curTS, p.ts) {
+			val = nil
+		} else if p.checkUncertainty {
+			// A range tombstone in the uncertainty interval of the txn deleted
+			// the version.
+			if ts, ok := p.rangeTombstones.deletedAt(p.curKey, p.curTS, p.txn.MaxTimestamp); ok {
+				return p.uncertaintyError(ts)
+			}
+		}
+	}
 	// Don't include deleted versions len(val) == 0, unless we've been instructed
 	// to include tombstones in the results.
 	if len(val) > 0 || p.tombstones {
@@ -168,14 +168,40 @@ func Run(
 		Threshold: newThreshold,
 	}
 
+	// MVCC range tombstones at or below the threshold make all versions they
+	// delete garbage.
+	rangeTombstones, err := engine.MVCCGetRangeTombstones(ctx, snap, desc.RangeID)
+	if err != nil {
+		return Info{}, err
+	}
+	rangeTombstones = rangeTombstones.AtOrBelow(newThreshold)
+
 	// Maps from txn ID to txn and intent key slice.
 	txnMap := map[uuid.UUID]*roachpb.Transaction{}
 	intentKeyMap := map[uuid.UUID][]roachpb.Key{}
-	err := processReplicatedKeyRange(ctx, desc, snap, now, newThreshold, gcer, txnMap, intentKeyMap, &info)
+	complete, err := processReplicatedKeyRange(
+		ctx, desc, snap, now, newThreshold, rangeTombstones, gcer, txnMap, intentKeyMap, &info,
+	)
 	if err != nil {
 		return Info{}, err
 	}
 
+	// Once all of the versions they delete have been removed, the range
+	// tombstones themselves are garbage. If a batch of keys failed to be
+	// removed, they are kept around so that the keys stay deleted.
+	if complete && len(rangeTombstones) > 0 {
+		log.Eventf(ctx, "removing %d MVCC range tombstones", len(rangeTombstones))
+		tombstoneKeys := make([]roachpb.GCRequest_GCKey, 0, len(rangeTombstones))
+		for _, t := range rangeTombstones {
+			tombstoneKeys = append(tombstoneKeys, roachpb.GCRequest_GCKey{
+				Key: keys.MVCCRangeTombstoneKey(desc.RangeID, t.StartKey, t.Timestamp),
+			})
+		}
+		if err := gcer.GC(ctx, tombstoneKeys); err != nil {
+			return Info{}, err
+		}
+	}
+
 	// From now on, all newly added keys are range-local.
 
 	// Process local range key entries (txn records, queue last processed times).
@@ -212,7 +238,8 @@ func Run(
 }
 
 // processReplicatedKeyRange identifies garbage and sends GC requests to
-// remove it.
+// remove it. Versions deleted by one of the supplied range tombstones are
+// garbage as well. It returns whether all of the garbage was removed.
 //
 // The logic iterates all versions of all keys in the range from oldest to
 // newest. Expired intents are written into the txnMap and intentKeyMap.
@@ -222,11 +249,12 @@ func processReplicatedKeyRange(
 	snap engine.Reader,
 	now hlc.Timestamp,
 	threshold hlc.Timestamp,
+	rangeTombstones engine.MVCCRangeTombstones,
 	gcer GCer,
 	txnMap map[uuid.UUID]*roachpb.Transaction,
 	intentKeyMap map[uuid.UUID][]roachpb.Key,
 	info *Info,
-) error {
+) (bool, error) {
 	var alloc bufalloc.ByteAllocator
 	// Compute intent expiration (intent age at which we attempt to resolve).
 	intentExp := now.Add(-IntentAgeThreshold.Nanoseconds(), 0)
@@ -278,6 +306,7 @@ func processReplicatedKeyRange(
 		haveGarbageForThisKey bool
 		gcTimestampForThisKey hlc.Timestamp
 		sentBatchForThisKey   bool
+		complete              = true
 	)
 	it := makeGCIterator(desc, snap)
 	defer it.close()
@@ -285,7 +314,7 @@ func processReplicatedKeyRange(
 		s, ok := it.state()
 		if !ok {
 			if it.err != nil {
-				return it.err
+				return false, it.err
 			}
 			break
 		}
@@ -297,7 +326,8 @@ func processReplicatedKeyRange(
 			continue
 		}
 		isNewest := s.curIsNewest()
-		if isGarbage(threshold, s.cur, s.next, isNewest) {
+		if isGarbage(threshold, s.cur, s.next, isNewest) ||
+			rangeTombstones.Deletes(s.cur.Key.Key, s.cur.Key.Timestamp, threshold) {
 			keyBytes := int64(s.cur.Key.EncodedSize())
 			batchGCKeysBytes += keyBytes
 			haveGarbageForThisKey = true
@@ -331,6 +361,7 @@ func processReplicatedKeyRange(
 				// thresholds. We may leave some inconsistent history
 				// behind, but nobody can read it.
 				log.Warningf(ctx, "failed to GC a batch of keys: %v", err)
+				complete = false
 			}
 			batchGCKeys = nil
 			batchGCKeysBytes = 0
@@ -339,10 +370,10 @@ func processReplicatedKeyRange(
 	}
 	if len(batchGCKeys) > 0 {
 		if err := gcer.GC(ctx, batchGCKeys); err != nil {
-			return err
+			return false, err
 		}
 	}
-	return nil
+	return complete, nil
 }
 
 // isGarbage makes a determination whether a key ('cur') is garbage. If 'next'
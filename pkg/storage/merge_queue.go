@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/storagebase"
+	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -284,17 +285,25 @@ func (mq *mergeQueue) process(
 
 	{
 		store := lhsRepl.store
-		// AdminMerge errors if there is a learner or joint config on either
-		// side and AdminRelocateRange removes any on the range it operates on.
-		// For the sake of obviousness, just fix this all upfront.
+		// AdminMerge errors if there is a learner, non-voter or joint config on
+		// either side and AdminRelocateRange removes any on the range it
+		// operates on. For the sake of obviousness, just fix this all upfront.
+		// Any non-voters are added back to the merged range by the replicate
+		// queue.
 		var err error
 		lhsDesc, err = maybeLeaveAtomicChangeReplicasAndRemoveLearners(ctx, store, lhsDesc)
+		if err == nil {
+			lhsDesc, err = removeNonVoters(ctx, store, lhsDesc, storagepb.ReasonUnknown)
+		}
 		if err != nil {
 			log.VEventf(ctx, 2, `%v`, err)
 			return err
 		}
 
 		rhsDesc, err = maybeLeaveAtomicChangeReplicasAndRemoveLearners(ctx, store, rhsDesc)
+		if err == nil {
+			rhsDesc, err = removeNonVoters(ctx, store, rhsDesc, storagepb.ReasonUnknown)
+		}
 		if err != nil {
 			log.VEventf(ctx, 2, `%v`, err)
 			return err
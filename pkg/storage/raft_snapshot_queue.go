@@ -141,6 +141,20 @@ func (rq *raftSnapshotQueue) processRaftSnapshot(
 		}
 	}
 
+	// A non-voter being added is sent a snapshot of type LEARNER by the node
+	// that's adding it, just like a learner. Unlike learners, non-voters are
+	// long-lived, so once that's done they're caught up by regular RAFT
+	// snapshots. See the learner case above for why we punt back to raft.
+	if repDesc.GetType() == roachpb.NON_VOTER {
+		if index := repl.getAndGCSnapshotLogTruncationConstraints(timeutil.Now(), repDesc.StoreID); index > 0 {
+			err := errors.Errorf(
+				"skipping snapshot; replica is likely a non-voter in the process of being added: %s", repDesc)
+			log.Info(ctx, err)
+			repl.reportSnapshotStatus(ctx, repDesc.ReplicaID, err)
+			return nil
+		}
+	}
+
 	err := repl.sendSnapshot(ctx, repDesc, snapType, SnapshotRequest_RECOVERY)
 
 	// NB: if the snapshot fails because of an overlapping replica on the
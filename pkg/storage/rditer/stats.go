@@ -11,6 +11,8 @@
 package rditer
 
 import (
+	"context"
+
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
@@ -18,7 +20,8 @@ import (
 
 // ComputeStatsForRange computes the stats for a given range by
 // iterating over all key ranges for the given range that should
-// be accounted for in its stats.
+// be accounted for in its stats. Data deleted by the range's MVCC range
+// tombstones is accounted for as non-live.
 func ComputeStatsForRange(
 	d *roachpb.RangeDescriptor, reader engine.Reader, nowNanos int64,
 ) (enginepb.MVCCStats, error) {
@@ -33,5 +36,16 @@ func ComputeStatsForRange(
 		}
 		ms.Add(msDelta)
 	}
+
+	tombstones, err := engine.MVCCGetRangeTombstones(context.TODO(), reader, d.RangeID)
+	if err != nil {
+		return enginepb.MVCCStats{}, err
+	}
+	msDelta, err := engine.ComputeRangeTombstoneStats(
+		reader, tombstones, d.StartKey.AsRawKey(), d.EndKey.AsRawKey(), nowNanos)
+	if err != nil {
+		return enginepb.MVCCStats{}, err
+	}
+	ms.Add(msDelta)
 	return ms, nil
 }
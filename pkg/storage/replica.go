@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/batcheval"
 	"github.com/cockroachdb/cockroach/pkg/storage/closedts/ctpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/cockroach/pkg/storage/concurrency"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/rangefeed"
@@ -179,6 +180,8 @@ type Replica struct {
 	store        *Store
 	abortSpan    *abortspan.AbortSpan // Avoids anomalous reads after abort
 	txnWaitQueue *txnwait.Queue       // Queues push txn attempts by txn ID
+	// lockTracker tracks the locks that requests wait on, for observability.
+	lockTracker *concurrency.LockTracker
 
 	// leaseholderStats tracks all incoming BatchRequests to the replica and which
 	// localities they come from in order to aid in lease rebalancing decisions.
@@ -196,6 +199,15 @@ type Replica struct {
 	// RWMutex.
 	readOnlyCmdMu syncutil.RWMutex
 
+	// onlineRestore tracks the async tasks ingesting the spans of online
+	// RESTOREs that requests are waiting for, so that concurrent requests
+	// don't ingest them twice. It is not held while evaluating requests.
+	onlineRestore struct {
+		syncutil.Mutex
+		// ingesting maps the start key of a span to its ingestion.
+		ingesting map[string]*onlineRestoreIngestion
+	}
+
 	// rangeStr is a string representation of a RangeDescriptor that can be
 	// atomically read and updated without needing to acquire the replica.mu lock.
 	// All updates to state.Desc should be duplicated here.
@@ -279,6 +291,15 @@ type Replica struct {
 		// If raftLogSizeTrusted is false, don't trust the above raftLogSize until
 		// it has been recomputed.
 		raftLogSizeTrusted bool
+		// hasRangeTombstones is false only if the range is known not to have
+		// any MVCC range tombstones, in which case reads skip looking them up.
+		// It is never reset once set, except when a snapshot is applied.
+		hasRangeTombstones bool
+		// hasOnlineRestoreSpans is false only if the range is known not to have
+		// any spans of an online RESTORE whose data hasn't been ingested yet, in
+		// which case requests skip looking them up. It is never reset once set,
+		// except when a snapshot is applied.
+		hasOnlineRestoreSpans bool
 		// raftLogLastCheckSize is the value of raftLogSize the last time the Raft
 		// log was checked for truncation or at the time of the last Raft log
 		// truncation.
@@ -669,6 +690,15 @@ func (r *Replica) GetTxnWaitQueue() *txnwait.Queue {
 	return r.txnWaitQueue
 }
 
+// LockTableInfo returns information about the locks on the Replica's range
+// that requests have discovered, and the requests waiting on them.
+//
+// TODO(nvanbenschoten): source this from concurrency.Manager.LockTableInfo
+// once the concurrency manager sequences requests on the Replica.
+func (r *Replica) LockTableInfo() []storagepb.LockStateInfo {
+	return r.lockTracker.Info()
+}
+
 // GetTerm returns the term of the given index in the raft log.
 func (r *Replica) GetTerm(i uint64) (uint64, error) {
 	r.mu.RLock()
@@ -688,6 +718,14 @@ func (r *Replica) GetGCThreshold() hlc.Timestamp {
 	return *r.mu.state.GCThreshold
 }
 
+// HasRangeTombstones returns whether the range may have MVCC range
+// tombstones.
+func (r *Replica) HasRangeTombstones() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.mu.hasRangeTombstones
+}
+
 // maxReplicaIDOfAny returns the maximum ReplicaID of any replica, including
 // voters and learners.
 func maxReplicaIDOfAny(desc *roachpb.RangeDescriptor) roachpb.ReplicaID {
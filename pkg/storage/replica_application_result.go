@@ -393,3 +393,15 @@ func (r *Replica) handleSuggestedCompactionsResult(
 		r.store.compactor.Suggest(ctx, sc)
 	}
 }
+
+func (r *Replica) handleAddedRangeTombstonesResult(ctx context.Context) {
+	r.mu.Lock()
+	r.mu.hasRangeTombstones = true
+	r.mu.Unlock()
+}
+
+func (r *Replica) handleAddedOnlineRestoreSpansResult(ctx context.Context) {
+	r.mu.Lock()
+	r.mu.hasOnlineRestoreSpans = true
+	r.mu.Unlock()
+}
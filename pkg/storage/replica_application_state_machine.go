@@ -1095,6 +1095,16 @@ func (sm *replicaStateMachine) handleNonTrivialReplicatedEvalResult(
 		rResult.SuggestedCompactions = nil
 	}
 
+	if rResult.AddedRangeTombstones {
+		sm.r.handleAddedRangeTombstonesResult(ctx)
+		rResult.AddedRangeTombstones = false
+	}
+
+	if rResult.AddedOnlineRestoreSpans {
+		sm.r.handleAddedOnlineRestoreSpansResult(ctx)
+		rResult.AddedOnlineRestoreSpans = false
+	}
+
 	// The rest of the actions are "nontrivial" and may have large effects on the
 	// in-memory and on-disk ReplicaStates. If any of these actions are present,
 	// we want to assert that these two states do not diverge.
@@ -380,6 +380,9 @@ func (r *Replica) adminSplitWithDescriptor(
 		return reply, nil
 	}
 	log.Event(ctx, "found split key")
+	if err := r.checkSplitKeyOutsideOnlineRestoreSpans(ctx, splitKey); err != nil {
+		return reply, err
+	}
 
 	// Create right hand side range descriptor.
 	rightRangeID, err := r.store.AllocateRangeID(ctx)
@@ -993,6 +996,14 @@ func (r *Replica) changeReplicasImpl(
 		return nil, err
 	}
 
+	if adds, removals := chgs.NonVoterAdditions(), chgs.NonVoterRemovals(); len(adds)+len(removals) > 0 {
+		if !cluster.Version.IsActive(ctx, r.ClusterSettings(), cluster.VersionNonVoterReplicas) {
+			return nil, errors.Errorf("non-voting replicas require cluster version %s",
+				cluster.VersionByKey(cluster.VersionNonVoterReplicas))
+		}
+		return r.changeNonVoters(ctx, desc, priority, reason, details, adds, removals)
+	}
+
 	if adds := chgs.Additions(); len(adds) > 0 {
 		// Lock learner snapshots even before we run the ConfChange txn to add them
 		// to prevent a race with the raft snapshot queue trying to send it first.
@@ -1044,6 +1055,63 @@ func (r *Replica) changeReplicasImpl(
 	return desc, err
 }
 
+// changeNonVoters adds and removes non-voting replicas. Since non-voters don't
+// count towards the quorum, they are added and removed directly (i.e. one at a
+// time and without going through a joint configuration). A newly added
+// non-voter is sent a snapshot of type LEARNER right away so that it can
+// start serving follower reads as soon as possible.
+//
+// Note that unlike a learner, a non-voter is not rolled back if its snapshot
+// fails: it's a legitimate member of the range at that point, and the raft
+// snapshot queue will eventually catch it up.
+func (r *Replica) changeNonVoters(
+	ctx context.Context,
+	desc *roachpb.RangeDescriptor,
+	priority SnapshotRequest_Priority,
+	reason storagepb.RangeLogEventReason,
+	details string,
+	adds, removals []roachpb.ReplicationTarget,
+) (*roachpb.RangeDescriptor, error) {
+	for _, target := range removals {
+		var err error
+		desc, err = execChangeReplicasTxn(
+			ctx, r.store, desc, reason, details,
+			[]internalReplicationChange{{target: target, typ: internalChangeTypeRemove}},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, target := range adds {
+		// See changeReplicasImpl for why we lock the snapshot before the
+		// ConfChange txn.
+		releaseSnapshotLockFn := r.lockLearnerSnapshot(ctx, []roachpb.ReplicationTarget{target})
+		var err error
+		desc, err = execChangeReplicasTxn(
+			ctx, r.store, desc, reason, details,
+			[]internalReplicationChange{{target: target, typ: internalChangeTypeAddNonVoter}},
+		)
+		if err != nil {
+			releaseSnapshotLockFn()
+			return nil, err
+		}
+		if fn := r.store.cfg.TestingKnobs.ReplicaSkipLearnerSnapshot; fn == nil || !fn() {
+			rDesc, ok := desc.GetReplicaDescriptor(target.StoreID)
+			if !ok {
+				releaseSnapshotLockFn()
+				return nil, errors.Errorf("programming error: replica %v not found in %v", target, desc)
+			}
+			err = r.sendSnapshot(ctx, rDesc, SnapshotRequest_LEARNER, priority)
+		}
+		releaseSnapshotLockFn()
+		if err != nil {
+			return nil, errors.Wrapf(err, "sending snapshot to non-voter %v", target)
+		}
+	}
+	return desc, nil
+}
+
 // maybeLeaveAtomicChangeReplicas transitions out of the joint configuration if
 // the descriptor indicates one. This involves running a distributed transaction
 // updating said descriptor, the result of which will be returned. The
@@ -1111,6 +1179,35 @@ func maybeLeaveAtomicChangeReplicasAndRemoveLearners(
 	return desc, nil
 }
 
+// removeNonVoters removes all non-voting replicas from the range. It's used by
+// operations that only know how to deal with voters, such as merges and
+// relocations; the replicate queue adds the non-voters back afterwards.
+func removeNonVoters(
+	ctx context.Context,
+	store *Store,
+	desc *roachpb.RangeDescriptor,
+	reason storagepb.RangeLogEventReason,
+) (*roachpb.RangeDescriptor, error) {
+	nonVoters := desc.Replicas().NonVoters()
+	if len(nonVoters) == 0 {
+		return desc, nil
+	}
+	log.VEventf(ctx, 2, `removing non-voter replicas %v from %v`, nonVoters, desc)
+	origDesc := desc
+	for _, rDesc := range nonVoters {
+		target := roachpb.ReplicationTarget{NodeID: rDesc.NodeID, StoreID: rDesc.StoreID}
+		var err error
+		desc, err = execChangeReplicasTxn(
+			ctx, store, desc, reason, "",
+			[]internalReplicationChange{{target: target, typ: internalChangeTypeRemove}},
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, `removing non-voters from %s`, origDesc)
+		}
+	}
+	return desc, nil
+}
+
 func validateReplicationChanges(
 	desc *roachpb.RangeDescriptor, chgs roachpb.ReplicationChanges,
 ) error {
@@ -1124,13 +1221,33 @@ func validateReplicationChanges(
 		byNodeID[chg.Target.NodeID] = chg
 	}
 
+	// Changes to non-voters can't be mixed with changes to voters. Non-voters are
+	// added and removed directly, which can't be combined with the atomic
+	// replication changes used for voters.
+	if nonVoterChgs := len(chgs.NonVoterAdditions()) + len(chgs.NonVoterRemovals()); nonVoterChgs > 0 &&
+		nonVoterChgs != len(chgs) {
+		return errors.Errorf("changes %+v mix voting and non-voting replicas", chgs)
+	}
+
 	// Then, check that we're not adding a second replica on nodes that already
 	// have one, or "re-add" an existing replica. We delete from byNodeID so that
 	// after this loop, it contains only StoreIDs that we haven't seen in desc.
 	for _, rDesc := range desc.Replicas().All() {
 		chg, ok := byNodeID[rDesc.NodeID]
 		delete(byNodeID, rDesc.NodeID)
-		if !ok || chg.ChangeType != roachpb.ADD_REPLICA {
+		if !ok {
+			continue
+		}
+		switch chg.ChangeType {
+		case roachpb.REMOVE_REPLICA:
+			if rDesc.GetType() == roachpb.NON_VOTER {
+				return errors.Errorf("unable to remove non-voter %v as a voter from %s", chg.Target, desc)
+			}
+			continue
+		case roachpb.REMOVE_NON_VOTER:
+			if rDesc.GetType() != roachpb.NON_VOTER {
+				return errors.Errorf("unable to remove %v as a non-voter from %s", chg.Target, desc)
+			}
 			continue
 		}
 		// We're adding a replica that's already there. This isn't allowed, even
@@ -1149,14 +1266,14 @@ func validateReplicationChanges(
 				"unable to add replica %v which is already present as a learner in %s", chg.Target, desc)
 		}
 
-		// Otherwise, we already had a full voter replica. Can't add another to
-		// this store.
+		// Otherwise, we already had a full voter or non-voter replica. Can't add
+		// another to this store.
 		return errors.Errorf("unable to add replica %v which is already present in %s", chg.Target, desc)
 	}
 
 	// Any removals left in the map now refer to nonexisting replicas, and we refuse them.
 	for _, chg := range byNodeID {
-		if chg.ChangeType != roachpb.REMOVE_REPLICA {
+		if chg.ChangeType != roachpb.REMOVE_REPLICA && chg.ChangeType != roachpb.REMOVE_NON_VOTER {
 			continue
 		}
 		return errors.Errorf("removing %v which is not in %s", chg.Target, desc)
@@ -1383,6 +1500,10 @@ const (
 	// voter with them), see:
 	// https://github.com/cockroachdb/cockroach/pull/40268
 	internalChangeTypeRemove
+	// internalChangeTypeAddNonVoter adds a non-voting replica. Non-voters never
+	// go through joint consensus; like learners, they're removed via
+	// internalChangeTypeRemove.
+	internalChangeTypeAddNonVoter
 )
 
 // internalReplicationChange is a replication target together with an internal
@@ -1434,6 +1555,9 @@ func prepareChangeReplicasTrigger(
 			case internalChangeTypeAddLearner:
 				added = append(added,
 					updatedDesc.AddReplica(chg.target.NodeID, chg.target.StoreID, roachpb.LEARNER))
+			case internalChangeTypeAddNonVoter:
+				added = append(added,
+					updatedDesc.AddReplica(chg.target.NodeID, chg.target.StoreID, roachpb.NON_VOTER))
 			case internalChangeTypePromoteLearner:
 				typ := roachpb.VOTER_FULL
 				if useJoint {
@@ -1450,7 +1574,7 @@ func prepareChangeReplicasTrigger(
 					return nil, errors.Errorf("target %s not found", chg.target)
 				}
 				prevTyp := rDesc.GetType()
-				if !useJoint || prevTyp == roachpb.LEARNER {
+				if !useJoint || prevTyp == roachpb.LEARNER || prevTyp == roachpb.NON_VOTER {
 					rDesc, _ = updatedDesc.RemoveReplica(chg.target.NodeID, chg.target.StoreID)
 				} else if prevTyp != roachpb.VOTER_FULL {
 					// NB: prevTyp is already known to be VOTER_FULL because of
@@ -2041,6 +2165,11 @@ func (s *Store) AdminRelocateRange(
 		log.Warning(ctx, err)
 		return err
 	}
+	newDesc, err = removeNonVoters(ctx, s, newDesc, storagepb.ReasonAdminRequest)
+	if err != nil {
+		log.Warning(ctx, err)
+		return err
+	}
 	rangeDesc = *newDesc
 
 	canRetry := func(err error) bool {
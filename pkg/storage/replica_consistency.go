@@ -571,6 +571,20 @@ func (r *Replica) sha512(
 			}
 			ms.Add(spanMS)
 		}
+		// Account for the data deleted by the range's MVCC range tombstones,
+		// which ComputeStatsGo is unaware of. The tombstone records themselves
+		// are part of the replicated key space and were hashed above.
+		tombstones, err := engine.MVCCGetRangeTombstones(ctx, snap, desc.RangeID)
+		if err != nil {
+			return nil, err
+		}
+		tombstoneMS, err := engine.ComputeRangeTombstoneStats(
+			snap, tombstones, desc.StartKey.AsRawKey(), desc.EndKey.AsRawKey(), 0, /* nowNanos */
+		)
+		if err != nil {
+			return nil, err
+		}
+		ms.Add(tombstoneMS)
 	}
 
 	var result replicaHash
@@ -167,6 +167,12 @@ func (rec SpanSetReplicaEvalContext) GetGCThreshold() hlc.Timestamp {
 	return rec.i.GetGCThreshold()
 }
 
+// HasRangeTombstones returns whether the range may have MVCC range
+// tombstones.
+func (rec SpanSetReplicaEvalContext) HasRangeTombstones() bool {
+	return rec.i.HasRangeTombstones()
+}
+
 // String implements Stringer.
 func (rec SpanSetReplicaEvalContext) String() string {
 	return rec.i.String()
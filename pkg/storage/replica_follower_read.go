@@ -37,16 +37,16 @@ var FollowerReadsEnabled = settings.RegisterPublicBoolSetting(
 func (r *Replica) canServeFollowerRead(
 	ctx context.Context, ba *roachpb.BatchRequest, pErr *roachpb.Error,
 ) *roachpb.Error {
-	// There's no known reason that a non-VOTER_FULL replica couldn't serve follower
-	// reads (or RangeFeed), but as of the time of writing, these are expected
-	// to be short-lived, so it's not worth working out the edge-cases. Revisit if
-	// we add long-lived learners or feel that incoming/outgoing voters also need
-	// to be able to serve follower reads.
+	// There's no known reason that a learner or an incoming/outgoing voter
+	// couldn't serve follower reads (or RangeFeed), but as of the time of
+	// writing, these are expected to be short-lived, so it's not worth working
+	// out the edge-cases. Non-voters are long-lived and exist precisely to serve
+	// follower reads, so they're allowed alongside full voters.
 	repDesc, err := r.GetReplicaDescriptor()
 	if err != nil {
 		return roachpb.NewError(err)
 	}
-	if typ := repDesc.GetType(); typ != roachpb.VOTER_FULL {
+	if typ := repDesc.GetType(); typ != roachpb.VOTER_FULL && typ != roachpb.NON_VOTER {
 		log.Eventf(ctx, "%s replicas cannot serve follower reads", typ)
 		return pErr
 	}
@@ -94,6 +94,35 @@ func (r *Replica) canServeFollowerRead(
 	return nil
 }
 
+// localReadTimestamp returns the newest timestamp at which the replica can
+// serve reads without redirecting them to the leaseholder, or false if it
+// can't serve reads at all. The leaseholder can serve reads at the current
+// time, while other replicas can serve follower reads up to their closed
+// timestamp. The checks mirror those performed by canServeFollowerRead.
+func (r *Replica) localReadTimestamp(ctx context.Context) (hlc.Timestamp, bool) {
+	now := r.store.Clock().Now()
+	if r.OwnsValidLease(now) {
+		return now, true
+	}
+	repDesc, err := r.GetReplicaDescriptor()
+	if err != nil {
+		return hlc.Timestamp{}, false
+	}
+	if typ := repDesc.GetType(); typ != roachpb.VOTER_FULL && typ != roachpb.NON_VOTER {
+		return hlc.Timestamp{}, false
+	}
+	if !FollowerReadsEnabled.Get(&r.store.cfg.Settings.SV) {
+		return hlc.Timestamp{}, false
+	}
+	r.mu.RLock()
+	lease := *r.mu.state.Lease
+	r.mu.RUnlock()
+	if lease.Type() != roachpb.LeaseEpoch {
+		return hlc.Timestamp{}, false
+	}
+	return r.maxClosed(ctx), true
+}
+
 // maxClosed returns the maximum closed timestamp for this range.
 // It is computed as the most recent of the known closed timestamp for the
 // current lease holder for this range as tracked by the closed timestamp
@@ -151,8 +151,15 @@ func (rgcq *replicaGCQueue) shouldQueue(
 	// command which sets it to VOTER_OUTGOING we would conservatively wait
 	// 10 days before removing the node. Finally we consider replicas which are
 	// VOTER_INCOMING as suspect because no replica should stay in that state for
-	// too long and being conservative here doesn't seem worthwhile.
-	isSuspect := replDesc.GetType() != roachpb.VOTER_FULL
+	// too long and being conservative here doesn't seem worthwhile. Non-voters,
+	// on the other hand, are long-lived like full voters and are not suspect
+	// just by virtue of their type.
+	var isSuspect bool
+	switch replDesc.GetType() {
+	case roachpb.VOTER_FULL, roachpb.NON_VOTER:
+	default:
+		isSuspect = true
+	}
 	if raftStatus := repl.RaftStatus(); raftStatus != nil {
 		isSuspect = isSuspect ||
 			(raftStatus.SoftState.RaftState == raft.StateCandidate ||
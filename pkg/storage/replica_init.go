@@ -18,6 +18,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/storage/abortspan"
+	"github.com/cockroachdb/cockroach/pkg/storage/concurrency"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanlatch"
 	"github.com/cockroachdb/cockroach/pkg/storage/split"
 	"github.com/cockroachdb/cockroach/pkg/storage/stateloader"
@@ -72,6 +74,7 @@ func newUnloadedReplica(
 		abortSpan:      abortspan.New(desc.RangeID),
 	}
 	r.txnWaitQueue = txnwait.NewQueue(store, r)
+	r.lockTracker = concurrency.NewLockTracker()
 	r.mu.pendingLeaseRequest = makePendingLeaseRequest(r)
 	r.mu.stateLoader = stateloader.Make(desc.RangeID)
 	r.mu.quiescent = true
@@ -144,6 +147,14 @@ func (r *Replica) loadRaftMuLockedReplicaMuLocked(desc *roachpb.RangeDescriptor)
 		return err
 	}
 	r.mu.lastTerm = invalidLastTerm
+	r.mu.hasRangeTombstones, err = engine.MVCCHasRangeTombstones(ctx, r.Engine(), desc.RangeID)
+	if err != nil {
+		return err
+	}
+	r.mu.hasOnlineRestoreSpans, err = hasOnlineRestoreSpans(ctx, r.Engine(), desc.RSpan())
+	if err != nil {
+		return err
+	}
 
 	// Ensure that we're not trying to load a replica with a different ID than
 	// was used to construct this Replica.
@@ -0,0 +1,230 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/batcheval"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// An online RESTORE makes the restored tables public before their data is
+// ingested. For each import span whose data hasn't been ingested yet, it
+// records the ImportRequest that ingests it under the range-local
+// OnlineRestoreSpanKey of the start of the span, in the range containing the
+// span. Before a request that reads keys of such a span is evaluated, it
+// waits for an async task of the replica to ingest the span and remove the
+// record, while the RESTORE job ingests the remaining spans in the
+// background. Ranges are not split in the middle of a pending span, so that
+// the record of a span always lives in the range containing it.
+
+// iterateOnlineRestoreSpans calls f with the ImportRequests of the pending
+// online restore spans recorded in the range-local keys of the span.
+func iterateOnlineRestoreSpans(
+	ctx context.Context,
+	reader engine.Reader,
+	span roachpb.RSpan,
+	f func(roachpb.ImportRequest) (bool, error),
+) error {
+	_, err := engine.MVCCIterate(
+		ctx, reader, keys.MakeRangeKeyPrefix(span.Key), keys.MakeRangeKeyPrefix(span.EndKey),
+		hlc.MaxTimestamp, engine.MVCCScanOptions{Inconsistent: true},
+		func(kv roachpb.KeyValue) (bool, error) {
+			_, suffix, _, err := keys.DecodeRangeKey(kv.Key)
+			if err != nil {
+				return false, err
+			}
+			if !bytes.Equal(suffix, keys.LocalOnlineRestoreSpanSuffix) {
+				return false, nil
+			}
+			var req roachpb.ImportRequest
+			if err := kv.Value.GetProto(&req); err != nil {
+				return false, errors.Wrapf(err, "decoding online restore span %s", kv.Key)
+			}
+			return f(req)
+		})
+	return err
+}
+
+// hasOnlineRestoreSpans returns whether any online restore span is pending
+// in the span.
+func hasOnlineRestoreSpans(
+	ctx context.Context, reader engine.Reader, span roachpb.RSpan,
+) (bool, error) {
+	var found bool
+	err := iterateOnlineRestoreSpans(ctx, reader, span, func(roachpb.ImportRequest) (bool, error) {
+		found = true
+		return true, nil
+	})
+	return found, err
+}
+
+// mayHaveOnlineRestoreSpans returns whether the range may have pending online
+// restore spans.
+func (r *Replica) mayHaveOnlineRestoreSpans() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.mu.hasOnlineRestoreSpans
+}
+
+// onlineRestoreIngestion is the ingestion of a pending online restore span
+// by an async task.
+type onlineRestoreIngestion struct {
+	done chan struct{}
+	// err is set before done is closed.
+	err error
+}
+
+// waitForOnlineRestoreSpans waits for the pending online restore spans of the
+// range that overlap the keys read by the batch to be ingested. The spans are
+// ingested by async tasks, which are shared by all the requests waiting for a
+// span and which are not affected by the cancellation of any of them.
+func (r *Replica) waitForOnlineRestoreSpans(ctx context.Context, ba *roachpb.BatchRequest) error {
+	if !r.mayHaveOnlineRestoreSpans() {
+		return nil
+	}
+	var spans []roachpb.Span
+	for _, union := range ba.Requests {
+		req := union.GetInner()
+		if _, ok := req.(*roachpb.AddSSTableRequest); ok {
+			// The batch is ingesting data, possibly that of a pending span.
+			return nil
+		}
+		if !roachpb.IsReadOnly(req) && !roachpb.IsReadAndWrite(req) {
+			continue
+		}
+		if span := req.Header().Span(); !keys.IsLocal(span.Key) {
+			spans = append(spans, span)
+		}
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	var ingestions []*onlineRestoreIngestion
+	if err := iterateOnlineRestoreSpans(ctx, r.Engine(), r.Desc().RSpan(),
+		func(req roachpb.ImportRequest) (bool, error) {
+			for _, span := range spans {
+				if req.Span().Overlaps(span) {
+					ingestion, err := r.ingestOnlineRestoreSpanAsync(ctx, req)
+					if err != nil {
+						return false, err
+					}
+					ingestions = append(ingestions, ingestion)
+					break
+				}
+			}
+			return false, nil
+		}); err != nil {
+		return err
+	}
+
+	if len(ingestions) > 0 {
+		log.Eventf(ctx, "waiting on the ingestion of %d online restore spans", len(ingestions))
+	}
+	for _, ingestion := range ingestions {
+		select {
+		case <-ingestion.done:
+			if ingestion.err != nil {
+				return ingestion.err
+			}
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "aborted while waiting for online restore spans")
+		case <-r.store.stopper.ShouldQuiesce():
+			return &roachpb.NodeUnavailableError{}
+		}
+	}
+	return nil
+}
+
+// ingestOnlineRestoreSpanAsync returns the ingestion of the pending online
+// restore span, starting an async task to ingest it unless one is already
+// running.
+func (r *Replica) ingestOnlineRestoreSpanAsync(
+	ctx context.Context, req roachpb.ImportRequest,
+) (*onlineRestoreIngestion, error) {
+	r.onlineRestore.Lock()
+	defer r.onlineRestore.Unlock()
+	key := string(req.Key)
+	if ingestion, ok := r.onlineRestore.ingesting[key]; ok {
+		return ingestion, nil
+	}
+
+	ingestion := &onlineRestoreIngestion{done: make(chan struct{})}
+	taskCtx := r.AnnotateCtx(context.Background())
+	if err := r.store.Stopper().RunAsyncTask(taskCtx, "storage.Replica: ingesting online restore span",
+		func(ctx context.Context) {
+			ingestion.err = r.ingestOnlineRestoreSpan(ctx, &req)
+			r.onlineRestore.Lock()
+			delete(r.onlineRestore.ingesting, key)
+			r.onlineRestore.Unlock()
+			close(ingestion.done)
+		}); err != nil {
+		return nil, err
+	}
+	if r.onlineRestore.ingesting == nil {
+		r.onlineRestore.ingesting = make(map[string]*onlineRestoreIngestion)
+	}
+	r.onlineRestore.ingesting[key] = ingestion
+	return ingestion, nil
+}
+
+// ingestOnlineRestoreSpan ingests the pending online restore span from the
+// backup and removes its record. Ingesting a span twice is harmless, since
+// the ingested data keeps the timestamps it has in the backup.
+func (r *Replica) ingestOnlineRestoreSpan(ctx context.Context, req *roachpb.ImportRequest) error {
+	log.VEventf(ctx, 1, "ingesting online restore span %s", req.Span())
+	cArgs := batcheval.CommandArgs{
+		EvalCtx: NewReplicaEvalContext(r, todoSpanSet),
+		Header:  roachpb.Header{Timestamp: r.store.Clock().Now()},
+		Args:    req,
+	}
+	if _, err := importCmdFn(ctx, cArgs); err != nil {
+		return errors.Wrapf(err, "ingesting online restore span %s", req.Span())
+	}
+	if err := r.store.DB().Del(ctx, keys.OnlineRestoreSpanKey(roachpb.RKey(req.Key))); err != nil {
+		return errors.Wrapf(err, "removing online restore span %s", req.Span())
+	}
+	return nil
+}
+
+// checkSplitKeyOutsideOnlineRestoreSpans returns an error if the split key is
+// in the middle of a pending online restore span of the range.
+func (r *Replica) checkSplitKeyOutsideOnlineRestoreSpans(
+	ctx context.Context, splitKey roachpb.RKey,
+) error {
+	if !r.mayHaveOnlineRestoreSpans() {
+		return nil
+	}
+	var pending roachpb.Span
+	if err := iterateOnlineRestoreSpans(ctx, r.Engine(), r.Desc().RSpan(),
+		func(req roachpb.ImportRequest) (bool, error) {
+			if bytes.Compare(req.Key, splitKey) < 0 && bytes.Compare(splitKey, req.EndKey) < 0 {
+				pending = req.Span()
+				return true, nil
+			}
+			return false, nil
+		}); err != nil {
+		return err
+	}
+	if pending.Key != nil {
+		return &benignError{errors.Errorf(
+			"cannot split range at key %s, in the middle of online restore span %s", splitKey, pending)}
+	}
+	return nil
+}
@@ -415,8 +415,10 @@ func (r *Replica) leasePostApply(ctx context.Context, newLease roachpb.Lease, pe
 
 	if leaseChangingHands && !iAmTheLeaseHolder {
 		// Also clear and disable the push transaction queue. Any waiters
-		// must be redirected to the new lease holder.
+		// must be redirected to the new lease holder. The locks they were
+		// waiting on are now tracked by the new lease holder.
 		r.txnWaitQueue.Clear(true /* disable */)
+		r.lockTracker.Clear()
 	}
 
 	// If we're the current raft leader, may want to transfer the leadership to
@@ -609,9 +611,17 @@ func (r *Replica) handleReadWriteLocalEvalResult(ctx context.Context, lResult re
 		log.Fatalf(ctx, "LocalEvalResult.MaybeWatchForMerge should be false")
 	}
 
+	if lResult.ResolvedLocks != nil {
+		for i := range lResult.ResolvedLocks {
+			r.lockTracker.OnLockUpdated(ctx, &lResult.ResolvedLocks[i])
+		}
+		lResult.ResolvedLocks = nil
+	}
+
 	if lResult.UpdatedTxns != nil {
 		for _, txn := range lResult.UpdatedTxns {
 			r.txnWaitQueue.UpdateTxn(ctx, txn)
+			r.lockTracker.OnTransactionUpdated(ctx, txn)
 		}
 		lResult.UpdatedTxns = nil
 	}
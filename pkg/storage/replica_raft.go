@@ -1015,7 +1015,7 @@ func (r *Replica) maybeCoalesceHeartbeat(
 		Term:          msg.Term,
 		Commit:        msg.Commit,
 		Quiesce:       quiesce,
-		ToIsLearner:   toReplica.GetType() == roachpb.LEARNER,
+		ToIsLearner:   toReplica.IsRaftLearner(),
 	}
 	if log.V(4) {
 		log.Infof(ctx, "coalescing beat: %+v", beat)
@@ -1538,7 +1538,7 @@ func (r *Replica) acquireSplitLock(
 	rightReplDesc, _ := split.RightDesc.GetReplicaDescriptor(r.StoreID())
 	rightRng, _, err := r.store.getOrCreateReplica(ctx, split.RightDesc.RangeID,
 		rightReplDesc.ReplicaID, nil, /* creatingReplica */
-		rightReplDesc.GetType() == roachpb.LEARNER)
+		rightReplDesc.IsRaftLearner())
 	// If getOrCreateReplica returns RaftGroupDeletedError we know that the RHS
 	// has already been removed. This case is handled properly in splitPostApply.
 	if _, isRaftGroupDeletedError := err.(*roachpb.RaftGroupDeletedError); isRaftGroupDeletedError {
@@ -1577,7 +1577,7 @@ func (r *Replica) acquireMergeLock(
 	rightReplDesc, _ := merge.RightDesc.GetReplicaDescriptor(r.StoreID())
 	rightRepl, _, err := r.store.getOrCreateReplica(ctx, merge.RightDesc.RangeID,
 		rightReplDesc.ReplicaID, nil, /* creatingReplica */
-		rightReplDesc.GetType() == roachpb.LEARNER)
+		rightReplDesc.IsRaftLearner())
 	if err != nil {
 		return nil, err
 	}
@@ -922,6 +922,15 @@ func (r *Replica) applySnapshot(
 		log.Fatalf(ctx, "failed to clear in-memory data of subsumed replicas while applying snapshot: %+v", err)
 	}
 
+	hasRangeTombstones, err := engine.MVCCHasRangeTombstones(ctx, r.store.Engine(), s.Desc.RangeID)
+	if err != nil {
+		log.Fatalf(ctx, "unable to look up range tombstones while applying snapshot: %+v", err)
+	}
+	hasOnlineRestoreSpans, err := hasOnlineRestoreSpans(ctx, r.store.Engine(), s.Desc.RSpan())
+	if err != nil {
+		log.Fatalf(ctx, "unable to look up online restore spans while applying snapshot: %+v", err)
+	}
+
 	// Atomically swap the placeholder, if any, for the replica, and update the
 	// replica's descriptor.
 	r.store.mu.Lock()
@@ -962,6 +971,8 @@ func (r *Replica) applySnapshot(
 	// Snapshots typically have fewer log entries than the leaseholder. The next
 	// time we hold the lease, recompute the log size before making decisions.
 	r.mu.raftLogSizeTrusted = false
+	r.mu.hasRangeTombstones = hasRangeTombstones
+	r.mu.hasOnlineRestoreSpans = hasOnlineRestoreSpans
 	r.assertStateLocked(ctx, r.store.Engine())
 	r.mu.Unlock()
 
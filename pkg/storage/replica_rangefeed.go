@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/storage/closedts"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
@@ -202,6 +203,13 @@ func (r *Replica) RangeFeed(
 	// Register the stream with a catch-up iterator.
 	var catchUpIter engine.SimpleIterator
 	if usingCatchupIter {
+		// Deletions performed by MVCC range tombstones are surfaced as point
+		// deletions by the catch-up scan.
+		rangeTombstones, err := r.loadRangeTombstonesForRangefeed(ctx, r.Engine())
+		if err != nil {
+			r.raftMu.Unlock()
+			return roachpb.NewError(err)
+		}
 		innerIter := r.Engine().NewIterator(engine.IterOptions{
 			UpperBound: args.Span.EndKey,
 			// RangeFeed originally intended to use the time-bound iterator
@@ -214,7 +222,7 @@ func (r *Replica) RangeFeed(
 			// MinTimestampHint: args.Timestamp,
 		})
 		catchUpIter = iteratorWithCloser{
-			SimpleIterator: innerIter,
+			SimpleIterator: engine.NewRangeTombstoneIterator(innerIter, rangeTombstones),
 			close:          iterSemRelease,
 		}
 		// Responsibility for releasing the semaphore now passes to the iterator.
@@ -492,6 +500,17 @@ func (r *Replica) populatePrevValsInLogicalOpLogRaftMuLocked(
 	}
 }
 
+// loadRangeTombstonesForRangefeed returns the MVCC range tombstones of the
+// range, which rangefeeds surface as deletions of the keys they cover.
+func (r *Replica) loadRangeTombstonesForRangefeed(
+	ctx context.Context, reader engine.Reader,
+) (engine.MVCCRangeTombstones, error) {
+	if !cluster.Version.IsActive(ctx, r.ClusterSettings(), cluster.VersionMVCCRangeTombstones) {
+		return nil, nil
+	}
+	return engine.MVCCGetRangeTombstones(ctx, reader, r.RangeID)
+}
+
 // handleLogicalOpLogRaftMuLocked passes the logical op log to the active
 // rangefeed, if one is running. The method accepts a reader, which is used to
 // look up the values associated with key-value writes in the log before handing
@@ -520,6 +539,13 @@ func (r *Replica) handleLogicalOpLogRaftMuLocked(
 		return
 	}
 
+	// Keys deleted by MVCC range tombstones are read as deletions.
+	rangeTombstones, err := r.loadRangeTombstonesForRangefeed(ctx, reader)
+	if err != nil {
+		r.disconnectRangefeedWithErr(p, roachpb.NewError(err))
+		return
+	}
+
 	// When reading straight from the Raft log, some logical ops will not be
 	// fully populated. Read from the Reader to populate all fields.
 	for _, op := range ops.Ops {
@@ -556,7 +582,10 @@ func (r *Replica) handleLogicalOpLogRaftMuLocked(
 		// Read the value directly from the Reader. This is performed in the
 		// same raftMu critical section that the logical op's corresponding
 		// WriteBatch is applied, so the value should exist.
-		val, _, err := engine.MVCCGet(ctx, reader, key, ts, engine.MVCCGetOptions{Tombstones: true})
+		val, _, err := engine.MVCCGet(ctx, reader, key, ts, engine.MVCCGetOptions{
+			Tombstones:      true,
+			RangeTombstones: rangeTombstones,
+		})
 		if val == nil && err == nil {
 			err = errors.New("value missing in reader")
 		}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/batcheval"
+	"github.com/cockroachdb/cockroach/pkg/storage/concurrency"
 	"github.com/cockroachdb/cockroach/pkg/storage/intentresolver"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanlatch"
 	"github.com/cockroachdb/cockroach/pkg/storage/spanset"
@@ -87,6 +88,12 @@ func (r *Replica) sendWithRangeID(
 		}
 	}
 
+	if isReadOnly || useRaft {
+		if err := r.waitForOnlineRestoreSpans(ctx, ba); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+	}
+
 	// Differentiate between read-write, read-only, and admin.
 	var pErr *roachpb.Error
 	if useRaft {
@@ -202,7 +209,7 @@ func (r *Replica) executeBatchWithConcurrencyRetries(
 			// Success.
 			return br, nil
 		case *roachpb.WriteIntentError:
-			if cleanup, pErr = r.handleWriteIntentError(ctx, ba, pErr, t, cleanup); pErr != nil {
+			if cleanup, pErr = r.handleWriteIntentError(ctx, ba, spans, pErr, t, cleanup); pErr != nil {
 				return nil, pErr
 			}
 			// Retry...
@@ -231,6 +238,7 @@ func (r *Replica) executeBatchWithConcurrencyRetries(
 func (r *Replica) handleWriteIntentError(
 	ctx context.Context,
 	ba *roachpb.BatchRequest,
+	spans *spanset.SpanSet,
 	pErr *roachpb.Error,
 	t *roachpb.WriteIntentError,
 	cleanup intentresolver.CleanupFunc,
@@ -278,7 +286,15 @@ func (r *Replica) handleWriteIntentError(
 	if cleanup != nil {
 		cleanup(t, nil)
 	}
+	// Record the discovered locks and the request's wait on them for as long
+	// as it pushes their holders, so that they are reported by LockTableInfo.
+	lwg := r.lockTracker.OnLocksDiscovered(ctx, concurrency.Request{
+		Txn:       ba.Txn,
+		Timestamp: ba.Timestamp,
+		Spans:     spans,
+	}, t.Intents)
 	cleanup, pErr = r.store.intentResolver.ProcessWriteIntentError(ctx, pErr, h, pushType)
+	r.lockTracker.FinishWaiting(lwg)
 	if pErr != nil {
 		// Do not propagate ambiguous results; assume success and retry original op.
 		if _, ok := pErr.GetDetail().(*roachpb.AmbiguousResultError); ok {
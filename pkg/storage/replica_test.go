@@ -11747,14 +11747,14 @@ func TestSplitSnapshotWarningStr(t *testing.T) {
 //
 // The test does the following things:
 //
-//  * Propose cmd at an initial MaxLeaseIndex.
-//  * Refresh that cmd immediately.
-//  * Fail the initial command with an injected error which will lead to a
-//    reproposal at a higher MaxLeaseIndex.
-//  * Simultaneously update the lease sequence number on the replica so all
-//    future commands will fail with NotLeaseHolderError.
-//  * Enable unconditional refreshes of commands after a raft ready so that
-//    higher MaxLeaseIndex commands are refreshed.
+//   - Propose cmd at an initial MaxLeaseIndex.
+//   - Refresh that cmd immediately.
+//   - Fail the initial command with an injected error which will lead to a
+//     reproposal at a higher MaxLeaseIndex.
+//   - Simultaneously update the lease sequence number on the replica so all
+//     future commands will fail with NotLeaseHolderError.
+//   - Enable unconditional refreshes of commands after a raft ready so that
+//     higher MaxLeaseIndex commands are refreshed.
 //
 // This order of events ensures that there will be a committed command which
 // experiences the lease mismatch error but does not carry the highest
@@ -12350,6 +12350,18 @@ func TestPrepareChangeReplicasTrigger(t *testing.T) {
 			typOp{roachpb.VOTER_FULL, noop},
 			typOp{roachpb.LEARNER, internalChangeTypeRemove},
 		),
+		// Simple addition of non-voter.
+		mk(
+			"SIMPLE(l2) ADD_REPLICA[(n200,s200):2NON_VOTER]: after=[(n100,s100):1 (n200,s200):2NON_VOTER] next=3",
+			typOp{roachpb.VOTER_FULL, noop},
+			typOp{none, internalChangeTypeAddNonVoter},
+		),
+		// Simple removal of non-voter.
+		mk(
+			"SIMPLE(r2) REMOVE_REPLICA[(n200,s200):2NON_VOTER]: after=[(n100,s100):1] next=3",
+			typOp{roachpb.VOTER_FULL, noop},
+			typOp{roachpb.NON_VOTER, internalChangeTypeRemove},
+		),
 
 		// All other cases below need to go through joint quorums (though some
 		// of them only due to limitations in etcd/raft).
@@ -12408,6 +12420,52 @@ func TestPrepareChangeReplicasTrigger(t *testing.T) {
 	}
 }
 
+func TestValidateReplicationChangesNonVoters(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	desc := roachpb.NewRangeDescriptor(roachpb.RangeID(10), roachpb.RKeyMin, roachpb.RKeyMax,
+		roachpb.MakeReplicaDescriptors([]roachpb.ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+			{NodeID: 2, StoreID: 2, ReplicaID: 2, Type: roachpb.ReplicaTypeNonVoter()},
+		}))
+	target := func(id int) roachpb.ReplicationTarget {
+		return roachpb.ReplicationTarget{NodeID: roachpb.NodeID(id), StoreID: roachpb.StoreID(id)}
+	}
+	chgs := func(typ roachpb.ReplicaChangeType, ids ...int) roachpb.ReplicationChanges {
+		var targets []roachpb.ReplicationTarget
+		for _, id := range ids {
+			targets = append(targets, target(id))
+		}
+		return roachpb.MakeReplicationChanges(typ, targets...)
+	}
+
+	for _, tc := range []struct {
+		chgs roachpb.ReplicationChanges
+		err  string
+	}{
+		{chgs: chgs(roachpb.ADD_NON_VOTER, 3)},
+		{chgs: chgs(roachpb.REMOVE_NON_VOTER, 2)},
+		{chgs: chgs(roachpb.ADD_NON_VOTER, 2), err: "which is already present"},
+		{chgs: chgs(roachpb.ADD_REPLICA, 2), err: "which is already present"},
+		{chgs: chgs(roachpb.REMOVE_NON_VOTER, 1), err: "unable to remove .* as a non-voter"},
+		{chgs: chgs(roachpb.REMOVE_REPLICA, 2), err: "unable to remove non-voter .* as a voter"},
+		{chgs: chgs(roachpb.REMOVE_NON_VOTER, 3), err: "which is not in"},
+		{
+			chgs: append(chgs(roachpb.ADD_NON_VOTER, 3), chgs(roachpb.ADD_REPLICA, 4)...),
+			err:  "mix voting and non-voting replicas",
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			err := validateReplicationChanges(desc, tc.chgs)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.True(t, testutils.IsError(err, tc.err), "expected %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
 func enableTraceDebugUseAfterFree() (restore func()) {
 	prev := trace.DebugUseAfterFinish
 	trace.DebugUseAfterFinish = true
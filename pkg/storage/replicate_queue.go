@@ -84,6 +84,18 @@ var (
 		Measurement: "Replica Removals",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaReplicateQueueAddNonVoterReplicaCount = metric.Metadata{
+		Name:        "queue.replicate.addnonvoterreplica",
+		Help:        "Number of non-voting replica additions attempted by the replicate queue",
+		Measurement: "Replica Additions",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaReplicateQueueRemoveNonVoterReplicaCount = metric.Metadata{
+		Name:        "queue.replicate.removenonvoterreplica",
+		Help:        "Number of non-voting replica removals attempted by the replicate queue (including dead non-voters and non-voters displaced by voters)",
+		Measurement: "Replica Removals",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaReplicateQueueRebalanceReplicaCount = metric.Metadata{
 		Name:        "queue.replicate.rebalancereplica",
 		Help:        "Number of replica rebalancer-initiated additions attempted by the replicate queue",
@@ -119,22 +131,26 @@ func (*quorumError) purgatoryErrorMarker() {}
 
 // ReplicateQueueMetrics is the set of metrics for the replicate queue.
 type ReplicateQueueMetrics struct {
-	AddReplicaCount           *metric.Counter
-	RemoveReplicaCount        *metric.Counter
-	RemoveDeadReplicaCount    *metric.Counter
-	RemoveLearnerReplicaCount *metric.Counter
-	RebalanceReplicaCount     *metric.Counter
-	TransferLeaseCount        *metric.Counter
+	AddReplicaCount            *metric.Counter
+	RemoveReplicaCount         *metric.Counter
+	RemoveDeadReplicaCount     *metric.Counter
+	RemoveLearnerReplicaCount  *metric.Counter
+	AddNonVoterReplicaCount    *metric.Counter
+	RemoveNonVoterReplicaCount *metric.Counter
+	RebalanceReplicaCount      *metric.Counter
+	TransferLeaseCount         *metric.Counter
 }
 
 func makeReplicateQueueMetrics() ReplicateQueueMetrics {
 	return ReplicateQueueMetrics{
-		AddReplicaCount:           metric.NewCounter(metaReplicateQueueAddReplicaCount),
-		RemoveReplicaCount:        metric.NewCounter(metaReplicateQueueRemoveReplicaCount),
-		RemoveDeadReplicaCount:    metric.NewCounter(metaReplicateQueueRemoveDeadReplicaCount),
-		RemoveLearnerReplicaCount: metric.NewCounter(metaReplicateQueueRemoveLearnerReplicaCount),
-		RebalanceReplicaCount:     metric.NewCounter(metaReplicateQueueRebalanceReplicaCount),
-		TransferLeaseCount:        metric.NewCounter(metaReplicateQueueTransferLeaseCount),
+		AddReplicaCount:            metric.NewCounter(metaReplicateQueueAddReplicaCount),
+		RemoveReplicaCount:         metric.NewCounter(metaReplicateQueueRemoveReplicaCount),
+		RemoveDeadReplicaCount:     metric.NewCounter(metaReplicateQueueRemoveDeadReplicaCount),
+		RemoveLearnerReplicaCount:  metric.NewCounter(metaReplicateQueueRemoveLearnerReplicaCount),
+		AddNonVoterReplicaCount:    metric.NewCounter(metaReplicateQueueAddNonVoterReplicaCount),
+		RemoveNonVoterReplicaCount: metric.NewCounter(metaReplicateQueueRemoveNonVoterReplicaCount),
+		RebalanceReplicaCount:      metric.NewCounter(metaReplicateQueueRebalanceReplicaCount),
+		TransferLeaseCount:         metric.NewCounter(metaReplicateQueueTransferLeaseCount),
 	}
 }
 
@@ -417,6 +433,19 @@ func (rq *replicateQueue) processOneChange(
 		// Requeue because either we failed to transition out of a joint state
 		// (bad) or we did and there might be more to do for that range.
 		return true, err
+	case AllocatorAddNonVoter:
+		return rq.addNonVoter(ctx, repl, dryRun)
+	case AllocatorRemoveNonVoter:
+		return rq.removeNonVoter(ctx, repl, dryRun)
+	case AllocatorRemoveDeadNonVoter:
+		_, deadNonVoterReplicas := rq.allocator.storePool.liveAndDeadReplicas(
+			desc.RangeID, desc.Replicas().NonVoters())
+		if len(deadNonVoterReplicas) == 0 {
+			// Nothing to do.
+			return false, nil
+		}
+		return rq.changeNonVoter(ctx, repl, roachpb.REMOVE_NON_VOTER, deadNonVoterReplicas[0],
+			storagepb.ReasonStoreDead, "", dryRun)
 	default:
 		return false, errors.Errorf("unknown allocator action %v", action)
 	}
@@ -494,6 +523,13 @@ func (rq *replicateQueue) addOrReplace(
 		NodeID:  newStore.Node.NodeID,
 		StoreID: newStore.StoreID,
 	}
+	// Voters take precedence over non-voters. If the chosen node holds a
+	// non-voter, remove it first; the voter is added on the next pass and the
+	// non-voter is placed elsewhere afterwards.
+	if nonVoter, ok := nonVoterOnNode(desc, newStore.Node.NodeID); ok {
+		return rq.changeNonVoter(ctx, repl, roachpb.REMOVE_NON_VOTER, nonVoter,
+			storagepb.ReasonRangeUnderReplicated, "", dryRun)
+	}
 
 	clusterNodes := rq.allocator.storePool.ClusterNodeCount()
 	need := GetNeededReplicas(*zone.NumReplicas, clusterNodes)
@@ -824,6 +860,91 @@ func (rq *replicateQueue) removeLearner(
 	return true, nil
 }
 
+// addNonVoter adds a non-voting replica to the range on a store chosen by the
+// allocator.
+func (rq *replicateQueue) addNonVoter(
+	ctx context.Context, repl *Replica, dryRun bool,
+) (requeue bool, _ error) {
+	desc, zone := repl.DescAndZone()
+	newStore, details, err := rq.allocator.AllocateNonVoterTarget(
+		ctx, zone, desc.RangeID, desc.Replicas().Voters(), desc.Replicas().NonVoters())
+	if err != nil {
+		return false, err
+	}
+	newReplica := roachpb.ReplicaDescriptor{
+		NodeID:  newStore.Node.NodeID,
+		StoreID: newStore.StoreID,
+	}
+	return rq.changeNonVoter(ctx, repl, roachpb.ADD_NON_VOTER, newReplica,
+		storagepb.ReasonRangeUnderReplicated, details, dryRun)
+}
+
+// removeNonVoter removes the least desirable non-voting replica from the range.
+func (rq *replicateQueue) removeNonVoter(
+	ctx context.Context, repl *Replica, dryRun bool,
+) (requeue bool, _ error) {
+	desc, zone := repl.DescAndZone()
+	removeReplica, details, err := rq.allocator.RemoveNonVoterTarget(
+		ctx, zone, desc.Replicas().NonVoters())
+	if err != nil {
+		return false, err
+	}
+	return rq.changeNonVoter(ctx, repl, roachpb.REMOVE_NON_VOTER, removeReplica,
+		storagepb.ReasonRangeOverReplicated, details, dryRun)
+}
+
+// changeNonVoter adds or removes a single non-voting replica. Non-voters never
+// hold the lease, so there's no need to transfer it away before a removal.
+func (rq *replicateQueue) changeNonVoter(
+	ctx context.Context,
+	repl *Replica,
+	changeType roachpb.ReplicaChangeType,
+	replica roachpb.ReplicaDescriptor,
+	reason storagepb.RangeLogEventReason,
+	details string,
+	dryRun bool,
+) (requeue bool, _ error) {
+	priority := SnapshotRequest_RECOVERY
+	if changeType == roachpb.ADD_NON_VOTER {
+		rq.metrics.AddNonVoterReplicaCount.Inc(1)
+		log.VEventf(ctx, 1, "adding non-voter on n%d,s%d", replica.NodeID, replica.StoreID)
+	} else {
+		priority = SnapshotRequest_UNKNOWN // unused
+		rq.metrics.RemoveNonVoterReplicaCount.Inc(1)
+		log.VEventf(ctx, 1, "removing non-voter %+v", replica)
+	}
+	target := roachpb.ReplicationTarget{
+		NodeID:  replica.NodeID,
+		StoreID: replica.StoreID,
+	}
+	if err := rq.changeReplicas(
+		ctx,
+		repl,
+		roachpb.MakeReplicationChanges(changeType, target),
+		repl.Desc(),
+		priority,
+		reason,
+		details,
+		dryRun,
+	); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// nonVoterOnNode returns the range's non-voting replica on the given node, if
+// there is one.
+func nonVoterOnNode(
+	desc *roachpb.RangeDescriptor, nodeID roachpb.NodeID,
+) (roachpb.ReplicaDescriptor, bool) {
+	for _, rDesc := range desc.Replicas().NonVoters() {
+		if rDesc.NodeID == nodeID {
+			return rDesc, true
+		}
+	}
+	return roachpb.ReplicaDescriptor{}, false
+}
+
 func (rq *replicateQueue) considerRebalance(
 	ctx context.Context,
 	repl *Replica,
@@ -841,6 +962,9 @@ func (rq *replicateQueue) considerRebalance(
 			storeFilterThrottled)
 		if !ok {
 			log.VEventf(ctx, 1, "no suitable rebalance target")
+		} else if _, ok := nonVoterOnNode(desc, addTarget.NodeID); ok {
+			// Rebalancing isn't important enough to displace a non-voter.
+			log.VEventf(ctx, 1, "rebalance target n%d already holds a non-voter", addTarget.NodeID)
 		} else if done, err := rq.maybeTransferLeaseAway(ctx, repl, removeTarget.StoreID, dryRun); err != nil {
 			log.VEventf(ctx, 1, "want to remove self, but failed to transfer lease away: %s", err)
 		} else if done {
@@ -43,6 +43,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/closedts/ctpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
 	"github.com/cockroachdb/cockroach/pkg/storage/compactor"
+	"github.com/cockroachdb/cockroach/pkg/storage/contention"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/idalloc"
@@ -700,6 +701,10 @@ type StoreConfig struct {
 	// subsystem. It is queried during the GC process and in the handling of
 	// AdminVerifyProtectedTimestampRequest.
 	ProtectedTimestampCache protectedts.Cache
+
+	// ContentionRegistry records the contention events observed by requests
+	// on the store. It is shared by all of the node's stores and may be nil.
+	ContentionRegistry *contention.Registry
 }
 
 // ConsistencyTestingKnobs is a BatchEvalTestingKnobs struct used to control the
@@ -2556,6 +2561,11 @@ func (s *Store) GetTxnWaitMetrics() *txnwait.Metrics {
 	return s.txnWaitMetrics
 }
 
+// GetContentionRegistry is part of txnwait.StoreInterface.
+func (s *Store) GetContentionRegistry() *contention.Registry {
+	return s.cfg.ContentionRegistry
+}
+
 func init() {
 	tracing.RegisterTagRemapping("s", "store")
 }
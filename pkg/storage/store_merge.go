@@ -88,6 +88,7 @@ func (s *Store) MergeRange(
 	// Clear the wait queue to redirect the queued transactions to the
 	// left-hand replica, if necessary.
 	rightRepl.txnWaitQueue.Clear(true /* disable */)
+	rightRepl.lockTracker.Clear()
 
 	leftLease, _ := leftRepl.GetLease()
 	rightLease, _ := rightRepl.GetLease()
@@ -107,6 +108,14 @@ func (s *Store) MergeRange(
 		setTimestampCacheLowWaterMark(s.tsCache, &rightDesc, freezeStart)
 	}
 
+	// The spans of online RESTOREs pending on the RHS, if any, are now part of
+	// the LHS.
+	if rightRepl.mayHaveOnlineRestoreSpans() {
+		leftRepl.mu.Lock()
+		leftRepl.mu.hasOnlineRestoreSpans = true
+		leftRepl.mu.Unlock()
+	}
+
 	// Update the subsuming range's descriptor.
 	leftRepl.setDescRaftMuLocked(ctx, &newLeftDesc)
 	return nil
@@ -282,6 +282,7 @@ func (s *Store) SplitRange(
 	// to ensure that no pre-split commands are inserted into the
 	// txnWaitQueue after we clear it.
 	leftRepl.txnWaitQueue.Clear(false /* disable */)
+	leftRepl.lockTracker.Clear()
 
 	// The rangefeed processor will no longer be provided logical ops for
 	// its entire range, so it needs to be shut down and all registrations
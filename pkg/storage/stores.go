@@ -155,6 +155,47 @@ func (ls *Stores) GetReplicaForRangeID(
 	return replica, store, nil
 }
 
+// LocalReadTimestamp returns the newest timestamp at which the replicas on the
+// local stores can serve reads of all of the given spans without redirecting
+// them to a leaseholder on another node. It returns false if part of the spans
+// isn't covered by a local replica that can serve reads.
+func (ls *Stores) LocalReadTimestamp(
+	ctx context.Context, spans roachpb.Spans,
+) (hlc.Timestamp, bool) {
+	ts := ls.clock.Now()
+	for _, span := range spans {
+		key, err := keys.Addr(span.Key)
+		if err != nil {
+			return hlc.Timestamp{}, false
+		}
+		endKey := key.Next()
+		if len(span.EndKey) > 0 {
+			if endKey, err = keys.AddrUpperBound(span.EndKey); err != nil {
+				return hlc.Timestamp{}, false
+			}
+		}
+		for key.Less(endKey) {
+			var repl *Replica
+			_ = ls.VisitStores(func(s *Store) error {
+				if r := s.LookupReplica(key); r != nil {
+					repl = r
+				}
+				return nil
+			})
+			if repl == nil {
+				return hlc.Timestamp{}, false
+			}
+			replTS, ok := repl.localReadTimestamp(ctx)
+			if !ok {
+				return hlc.Timestamp{}, false
+			}
+			ts.Backward(replTS)
+			key = repl.Desc().EndKey
+		}
+	}
+	return ts, true
+}
+
 // Send implements the client.Sender interface. The store is looked up from the
 // store map using the ID specified in the request.
 func (ls *Stores) Send(
@@ -205,6 +205,39 @@ func TestStoresGetStore(t *testing.T) {
 	}
 }
 
+// TestStoresLocalReadTimestamp verifies that the local stores report the
+// timestamp at which they can serve reads of the spans they contain.
+func TestStoresLocalReadTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	store, manual := createTestStore(t, testStoreOpts{}, stopper)
+
+	spans := roachpb.Spans{
+		{Key: roachpb.Key("a")},
+		{Key: roachpb.Key("b"), EndKey: roachpb.Key("c")},
+	}
+
+	ls := newStores(log.AmbientContext{Tracer: tracing.NewTracer()}, store.Clock())
+	if _, ok := ls.LocalReadTimestamp(ctx, spans); ok {
+		t.Fatal("expected spans without a local replica to be rejected")
+	}
+
+	// Acquire the lease, after which reads can be served at the current time.
+	ls.AddStore(store)
+	if _, err := store.DB().Get(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	ts, ok := ls.LocalReadTimestamp(ctx, spans)
+	if !ok {
+		t.Fatal("expected the local leaseholder to serve reads")
+	}
+	if now := manual.UnixNano(); ts.WallTime != now {
+		t.Errorf("expected reads to be served at %d, got %s", now, ts)
+	}
+}
+
 var storeIDAlloc roachpb.StoreID
 
 // createStores creates a slice of count stores.
@@ -19,7 +19,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/contention"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/storage/storagepb"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -105,6 +107,8 @@ func createPushTxnResponse(txn *roachpb.Transaction) *roachpb.PushTxnResponse {
 // dependency cycles.
 type waitingPush struct {
 	req *roachpb.PushTxnRequest
+	// start is the time at which the push began waiting in the queue.
+	start time.Time
 	// pending channel receives updated, pushed txn or nil if queue is cleared.
 	pending chan *roachpb.Transaction
 	mu      struct {
@@ -156,6 +160,7 @@ type StoreInterface interface {
 	DB() *client.DB
 	GetTxnWaitKnobs() TestingKnobs
 	GetTxnWaitMetrics() *Metrics
+	GetContentionRegistry() *contention.Registry
 }
 
 // ReplicaInterface provides some parts of a Replica without incurring a dependency.
@@ -432,6 +437,7 @@ func (q *Queue) MaybeWaitForPush(
 
 	push := &waitingPush{
 		req:     req,
+		start:   timeutil.Now(),
 		pending: make(chan *roachpb.Transaction, 1),
 	}
 	pending.waitingPushes = append(pending.waitingPushes, push)
@@ -458,6 +464,20 @@ func (q *Queue) MaybeWaitForPush(
 	}
 	q.mu.Unlock()
 
+	// Record the time spent waiting as contention on the pushee. The queue
+	// doesn't know the key that the pusher conflicted on, so the event is
+	// attributed to the anchor key of the pushee's transaction record.
+	defer func() {
+		end := timeutil.Now()
+		q.store.GetContentionRegistry().AddContentionEvent(storagepb.ContentionEvent{
+			Key:          req.PusheeTxn.Key,
+			HolderTxn:    req.PusheeTxn,
+			WaitingTxnID: req.PusherTxn.ID,
+			Duration:     end.Sub(push.start),
+			EndTime:      end,
+		})
+	}()
+
 	// Wait for any updates to the pusher txn to be notified when
 	// status, priority, or dependents (for deadlock detection) have
 	// changed.
@@ -19,11 +19,13 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/contention"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -171,11 +173,12 @@ func (mockRepl) ContainsKey(_ roachpb.Key) bool { return true }
 
 // mockStore implements the StoreInterface interface.
 type mockStore struct {
-	manual  *hlc.ManualClock
-	clock   *hlc.Clock
-	stopper *stop.Stopper
-	db      *client.DB
-	metrics *Metrics
+	manual     *hlc.ManualClock
+	clock      *hlc.Clock
+	stopper    *stop.Stopper
+	db         *client.DB
+	metrics    *Metrics
+	contention *contention.Registry
 }
 
 func newMockStore(s client.SenderFunc) StoreInterface {
@@ -184,6 +187,7 @@ func newMockStore(s client.SenderFunc) StoreInterface {
 	ms.clock = hlc.NewClock(ms.manual.UnixNano, time.Nanosecond)
 	ms.stopper = stop.NewStopper()
 	ms.metrics = NewMetrics(time.Minute)
+	ms.contention = contention.NewRegistry()
 	if s != nil {
 		factory := client.NonTransactionalFactoryFunc(s)
 		ms.db = client.NewDB(testutils.MakeAmbientCtx(), factory, ms.clock)
@@ -196,6 +200,9 @@ func (s mockStore) Stopper() *stop.Stopper        { return s.stopper }
 func (s mockStore) DB() *client.DB                { return s.db }
 func (s mockStore) GetTxnWaitKnobs() TestingKnobs { return TestingKnobs{} }
 func (s mockStore) GetTxnWaitMetrics() *Metrics   { return s.metrics }
+func (s mockStore) GetContentionRegistry() *contention.Registry {
+	return s.contention
+}
 
 // TestMaybeWaitForQueryWithContextCancellation adds a new waiting query to the
 // queue and cancels its context. It then verifies that the query was cleaned
@@ -292,4 +299,10 @@ func TestPushersReleasedAfterAnyQueryTxnFindsAbortedTxn(t *testing.T) {
 		}()
 	}
 	wg.Wait()
+
+	// Each pusher's wait is recorded as contention caused by the pushee.
+	summary := ms.GetContentionRegistry().Summary(timeutil.Now())
+	require.Len(t, summary.Txns, 1)
+	require.Equal(t, txn.ID, summary.Txns[0].TxnID)
+	require.Equal(t, int64(numPushees), summary.Txns[0].NumContentionEvents)
 }
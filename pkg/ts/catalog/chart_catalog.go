@@ -1290,6 +1290,10 @@ var charts = []sectionDescription{
 	{
 		Organization: [][]string{{ReplicationLayer, "Replicate Queue"}},
 		Charts: []chartDescription{
+			{
+				Title:   "Add Non-Voter Count",
+				Metrics: []string{"queue.replicate.addnonvoterreplica"},
+			},
 			{
 				Title:   "Add Replica Count",
 				Metrics: []string{"queue.replicate.addreplica"},
@@ -1316,6 +1320,7 @@ var charts = []sectionDescription{
 					"queue.replicate.removedeadreplica",
 					"queue.replicate.removereplica",
 					"queue.replicate.removelearnerreplica",
+					"queue.replicate.removenonvoterreplica",
 				},
 			},
 			{
@@ -111,6 +111,22 @@ minute point; this means that any downsampler that could have been used on the
 original data is still accessible in the rolled-up data.
 
 
+Histograms
+
+If the timeseries.storage.histogram_buckets.enabled cluster setting is set,
+histograms are also recorded as a single series holding the cumulative count of
+each of their buckets, with a source key of [source id]:[bucket index]. All
+histograms share a fixed exponential bucket layout (see tspb.HistogramBucket).
+Because the counts are cumulative, the histogram of the values recorded during
+any sample period, at any resolution, is the difference between the counts at
+the end and at the start of the period; histograms of different sources are
+merged by summing their counts. Queries specifying a quantile return the
+quantile of these merged histograms for each sample period, so that percentiles
+are never averaged across sources or time. Since every non-empty bucket is its
+own series, recording buckets greatly increases the amount of data stored, and
+quantile queries return no data while the setting is disabled.
+
+
 Example
 
 A hypothetical example from CockroachDB: we want to record the available
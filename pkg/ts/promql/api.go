@@ -0,0 +1,274 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package promql implements a subset of the Prometheus query language and of
+// the Prometheus HTTP API over the internal time series database, so that
+// tools like Grafana can read the historical metrics of a cluster without a
+// separate Prometheus server.
+//
+// The metrics are named as in the _status/vars endpoint, e.g.
+// sql_query_count, and their series are labeled with the node_id or the
+// store they come from. The time series can also be selected by their name
+// in the database, e.g. {__name__="cr.node.sql.query.count"}.
+//
+// The supported subset of the language consists of:
+//  - instant and range vector selectors, with label matchers and offsets;
+//  - the functions rate, irate, increase, delta, avg_over_time,
+//    sum_over_time, min_over_time, max_over_time, count_over_time, abs,
+//    ceil, floor, sqrt, exp, ln, log2 and log10;
+//  - the aggregations sum, avg, min, max, count, stddev and quantile, with
+//    by and without clauses;
+//  - the arithmetic operators, between scalars and vectors, and between
+//    vectors with one-to-one matching on all labels.
+//
+// Unlike in Prometheus, rate, increase and delta are not extrapolated to the
+// boundaries of the range. The resolution of the samples is 10s, or 30m when
+// the step of the query and its ranges are large enough to use the rollups
+// of the database.
+package promql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/pkg/errors"
+)
+
+// URLPrefix is the prefix of the endpoints of the Prometheus HTTP API. Data
+// sources in Grafana use it as their URL, e.g. https://host:8080/ts/prom.
+const URLPrefix = "/ts/prom/"
+
+// Handler serves the Prometheus HTTP API endpoints:
+//  - api/v1/query for instant queries;
+//  - api/v1/query_range for range queries;
+//  - api/v1/labels and api/v1/label/<name>/values for the label names and
+//    values.
+type Handler struct {
+	engine *Engine
+	mux    *http.ServeMux
+}
+
+var _ http.Handler = &Handler{}
+
+// NewHandler creates a Handler serving queries over the given time series
+// database.
+func NewHandler(querier Querier, names MetricNamesFn) *Handler {
+	h := &Handler{engine: NewEngine(querier, names), mux: http.NewServeMux()}
+	h.mux.HandleFunc(URLPrefix+"api/v1/query", h.handleQuery)
+	h.mux.HandleFunc(URLPrefix+"api/v1/query_range", h.handleQueryRange)
+	h.mux.HandleFunc(URLPrefix+"api/v1/labels", h.handleLabels)
+	h.mux.HandleFunc(URLPrefix+"api/v1/label/", h.handleLabelValues)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	ts := timeutil.Now()
+	if t := r.FormValue("time"); t != "" {
+		var err error
+		if ts, err = parseTime(t); err != nil {
+			writeError(r, w, &badDataError{errors.Wrap(err, "invalid parameter 'time'")})
+			return
+		}
+	}
+	res, err := h.engine.query(r.Context(), r.FormValue("query"), ts, ts, time.Second)
+	if err != nil {
+		writeError(r, w, err)
+		return
+	}
+	switch v := res.value.(type) {
+	case scalarValue:
+		writeData(r, w, "scalar", point(res.times[0], v[0]))
+	case vectorValue:
+		samples := []vectorSample{}
+		for _, ss := range v {
+			if ss.ok[0] {
+				samples = append(samples, vectorSample{
+					Metric: metricOf(ss.labels),
+					Value:  point(res.times[0], ss.vals[0]),
+				})
+			}
+		}
+		writeData(r, w, "vector", samples)
+	}
+}
+
+func (h *Handler) handleQueryRange(w http.ResponseWriter, r *http.Request) {
+	start, err := parseTime(r.FormValue("start"))
+	if err != nil {
+		writeError(r, w, &badDataError{errors.Wrap(err, "invalid parameter 'start'")})
+		return
+	}
+	end, err := parseTime(r.FormValue("end"))
+	if err != nil {
+		writeError(r, w, &badDataError{errors.Wrap(err, "invalid parameter 'end'")})
+		return
+	}
+	step, err := parseStep(r.FormValue("step"))
+	if err != nil {
+		writeError(r, w, &badDataError{errors.Wrap(err, "invalid parameter 'step'")})
+		return
+	}
+	res, err := h.engine.query(r.Context(), r.FormValue("query"), start, end, step)
+	if err != nil {
+		writeError(r, w, err)
+		return
+	}
+	series := []matrixSeries{}
+	switch v := res.value.(type) {
+	case scalarValue:
+		s := matrixSeries{Metric: map[string]string{}}
+		for i, t := range res.times {
+			s.Values = append(s.Values, point(t, v[i]))
+		}
+		series = append(series, s)
+	case vectorValue:
+		for _, ss := range v {
+			s := matrixSeries{Metric: metricOf(ss.labels)}
+			for i, t := range res.times {
+				if ss.ok[i] {
+					s.Values = append(s.Values, point(t, ss.vals[i]))
+				}
+			}
+			if len(s.Values) > 0 {
+				series = append(series, s)
+			}
+		}
+	}
+	writeData(r, w, "matrix", series)
+}
+
+func (h *Handler) handleLabels(w http.ResponseWriter, r *http.Request) {
+	writeData(r, w, "", []string{nameLabel, "node_id", "store"})
+}
+
+func (h *Handler) handleLabelValues(w http.ResponseWriter, r *http.Request) {
+	const suffix = "/values"
+	path := r.URL.Path[len(URLPrefix+"api/v1/label/"):]
+	if len(path) <= len(suffix) || path[len(path)-len(suffix):] != suffix {
+		http.NotFound(w, r)
+		return
+	}
+	// Only the metric names are known without querying the series.
+	values := []string{}
+	if path[:len(path)-len(suffix)] == nameLabel && h.engine.names != nil {
+		for name := range h.engine.names() {
+			values = append(values, name)
+		}
+		sort.Strings(values)
+	}
+	writeData(r, w, "", values)
+}
+
+// parseTime parses a timestamp in seconds since the epoch, e.g.
+// 1580000000.5, or in the RFC 3339 format.
+func parseTime(s string) (time.Time, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(f)
+		return timeutil.Unix(int64(sec), int64(frac*float64(time.Second))), nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// parseStep parses a duration in seconds, e.g. 15, or in the PromQL format,
+// e.g. 1m.
+func parseStep(s string) (time.Duration, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(f * float64(time.Second)), nil
+	}
+	return parseDuration(s)
+}
+
+type vectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]interface{}    `json:"value"`
+}
+
+type matrixSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values [][2]interface{}  `json:"values"`
+}
+
+// point returns a datapoint in the format of the Prometheus HTTP API: the
+// time in seconds, and the value as a string.
+func point(t int64, v float64) [2]interface{} {
+	return [2]interface{}{float64(t) / float64(time.Second), formatValue(v)}
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func metricOf(ls labels) map[string]string {
+	m := make(map[string]string, len(ls))
+	for _, l := range ls {
+		m[l.name] = l.value
+	}
+	return m
+}
+
+type response struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+type queryData struct {
+	ResultType string      `json:"resultType"`
+	Result     interface{} `json:"result"`
+}
+
+func writeData(r *http.Request, w http.ResponseWriter, resultType string, result interface{}) {
+	var data interface{} = result
+	if resultType != "" {
+		data = queryData{ResultType: resultType, Result: result}
+	}
+	writeResponse(r, w, http.StatusOK, response{Status: "success", Data: data})
+}
+
+func writeError(r *http.Request, w http.ResponseWriter, err error) {
+	status, errType := http.StatusUnprocessableEntity, "execution"
+	if _, ok := err.(*badDataError); ok {
+		status, errType = http.StatusBadRequest, "bad_data"
+	}
+	writeResponse(r, w, status, response{Status: "error", ErrorType: errType, Error: err.Error()})
+}
+
+func writeResponse(r *http.Request, w http.ResponseWriter, status int, resp response) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		log.Errorf(r.Context(), "unable to marshal the response: %v", err)
+		http.Error(w, fmt.Sprintf("unable to marshal the response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package promql
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	e := newTestEngine()
+	h := NewHandler(e.querier, e.names)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	unix := func(t time.Time) string {
+		return strconv.FormatInt(t.Unix(), 10)
+	}
+	ts := testStart.Add(5 * time.Minute)
+	testCases := []struct {
+		path     string
+		params   url.Values
+		status   int
+		expected string
+	}{
+		{
+			path:     "api/v1/query",
+			params:   url.Values{"query": {`capacity{store="1"}`}, "time": {unix(ts)}},
+			status:   http.StatusOK,
+			expected: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"capacity","store":"1"},"value":[1577837100,"100"]}]}}`,
+		},
+		{
+			path:     "api/v1/query",
+			params:   url.Values{"query": {`1 / 0`}, "time": {ts.Format(time.RFC3339)}},
+			status:   http.StatusOK,
+			expected: `{"status":"success","data":{"resultType":"scalar","result":[1577837100,"+Inf"]}}`,
+		},
+		{
+			path: "api/v1/query_range",
+			params: url.Values{
+				"query": {`sum(rate(sql_query_count{node_id!="3"}[30s]))`},
+				"start": {unix(testStart)}, "end": {unix(testStart.Add(time.Minute))}, "step": {"30s"},
+			},
+			status:   http.StatusOK,
+			expected: `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1577836830,"3"],[1577836860,"3"]]}]}}`,
+		},
+		{
+			path:     "api/v1/query_range",
+			params:   url.Values{"query": {`capacity`}, "start": {"x"}},
+			status:   http.StatusBadRequest,
+			expected: `{"status":"error","errorType":"bad_data","error":"invalid parameter 'start': parsing time \"x\" as \"2006-01-02T15:04:05.999999999Z07:00\": cannot parse \"x\" as \"2006\""}`,
+		},
+		{
+			path:     "api/v1/query",
+			params:   url.Values{"query": {`sum(`}},
+			status:   http.StatusBadRequest,
+			expected: `{"status":"error","errorType":"bad_data","error":"unexpected end of input at position 4, expected expression"}`,
+		},
+		{
+			path:     "api/v1/label/__name__/values",
+			status:   http.StatusOK,
+			expected: `{"status":"success","data":["capacity","capacity_used","sql_query_count","sys_cpu_user_ns","unknown_but_named"]}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path+"?"+tc.params.Encode(), func(t *testing.T) {
+			resp, err := http.PostForm(srv.URL+URLPrefix+tc.path, tc.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+			if string(body) != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, body)
+			}
+		})
+	}
+}
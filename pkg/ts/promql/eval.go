@@ -0,0 +1,893 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package promql
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/pkg/errors"
+)
+
+var (
+	posInf = math.Inf(1)
+	negInf = math.Inf(-1)
+	nan    = math.NaN()
+)
+
+const (
+	// defaultLookback is how far back an instant vector selector looks for
+	// the latest sample of a series, like in Prometheus.
+	defaultLookback = 5 * time.Minute
+	// maxSteps is the maximum number of steps of a range query, like in
+	// Prometheus.
+	maxSteps = 11000
+	// fineSampleDuration and coarseSampleDuration are the durations of the
+	// samples read from the time series database; they correspond to its
+	// resolutions (see ts.Resolution10s and ts.Resolution30m).
+	fineSampleDuration   = 10 * time.Second
+	coarseSampleDuration = 30 * time.Minute
+)
+
+// Querier is the interface to the time series database used by the
+// evaluation of the queries, implemented by ts.Server.
+type Querier interface {
+	Query(context.Context, *tspb.TimeSeriesQueryRequest) (*tspb.TimeSeriesQueryResponse, error)
+}
+
+// MetricNamesFn returns the names of the time series in the database, keyed
+// by their name in PromQL, e.g. cr.node.sql.query.count for
+// sql_query_count.
+type MetricNamesFn func() map[string]string
+
+// Engine evaluates PromQL queries over the time series database.
+type Engine struct {
+	querier Querier
+	names   MetricNamesFn
+}
+
+// NewEngine creates an Engine.
+func NewEngine(querier Querier, names MetricNamesFn) *Engine {
+	return &Engine{querier: querier, names: names}
+}
+
+// label is a label of a series.
+type label struct {
+	name, value string
+}
+
+// labels are the labels of a series, sorted by name.
+type labels []label
+
+func (ls labels) get(name string) string {
+	for _, l := range ls {
+		if l.name == name {
+			return l.value
+		}
+	}
+	return ""
+}
+
+// key returns a string identifying the label set.
+func (ls labels) key() string {
+	var buf strings.Builder
+	for _, l := range ls {
+		buf.WriteString(l.name)
+		buf.WriteByte(0)
+		buf.WriteString(l.value)
+		buf.WriteByte(0)
+	}
+	return buf.String()
+}
+
+// without returns the labels other than the given ones.
+func (ls labels) without(names ...string) labels {
+	res := make(labels, 0, len(ls))
+	for _, l := range ls {
+		keep := true
+		for _, n := range names {
+			if l.name == n {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+// only returns the given labels.
+func (ls labels) only(names []string) labels {
+	res := make(labels, 0, len(names))
+	for _, l := range ls {
+		for _, n := range names {
+			if l.name == n {
+				res = append(res, l)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// sample is a datapoint of a raw series.
+type sample struct {
+	t int64 // nanoseconds.
+	v float64
+}
+
+// rawSeries is a series read from the database.
+type rawSeries struct {
+	labels  labels
+	samples []sample
+}
+
+// value is the result of the evaluation of an expression at each step of a
+// query: either a scalarValue or a vectorValue.
+type value interface{}
+
+// scalarValue holds the value of a scalar at each step.
+type scalarValue []float64
+
+// vectorValue holds series which have a value at some of the steps.
+type vectorValue []*stepSeries
+
+// stepSeries is a series evaluated at the steps of a query.
+type stepSeries struct {
+	labels labels
+	vals   []float64
+	ok     []bool
+}
+
+func newStepSeries(ls labels, steps int) *stepSeries {
+	return &stepSeries{labels: ls, vals: make([]float64, steps), ok: make([]bool, steps)}
+}
+
+// result is the result of a query.
+type result struct {
+	value value
+	// times are the times of the steps.
+	times []int64
+}
+
+// evaluator evaluates an expression at the steps of a query.
+type evaluator struct {
+	ctx     context.Context
+	querier Querier
+	names   map[string]string
+	// start and step are in nanoseconds.
+	start, step int64
+	steps       int
+	sample      time.Duration
+	lookback    time.Duration
+	// series holds the raw series read for each selector.
+	series map[*vectorSelector][]rawSeries
+}
+
+// query evaluates a query at the steps between start and end.
+func (e *Engine) query(
+	ctx context.Context, q string, start, end time.Time, step time.Duration,
+) (*result, error) {
+	ex, err := parseExpr(q)
+	if err != nil {
+		return nil, &badDataError{err}
+	}
+	if end.Before(start) {
+		return nil, &badDataError{errors.New("end timestamp must not be before start time")}
+	}
+	if step <= 0 {
+		return nil, &badDataError{errors.New("zero or negative query resolution step widths are not accepted")}
+	}
+	steps := int(end.Sub(start)/step) + 1
+	if steps > maxSteps {
+		return nil, &badDataError{errors.Errorf(
+			"exceeded maximum resolution of %d points per timeseries: try decreasing the query resolution (?step=XX)",
+			maxSteps)}
+	}
+
+	ev := &evaluator{
+		ctx:     ctx,
+		querier: e.querier,
+		start:   start.UnixNano(),
+		step:    step.Nanoseconds(),
+		steps:   steps,
+		series:  make(map[*vectorSelector][]rawSeries),
+	}
+	if e.names != nil {
+		ev.names = e.names()
+	}
+
+	// Read the coarse samples when they're fine enough for the step and for
+	// the ranges of the query.
+	ev.sample = fineSampleDuration
+	minRange := time.Duration(math.MaxInt64)
+	walk(ex, func(x expr) {
+		if ms, ok := x.(*matrixSelector); ok && ms.rng < minRange {
+			minRange = ms.rng
+		}
+	})
+	if step >= coarseSampleDuration && minRange >= 2*coarseSampleDuration {
+		ev.sample = coarseSampleDuration
+	}
+	ev.lookback = defaultLookback
+	if ev.lookback < 2*ev.sample {
+		ev.lookback = 2 * ev.sample
+	}
+
+	var fetchErr error
+	walk(ex, func(x expr) {
+		if fetchErr != nil {
+			return
+		}
+		switch x := x.(type) {
+		case *matrixSelector:
+			fetchErr = ev.fetch(x.vectorSelector, x.rng)
+		case *vectorSelector:
+			if _, ok := ev.series[x]; !ok {
+				fetchErr = ev.fetch(x, ev.lookback)
+			}
+		}
+	})
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+
+	v, err := ev.eval(ex)
+	if err != nil {
+		return nil, err
+	}
+	res := &result{value: v, times: make([]int64, steps)}
+	for i := range res.times {
+		res.times[i] = ev.stepTime(i)
+	}
+	return res, nil
+}
+
+// walk calls fn on the given expression and on all its subexpressions.
+// Matrix selectors are visited instead of their vector selector.
+func walk(x expr, fn func(expr)) {
+	fn(x)
+	switch x := x.(type) {
+	case *call:
+		for _, a := range x.args {
+			walk(a, fn)
+		}
+	case *aggregateExpr:
+		if x.param != nil {
+			walk(x.param, fn)
+		}
+		walk(x.expr, fn)
+	case *binaryExpr:
+		walk(x.lhs, fn)
+		walk(x.rhs, fn)
+	case *unaryExpr:
+		walk(x.expr, fn)
+	case *parenExpr:
+		walk(x.expr, fn)
+	}
+}
+
+func (ev *evaluator) stepTime(i int) int64 {
+	return ev.start + int64(i)*ev.step
+}
+
+// sourceLabel returns the label holding the source of the series with the
+// given name in the database: the node or store ID.
+func sourceLabel(tsName string) string {
+	if strings.HasPrefix(tsName, "cr.store.") {
+		return "store"
+	}
+	return "node_id"
+}
+
+// fetch reads the series selected by the given selector, over the steps of
+// the query extended by the given range.
+func (ev *evaluator) fetch(vs *vectorSelector, rng time.Duration) error {
+	tsName, ok := ev.names[vs.name]
+	if !ok {
+		if !strings.HasPrefix(vs.name, "cr.") {
+			// Unknown metrics have no series, like in Prometheus.
+			ev.series[vs] = nil
+			return nil
+		}
+		// The name of the time series in the database, e.g.
+		// {__name__="cr.node.sql.query.count"}.
+		tsName = vs.name
+	}
+	sampleNanos := ev.sample.Nanoseconds()
+	startNanos := ev.start - vs.offset.Nanoseconds() - rng.Nanoseconds()
+	endNanos := ev.stepTime(ev.steps-1) - vs.offset.Nanoseconds() + sampleNanos
+	// The database refuses queries in the future, and doesn't return the
+	// current sample period.
+	if cutoff := timeutil.Now().UnixNano() - sampleNanos; endNanos > cutoff {
+		endNanos = cutoff
+	}
+	if startNanos > endNanos {
+		ev.series[vs] = nil
+		return nil
+	}
+	req := tspb.TimeSeriesQueryRequest{
+		StartNanos:  startNanos,
+		EndNanos:    endNanos,
+		SampleNanos: sampleNanos,
+	}
+	// sourceQuery reads the series of a single source, or that of the sources
+	// aggregated if source is empty.
+	sourceQuery := func(source string) tspb.Query {
+		q := tspb.Query{
+			Name:             tsName,
+			Downsampler:      tspb.TimeSeriesQueryAggregator_AVG.Enum(),
+			SourceAggregator: tspb.TimeSeriesQueryAggregator_SUM.Enum(),
+		}
+		if source != "" {
+			q.Sources = []string{source}
+		}
+		return q
+	}
+	toSamples := func(dps []tspb.TimeSeriesDatapoint) []sample {
+		samples := make([]sample, len(dps))
+		for j, dp := range dps {
+			samples[j] = sample{t: dp.TimestampNanos + vs.offset.Nanoseconds(), v: dp.Value}
+		}
+		return samples
+	}
+
+	srcLabel := sourceLabel(tsName)
+	var series []rawSeries
+	sources, known := selectedSources(vs, srcLabel)
+	if !known {
+		// Find the sources of the series first. The response aggregates the
+		// data of the sources, so it is only reused if there's a single one.
+		req.Queries = []tspb.Query{sourceQuery("")}
+		resp, err := ev.querier.Query(ev.ctx, &req)
+		if err != nil {
+			return err
+		}
+		sources = resp.Results[0].Sources
+		if len(sources) == 1 {
+			if ls := sourceLabels(vs, srcLabel, sources[0]); ls != nil {
+				series = append(series, rawSeries{labels: ls, samples: toSamples(resp.Results[0].Datapoints)})
+			}
+			sources = nil
+		}
+	}
+	req.Queries = req.Queries[:0]
+	var sourceSeries []rawSeries
+	for _, source := range sources {
+		if ls := sourceLabels(vs, srcLabel, source); ls != nil {
+			sourceSeries = append(sourceSeries, rawSeries{labels: ls})
+			req.Queries = append(req.Queries, sourceQuery(source))
+		}
+	}
+	if len(sourceSeries) > 0 {
+		resp, err := ev.querier.Query(ev.ctx, &req)
+		if err != nil {
+			return err
+		}
+		for i := range sourceSeries {
+			dps := resp.Results[i].Datapoints
+			// Sources named by the selector may not exist, in which case they
+			// have no series.
+			if known && len(dps) == 0 {
+				continue
+			}
+			sourceSeries[i].samples = toSamples(dps)
+			series = append(series, sourceSeries[i])
+		}
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return lessSource(series[i].labels.get(srcLabel), series[j].labels.get(srcLabel))
+	})
+	ev.series[vs] = series
+	return nil
+}
+
+// selectedSources returns the sources that the given selector selects by
+// value, e.g. with {node_id="1"}, in which case they don't need to be found in
+// the database first.
+func selectedSources(vs *vectorSelector, srcLabel string) (_ []string, known bool) {
+	for _, m := range vs.matchers {
+		if m.name == srcLabel && m.typ == matchEqual {
+			if m.value == "" {
+				// Every series has a source.
+				return nil, true
+			}
+			return []string{m.value}, true
+		}
+	}
+	return nil, false
+}
+
+// sourceLabels returns the labels of the series of the given source, or nil
+// if the selector doesn't select it.
+func sourceLabels(vs *vectorSelector, srcLabel, source string) labels {
+	ls := labels{{name: nameLabel, value: vs.name}, {name: srcLabel, value: source}}
+	sort.Slice(ls, func(i, j int) bool { return ls[i].name < ls[j].name })
+	for _, m := range vs.matchers {
+		if !m.matches(ls.get(m.name)) {
+			return nil
+		}
+	}
+	return ls
+}
+
+// lessSource orders the sources numerically when they are IDs.
+func lessSource(a, b string) bool {
+	ai, errA := strconv.Atoi(a)
+	bi, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return ai < bi
+	}
+	return a < b
+}
+
+func (ev *evaluator) eval(x expr) (value, error) {
+	if err := ev.ctx.Err(); err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case *numberLiteral:
+		s := make(scalarValue, ev.steps)
+		for i := range s {
+			s[i] = x.val
+		}
+		return s, nil
+	case *parenExpr:
+		return ev.eval(x.expr)
+	case *vectorSelector:
+		return ev.evalVectorSelector(x), nil
+	case *matrixSelector:
+		return nil, &badDataError{errors.Errorf(
+			"range vector %s must be the argument of a function, e.g. rate(%s)", x, x)}
+	case *call:
+		return ev.evalCall(x)
+	case *aggregateExpr:
+		return ev.evalAggregation(x)
+	case *unaryExpr:
+		v, err := ev.eval(x.expr)
+		if err != nil {
+			return nil, err
+		}
+		return ev.evalBinary("*", scalarValueOf(ev.steps, -1), v)
+	case *binaryExpr:
+		lhs, err := ev.eval(x.lhs)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := ev.eval(x.rhs)
+		if err != nil {
+			return nil, err
+		}
+		return ev.evalBinary(x.op, lhs, rhs)
+	}
+	return nil, errors.Errorf("unsupported expression %s", x)
+}
+
+func scalarValueOf(steps int, v float64) scalarValue {
+	s := make(scalarValue, steps)
+	for i := range s {
+		s[i] = v
+	}
+	return s
+}
+
+// evalVectorSelector returns, at each step, the latest sample of each
+// series within the lookback period.
+func (ev *evaluator) evalVectorSelector(vs *vectorSelector) vectorValue {
+	var res vectorValue
+	lookback := ev.lookback.Nanoseconds()
+	for _, rs := range ev.series[vs] {
+		ss := newStepSeries(rs.labels, ev.steps)
+		j := 0
+		for i := 0; i < ev.steps; i++ {
+			t := ev.stepTime(i)
+			for j < len(rs.samples) && rs.samples[j].t <= t {
+				j++
+			}
+			if j > 0 && rs.samples[j-1].t > t-lookback {
+				ss.vals[i], ss.ok[i] = rs.samples[j-1].v, true
+			}
+		}
+		res = append(res, ss)
+	}
+	return res
+}
+
+// argType is the type of the argument of a function.
+type argType int
+
+const (
+	argVector argType = iota
+	argMatrix
+)
+
+// function is a supported PromQL function. Functions either compute a value
+// from the samples of each series over a range (rangeFn), or transform the
+// values of each series (valueFn).
+type function struct {
+	name     string
+	argTypes []argType
+	rangeFn  func(samples []sample, rng time.Duration) (float64, bool)
+	valueFn  func(float64) float64
+}
+
+var functions = map[string]*function{}
+
+func init() {
+	for _, fn := range []*function{
+		{name: "rate", rangeFn: func(s []sample, _ time.Duration) (float64, bool) {
+			return counterRate(s)
+		}},
+		{name: "irate", rangeFn: func(s []sample, _ time.Duration) (float64, bool) {
+			if len(s) < 2 {
+				return 0, false
+			}
+			return counterRate(s[len(s)-2:])
+		}},
+		{name: "increase", rangeFn: func(s []sample, rng time.Duration) (float64, bool) {
+			r, ok := counterRate(s)
+			return r * rng.Seconds(), ok
+		}},
+		{name: "delta", rangeFn: func(s []sample, rng time.Duration) (float64, bool) {
+			if len(s) < 2 {
+				return 0, false
+			}
+			first, last := s[0], s[len(s)-1]
+			return (last.v - first.v) / float64(last.t-first.t) * float64(rng.Nanoseconds()), true
+		}},
+		{name: "avg_over_time", rangeFn: overTime(func(vals []float64) float64 {
+			return aggSum(vals) / float64(len(vals))
+		})},
+		{name: "sum_over_time", rangeFn: overTime(aggSum)},
+		{name: "min_over_time", rangeFn: overTime(aggMin)},
+		{name: "max_over_time", rangeFn: overTime(aggMax)},
+		{name: "count_over_time", rangeFn: overTime(func(vals []float64) float64 {
+			return float64(len(vals))
+		})},
+		{name: "abs", valueFn: math.Abs},
+		{name: "ceil", valueFn: math.Ceil},
+		{name: "floor", valueFn: math.Floor},
+		{name: "sqrt", valueFn: math.Sqrt},
+		{name: "exp", valueFn: math.Exp},
+		{name: "ln", valueFn: math.Log},
+		{name: "log2", valueFn: math.Log2},
+		{name: "log10", valueFn: math.Log10},
+	} {
+		if fn.rangeFn != nil {
+			fn.argTypes = []argType{argMatrix}
+		} else {
+			fn.argTypes = []argType{argVector}
+		}
+		functions[fn.name] = fn
+	}
+}
+
+// counterRate returns the per-second rate of increase of a counter over the
+// given samples, taking counter resets into account. Unlike Prometheus, the
+// rate is not extrapolated to the boundaries of the range.
+func counterRate(s []sample) (float64, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	var increase float64
+	for i := 1; i < len(s); i++ {
+		if d := s[i].v - s[i-1].v; d >= 0 {
+			increase += d
+		} else {
+			// The counter was reset.
+			increase += s[i].v
+		}
+	}
+	return increase / (float64(s[len(s)-1].t-s[0].t) / float64(time.Second)), true
+}
+
+func overTime(agg func([]float64) float64) func([]sample, time.Duration) (float64, bool) {
+	return func(s []sample, _ time.Duration) (float64, bool) {
+		if len(s) == 0 {
+			return 0, false
+		}
+		vals := make([]float64, len(s))
+		for i := range s {
+			vals[i] = s[i].v
+		}
+		return agg(vals), true
+	}
+}
+
+func (ev *evaluator) evalCall(c *call) (value, error) {
+	if c.fn.rangeFn != nil {
+		ms := c.args[0].(*matrixSelector)
+		rng := ms.rng.Nanoseconds()
+		var res vectorValue
+		for _, rs := range ev.series[ms.vectorSelector] {
+			ss := newStepSeries(rs.labels.without(nameLabel), ev.steps)
+			lo, hi := 0, 0
+			for i := 0; i < ev.steps; i++ {
+				t := ev.stepTime(i)
+				for hi < len(rs.samples) && rs.samples[hi].t <= t {
+					hi++
+				}
+				for lo < hi && rs.samples[lo].t <= t-rng {
+					lo++
+				}
+				ss.vals[i], ss.ok[i] = c.fn.rangeFn(rs.samples[lo:hi], ms.rng)
+			}
+			res = append(res, ss)
+		}
+		return res, nil
+	}
+
+	arg, err := ev.eval(c.args[0])
+	if err != nil {
+		return nil, err
+	}
+	vec, ok := arg.(vectorValue)
+	if !ok {
+		return nil, &badDataError{errors.Errorf("function %s expects an instant vector, got a scalar", c.fn.name)}
+	}
+	res := make(vectorValue, len(vec))
+	for i, ss := range vec {
+		out := newStepSeries(ss.labels.without(nameLabel), ev.steps)
+		for j := range ss.vals {
+			if ss.ok[j] {
+				out.vals[j], out.ok[j] = c.fn.valueFn(ss.vals[j]), true
+			}
+		}
+		res[i] = out
+	}
+	return res, nil
+}
+
+func (ev *evaluator) evalAggregation(agg *aggregateExpr) (value, error) {
+	var param scalarValue
+	if agg.param != nil {
+		p, err := ev.eval(agg.param)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if param, ok = p.(scalarValue); !ok {
+			return nil, &badDataError{errors.Errorf("the parameter of %s must be a scalar", agg.op)}
+		}
+	}
+	v, err := ev.eval(agg.expr)
+	if err != nil {
+		return nil, err
+	}
+	vec, ok := v.(vectorValue)
+	if !ok {
+		return nil, &badDataError{errors.Errorf("%s expects an instant vector, got a scalar", agg.op)}
+	}
+
+	// Group the series.
+	type group struct {
+		labels  labels
+		members []*stepSeries
+	}
+	groups := make(map[string]*group)
+	var order []*group
+	for _, ss := range vec {
+		var ls labels
+		if agg.without {
+			ls = ss.labels.without(append([]string{nameLabel}, agg.grouping...)...)
+		} else {
+			ls = ss.labels.only(agg.grouping)
+		}
+		k := ls.key()
+		g, ok := groups[k]
+		if !ok {
+			g = &group{labels: ls}
+			groups[k] = g
+			order = append(order, g)
+		}
+		g.members = append(g.members, ss)
+	}
+
+	res := make(vectorValue, 0, len(order))
+	vals := make([]float64, 0, len(vec))
+	for _, g := range order {
+		out := newStepSeries(g.labels, ev.steps)
+		for i := 0; i < ev.steps; i++ {
+			vals = vals[:0]
+			for _, m := range g.members {
+				if m.ok[i] {
+					vals = append(vals, m.vals[i])
+				}
+			}
+			if len(vals) == 0 {
+				continue
+			}
+			out.ok[i] = true
+			switch agg.op {
+			case "sum":
+				out.vals[i] = aggSum(vals)
+			case "avg":
+				out.vals[i] = aggSum(vals) / float64(len(vals))
+			case "min":
+				out.vals[i] = aggMin(vals)
+			case "max":
+				out.vals[i] = aggMax(vals)
+			case "count":
+				out.vals[i] = float64(len(vals))
+			case "stddev":
+				out.vals[i] = aggStddev(vals)
+			case "quantile":
+				out.vals[i] = aggQuantile(param[i], vals)
+			default:
+				return nil, errors.Errorf("unsupported aggregation %s", agg.op)
+			}
+		}
+		res = append(res, out)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].labels.key() < res[j].labels.key() })
+	return res, nil
+}
+
+func aggSum(vals []float64) float64 {
+	var sum float64
+	for _, v := range vals {
+		sum += v
+	}
+	return sum
+}
+
+func aggMin(vals []float64) float64 {
+	min := vals[0]
+	for _, v := range vals[1:] {
+		if v < min || math.IsNaN(min) {
+			min = v
+		}
+	}
+	return min
+}
+
+func aggMax(vals []float64) float64 {
+	max := vals[0]
+	for _, v := range vals[1:] {
+		if v > max || math.IsNaN(max) {
+			max = v
+		}
+	}
+	return max
+}
+
+func aggStddev(vals []float64) float64 {
+	mean := aggSum(vals) / float64(len(vals))
+	var variance float64
+	for _, v := range vals {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(vals)))
+}
+
+// aggQuantile returns the φ-quantile of the values, interpolating linearly
+// between the closest ranks like in Prometheus.
+func aggQuantile(phi float64, vals []float64) float64 {
+	switch {
+	case math.IsNaN(phi):
+		return nan
+	case phi < 0:
+		return negInf
+	case phi > 1:
+		return posInf
+	}
+	sorted := append([]float64(nil), vals...)
+	sort.Float64s(sorted)
+	rank := phi * float64(len(sorted)-1)
+	lower := math.Floor(rank)
+	upper := math.Ceil(rank)
+	weight := rank - lower
+	return sorted[int(lower)]*(1-weight) + sorted[int(upper)]*weight
+}
+
+func applyOp(op string, a, b float64) float64 {
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "/":
+		return a / b
+	case "%":
+		return math.Mod(a, b)
+	case "^":
+		return math.Pow(a, b)
+	}
+	panic(errors.Errorf("unknown operator %s", op))
+}
+
+func (ev *evaluator) evalBinary(op string, lhs, rhs value) (value, error) {
+	ls, lScalar := lhs.(scalarValue)
+	rs, rScalar := rhs.(scalarValue)
+	switch {
+	case lScalar && rScalar:
+		res := make(scalarValue, ev.steps)
+		for i := range res {
+			res[i] = applyOp(op, ls[i], rs[i])
+		}
+		return res, nil
+
+	case lScalar || rScalar:
+		vec, _ := rhs.(vectorValue)
+		scalar := ls
+		if rScalar {
+			vec, scalar = lhs.(vectorValue), rs
+		}
+		res := make(vectorValue, len(vec))
+		for i, ss := range vec {
+			out := newStepSeries(ss.labels.without(nameLabel), ev.steps)
+			for j := range ss.vals {
+				if !ss.ok[j] {
+					continue
+				}
+				if rScalar {
+					out.vals[j] = applyOp(op, ss.vals[j], scalar[j])
+				} else {
+					out.vals[j] = applyOp(op, scalar[j], ss.vals[j])
+				}
+				out.ok[j] = true
+			}
+			res[i] = out
+		}
+		return res, nil
+	}
+
+	// Both sides are vectors: match the series with the same labels, other
+	// than the metric name, one-to-one.
+	lv, rv := lhs.(vectorValue), rhs.(vectorValue)
+	rByKey := make(map[string]*stepSeries, len(rv))
+	for _, ss := range rv {
+		k := ss.labels.without(nameLabel).key()
+		if _, ok := rByKey[k]; ok {
+			return nil, &badDataError{errors.Errorf(
+				"many-to-many matching not allowed: found duplicate series on the right side of %q", op)}
+		}
+		rByKey[k] = ss
+	}
+	seen := make(map[string]struct{}, len(lv))
+	var res vectorValue
+	for _, l := range lv {
+		ls := l.labels.without(nameLabel)
+		k := ls.key()
+		if _, ok := seen[k]; ok {
+			return nil, &badDataError{errors.Errorf(
+				"many-to-many matching not allowed: found duplicate series on the left side of %q", op)}
+		}
+		seen[k] = struct{}{}
+		r, ok := rByKey[k]
+		if !ok {
+			continue
+		}
+		out := newStepSeries(ls, ev.steps)
+		for j := range l.vals {
+			if l.ok[j] && r.ok[j] {
+				out.vals[j], out.ok[j] = applyOp(op, l.vals[j], r.vals[j]), true
+			}
+		}
+		res = append(res, out)
+	}
+	return res, nil
+}
+
+// badDataError is an error due to an invalid query.
+type badDataError struct {
+	error
+}
@@ -0,0 +1,305 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package promql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
+)
+
+// testQuerier serves the datapoints of the series of each source, keyed by
+// the name of the series in the database.
+type testQuerier map[string]map[string][]tspb.TimeSeriesDatapoint
+
+func (q testQuerier) Query(
+	_ context.Context, req *tspb.TimeSeriesQueryRequest,
+) (*tspb.TimeSeriesQueryResponse, error) {
+	if req.SampleNanos != fineSampleDuration.Nanoseconds() {
+		return nil, fmt.Errorf("unexpected sample duration %d", req.SampleNanos)
+	}
+	resp := &tspb.TimeSeriesQueryResponse{}
+	for _, query := range req.Queries {
+		var result tspb.TimeSeriesQueryResponse_Result
+		result.Query = query
+		sources := query.Sources
+		if len(sources) == 0 {
+			for source := range q[query.Name] {
+				sources = append(sources, source)
+			}
+			sort.Strings(sources)
+			result.Sources = sources
+		}
+		if len(sources) == 1 {
+			for _, dp := range q[query.Name][sources[0]] {
+				if dp.TimestampNanos >= req.StartNanos && dp.TimestampNanos <= req.EndNanos {
+					result.Datapoints = append(result.Datapoints, dp)
+				}
+			}
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+var testStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// testSeries returns datapoints every 10s for 10m from testStart, with the
+// given value function of the index of the datapoint.
+func testSeries(fn func(i int) float64) []tspb.TimeSeriesDatapoint {
+	var dps []tspb.TimeSeriesDatapoint
+	for i := 0; i < 60; i++ {
+		dps = append(dps, tspb.TimeSeriesDatapoint{
+			TimestampNanos: testStart.Add(time.Duration(i) * 10 * time.Second).UnixNano(),
+			Value:          fn(i),
+		})
+	}
+	return dps
+}
+
+func newTestEngine() *Engine {
+	q := testQuerier{
+		// Counters increasing by 1/s, 2/s and 3/s; the third one is reset in
+		// the middle.
+		"cr.node.sql.query.count": {
+			"1": testSeries(func(i int) float64 { return float64(10 * i) }),
+			"2": testSeries(func(i int) float64 { return float64(20 * i) }),
+			"3": testSeries(func(i int) float64 { return float64(30 * (i % 30)) }),
+		},
+		"cr.store.capacity": {
+			"1": testSeries(func(int) float64 { return 100 }),
+			"2": testSeries(func(int) float64 { return 200 }),
+		},
+		"cr.store.capacity.used": {
+			"1": testSeries(func(int) float64 { return 25 }),
+			"2": testSeries(func(int) float64 { return 50 }),
+		},
+	}
+	names := func() map[string]string {
+		return map[string]string{
+			"sql_query_count":   "cr.node.sql.query.count",
+			"capacity":          "cr.store.capacity",
+			"capacity_used":     "cr.store.capacity.used",
+			"sys_cpu_user_ns":   "cr.node.sys.cpu.user.ns",
+			"unknown_but_named": "cr.node.unknown",
+		}
+	}
+	return NewEngine(q, names)
+}
+
+// formatResult formats the result of an instant query.
+func formatResult(res *result) string {
+	switch v := res.value.(type) {
+	case scalarValue:
+		return formatValue(v[0])
+	case vectorValue:
+		var parts []string
+		for _, ss := range v {
+			if !ss.ok[0] {
+				continue
+			}
+			var ls []string
+			for _, l := range ss.labels {
+				ls = append(ls, fmt.Sprintf("%s=%s", l.name, l.value))
+			}
+			parts = append(parts, fmt.Sprintf("{%s} %s", strings.Join(ls, ","), formatValue(ss.vals[0])))
+		}
+		return strings.Join(parts, "; ")
+	}
+	return fmt.Sprintf("unexpected value %T", res.value)
+}
+
+func TestEngineInstantQuery(t *testing.T) {
+	e := newTestEngine()
+	ts := testStart.Add(5 * time.Minute)
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{`1 + 2 * 3`, `7`},
+		{`2 ^ 3 ^ 2`, `512`},
+		{`sql_query_count{node_id="1"}`, `{__name__=sql_query_count,node_id=1} 300`},
+		{`{__name__="cr.node.sql.query.count", node_id=~"1|2"}`,
+			`{__name__=cr.node.sql.query.count,node_id=1} 300; {__name__=cr.node.sql.query.count,node_id=2} 600`},
+		{`sql_query_count{node_id!="1"} offset 1m`,
+			`{__name__=sql_query_count,node_id=2} 480; {__name__=sql_query_count,node_id=3} 720`},
+		{`rate(sql_query_count[1m])`,
+			`{node_id=1} 1; {node_id=2} 2; {node_id=3} 2.4`},
+		{`irate(sql_query_count{node_id="3"}[1m])`, `{node_id=3} 0`},
+		{`increase(sql_query_count{node_id="1"}[2m])`, `{node_id=1} 120`},
+		{`sum(rate(sql_query_count{node_id!="3"}[1m]))`, `{} 3`},
+		{`sum by (node_id) (rate(sql_query_count{node_id!="3"}[1m]))`, `{node_id=1} 1; {node_id=2} 2`},
+		{`avg(capacity)`, `{} 150`},
+		{`avg without (store) (capacity)`, `{} 150`},
+		{`min(capacity)`, `{} 100`},
+		{`max(capacity)`, `{} 200`},
+		{`count(capacity)`, `{} 2`},
+		{`stddev(capacity)`, `{} 50`},
+		{`quantile(0.5, sql_query_count)`, `{} 300`},
+		{`quantile(0.75, sql_query_count)`, `{} 450`},
+		{`quantile(2, sql_query_count)`, `{} +Inf`},
+		{`max_over_time(sql_query_count{node_id="3"}[5m])`, `{node_id=3} 870`},
+		{`count_over_time(capacity{store="1"}[1m])`, `{store=1} 6`},
+		{`capacity_used / capacity * 100`, `{store=1} 25; {store=2} 25`},
+		{`-capacity{store="1"}`, `{store=1} -100`},
+		{`abs(-capacity{store="1"})`, `{store=1} 100`},
+		{`sys_cpu_user_ns`, ``},
+		{`unknown_metric`, ``},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			res, err := e.query(context.Background(), tc.query, ts, ts, time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := formatResult(res); s != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, s)
+			}
+		})
+	}
+}
+
+// countingQuerier counts the series queries it serves.
+type countingQuerier struct {
+	testQuerier
+	queries int
+}
+
+func (q *countingQuerier) Query(
+	ctx context.Context, req *tspb.TimeSeriesQueryRequest,
+) (*tspb.TimeSeriesQueryResponse, error) {
+	q.queries += len(req.Queries)
+	return q.testQuerier.Query(ctx, req)
+}
+
+func TestEngineSourceQueries(t *testing.T) {
+	q := &countingQuerier{testQuerier: testQuerier{
+		"cr.store.capacity": {
+			"1": testSeries(func(int) float64 { return 100 }),
+			"2": testSeries(func(int) float64 { return 200 }),
+		},
+		"cr.node.sql.conns": {
+			"1": testSeries(func(int) float64 { return 5 }),
+		},
+	}}
+	names := func() map[string]string {
+		return map[string]string{
+			"capacity":  "cr.store.capacity",
+			"sql_conns": "cr.node.sql.conns",
+		}
+	}
+	e := NewEngine(q, names)
+	ts := testStart.Add(5 * time.Minute)
+	testCases := []struct {
+		query    string
+		expected string
+		// queries is the number of series read from the database.
+		queries int
+	}{
+		// The sources selected by value are read directly.
+		{`capacity{store="1"}`, `{__name__=capacity,store=1} 100`, 1},
+		{`capacity{store="3"}`, ``, 1},
+		// Other selectors find the sources first.
+		{`capacity`, `{__name__=capacity,store=1} 100; {__name__=capacity,store=2} 200`, 3},
+		{`capacity{store!="1"}`, `{__name__=capacity,store=2} 200`, 2},
+		// The data of a single source is read along with it.
+		{`sql_conns`, `{__name__=sql_conns,node_id=1} 5`, 1},
+		{`sql_conns{node_id!="1"}`, ``, 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q.queries = 0
+			res, err := e.query(context.Background(), tc.query, ts, ts, time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := formatResult(res); s != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, s)
+			}
+			if q.queries != tc.queries {
+				t.Errorf("expected %d series queries, got %d", tc.queries, q.queries)
+			}
+		})
+	}
+}
+
+func TestEngineRangeQuery(t *testing.T) {
+	e := newTestEngine()
+	res, err := e.query(context.Background(), `rate(sql_query_count{node_id="1"}[30s])`,
+		testStart, testStart.Add(2*time.Minute), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.times) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(res.times))
+	}
+	vec := res.value.(vectorValue)
+	if len(vec) != 1 {
+		t.Fatalf("expected 1 series, got %d", len(vec))
+	}
+	// There is a single sample in the range at the first step.
+	expectedOK := []bool{false, true, true}
+	for i, ok := range vec[0].ok {
+		if ok != expectedOK[i] {
+			t.Errorf("step %d: expected ok=%t, got %t", i, expectedOK[i], ok)
+		}
+		if ok && vec[0].vals[i] != 1 {
+			t.Errorf("step %d: expected 1, got %f", i, vec[0].vals[i])
+		}
+	}
+}
+
+func TestEngineQueryError(t *testing.T) {
+	e := newTestEngine()
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{`sum(`, `unexpected end of input`},
+		{`capacity[5m]`, `must be the argument of a function`},
+		{`sum(1)`, `sum expects an instant vector`},
+		{`quantile(capacity, capacity)`, `parameter of quantile must be a scalar`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := e.query(context.Background(), tc.query, testStart, testStart, time.Second)
+			if !testutils.IsError(err, tc.expected) {
+				t.Errorf("expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	_, err := e.query(context.Background(), `capacity`, testStart, testStart.Add(time.Hour), time.Millisecond)
+	if !testutils.IsError(err, "exceeded maximum resolution") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestAggQuantile(t *testing.T) {
+	vals := []float64{4, 1, 3, 2}
+	for phi, expected := range map[float64]float64{
+		0: 1, 0.5: 2.5, 1: 4, -1: math.Inf(-1),
+	} {
+		if q := aggQuantile(phi, vals); q != expected {
+			t.Errorf("%f: expected %f, got %f", phi, expected, q)
+		}
+	}
+	if !math.IsNaN(aggQuantile(math.NaN(), vals)) {
+		t.Error("expected NaN")
+	}
+}
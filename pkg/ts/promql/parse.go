@@ -0,0 +1,719 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package promql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// expr is a node of the syntax tree of a PromQL expression.
+type expr interface {
+	fmt.Stringer
+}
+
+// numberLiteral is a scalar constant, e.g. 0.5.
+type numberLiteral struct {
+	val float64
+}
+
+// vectorSelector selects the series of a metric, e.g. sql_query_count{node_id="1"}.
+type vectorSelector struct {
+	name     string
+	matchers []*labelMatcher
+	offset   time.Duration
+}
+
+// matrixSelector selects the samples of the series of a metric over a
+// range, e.g. sql_query_count[5m].
+type matrixSelector struct {
+	vectorSelector *vectorSelector
+	rng            time.Duration
+}
+
+// call is a function call, e.g. rate(sql_query_count[5m]).
+type call struct {
+	fn   *function
+	args []expr
+}
+
+// aggregateExpr is an aggregation across series, e.g.
+// sum by (node_id) (sql_query_count).
+type aggregateExpr struct {
+	op       string
+	param    expr
+	expr     expr
+	grouping []string
+	without  bool
+}
+
+// binaryExpr is an arithmetic operation, e.g. a / b.
+type binaryExpr struct {
+	op       string
+	lhs, rhs expr
+}
+
+// unaryExpr is a negation, e.g. -a.
+type unaryExpr struct {
+	expr expr
+}
+
+// parenExpr is a parenthesized expression.
+type parenExpr struct {
+	expr expr
+}
+
+func (e *numberLiteral) String() string {
+	return strconv.FormatFloat(e.val, 'f', -1, 64)
+}
+
+func (e *vectorSelector) String() string {
+	var buf strings.Builder
+	buf.WriteString(e.name)
+	var matchers []string
+	for _, m := range e.matchers {
+		if m.name == nameLabel && e.name != "" {
+			continue
+		}
+		matchers = append(matchers, m.String())
+	}
+	if len(matchers) > 0 || e.name == "" {
+		fmt.Fprintf(&buf, "{%s}", strings.Join(matchers, ","))
+	}
+	if e.offset != 0 {
+		fmt.Fprintf(&buf, " offset %s", formatDuration(e.offset))
+	}
+	return buf.String()
+}
+
+func (e *matrixSelector) String() string {
+	vs := *e.vectorSelector
+	vs.offset = 0
+	s := fmt.Sprintf("%s[%s]", vs.String(), formatDuration(e.rng))
+	if e.vectorSelector.offset != 0 {
+		s += " offset " + formatDuration(e.vectorSelector.offset)
+	}
+	return s
+}
+
+func (e *call) String() string {
+	args := make([]string, len(e.args))
+	for i, a := range e.args {
+		args[i] = a.String()
+	}
+	return fmt.Sprintf("%s(%s)", e.fn.name, strings.Join(args, ", "))
+}
+
+func (e *aggregateExpr) String() string {
+	var buf strings.Builder
+	buf.WriteString(e.op)
+	if e.without {
+		fmt.Fprintf(&buf, " without (%s) ", strings.Join(e.grouping, ", "))
+	} else if len(e.grouping) > 0 {
+		fmt.Fprintf(&buf, " by (%s) ", strings.Join(e.grouping, ", "))
+	}
+	buf.WriteByte('(')
+	if e.param != nil {
+		buf.WriteString(e.param.String())
+		buf.WriteString(", ")
+	}
+	buf.WriteString(e.expr.String())
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+func (e *binaryExpr) String() string {
+	return fmt.Sprintf("%s %s %s", e.lhs, e.op, e.rhs)
+}
+
+func (e *unaryExpr) String() string {
+	return "-" + e.expr.String()
+}
+
+func (e *parenExpr) String() string {
+	return fmt.Sprintf("(%s)", e.expr)
+}
+
+// nameLabel is the label holding the metric name of a series.
+const nameLabel = "__name__"
+
+// matchType is the type of a labelMatcher.
+type matchType string
+
+const (
+	matchEqual     matchType = "="
+	matchNotEqual  matchType = "!="
+	matchRegexp    matchType = "=~"
+	matchNotRegexp matchType = "!~"
+)
+
+// labelMatcher is a condition on the value of a label of the selected
+// series, e.g. node_id=~"1|2".
+type labelMatcher struct {
+	name  string
+	typ   matchType
+	value string
+	re    *regexp.Regexp
+}
+
+func newLabelMatcher(name string, typ matchType, value string) (*labelMatcher, error) {
+	m := &labelMatcher{name: name, typ: typ, value: value}
+	if typ == matchRegexp || typ == matchNotRegexp {
+		// Like in Prometheus, the regular expressions are fully anchored.
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regular expression in label matcher %s%s%q", name, typ, value)
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+// matches returns whether the given label value satisfies the matcher. An
+// absent label has an empty value.
+func (m *labelMatcher) matches(v string) bool {
+	switch m.typ {
+	case matchEqual:
+		return v == m.value
+	case matchNotEqual:
+		return v != m.value
+	case matchRegexp:
+		return m.re.MatchString(v)
+	case matchNotRegexp:
+		return !m.re.MatchString(v)
+	}
+	panic(fmt.Sprintf("unknown match type %s", m.typ))
+}
+
+func (m *labelMatcher) String() string {
+	return fmt.Sprintf("%s%s%q", m.name, m.typ, m.value)
+}
+
+// aggregations are the supported aggregation operators. Those marked true
+// take a parameter.
+var aggregations = map[string]bool{
+	"sum":      false,
+	"avg":      false,
+	"min":      false,
+	"max":      false,
+	"count":    false,
+	"stddev":   false,
+	"quantile": true,
+}
+
+// tokenType is the type of a lexical token.
+type tokenType int
+
+const (
+	tokEOF tokenType = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokDuration
+	tokPunct
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+func (t token) String() string {
+	if t.typ == tokEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", t.val)
+}
+
+// lex splits a PromQL expression into tokens.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(input); {
+		c := rune(input[pos])
+		switch {
+		case unicode.IsSpace(c):
+			pos++
+		case c == '#':
+			// Comment until the end of the line.
+			for pos < len(input) && input[pos] != '\n' {
+				pos++
+			}
+		case c == '"' || c == '\'' || c == '`':
+			end := pos + 1
+			for ; end < len(input) && rune(input[end]) != c; end++ {
+				if input[end] == '\\' && c != '`' {
+					end++
+				}
+			}
+			if end >= len(input) {
+				return nil, errors.Errorf("unterminated string at position %d", pos)
+			}
+			s, err := unquote(input[pos : end+1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid string at position %d", pos)
+			}
+			tokens = append(tokens, token{typ: tokString, val: s, pos: pos})
+			pos = end + 1
+		case isDigit(c) || (c == '.' && pos+1 < len(input) && isDigit(rune(input[pos+1]))):
+			end := pos
+			for end < len(input) && (isAlnum(rune(input[end])) || input[end] == '.') {
+				end++
+			}
+			// Exponents may be signed.
+			if end < len(input) && (input[end] == '+' || input[end] == '-') &&
+				(input[end-1] == 'e' || input[end-1] == 'E') && !strings.HasPrefix(input[pos:], "0x") {
+				end++
+				for end < len(input) && isDigit(rune(input[end])) {
+					end++
+				}
+			}
+			s := input[pos:end]
+			if _, err := strconv.ParseFloat(s, 64); err == nil {
+				tokens = append(tokens, token{typ: tokNumber, val: s, pos: pos})
+			} else if _, err := parseDuration(s); err == nil {
+				tokens = append(tokens, token{typ: tokDuration, val: s, pos: pos})
+			} else {
+				return nil, errors.Errorf("invalid number or duration %q at position %d", s, pos)
+			}
+			pos = end
+		case isAlpha(c) || c == '_' || c == ':':
+			end := pos
+			for end < len(input) && (isAlnum(rune(input[end])) || input[end] == '_' || input[end] == ':') {
+				end++
+			}
+			tokens = append(tokens, token{typ: tokIdent, val: input[pos:end], pos: pos})
+			pos = end
+		default:
+			val := string(c)
+			if pos+1 < len(input) {
+				if two := input[pos : pos+2]; two == "!=" || two == "=~" || two == "!~" {
+					val = two
+				}
+			}
+			if len(val) == 1 && !strings.ContainsRune("(){}[],=+-*/%^", c) {
+				return nil, errors.Errorf("unexpected character %q at position %d", val, pos)
+			}
+			tokens = append(tokens, token{typ: tokPunct, val: val, pos: pos})
+			pos += len(val)
+		}
+	}
+	return append(tokens, token{typ: tokEOF, pos: len(input)}), nil
+}
+
+func isDigit(c rune) bool { return c >= '0' && c <= '9' }
+func isAlpha(c rune) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isAlnum(c rune) bool { return isAlpha(c) || isDigit(c) }
+
+// unquote unquotes a PromQL string literal, which uses the Go escaping rules
+// whatever its quotes.
+func unquote(s string) (string, error) {
+	if s[0] == '\'' {
+		body := strings.Replace(s[1:len(s)-1], `\'`, `'`, -1)
+		body = strings.Replace(body, `"`, `\"`, -1)
+		s = `"` + body + `"`
+	}
+	return strconv.Unquote(s)
+}
+
+// durationRE matches the durations of PromQL, e.g. 1h30m.
+var durationRE = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)w)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?(?:(\d+)ms)?$`)
+
+var durationUnits = []time.Duration{
+	365 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second, time.Millisecond,
+}
+
+// parseDuration parses a PromQL duration.
+func parseDuration(s string) (time.Duration, error) {
+	m := durationRE.FindStringSubmatch(s)
+	if s == "" || m == nil {
+		return 0, errors.Errorf("invalid duration %q", s)
+	}
+	var d time.Duration
+	for i, unit := range durationUnits {
+		if m[i+1] != "" {
+			n, err := strconv.ParseInt(m[i+1], 10, 64)
+			if err != nil {
+				return 0, errors.Wrapf(err, "invalid duration %q", s)
+			}
+			d += time.Duration(n) * unit
+		}
+	}
+	return d, nil
+}
+
+// formatDuration formats a duration in the PromQL format.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	var buf strings.Builder
+	for i, unit := range durationUnits {
+		if n := d / unit; n > 0 {
+			fmt.Fprintf(&buf, "%d%s", n, []string{"y", "w", "d", "h", "m", "s", "ms"}[i])
+			d -= n * unit
+		}
+	}
+	return buf.String()
+}
+
+// parser is a recursive descent parser of PromQL expressions.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseExpr parses a PromQL expression. Only a subset of PromQL is
+// supported: see the package documentation.
+func parseExpr(input string) (expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{tokens: tokens}
+	e, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.typ != tokEOF {
+		return nil, p.unexpected(t, "end of input")
+	}
+	return e, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	return errors.Errorf("unexpected %s at position %d, expected %s", t, t.pos, expected)
+}
+
+func (p *parser) expectPunct(val string) error {
+	if t := p.next(); t.typ != tokPunct || t.val != val {
+		return p.unexpected(t, fmt.Sprintf("%q", val))
+	}
+	return nil
+}
+
+// binaryPrecedence returns the precedence of the given binary operator, or
+// -1 if the token is not a supported binary operator.
+func binaryPrecedence(t token) int {
+	if t.typ != tokPunct {
+		return -1
+	}
+	switch t.val {
+	case "+", "-":
+		return 1
+	case "*", "/", "%":
+		return 2
+	case "^":
+		return 3
+	}
+	return -1
+}
+
+// parseBinary parses a sequence of binary operations whose operators have at
+// least the given precedence.
+func (p *parser) parseBinary(minPrec int) (expr, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		prec := binaryPrecedence(t)
+		if prec < 0 || prec < minPrec {
+			return lhs, nil
+		}
+		p.next()
+		// All operators are left-associative, except ^.
+		nextMin := prec + 1
+		if t.val == "^" {
+			nextMin = prec
+		}
+		rhs, err := p.parseBinary(nextMin)
+		if err != nil {
+			return nil, err
+		}
+		lhs = &binaryExpr{op: t.val, lhs: lhs, rhs: rhs}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if t := p.peek(); t.typ == tokPunct && (t.val == "-" || t.val == "+") {
+		p.next()
+		// Unary operators bind less tightly than ^, like in Prometheus.
+		e, err := p.parseBinary(binaryPrecedence(token{typ: tokPunct, val: "^"}))
+		if err != nil {
+			return nil, err
+		}
+		if t.val == "+" {
+			return e, nil
+		}
+		if n, ok := e.(*numberLiteral); ok {
+			return &numberLiteral{val: -n.val}, nil
+		}
+		return &unaryExpr{expr: e}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.next()
+	switch t.typ {
+	case tokNumber:
+		v, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &numberLiteral{val: v}, nil
+	case tokPunct:
+		switch t.val {
+		case "(":
+			e, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			return &parenExpr{expr: e}, nil
+		case "{":
+			return p.parseSelector("")
+		}
+	case tokIdent:
+		lower := strings.ToLower(t.val)
+		if _, ok := aggregations[lower]; ok {
+			return p.parseAggregation(lower)
+		}
+		if next := p.peek(); next.typ == tokPunct && next.val == "(" {
+			return p.parseCall(t)
+		}
+		switch lower {
+		case "inf":
+			return &numberLiteral{val: posInf}, nil
+		case "nan":
+			return &numberLiteral{val: nan}, nil
+		}
+		if next := p.peek(); next.typ == tokPunct && next.val == "{" {
+			p.next()
+		} else {
+			return p.parseSelectorSuffix(&vectorSelector{name: t.val, matchers: []*labelMatcher{
+				{name: nameLabel, typ: matchEqual, value: t.val},
+			}})
+		}
+		return p.parseSelector(t.val)
+	}
+	return nil, p.unexpected(t, "expression")
+}
+
+// parseSelector parses the label matchers of a vector selector, after the
+// opening brace, and what follows the selector.
+func (p *parser) parseSelector(name string) (expr, error) {
+	vs := &vectorSelector{name: name}
+	if name != "" {
+		vs.matchers = append(vs.matchers, &labelMatcher{name: nameLabel, typ: matchEqual, value: name})
+	}
+	for {
+		t := p.next()
+		if t.typ == tokPunct && t.val == "}" {
+			break
+		}
+		if t.typ != tokIdent {
+			return nil, p.unexpected(t, "label name")
+		}
+		op := p.next()
+		if op.typ != tokPunct || (op.val != "=" && op.val != "!=" && op.val != "=~" && op.val != "!~") {
+			return nil, p.unexpected(op, "label matching operator")
+		}
+		v := p.next()
+		if v.typ != tokString {
+			return nil, p.unexpected(v, "string")
+		}
+		m, err := newLabelMatcher(t.val, matchType(op.val), v.val)
+		if err != nil {
+			return nil, err
+		}
+		if m.name == nameLabel && vs.name != "" {
+			return nil, errors.Errorf("metric name %q specified twice", vs.name)
+		}
+		if m.name == nameLabel && m.typ == matchEqual {
+			vs.name = m.value
+		}
+		vs.matchers = append(vs.matchers, m)
+		if sep := p.peek(); sep.typ == tokPunct && sep.val == "," {
+			p.next()
+		}
+	}
+	if vs.name == "" {
+		return nil, errors.New("vector selector must contain a metric name")
+	}
+	return p.parseSelectorSuffix(vs)
+}
+
+// parseSelectorSuffix parses the optional range and offset of a selector.
+func (p *parser) parseSelectorSuffix(vs *vectorSelector) (expr, error) {
+	var e expr = vs
+	if t := p.peek(); t.typ == tokPunct && t.val == "[" {
+		p.next()
+		d := p.next()
+		if d.typ != tokDuration {
+			return nil, p.unexpected(d, "duration")
+		}
+		rng, err := parseDuration(d.val)
+		if err != nil {
+			return nil, err
+		}
+		if rng <= 0 {
+			return nil, errors.Errorf("range must be positive, got %s", d.val)
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		e = &matrixSelector{vectorSelector: vs, rng: rng}
+	}
+	if t := p.peek(); t.typ == tokIdent && strings.ToLower(t.val) == "offset" {
+		p.next()
+		d := p.next()
+		if d.typ != tokDuration {
+			return nil, p.unexpected(d, "duration")
+		}
+		offset, err := parseDuration(d.val)
+		if err != nil {
+			return nil, err
+		}
+		vs.offset = offset
+	}
+	return e, nil
+}
+
+// parseGrouping parses the label list of a by or without clause.
+func (p *parser) parseGrouping() ([]string, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var labels []string
+	for {
+		t := p.next()
+		if t.typ == tokPunct && t.val == ")" {
+			return labels, nil
+		}
+		if t.typ != tokIdent {
+			return nil, p.unexpected(t, "label name")
+		}
+		labels = append(labels, t.val)
+		if sep := p.peek(); sep.typ == tokPunct && sep.val == "," {
+			p.next()
+		}
+	}
+}
+
+// parseGroupingClause parses an optional by or without clause.
+func (p *parser) parseGroupingClause(agg *aggregateExpr) (bool, error) {
+	t := p.peek()
+	if t.typ != tokIdent {
+		return false, nil
+	}
+	switch strings.ToLower(t.val) {
+	case "by":
+	case "without":
+		agg.without = true
+	default:
+		return false, nil
+	}
+	p.next()
+	var err error
+	agg.grouping, err = p.parseGrouping()
+	return true, err
+}
+
+func (p *parser) parseAggregation(op string) (expr, error) {
+	agg := &aggregateExpr{op: op}
+	hasGrouping, err := p.parseGroupingClause(agg)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	if aggregations[op] {
+		if agg.param, err = p.parseBinary(0); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(","); err != nil {
+			return nil, err
+		}
+	}
+	if agg.expr, err = p.parseBinary(0); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	if !hasGrouping {
+		if _, err := p.parseGroupingClause(agg); err != nil {
+			return nil, err
+		}
+	}
+	return agg, nil
+}
+
+func (p *parser) parseCall(name token) (expr, error) {
+	fn, ok := functions[name.val]
+	if !ok {
+		return nil, errors.Errorf("unknown or unsupported function %q at position %d", name.val, name.pos)
+	}
+	p.next() // (
+	c := &call{fn: fn}
+	for {
+		if t := p.peek(); t.typ == tokPunct && t.val == ")" {
+			p.next()
+			break
+		}
+		if len(c.args) > 0 {
+			if err := p.expectPunct(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		c.args = append(c.args, arg)
+	}
+	if len(c.args) != len(fn.argTypes) {
+		return nil, errors.Errorf("function %s expects %d argument(s), got %d", fn.name, len(fn.argTypes), len(c.args))
+	}
+	for i, arg := range c.args {
+		if _, isMatrix := arg.(*matrixSelector); isMatrix != (fn.argTypes[i] == argMatrix) {
+			if isMatrix {
+				return nil, errors.Errorf("function %s expects an instant vector, got a range vector", fn.name)
+			}
+			return nil, errors.Errorf("function %s expects a range vector, e.g. %s(metric[5m])", fn.name, fn.name)
+		}
+	}
+	return c, nil
+}
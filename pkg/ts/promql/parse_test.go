@@ -0,0 +1,93 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package promql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+)
+
+func TestParseExpr(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{`1.5`, `1.5`},
+		{`sql_query_count`, `sql_query_count`},
+		{`sql_query_count{node_id="1"}`, `sql_query_count{node_id="1"}`},
+		{`sql_query_count{node_id=~"1|2", store!='3'}`, `sql_query_count{node_id=~"1|2",store!="3"}`},
+		{`{__name__="cr.node.sql.query.count"}`, `cr.node.sql.query.count`},
+		{`sql_query_count offset 1h`, `sql_query_count offset 1h`},
+		{`rate(sql_query_count[5m])`, `rate(sql_query_count[5m])`},
+		{`rate(sql_query_count[90s] offset 1d)`, `rate(sql_query_count[1m30s] offset 1d)`},
+		{`sum by (node_id) (rate(sql_query_count[1m]))`, `sum by (node_id) (rate(sql_query_count[1m]))`},
+		{`sum(rate(sql_query_count[1m])) by (node_id)`, `sum by (node_id) (rate(sql_query_count[1m]))`},
+		{`avg without (store) (capacity)`, `avg without (store) (capacity)`},
+		{`quantile(0.9, sys_rss)`, `quantile(0.9, sys_rss)`},
+		{`1 + 2 * 3`, `1 + 2 * 3`},
+		{`(1 + 2) * 3`, `(1 + 2) * 3`},
+		{`2 ^ 3 ^ 2`, `2 ^ 3 ^ 2`},
+		{`-sys_rss / 1024`, `-sys_rss / 1024`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			e, err := parseExpr(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := e.String(); s != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, s)
+			}
+		})
+	}
+
+	// Check the precedence and associativity of the operators.
+	e, err := parseExpr(`1 - 2 - 3 * 4 ^ 2 ^ 3`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := e.(*binaryExpr)
+	if sub.op != "-" || sub.lhs.(*binaryExpr).op != "-" {
+		t.Errorf("expected left-associative subtractions, got %#v", sub)
+	}
+	pow := sub.rhs.(*binaryExpr).rhs.(*binaryExpr)
+	if pow.op != "^" || pow.rhs.(*binaryExpr).op != "^" {
+		t.Errorf("expected right-associative powers, got %#v", pow)
+	}
+}
+
+func TestParseExprError(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{``, `unexpected end of input`},
+		{`sum(`, `unexpected end of input`},
+		{`sql_query_count{node_id="1"`, `unexpected end of input`},
+		{`{node_id="1"}`, `metric name`},
+		{`sql_query_count[5x]`, `duration`},
+		{`unknown(sql_query_count)`, `unknown or unsupported function "unknown"`},
+		{`rate(sql_query_count)`, `function rate expects a range vector`},
+		{`abs(sql_query_count[5m])`, `function abs expects an instant vector`},
+		{`quantile(sys_rss)`, `unexpected`},
+		{`sql_query_count{node_id=~"("}`, `error parsing regexp`},
+		{`sql_query_count and sys_rss`, `unexpected`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := parseExpr(tc.input)
+			if !testutils.IsError(err, tc.expected) {
+				t.Errorf("expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
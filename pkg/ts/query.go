@@ -459,6 +459,11 @@ func (db *DB) Query(
 	if err := verifyDownsampler(query.GetDownsampler()); err != nil {
 		return nil, nil, err
 	}
+	if query.Quantile != nil {
+		if q := *query.Quantile; !(q >= 0 && q <= 1) {
+			return nil, nil, errors.Errorf("quantile %f is not between 0 and 1", q)
+		}
+	}
 
 	// Adjust timespan based on the current time.
 	if err := timespan.adjustForCurrentTime(diskResolution); err != nil {
@@ -551,7 +556,8 @@ func (db *DB) queryChunk(
 
 	var data []client.KeyValue
 	var err error
-	if len(query.Sources) == 0 {
+	if len(query.Sources) == 0 || query.Quantile != nil {
+		// The sources of histogram buckets are filtered when aggregating them.
 		data, err = db.readAllSourcesFromDatabase(ctx, query.Name, diskResolution, diskTimespan)
 	} else {
 		data, err = db.readFromDatabase(ctx, query.Name, diskResolution, diskTimespan, query.Sources)
@@ -570,6 +576,11 @@ func (db *DB) queryChunk(
 		return nil
 	}
 
+	if query.Quantile != nil {
+		// The buckets of histograms hold cumulative counts: the latest count of
+		// a sample period is its maximum.
+		query.Downsampler = tspb.TimeSeriesQueryAggregator_MAX.Enum()
+	}
 	if timespan.SampleDurationNanos != diskResolution.SampleDuration() {
 		downsampleSpans(sourceSpans, timespan.SampleDurationNanos, query.GetDownsampler())
 		// downsampleSpans always produces single-valued spans. At the time of
@@ -584,18 +595,110 @@ func (db *DB) queryChunk(
 	// Aggregate spans, increasing our memory usage if the destination slice is
 	// expanded.
 	oldCap := cap(*dest)
-	aggregateSpansToDatapoints(sourceSpans, query, timespan, mem.InterpolationLimitNanos, dest)
+	if query.Quantile != nil {
+		if err := aggregateHistogramSpansToDatapoints(
+			ctx, sourceSpans, query, timespan, mem.InterpolationLimitNanos, &acc, dest, sourceSet,
+		); err != nil {
+			return err
+		}
+	} else {
+		aggregateSpansToDatapoints(sourceSpans, query, timespan, mem.InterpolationLimitNanos, dest)
+		// Add unique sources to the supplied source set.
+		for k := range sourceSpans {
+			sourceSet[k] = struct{}{}
+		}
+	}
 	if oldCap > cap(*dest) {
 		if err := mem.resultAccount.Grow(ctx, sizeOfDataPoint*int64(cap(*dest)-oldCap)); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	// Add unique sources to the supplied source set.
-	for k := range sourceSpans {
-		sourceSet[k] = struct{}{}
+// aggregateHistogramSpansToDatapoints computes the datapoints of a quantile
+// query from the spans of the buckets of a histogram, keyed by bucket source
+// (see tspb.HistogramBucketSource). The increases of the cumulative count of
+// each bucket during each sample period are summed across the queried
+// sources, and the quantile of the resulting histogram is computed for each
+// sample period.
+func aggregateHistogramSpansToDatapoints(
+	ctx context.Context,
+	spans map[string]timeSeriesSpan,
+	query tspb.Query,
+	timespan QueryTimespan,
+	interpolationLimitNanos int64,
+	acc *mon.BoundAccount,
+	dest *[]tspb.TimeSeriesDatapoint,
+	sourceSet map[string]struct{},
+) error {
+	var querySources map[string]struct{}
+	if len(query.Sources) > 0 {
+		querySources = make(map[string]struct{}, len(query.Sources))
+		for _, source := range query.Sources {
+			querySources[source] = struct{}{}
+		}
+	}
+
+	// Group the spans by bucket.
+	var bucketSpans [tspb.NumHistogramBuckets]map[string]timeSeriesSpan
+	for bucketSource, span := range spans {
+		source, bucket, err := tspb.ParseHistogramBucketSource(bucketSource)
+		if err != nil {
+			return err
+		}
+		if querySources != nil {
+			if _, ok := querySources[source]; !ok {
+				continue
+			}
+		}
+		if bucketSpans[bucket] == nil {
+			bucketSpans[bucket] = make(map[string]timeSeriesSpan)
+		}
+		bucketSpans[bucket][source] = span
+		sourceSet[source] = struct{}{}
+	}
+
+	// Compute the increase of each bucket during each sample period.
+	bucketQuery := query
+	bucketQuery.SourceAggregator = tspb.TimeSeriesQueryAggregator_SUM.Enum()
+	bucketQuery.Derivative = tspb.TimeSeriesQueryDerivative_NON_NEGATIVE_DERIVATIVE.Enum()
+	var bucketDatapoints [tspb.NumHistogramBuckets][]tspb.TimeSeriesDatapoint
+	for bucket, spans := range bucketSpans {
+		if len(spans) == 0 {
+			continue
+		}
+		aggregateSpansToDatapoints(spans, bucketQuery, timespan, interpolationLimitNanos, &bucketDatapoints[bucket])
+		if err := acc.Grow(ctx, sizeOfDataPoint*int64(cap(bucketDatapoints[bucket]))); err != nil {
+			return err
+		}
+	}
+
+	// Merge the buckets of each sample period, in timestamp order.
+	var counts [tspb.NumHistogramBuckets]float64
+	var next [tspb.NumHistogramBuckets]int
+	for {
+		timestamp := int64(math.MaxInt64)
+		for bucket, dps := range bucketDatapoints {
+			if next[bucket] < len(dps) && dps[next[bucket]].TimestampNanos < timestamp {
+				timestamp = dps[next[bucket]].TimestampNanos
+			}
+		}
+		if timestamp == math.MaxInt64 {
+			return nil
+		}
+		for bucket, dps := range bucketDatapoints {
+			counts[bucket] = 0
+			if next[bucket] < len(dps) && dps[next[bucket]].TimestampNanos == timestamp {
+				counts[bucket] = dps[next[bucket]].Value
+				next[bucket]++
+			}
+		}
+		*dest = append(*dest, tspb.TimeSeriesDatapoint{
+			TimestampNanos: timestamp,
+			Value:          tspb.HistogramQuantile(*query.Quantile, counts[:]),
+		})
 	}
-	return nil
 }
 
 // downsampleSpans downsamples the provided timeSeriesSpans in place, without
@@ -698,3 +698,87 @@ func TestQueryRollup(t *testing.T) {
 		query.assertSuccess(13, 2)
 	}
 }
+
+// TestQueryHistogramQuantile verifies the quantiles computed from the buckets
+// of histograms.
+func TestQueryHistogramQuantile(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	tm := newTestModelRunner(t)
+	tm.Start()
+	defer tm.Stop()
+
+	tm.storeTimeSeriesData(resolution1ns, []tspb.TimeSeriesData{
+		tsd("test.histogram", tspb.HistogramBucketSource("1", 4),
+			tsdp(1, 10),
+			tsdp(2, 20),
+			tsdp(3, 30),
+		),
+		tsd("test.histogram", tspb.HistogramBucketSource("1", 8),
+			tsdp(1, 5),
+			tsdp(2, 5),
+			tsdp(3, 25),
+		),
+		tsd("test.histogram", tspb.HistogramBucketSource("2", 8),
+			tsdp(1, 100),
+			tsdp(2, 110),
+			tsdp(3, 110),
+		),
+	})
+
+	// The lower and upper bounds of bucket 8.
+	lower, upper := tspb.HistogramBucketUpperBound(7), tspb.HistogramBucketUpperBound(8)
+	testCases := []struct {
+		sources  []string
+		quantile float64
+		expected []tspb.TimeSeriesDatapoint
+	}{
+		{
+			// At time 2, buckets 4 and 8 both hold 10 values; at time 3, bucket 4
+			// holds 10 values and bucket 8 holds 20 values.
+			quantile: 0.5,
+			expected: []tspb.TimeSeriesDatapoint{
+				tsdp(2, tspb.HistogramBucketUpperBound(4)),
+				tsdp(3, lower+(upper-lower)*0.25),
+			},
+		},
+		{
+			quantile: 1,
+			expected: []tspb.TimeSeriesDatapoint{tsdp(2, upper), tsdp(3, upper)},
+		},
+		{
+			// Node 2 recorded no values at time 3.
+			sources:  []string{"2"},
+			quantile: 0.5,
+			expected: []tspb.TimeSeriesDatapoint{tsdp(2, lower+(upper-lower)*0.5), tsdp(3, 0)},
+		},
+	}
+	for _, tc := range testCases {
+		query := tm.makeQuery("test.histogram", resolution1ns, 0, 3)
+		query.Sources = tc.sources
+		query.Quantile = &tc.quantile
+		datapoints, sources, err := query.queryDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(datapoints) != len(tc.expected) {
+			t.Fatalf("%+v: expected %v, got %v", tc, tc.expected, datapoints)
+		}
+		for i, dp := range datapoints {
+			if e := tc.expected[i]; dp.TimestampNanos != e.TimestampNanos || math.Abs(dp.Value-e.Value) > 1e-9 {
+				t.Errorf("%+v: expected %v, got %v", tc, tc.expected, datapoints)
+			}
+		}
+		expectedSources := len(tc.sources)
+		if expectedSources == 0 {
+			expectedSources = 2
+		}
+		if len(sources) != expectedSources {
+			t.Errorf("%+v: expected %d sources, got %v", tc, expectedSources, sources)
+		}
+	}
+
+	query := tm.makeQuery("test.histogram", resolution1ns, 0, 3)
+	invalid := 2.0
+	query.Quantile = &invalid
+	query.assertError("quantile 2.000000 is not between 0 and 1")
+}
@@ -42,6 +42,9 @@ const (
 	// dumpBatchSize is the number of keys processed in each batch by the dump
 	// command.
 	dumpBatchSize = 100
+	// histogramEstimatedBuckets is the estimated number of non-empty buckets of
+	// a histogram, used to estimate the memory usage of quantile queries.
+	histogramEstimatedBuckets = 16
 )
 
 // ClusterNodeCountFn is a function that returns the number of nodes active on
@@ -244,6 +247,11 @@ func (s *Server) Query(
 					} else {
 						estimatedSourceCount = estimatedClusterNodeCount
 					}
+					if query.Quantile != nil {
+						// Each source of a histogram has a series per non-empty
+						// bucket.
+						estimatedSourceCount *= histogramEstimatedBuckets
+					}
 
 					// Create a memory account for the results of this query.
 					memContexts[queryIdx] = MakeQueryMemoryContext(
@@ -0,0 +1,136 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tspb
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Histograms are stored in the time series database as a single series whose
+// sources hold the count of each bucket of the histogram of a source: the
+// source "1:37" holds the number of values recorded by node 1 in bucket 37.
+// The counts are cumulative since the start of the source, like counters, so
+// that the histogram of the values recorded during any period, at any
+// resolution, is obtained by subtracting the counts at the start of the period
+// from the counts at its end. The histograms of different sources are merged
+// by summing their counts.
+//
+// All histograms share the same exponential bucket layout: the upper bounds
+// of the buckets are the powers of 2^(1/4), from 1 to 2^40, followed by an
+// overflow bucket. The width of a bucket is thus about 19% of its upper bound.
+// Only the buckets which have ever received a value are recorded.
+
+const (
+	// histogramSeriesSuffix is appended to the name of a histogram to form the
+	// name of the series holding its buckets.
+	histogramSeriesSuffix = "-buckets"
+	// histogramBucketSourceSep separates the source and the bucket index in the
+	// sources of the series holding the buckets of a histogram.
+	histogramBucketSourceSep = ":"
+	// histogramBucketsPerDoubling is the number of buckets between successive
+	// powers of two.
+	histogramBucketsPerDoubling = 4
+	// histogramMaxExponent is the exponent of the power of two upper bound of
+	// the last bucket before the overflow bucket.
+	histogramMaxExponent = 40
+)
+
+// NumHistogramBuckets is the number of buckets of the histograms stored in the
+// time series database, including the overflow bucket.
+const NumHistogramBuckets = histogramBucketsPerDoubling*histogramMaxExponent + 2
+
+// HistogramSeriesName returns the name of the series holding the buckets of
+// the histogram with the given name.
+func HistogramSeriesName(name string) string {
+	return name + histogramSeriesSuffix
+}
+
+// HistogramBucketSource returns the source holding the given bucket of the
+// histogram of the given source.
+func HistogramBucketSource(source string, bucket int) string {
+	return source + histogramBucketSourceSep + strconv.Itoa(bucket)
+}
+
+// ParseHistogramBucketSource is the inverse of HistogramBucketSource.
+func ParseHistogramBucketSource(bucketSource string) (source string, bucket int, _ error) {
+	i := strings.LastIndex(bucketSource, histogramBucketSourceSep)
+	if i < 0 {
+		return "", 0, fmt.Errorf("invalid histogram bucket source %q", bucketSource)
+	}
+	bucket, err := strconv.Atoi(bucketSource[i+1:])
+	if err != nil || bucket < 0 || bucket >= NumHistogramBuckets {
+		return "", 0, fmt.Errorf("invalid histogram bucket source %q", bucketSource)
+	}
+	return bucketSource[:i], bucket, nil
+}
+
+// HistogramBucket returns the bucket of the given value.
+func HistogramBucket(v float64) int {
+	if v <= 1 {
+		return 0
+	}
+	b := int(math.Ceil(math.Log2(v) * histogramBucketsPerDoubling))
+	if b >= NumHistogramBuckets {
+		return NumHistogramBuckets - 1
+	}
+	return b
+}
+
+// HistogramBucketUpperBound returns the upper bound of the given bucket.
+func HistogramBucketUpperBound(bucket int) float64 {
+	if bucket >= NumHistogramBuckets-1 {
+		return math.Inf(1)
+	}
+	return math.Exp2(float64(bucket) / histogramBucketsPerDoubling)
+}
+
+// HistogramQuantile returns the given quantile, between 0 and 1, of the
+// histogram with the given bucket counts, assuming that the values are
+// uniformly distributed within each bucket. It returns 0 if the histogram is
+// empty, and the lower bound of the overflow bucket if the quantile falls into
+// it.
+func HistogramQuantile(q float64, counts []float64) float64 {
+	var total float64
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := q * total
+	var cumulative float64
+	for b, c := range counts {
+		if c <= 0 {
+			continue
+		}
+		if cumulative+c < rank && b < len(counts)-1 {
+			cumulative += c
+			continue
+		}
+		var lower float64
+		if b > 0 {
+			lower = HistogramBucketUpperBound(b - 1)
+		}
+		upper := HistogramBucketUpperBound(b)
+		if math.IsInf(upper, 1) {
+			return lower
+		}
+		fraction := (rank - cumulative) / c
+		if fraction > 1 {
+			fraction = 1
+		}
+		return lower + (upper-lower)*fraction
+	}
+	return 0
+}
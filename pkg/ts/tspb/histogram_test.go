@@ -0,0 +1,88 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tspb
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogramBucket(t *testing.T) {
+	testCases := []struct {
+		value  float64
+		bucket int
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 0},
+		{1.1, 1},
+		{2, 4},
+		{10, 14},
+		{1e9, 120},
+		{1e13, NumHistogramBuckets - 1},
+	}
+	for _, tc := range testCases {
+		b := HistogramBucket(tc.value)
+		if b != tc.bucket {
+			t.Errorf("%f: expected bucket %d, got %d", tc.value, tc.bucket, b)
+		}
+		if upper := HistogramBucketUpperBound(b); tc.value > upper {
+			t.Errorf("%f: value above the upper bound %f of its bucket", tc.value, upper)
+		}
+		if b > 0 {
+			if lower := HistogramBucketUpperBound(b - 1); tc.value <= lower {
+				t.Errorf("%f: value below the lower bound %f of its bucket", tc.value, lower)
+			}
+		}
+	}
+}
+
+func TestHistogramBucketSource(t *testing.T) {
+	source, bucket, err := ParseHistogramBucketSource(HistogramBucketSource("1", 37))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source != "1" || bucket != 37 {
+		t.Errorf("expected 1 and 37, got %s and %d", source, bucket)
+	}
+	for _, invalid := range []string{"1", "1:x", "1:-1", "1:1000"} {
+		if _, _, err := ParseHistogramBucketSource(invalid); err == nil {
+			t.Errorf("%q: expected error", invalid)
+		}
+	}
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	counts := make([]float64, NumHistogramBuckets)
+	if q := HistogramQuantile(0.5, counts); q != 0 {
+		t.Errorf("expected 0 for an empty histogram, got %f", q)
+	}
+
+	// 10 values in (2^(3/4), 2], and 30 values in the overflow bucket.
+	counts[4] = 10
+	counts[NumHistogramBuckets-1] = 30
+	lower := HistogramBucketUpperBound(3)
+	testCases := []struct {
+		quantile float64
+		expected float64
+	}{
+		{0, lower},
+		{0.125, lower + (2-lower)*0.5},
+		{0.25, 2},
+		{0.5, math.Exp2(40)},
+		{1, math.Exp2(40)},
+	}
+	for _, tc := range testCases {
+		if q := HistogramQuantile(tc.quantile, counts); math.Abs(q-tc.expected) > 1e-9 {
+			t.Errorf("%f: expected %f, got %f", tc.quantile, tc.expected, q)
+		}
+	}
+}
@@ -46,6 +46,7 @@ import ProblemRanges from "src/views/reports/containers/problemRanges";
 import Range from "src/views/reports/containers/range";
 import ReduxDebug from "src/views/reports/containers/redux";
 import Settings from "src/views/reports/containers/settings";
+import StatementDiagnostics from "src/views/reports/containers/statementDiagnostics";
 import Stores from "src/views/reports/containers/stores";
 import StatementDetails from "src/views/statements/statementDetails";
 import StatementsPage from "src/views/statements/statementsPage";
@@ -153,6 +154,7 @@ export const App: React.FC<AppProps> = (props: AppProps) => {
                   <Route exact path="/reports/nodes" component={ Nodes } />
                   <Route exact path="/reports/nodes/history" component={ ConnectedDecommissionedNodeHistory } />
                   <Route exact path="/reports/settings" component={ Settings } />
+                  <Route exact path="/reports/statements/diagnostics" component={ StatementDiagnostics } />
                   <Route exact path={`/reports/certificates/:${nodeIDAttr}`} component={ Certificates } />
                   <Route exact path={`/reports/range/:${rangeIDAttr}`} component={ Range } />
                   <Route exact path={`/reports/stores/:${nodeIDAttr}`} component={ Stores } />
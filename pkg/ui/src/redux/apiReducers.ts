@@ -253,6 +253,14 @@ const dataDistributionReducerObj = new CachedDataReducer(
 );
 export const refreshDataDistribution = dataDistributionReducerObj.refresh;
 
+const statementDiagnosticsReportsReducerObj = new CachedDataReducer(
+  api.getStatementDiagnosticsReports,
+  "statementDiagnosticsReports",
+  moment.duration(10, "s"),
+);
+export const refreshStatementDiagnosticsReports = statementDiagnosticsReportsReducerObj.refresh;
+export const invalidateStatementDiagnosticsReports = statementDiagnosticsReportsReducerObj.invalidateData;
+
 const metricMetadataReducerObj = new CachedDataReducer(api.getAllMetricMetadata, "metricMetadata");
 export const refreshMetricMetadata = metricMetadataReducerObj.refresh;
 
@@ -282,6 +290,7 @@ export interface APIReducersState {
   stores: KeyedCachedDataReducerState<api.StoresResponseMessage>;
   statements: CachedDataReducerState<api.StatementsResponseMessage>;
   dataDistribution: CachedDataReducerState<api.DataDistributionResponseMessage>;
+  statementDiagnosticsReports: CachedDataReducerState<api.StatementDiagnosticsReportsResponseMessage>;
   metricMetadata: CachedDataReducerState<api.MetricMetadataResponseMessage>;
 }
 
@@ -311,6 +320,7 @@ export const apiReducersReducer = combineReducers<APIReducersState>({
   [storesReducerObj.actionNamespace]: storesReducerObj.reducer,
   [queriesReducerObj.actionNamespace]: queriesReducerObj.reducer,
   [dataDistributionReducerObj.actionNamespace]: dataDistributionReducerObj.reducer,
+  [statementDiagnosticsReportsReducerObj.actionNamespace]: statementDiagnosticsReportsReducerObj.reducer,
   [metricMetadataReducerObj.actionNamespace]: metricMetadataReducerObj.reducer,
 });
 
@@ -13,6 +13,7 @@
  */
 
 import _ from "lodash";
+import Long from "long";
 import moment from "moment";
 
 import * as protos from "src/js/protos";
@@ -107,6 +108,12 @@ export type DataDistributionResponseMessage = protos.cockroach.server.serverpb.D
 export type EnqueueRangeRequestMessage = protos.cockroach.server.serverpb.EnqueueRangeRequest;
 export type EnqueueRangeResponseMessage = protos.cockroach.server.serverpb.EnqueueRangeResponse;
 
+export type StatementDiagnosticsReportsRequestMessage = protos.cockroach.server.serverpb.StatementDiagnosticsReportsRequest;
+export type StatementDiagnosticsReportsResponseMessage = protos.cockroach.server.serverpb.StatementDiagnosticsReportsResponse;
+
+export type CreateStatementDiagnosticsReportRequestMessage = protos.cockroach.server.serverpb.CreateStatementDiagnosticsReportRequest;
+export type CreateStatementDiagnosticsReportResponseMessage = protos.cockroach.server.serverpb.CreateStatementDiagnosticsReportResponse;
+
 export type MetricMetadataRequestMessage = protos.cockroach.server.serverpb.MetricMetadataRequest;
 export type MetricMetadataResponseMessage = protos.cockroach.server.serverpb.MetricMetadataResponse;
 
@@ -358,6 +365,24 @@ export function enqueueRange(req: EnqueueRangeRequestMessage, timeout?: moment.D
   return timeoutFetch(serverpb.EnqueueRangeResponse, `${API_PREFIX}/enqueue_range`, req as any, timeout);
 }
 
+// getStatementDiagnosticsReports lists the statement diagnostics requests and
+// whether a bundle has been collected for them.
+export function getStatementDiagnosticsReports(_req: StatementDiagnosticsReportsRequestMessage, timeout?: moment.Duration): Promise<StatementDiagnosticsReportsResponseMessage> {
+  return timeoutFetch(serverpb.StatementDiagnosticsReportsResponse, `${STATUS_PREFIX}/stmtdiagreports`, null, timeout);
+}
+
+// createStatementDiagnosticsReport requests a diagnostics bundle for the next
+// execution of a statement fingerprint.
+export function createStatementDiagnosticsReport(req: CreateStatementDiagnosticsReportRequestMessage, timeout?: moment.Duration): Promise<CreateStatementDiagnosticsReportResponseMessage> {
+  return timeoutFetch(serverpb.CreateStatementDiagnosticsReportResponse, `${STATUS_PREFIX}/stmtdiagreports`, req as any, timeout);
+}
+
+// statementBundleURL returns the URL from which the zip file of a collected
+// statement diagnostics bundle can be downloaded.
+export function statementBundleURL(statementDiagnosticsID: Long | number): string {
+  return `${API_PREFIX}/stmtbundle/${FixLong(statementDiagnosticsID).toString()}`;
+}
+
 export function getAllMetricMetadata(_req: MetricMetadataRequestMessage = null, timeout?: moment.Duration): Promise<MetricMetadataResponseMessage> {
   return timeoutFetch(serverpb.MetricMetadataResponse, `${API_PREFIX}/metricmetadata`, null, timeout);
 }
@@ -121,6 +121,11 @@ export default function Debug() {
           url="#/data-distribution"
           note="View the distribution of table data across nodes and verify zone configuration."
         />
+        <DebugPanelLink
+          name="Statement Diagnostics"
+          url="#/reports/statements/diagnostics"
+          note="Request and download diagnostics bundles for the next execution of a statement."
+        />
         <PanelTitle>Configuration</PanelTitle>
         <DebugPanelLink
           name="Cluster Settings"
@@ -0,0 +1,191 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+import _ from "lodash";
+import React, { Fragment } from "react";
+import { Helmet } from "react-helmet";
+import { connect } from "react-redux";
+import { withRouter } from "react-router-dom";
+
+import * as protos from "src/js/protos";
+import { invalidateStatementDiagnosticsReports, refreshStatementDiagnosticsReports } from "src/redux/apiReducers";
+import { CachedDataReducerState } from "src/redux/cachedDataReducer";
+import { AdminUIState } from "src/redux/state";
+import { createStatementDiagnosticsReport, statementBundleURL } from "src/util/api";
+import { TimestampToMoment } from "src/util/convert";
+import { DATE_FORMAT } from "src/util/format";
+import Loading from "src/views/shared/components/loading";
+
+import CreateStatementDiagnosticsReportRequest = protos.cockroach.server.serverpb.CreateStatementDiagnosticsReportRequest;
+import StatementDiagnosticsReportsRequest = protos.cockroach.server.serverpb.StatementDiagnosticsReportsRequest;
+import StatementDiagnosticsReportsResponse = protos.cockroach.server.serverpb.StatementDiagnosticsReportsResponse;
+
+interface StatementDiagnosticsOwnProps {
+  reports: CachedDataReducerState<StatementDiagnosticsReportsResponse>;
+  refreshStatementDiagnosticsReports: typeof refreshStatementDiagnosticsReports;
+  invalidateStatementDiagnosticsReports: typeof invalidateStatementDiagnosticsReports;
+}
+
+type StatementDiagnosticsProps = StatementDiagnosticsOwnProps;
+
+interface StatementDiagnosticsState {
+  fingerprint: string;
+  error: Error;
+}
+
+/**
+ * Renders the Statement Diagnostics page, which lists the statement
+ * diagnostics requests, links to the bundles collected for them and allows
+ * requesting a bundle for the next execution of a statement fingerprint.
+ */
+export class StatementDiagnostics extends React.Component<StatementDiagnosticsProps, StatementDiagnosticsState> {
+  state: StatementDiagnosticsState = {
+    fingerprint: "",
+    error: null,
+  };
+
+  refresh(props = this.props) {
+    props.refreshStatementDiagnosticsReports(new StatementDiagnosticsReportsRequest());
+  }
+
+  componentWillMount() {
+    // Refresh the reports query when mounting.
+    this.refresh();
+  }
+
+  componentWillReceiveProps(nextProps: StatementDiagnosticsProps) {
+    // Refresh the reports once they are invalidated, so that newly collected
+    // bundles show up.
+    this.refresh(nextProps);
+  }
+
+  handleUpdateFingerprint = (evt: React.FormEvent<{ value: string }>) => {
+    this.setState({
+      fingerprint: evt.currentTarget.value,
+    });
+  }
+
+  handleSubmit = (evt: React.FormEvent<any>) => {
+    evt.preventDefault();
+
+    const req = new CreateStatementDiagnosticsReportRequest({
+      statement_fingerprint: this.state.fingerprint,
+    });
+    createStatementDiagnosticsReport(req).then(
+      () => {
+        this.setState({ fingerprint: "", error: null });
+        // Show the new request right away instead of on the next poll.
+        this.props.invalidateStatementDiagnosticsReports();
+        this.refresh();
+      },
+      error => {
+        this.setState({ error });
+      },
+    );
+  }
+
+  renderTable() {
+    const { reports } = this.props.reports.data;
+
+    if (_.isEmpty(reports)) {
+      return <p>No statement diagnostics have been requested.</p>;
+    }
+
+    return (
+      <table className="settings-table">
+        <thead>
+          <tr className="settings-table__row settings-table__row--header">
+            <th className="settings-table__cell settings-table__cell--header">Requested At</th>
+            <th className="settings-table__cell settings-table__cell--header">Statement Fingerprint</th>
+            <th className="settings-table__cell settings-table__cell--header">Bundle</th>
+          </tr>
+        </thead>
+        <tbody>
+          {
+            _.orderBy(reports, (report) => TimestampToMoment(report.requested_at).valueOf(), "desc")
+              .map((report) => (
+                <tr key={report.id.toString()} className="settings-table__row">
+                  <td className="settings-table__cell">
+                    {TimestampToMoment(report.requested_at).format(DATE_FORMAT)}
+                  </td>
+                  <td className="settings-table__cell">
+                    <pre>{report.statement_fingerprint}</pre>
+                  </td>
+                  <td className="settings-table__cell">
+                    {report.completed
+                      ? <a href={statementBundleURL(report.statement_diagnostics_id)}>Download</a>
+                      : "Waiting for the next execution"}
+                  </td>
+                </tr>
+              ))
+          }
+        </tbody>
+      </table>
+    );
+  }
+
+  renderError() {
+    const { error } = this.state;
+
+    if (!error) {
+      return null;
+    }
+
+    return (
+      <Fragment>Error requesting statement diagnostics: { error.message }</Fragment>
+    );
+  }
+
+  render() {
+    return (
+      <div className="section">
+        <Helmet title="Statement Diagnostics | Debug" />
+        <h1 className="base-heading">Statement Diagnostics</h1>
+        <form onSubmit={this.handleSubmit} className="form-internal" method="post">
+          <label>
+            Statement fingerprint:{" "}
+            <input
+              type="text"
+              name="fingerprint"
+              className="input-text"
+              onChange={this.handleUpdateFingerprint}
+              value={this.state.fingerprint}
+              placeholder="SELECT v FROM t WHERE k = _"
+            />
+          </label>
+          {" "}
+          <input
+            type="submit"
+            className="submit-button"
+            value="Request Diagnostics"
+          />
+        </form>
+        {this.renderError()}
+        <br />
+        <Loading
+          loading={!this.props.reports.data}
+          error={this.props.reports.lastError}
+          render={() => this.renderTable()}
+        />
+      </div>
+    );
+  }
+}
+
+const mapStateToProps = (state: AdminUIState) => ({
+  reports: state.cachedData.statementDiagnosticsReports,
+});
+
+const mapDispatchToProps = {
+  refreshStatementDiagnosticsReports,
+  invalidateStatementDiagnosticsReports,
+};
+
+export default withRouter(connect(mapStateToProps, mapDispatchToProps)(StatementDiagnostics));
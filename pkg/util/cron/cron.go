@@ -0,0 +1,218 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package cron parses and evaluates cron expressions, which describe
+// recurring points in time with a resolution of one minute.
+//
+// An expression consists of five space-separated fields:
+//
+//   minute (0-59) hour (0-23) day-of-month (1-31) month (1-12) day-of-week (0-7)
+//
+// Each field is either "*", or a comma-separated list of values and
+// hyphen-separated ranges, optionally followed by a "/step". Months and days
+// of the week may also be given by their three-letter English names, and both
+// 0 and 7 denote Sunday. As in traditional cron implementations, when both the
+// day-of-month and the day-of-week fields are restricted, a day matches when
+// either of them matches.
+//
+// The following macros are accepted as well: @yearly (or @annually),
+// @monthly, @weekly, @daily (or @midnight) and @hourly.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are set when the respective field is "*" (possibly
+	// with a step), in which case the other day field alone determines the
+	// matching days.
+	domStar, dowStar bool
+}
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day-of-month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}}
+	dowField = field{name: "day-of-week", min: 0, max: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}}
+)
+
+// Parse parses a cron expression.
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "@") {
+		m, ok := macros[strings.ToLower(spec)]
+		if !ok {
+			return nil, errors.Newf("unknown cron macro %q", spec)
+		}
+		spec = m
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Newf(
+			"cron expression %q must have 5 fields, found %d", expr, len(fields))
+	}
+
+	var s Schedule
+	var err error
+	if s.minute, _, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, _, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, s.domStar, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, _, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, s.dowStar, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	// Sunday may be specified either as 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return &s, nil
+}
+
+// parse returns the set of values matched by the field as a bitmask, and
+// whether the field is a wildcard.
+func (f field) parse(spec string) (uint64, bool, error) {
+	var bits uint64
+	star := false
+	for _, part := range strings.Split(spec, ",") {
+		rangeSpec, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			var err error
+			rangeSpec = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, false, errors.Newf("invalid step in %s field %q", f.name, part)
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rangeSpec == "*":
+			lo, hi = f.min, f.max
+			star = star || len(spec) == len(part)
+		case strings.IndexByte(rangeSpec, '-') >= 0:
+			i := strings.IndexByte(rangeSpec, '-')
+			var err error
+			if lo, err = f.value(rangeSpec[:i]); err != nil {
+				return 0, false, err
+			}
+			if hi, err = f.value(rangeSpec[i+1:]); err != nil {
+				return 0, false, err
+			}
+			if lo > hi {
+				return 0, false, errors.Newf("invalid range in %s field %q", f.name, part)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rangeSpec); err != nil {
+				return 0, false, err
+			}
+			hi = lo
+			if step != 1 {
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, star, nil
+}
+
+func (f field) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.Newf("invalid value %q for %s field, expected a value in [%d, %d]",
+			s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// maxSearchYears bounds the search for the next matching time. An expression
+// such as "0 0 30 2 *" never matches.
+const maxSearchYears = 5
+
+// Next returns the earliest time strictly after t matched by the schedule,
+// in t's location. The zero time is returned if the schedule never matches.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	loc := t.Location()
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
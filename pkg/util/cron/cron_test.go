@@ -0,0 +1,98 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+)
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		expr string
+		err  string
+	}{
+		{"", "must have 5 fields"},
+		{"* * * *", "must have 5 fields"},
+		{"@fortnightly", "unknown cron macro"},
+		{"60 * * * *", "invalid value \"60\" for minute field"},
+		{"* 24 * * *", "invalid value \"24\" for hour field"},
+		{"* * 0 * *", "invalid value \"0\" for day-of-month field"},
+		{"* * * 13 *", "invalid value \"13\" for month field"},
+		{"* * * * 8", "invalid value \"8\" for day-of-week field"},
+		{"5-1 * * * *", "invalid range in minute field"},
+		{"*/0 * * * *", "invalid step in minute field"},
+		{"* * * foo *", "invalid value \"foo\" for month field"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := Parse(tc.expr)
+			if !testutils.IsError(err, tc.err) {
+				t.Fatalf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	mustParse := func(s string) time.Time {
+		ts, err := time.Parse("2006-01-02 15:04 Mon", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+
+	testCases := []struct {
+		expr     string
+		from     string
+		expected string
+	}{
+		{"* * * * *", "2020-01-01 00:00 Wed", "2020-01-01 00:01 Wed"},
+		{"@hourly", "2020-01-01 00:00 Wed", "2020-01-01 01:00 Wed"},
+		{"@daily", "2020-01-01 00:00 Wed", "2020-01-02 00:00 Thu"},
+		{"@daily", "2020-01-01 23:59 Wed", "2020-01-02 00:00 Thu"},
+		{"@weekly", "2020-01-01 12:00 Wed", "2020-01-05 00:00 Sun"},
+		{"@monthly", "2020-01-31 12:00 Fri", "2020-02-01 00:00 Sat"},
+		{"@yearly", "2020-06-01 00:00 Mon", "2021-01-01 00:00 Fri"},
+		{"30 2 * * *", "2020-01-01 02:30 Wed", "2020-01-02 02:30 Thu"},
+		{"*/15 * * * *", "2020-01-01 00:16 Wed", "2020-01-01 00:30 Wed"},
+		{"0 9-17/4 * * *", "2020-01-01 14:00 Wed", "2020-01-01 17:00 Wed"},
+		{"0 0 * * mon-fri", "2020-01-03 12:00 Fri", "2020-01-06 00:00 Mon"},
+		{"0 0 * * 7", "2020-01-01 00:00 Wed", "2020-01-05 00:00 Sun"},
+		{"0 0 29 feb *", "2020-03-01 00:00 Sun", "2024-02-29 00:00 Thu"},
+		{"0 0 31 * *", "2020-04-01 00:00 Wed", "2020-05-31 00:00 Sun"},
+		// Day of month and day of week are or'ed when both are restricted.
+		{"0 0 15 * fri", "2020-01-01 00:00 Wed", "2020-01-03 00:00 Fri"},
+		{"0 0 15 * fri", "2020-01-10 00:00 Fri", "2020-01-15 00:00 Wed"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr+"/"+tc.from, func(t *testing.T) {
+			s, err := Parse(tc.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next, expected := s.Next(mustParse(tc.from)), mustParse(tc.expected); !next.Equal(expected) {
+				t.Fatalf("expected %s, got %s", expected, next)
+			}
+		})
+	}
+
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := s.Next(mustParse("2020-01-01 00:00 Wed")); !next.IsZero() {
+		t.Fatalf("expected schedule to never match, got %s", next)
+	}
+}
@@ -49,6 +49,16 @@ type loggingT struct {
 	// facilities.
 	vmoduleConfig vmoduleConfig
 
+	// format is the format of the log entries. Handled atomically.
+	format entryFormat
+
+	// redactableLogs, when set, encloses the unsafe values of the log
+	// messages in redaction markers. Set during initialization.
+	redactableLogs bool
+
+	// sinks holds the log sinks configured per channel.
+	sinks sinkConfig
+
 	// mu protects the remaining elements of this structure and is
 	// used to synchronize logging.
 	// mu should be held only for short periods of time and
@@ -81,6 +91,10 @@ type loggerT struct {
 	// Name prefix for log files.
 	prefix string
 
+	// channel is the name of the logger in the log sink configuration and
+	// in the JSON log format.
+	channel string
+
 	// Level flag for output to files.
 	fileThreshold Severity
 
@@ -113,6 +127,7 @@ func init() {
 	// commands set their default separately in cli/flags.go.
 	logging.stderrThreshold = Severity_INFO
 	mainLog.prefix = program
+	mainLog.channel = mainChannel
 	mainLog.fileThreshold = Severity_INFO
 }
 
@@ -190,6 +205,7 @@ func (l *loggerT) outputLogEntry(s Severity, file string, line int, msg string)
 	// Set additional details in log entry.
 	now := timeutil.Now()
 	entry := MakeEntry(s, now.UnixNano(), file, line, msg)
+	entry.Redactable = logging.redactableLogs
 
 	if f, ok := logging.interceptor.Load().(InterceptorFn); ok && f != nil {
 		f(entry)
@@ -251,31 +267,40 @@ func (l *loggerT) outputLogEntry(s Severity, file string, line int, msg string)
 		}()
 	}
 
-	if s >= logging.stderrThreshold.get() || (s == Severity_FATAL && l.stderrRedirected()) {
-		// We force-copy FATAL messages to stderr, because the process is bound
-		// to terminate and the user will want to know why.
-		l.outputToStderr(entry, stacks)
-	}
-	if l.logDir.IsSet() && s >= l.fileThreshold.get() {
-		if err := l.ensureFile(); err != nil {
-			// Make sure the message appears somewhere.
+	if sinks := logging.sinks.forChannel(l.channel); sinks == nil {
+		if s >= logging.stderrThreshold.get() || (s == Severity_FATAL && l.stderrRedirected()) {
+			// We force-copy FATAL messages to stderr, because the process is bound
+			// to terminate and the user will want to know why.
 			l.outputToStderr(entry, stacks)
-			l.exitLocked(err)
-			l.mu.Unlock()
-			return
 		}
-
-		buf := logging.processForFile(entry, stacks)
-		data := buf.Bytes()
-
-		if err := l.writeToFile(data); err != nil {
-			l.exitLocked(err)
-			l.mu.Unlock()
-			putBuffer(buf)
-			return
+		if l.logDir.IsSet() && s >= l.fileThreshold.get() {
+			if !l.outputToFile(entry, stacks) {
+				l.mu.Unlock()
+				return
+			}
+		}
+	} else {
+		if s == Severity_FATAL && l.stderrRedirected() && !sinks.stderr {
+			l.outputToStderr(entry, stacks)
+		}
+		if s >= l.fileThreshold.get() {
+			if sinks.stderr {
+				l.outputToStderr(entry, stacks)
+			}
+			if sinks.file && l.logDir.IsSet() {
+				if !l.outputToFile(entry, stacks) {
+					l.mu.Unlock()
+					return
+				}
+			}
+			if len(sinks.others) > 0 {
+				buf := l.processForFile(entry, stacks)
+				for _, sink := range sinks.others {
+					sink.output(l.channel, entry, stacks, buf.Bytes())
+				}
+				putBuffer(buf)
+			}
 		}
-
-		putBuffer(buf)
 	}
 	// Flush and exit on fatal logging.
 	if s == Severity_FATAL {
@@ -319,8 +344,28 @@ func (l *loggerT) printPanicToFile(r interface{}) {
 	}
 }
 
+// outputToFile writes the entry to the logger's current log file. It
+// returns false if the write failed, after the process was told to exit.
+// l.mu is held.
+func (l *loggerT) outputToFile(entry Entry, stacks []byte) bool {
+	if err := l.ensureFile(); err != nil {
+		// Make sure the message appears somewhere.
+		l.outputToStderr(entry, stacks)
+		l.exitLocked(err)
+		return false
+	}
+
+	buf := l.processForFile(entry, stacks)
+	defer putBuffer(buf)
+	if err := l.writeToFile(buf.Bytes()); err != nil {
+		l.exitLocked(err)
+		return false
+	}
+	return true
+}
+
 func (l *loggerT) outputToStderr(entry Entry, stacks []byte) {
-	buf := logging.processForStderr(entry, stacks)
+	buf := l.processForStderr(entry, stacks)
 	_, err := OrigStderr.Write(buf.Bytes())
 	putBuffer(buf)
 	if err != nil {
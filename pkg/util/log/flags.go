@@ -29,4 +29,11 @@ func init() {
 		logflags.LogToStderrName, "logs at or above this threshold go to stderr")
 	flag.Var(&mainLog.fileThreshold,
 		logflags.LogFileVerbosityThresholdName, "minimum verbosity of messages written to the log file")
+	// These are defined here because they configure unexported state.
+	flag.Var(&logging.format,
+		logflags.LogFormatName, "format of log entries: crdb-v1 or json")
+	flag.Var(&logging.sinks,
+		logflags.LogSinkName, "[<channel>,...=]<sink> destination of log entries; can be repeated")
+	flag.BoolVar(&logging.redactableLogs,
+		logflags.RedactableLogsName, false, "enclose the values that may contain user data in redaction markers")
 }
@@ -0,0 +1,176 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// entryFormat is the format of the log entries emitted to the log files,
+// to stderr and to the log sinks. It is configured with the --log-format
+// flag.
+type entryFormat int32
+
+const (
+	// formatCrdbV1 is the traditional format of log entries, inherited
+	// from glog. See formatHeader() for details.
+	formatCrdbV1 entryFormat = iota
+	// formatJSON emits every log entry as a JSON object on a single line.
+	// See jsonEntry for details.
+	formatJSON
+)
+
+var entryFormatNames = map[entryFormat]string{
+	formatCrdbV1: "crdb-v1",
+	formatJSON:   "json",
+}
+
+// get returns the value of the entryFormat.
+func (f *entryFormat) get() entryFormat {
+	return entryFormat(atomic.LoadInt32((*int32)(f)))
+}
+
+// set sets the value of the entryFormat.
+func (f *entryFormat) set(val entryFormat) {
+	atomic.StoreInt32((*int32)(f), int32(val))
+}
+
+// Set is part of the flag.Value interface.
+func (f *entryFormat) Set(value string) error {
+	for format, name := range entryFormatNames {
+		if name == value {
+			f.set(format)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown log format: %q", value)
+}
+
+// String is part of the flag.Value interface.
+func (f *entryFormat) String() string {
+	return entryFormatNames[f.get()]
+}
+
+// Type is part of the flag.Value interface.
+func (f *entryFormat) Type() string {
+	return "string"
+}
+
+// jsonEntry is the representation of a log entry in the JSON format. Log
+// pipelines can rely on the field names, which are part of the public
+// interface of the logging system.
+type jsonEntry struct {
+	// Severity is the name of the entry's severity, e.g. INFO.
+	Severity string `json:"severity"`
+	// Time is the time of the entry in nanoseconds since the epoch.
+	Time int64 `json:"time"`
+	// Timestamp is Time formatted as RFC3339 with nanoseconds, in UTC.
+	Timestamp string `json:"timestamp"`
+	Goroutine int64  `json:"goroutine,omitempty"`
+	File      string `json:"file"`
+	Line      int64  `json:"line"`
+	// Channel is the logger which emitted the entry: main for the main
+	// logger, or the name of a secondary logger (e.g. sql-audit).
+	Channel string `json:"channel,omitempty"`
+	Message string `json:"message"`
+	// Stacks holds the goroutine stacks dumped on fatal errors.
+	Stacks string `json:"stacks,omitempty"`
+	// Redactable is set if the unsafe values of the message are enclosed in
+	// redaction markers.
+	Redactable bool `json:"redactable,omitempty"`
+}
+
+func makeJSONEntry(channel string, entry Entry, stacks []byte) jsonEntry {
+	return jsonEntry{
+		Severity:   entry.Severity.String(),
+		Time:       entry.Time,
+		Timestamp:  timeutil.Unix(0, entry.Time).UTC().Format(time.RFC3339Nano),
+		Goroutine:  entry.Goroutine,
+		File:       entry.File,
+		Line:       entry.Line,
+		Channel:    channel,
+		Message:    strings.TrimSuffix(entry.Message, "\n"),
+		Stacks:     string(stacks),
+		Redactable: entry.Redactable,
+	}
+}
+
+// formatJSONEntry formats an Entry as a single line JSON object into a newly
+// allocated *buffer. The caller is responsible for calling putBuffer()
+// afterwards.
+func formatJSONEntry(channel string, entry Entry, stacks []byte) *buffer {
+	buf := getBuffer()
+	// Encode() terminates the object with a newline, which is the entry
+	// separator. Marshaling this struct cannot fail.
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(makeJSONEntry(channel, entry, stacks))
+	return buf
+}
+
+// decodeJSON decodes the next JSON log entry into the provided protobuf
+// message. Lines that are not JSON log entries, like the panics written
+// directly to the log files, are skipped.
+func (d *EntryDecoder) decodeJSON(entry *Entry) error {
+	for {
+		if !d.scanner.Scan() {
+			if err := d.scanner.Err(); err != nil {
+				return err
+			}
+			return io.EOF
+		}
+		var e jsonEntry
+		if err := json.Unmarshal(d.scanner.Bytes(), &e); err != nil || e.Time == 0 {
+			continue
+		}
+		sev, ok := SeverityByName(e.Severity)
+		if !ok {
+			sev = Severity_UNKNOWN
+		}
+		*entry = Entry{
+			Severity:   sev,
+			Time:       e.Time,
+			Goroutine:  e.Goroutine,
+			File:       e.File,
+			Line:       e.Line,
+			Message:    e.Message,
+			Redactable: e.Redactable,
+		}
+		return nil
+	}
+}
+
+// isJSONLog peeks at the start of the given reader to determine whether it
+// contains log entries in the JSON format.
+func isJSONLog(r *bufio.Reader) bool {
+	for i := 1; ; i++ {
+		b, err := r.Peek(i)
+		if err != nil {
+			return false
+		}
+		switch b[i-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/kr/pretty"
+)
+
+func TestJSONEntryDecoder(t *testing.T) {
+	t1 := timeutil.Now().Round(time.Microsecond)
+	t2 := t1.Add(time.Microsecond)
+	t3 := t2.Add(time.Microsecond)
+
+	expected := []Entry{
+		{Severity: Severity_INFO, Time: t1.UnixNano(), Goroutine: 1, File: "format_json_test.go", Line: 10, Message: "info"},
+		{Severity: Severity_WARNING, Time: t2.UnixNano(), Goroutine: 2, File: "format_json_test.go", Line: 11, Message: "multi-\nline <html> \"quoted\""},
+		{Severity: Severity_FATAL, Time: t3.UnixNano(), Goroutine: 3, File: "format_json_test.go", Line: 12, Message: "fatal"},
+	}
+
+	var contents strings.Builder
+	// Leading blank lines don't prevent the detection of the format.
+	contents.WriteString("\n")
+	for i, e := range expected {
+		var stacks []byte
+		if e.Severity == Severity_FATAL {
+			stacks = []byte("goroutine 1 [running]:\n")
+		}
+		// The message is stored without the newline appended by MakeEntry.
+		e.Message += "\n"
+		buf := formatJSONEntry(mainChannel, e, stacks)
+		contents.Write(buf.Bytes())
+		putBuffer(buf)
+		if i == 0 {
+			// Lines that are not log entries, e.g. panics written directly to
+			// the log file, are skipped.
+			contents.WriteString("panic: boom\n")
+		}
+	}
+
+	decoder := NewEntryDecoder(strings.NewReader(contents.String()))
+	var entries []Entry
+	for {
+		var entry Entry
+		if err := decoder.Decode(&entry); err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if !reflect.DeepEqual(expected, entries) {
+		t.Fatalf("%s\n", strings.Join(pretty.Diff(expected, entries), "\n"))
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	s := ScopeWithoutShowLogs(t)
+	defer s.Close(t)
+	setFlags()
+	defer mainLog.swap(mainLog.newBuffers())
+	defer logging.format.set(logging.format.get())
+	if err := logging.format.Set("json"); err != nil {
+		t.Fatal(err)
+	}
+
+	Info(context.Background(), "hello\tworld")
+
+	var e jsonEntry
+	if err := json.Unmarshal([]byte(contents()), &e); err != nil {
+		t.Fatalf("%v: %q", err, contents())
+	}
+	if e.Severity != "INFO" || e.Message != "hello\tworld" || e.Channel != mainChannel ||
+		e.File != "util/log/format_json_test.go" || e.Time == 0 || e.Timestamp == "" {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, e.Timestamp); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := logging.format.Set("xml"); err == nil || !strings.Contains(err.Error(), "unknown log format") {
+		t.Fatalf("expected error, got %v", err)
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 func init() {
 	errors.SetWarningFn(Warningf)
+	tracing.SetWarningFn(Warningf)
 }
 
 // FatalOnPanic recovers from a panic and exits the process with a
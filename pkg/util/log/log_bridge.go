@@ -71,6 +71,6 @@ func (lb logBridge) Write(b []byte) (n int, err error) {
 			line = 1
 		}
 	}
-	mainLog.outputLogEntry(Severity(lb), file, line, text)
+	mainLog.outputLogEntry(Severity(lb), file, line, unsafeMessage(text))
 	return len(b), nil
 }
@@ -31,6 +31,12 @@ var noColor bool
 func (l *loggingT) formatLogEntry(entry Entry, stacks []byte, cp ttycolor.Profile) *buffer {
 	buf := l.formatHeader(entry.Severity, timeutil.Unix(0, entry.Time),
 		int(entry.Goroutine), entry.File, int(entry.Line), cp)
+	if entry.Redactable {
+		// Replace the trailing space of the header, so that the indicator
+		// directly follows the file and line number.
+		buf.Truncate(buf.Len() - 1)
+		_, _ = buf.WriteString(redactableIndicator + " ")
+	}
 	_, _ = buf.WriteString(entry.Message)
 	if buf.Bytes()[buf.Len()-1] != '\n' {
 		_ = buf.WriteByte('\n')
@@ -134,13 +140,23 @@ func (l *loggingT) formatHeader(
 }
 
 // processForStderr formats a log entry for output to standard error.
-func (l *loggingT) processForStderr(entry Entry, stacks []byte) *buffer {
-	return l.formatLogEntry(entry, stacks, ttycolor.StderrProfile)
+func (l *loggerT) processForStderr(entry Entry, stacks []byte) *buffer {
+	return l.formatEntry(entry, stacks, ttycolor.StderrProfile)
+}
+
+// processForFile formats a log entry for output to a file or a log sink.
+func (l *loggerT) processForFile(entry Entry, stacks []byte) *buffer {
+	return l.formatEntry(entry, stacks, nil)
 }
 
-// processForFile formats a log entry for output to a file.
-func (l *loggingT) processForFile(entry Entry, stacks []byte) *buffer {
-	return l.formatLogEntry(entry, stacks, nil)
+// formatEntry formats a log entry in the configured log format into a newly
+// allocated *buffer. The color profile is only used by the crdb-v1 format.
+// The caller is responsible for calling putBuffer() afterwards.
+func (l *loggerT) formatEntry(entry Entry, stacks []byte, cp ttycolor.Profile) *buffer {
+	if logging.format.get() == formatJSON {
+		return formatJSONEntry(l.channel, entry, stacks)
+	}
+	return logging.formatLogEntry(entry, stacks, cp)
 }
 
 // MakeEntry creates an Entry.
@@ -176,21 +192,37 @@ type EntryDecoder struct {
 	re                 *regexp.Regexp
 	scanner            *bufio.Scanner
 	truncatedLastEntry bool
+	// json is set if the entries are in the JSON format.
+	json bool
 }
 
-// NewEntryDecoder creates a new instance of EntryDecoder.
+// NewEntryDecoder creates a new instance of EntryDecoder. The format of the
+// log entries, crdb-v1 or JSON, is detected from the start of the input.
 func NewEntryDecoder(in io.Reader) *EntryDecoder {
-	d := &EntryDecoder{scanner: bufio.NewScanner(in), re: entryRE}
-	d.scanner.Split(d.split)
+	br := bufio.NewReader(in)
+	d := &EntryDecoder{scanner: bufio.NewScanner(br), re: entryRE}
+	if isJSONLog(br) {
+		d.json = true
+		d.scanner.Buffer(nil, maxJSONEntrySize)
+	} else {
+		d.scanner.Split(d.split)
+	}
 	return d
 }
 
+// maxJSONEntrySize is the size of the largest JSON log entry that can be
+// decoded. It leaves room for the goroutine dumps of fatal errors.
+const maxJSONEntrySize = 16 << 20
+
 // MessageTimeFormat is the format of the timestamp in log message headers as
 // used in time.Parse and time.Format.
 const MessageTimeFormat = "060102 15:04:05.999999"
 
 // Decode decodes the next log entry into the provided protobuf message.
 func (d *EntryDecoder) Decode(entry *Entry) error {
+	if d.json {
+		return d.decodeJSON(entry)
+	}
 	for {
 		if !d.scanner.Scan() {
 			if err := d.scanner.Err(); err != nil {
@@ -223,6 +255,10 @@ func (d *EntryDecoder) Decode(entry *Entry) error {
 		}
 		entry.Line = int64(line)
 		entry.Message = strings.TrimSpace(string(b[len(m[0]):]))
+		entry.Redactable = strings.HasPrefix(entry.Message, redactableIndicator)
+		if entry.Redactable {
+			entry.Message = strings.TrimSpace(entry.Message[len(redactableIndicator):])
+		}
 		return nil
 	}
 }
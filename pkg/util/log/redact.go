@@ -0,0 +1,218 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/logtags"
+)
+
+// Redactable log entries enclose the values that may contain user data,
+// for instance keys or SQL constants, between redaction markers:
+//
+//   I200101 00:00:00.000000 1 file.go:10 ⋮ [n1] found key ‹/Table/53/1/"foo"›
+//
+// The ⋮ after the file and line number indicates that the entry is
+// redactable; the markers are stored in the log files, and removed when
+// the logs are redacted (e.g. in `debug zip --redact-logs`) or read by
+// a human (e.g. in `debug merge-logs`).
+//
+// Log messages are formatted from a trusted format string, considered to be
+// safe, and arguments which are unsafe unless they implement SafeValue or
+// SafeMessager (see Safe()). Redaction markers are only added when
+// redactable logs are enabled with the --redactable-logs flag.
+const (
+	startRedactable = "‹"
+	endRedactable   = "›"
+	// escapeMark replaces the redaction markers found in unsafe values, so
+	// that they can't confuse the redaction.
+	escapeMark = "?"
+	// redactedMarker replaces the unsafe values of redacted entries.
+	redactedMarker = startRedactable + "×" + endRedactable
+	// redactableIndicator follows the header of redactable entries in the
+	// crdb-v1 format.
+	redactableIndicator = "⋮"
+)
+
+// SafeValue is implemented by the types whose values are known not to
+// contain user data, like node or range IDs. They're not enclosed in
+// redaction markers in redactable log entries.
+type SafeValue interface {
+	SafeValue()
+}
+
+var markersEscaper = strings.NewReplacer(startRedactable, escapeMark, endRedactable, escapeMark)
+
+var markersStripper = strings.NewReplacer(startRedactable, "", endRedactable, "")
+
+var unsafeValueRE = regexp.MustCompile(startRedactable + `[^` + startRedactable + endRedactable + `]*` + endRedactable)
+
+// TestingSetRedactable sets the redactable flag of the logging package, and
+// returns a function restoring the previous value.
+func TestingSetRedactable(redactable bool) (cleanup func()) {
+	prev := logging.redactableLogs
+	logging.redactableLogs = redactable
+	return func() { logging.redactableLogs = prev }
+}
+
+// isSafe returns whether the given formatting argument is known not to
+// contain user data.
+func isSafe(arg interface{}) bool {
+	switch arg.(type) {
+	case SafeValue, SafeMessager:
+		return true
+	}
+	return false
+}
+
+// unsafeArg wraps the formatting arguments which may contain user data,
+// so that their formatted value is enclosed in redaction markers.
+type unsafeArg struct {
+	v interface{}
+}
+
+// Format implements fmt.Formatter.
+func (a unsafeArg) Format(s fmt.State, verb rune) {
+	formatted := fmt.Sprintf(reproduceFormat(s, verb), a.v)
+	_, _ = io.WriteString(s, startRedactable+markersEscaper.Replace(formatted)+endRedactable)
+}
+
+// reproduceFormat returns the formatting directive which produced the
+// given fmt.State and verb.
+func reproduceFormat(s fmt.State, verb rune) string {
+	var buf strings.Builder
+	buf.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			buf.WriteRune(flag)
+		}
+	}
+	if width, ok := s.Width(); ok {
+		buf.WriteString(strconv.Itoa(width))
+	}
+	if prec, ok := s.Precision(); ok {
+		buf.WriteByte('.')
+		buf.WriteString(strconv.Itoa(prec))
+	}
+	buf.WriteRune(verb)
+	return buf.String()
+}
+
+// formatArgs appends a log message formatted from the given format and
+// arguments to buf, as fmt.Fprintf, or fmt.Fprint if format is empty. The
+// unsafe arguments are enclosed in redaction markers if redactable logs are
+// enabled.
+func formatArgs(buf *strings.Builder, format string, args []interface{}) {
+	if len(args) == 0 {
+		buf.WriteString(format)
+		return
+	}
+	if !logging.redactableLogs {
+		if len(format) == 0 {
+			fmt.Fprint(buf, args...)
+		} else {
+			fmt.Fprintf(buf, format, args...)
+		}
+		return
+	}
+	wrapped := make([]interface{}, len(args))
+	for i, arg := range args {
+		if isSafe(arg) {
+			wrapped[i] = arg
+		} else {
+			wrapped[i] = unsafeArg{arg}
+		}
+	}
+	if len(format) > 0 {
+		fmt.Fprintf(buf, format, wrapped...)
+		return
+	}
+	// Reproduce the spacing of fmt.Fprint, which depends on the type of the
+	// original arguments.
+	for i, arg := range wrapped {
+		if i > 0 && !isString(args[i-1]) && !isString(args[i]) {
+			buf.WriteByte(' ')
+		}
+		fmt.Fprint(buf, arg)
+	}
+}
+
+func isString(arg interface{}) bool {
+	return arg != nil && reflect.TypeOf(arg).Kind() == reflect.String
+}
+
+// formatRedactableTags is like formatTags, except that the values of the
+// tags are enclosed in redaction markers unless they are safe.
+func formatRedactableTags(ctx context.Context, buf *strings.Builder) bool {
+	tags := logtags.FromContext(ctx)
+	if tags == nil {
+		return false
+	}
+	buf.WriteByte('[')
+	t := tags.Get()
+	for i := range t {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(t[i].Key())
+		if v := t[i].Value(); v != nil && v != "" {
+			if len(t[i].Key()) > 1 {
+				buf.WriteByte('=')
+			}
+			if isSafe(v) {
+				fmt.Fprint(buf, v)
+			} else {
+				fmt.Fprint(buf, unsafeArg{v})
+			}
+		}
+	}
+	buf.WriteString("] ")
+	return true
+}
+
+// unsafeMessage returns a message made of the given text, enclosed in
+// redaction markers if redactable logs are enabled. Used for the messages
+// which don't go through formatArgs.
+func unsafeMessage(text string) string {
+	if !logging.redactableLogs {
+		return text
+	}
+	return startRedactable + markersEscaper.Replace(text) + endRedactable
+}
+
+// Redact replaces the unsafe values of the entry's message with redaction
+// markers. The whole message of an entry which is not redactable is
+// considered to be unsafe.
+func (e *Entry) Redact() {
+	if !e.Redactable {
+		e.Message = redactedMarker
+		e.Redactable = true
+		return
+	}
+	e.Message = unsafeValueRE.ReplaceAllString(e.Message, redactedMarker)
+}
+
+// StripMarkers removes the redaction markers of the entry's message, if
+// any. The resulting entry is not redactable anymore.
+func (e *Entry) StripMarkers() {
+	if !e.Redactable {
+		return
+	}
+	e.Message = markersStripper.Replace(e.Message)
+	e.Redactable = false
+}
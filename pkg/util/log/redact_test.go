@@ -0,0 +1,126 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/logtags"
+)
+
+type safeID int
+
+func (safeID) SafeValue() {}
+
+func TestRedactableMessage(t *testing.T) {
+	defer TestingSetRedactable(true)()
+
+	ctx := logtags.AddTag(context.Background(), "n", safeID(1))
+	ctx = logtags.AddTag(ctx, "client", "127.0.0.1:1234")
+	ctx = logtags.AddTag(ctx, "intExec", nil)
+
+	testCases := []struct {
+		format   string
+		args     []interface{}
+		expected string
+	}{
+		{"hello world", nil, "hello world"},
+		{"key %s", []interface{}{"/Table/53/1"}, "key ‹/Table/53/1›"},
+		{"%s on r%d", []interface{}{"foo", safeID(12)}, "‹foo› on r12"},
+		{"%05.1f|%-4d|%q", []interface{}{3.14159, 7, "x"}, `‹003.1›|‹7   ›|‹"x"›`},
+		{"safe %s", []interface{}{Safe("value")}, "safe value"},
+		{"error: %v", []interface{}{errors.New("boom")}, "error: ‹boom›"},
+		// Markers in unsafe values are escaped.
+		{"%s", []interface{}{"a‹b›c"}, "‹a?b?c›"},
+		// The spacing of fmt.Fprint is preserved.
+		{"", []interface{}{"a", 1, 2, "b"}, "‹a›‹1› ‹2›‹b›"},
+	}
+	for _, tc := range testCases {
+		actual := MakeMessage(ctx, tc.format, tc.args)
+		expected := "[n1,client=‹127.0.0.1:1234›,intExec] " + tc.expected
+		if actual != expected {
+			t.Errorf("%q: expected %q, got %q", tc.format, expected, actual)
+		}
+	}
+
+	defer TestingSetRedactable(false)()
+	if actual, expected := MakeMessage(ctx, "key %s", []interface{}{"foo"}),
+		"[n1,client=127.0.0.1:1234,intExec] key foo"; actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestEntryRedaction(t *testing.T) {
+	testCases := []struct {
+		entry    Entry
+		redacted string
+		stripped string
+	}{
+		{
+			entry:    Entry{Message: "[n1,client=‹1.2.3.4›] key ‹/Table/53› on r‹›2", Redactable: true},
+			redacted: "[n1,client=‹×›] key ‹×› on r‹×›2",
+			stripped: "[n1,client=1.2.3.4] key /Table/53 on r2",
+		},
+		{
+			entry:    Entry{Message: "[n1] no user data", Redactable: true},
+			redacted: "[n1] no user data",
+			stripped: "[n1] no user data",
+		},
+		{
+			// The whole message of an entry which isn't redactable is unsafe.
+			entry:    Entry{Message: "[n1] key /Table/53"},
+			redacted: "‹×›",
+			stripped: "[n1] key /Table/53",
+		},
+	}
+	for _, tc := range testCases {
+		e := tc.entry
+		e.Redact()
+		if e.Message != tc.redacted || !e.Redactable {
+			t.Errorf("expected redacted %q, got %+v", tc.redacted, e)
+		}
+		e = tc.entry
+		e.StripMarkers()
+		if e.Message != tc.stripped || e.Redactable {
+			t.Errorf("expected stripped %q, got %+v", tc.stripped, e)
+		}
+	}
+}
+
+func TestRedactableEntryDecoder(t *testing.T) {
+	entries := []Entry{
+		{Severity: Severity_INFO, Time: 1e18, Goroutine: 1, File: "a.go", Line: 1, Message: "key ‹foo›", Redactable: true},
+		{Severity: Severity_INFO, Time: 1e18 + 1000, Goroutine: 2, File: "b.go", Line: 2, Message: "plain"},
+	}
+	var buf strings.Builder
+	for _, e := range entries {
+		if err := e.Format(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !strings.Contains(buf.String(), "a.go:1 ⋮ key ‹foo›\n") {
+		t.Fatalf("missing redactable indicator: %q", buf.String())
+	}
+
+	decoder := NewEntryDecoder(strings.NewReader(buf.String()))
+	for _, expected := range entries {
+		var e Entry
+		if err := decoder.Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Message != expected.Message || e.Redactable != expected.Redactable {
+			t.Errorf("expected %+v, got %+v", expected, e)
+		}
+	}
+}
@@ -65,6 +65,7 @@ func NewSecondaryLogger(
 		logger: loggerT{
 			logDir:           DirName{name: dir},
 			prefix:           program + "-" + fileNamePrefix,
+			channel:          fileNamePrefix,
 			fileThreshold:    Severity_INFO,
 			noStderrRedirect: true,
 			gcNotify:         make(chan struct{}, 1),
@@ -100,11 +101,7 @@ func (l *SecondaryLogger) output(
 		fmt.Fprintf(&buf, "%d ", counter)
 	}
 
-	if format == "" {
-		fmt.Fprint(&buf, args...)
-	} else {
-		fmt.Fprintf(&buf, format, args...)
-	}
+	formatArgs(&buf, format, args)
 	l.logger.outputLogEntry(Severity_INFO, file, line, buf.String())
 }
 
@@ -0,0 +1,159 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// fluentTagPrefix prefixes the channel name in the tags of the events sent
+// to Fluentd collectors, e.g. cockroach.sql-audit.
+const fluentTagPrefix = "cockroach."
+
+// fluentSink is a logSink emitting entries to a log collector speaking the
+// Fluentd forward protocol, e.g. Fluentd or Fluent Bit. See
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1.
+//
+// Every entry is sent as a message mode event, tagged after its channel,
+// whose record holds the fields of the JSON format (see jsonEntry)
+// independently of the configured log format.
+type fluentSink struct {
+	spec    string
+	network string
+	addr    string
+	conn    *sinkConn
+}
+
+var _ logSink = (*fluentSink)(nil)
+
+func newFluentSink(spec string, network string, addr string) *fluentSink {
+	s := &fluentSink{spec: spec, network: network, addr: addr}
+	s.conn = newSinkConn(s, s.dial)
+	return s
+}
+
+// String implements the logSink interface.
+func (s *fluentSink) String() string {
+	return s.spec
+}
+
+// output implements the logSink interface.
+func (s *fluentSink) output(channel string, entry Entry, stacks []byte, _ []byte) {
+	var buf bytes.Buffer
+	encodeFluentEvent(&buf, channel, entry, stacks)
+	s.conn.send(buf.Bytes())
+}
+
+// dial connects to the collector.
+func (s *fluentSink) dial() (net.Conn, error) {
+	conn, err := net.DialTimeout(s.network, s.addr, sinkWriteTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to log collector")
+	}
+	return conn, nil
+}
+
+// close closes the connection to the collector.
+func (s *fluentSink) close() {
+	s.conn.close()
+}
+
+// encodeFluentEvent encodes a log entry as a forward protocol event in
+// message mode, i.e. the MessagePack array [tag, time, record].
+func encodeFluentEvent(buf *bytes.Buffer, channel string, entry Entry, stacks []byte) {
+	e := makeJSONEntry(channel, entry, stacks)
+
+	buf.WriteByte(0x93) // fixarray of 3 elements.
+	msgpackString(buf, fluentTagPrefix+channel)
+
+	// The time is encoded as an EventTime, the fixext8 extension of type 0
+	// holding the seconds and nanoseconds since the epoch.
+	var tmp [8]byte
+	binary.BigEndian.PutUint32(tmp[:4], uint32(entry.Time/1e9))
+	binary.BigEndian.PutUint32(tmp[4:], uint32(entry.Time%1e9))
+	buf.Write([]byte{0xd7, 0x00})
+	buf.Write(tmp[:])
+
+	fields := 7
+	if e.Goroutine != 0 {
+		fields++
+	}
+	if e.Stacks != "" {
+		fields++
+	}
+	if e.Redactable {
+		fields++
+	}
+	buf.WriteByte(0x80 | byte(fields)) // fixmap.
+	msgpackString(buf, "severity")
+	msgpackString(buf, e.Severity)
+	msgpackString(buf, "time")
+	msgpackInt(buf, e.Time)
+	msgpackString(buf, "timestamp")
+	msgpackString(buf, e.Timestamp)
+	if e.Goroutine != 0 {
+		msgpackString(buf, "goroutine")
+		msgpackInt(buf, e.Goroutine)
+	}
+	msgpackString(buf, "file")
+	msgpackString(buf, e.File)
+	msgpackString(buf, "line")
+	msgpackInt(buf, e.Line)
+	msgpackString(buf, "channel")
+	msgpackString(buf, e.Channel)
+	msgpackString(buf, "message")
+	msgpackString(buf, e.Message)
+	if e.Stacks != "" {
+		msgpackString(buf, "stacks")
+		msgpackString(buf, e.Stacks)
+	}
+	if e.Redactable {
+		msgpackString(buf, "redactable")
+		buf.WriteByte(0xc3) // true.
+	}
+}
+
+// msgpackString appends a MessagePack string.
+func msgpackString(buf *bytes.Buffer, s string) {
+	var tmp [4]byte
+	switch n := len(s); {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n)) // fixstr.
+	case n <= 0xff:
+		buf.WriteByte(0xd9) // str 8.
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xda) // str 16.
+		binary.BigEndian.PutUint16(tmp[:2], uint16(n))
+		buf.Write(tmp[:2])
+	default:
+		buf.WriteByte(0xdb) // str 32.
+		binary.BigEndian.PutUint32(tmp[:4], uint32(n))
+		buf.Write(tmp[:4])
+	}
+	buf.WriteString(s)
+}
+
+// msgpackInt appends a MessagePack integer.
+func msgpackInt(buf *bytes.Buffer, i int64) {
+	if i >= 0 && i < 128 {
+		buf.WriteByte(byte(i)) // positive fixint.
+		return
+	}
+	var tmp [8]byte
+	binary.BigEndian.PutUint64(tmp[:], uint64(i))
+	buf.WriteByte(0xd3) // int 64.
+	buf.Write(tmp[:])
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/pkg/errors"
+)
+
+// syslogSocketPaths are the paths of the local syslog socket tried by
+// default, in order.
+var syslogSocketPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// syslogFacilityUser is the syslog facility of the log entries.
+const syslogFacilityUser = 1
+
+// syslogSeverities maps our severities to syslog severities.
+var syslogSeverities = map[Severity]int{
+	Severity_INFO:    6, // informational
+	Severity_WARNING: 4, // warning
+	Severity_ERROR:   3, // error
+	Severity_FATAL:   2, // critical
+}
+
+// syslogSink is a logSink emitting entries to a syslog daemon listening on
+// a local socket. It uses the traditional BSD syslog format accepted on
+// local sockets, as in log/syslog, which we can't use as it's not available
+// on all platforms.
+type syslogSink struct {
+	spec string
+	// path is the path of the socket, or empty for the default paths.
+	path string
+	conn *sinkConn
+}
+
+var _ logSink = (*syslogSink)(nil)
+
+func newSyslogSink(spec string, path string) *syslogSink {
+	s := &syslogSink{spec: spec, path: path}
+	s.conn = newSinkConn(s, s.dial)
+	return s
+}
+
+// String implements the logSink interface.
+func (s *syslogSink) String() string {
+	return s.spec
+}
+
+// output implements the logSink interface.
+func (s *syslogSink) output(_ string, entry Entry, _ []byte, formatted []byte) {
+	severity, ok := syslogSeverities[entry.Severity]
+	if !ok {
+		severity = syslogSeverities[Severity_INFO]
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>%s %s[%d]: ",
+		syslogFacilityUser*8+severity,
+		timeutil.Unix(0, entry.Time).Format(time.Stamp), program, pid)
+	// The formatted entry is terminated by a newline, which delimits the
+	// messages on stream sockets.
+	buf.Write(formatted)
+	s.conn.send(buf.Bytes())
+}
+
+// dial connects to the syslog socket. Syslog daemons listen on datagram
+// sockets, and sometimes on stream sockets.
+func (s *syslogSink) dial() (net.Conn, error) {
+	paths := syslogSocketPaths
+	if s.path != "" {
+		paths = []string{s.path}
+	}
+	var lastErr error
+	for _, path := range paths {
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err := net.DialTimeout(network, path, sinkWriteTimeout)
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+	}
+	return nil, errors.Wrap(lastErr, "connecting to syslog")
+}
+
+// close closes the connection to the syslog daemon.
+func (s *syslogSink) close() {
+	s.conn.close()
+}
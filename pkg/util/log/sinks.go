@@ -0,0 +1,380 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// mainChannel is the channel name of the main logger. The channels of the
+// secondary loggers are named after their file name prefix, e.g. sql-audit.
+const mainChannel = "main"
+
+// logSink is a destination of log entries other than the log files and
+// the process' standard error, for instance a syslog daemon or a log
+// collector on the network.
+//
+// Sinks are shared by all the channels they're configured for, so they
+// must be safe for concurrent use. They are not critical to the process:
+// errors are reported on stderr and the entries are dropped. Network sinks
+// must not block, since they are called with the logger's mutex held; they
+// send their entries from a sinkConn.
+type logSink interface {
+	// output emits a log entry of the given channel. formatted is the entry
+	// in the configured log format, terminated by a newline.
+	output(channel string, entry Entry, stacks []byte, formatted []byte)
+	// String returns the specification of the sink.
+	String() string
+}
+
+// channelSinks holds the destinations of the log entries of a channel.
+type channelSinks struct {
+	// file is set if the entries go to the channel's log files.
+	file bool
+	// stderr is set if the entries go to stderr, independently of the
+	// stderr threshold.
+	stderr bool
+	// others are the remaining sinks.
+	others []logSink
+}
+
+// sinkConfig is the configuration of the log sinks, populated by the
+// --log-sink flag. Each occurrence of the flag configures a sink for some
+// channels, or for all of them:
+//
+//   --log-sink=[<channel>,...=]<sink>
+//
+// where sink is one of:
+//
+//   file                     the channel's log files in the log directory.
+//   stderr                   the process' standard error.
+//   syslog[:///<socket>]     a syslog daemon listening on a local socket,
+//                            by default /dev/log.
+//   fluent://<host>:<port>   a collector speaking the Fluentd forward
+//   fluent+unix:///<socket>  protocol, e.g. Fluentd or Fluent Bit.
+//
+// The channels without a configured sink keep the default behavior: their
+// entries go to the log files, and to stderr according to --logtostderr.
+// The channels with configured sinks only go to these sinks. Entries below
+// the file verbosity threshold of their logger are not sent to the sinks.
+type sinkConfig struct {
+	syncutil.Mutex
+
+	// specs are the values of the flag occurrences.
+	specs []string
+	// byURL holds the sinks by their specification, so that the channels
+	// configured with the same sink share it.
+	byURL map[string]logSink
+	// byChannel holds the sinks configured for specific channels, and
+	// allChannels those configured for all the channels.
+	byChannel   map[string]*channelSinks
+	allChannels *channelSinks
+}
+
+// Set is part of the flag.Value interface.
+func (c *sinkConfig) Set(value string) error {
+	var channels []string
+	sinkURL := value
+	// The channel list is separated from the sink by the first '=', unless
+	// that '=' is part of the sink's URL.
+	if i := strings.IndexByte(value, '='); i >= 0 && !strings.ContainsAny(value[:i], ":/") {
+		channels = strings.Split(value[:i], ",")
+		sinkURL = value[i+1:]
+		for _, channel := range channels {
+			if channel == "" {
+				return fmt.Errorf("empty log channel in log sink %q", value)
+			}
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if c.byURL == nil {
+		c.byURL = make(map[string]logSink)
+		c.byChannel = make(map[string]*channelSinks)
+	}
+
+	add := func(sinks *channelSinks) (*channelSinks, error) {
+		if sinks == nil {
+			sinks = &channelSinks{}
+		}
+		switch sinkURL {
+		case "file":
+			sinks.file = true
+			return sinks, nil
+		case "stderr":
+			sinks.stderr = true
+			return sinks, nil
+		}
+		sink, ok := c.byURL[sinkURL]
+		if !ok {
+			var err error
+			if sink, err = newLogSink(sinkURL); err != nil {
+				return nil, err
+			}
+			c.byURL[sinkURL] = sink
+		}
+		sinks.others = append(sinks.others, sink)
+		return sinks, nil
+	}
+	if len(channels) == 0 {
+		sinks, err := add(c.allChannels)
+		if err != nil {
+			return err
+		}
+		c.allChannels = sinks
+	}
+	for _, channel := range channels {
+		sinks, err := add(c.byChannel[channel])
+		if err != nil {
+			return err
+		}
+		c.byChannel[channel] = sinks
+	}
+	c.specs = append(c.specs, value)
+	return nil
+}
+
+// String is part of the flag.Value interface.
+func (c *sinkConfig) String() string {
+	c.Lock()
+	defer c.Unlock()
+	return strings.Join(c.specs, " ")
+}
+
+// Type is part of the flag.Value interface.
+func (c *sinkConfig) Type() string {
+	return "string"
+}
+
+// forChannel returns the sinks configured for the given channel, or nil if
+// none are configured.
+func (c *sinkConfig) forChannel(channel string) *channelSinks {
+	c.Lock()
+	defer c.Unlock()
+	sinks, all := c.byChannel[channel], c.allChannels
+	if sinks == nil || all == nil {
+		if sinks == nil {
+			return all
+		}
+		return sinks
+	}
+	return &channelSinks{
+		file:   sinks.file || all.file,
+		stderr: sinks.stderr || all.stderr,
+		others: append(append([]logSink(nil), sinks.others...), all.others...),
+	}
+}
+
+// reset removes all the configured sinks. Used in tests.
+func (c *sinkConfig) reset() {
+	c.Lock()
+	defer c.Unlock()
+	for _, sink := range c.byURL {
+		if closer, ok := sink.(interface{ close() }); ok {
+			closer.close()
+		}
+	}
+	c.specs = nil
+	c.byURL = nil
+	c.byChannel = nil
+	c.allChannels = nil
+}
+
+// newLogSink creates the sink with the given specification, as documented on
+// sinkConfig. Sinks connect lazily when they emit their first entry.
+func newLogSink(spec string) (logSink, error) {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log sink %q: %v", spec, err)
+	}
+	switch u.Scheme {
+	case "":
+		if u.Path == "syslog" {
+			return newSyslogSink(spec, ""), nil
+		}
+	case "syslog":
+		if u.Host != "" {
+			return nil, fmt.Errorf("invalid log sink %q: only local syslog sockets are supported", spec)
+		}
+		return newSyslogSink(spec, u.Path), nil
+	case "fluent":
+		if u.Host == "" || u.Port() == "" {
+			return nil, fmt.Errorf("invalid log sink %q: expected fluent://<host>:<port>", spec)
+		}
+		return newFluentSink(spec, "tcp", u.Host), nil
+	case "fluent+unix":
+		if u.Path == "" {
+			return nil, fmt.Errorf("invalid log sink %q: expected fluent+unix:///<socket>", spec)
+		}
+		return newFluentSink(spec, "unix", u.Path), nil
+	}
+	return nil, fmt.Errorf("unknown log sink %q", spec)
+}
+
+// sinkWriteTimeout bounds the connection attempts and the writes of the
+// network log sinks, so that a stuck collector does not stall their
+// goroutine for long.
+const sinkWriteTimeout = time.Second
+
+// sinkBufferSize is the number of entries a network log sink buffers while
+// it is sending earlier entries or waiting to reconnect. Further entries are
+// dropped.
+const sinkBufferSize = 1024
+
+// sinkMinBackoff and sinkMaxBackoff bound the time a network log sink waits
+// before reconnecting after a failed connection attempt.
+const (
+	sinkMinBackoff = 100 * time.Millisecond
+	sinkMaxBackoff = 30 * time.Second
+)
+
+// sinkConn sends the messages of a network log sink to its destination from
+// a goroutine, so that logging never waits on the network: messages are
+// buffered, and dropped when the buffer is full. The goroutine connects when
+// the first message is sent, and reconnects with an exponential backoff when
+// the connection fails.
+type sinkConn struct {
+	sink logSink
+	dial func() (net.Conn, error)
+
+	msgs      chan []byte
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopper   chan struct{}
+	stopped   chan struct{}
+
+	// dropped is the number of messages dropped since the last message was
+	// sent. Accessed atomically.
+	dropped int64
+	// errors is only used by the goroutine.
+	errors sinkErrorReporter
+}
+
+func newSinkConn(sink logSink, dial func() (net.Conn, error)) *sinkConn {
+	return &sinkConn{
+		sink:    sink,
+		dial:    dial,
+		msgs:    make(chan []byte, sinkBufferSize),
+		stopper: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// send queues a message, or drops it if the buffer is full. The message must
+// not be modified afterwards.
+func (c *sinkConn) send(msg []byte) {
+	c.startOnce.Do(func() { go c.run() })
+	select {
+	case c.msgs <- msg:
+	default:
+		atomic.AddInt64(&c.dropped, 1)
+	}
+}
+
+func (c *sinkConn) run() {
+	defer close(c.stopped)
+	var conn net.Conn
+	defer func() {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}()
+	backoff := sinkMinBackoff
+	for {
+		var msg []byte
+		select {
+		case msg = <-c.msgs:
+		case <-c.stopper:
+			return
+		}
+
+		if conn == nil {
+			var err error
+			if conn, err = c.dial(); err != nil {
+				atomic.AddInt64(&c.dropped, 1)
+				c.errors.report(c.sink, err)
+				// The messages sent in the meantime are buffered until the
+				// buffer is full.
+				timer := time.NewTimer(backoff)
+				select {
+				case <-timer.C:
+				case <-c.stopper:
+					timer.Stop()
+					return
+				}
+				if backoff *= 2; backoff > sinkMaxBackoff {
+					backoff = sinkMaxBackoff
+				}
+				continue
+			}
+			backoff = sinkMinBackoff
+		}
+
+		_ = conn.SetWriteDeadline(timeutil.Now().Add(sinkWriteTimeout))
+		if _, err := conn.Write(msg); err != nil {
+			// Reconnect on the next message, e.g. if the collector was
+			// restarted.
+			_ = conn.Close()
+			conn = nil
+			atomic.AddInt64(&c.dropped, 1)
+			c.errors.report(c.sink, err)
+			continue
+		}
+		c.errors.report(c.sink, nil)
+		if dropped := atomic.SwapInt64(&c.dropped, 0); dropped > 0 {
+			fmt.Fprintf(OrigStderr, "log: dropped %d entries sent to log sink %s\n", dropped, c.sink)
+		}
+	}
+}
+
+// close stops the goroutine and closes the connection. The messages that
+// weren't sent yet are dropped.
+func (c *sinkConn) close() {
+	c.stopOnce.Do(func() {
+		close(c.stopper)
+		// Wait for the goroutine if it was started, and prevent it from being
+		// started otherwise.
+		started := true
+		c.startOnce.Do(func() { started = false })
+		if started {
+			<-c.stopped
+		}
+	})
+}
+
+// sinkErrorReporter reports the errors of a log sink on stderr, only
+// when the sink starts failing so as to not flood stderr. It is not safe
+// for concurrent use.
+type sinkErrorReporter struct {
+	failing bool
+}
+
+// report reports the outcome of an operation of the given sink.
+func (r *sinkErrorReporter) report(sink logSink, err error) {
+	if err == nil {
+		r.failing = false
+		return
+	}
+	if !r.failing {
+		r.failing = true
+		fmt.Fprintf(OrigStderr, "log: dropping entries sent to log sink %s: %v\n", sink, err)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSinkConfig(t *testing.T) {
+	testCases := []struct {
+		specs    []string
+		channel  string
+		expected string
+		err      string
+	}{
+		{specs: nil, channel: mainChannel, expected: "<nil>"},
+		{specs: []string{"file"}, channel: mainChannel, expected: "file"},
+		{specs: []string{"sql-audit=file", "stderr"}, channel: mainChannel, expected: "stderr"},
+		{specs: []string{"sql-audit=file", "stderr"}, channel: "sql-audit", expected: "file stderr"},
+		{specs: []string{"sql-audit,sql-exec=syslog"}, channel: "sql-exec", expected: "syslog"},
+		{specs: []string{"sql-audit,sql-exec=syslog"}, channel: mainChannel, expected: "<nil>"},
+		{specs: []string{"main=syslog:///tmp/log.sock", "fluent://localhost:24224"}, channel: mainChannel,
+			expected: "syslog:///tmp/log.sock fluent://localhost:24224"},
+		{specs: []string{"fluent+unix:///tmp/fluent.sock"}, channel: "pebble", expected: "fluent+unix:///tmp/fluent.sock"},
+
+		{specs: []string{"kafka://localhost:9092"}, err: `unknown log sink "kafka://localhost:9092"`},
+		{specs: []string{"syslog://remote:514"}, err: `only local syslog sockets are supported`},
+		{specs: []string{"fluent://localhost"}, err: `expected fluent://<host>:<port>`},
+		{specs: []string{"fluent+unix://"}, err: `expected fluent+unix:///<socket>`},
+		{specs: []string{"main,=file"}, err: `empty log channel`},
+	}
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.specs, " "), func(t *testing.T) {
+			var c sinkConfig
+			defer c.reset()
+			var err error
+			for _, spec := range tc.specs {
+				if err = c.Set(spec); err != nil {
+					break
+				}
+			}
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := describeSinks(c.forChannel(tc.channel)); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func describeSinks(sinks *channelSinks) string {
+	if sinks == nil {
+		return "<nil>"
+	}
+	var parts []string
+	if sinks.file {
+		parts = append(parts, "file")
+	}
+	if sinks.stderr {
+		parts = append(parts, "stderr")
+	}
+	for _, sink := range sinks.others {
+		parts = append(parts, sink.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestSinkConnDropsWhenBusy(t *testing.T) {
+	server, client := net.Pipe()
+	release := make(chan struct{})
+	var dials int32
+	dial := func() (net.Conn, error) {
+		if atomic.AddInt32(&dials, 1) > 1 {
+			return nil, errors.New("collector is gone")
+		}
+		<-release
+		return client, nil
+	}
+	c := newSinkConn(newFluentSink("fluent://test:1", "tcp", "test:1"), dial)
+	defer c.close()
+	// Closing the other end first makes the pending writes fail right away.
+	defer server.Close()
+
+	// The goroutine is stuck connecting, so the messages that don't fit in
+	// the buffer are dropped without blocking.
+	const extra = 100
+	for i := 0; i < sinkBufferSize+1+extra; i++ {
+		c.send([]byte(fmt.Sprintf("m%d\n", i)))
+	}
+	if dropped := atomic.LoadInt64(&c.dropped); dropped < extra || dropped > extra+1 {
+		t.Fatalf("expected about %d dropped messages, got %d", extra, dropped)
+	}
+
+	close(release)
+	buf := make([]byte, 16)
+	_ = server.SetReadDeadline(time.Now().Add(10 * time.Second))
+	n, err := server.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := string(buf[:n]); msg != "m0\n" {
+		t.Fatalf("unexpected first message %q", msg)
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syslog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "log.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink, err := newLogSink("syslog://" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.(*syslogSink).close()
+
+	entry := MakeEntry(Severity_WARNING, time.Now().UnixNano(), "sinks_test.go", 10, "hello")
+	sink.output(mainChannel, entry, nil, []byte("formatted hello\n"))
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := string(buf[:n])
+	// The priority of user-level warnings is 1*8+4.
+	if !strings.HasPrefix(msg, "<12>") || !strings.HasSuffix(msg, ": formatted hello\n") {
+		t.Fatalf("unexpected syslog message: %q", msg)
+	}
+}
+
+func TestFluentSink(t *testing.T) {
+	s := ScopeWithoutShowLogs(t)
+	defer s.Close(t)
+	setFlags()
+	defer mainLog.swap(mainLog.newBuffers())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			received <- nil
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4096)
+		_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+		n, _ := conn.Read(buf)
+		received <- buf[:n]
+	}()
+
+	defer logging.sinks.reset()
+	if err := logging.sinks.Set(mainChannel + "=fluent://" + ln.Addr().String()); err != nil {
+		t.Fatal(err)
+	}
+	Info(context.Background(), "hello fluent")
+
+	event := <-received
+	if len(event) == 0 || event[0] != 0x93 {
+		t.Fatalf("expected a forward protocol event, got %q", event)
+	}
+	for _, expected := range []string{"cockroach.main", "severity", "INFO", "hello fluent"} {
+		if !bytes.Contains(event, []byte(expected)) {
+			t.Errorf("expected %q in event %q", expected, event)
+		}
+	}
+	// The channel has sinks, so its entries don't go to the log file anymore.
+	if contains("hello fluent", t) {
+		t.Errorf("unexpected entry in log file: %q", contents())
+	}
+}
@@ -12,7 +12,6 @@ package log
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -23,6 +22,9 @@ import (
 // formatTags appends the tags to a strings.Builder. If there are no tags,
 // returns false.
 func formatTags(ctx context.Context, buf *strings.Builder) bool {
+	if logging.redactableLogs {
+		return formatRedactableTags(ctx, buf)
+	}
 	tags := logtags.FromContext(ctx)
 	if tags == nil {
 		return false
@@ -37,13 +39,7 @@ func formatTags(ctx context.Context, buf *strings.Builder) bool {
 func MakeMessage(ctx context.Context, format string, args []interface{}) string {
 	var buf strings.Builder
 	formatTags(ctx, &buf)
-	if len(args) == 0 {
-		buf.WriteString(format)
-	} else if len(format) == 0 {
-		fmt.Fprint(&buf, args...)
-	} else {
-		fmt.Fprintf(&buf, format, args...)
-	}
+	formatArgs(&buf, format, args)
 	return buf.String()
 }
 
@@ -148,6 +148,12 @@ func exportedName(name string) string {
 	return prometheusNameReplaceRE.ReplaceAllString(name, "_")
 }
 
+// ExportedName returns the name of the metric with the given name in the
+// Prometheus exposition format, e.g. sql_query_count for sql.query.count.
+func ExportedName(name string) string {
+	return exportedName(name)
+}
+
 // exportedLabel takes a metric name and generates a valid prometheus name.
 func exportedLabel(name string) string {
 	return prometheusLabelReplaceRE.ReplaceAllString(name, "_")
@@ -0,0 +1,792 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	opentracing "github.com/opentracing/opentracing-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+)
+
+// The OTLP shadow tracer exports the spans to an OpenTelemetry collector,
+// or to any backend accepting the OpenTelemetry protocol (OTLP) over HTTP
+// like Jaeger. Unlike the other shadow tracers, it samples the traces: the
+// sampling decision is taken when a root span is started, according to the
+// trace.opentelemetry.* cluster settings and to the SamplingHint of the
+// span, and is propagated to the child spans, including the remote ones.
+//
+// The span contexts are propagated in the W3C Trace Context format (see
+// https://www.w3.org/TR/trace-context/), which is also the format of the
+// trace contexts provided by SQL clients.
+
+var otlpCollector = settings.RegisterPublicStringSetting(
+	"trace.opentelemetry.collector",
+	"if set, traces go to the given OpenTelemetry collector or Jaeger instance using OTLP over HTTP "+
+		"(example: '127.0.0.1:4318'); ignored if trace.lightstep.token or trace.zipkin.collector is set",
+	"",
+)
+
+var otlpSampleRate = func() *settings.FloatSetting {
+	s := settings.RegisterValidatedFloatSetting(
+		"trace.opentelemetry.sample_rate",
+		"fraction of the traces exported to trace.opentelemetry.collector, unless overridden "+
+			"by trace.opentelemetry.app_sample_rates or trace.opentelemetry.statement_fingerprint_filter",
+		1,
+		validateSampleRate,
+	)
+	s.SetVisibility(settings.Public)
+	return s
+}()
+
+var otlpAppSampleRates = func() *settings.StringSetting {
+	s := settings.RegisterValidatedStringSetting(
+		"trace.opentelemetry.app_sample_rates",
+		"comma-separated list of application_name=fraction pairs overriding trace.opentelemetry.sample_rate "+
+			"for the SQL transactions of the given applications (example: 'app1=0.5,app2=0')",
+		"",
+		func(_ *settings.Values, spec string) error {
+			_, err := parseAppSampleRates(spec)
+			return err
+		},
+	)
+	s.SetVisibility(settings.Public)
+	return s
+}()
+
+var otlpFingerprintFilter = func() *settings.StringSetting {
+	s := settings.RegisterValidatedStringSetting(
+		"trace.opentelemetry.statement_fingerprint_filter",
+		"if set, the traces of the SQL transactions started by a statement whose fingerprint matches "+
+			"this regular expression are always exported to trace.opentelemetry.collector",
+		"",
+		func(_ *settings.Values, filter string) error {
+			_, err := regexp.Compile(filter)
+			return err
+		},
+	)
+	s.SetVisibility(settings.Public)
+	return s
+}()
+
+func validateSampleRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return errors.Errorf("sample rate must be between 0 and 1, got %f", rate)
+	}
+	return nil
+}
+
+// parseAppSampleRates parses the value of the
+// trace.opentelemetry.app_sample_rates setting.
+func parseAppSampleRates(spec string) (map[string]float64, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	rates := make(map[string]float64)
+	for _, part := range strings.Split(spec, ",") {
+		i := strings.LastIndexByte(part, '=')
+		if i < 0 {
+			return nil, errors.Errorf("invalid application sample rate %q: expected <application name>=<fraction>", part)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(part[i+1:]), 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid application sample rate %q", part)
+		}
+		if err := validateSampleRate(rate); err != nil {
+			return nil, errors.Wrapf(err, "invalid application sample rate %q", part)
+		}
+		rates[strings.TrimSpace(part[:i])] = rate
+	}
+	return rates, nil
+}
+
+// SamplingHint is a StartSpanOption informing the sampling decision of the
+// shadow tracers which sample the traces, when starting root spans.
+type SamplingHint struct {
+	// AppName is the application name of the SQL session.
+	AppName string
+	// StmtFingerprint returns the fingerprint of the statement which started
+	// the SQL transaction, if any. It is only called if needed.
+	StmtFingerprint func() string
+}
+
+var _ opentracing.StartSpanOption = SamplingHint{}
+
+// Apply is part of the opentracing.StartSpanOption interface.
+func (SamplingHint) Apply(*opentracing.StartSpanOptions) {}
+
+// otlpSampler takes the sampling decisions of the root spans.
+type otlpSampler struct {
+	sv *settings.Values
+
+	mu struct {
+		syncutil.Mutex
+		// The parsed values of the settings, cached until they change.
+		appRatesSpec string
+		appRates     map[string]float64
+		filterSpec   string
+		filter       *regexp.Regexp
+	}
+}
+
+func (s *otlpSampler) config() (map[string]float64, *regexp.Regexp) {
+	appRatesSpec, filterSpec := otlpAppSampleRates.Get(s.sv), otlpFingerprintFilter.Get(s.sv)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if appRatesSpec != s.mu.appRatesSpec {
+		// The setting was validated.
+		s.mu.appRates, _ = parseAppSampleRates(appRatesSpec)
+		s.mu.appRatesSpec = appRatesSpec
+	}
+	if filterSpec != s.mu.filterSpec {
+		s.mu.filter = nil
+		if filterSpec != "" {
+			s.mu.filter, _ = regexp.Compile(filterSpec)
+		}
+		s.mu.filterSpec = filterSpec
+	}
+	return s.mu.appRates, s.mu.filter
+}
+
+// sample decides whether a trace started with the given hint is sampled.
+func (s *otlpSampler) sample(hint *SamplingHint) bool {
+	rate := otlpSampleRate.Get(s.sv)
+	if hint != nil {
+		appRates, filter := s.config()
+		if filter != nil && hint.StmtFingerprint != nil && filter.MatchString(hint.StmtFingerprint()) {
+			return true
+		}
+		if appRate, ok := appRates[hint.AppName]; ok {
+			rate = appRate
+		}
+	}
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+type otlpManager struct {
+	exporter *otlpExporter
+}
+
+func (*otlpManager) Name() string {
+	return otlpTracerName
+}
+
+func (m *otlpManager) Close(opentracing.Tracer) {
+	m.exporter.close()
+}
+
+// otlpTracerName is the name of the OTLP shadow tracer.
+const otlpTracerName = "otlp"
+
+func createOTLPTracer(
+	collectorAddr string, sv *settings.Values,
+) (shadowTracerManager, opentracing.Tracer) {
+	exporter := newOTLPExporter(collectorAddr)
+	return &otlpManager{exporter: exporter}, &otlpTracer{
+		exporter: exporter,
+		sampler:  &otlpSampler{sv: sv},
+	}
+}
+
+// otlpTracer is an opentracing.Tracer recording spans for the OTLP
+// exporter.
+type otlpTracer struct {
+	exporter *otlpExporter
+	sampler  *otlpSampler
+}
+
+var _ opentracing.Tracer = &otlpTracer{}
+
+// StartSpan is part of the opentracing.Tracer interface.
+func (t *otlpTracer) StartSpan(
+	operationName string, opts ...opentracing.StartSpanOption,
+) opentracing.Span {
+	var sso opentracing.StartSpanOptions
+	var hint *SamplingHint
+	for _, o := range opts {
+		o.Apply(&sso)
+		if h, ok := o.(SamplingHint); ok {
+			hint = &h
+		}
+	}
+
+	s := &otlpSpan{tracer: t}
+	var hasParent bool
+	for _, r := range sso.References {
+		if parentCtx, ok := r.ReferencedContext.(otlpSpanContext); ok {
+			s.ctx.traceID = parentCtx.traceID
+			s.ctx.sampled = parentCtx.sampled
+			s.parentSpanID = parentCtx.spanID
+			hasParent = true
+			break
+		}
+	}
+	if !hasParent {
+		binary.BigEndian.PutUint64(s.ctx.traceID[:8], rand.Uint64())
+		binary.BigEndian.PutUint64(s.ctx.traceID[8:], nonZeroRandUint64())
+		s.ctx.sampled = t.sampler.sample(hint)
+	}
+	binary.BigEndian.PutUint64(s.ctx.spanID[:], nonZeroRandUint64())
+
+	if !s.ctx.sampled {
+		// The span is only used to propagate the sampling decision.
+		return s
+	}
+	s.mu.name = operationName
+	s.mu.start = sso.StartTime
+	if s.mu.start.IsZero() {
+		s.mu.start = timeutil.Now()
+	}
+	for k, v := range sso.Tags {
+		s.setTagLocked(k, v)
+	}
+	return s
+}
+
+func nonZeroRandUint64() uint64 {
+	for {
+		if v := rand.Uint64(); v != 0 {
+			return v
+		}
+	}
+}
+
+// otlpTraceParentKey is the key of the span context in the carriers.
+const otlpTraceParentKey = "traceparent"
+
+// Inject is part of the opentracing.Tracer interface.
+func (t *otlpTracer) Inject(
+	osc opentracing.SpanContext, format interface{}, carrier interface{},
+) error {
+	if format != opentracing.HTTPHeaders && format != opentracing.TextMap {
+		return opentracing.ErrUnsupportedFormat
+	}
+	sc, ok := osc.(otlpSpanContext)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+	mapWriter, ok := carrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+	mapWriter.Set(otlpTraceParentKey, sc.traceParent())
+	return nil
+}
+
+// Extract is part of the opentracing.Tracer interface.
+func (t *otlpTracer) Extract(
+	format interface{}, carrier interface{},
+) (opentracing.SpanContext, error) {
+	if format != opentracing.HTTPHeaders && format != opentracing.TextMap {
+		return nil, opentracing.ErrUnsupportedFormat
+	}
+	mapReader, ok := carrier.(opentracing.TextMapReader)
+	if !ok {
+		return nil, opentracing.ErrInvalidCarrier
+	}
+	var sc otlpSpanContext
+	var found bool
+	if err := mapReader.ForeachKey(func(k, v string) error {
+		if !strings.EqualFold(k, otlpTraceParentKey) {
+			return nil
+		}
+		var err error
+		sc, err = parseTraceParent(v)
+		if err != nil {
+			return opentracing.ErrSpanContextCorrupted
+		}
+		found = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+	return sc, nil
+}
+
+// otlpSpanContext is the opentracing.SpanContext of the OTLP spans.
+type otlpSpanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+	sampled bool
+}
+
+var _ opentracing.SpanContext = otlpSpanContext{}
+
+// ForeachBaggageItem is part of the opentracing.SpanContext interface. The
+// baggage is propagated by our own Tracer.
+func (otlpSpanContext) ForeachBaggageItem(func(k, v string) bool) {}
+
+// traceParent formats the span context as a W3C traceparent header.
+func (sc otlpSpanContext) traceParent() string {
+	flags := "00"
+	if sc.sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s",
+		hex.EncodeToString(sc.traceID[:]), hex.EncodeToString(sc.spanID[:]), flags)
+}
+
+var traceParentRE = regexp.MustCompile(`^([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+
+// parseTraceParent parses a W3C traceparent header.
+func parseTraceParent(traceParent string) (otlpSpanContext, error) {
+	var sc otlpSpanContext
+	m := traceParentRE.FindStringSubmatch(strings.TrimSpace(traceParent))
+	if m == nil {
+		return sc, errors.Errorf("invalid traceparent %q: expected 00-<trace id>-<parent id>-<flags>", traceParent)
+	}
+	if m[1] == "ff" {
+		return sc, errors.Errorf("invalid traceparent %q: unsupported version", traceParent)
+	}
+	// The regular expression validated the hexadecimal strings.
+	_, _ = hex.Decode(sc.traceID[:], []byte(m[2]))
+	_, _ = hex.Decode(sc.spanID[:], []byte(m[3]))
+	if sc.traceID == ([16]byte{}) || sc.spanID == ([8]byte{}) {
+		return sc, errors.Errorf("invalid traceparent %q: zero trace or parent id", traceParent)
+	}
+	var flags [1]byte
+	_, _ = hex.Decode(flags[:], []byte(m[4]))
+	sc.sampled = flags[0]&1 != 0
+	return sc, nil
+}
+
+// ValidateTraceParent checks that the given string is a valid W3C traceparent
+// header.
+func ValidateTraceParent(traceParent string) error {
+	_, err := parseTraceParent(traceParent)
+	return err
+}
+
+// ExtractTraceParent returns a span context from a W3C traceparent header
+// provided by a client, e.g. a SQL client. The spans started as children of
+// this context are part of the client's trace in the OTLP shadow tracer, if
+// it is the current shadow tracer.
+func (t *Tracer) ExtractTraceParent(traceParent string) (opentracing.SpanContext, error) {
+	sc, err := parseTraceParent(traceParent)
+	if err != nil {
+		return nil, err
+	}
+	carrier := opentracing.TextMapCarrier{
+		fieldNameTraceID:                  strconv.FormatUint(binary.BigEndian.Uint64(sc.traceID[8:]), 16),
+		fieldNameSpanID:                   strconv.FormatUint(binary.BigEndian.Uint64(sc.spanID[:]), 16),
+		fieldNameShadowType:               otlpTracerName,
+		prefixShadow + otlpTraceParentKey: sc.traceParent(),
+	}
+	return t.Extract(opentracing.TextMap, carrier)
+}
+
+// otlpSpan is the opentracing.Span of the OTLP tracer. Only the sampled
+// spans record their operation, tags and logs.
+type otlpSpan struct {
+	tracer       *otlpTracer
+	ctx          otlpSpanContext
+	parentSpanID [8]byte
+
+	mu struct {
+		syncutil.Mutex
+		name       string
+		start, end time.Time
+		attributes map[string]interface{}
+		events     []opentracing.LogRecord
+		finished   bool
+	}
+}
+
+var _ opentracing.Span = &otlpSpan{}
+
+// Finish is part of the opentracing.Span interface.
+func (s *otlpSpan) Finish() {
+	s.FinishWithOptions(opentracing.FinishOptions{})
+}
+
+// FinishWithOptions is part of the opentracing.Span interface.
+func (s *otlpSpan) FinishWithOptions(opts opentracing.FinishOptions) {
+	if !s.ctx.sampled {
+		return
+	}
+	s.mu.Lock()
+	if s.mu.finished {
+		s.mu.Unlock()
+		return
+	}
+	s.mu.finished = true
+	s.mu.end = opts.FinishTime
+	if s.mu.end.IsZero() {
+		s.mu.end = timeutil.Now()
+	}
+	for _, lr := range opts.LogRecords {
+		s.logLocked(lr)
+	}
+	s.mu.Unlock()
+	s.tracer.exporter.add(s)
+}
+
+// Context is part of the opentracing.Span interface.
+func (s *otlpSpan) Context() opentracing.SpanContext {
+	return s.ctx
+}
+
+// SetOperationName is part of the opentracing.Span interface.
+func (s *otlpSpan) SetOperationName(operationName string) opentracing.Span {
+	if s.ctx.sampled {
+		s.mu.Lock()
+		s.mu.name = operationName
+		s.mu.Unlock()
+	}
+	return s
+}
+
+// SetTag is part of the opentracing.Span interface.
+func (s *otlpSpan) SetTag(key string, value interface{}) opentracing.Span {
+	if s.ctx.sampled {
+		s.mu.Lock()
+		s.setTagLocked(key, value)
+		s.mu.Unlock()
+	}
+	return s
+}
+
+func (s *otlpSpan) setTagLocked(key string, value interface{}) {
+	if s.mu.attributes == nil {
+		s.mu.attributes = make(map[string]interface{})
+	}
+	s.mu.attributes[key] = value
+}
+
+// LogFields is part of the opentracing.Span interface.
+func (s *otlpSpan) LogFields(fields ...otlog.Field) {
+	if s.ctx.sampled {
+		s.mu.Lock()
+		s.logLocked(opentracing.LogRecord{Timestamp: timeutil.Now(), Fields: fields})
+		s.mu.Unlock()
+	}
+}
+
+func (s *otlpSpan) logLocked(lr opentracing.LogRecord) {
+	if len(s.mu.events) < maxLogsPerSpan {
+		s.mu.events = append(s.mu.events, lr)
+	}
+}
+
+// LogKV is part of the opentracing.Span interface.
+func (s *otlpSpan) LogKV(alternatingKeyValues ...interface{}) {
+	fields, err := otlog.InterleavedKVToFields(alternatingKeyValues...)
+	if err != nil {
+		s.LogFields(otlog.Error(err), otlog.String("function", "LogKV"))
+		return
+	}
+	s.LogFields(fields...)
+}
+
+// SetBaggageItem is part of the opentracing.Span interface. The baggage is
+// propagated by our own Tracer.
+func (s *otlpSpan) SetBaggageItem(restrictedKey, value string) opentracing.Span {
+	return s
+}
+
+// BaggageItem is part of the opentracing.Span interface.
+func (s *otlpSpan) BaggageItem(restrictedKey string) string {
+	return ""
+}
+
+// Tracer is part of the opentracing.Span interface.
+func (s *otlpSpan) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+// LogEvent is part of the opentracing.Span interface. Deprecated.
+func (s *otlpSpan) LogEvent(event string) {
+	s.LogFields(otlog.String("event", event))
+}
+
+// LogEventWithPayload is part of the opentracing.Span interface. Deprecated.
+func (s *otlpSpan) LogEventWithPayload(event string, payload interface{}) {
+	s.LogFields(otlog.String("event", event), otlog.Object("payload", payload))
+}
+
+// Log is part of the opentracing.Span interface. Deprecated.
+func (s *otlpSpan) Log(data opentracing.LogData) {
+	s.mu.Lock()
+	s.logLocked(data.ToLogRecord())
+	s.mu.Unlock()
+}
+
+const (
+	// otlpQueueSize is the number of finished spans buffered by the exporter;
+	// spans are dropped when the queue is full.
+	otlpQueueSize = 8192
+	// otlpBatchSize is the maximum number of spans per export request.
+	otlpBatchSize = 512
+	// otlpFlushInterval is the maximum delay before a finished span is
+	// exported.
+	otlpFlushInterval = time.Second
+	// otlpRequestTimeout bounds the export requests.
+	otlpRequestTimeout = 10 * time.Second
+)
+
+var otlpLogEveryN = util.Every(5 * time.Second)
+
+// warningFn reports the problems encountered by the OTLP exporter. It is set
+// to log.Warningf by the log package, which depends on this package and so
+// can't be used from here directly.
+var warningFn func(ctx context.Context, format string, args ...interface{})
+
+// SetWarningFn sets the function used to report the problems encountered by
+// the OTLP exporter.
+func SetWarningFn(fn func(ctx context.Context, format string, args ...interface{})) {
+	warningFn = fn
+}
+
+// otlpExporter sends the finished spans to the collector in batches.
+type otlpExporter struct {
+	url    string
+	client *http.Client
+	spans  chan *otlpSpan
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newOTLPExporter(collectorAddr string) *otlpExporter {
+	url := collectorAddr
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	e := &otlpExporter{
+		url:    strings.TrimSuffix(url, "/") + "/v1/traces",
+		client: &http.Client{Timeout: otlpRequestTimeout},
+		spans:  make(chan *otlpSpan, otlpQueueSize),
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// add queues a finished span for export.
+func (e *otlpExporter) add(s *otlpSpan) {
+	select {
+	case e.spans <- s:
+	default:
+		e.warningf("dropping spans: queue full")
+	}
+}
+
+// close exports the queued spans and stops the exporter.
+func (e *otlpExporter) close() {
+	close(e.stopCh)
+	<-e.doneCh
+}
+
+func (e *otlpExporter) run() {
+	defer close(e.doneCh)
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+	batch := make([]*otlpSpan, 0, otlpBatchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			if err := e.export(batch); err != nil {
+				e.warningf("exporting %d spans: %v", len(batch), err)
+			}
+			batch = batch[:0]
+		}
+	}
+	for {
+		select {
+		case s := <-e.spans:
+			batch = append(batch, s)
+			if len(batch) == otlpBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.stopCh:
+			for {
+				select {
+				case s := <-e.spans:
+					batch = append(batch, s)
+					if len(batch) == otlpBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *otlpExporter) warningf(format string, args ...interface{}) {
+	if warningFn != nil && otlpLogEveryN.ShouldProcess(timeutil.Now()) {
+		warningFn(context.Background(), "OTLP exporter %s: %s", e.url, fmt.Sprintf(format, args...))
+	}
+}
+
+// export sends a batch of spans to the collector, in the JSON encoding of
+// the ExportTraceServiceRequest message.
+func (e *otlpExporter) export(batch []*otlpSpan) error {
+	body, err := json.Marshal(makeOTLPRequest(batch))
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// The following types reproduce the JSON encoding of the OTLP messages.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope      `json:"scope"`
+	Spans []otlpJSONSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpJSONSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Events            []otlpEvent     `json:"events,omitempty"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Name         string          `json:"name"`
+	Attributes   []otlpAttribute `json:"attributes,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// otlpSpanKindInternal is the kind of all the exported spans.
+const otlpSpanKindInternal = 1
+
+func makeOTLPRequest(batch []*otlpSpan) otlpRequest {
+	spans := make([]otlpJSONSpan, 0, len(batch))
+	for _, s := range batch {
+		spans = append(spans, s.toJSON())
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpAttribute{
+			makeOTLPAttribute("service.name", "cockroach"),
+		}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "cockroach"},
+			Spans: spans,
+		}},
+	}}}
+}
+
+func (s *otlpSpan) toJSON() otlpJSONSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	js := otlpJSONSpan{
+		TraceID:           hex.EncodeToString(s.ctx.traceID[:]),
+		SpanID:            hex.EncodeToString(s.ctx.spanID[:]),
+		Name:              s.mu.name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.mu.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.mu.end.UnixNano(), 10),
+	}
+	if s.parentSpanID != ([8]byte{}) {
+		js.ParentSpanID = hex.EncodeToString(s.parentSpanID[:])
+	}
+	for k, v := range s.mu.attributes {
+		js.Attributes = append(js.Attributes, makeOTLPAttribute(k, v))
+	}
+	for _, lr := range s.mu.events {
+		ev := otlpEvent{TimeUnixNano: strconv.FormatInt(lr.Timestamp.UnixNano(), 10), Name: "log"}
+		for _, f := range lr.Fields {
+			if f.Key() == "event" {
+				ev.Name = fmt.Sprint(f.Value())
+				continue
+			}
+			ev.Attributes = append(ev.Attributes, makeOTLPAttribute(f.Key(), f.Value()))
+		}
+		js.Events = append(js.Events, ev)
+	}
+	return js
+}
+
+func makeOTLPAttribute(key string, value interface{}) otlpAttribute {
+	attr := otlpAttribute{Key: key}
+	switch v := value.(type) {
+	case bool:
+		attr.Value.BoolValue = &v
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		s := fmt.Sprint(v)
+		attr.Value.IntValue = &s
+	case float32:
+		f := float64(v)
+		attr.Value.DoubleValue = &f
+	case float64:
+		attr.Value.DoubleValue = &v
+	default:
+		s := fmt.Sprint(v)
+		attr.Value.StringValue = &s
+	}
+	return attr
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tracing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+func TestTraceParent(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := parseTraceParent(traceParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sc.sampled {
+		t.Error("expected sampled span context")
+	}
+	if s := sc.traceParent(); s != traceParent {
+		t.Errorf("expected %s, got %s", traceParent, s)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+	} {
+		if err := ValidateTraceParent(invalid); err == nil {
+			t.Errorf("%q: expected error", invalid)
+		}
+	}
+}
+
+func TestParseAppSampleRates(t *testing.T) {
+	rates, err := parseAppSampleRates("app1=0.5, my=app=1,app3 = 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{"app1": 0.5, "my=app": 1, "app3": 0}
+	if !reflect.DeepEqual(rates, expected) {
+		t.Errorf("expected %v, got %v", expected, rates)
+	}
+
+	for _, invalid := range []string{"app", "app=x", "app=2", "app=-1"} {
+		if _, err := parseAppSampleRates(invalid); err == nil {
+			t.Errorf("%q: expected error", invalid)
+		}
+	}
+}
+
+func TestOTLPSampler(t *testing.T) {
+	var sv settings.Values
+	sv.Init(settings.TestOpaque)
+	u := settings.NewUpdater(&sv)
+	for key, value := range map[string]string{
+		"trace.opentelemetry.sample_rate":                  "0",
+		"trace.opentelemetry.app_sample_rates":             "app1=1",
+		"trace.opentelemetry.statement_fingerprint_filter": "^DELETE",
+	} {
+		typ := "s"
+		if key == "trace.opentelemetry.sample_rate" {
+			typ = "f"
+		}
+		if err := u.Set(key, value, typ); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &otlpSampler{sv: &sv}
+
+	fingerprint := func(f string) func() string {
+		return func() string { return f }
+	}
+	testCases := []struct {
+		hint     *SamplingHint
+		expected bool
+	}{
+		{nil, false},
+		{&SamplingHint{AppName: "app2"}, false},
+		{&SamplingHint{AppName: "app1"}, true},
+		{&SamplingHint{AppName: "app2", StmtFingerprint: fingerprint("SELECT _")}, false},
+		{&SamplingHint{AppName: "app2", StmtFingerprint: fingerprint("DELETE FROM t")}, true},
+	}
+	for i, tc := range testCases {
+		if sampled := s.sample(tc.hint); sampled != tc.expected {
+			t.Errorf("%d: expected sampled=%t, got %t", i, tc.expected, sampled)
+		}
+	}
+}
+
+func TestOTLPTracer(t *testing.T) {
+	requests := make(chan otlpRequest, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var req otlpRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Error(err)
+		}
+		requests <- req
+	}))
+	defer collector.Close()
+
+	var sv settings.Values
+	sv.Init(settings.TestOpaque)
+	tr := NewTracer()
+	tr.setShadowTracer(createOTLPTracer(strings.TrimPrefix(collector.URL, "http://"), &sv))
+
+	// Continue the trace of a client.
+	const clientTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	parentCtx, err := tr.ExtractTraceParent(clientTraceParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := tr.StartSpan("root", opentracing.ChildOf(parentCtx))
+	root.SetTag("tag", 1)
+	child := StartChildSpan("child", root, nil /* logTags */, false /* separateRecording */)
+	child.LogKV("event", "hello")
+
+	// The span contexts are propagated across nodes.
+	carrier := make(opentracing.HTTPHeadersCarrier)
+	if err := tr.Inject(child.Context(), opentracing.HTTPHeaders, carrier); err != nil {
+		t.Fatal(err)
+	}
+	remoteCtx, err := tr.Extract(opentracing.HTTPHeaders, carrier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote := tr.StartSpan("remote", opentracing.FollowsFrom(remoteCtx))
+
+	remote.Finish()
+	child.Finish()
+	root.Finish()
+	tr.setShadowTracer(nil, nil)
+
+	req := <-requests
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %+v", spans)
+	}
+	byName := make(map[string]otlpJSONSpan)
+	for _, s := range spans {
+		if s.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("%s: unexpected trace id %s", s.Name, s.TraceID)
+		}
+		byName[s.Name] = s
+	}
+	if p := byName["root"].ParentSpanID; p != "00f067aa0ba902b7" {
+		t.Errorf("expected the client's span as parent of the root span, got %s", p)
+	}
+	if p := byName["child"].ParentSpanID; p != byName["root"].SpanID {
+		t.Errorf("expected root as parent of child, got %s", p)
+	}
+	if p := byName["remote"].ParentSpanID; p != byName["child"].SpanID {
+		t.Errorf("expected child as parent of remote, got %s", p)
+	}
+	if attrs := byName["root"].Attributes; len(attrs) != 1 || attrs[0].Key != "tag" ||
+		attrs[0].Value.IntValue == nil || *attrs[0].Value.IntValue != "1" {
+		t.Errorf("unexpected attributes %+v", attrs)
+	}
+	if events := byName["child"].Events; len(events) != 1 || events[0].Name != "hello" {
+		t.Errorf("unexpected events %+v", events)
+	}
+}
+
+func TestOTLPTracerNotSampled(t *testing.T) {
+	var sv settings.Values
+	sv.Init(settings.TestOpaque)
+	otlpSampleRate.Override(&sv, 0)
+	tr := NewTracer()
+	// The exporter must not be used: the collector address is invalid.
+	tr.setShadowTracer(createOTLPTracer("invalid:0", &sv))
+	defer tr.setShadowTracer(nil, nil)
+
+	s := tr.StartRootSpanWithHint("root", nil /* logTags */, NonRecordableSpan, &SamplingHint{AppName: "app"})
+	carrier := make(opentracing.TextMapCarrier)
+	if err := tr.Inject(s.Context(), opentracing.TextMap, carrier); err != nil {
+		t.Fatal(err)
+	}
+	traceParent := carrier[prefixShadow+otlpTraceParentKey]
+	if !strings.HasSuffix(traceParent, "-00") {
+		t.Errorf("expected unsampled traceparent, got %q", traceParent)
+	}
+	s.Finish()
+	if n := len(s.(*span).shadowSpan.(*otlpSpan).tracer.exporter.spans); n != 0 {
+		t.Errorf("expected no exported spans, got %d", n)
+	}
+}
@@ -76,12 +76,14 @@ func (st *shadowTracer) Close() {
 // The Shadow span will have a parent if parentShadowCtx is not nil.
 // parentType is ignored if parentShadowCtx is nil.
 //
-// The tags from s are copied to the Shadow span.
+// The tags from s are copied to the Shadow span. hint, if not nil, is passed
+// to the shadow tracer for its sampling decision.
 func linkShadowSpan(
 	s *span,
 	shadowTr *shadowTracer,
 	parentShadowCtx opentracing.SpanContext,
 	parentType opentracing.SpanReferenceType,
+	hint *SamplingHint,
 ) {
 	// Create the shadow lightstep span.
 	var opts []opentracing.StartSpanOption
@@ -105,6 +107,9 @@ func linkShadowSpan(
 			ReferencedContext: parentShadowCtx,
 		})
 	}
+	if hint != nil {
+		opts = append(opts, *hint)
+	}
 	s.shadowTr = shadowTr
 	s.shadowSpan = shadowTr.StartSpan(s.operation, opts...)
 }
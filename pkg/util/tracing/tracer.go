@@ -125,6 +125,8 @@ func (t *Tracer) Configure(sv *settings.Values) {
 			t.setShadowTracer(createLightStepTracer(lsToken))
 		} else if zipkinAddr := zipkinCollector.Get(sv); zipkinAddr != "" {
 			t.setShadowTracer(createZipkinTracer(zipkinAddr))
+		} else if otlpAddr := otlpCollector.Get(sv); otlpAddr != "" {
+			t.setShadowTracer(createOTLPTracer(otlpAddr, sv))
 		} else {
 			t.setShadowTracer(nil, nil)
 		}
@@ -140,6 +142,7 @@ func (t *Tracer) Configure(sv *settings.Values) {
 	enableNetTrace.SetOnChange(sv, reconfigure)
 	lightstepToken.SetOnChange(sv, reconfigure)
 	zipkinCollector.SetOnChange(sv, reconfigure)
+	otlpCollector.SetOnChange(sv, reconfigure)
 }
 
 func (t *Tracer) useNetTrace() bool {
@@ -219,10 +222,14 @@ func (t *Tracer) StartSpan(
 
 	var sso opentracing.StartSpanOptions
 	var recordable bool
+	var hint *SamplingHint
 	for _, o := range opts {
 		o.Apply(&sso)
-		if _, ok := o.(recordableOption); ok {
+		switch o := o.(type) {
+		case recordableOption:
 			recordable = true
+		case SamplingHint:
+			hint = &o
 		}
 	}
 
@@ -292,7 +299,7 @@ func (t *Tracer) StartSpan(
 		if hasParent {
 			parentShadowCtx = parentCtx.shadowCtx
 		}
-		linkShadowSpan(s, shadowTr, parentShadowCtx, parentType)
+		linkShadowSpan(s, shadowTr, parentShadowCtx, parentType, hint)
 	}
 
 	// Start recording if necessary.
@@ -356,6 +363,15 @@ func (t *Tracer) AlwaysTrace() bool {
 // logTags can be nil.
 func (t *Tracer) StartRootSpan(
 	opName string, logTags *logtags.Buffer, recordable RecordableOpt,
+) opentracing.Span {
+	return t.StartRootSpanWithHint(opName, logTags, recordable, nil /* hint */)
+}
+
+// StartRootSpanWithHint is like StartRootSpan, except that the given sampling
+// hint, if not nil, informs the sampling decision of the shadow tracer (see
+// trace.opentelemetry.sample_rate).
+func (t *Tracer) StartRootSpanWithHint(
+	opName string, logTags *logtags.Buffer, recordable RecordableOpt, hint *SamplingHint,
 ) opentracing.Span {
 	// In the usual case, we return noopSpan.
 	if !t.AlwaysTrace() && recordable == NonRecordableSpan {
@@ -378,7 +394,7 @@ func (t *Tracer) StartRootSpan(
 	if shadowTracer != nil {
 		linkShadowSpan(
 			s, shadowTracer, nil, /* parentShadowCtx */
-			opentracing.SpanReferenceType(0) /* parentType - ignored*/)
+			opentracing.SpanReferenceType(0) /* parentType - ignored*/, hint)
 	}
 
 	if t.useNetTrace() {
@@ -442,7 +458,9 @@ func StartChildSpan(
 	s.SpanID = uint64(rand.Int63())
 
 	if pSpan.shadowTr != nil {
-		linkShadowSpan(s, pSpan.shadowTr, pSpan.shadowSpan.Context(), opentracing.ChildOfRef)
+		linkShadowSpan(
+			s, pSpan.shadowTr, pSpan.shadowSpan.Context(), opentracing.ChildOfRef, nil, /* hint */
+		)
 	}
 
 	// Start recording if necessary.